@@ -57,6 +57,11 @@ func main() {
 	cmd.RegisterDatastoreRootFlags(datastoreCmd)
 	rootCmd.AddCommand(datastoreCmd)
 
+	// Add schema commands
+	schemaCmd := cmd.NewSchemaCommand(rootCmd.Use)
+	cmd.RegisterSchemaRootFlags(schemaCmd)
+	rootCmd.AddCommand(schemaCmd)
+
 	// Add head command.
 	headCmd := cmd.NewHeadCommand(rootCmd.Use)
 	cmd.RegisterHeadFlags(headCmd)
@@ -0,0 +1,342 @@
+// Package audit implements an audit log recording every write to the relationships and schema
+// of a SpiceDB instance: who made it, as part of which request, at which revision and with which
+// exact mutations.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	log "github.com/authzed/spicedb/internal/logging"
+)
+
+const (
+	// SinkFile writes audit events as JSON lines to a file, which is rotated once it reaches
+	// its maximum size.
+	SinkFile = "file"
+
+	// SinkStdout writes audit events as JSON lines to the standard output.
+	SinkStdout = "stdout"
+
+	// SinkGRPC streams audit events to a collector over gRPC.
+	SinkGRPC = "grpc"
+)
+
+const (
+	// OverflowBlock makes writes wait for room in the buffer of audit events, so that a slow
+	// sink slows down writes.
+	OverflowBlock = "block"
+
+	// OverflowReject rejects writes while the buffer of audit events is full, with an
+	// `Unavailable` error which can be retried.
+	OverflowReject = "reject"
+
+	// OverflowDrop performs writes without auditing them while the buffer of audit events is
+	// full.
+	OverflowDrop = "drop"
+)
+
+const (
+	// maxRetryInterval is the maximum interval between attempts to write an event to a sink.
+	maxRetryInterval = 5 * time.Second
+
+	// maxShutdownAttempts is the number of attempts made to write each remaining event to a
+	// failing sink once the auditor is closed, after which the event is lost.
+	maxShutdownAttempts = 3
+)
+
+var (
+	writtenCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "spicedb",
+		Subsystem: "audit",
+		Name:      "written_events_total",
+		Help:      "total number of audit events written to the sink",
+	})
+
+	failedWritesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "spicedb",
+		Subsystem: "audit",
+		Name:      "failed_writes_total",
+		Help:      "total number of failed attempts to write an audit event to the sink",
+	})
+
+	overflowCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "spicedb",
+		Subsystem: "audit",
+		Name:      "buffer_overflows_total",
+		Help:      "total number of writes dropped from or rejected by the audit log due to its full buffer",
+	}, []string{"method", "policy"})
+)
+
+// Config configures the audit log.
+type Config struct {
+	// Sink is the sink to which audit events are written: `file`, `stdout` or `grpc`. If empty,
+	// writes are not audited.
+	Sink string `debugmap:"visible"`
+
+	// FilePath is the path of the file to which the `file` sink writes.
+	FilePath string `debugmap:"visible"`
+
+	// FileMaxSizeMB is the size, in megabytes, at which the file of the `file` sink is rotated.
+	FileMaxSizeMB int `debugmap:"visible"`
+
+	// FileMaxBackups is the number of rotated files kept by the `file` sink, of which the oldest
+	// are deleted. If zero, all rotated files are kept.
+	FileMaxBackups int `debugmap:"visible"`
+
+	// GRPCEndpoint is the address of the collector to which the `grpc` sink streams.
+	GRPCEndpoint string `debugmap:"visible"`
+
+	// GRPCCACertPath is the path of the certificate authority used to verify the collector of
+	// the `grpc` sink. If empty, the connection to the collector is not secured.
+	GRPCCACertPath string `debugmap:"visible"`
+
+	// BufferSize is the number of audit events buffered while being written to the sink.
+	BufferSize int `debugmap:"visible"`
+
+	// OverflowPolicy is the policy applied to writes while the buffer is full: `block`,
+	// `reject` or `drop`.
+	OverflowPolicy string `debugmap:"visible"`
+}
+
+// Enabled returns whether writes are audited.
+func (c Config) Enabled() bool {
+	return c.Sink != ""
+}
+
+func (c Config) MarshalZerologObject(e *zerolog.Event) {
+	e.Str("sink", c.Sink).
+		Str("filePath", c.FilePath).
+		Int("fileMaxSizeMB", c.FileMaxSizeMB).
+		Int("fileMaxBackups", c.FileMaxBackups).
+		Str("grpcEndpoint", c.GRPCEndpoint).
+		Int("bufferSize", c.BufferSize).
+		Str("overflowPolicy", c.OverflowPolicy)
+}
+
+// Event is the record of a write or, in the decision log, of the decisions of a permission
+// check or lookup.
+type Event struct {
+	// Time is the time at which the call completed.
+	Time time.Time `json:"time"`
+
+	// Method is the full name of the gRPC method called.
+	Method string `json:"method"`
+
+	// Principal identifies the authenticated caller.
+	Principal string `json:"principal"`
+
+	// RequestID is the ID of the request, if any.
+	RequestID string `json:"request_id,omitempty"`
+
+	// Revision is the ZedToken of the revision at which the write was applied or the decisions
+	// were made, if known.
+	Revision string `json:"revision,omitempty"`
+
+	// Mutations are the exact mutations of a write, as the JSON encoding of the request or, for
+	// streamed requests, of a request combining all messages, up to a maximum number of
+	// relationships.
+	Mutations json.RawMessage `json:"mutations,omitempty"`
+
+	// Details are the details of a write found in neither its mutations nor its response, such
+	// as the version of the schema to which a write of the schema rolled back, the number of
+	// relationships deleted, or the number of relationships imported when the mutations of a
+	// large bulk import are truncated.
+	Details map[string]string `json:"details,omitempty"`
+
+	// Decisions are the decisions of a permission check or lookup.
+	Decisions []*Decision `json:"decisions,omitempty"`
+}
+
+// Auditor records audit events, which are buffered while being written to its sink.
+//
+// Room in the buffer is reserved before a write is performed, so that every performed write can
+// be recorded, unless the overflow policy drops it. Events which fail to be written are retried
+// until written, so that a failing sink fills the buffer and applies the overflow policy.
+type Auditor struct {
+	sink          Sink
+	policy        string
+	retryInterval time.Duration
+
+	// slots holds a token for each reserved room in the buffer, which is released once the
+	// event is written or the write is not performed.
+	slots  chan struct{}
+	events chan *Event
+	done   chan struct{}
+
+	lock   sync.RWMutex
+	closed bool
+}
+
+// NewAuditor returns an auditor writing to the configured sink.
+func NewAuditor(ctx context.Context, config Config) (*Auditor, error) {
+	if config.BufferSize <= 0 {
+		return nil, fmt.Errorf("audit log buffer size must be positive, got %d", config.BufferSize)
+	}
+
+	switch config.OverflowPolicy {
+	case OverflowBlock, OverflowReject, OverflowDrop:
+	default:
+		return nil, fmt.Errorf("unknown audit log overflow policy `%s`", config.OverflowPolicy)
+	}
+
+	var sink Sink
+	var err error
+	switch config.Sink {
+	case SinkFile:
+		if config.FilePath == "" {
+			return nil, errors.New("a file path is required for the audit log file sink")
+		}
+		if config.FileMaxSizeMB <= 0 {
+			return nil, fmt.Errorf("audit log file max size must be positive, got %d", config.FileMaxSizeMB)
+		}
+		sink, err = NewRotatingFileSink(config.FilePath, int64(config.FileMaxSizeMB)*1024*1024, config.FileMaxBackups)
+
+	case SinkStdout:
+		sink = NewStdoutSink()
+
+	case SinkGRPC:
+		if config.GRPCEndpoint == "" {
+			return nil, errors.New("an endpoint is required for the audit log gRPC sink")
+		}
+		sink, err = NewGRPCSink(ctx, config.GRPCEndpoint, config.GRPCCACertPath)
+
+	default:
+		return nil, fmt.Errorf("unknown audit log sink `%s`", config.Sink)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audit log sink: %w", err)
+	}
+
+	return newAuditor(sink, config.BufferSize, config.OverflowPolicy), nil
+}
+
+func newAuditor(sink Sink, bufferSize int, policy string) *Auditor {
+	a := &Auditor{
+		sink:          sink,
+		policy:        policy,
+		retryInterval: 100 * time.Millisecond,
+		slots:         make(chan struct{}, bufferSize),
+		events:        make(chan *Event, bufferSize),
+		done:          make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+// reserve reserves room in the buffer for the event of a write, applying the overflow policy if
+// the buffer is full. It returns whether room was reserved, in which case either publish or
+// release must be called.
+func (a *Auditor) reserve(ctx context.Context, fullMethod string) (bool, error) {
+	select {
+	case a.slots <- struct{}{}:
+		return true, nil
+	default:
+	}
+
+	switch a.policy {
+	case OverflowDrop:
+		overflowCounter.WithLabelValues(fullMethod, a.policy).Inc()
+		log.Ctx(ctx).Warn().Str("method", fullMethod).Msg("audit log buffer is full; write will not be audited")
+		return false, nil
+
+	case OverflowReject:
+		overflowCounter.WithLabelValues(fullMethod, a.policy).Inc()
+		return false, status.Errorf(codes.Unavailable, "audit log buffer is full; retry later")
+
+	default:
+		select {
+		case a.slots <- struct{}{}:
+			return true, nil
+		case <-ctx.Done():
+			return false, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+// release releases reserved room in the buffer, for a write which was not performed.
+func (a *Auditor) release() {
+	<-a.slots
+}
+
+// publish buffers the event of a write, for which room was reserved.
+func (a *Auditor) publish(event *Event) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	if a.closed {
+		log.Error().Str("method", event.Method).Str("request_id", event.RequestID).Msg("audit log is closed; audit event is lost")
+		return
+	}
+
+	// Room was reserved, so this never blocks.
+	a.events <- event
+}
+
+func (a *Auditor) run() {
+	defer close(a.done)
+	for event := range a.events {
+		a.write(event)
+		a.release()
+	}
+}
+
+// write writes an event to the sink, retrying until written or, once the auditor is closed,
+// until the attempts are exhausted.
+func (a *Auditor) write(event *Event) {
+	backoffInterval := backoff.NewExponentialBackOff()
+	backoffInterval.InitialInterval = a.retryInterval
+	backoffInterval.MaxInterval = maxRetryInterval
+	backoffInterval.MaxElapsedTime = 0
+	backoffInterval.Reset()
+
+	for attempt := 1; ; attempt++ {
+		err := a.sink.Write(event)
+		if err == nil {
+			writtenCounter.Inc()
+			return
+		}
+		failedWritesCounter.Inc()
+
+		if a.isClosed() && attempt >= maxShutdownAttempts {
+			log.Error().Err(err).Str("method", event.Method).Str("request_id", event.RequestID).Msg("failed to write audit event while closing the audit log; audit event is lost")
+			return
+		}
+
+		nextInterval := backoffInterval.NextBackOff()
+		log.Warn().Err(err).Dur("next-attempt-in", nextInterval).Msg("failed to write audit event")
+		time.Sleep(nextInterval)
+	}
+}
+
+func (a *Auditor) isClosed() bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.closed
+}
+
+// Close writes the buffered events and closes the sink. Writes must no longer be performed.
+func (a *Auditor) Close() error {
+	a.lock.Lock()
+	if a.closed {
+		a.lock.Unlock()
+		return nil
+	}
+	a.closed = true
+	close(a.events)
+	a.lock.Unlock()
+
+	<-a.done
+	return a.sink.Close()
+}
@@ -0,0 +1,335 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/grpcutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/authzed/spicedb/internal/auth"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/pkg/middleware/requestid"
+	"github.com/authzed/spicedb/pkg/zedtoken"
+)
+
+type fakeSink struct {
+	sync.Mutex
+	events   []*Event
+	failures int
+	unblock  chan struct{}
+	closed   bool
+}
+
+func (fs *fakeSink) Write(event *Event) error {
+	if fs.unblock != nil {
+		<-fs.unblock
+	}
+
+	fs.Lock()
+	defer fs.Unlock()
+	if fs.failures > 0 {
+		fs.failures--
+		return errors.New("sink failure")
+	}
+	fs.events = append(fs.events, event)
+	return nil
+}
+
+func (fs *fakeSink) Close() error {
+	fs.Lock()
+	defer fs.Unlock()
+	fs.closed = true
+	return nil
+}
+
+var writeRelationships = &grpc.UnaryServerInfo{FullMethod: v1.PermissionsService_WriteRelationships_FullMethodName}
+
+func writeHandler(token string) grpc.UnaryHandler {
+	return func(context.Context, any) (any, error) {
+		return &v1.WriteRelationshipsResponse{WrittenAt: &v1.ZedToken{Token: token}}, nil
+	}
+}
+
+func TestUnaryAudit(t *testing.T) {
+	sink := &fakeSink{}
+	auditor := newAuditor(sink, 10, OverflowBlock)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestid.RequestIDMetadataKey, "somerequestid"))
+	ctx = auth.ContextWithClaims(ctx, &auth.Claims{Subject: "somecaller"})
+	req := &v1.WriteRelationshipsRequest{Updates: []*v1.RelationshipUpdate{{
+		Operation: v1.RelationshipUpdate_OPERATION_TOUCH,
+		Relationship: &v1.Relationship{
+			Resource: &v1.ObjectReference{ObjectType: "document", ObjectId: "doc"},
+			Relation: "viewer",
+			Subject:  &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "user", ObjectId: "tom"}},
+		},
+	}}}
+
+	_, err := auditor.UnaryServerInterceptor()(ctx, req, writeRelationships, writeHandler("sometoken"))
+	require.NoError(t, err)
+
+	// Failed writes, plans and reads are not audited.
+	_, err = auditor.UnaryServerInterceptor()(ctx, req, writeRelationships, func(context.Context, any) (any, error) {
+		return nil, errors.New("write failed")
+	})
+	require.Error(t, err)
+
+	_, err = auditor.UnaryServerInterceptor()(ctx, &v1.WriteSchemaRequest{}, &grpc.UnaryServerInfo{FullMethod: v1.SchemaService_WriteSchema_FullMethodName}, func(ctx context.Context, _ any) (any, error) {
+		SkipInContext(ctx)
+		return &v1.WriteSchemaResponse{}, nil
+	})
+	require.NoError(t, err)
+
+	_, err = auditor.UnaryServerInterceptor()(ctx, &v1.CheckPermissionRequest{}, &grpc.UnaryServerInfo{FullMethod: v1.PermissionsService_CheckPermission_FullMethodName}, func(context.Context, any) (any, error) {
+		return &v1.CheckPermissionResponse{}, nil
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, auditor.Close())
+	require.True(t, sink.closed)
+	require.Len(t, sink.events, 1)
+
+	event := sink.events[0]
+	require.Equal(t, v1.PermissionsService_WriteRelationships_FullMethodName, event.Method)
+	require.Equal(t, "subject:somecaller", event.Principal)
+	require.Equal(t, "somerequestid", event.RequestID)
+	require.Equal(t, "sometoken", event.Revision)
+
+	var mutations map[string]any
+	require.NoError(t, json.Unmarshal(event.Mutations, &mutations))
+	require.Len(t, mutations["updates"], 1)
+	require.Equal(t, "OPERATION_TOUCH", mutations["updates"].([]any)[0].(map[string]any)["operation"])
+}
+
+func TestUnaryAuditReportedMutations(t *testing.T) {
+	sink := &fakeSink{}
+	auditor := newAuditor(sink, 10, OverflowBlock)
+
+	// A rollback of the schema holds no schema in its request, so it reports the schema applied.
+	_, err := auditor.UnaryServerInterceptor()(context.Background(), &v1.WriteSchemaRequest{}, &grpc.UnaryServerInfo{FullMethod: v1.SchemaService_WriteSchema_FullMethodName}, func(ctx context.Context, _ any) (any, error) {
+		SetMutationsInContext(ctx, &v1.WriteSchemaRequest{Schema: "definition user {}"})
+		SetDetailInContext(ctx, "rolled_back_to_version", "1")
+		return &v1.WriteSchemaResponse{WrittenAt: &v1.ZedToken{Token: "sometoken"}}, nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, auditor.Close())
+
+	require.Len(t, sink.events, 1)
+	require.Equal(t, map[string]string{"rolled_back_to_version": "1"}, sink.events[0].Details)
+
+	mutations := &v1.WriteSchemaRequest{}
+	require.NoError(t, protojson.Unmarshal(sink.events[0].Mutations, mutations))
+	require.Equal(t, "definition user {}", mutations.Schema)
+}
+
+func TestStreamAudit(t *testing.T) {
+	sink := &fakeSink{}
+	auditor := newAuditor(sink, 10, OverflowBlock)
+	revision := revisions.NewForTransactionID(42)
+
+	relationship := func(id string) *v1.Relationship {
+		return &v1.Relationship{
+			Resource: &v1.ObjectReference{ObjectType: "document", ObjectId: id},
+			Relation: "viewer",
+			Subject:  &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "user", ObjectId: "tom"}},
+		}
+	}
+	stream := &fakeServerStream{ctx: context.Background(), messages: [][]*v1.Relationship{
+		{relationship("first"), relationship("second")},
+		{relationship("third")},
+	}}
+
+	err := auditor.StreamServerInterceptor()(nil, stream, &grpc.StreamServerInfo{FullMethod: v1.ExperimentalService_BulkImportRelationships_FullMethodName}, func(_ any, stream grpc.ServerStream) error {
+		// The message is reused across receives, as done by the service.
+		req := &v1.BulkImportRelationshipsRequest{}
+		for stream.RecvMsg(req) == nil {
+			for _, relationship := range req.Relationships {
+				relationship.Resource.ObjectId = "overwritten"
+			}
+		}
+
+		SetRevisionInContext(stream.Context(), revision)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, auditor.Close())
+
+	require.Len(t, sink.events, 1)
+	require.Equal(t, zedtoken.MustNewFromRevision(revision).Token, sink.events[0].Revision)
+	require.Regexp(t, `^token:[0-9a-f]{64}$`, sink.events[0].Principal)
+	require.Equal(t, map[string]string{"imported_relationships": "3"}, sink.events[0].Details)
+
+	mutations := &v1.BulkImportRelationshipsRequest{}
+	require.NoError(t, protojson.Unmarshal(sink.events[0].Mutations, mutations))
+	require.Len(t, mutations.Relationships, 3)
+	require.Equal(t, "first", mutations.Relationships[0].Resource.ObjectId)
+	require.Equal(t, "third", mutations.Relationships[2].Resource.ObjectId)
+}
+
+func TestStreamAuditTruncatesLargeImports(t *testing.T) {
+	sink := &fakeSink{}
+	auditor := newAuditor(sink, 10, OverflowBlock)
+
+	batch := make([]*v1.Relationship, 0, 600)
+	for i := 0; i < 600; i++ {
+		batch = append(batch, &v1.Relationship{
+			Resource: &v1.ObjectReference{ObjectType: "document", ObjectId: strconv.Itoa(i)},
+			Relation: "viewer",
+			Subject:  &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "user", ObjectId: "tom"}},
+		})
+	}
+	stream := &fakeServerStream{ctx: context.Background(), messages: [][]*v1.Relationship{batch, batch}}
+
+	err := auditor.StreamServerInterceptor()(nil, stream, &grpc.StreamServerInfo{FullMethod: v1.ExperimentalService_BulkImportRelationships_FullMethodName}, func(_ any, stream grpc.ServerStream) error {
+		req := &v1.BulkImportRelationshipsRequest{}
+		for {
+			if err := stream.RecvMsg(req); err != nil {
+				return nil
+			}
+		}
+	})
+	require.NoError(t, err)
+	require.NoError(t, auditor.Close())
+
+	require.Len(t, sink.events, 1)
+	require.Equal(t, map[string]string{"imported_relationships": "1200", "relationships_truncated": "true"}, sink.events[0].Details)
+
+	mutations := &v1.BulkImportRelationshipsRequest{}
+	require.NoError(t, protojson.Unmarshal(sink.events[0].Mutations, mutations))
+	require.Len(t, mutations.Relationships, maxRecordedImportRelationships)
+}
+
+func TestUnaryAuditDeletedRelationships(t *testing.T) {
+	sink := &fakeSink{}
+	auditor := newAuditor(sink, 10, OverflowBlock)
+
+	_, err := auditor.UnaryServerInterceptor()(context.Background(), &v1.DeleteRelationshipsRequest{}, &grpc.UnaryServerInfo{FullMethod: v1.PermissionsService_DeleteRelationships_FullMethodName}, func(ctx context.Context, _ any) (any, error) {
+		require.True(t, IsAuditedInContext(ctx))
+		SetDetailInContext(ctx, "deleted_relationships", "3")
+		return &v1.DeleteRelationshipsResponse{DeletedAt: &v1.ZedToken{Token: "sometoken"}}, nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, auditor.Close())
+
+	require.Len(t, sink.events, 1)
+	require.Equal(t, "sometoken", sink.events[0].Revision)
+	require.Equal(t, map[string]string{"deleted_relationships": "3"}, sink.events[0].Details)
+	require.False(t, IsAuditedInContext(context.Background()))
+}
+
+func TestOverflowPolicies(t *testing.T) {
+	tcs := []struct {
+		policy          string
+		expectedCode    codes.Code
+		expectedHandled bool
+	}{
+		{OverflowBlock, codes.DeadlineExceeded, false},
+		{OverflowReject, codes.Unavailable, false},
+		{OverflowDrop, codes.OK, true},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.policy, func(t *testing.T) {
+			sink := &fakeSink{unblock: make(chan struct{})}
+			auditor := newAuditor(sink, 1, tc.policy)
+
+			// The sink is stuck writing the first event, which fills the buffer.
+			_, err := auditor.UnaryServerInterceptor()(context.Background(), &v1.WriteRelationshipsRequest{}, writeRelationships, writeHandler("first"))
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			handled := false
+			_, err = auditor.UnaryServerInterceptor()(ctx, &v1.WriteRelationshipsRequest{}, writeRelationships, func(ctx context.Context, req any) (any, error) {
+				handled = true
+				return writeHandler("second")(ctx, req)
+			})
+			if tc.expectedCode == codes.OK {
+				require.NoError(t, err)
+			} else {
+				grpcutil.RequireStatus(t, tc.expectedCode, err)
+			}
+			require.Equal(t, tc.expectedHandled, handled)
+
+			close(sink.unblock)
+			require.NoError(t, auditor.Close())
+			require.Len(t, sink.events, 1)
+			require.Equal(t, "first", sink.events[0].Revision)
+		})
+	}
+}
+
+func TestSinkFailuresAreRetried(t *testing.T) {
+	sink := &fakeSink{failures: 2}
+	auditor := newAuditor(sink, 10, OverflowBlock)
+	auditor.retryInterval = time.Millisecond
+
+	_, err := auditor.UnaryServerInterceptor()(context.Background(), &v1.WriteRelationshipsRequest{}, writeRelationships, writeHandler("sometoken"))
+	require.NoError(t, err)
+
+	require.NoError(t, auditor.Close())
+	require.Len(t, sink.events, 1)
+	require.Zero(t, sink.failures)
+}
+
+func TestNewAuditorValidation(t *testing.T) {
+	valid := Config{Sink: SinkStdout, BufferSize: 10, OverflowPolicy: OverflowBlock}
+
+	tcs := []struct {
+		name        string
+		modify      func(config *Config)
+		expectedErr string
+	}{
+		{"unknown sink", func(config *Config) { config.Sink = "somewhere" }, "unknown audit log sink `somewhere`"},
+		{"unknown policy", func(config *Config) { config.OverflowPolicy = "ignore" }, "unknown audit log overflow policy `ignore`"},
+		{"no buffer", func(config *Config) { config.BufferSize = 0 }, "audit log buffer size must be positive"},
+		{"file without path", func(config *Config) { config.Sink = SinkFile }, "a file path is required"},
+		{"grpc without endpoint", func(config *Config) { config.Sink = SinkGRPC }, "an endpoint is required"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			config := valid
+			tc.modify(&config)
+			_, err := NewAuditor(context.Background(), config)
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+
+	auditor, err := NewAuditor(context.Background(), valid)
+	require.NoError(t, err)
+	require.NoError(t, auditor.Close())
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	messages [][]*v1.Relationship
+}
+
+func (fss *fakeServerStream) Context() context.Context {
+	return fss.ctx
+}
+
+func (fss *fakeServerStream) RecvMsg(m any) error {
+	if len(fss.messages) == 0 {
+		return context.Canceled
+	}
+	m.(*v1.BulkImportRelationshipsRequest).Relationships = fss.messages[0]
+	fss.messages = fss.messages[1:]
+	return nil
+}
@@ -0,0 +1,410 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	log "github.com/authzed/spicedb/internal/logging"
+)
+
+const (
+	// RedactResourceID redacts the IDs of the resources of decisions.
+	RedactResourceID = "resource_id"
+
+	// RedactSubjectID redacts the IDs of the subjects of decisions.
+	RedactSubjectID = "subject_id"
+
+	// RedactCaveatContext redacts all values of the caveat contexts of decisions. A single value
+	// is redacted with `caveat_context.<name>`.
+	RedactCaveatContext = "caveat_context"
+)
+
+// redactedValue replaces the values of redacted fields.
+const redactedValue = "[REDACTED]"
+
+// maxDecisionsPerEvent is the maximum number of decisions of a lookup logged in a single event,
+// beyond which they are logged in further events.
+const maxDecisionsPerEvent = 1000
+
+// DecisionConfig configures the decision log.
+type DecisionConfig struct {
+	// Log configures the sink and buffer of the decision log. If it has no sink, decisions are
+	// not logged.
+	Log Config `debugmap:"visible"`
+
+	// CheckSampleRate is the fraction of CheckPermission calls which are logged.
+	CheckSampleRate float64 `debugmap:"visible"`
+
+	// BulkCheckSampleRate is the fraction of BulkCheckPermission calls which are logged.
+	BulkCheckSampleRate float64 `debugmap:"visible"`
+
+	// LookupResourcesSampleRate is the fraction of LookupResources calls which are logged.
+	LookupResourcesSampleRate float64 `debugmap:"visible"`
+
+	// RedactedFields are the fields of decisions whose values are redacted: `resource_id`,
+	// `subject_id`, `caveat_context` or `caveat_context.<name>`.
+	RedactedFields []string `debugmap:"visible"`
+}
+
+// Enabled returns whether decisions are logged.
+func (c DecisionConfig) Enabled() bool {
+	return c.Log.Enabled()
+}
+
+func (c DecisionConfig) MarshalZerologObject(e *zerolog.Event) {
+	e.EmbedObject(c.Log).
+		Float64("checkSampleRate", c.CheckSampleRate).
+		Float64("bulkCheckSampleRate", c.BulkCheckSampleRate).
+		Float64("lookupResourcesSampleRate", c.LookupResourcesSampleRate).
+		Strs("redactedFields", c.RedactedFields)
+}
+
+// Decision is the decision of whether a subject has a permission on a resource. The decision
+// recording the error of a failed lookup has no resource ID.
+type Decision struct {
+	ResourceType    string `json:"resource_type"`
+	ResourceID      string `json:"resource_id"`
+	Permission      string `json:"permission"`
+	SubjectType     string `json:"subject_type"`
+	SubjectID       string `json:"subject_id"`
+	SubjectRelation string `json:"subject_relation,omitempty"`
+
+	// Result is the permissionship of the subject, if decided.
+	Result string `json:"result,omitempty"`
+
+	// MissingContext are the names of the caveat context values which were missing for the
+	// decision to be unconditional.
+	MissingContext []string `json:"missing_context,omitempty"`
+
+	// CaveatContext is the caveat context given with the request.
+	CaveatContext map[string]any `json:"caveat_context,omitempty"`
+
+	// Error is the message of the error which prevented a decision, in which case it has no
+	// result.
+	Error string `json:"error,omitempty"`
+}
+
+// redaction is the set of fields redacted from decisions.
+type redaction struct {
+	resourceID    bool
+	subjectID     bool
+	caveatContext bool
+	caveatValues  map[string]struct{}
+}
+
+func newRedaction(fields []string) (redaction, error) {
+	r := redaction{caveatValues: make(map[string]struct{})}
+	for _, field := range fields {
+		switch field {
+		case RedactResourceID:
+			r.resourceID = true
+		case RedactSubjectID:
+			r.subjectID = true
+		case RedactCaveatContext:
+			r.caveatContext = true
+		default:
+			name, ok := strings.CutPrefix(field, RedactCaveatContext+".")
+			if !ok || name == "" {
+				return redaction{}, fmt.Errorf("unknown decision log redacted field `%s`", field)
+			}
+			r.caveatValues[name] = struct{}{}
+		}
+	}
+	return r, nil
+}
+
+// newDecision returns the decision for the given request, with fields redacted.
+func (r redaction) newDecision(resource *v1.ObjectReference, permission string, subject *v1.SubjectReference, caveatContext *structpb.Struct) *Decision {
+	decision := &Decision{
+		ResourceType:    resource.GetObjectType(),
+		ResourceID:      resource.GetObjectId(),
+		Permission:      permission,
+		SubjectType:     subject.GetObject().GetObjectType(),
+		SubjectID:       subject.GetObject().GetObjectId(),
+		SubjectRelation: subject.GetOptionalRelation(),
+	}
+
+	if r.resourceID && decision.ResourceID != "" {
+		decision.ResourceID = redactedValue
+	}
+	if r.subjectID {
+		decision.SubjectID = redactedValue
+	}
+
+	if caveatContext != nil && len(caveatContext.Fields) > 0 {
+		decision.CaveatContext = caveatContext.AsMap()
+		for name := range decision.CaveatContext {
+			if _, ok := r.caveatValues[name]; ok || r.caveatContext {
+				decision.CaveatContext[name] = redactedValue
+			}
+		}
+	}
+	return decision
+}
+
+func (d *Decision) setResult(permissionship fmt.Stringer, partialCaveatInfo *v1.PartialCaveatInfo) {
+	d.Result = permissionship.String()
+	d.MissingContext = partialCaveatInfo.GetMissingRequiredContext()
+}
+
+// DecisionLogger logs a sample of the decisions of permission checks and lookups, which are
+// buffered while being written to its sink like audit events.
+type DecisionLogger struct {
+	auditor     *Auditor
+	sampleRates map[string]float64
+	redaction   redaction
+}
+
+// NewDecisionLogger returns a decision logger writing to the configured sink.
+func NewDecisionLogger(ctx context.Context, config DecisionConfig) (*DecisionLogger, error) {
+	sampleRates := map[string]float64{
+		v1.PermissionsService_CheckPermission_FullMethodName:      config.CheckSampleRate,
+		v1.ExperimentalService_BulkCheckPermission_FullMethodName: config.BulkCheckSampleRate,
+		v1.PermissionsService_LookupResources_FullMethodName:      config.LookupResourcesSampleRate,
+	}
+	for method, rate := range sampleRates {
+		if rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("decision log sample rate of %s must be between 0 and 1, got %v", method, rate)
+		}
+	}
+
+	redaction, err := newRedaction(config.RedactedFields)
+	if err != nil {
+		return nil, err
+	}
+
+	auditor, err := NewAuditor(ctx, config.Log)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DecisionLogger{
+		auditor:     auditor,
+		sampleRates: sampleRates,
+		redaction:   redaction,
+	}, nil
+}
+
+// Close writes the buffered decisions and closes the sink.
+func (l *DecisionLogger) Close() error {
+	return l.auditor.Close()
+}
+
+// sampled returns whether the call of the given method is logged.
+func (l *DecisionLogger) sampled(fullMethod string) bool {
+	rate, ok := l.sampleRates[fullMethod]
+	return ok && rand.Float64() < rate //nolint:gosec // sampling does not require a secure source
+}
+
+// UnaryServerInterceptor returns a new unary server interceptor that logs the decisions of
+// permission checks, and the errors of those which failed.
+func (l *DecisionLogger) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !l.sampled(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		reserved, err := l.auditor.reserve(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		if !reserved {
+			return handler(ctx, req)
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			decisions := l.failedDecisions(req, err)
+			if len(decisions) == 0 {
+				l.auditor.release()
+				return resp, err
+			}
+
+			event := newEvent(ctx, info.FullMethod, "")
+			event.Decisions = decisions
+			l.auditor.publish(event)
+			return resp, err
+		}
+
+		var event *Event
+		switch resp := resp.(type) {
+		case *v1.CheckPermissionResponse:
+			req := req.(*v1.CheckPermissionRequest)
+			decision := l.redaction.newDecision(req.Resource, req.Permission, req.Subject, req.Context)
+			decision.setResult(resp.Permissionship, resp.PartialCaveatInfo)
+
+			event = newEvent(ctx, info.FullMethod, resp.GetCheckedAt().GetToken())
+			event.Decisions = []*Decision{decision}
+
+		case *v1.BulkCheckPermissionResponse:
+			event = newEvent(ctx, info.FullMethod, resp.GetCheckedAt().GetToken())
+			for _, pair := range resp.Pairs {
+				item := pair.Request
+				decision := l.redaction.newDecision(item.GetResource(), item.GetPermission(), item.GetSubject(), item.GetContext())
+				switch response := pair.Response.(type) {
+				case *v1.BulkCheckPermissionPair_Item:
+					decision.setResult(response.Item.Permissionship, response.Item.PartialCaveatInfo)
+				case *v1.BulkCheckPermissionPair_Error:
+					decision.Error = response.Error.GetMessage()
+				}
+				event.Decisions = append(event.Decisions, decision)
+			}
+
+		default:
+			l.auditor.release()
+			return resp, nil
+		}
+
+		l.auditor.publish(event)
+		return resp, nil
+	}
+}
+
+// failedDecisions returns the decisions recording the error of a failed check.
+func (l *DecisionLogger) failedDecisions(req interface{}, err error) []*Decision {
+	message := status.Convert(err).Message()
+	switch req := req.(type) {
+	case *v1.CheckPermissionRequest:
+		decision := l.redaction.newDecision(req.Resource, req.Permission, req.Subject, req.Context)
+		decision.Error = message
+		return []*Decision{decision}
+
+	case *v1.BulkCheckPermissionRequest:
+		decisions := make([]*Decision, 0, len(req.Items))
+		for _, item := range req.Items {
+			decision := l.redaction.newDecision(item.GetResource(), item.GetPermission(), item.GetSubject(), item.GetContext())
+			decision.Error = message
+			decisions = append(decisions, decision)
+		}
+		return decisions
+
+	default:
+		return nil
+	}
+}
+
+// StreamServerInterceptor returns a new stream server interceptor that logs the decisions of
+// resource lookups, including those sent before a lookup failed along with its error. The
+// decisions of large lookups are logged in several events.
+func (l *DecisionLogger) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !l.sampled(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		reserved, err := l.auditor.reserve(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		if !reserved {
+			return handler(srv, stream)
+		}
+
+		logging := &decisionLoggingServerStream{
+			WrappedServerStream: middleware.WrapServerStream(stream),
+			auditor:             l.auditor,
+			fullMethod:          info.FullMethod,
+			redaction:           l.redaction,
+			reserved:            true,
+		}
+		err = handler(srv, logging)
+		if !logging.reserved {
+			return err
+		}
+
+		if err != nil && logging.req != nil {
+			resource := &v1.ObjectReference{ObjectType: logging.req.ResourceObjectType}
+			decision := l.redaction.newDecision(resource, logging.req.Permission, logging.req.Subject, logging.req.Context)
+			decision.Error = status.Convert(err).Message()
+			logging.decisions = append(logging.decisions, decision)
+		}
+
+		if len(logging.decisions) == 0 {
+			l.auditor.release()
+			return err
+		}
+
+		logging.publish()
+		return err
+	}
+}
+
+// decisionLoggingServerStream records the decisions of the resources sent by a lookup, which are
+// published whenever they reach the maximum number of decisions of an event.
+type decisionLoggingServerStream struct {
+	*middleware.WrappedServerStream
+	auditor    *Auditor
+	fullMethod string
+	redaction  redaction
+
+	req       *v1.LookupResourcesRequest
+	revision  string
+	decisions []*Decision
+
+	// reserved is whether room is reserved for the next event; if not, no further decisions are
+	// recorded.
+	reserved bool
+}
+
+// publish publishes the recorded decisions, for which room was reserved.
+func (s *decisionLoggingServerStream) publish() {
+	event := newEvent(s.Context(), s.fullMethod, s.revision)
+	event.Decisions = s.decisions
+	s.auditor.publish(event)
+
+	s.decisions = nil
+	s.reserved = false
+}
+
+func (s *decisionLoggingServerStream) RecvMsg(m interface{}) error {
+	if err := s.WrappedServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if req, ok := m.(*v1.LookupResourcesRequest); ok {
+		s.req = req
+	}
+	return nil
+}
+
+func (s *decisionLoggingServerStream) SendMsg(m interface{}) error {
+	if err := s.WrappedServerStream.SendMsg(m); err != nil {
+		return err
+	}
+
+	resp, ok := m.(*v1.LookupResourcesResponse)
+	if !ok || s.req == nil || !s.reserved {
+		return nil
+	}
+
+	if s.revision == "" {
+		s.revision = resp.GetLookedUpAt().GetToken()
+	}
+
+	resource := &v1.ObjectReference{ObjectType: s.req.ResourceObjectType, ObjectId: resp.ResourceObjectId}
+	decision := s.redaction.newDecision(resource, s.req.Permission, s.req.Subject, s.req.Context)
+	decision.setResult(resp.Permissionship, resp.PartialCaveatInfo)
+	s.decisions = append(s.decisions, decision)
+
+	if len(s.decisions) == maxDecisionsPerEvent {
+		s.publish()
+
+		// The lookup is not failed if no room is left for its further decisions, which are then
+		// not logged.
+		reserved, err := s.auditor.reserve(s.Context(), s.fullMethod)
+		if err != nil {
+			log.Ctx(s.Context()).Warn().Err(err).Str("method", s.fullMethod).Msg("further decisions of lookup will not be logged")
+		}
+		s.reserved = reserved
+	}
+	return nil
+}
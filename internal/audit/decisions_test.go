@@ -0,0 +1,317 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/stretchr/testify/require"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/authzed/spicedb/internal/auth"
+)
+
+func newTestDecisionLogger(t *testing.T, sink Sink, sampleRate float64, redactedFields ...string) *DecisionLogger {
+	redaction, err := newRedaction(redactedFields)
+	require.NoError(t, err)
+
+	return &DecisionLogger{
+		auditor: newAuditor(sink, 10, OverflowBlock),
+		sampleRates: map[string]float64{
+			v1.PermissionsService_CheckPermission_FullMethodName:      sampleRate,
+			v1.ExperimentalService_BulkCheckPermission_FullMethodName: sampleRate,
+			v1.PermissionsService_LookupResources_FullMethodName:      sampleRate,
+		},
+		redaction: redaction,
+	}
+}
+
+var (
+	doc = &v1.ObjectReference{ObjectType: "document", ObjectId: "doc"}
+	tom = &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "user", ObjectId: "tom"}}
+)
+
+func mustStruct(t *testing.T, values map[string]any) *structpb.Struct {
+	s, err := structpb.NewStruct(values)
+	require.NoError(t, err)
+	return s
+}
+
+func TestCheckDecisions(t *testing.T) {
+	tcs := []struct {
+		name             string
+		redactedFields   []string
+		expectedDecision *Decision
+	}{
+		{
+			"without redaction",
+			nil,
+			&Decision{
+				ResourceType:   "document",
+				ResourceID:     "doc",
+				Permission:     "view",
+				SubjectType:    "user",
+				SubjectID:      "tom",
+				Result:         "PERMISSIONSHIP_CONDITIONAL_PERMISSION",
+				MissingContext: []string{"day"},
+				CaveatContext:  map[string]any{"ip": "10.0.0.1", "region": "eu"},
+			},
+		},
+		{
+			"with redacted ids and caveat value",
+			[]string{RedactResourceID, RedactSubjectID, "caveat_context.ip"},
+			&Decision{
+				ResourceType:   "document",
+				ResourceID:     redactedValue,
+				Permission:     "view",
+				SubjectType:    "user",
+				SubjectID:      redactedValue,
+				Result:         "PERMISSIONSHIP_CONDITIONAL_PERMISSION",
+				MissingContext: []string{"day"},
+				CaveatContext:  map[string]any{"ip": redactedValue, "region": "eu"},
+			},
+		},
+		{
+			"with redacted caveat context",
+			[]string{RedactCaveatContext},
+			&Decision{
+				ResourceType:   "document",
+				ResourceID:     "doc",
+				Permission:     "view",
+				SubjectType:    "user",
+				SubjectID:      "tom",
+				Result:         "PERMISSIONSHIP_CONDITIONAL_PERMISSION",
+				MissingContext: []string{"day"},
+				CaveatContext:  map[string]any{"ip": redactedValue, "region": redactedValue},
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			sink := &fakeSink{}
+			logger := newTestDecisionLogger(t, sink, 1, tc.redactedFields...)
+
+			ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{Subject: "somecaller"})
+			req := &v1.CheckPermissionRequest{
+				Resource:   doc,
+				Permission: "view",
+				Subject:    tom,
+				Context:    mustStruct(t, map[string]any{"ip": "10.0.0.1", "region": "eu"}),
+			}
+			_, err := logger.UnaryServerInterceptor()(ctx, req, &grpc.UnaryServerInfo{FullMethod: v1.PermissionsService_CheckPermission_FullMethodName}, func(context.Context, any) (any, error) {
+				return &v1.CheckPermissionResponse{
+					CheckedAt:         &v1.ZedToken{Token: "sometoken"},
+					Permissionship:    v1.CheckPermissionResponse_PERMISSIONSHIP_CONDITIONAL_PERMISSION,
+					PartialCaveatInfo: &v1.PartialCaveatInfo{MissingRequiredContext: []string{"day"}},
+				}, nil
+			})
+			require.NoError(t, err)
+			require.NoError(t, logger.Close())
+
+			require.Len(t, sink.events, 1)
+			require.Equal(t, "subject:somecaller", sink.events[0].Principal)
+			require.Equal(t, "sometoken", sink.events[0].Revision)
+			require.Equal(t, []*Decision{tc.expectedDecision}, sink.events[0].Decisions)
+
+			// The request is not modified by redaction.
+			require.Equal(t, "doc", req.Resource.ObjectId)
+			require.Equal(t, "10.0.0.1", req.Context.AsMap()["ip"])
+		})
+	}
+}
+
+func TestBulkCheckDecisions(t *testing.T) {
+	sink := &fakeSink{}
+	logger := newTestDecisionLogger(t, sink, 1)
+
+	first := &v1.BulkCheckPermissionRequestItem{Resource: doc, Permission: "view", Subject: tom}
+	second := &v1.BulkCheckPermissionRequestItem{Resource: doc, Permission: "edit", Subject: tom}
+	req := &v1.BulkCheckPermissionRequest{Items: []*v1.BulkCheckPermissionRequestItem{first, second}}
+
+	_, err := logger.UnaryServerInterceptor()(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: v1.ExperimentalService_BulkCheckPermission_FullMethodName}, func(context.Context, any) (any, error) {
+		return &v1.BulkCheckPermissionResponse{
+			CheckedAt: &v1.ZedToken{Token: "sometoken"},
+			Pairs: []*v1.BulkCheckPermissionPair{
+				{Request: first, Response: &v1.BulkCheckPermissionPair_Item{Item: &v1.BulkCheckPermissionResponseItem{
+					Permissionship: v1.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION,
+				}}},
+				{Request: second, Response: &v1.BulkCheckPermissionPair_Error{Error: &rpcstatus.Status{Message: "unknown permission"}}},
+			},
+		}, nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, logger.Close())
+
+	require.Len(t, sink.events, 1)
+	require.Equal(t, "sometoken", sink.events[0].Revision)
+	require.Equal(t, []*Decision{
+		{ResourceType: "document", ResourceID: "doc", Permission: "view", SubjectType: "user", SubjectID: "tom", Result: "PERMISSIONSHIP_HAS_PERMISSION"},
+		{ResourceType: "document", ResourceID: "doc", Permission: "edit", SubjectType: "user", SubjectID: "tom", Error: "unknown permission"},
+	}, sink.events[0].Decisions)
+}
+
+func TestLookupResourcesDecisions(t *testing.T) {
+	sink := &fakeSink{}
+	logger := newTestDecisionLogger(t, sink, 1, RedactSubjectID)
+
+	stream := &fakeLookupStream{ctx: context.Background(), req: &v1.LookupResourcesRequest{
+		ResourceObjectType: "document",
+		Permission:         "view",
+		Subject:            &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "group", ObjectId: "eng"}, OptionalRelation: "member"},
+	}}
+
+	// Decisions sent before the lookup failed are logged.
+	err := logger.StreamServerInterceptor()(nil, stream, &grpc.StreamServerInfo{FullMethod: v1.PermissionsService_LookupResources_FullMethodName}, func(_ any, stream grpc.ServerStream) error {
+		req := &v1.LookupResourcesRequest{}
+		require.NoError(t, stream.RecvMsg(req))
+		for _, id := range []string{"first", "second"} {
+			require.NoError(t, stream.SendMsg(&v1.LookupResourcesResponse{
+				LookedUpAt:       &v1.ZedToken{Token: "sometoken"},
+				ResourceObjectId: id,
+				Permissionship:   v1.LookupPermissionship_LOOKUP_PERMISSIONSHIP_HAS_PERMISSION,
+			}))
+		}
+		return errors.New("lookup failed")
+	})
+	require.Error(t, err)
+	require.NoError(t, logger.Close())
+
+	require.Len(t, stream.sent, 2)
+	require.Len(t, sink.events, 1)
+	require.Equal(t, "sometoken", sink.events[0].Revision)
+	require.Equal(t, []*Decision{
+		{ResourceType: "document", ResourceID: "first", Permission: "view", SubjectType: "group", SubjectID: redactedValue, SubjectRelation: "member", Result: "LOOKUP_PERMISSIONSHIP_HAS_PERMISSION"},
+		{ResourceType: "document", ResourceID: "second", Permission: "view", SubjectType: "group", SubjectID: redactedValue, SubjectRelation: "member", Result: "LOOKUP_PERMISSIONSHIP_HAS_PERMISSION"},
+		{ResourceType: "document", Permission: "view", SubjectType: "group", SubjectID: redactedValue, SubjectRelation: "member", Error: "lookup failed"},
+	}, sink.events[0].Decisions)
+}
+
+func TestLookupResourcesDecisionsAreChunked(t *testing.T) {
+	sink := &fakeSink{}
+	logger := newTestDecisionLogger(t, sink, 1)
+
+	stream := &fakeLookupStream{ctx: context.Background(), req: &v1.LookupResourcesRequest{
+		ResourceObjectType: "document",
+		Permission:         "view",
+		Subject:            tom,
+	}}
+
+	resourceCount := 2*maxDecisionsPerEvent + 1
+	err := logger.StreamServerInterceptor()(nil, stream, &grpc.StreamServerInfo{FullMethod: v1.PermissionsService_LookupResources_FullMethodName}, func(_ any, stream grpc.ServerStream) error {
+		require.NoError(t, stream.RecvMsg(&v1.LookupResourcesRequest{}))
+		for i := 0; i < resourceCount; i++ {
+			require.NoError(t, stream.SendMsg(&v1.LookupResourcesResponse{
+				ResourceObjectId: strconv.Itoa(i),
+				Permissionship:   v1.LookupPermissionship_LOOKUP_PERMISSIONSHIP_HAS_PERMISSION,
+			}))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, logger.Close())
+
+	require.Len(t, sink.events, 3)
+	require.Len(t, sink.events[0].Decisions, maxDecisionsPerEvent)
+	require.Len(t, sink.events[1].Decisions, maxDecisionsPerEvent)
+	require.Len(t, sink.events[2].Decisions, 1)
+	require.Equal(t, "0", sink.events[0].Decisions[0].ResourceID)
+	require.Equal(t, strconv.Itoa(resourceCount-1), sink.events[2].Decisions[0].ResourceID)
+}
+
+func TestUnsampledAndFailedCalls(t *testing.T) {
+	checkInfo := &grpc.UnaryServerInfo{FullMethod: v1.PermissionsService_CheckPermission_FullMethodName}
+	checkReq := &v1.CheckPermissionRequest{Resource: doc, Permission: "view", Subject: tom}
+	checked := func(context.Context, any) (any, error) {
+		return &v1.CheckPermissionResponse{Permissionship: v1.CheckPermissionResponse_PERMISSIONSHIP_NO_PERMISSION}, nil
+	}
+
+	sink := &fakeSink{}
+	logger := newTestDecisionLogger(t, sink, 0)
+	_, err := logger.UnaryServerInterceptor()(context.Background(), checkReq, checkInfo, checked)
+	require.NoError(t, err)
+	require.NoError(t, logger.Close())
+	require.Empty(t, sink.events)
+
+	// Failed checks are logged with their error.
+	sink = &fakeSink{}
+	logger = newTestDecisionLogger(t, sink, 1)
+	_, err = logger.UnaryServerInterceptor()(context.Background(), checkReq, checkInfo, func(context.Context, any) (any, error) {
+		return nil, status.Error(codes.FailedPrecondition, "check failed")
+	})
+	require.Error(t, err)
+
+	// Other methods are never logged.
+	_, err = logger.UnaryServerInterceptor()(context.Background(), &v1.WriteRelationshipsRequest{}, writeRelationships, writeHandler("sometoken"))
+	require.NoError(t, err)
+	require.NoError(t, logger.Close())
+	require.Len(t, sink.events, 1)
+	require.Equal(t, []*Decision{
+		{ResourceType: "document", ResourceID: "doc", Permission: "view", SubjectType: "user", SubjectID: "tom", Error: "check failed"},
+	}, sink.events[0].Decisions)
+}
+
+func TestNewDecisionLoggerValidation(t *testing.T) {
+	valid := DecisionConfig{
+		Log:                       Config{Sink: SinkStdout, BufferSize: 10, OverflowPolicy: OverflowDrop},
+		CheckSampleRate:           1,
+		BulkCheckSampleRate:       0.5,
+		LookupResourcesSampleRate: 0,
+		RedactedFields:            []string{RedactSubjectID, "caveat_context.ip"},
+	}
+
+	tcs := []struct {
+		name        string
+		modify      func(config *DecisionConfig)
+		expectedErr string
+	}{
+		{"sample rate above one", func(config *DecisionConfig) { config.CheckSampleRate = 1.5 }, "must be between 0 and 1"},
+		{"negative sample rate", func(config *DecisionConfig) { config.LookupResourcesSampleRate = -1 }, "must be between 0 and 1"},
+		{"unknown redacted field", func(config *DecisionConfig) { config.RedactedFields = []string{"permission"} }, "unknown decision log redacted field `permission`"},
+		{"unnamed caveat value", func(config *DecisionConfig) { config.RedactedFields = []string{"caveat_context."} }, "unknown decision log redacted field"},
+		{"invalid log", func(config *DecisionConfig) { config.Log.Sink = "somewhere" }, "unknown audit log sink"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			config := valid
+			tc.modify(&config)
+			_, err := NewDecisionLogger(context.Background(), config)
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+
+	logger, err := NewDecisionLogger(context.Background(), valid)
+	require.NoError(t, err)
+	require.NoError(t, logger.Close())
+}
+
+type fakeLookupStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	req  *v1.LookupResourcesRequest
+	sent []any
+}
+
+func (fls *fakeLookupStream) Context() context.Context {
+	return fls.ctx
+}
+
+func (fls *fakeLookupStream) RecvMsg(m any) error {
+	proto.Merge(m.(proto.Message), fls.req)
+	return nil
+}
+
+func (fls *fakeLookupStream) SendMsg(m any) error {
+	fls.sent = append(fls.sent, m)
+	return nil
+}
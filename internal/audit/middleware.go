@@ -0,0 +1,238 @@
+package audit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/authzed/spicedb/internal/auth"
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/middleware/requestid"
+	"github.com/authzed/spicedb/pkg/zedtoken"
+)
+
+// auditedUnaryMethods are the unary methods whose calls are audited.
+var auditedUnaryMethods = map[string]struct{}{
+	v1.PermissionsService_WriteRelationships_FullMethodName:  {},
+	v1.PermissionsService_DeleteRelationships_FullMethodName: {},
+	v1.SchemaService_WriteSchema_FullMethodName:              {},
+}
+
+type ctxKeyType struct{}
+
+var handleKey ctxKeyType = struct{}{}
+
+const (
+	// maxRecordedImportRelationships is the maximum number of relationships of a bulk import
+	// recorded in its event, beyond which only their count is recorded.
+	maxRecordedImportRelationships = 1000
+
+	detailImportedRelationships  = "imported_relationships"
+	detailRelationshipsTruncated = "relationships_truncated"
+)
+
+// handle allows services to report details of writes which are not part of their responses.
+type handle struct {
+	// revision is the ZedToken of the revision at which the write was applied, if known.
+	revision string
+
+	// mutations, if set, are recorded as the mutations of the write instead of its request.
+	mutations proto.Message
+
+	// details are the details of the write found in neither its mutations nor its response.
+	details map[string]string
+
+	skip bool
+}
+
+func contextWithHandle(ctx context.Context) (context.Context, *handle) {
+	h := &handle{}
+	return context.WithValue(ctx, handleKey, h), h
+}
+
+// SetRevisionInContext reports the revision at which the write of the request of the given
+// context was applied, for writes whose responses do not carry it.
+func SetRevisionInContext(ctx context.Context, revision datastore.Revision) {
+	if h, ok := ctx.Value(handleKey).(*handle); ok {
+		h.revision = zedtoken.MustNewFromRevision(revision).Token
+	}
+}
+
+// SetMutationsInContext reports the mutations applied by the write of the request of the given
+// context, for writes whose requests do not hold them.
+func SetMutationsInContext(ctx context.Context, mutations proto.Message) {
+	if h, ok := ctx.Value(handleKey).(*handle); ok {
+		h.mutations = mutations
+	}
+}
+
+// SetDetailInContext reports a detail of the write of the request of the given context, for
+// details found in neither its mutations nor its response.
+func SetDetailInContext(ctx context.Context, key string, value string) {
+	if h, ok := ctx.Value(handleKey).(*handle); ok {
+		if h.details == nil {
+			h.details = map[string]string{}
+		}
+		h.details[key] = value
+	}
+}
+
+// IsAuditedInContext returns whether the write of the request of the given context is audited,
+// for details which are costly to compute.
+func IsAuditedInContext(ctx context.Context) bool {
+	_, ok := ctx.Value(handleKey).(*handle)
+	return ok
+}
+
+// SkipInContext reports that the request of the given context did not write, and so is not to be
+// audited.
+func SkipInContext(ctx context.Context) {
+	if h, ok := ctx.Value(handleKey).(*handle); ok {
+		h.skip = true
+	}
+}
+
+// UnaryServerInterceptor returns a new unary server interceptor that audits successful writes.
+func (a *Auditor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, ok := auditedUnaryMethods[info.FullMethod]; !ok {
+			return handler(ctx, req)
+		}
+
+		reserved, err := a.reserve(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		if !reserved {
+			return handler(ctx, req)
+		}
+
+		ctx, h := contextWithHandle(ctx)
+		resp, err := handler(ctx, req)
+		if err != nil || h.skip {
+			a.release()
+			return resp, err
+		}
+
+		h.revisionFromResponse(resp)
+		a.record(ctx, info.FullMethod, req.(proto.Message), h)
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor returns a new stream server interceptor that audits successful bulk
+// imports of relationships. Only the first relationships of large imports are recorded, along
+// with the number of relationships imported.
+func (a *Auditor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if info.FullMethod != v1.ExperimentalService_BulkImportRelationships_FullMethodName {
+			return handler(srv, stream)
+		}
+
+		reserved, err := a.reserve(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		if !reserved {
+			return handler(srv, stream)
+		}
+
+		wrapped := middleware.WrapServerStream(stream)
+		ctx, h := contextWithHandle(stream.Context())
+		wrapped.WrappedContext = ctx
+
+		recording := &recordingServerStream{WrappedServerStream: wrapped}
+		if err := handler(srv, recording); err != nil || h.skip {
+			a.release()
+			return err
+		}
+
+		SetDetailInContext(ctx, detailImportedRelationships, strconv.FormatUint(recording.count, 10))
+		if recording.count > uint64(len(recording.relationships)) {
+			SetDetailInContext(ctx, detailRelationshipsTruncated, "true")
+		}
+
+		a.record(ctx, info.FullMethod, &v1.BulkImportRelationshipsRequest{Relationships: recording.relationships}, h)
+		return nil
+	}
+}
+
+// recordingServerStream records the first relationships received on a bulk import, and counts
+// all of them.
+type recordingServerStream struct {
+	*middleware.WrappedServerStream
+	relationships []*v1.Relationship
+	count         uint64
+}
+
+func (s *recordingServerStream) RecvMsg(m interface{}) error {
+	if err := s.WrappedServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if req, ok := m.(*v1.BulkImportRelationshipsRequest); ok {
+		// Received messages may be reused by the service, so their relationships are copied.
+		for _, relationship := range req.Relationships {
+			if len(s.relationships) < maxRecordedImportRelationships {
+				s.relationships = append(s.relationships, relationship.CloneVT())
+			}
+		}
+		s.count += uint64(len(req.Relationships))
+	}
+	return nil
+}
+
+func (h *handle) revisionFromResponse(resp interface{}) {
+	var token *v1.ZedToken
+	switch resp := resp.(type) {
+	case *v1.WriteRelationshipsResponse:
+		token = resp.GetWrittenAt()
+	case *v1.DeleteRelationshipsResponse:
+		token = resp.GetDeletedAt()
+	case *v1.WriteSchemaResponse:
+		token = resp.GetWrittenAt()
+	}
+
+	if token != nil {
+		h.revision = token.Token
+	}
+}
+
+// record publishes the event of a performed write, for which room was reserved.
+func (a *Auditor) record(ctx context.Context, fullMethod string, mutations proto.Message, h *handle) {
+	if h.mutations != nil {
+		mutations = h.mutations
+	}
+
+	encoded, err := protojson.Marshal(mutations)
+	if err != nil {
+		// The write was performed, and so is not failed.
+		log.Ctx(ctx).Error().Err(err).Str("method", fullMethod).Msg("failed to encode mutations for the audit log; write will not be audited")
+		a.release()
+		return
+	}
+
+	event := newEvent(ctx, fullMethod, h.revision)
+	event.Mutations = encoded
+	event.Details = h.details
+	a.publish(event)
+}
+
+// newEvent returns the event of a call completed with the given context.
+func newEvent(ctx context.Context, fullMethod string, revision string) *Event {
+	requestID, _ := requestid.FromContext(ctx)
+	return &Event{
+		Time:      time.Now().UTC(),
+		Method:    fullMethod,
+		Principal: auth.PrincipalFromContext(ctx),
+		RequestID: requestID,
+		Revision:  revision,
+	}
+}
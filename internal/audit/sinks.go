@@ -0,0 +1,297 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/authzed/grpcutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Sink is a destination of audit events. Writes to a sink are not concurrent.
+type Sink interface {
+	// Write durably writes an event.
+	Write(event *Event) error
+
+	// Close closes the sink once all events have been written.
+	Close() error
+}
+
+// writerSink writes events as JSON lines to a writer.
+type writerSink struct {
+	w io.Writer
+}
+
+// NewStdoutSink returns a sink writing events as JSON lines to the standard output.
+func NewStdoutSink() Sink {
+	return &writerSink{w: os.Stdout}
+}
+
+func (s *writerSink) Write(event *Event) error {
+	line, err := marshalLine(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.w.Write(line)
+	return err
+}
+
+func (s *writerSink) Close() error {
+	return nil
+}
+
+func marshalLine(event *Event) ([]byte, error) {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit event: %w", err)
+	}
+	return append(line, '\n'), nil
+}
+
+// rotatedFileTimeFormat is the format of the time suffixed to the names of rotated files, which
+// sorts them in the order of their rotation.
+const rotatedFileTimeFormat = "20060102T150405.000000000Z"
+
+// rotatingFileSink writes events as JSON lines to a file, which is renamed with the time of its
+// rotation once it reaches its maximum size. Files are only ever appended to.
+type rotatingFileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	now        func() time.Time
+
+	file *os.File
+	size int64
+}
+
+// NewRotatingFileSink returns a sink writing events as JSON lines to the file at the given path,
+// which is rotated once it reaches the given size. At most maxBackups rotated files are kept, or
+// all of them if zero.
+func NewRotatingFileSink(path string, maxSize int64, maxBackups int) (Sink, error) {
+	s := &rotatingFileSink{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+		now:        time.Now,
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *rotatingFileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *rotatingFileSink) Write(event *Event) error {
+	line, err := marshalLine(event)
+	if err != nil {
+		return err
+	}
+
+	if s.file == nil {
+		// A previous rotation failed to reopen the file.
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+
+	if s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return fmt.Errorf("failed to rotate audit log file: %w", err)
+		}
+	}
+
+	written, err := s.file.Write(line)
+	s.size += int64(written)
+	if err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+func (s *rotatingFileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	s.file = nil
+
+	rotatedPath := s.path + "." + s.now().UTC().Format(rotatedFileTimeFormat)
+	if err := os.Rename(s.path, rotatedPath); err != nil {
+		return err
+	}
+
+	if err := s.open(); err != nil {
+		return err
+	}
+	return s.removeOldBackups()
+}
+
+func (s *rotatingFileSink) removeOldBackups() error {
+	if s.maxBackups <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(s.path))
+	if err != nil {
+		return err
+	}
+
+	rotatedPrefix := filepath.Base(s.path) + "."
+	var backups []string
+	for _, entry := range entries {
+		suffix, ok := strings.CutPrefix(entry.Name(), rotatedPrefix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if _, err := time.Parse(rotatedFileTimeFormat, suffix); err == nil {
+			backups = append(backups, entry.Name())
+		}
+	}
+
+	sort.Strings(backups)
+	for len(backups) > s.maxBackups {
+		if err := os.Remove(filepath.Join(filepath.Dir(s.path), backups[0])); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+func (s *rotatingFileSink) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
+
+// GRPCSinkMethod is the bidirectional streaming method called on collectors by the gRPC sink.
+// Each event is sent as a google.protobuf.Struct holding its JSON encoding, which the collector
+// must acknowledge with a google.protobuf.Empty once durably recorded, in the order received.
+const GRPCSinkMethod = "/spicedb.audit.v1.AuditLogService/RecordEvents"
+
+var grpcSinkStreamDesc = &grpc.StreamDesc{
+	StreamName:    "RecordEvents",
+	ClientStreams: true,
+	ServerStreams: true,
+}
+
+// grpcSink streams events to a collector, reopening the stream after failures.
+type grpcSink struct {
+	conn *grpc.ClientConn
+
+	stream grpc.ClientStream
+	cancel context.CancelFunc
+}
+
+// NewGRPCSink returns a sink streaming events to the collector at the given endpoint, verified
+// with the certificate authority at the given path if any.
+func NewGRPCSink(ctx context.Context, endpoint string, caCertPath string) (Sink, error) {
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if caCertPath != "" {
+		customCertOpt, err := grpcutil.WithCustomCerts(grpcutil.VerifyCA, caCertPath)
+		if err != nil {
+			return nil, err
+		}
+		opts = []grpc.DialOption{customCertOpt}
+	}
+
+	conn, err := grpc.DialContext(ctx, endpoint, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcSink{conn: conn}, nil
+}
+
+func (s *grpcSink) Write(event *Event) error {
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+
+	message := &structpb.Struct{}
+	if err := protojson.Unmarshal(encoded, message); err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+
+	if s.stream == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream, err := s.conn.NewStream(ctx, grpcSinkStreamDesc, GRPCSinkMethod)
+		if err != nil {
+			cancel()
+			return err
+		}
+		s.stream, s.cancel = stream, cancel
+	}
+
+	if err := s.send(message); err != nil {
+		s.resetStream()
+		return err
+	}
+	return nil
+}
+
+// send sends a message on the stream and waits for its acknowledgement.
+func (s *grpcSink) send(message *structpb.Struct) error {
+	err := s.stream.SendMsg(message)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	// If the stream was closed by the collector, its status is returned when receiving.
+	recvErr := s.stream.RecvMsg(&emptypb.Empty{})
+	switch {
+	case recvErr != nil && !errors.Is(recvErr, io.EOF):
+		return recvErr
+	case recvErr != nil || err != nil:
+		return errors.New("audit log collector closed the stream")
+	default:
+		return nil
+	}
+}
+
+func (s *grpcSink) resetStream() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.stream, s.cancel = nil, nil
+}
+
+func (s *grpcSink) Close() error {
+	if s.stream != nil {
+		if err := s.stream.CloseSend(); err == nil {
+			// Wait for the collector to close the stream.
+			_ = s.stream.RecvMsg(&emptypb.Empty{})
+		}
+		s.resetStream()
+	}
+	return s.conn.Close()
+}
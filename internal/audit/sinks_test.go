@@ -0,0 +1,159 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestRotatingFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	event := &Event{Method: "somemethod", Principal: "subject:somecaller", Mutations: json.RawMessage(`{}`)}
+	line, err := marshalLine(event)
+	require.NoError(t, err)
+
+	// Each file holds two events, and only two rotated files are kept.
+	sink, err := NewRotatingFileSink(path, int64(2*len(line)), 2)
+	require.NoError(t, err)
+
+	rotations := 0
+	sink.(*rotatingFileSink).now = func() time.Time {
+		rotations++
+		return time.Date(2023, 1, 1, 0, 0, rotations, 0, time.UTC)
+	}
+
+	for i := 0; i < 7; i++ {
+		require.NoError(t, sink.Write(event))
+	}
+	require.NoError(t, sink.Close())
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	require.Equal(t, []string{
+		"audit.log",
+		"audit.log.20230101T000002.000000000Z",
+		"audit.log.20230101T000003.000000000Z",
+	}, names)
+
+	require.Equal(t, 1, countLines(t, path))
+	require.Equal(t, 2, countLines(t, path+".20230101T000003.000000000Z"))
+
+	// Reopened files are appended to.
+	sink, err = NewRotatingFileSink(path, int64(2*len(line)), 2)
+	require.NoError(t, err)
+	require.NoError(t, sink.Write(event))
+	require.NoError(t, sink.Close())
+	require.Equal(t, 2, countLines(t, path))
+}
+
+func countLines(t *testing.T, path string) int {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	count := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		count++
+	}
+	require.NoError(t, scanner.Err())
+	return count
+}
+
+// collector is a collector of audit events, which fails the given number of streams after
+// receiving their first event.
+type collector struct {
+	sync.Mutex
+	events   []map[string]any
+	failures int
+}
+
+func (c *collector) recordEvents(_ any, stream grpc.ServerStream) error {
+	for {
+		message := &structpb.Struct{}
+		if err := stream.RecvMsg(message); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		c.Lock()
+		if c.failures > 0 {
+			c.failures--
+			c.Unlock()
+			return errors.New("collector failure")
+		}
+		c.events = append(c.events, message.AsMap())
+		c.Unlock()
+
+		if err := stream.SendMsg(&emptypb.Empty{}); err != nil {
+			return err
+		}
+	}
+}
+
+func TestGRPCSink(t *testing.T) {
+	c := &collector{failures: 1}
+
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "spicedb.audit.v1.AuditLogService",
+		HandlerType: (*any)(nil),
+		Streams: []grpc.StreamDesc{{
+			StreamName:    "RecordEvents",
+			Handler:       c.recordEvents,
+			ServerStreams: true,
+			ClientStreams: true,
+		}},
+	}, c)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	sink, err := NewGRPCSink(context.Background(), listener.Addr().String(), "")
+	require.NoError(t, err)
+
+	first := &Event{Method: "first", Principal: "subject:somecaller", Mutations: json.RawMessage(`{"updates":[]}`)}
+	second := &Event{Method: "second", Principal: "subject:somecaller", Mutations: json.RawMessage(`{}`)}
+
+	// The collector fails the first stream without acknowledging the event, which is retried on
+	// a new stream.
+	require.Error(t, sink.Write(first))
+	require.NoError(t, sink.Write(first))
+	require.NoError(t, sink.Write(second))
+	require.NoError(t, sink.Close())
+
+	require.Len(t, c.events, 2)
+	require.Equal(t, "first", c.events[0]["method"])
+	require.Equal(t, "subject:somecaller", c.events[0]["principal"])
+	require.Equal(t, map[string]any{"updates": []any{}}, c.events[0]["mutations"])
+	require.Equal(t, "second", c.events[1]["method"])
+}
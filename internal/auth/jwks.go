@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// jsonWebKey is a public key in JSON Web Key format, as defined in RFC 7517.
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+
+	// RSA keys.
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC and OKP keys.
+	Curve string `json:"crv"`
+	X     string `json:"x"`
+	Y     string `json:"y"`
+}
+
+// keySet is a set of public keys against which the signatures of tokens are verified.
+type keySet struct {
+	byID map[string]crypto.PublicKey
+
+	// unidentified are the keys without a key ID, tried for tokens without a key ID.
+	unidentified []crypto.PublicKey
+}
+
+// candidates returns the keys which may have signed a token with the given key ID.
+func (ks *keySet) candidates(keyID string) []crypto.PublicKey {
+	if keyID != "" {
+		if key, ok := ks.byID[keyID]; ok {
+			return []crypto.PublicKey{key}
+		}
+		return nil
+	}
+
+	candidates := make([]crypto.PublicKey, 0, len(ks.byID)+len(ks.unidentified))
+	candidates = append(candidates, ks.unidentified...)
+	for _, key := range ks.byID {
+		candidates = append(candidates, key)
+	}
+	return candidates
+}
+
+// parseJWKS parses a JSON Web Key Set, ignoring keys not used for signatures.
+func parseJWKS(data []byte) (*keySet, error) {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	ks := &keySet{byID: make(map[string]crypto.PublicKey, len(jwks.Keys))}
+	for index, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid key #%d in JWKS: %w", index+1, err)
+		}
+
+		if jwk.KeyID == "" {
+			ks.unidentified = append(ks.unidentified, key)
+			continue
+		}
+
+		if _, ok := ks.byID[jwk.KeyID]; ok {
+			return nil, fmt.Errorf("duplicate key ID `%s` in JWKS", jwk.KeyID)
+		}
+		ks.byID[jwk.KeyID] = key
+	}
+
+	if len(ks.byID) == 0 && len(ks.unidentified) == 0 {
+		return nil, errors.New("JWKS contains no signing keys")
+	}
+	return ks, nil
+}
+
+func (jwk jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA exponent: %w", err)
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("RSA exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported EC curve `%s`", jwk.Curve)
+		}
+
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC x coordinate: %w", err)
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC y coordinate: %w", err)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("EC point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "OKP":
+		if jwk.Curve != "Ed25519" {
+			return nil, fmt.Errorf("unsupported OKP curve `%s`", jwk.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Ed25519 key: %w", err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type `%s`", jwk.KeyType)
+	}
+}
+
+func decodeBigInt(encoded string) (*big.Int, error) {
+	if encoded == "" {
+		return nil, errors.New("missing value")
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(decoded), nil
+}
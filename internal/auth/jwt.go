@@ -0,0 +1,459 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	grpcauth "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	log "github.com/authzed/spicedb/internal/logging"
+)
+
+const (
+	errInvalidJWT = "invalid token: %s"
+
+	// clockSkew is the leeway allowed when validating the expiry and not-before times of tokens.
+	clockSkew = 30 * time.Second
+
+	// minKeyRefreshInterval is the minimum interval between reloads of the keys, which are
+	// reloaded when a token is signed by an unknown key.
+	minKeyRefreshInterval = 1 * time.Minute
+
+	// maxJWKSSize is the maximum size of a JWKS fetched over HTTP.
+	maxJWKSSize = 1 << 20
+
+	// jwksFetchTimeout is the timeout of requests fetching a JWKS over HTTP.
+	jwksFetchTimeout = 10 * time.Second
+)
+
+// schemaPrefixRegex matches the prefixes of definition names in schemas.
+var schemaPrefixRegex = regexp.MustCompile(`^[a-z][a-z0-9_]{1,61}[a-z0-9]$`)
+
+// JWTConfig configures the authentication of requests with JSON Web Tokens.
+type JWTConfig struct {
+	// JWKS is the location of the JSON Web Key Set against which tokens are verified: either the
+	// path to a file, for offline use, or an `https://` URL such as the `jwks_uri` of an OIDC
+	// provider. If empty, requests are not authenticated with tokens.
+	JWKS string `debugmap:"visible"`
+
+	// Issuer is the issuer required in the `iss` claim of tokens, if any.
+	Issuer string `debugmap:"visible"`
+
+	// Audience is the audience required in the `aud` claim of tokens, if any.
+	Audience string `debugmap:"visible"`
+
+	// SchemaPrefixClaim is the claim of tokens carrying the schema prefix to which callers are
+	// bound, if any. If set, tokens without a valid prefix in the claim are rejected.
+	SchemaPrefixClaim string `debugmap:"visible"`
+}
+
+func (c JWTConfig) MarshalZerologObject(e *zerolog.Event) {
+	e.Str("jwks", c.JWKS).Str("issuer", c.Issuer).Str("audience", c.Audience).Str("schemaPrefixClaim", c.SchemaPrefixClaim)
+}
+
+// JWTVerifier verifies JSON Web Tokens against a JSON Web Key Set.
+type JWTVerifier struct {
+	config   JWTConfig
+	loadJWKS func(ctx context.Context) ([]byte, error)
+	now      func() time.Time
+	reloads  singleflight.Group
+
+	lock     sync.Mutex
+	keys     *keySet
+	loadedAt time.Time
+}
+
+// NewJWTVerifier returns a verifier for tokens signed by the keys of the configured JWKS, which
+// is loaded immediately to validate the configuration.
+func NewJWTVerifier(ctx context.Context, config JWTConfig) (*JWTVerifier, error) {
+	return newJWTVerifier(ctx, config, &http.Client{Timeout: jwksFetchTimeout})
+}
+
+func newJWTVerifier(ctx context.Context, config JWTConfig, client *http.Client) (*JWTVerifier, error) {
+	if config.JWKS == "" {
+		return nil, errors.New("a JWKS is required to verify tokens")
+	}
+
+	// Keys fetched over plain HTTP could be substituted, allowing anyone to forge tokens.
+	if strings.HasPrefix(config.JWKS, "http://") {
+		return nil, fmt.Errorf("the JWKS must be fetched over HTTPS: `%s`", config.JWKS)
+	}
+
+	verifier := &JWTVerifier{
+		config:   config,
+		loadJWKS: jwksLoader(config.JWKS, client),
+		now:      time.Now,
+	}
+
+	keys, err := verifier.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	verifier.keys = keys
+	verifier.loadedAt = verifier.now()
+	return verifier, nil
+}
+
+func jwksLoader(location string, client *http.Client) func(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(location, "https://") {
+		return func(context.Context) ([]byte, error) {
+			return os.ReadFile(location)
+		}
+	}
+
+	return func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status fetching JWKS: %s", resp.Status)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+	}
+}
+
+func (v *JWTVerifier) load(ctx context.Context) (*keySet, error) {
+	data, err := v.loadJWKS(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load JWKS from `%s`: %w", v.config.JWKS, err)
+	}
+	return parseJWKS(data)
+}
+
+// candidateKeys returns the keys which may have signed a token with the given key ID, reloading
+// the keys if none are known, so that rotated keys are picked up.
+func (v *JWTVerifier) candidateKeys(ctx context.Context, keyID string) []crypto.PublicKey {
+	v.lock.Lock()
+	candidates := v.keys.candidates(keyID)
+	mayReload := len(candidates) == 0 && v.now().Sub(v.loadedAt) >= minKeyRefreshInterval
+	v.lock.Unlock()
+	if !mayReload {
+		return candidates
+	}
+
+	// The keys are reloaded without holding the lock, so that requests signed by known keys are
+	// not blocked by a slow JWKS endpoint, and independently of the context of the request, as
+	// concurrent reloads are shared.
+	reloaded, _, _ := v.reloads.Do("", func() (any, error) {
+		v.lock.Lock()
+		if v.now().Sub(v.loadedAt) < minKeyRefreshInterval {
+			keys := v.keys
+			v.lock.Unlock()
+			return keys, nil
+		}
+		v.loadedAt = v.now()
+		v.lock.Unlock()
+
+		keys, err := v.load(context.Background())
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to reload JWKS; keeping the keys last loaded")
+			return nil, err
+		}
+
+		v.lock.Lock()
+		v.keys = keys
+		v.lock.Unlock()
+		return keys, nil
+	})
+
+	keys, ok := reloaded.(*keySet)
+	if !ok {
+		return nil
+	}
+	return keys.candidates(keyID)
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Issuer    string          `json:"iss"`
+	Subject   string          `json:"sub"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *float64        `json:"exp"`
+	NotBefore *float64        `json:"nbf"`
+
+	// Scope is the space-separated scopes of the token, as defined in RFC 8693.
+	Scope string `json:"scope"`
+
+	// Scp is the list of scopes of the token, as issued by some providers instead of `scope`.
+	Scp []string `json:"scp"`
+}
+
+// Verify verifies the signature and validity of a token, returning its claims.
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed token header: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token signature: %w", err)
+	}
+
+	verifySignature, err := signatureVerifier(header.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, key := range v.candidateKeys(ctx, header.KeyID) {
+		if verifySignature(key, signed, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errors.New("token signature could not be verified")
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("malformed token claims: %w", err)
+	}
+
+	if err := v.validateClaims(claims); err != nil {
+		return nil, err
+	}
+
+	schemaPrefix, err := v.schemaPrefix(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := claims.Scp
+	if claims.Scope != "" {
+		scopes = append(scopes, strings.Fields(claims.Scope)...)
+	}
+
+	return &Claims{
+		Subject:      claims.Subject,
+		Scopes:       ParseScopes(scopes),
+		SchemaPrefix: schemaPrefix,
+	}, nil
+}
+
+// schemaPrefix returns the schema prefix carried by the configured claim of a token, if any.
+func (v *JWTVerifier) schemaPrefix(segment string) (string, error) {
+	if v.config.SchemaPrefixClaim == "" {
+		return "", nil
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(segment, &claims); err != nil {
+		return "", fmt.Errorf("malformed token claims: %w", err)
+	}
+
+	prefix, ok := claims[v.config.SchemaPrefixClaim].(string)
+	if !ok {
+		return "", fmt.Errorf("token has no `%s` claim", v.config.SchemaPrefixClaim)
+	}
+	if !schemaPrefixRegex.MatchString(prefix) {
+		return "", fmt.Errorf("token claim `%s` is not a valid schema prefix: `%s`", v.config.SchemaPrefixClaim, prefix)
+	}
+	return prefix, nil
+}
+
+func (v *JWTVerifier) validateClaims(claims jwtClaims) error {
+	now := v.now()
+	if claims.ExpiresAt == nil {
+		return errors.New("token has no expiry")
+	}
+	if now.After(numericDate(*claims.ExpiresAt).Add(clockSkew)) {
+		return errors.New("token has expired")
+	}
+	if claims.NotBefore != nil && now.Add(clockSkew).Before(numericDate(*claims.NotBefore)) {
+		return errors.New("token is not yet valid")
+	}
+
+	if v.config.Issuer != "" && claims.Issuer != v.config.Issuer {
+		return fmt.Errorf("unexpected token issuer `%s`", claims.Issuer)
+	}
+
+	if v.config.Audience != "" {
+		audiences, err := parseAudience(claims.Audience)
+		if err != nil {
+			return err
+		}
+
+		found := false
+		for _, audience := range audiences {
+			if audience == v.config.Audience {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.New("token is not intended for this audience")
+		}
+	}
+
+	return nil
+}
+
+// parseAudience parses the `aud` claim, which is either a single audience or a list of them.
+func parseAudience(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return []string{single}, nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(raw, &multiple); err != nil {
+		return nil, errors.New("malformed token audience")
+	}
+	return multiple, nil
+}
+
+func numericDate(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
+
+func decodeSegment(segment string, into any) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(decoded, into)
+}
+
+type verifyFunc func(key crypto.PublicKey, signed []byte, signature []byte) bool
+
+// signatureVerifier returns the function verifying signatures of the given algorithm, which
+// also ensures that the key is of the type expected by the algorithm.
+func signatureVerifier(algorithm string) (verifyFunc, error) {
+	switch algorithm {
+	case "RS256":
+		return verifyRSA(crypto.SHA256, sha256.New, false), nil
+	case "RS384":
+		return verifyRSA(crypto.SHA384, sha512.New384, false), nil
+	case "RS512":
+		return verifyRSA(crypto.SHA512, sha512.New, false), nil
+	case "PS256":
+		return verifyRSA(crypto.SHA256, sha256.New, true), nil
+	case "PS384":
+		return verifyRSA(crypto.SHA384, sha512.New384, true), nil
+	case "PS512":
+		return verifyRSA(crypto.SHA512, sha512.New, true), nil
+	case "ES256":
+		return verifyECDSA(sha256.New, 32), nil
+	case "ES384":
+		return verifyECDSA(sha512.New384, 48), nil
+	case "ES512":
+		return verifyECDSA(sha512.New, 66), nil
+	case "EdDSA":
+		return func(key crypto.PublicKey, signed []byte, signature []byte) bool {
+			edKey, ok := key.(ed25519.PublicKey)
+			return ok && ed25519.Verify(edKey, signed, signature)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported token signing algorithm `%s`", algorithm)
+	}
+}
+
+func verifyRSA(hashType crypto.Hash, newHash func() hash.Hash, pss bool) verifyFunc {
+	return func(key crypto.PublicKey, signed []byte, signature []byte) bool {
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return false
+		}
+
+		hasher := newHash()
+		hasher.Write(signed)
+		digest := hasher.Sum(nil)
+
+		if pss {
+			return rsa.VerifyPSS(rsaKey, hashType, digest, signature, nil) == nil
+		}
+		return rsa.VerifyPKCS1v15(rsaKey, hashType, digest, signature) == nil
+	}
+}
+
+func verifyECDSA(newHash func() hash.Hash, size int) verifyFunc {
+	return func(key crypto.PublicKey, signed []byte, signature []byte) bool {
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok || (ecKey.Curve.Params().BitSize+7)/8 != size || len(signature) != 2*size {
+			return false
+		}
+
+		hasher := newHash()
+		hasher.Write(signed)
+
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(ecKey, hasher.Sum(nil), r, s)
+	}
+}
+
+// RequireJWT requires that gRPC requests have a Bearer Token which is either a JSON Web Token
+// verified by the given verifier, whose claims are then carried by the request context, or
+// equivalent to one of the provided preshared key(s), which grant unrestricted access.
+func RequireJWT(verifier *JWTVerifier, presharedKeys []string) grpcauth.AuthFunc {
+	return func(ctx context.Context) (context.Context, error) {
+		token, err := grpcauth.AuthFromMD(ctx, "bearer")
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, errInvalidJWT, err.Error())
+		}
+
+		if token == "" {
+			return nil, status.Errorf(codes.Unauthenticated, "missing token")
+		}
+
+		for _, presharedKey := range presharedKeys {
+			if match := subtle.ConstantTimeCompare([]byte(presharedKey), []byte(token)); match == 1 {
+				return ctx, nil
+			}
+		}
+
+		claims, err := verifier.Verify(ctx, token)
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, errInvalidJWT, err.Error())
+		}
+
+		return ContextWithClaims(ctx, claims), nil
+	}
+}
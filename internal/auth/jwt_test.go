@@ -0,0 +1,405 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/authzed/grpcutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+type testKey struct {
+	id        string
+	algorithm string
+	private   crypto.Signer
+}
+
+func (tk testKey) jwk() map[string]string {
+	switch public := tk.private.Public().(type) {
+	case *rsa.PublicKey:
+		return map[string]string{
+			"kty": "RSA",
+			"kid": tk.id,
+			"n":   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		return map[string]string{
+			"kty": "EC",
+			"kid": tk.id,
+			"crv": "P-256",
+			"x":   base64.RawURLEncoding.EncodeToString(public.X.FillBytes(make([]byte, 32))),
+			"y":   base64.RawURLEncoding.EncodeToString(public.Y.FillBytes(make([]byte, 32))),
+		}
+	case ed25519.PublicKey:
+		return map[string]string{
+			"kty": "OKP",
+			"kid": tk.id,
+			"crv": "Ed25519",
+			"x":   base64.RawURLEncoding.EncodeToString(public),
+		}
+	default:
+		panic("unsupported key type")
+	}
+}
+
+func (tk testKey) sign(t *testing.T, claims map[string]any) string {
+	header, err := json.Marshal(map[string]string{"alg": tk.algorithm, "kid": tk.id, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch private := tk.private.(type) {
+	case *rsa.PrivateKey:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, private, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, private, digest[:])
+		require.NoError(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(private, []byte(signed))
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newTestKeys(t *testing.T) []testKey {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return []testKey{
+		{id: "rsa", algorithm: "RS256", private: rsaKey},
+		{id: "ec", algorithm: "ES256", private: ecKey},
+		{id: "ed", algorithm: "EdDSA", private: edKey},
+	}
+}
+
+func writeJWKS(t *testing.T, path string, keys ...testKey) {
+	jwks := map[string][]map[string]string{"keys": {}}
+	for _, key := range keys {
+		jwks["keys"] = append(jwks["keys"], key.jwk())
+	}
+
+	data, err := json.Marshal(jwks)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func TestJWTVerification(t *testing.T) {
+	keys := newTestKeys(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, keys...)
+
+	verifier, err := NewJWTVerifier(context.Background(), JWTConfig{
+		JWKS:     path,
+		Issuer:   "https://issuer.example.com",
+		Audience: "spicedb",
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	validClaims := func() map[string]any {
+		return map[string]any{
+			"iss":   "https://issuer.example.com",
+			"sub":   "some-service",
+			"aud":   []string{"other", "spicedb"},
+			"exp":   now.Add(time.Hour).Unix(),
+			"scope": "openid relationships:read prefix:tenant1/",
+		}
+	}
+
+	for _, key := range keys {
+		key := key
+		t.Run(key.algorithm, func(t *testing.T) {
+			claims, err := verifier.Verify(context.Background(), key.sign(t, validClaims()))
+			require.NoError(t, err)
+			require.Equal(t, "some-service", claims.Subject)
+			require.True(t, claims.Scopes.Allows(AreaRelationships, AccessRead))
+			require.False(t, claims.Scopes.Allows(AreaRelationships, AccessWrite))
+			require.Equal(t, []string{"tenant1/"}, claims.Scopes.Prefixes())
+		})
+	}
+
+	tcs := []struct {
+		name        string
+		modify      func(claims map[string]any)
+		expectedErr string
+	}{
+		{"expired", func(claims map[string]any) { claims["exp"] = now.Add(-time.Hour).Unix() }, "token has expired"},
+		{"no expiry", func(claims map[string]any) { delete(claims, "exp") }, "token has no expiry"},
+		{"not yet valid", func(claims map[string]any) { claims["nbf"] = now.Add(time.Hour).Unix() }, "token is not yet valid"},
+		{"wrong issuer", func(claims map[string]any) { claims["iss"] = "someone-else" }, "unexpected token issuer `someone-else`"},
+		{"wrong audience", func(claims map[string]any) { claims["aud"] = "other" }, "token is not intended for this audience"},
+		{"no audience", func(claims map[string]any) { delete(claims, "aud") }, "token is not intended for this audience"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			claims := validClaims()
+			tc.modify(claims)
+			_, err := verifier.Verify(context.Background(), keys[0].sign(t, claims))
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+
+	t.Run("tampered", func(t *testing.T) {
+		token := keys[0].sign(t, validClaims())
+		forged := keys[1].sign(t, validClaims())
+		_, err := verifier.Verify(context.Background(), token[:len(token)-10]+forged[len(forged)-10:])
+		require.Error(t, err)
+	})
+
+	t.Run("key of another algorithm", func(t *testing.T) {
+		mismatched := testKey{id: "rsa", algorithm: "ES256", private: keys[1].private}
+		_, err := verifier.Verify(context.Background(), mismatched.sign(t, validClaims()))
+		require.ErrorContains(t, err, "token signature could not be verified")
+	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		token := keys[0].sign(t, validClaims())
+		header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+		_, err := verifier.Verify(context.Background(), header+token[len(header):])
+		require.Error(t, err)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := verifier.Verify(context.Background(), "not-a-jwt")
+		require.ErrorContains(t, err, "malformed token")
+	})
+}
+
+func TestJWTSchemaPrefixClaim(t *testing.T) {
+	keys := newTestKeys(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, keys...)
+
+	verifier, err := NewJWTVerifier(context.Background(), JWTConfig{JWKS: path, SchemaPrefixClaim: "tenant"})
+	require.NoError(t, err)
+
+	exp := time.Now().Add(time.Hour).Unix()
+	claims, err := verifier.Verify(context.Background(), keys[0].sign(t, map[string]any{"exp": exp, "tenant": "tenant1"}))
+	require.NoError(t, err)
+	require.Equal(t, "tenant1", claims.SchemaPrefix)
+
+	tcs := []struct {
+		name        string
+		tenant      any
+		expectedErr string
+	}{
+		{"missing", nil, "token has no `tenant` claim"},
+		{"not a string", 42, "token has no `tenant` claim"},
+		{"with a slash", "tenant1/nested", "token claim `tenant` is not a valid schema prefix"},
+		{"uppercase", "Tenant1", "token claim `tenant` is not a valid schema prefix"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tokenClaims := map[string]any{"exp": exp}
+			if tc.tenant != nil {
+				tokenClaims["tenant"] = tc.tenant
+			}
+			_, err := verifier.Verify(context.Background(), keys[0].sign(t, tokenClaims))
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestJWTKeyRotation(t *testing.T) {
+	keys := newTestKeys(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, keys[0])
+
+	verifier, err := NewJWTVerifier(context.Background(), JWTConfig{JWKS: path})
+	require.NoError(t, err)
+
+	now := time.Now()
+	verifier.now = func() time.Time { return now }
+	claims := map[string]any{"sub": "rotated", "exp": now.Add(time.Hour).Unix()}
+
+	// Keys unknown when loaded are only picked up once the keys may be reloaded.
+	writeJWKS(t, path, keys[0], keys[1])
+	_, err = verifier.Verify(context.Background(), keys[1].sign(t, claims))
+	require.Error(t, err)
+
+	now = now.Add(2 * minKeyRefreshInterval)
+	verified, err := verifier.Verify(context.Background(), keys[1].sign(t, claims))
+	require.NoError(t, err)
+	require.Equal(t, "rotated", verified.Subject)
+}
+
+func TestJWKSFromURL(t *testing.T) {
+	keys := newTestKeys(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, keys...)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, path)
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	verifier, err := newJWTVerifier(context.Background(), JWTConfig{JWKS: server.URL + "/jwks.json"}, server.Client())
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(context.Background(), keys[2].sign(t, map[string]any{"exp": time.Now().Add(time.Hour).Unix()}))
+	require.NoError(t, err)
+
+	_, err = newJWTVerifier(context.Background(), JWTConfig{JWKS: server.URL + "/missing.json"}, server.Client())
+	require.Error(t, err)
+
+	insecureServer := httptest.NewServer(mux)
+	defer insecureServer.Close()
+
+	_, err = NewJWTVerifier(context.Background(), JWTConfig{JWKS: insecureServer.URL + "/jwks.json"})
+	require.ErrorContains(t, err, "must be fetched over HTTPS")
+}
+
+func TestJWTReloadDoesNotBlockKnownKeys(t *testing.T) {
+	keys := newTestKeys(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, keys[0])
+
+	verifier, err := NewJWTVerifier(context.Background(), JWTConfig{JWKS: path})
+	require.NoError(t, err)
+
+	now := time.Now().Add(2 * minKeyRefreshInterval)
+	verifier.now = func() time.Time { return now }
+	claims := map[string]any{"exp": time.Now().Add(time.Hour).Unix()}
+
+	reloading := make(chan struct{})
+	release := make(chan struct{})
+	loadJWKS := verifier.loadJWKS
+	verifier.loadJWKS = func(ctx context.Context) ([]byte, error) {
+		close(reloading)
+		<-release
+		return loadJWKS(ctx)
+	}
+
+	// A token signed by an unknown key triggers a reload, which hangs.
+	unknown := keys[1].sign(t, claims)
+	reloaded := make(chan error)
+	go func() {
+		_, err := verifier.Verify(context.Background(), unknown)
+		reloaded <- err
+	}()
+	<-reloading
+
+	// Tokens signed by known keys are verified in the meantime.
+	_, err = verifier.Verify(context.Background(), keys[0].sign(t, claims))
+	require.NoError(t, err)
+
+	close(release)
+	require.Error(t, <-reloaded)
+}
+
+func TestParseJWKS(t *testing.T) {
+	tcs := []struct {
+		name        string
+		jwks        string
+		expectedErr string
+	}{
+		{"not json", `keys`, "invalid JWKS"},
+		{"no keys", `{"keys": []}`, "JWKS contains no signing keys"},
+		{"only encryption keys", `{"keys": [{"kty": "OKP", "crv": "Ed25519", "use": "enc", "x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`, "JWKS contains no signing keys"},
+		{"unsupported key type", `{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`, "unsupported key type `oct`"},
+		{"unsupported curve", `{"keys": [{"kty": "EC", "crv": "P-192", "x": "AA", "y": "AA"}]}`, "unsupported EC curve `P-192`"},
+		{"point not on curve", `{"keys": [{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`, "EC point is not on the curve"},
+		{"duplicate key IDs", `{"keys": [{"kty": "OKP", "crv": "Ed25519", "kid": "a", "x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}, {"kty": "OKP", "crv": "Ed25519", "kid": "a", "x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`, "duplicate key ID `a`"},
+		{"valid", `{"keys": [{"kty": "OKP", "crv": "Ed25519", "x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}]}`, ""},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseJWKS([]byte(tc.jwks))
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestRequireJWT(t *testing.T) {
+	keys := newTestKeys(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, keys...)
+
+	verifier, err := NewJWTVerifier(context.Background(), JWTConfig{JWKS: path})
+	require.NoError(t, err)
+	authFunc := RequireJWT(verifier, []string{"somepresharedkey"})
+
+	token := keys[0].sign(t, map[string]any{"sub": "caller", "exp": time.Now().Add(time.Hour).Unix(), "scp": []string{"schema:read"}})
+	ctx, err := authFunc(withTokenMetadata("bearer " + token))
+	require.NoError(t, err)
+
+	claims, ok := ClaimsFromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, "caller", claims.Subject)
+	require.True(t, claims.Scopes.Allows(AreaSchema, AccessRead))
+
+	// Preshared keys grant unrestricted access, without claims.
+	ctx, err = authFunc(withTokenMetadata("bearer somepresharedkey"))
+	require.NoError(t, err)
+	_, ok = ClaimsFromContext(ctx)
+	require.False(t, ok)
+
+	_, err = authFunc(withTokenMetadata("bearer someotherkey"))
+	grpcutil.RequireStatus(t, codes.Unauthenticated, err)
+
+	_, err = authFunc(withTokenMetadata("bearer "))
+	grpcutil.RequireStatus(t, codes.Unauthenticated, err)
+}
+
+func TestScopes(t *testing.T) {
+	scopes := ParseScopes([]string{"openid", "schema:*", "*:read", "prefix:tenant1/", "prefix:shared", "prefix:", "prefix:/"})
+	require.True(t, scopes.Allows(AreaSchema, AccessRead))
+	require.True(t, scopes.Allows(AreaSchema, AccessWrite))
+	require.True(t, scopes.Allows(AreaRelationships, AccessRead))
+	require.False(t, scopes.Allows(AreaRelationships, AccessWrite))
+
+	require.True(t, scopes.IsPrefixRestricted())
+	require.True(t, scopes.AllowsObjectType("tenant1/document"))
+	require.True(t, scopes.AllowsObjectType("shared/user"))
+	require.False(t, scopes.AllowsObjectType("tenant2/document"))
+	require.False(t, scopes.AllowsObjectType("tenant10/document"))
+	require.False(t, scopes.AllowsObjectType("shared_other/user"))
+	require.False(t, scopes.AllowsObjectType("tenant1"))
+	require.Equal(t, []string{"tenant1/", "shared/"}, scopes.Prefixes())
+
+	all := ParseScopes([]string{"*"})
+	require.True(t, all.Allows(AreaRelationships, AccessWrite))
+	require.False(t, all.IsPrefixRestricted())
+	require.True(t, all.AllowsObjectType("anything"))
+
+	require.False(t, ParseScopes(nil).Allows(AreaSchema, AccessRead))
+}
@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	grpcauth "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
+)
+
+const (
+	// AreaSchema is the area of scopes granting access to the schema.
+	AreaSchema = "schema"
+
+	// AreaRelationships is the area of scopes granting access to relationships, including the
+	// permissions computed from them.
+	AreaRelationships = "relationships"
+
+	// AccessRead is the access of scopes granting read access to an area.
+	AccessRead = "read"
+
+	// AccessWrite is the access of scopes granting write access to an area.
+	AccessWrite = "write"
+
+	scopeWildcard = "*"
+	prefixScope   = "prefix:"
+)
+
+// Scopes are the scopes granted to a caller by a token.
+//
+// Access is granted by scopes of the form `<area>:<access>`, e.g. `relationships:read` or
+// `schema:write`, where either part may be `*` to grant all areas or accesses. Scopes of the form
+// `prefix:<prefix>` restrict the caller to the object types under one of the prefixes, e.g.
+// `prefix:tenant1` or `prefix:tenant1/` grant access to `tenant1/document` but not to
+// `tenant10/document`; callers without such scopes may access all object types. Other scopes are
+// ignored, as tokens commonly carry scopes for other services.
+type Scopes struct {
+	grants   map[string]struct{}
+	prefixes []string
+}
+
+// ParseScopes parses the scopes granted by a token.
+func ParseScopes(scopes []string) Scopes {
+	parsed := Scopes{grants: map[string]struct{}{}}
+	for _, scope := range scopes {
+		if prefix, ok := strings.CutPrefix(scope, prefixScope); ok {
+			// Prefixes are kept with their separator, so that they only match whole segments.
+			if prefix = strings.TrimSuffix(prefix, "/"); prefix != "" {
+				parsed.prefixes = append(parsed.prefixes, prefix+"/")
+			}
+			continue
+		}
+
+		if scope == scopeWildcard {
+			scope = scopeWildcard + ":" + scopeWildcard
+		}
+		parsed.grants[scope] = struct{}{}
+	}
+	return parsed
+}
+
+// Allows returns whether the scopes grant the given access to the given area.
+func (s Scopes) Allows(area string, access string) bool {
+	for _, grant := range []string{
+		area + ":" + access,
+		area + ":" + scopeWildcard,
+		scopeWildcard + ":" + access,
+		scopeWildcard + ":" + scopeWildcard,
+	} {
+		if _, ok := s.grants[grant]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPrefixRestricted returns whether the scopes restrict the object types which may be accessed.
+func (s Scopes) IsPrefixRestricted() bool {
+	return len(s.prefixes) > 0
+}
+
+// Prefixes returns the prefixes of the object types which may be accessed, if restricted, each
+// ending with the `/` separator.
+func (s Scopes) Prefixes() []string {
+	return s.prefixes
+}
+
+// AllowsObjectType returns whether the scopes grant access to the given object type.
+func (s Scopes) AllowsObjectType(objectType string) bool {
+	if !s.IsPrefixRestricted() {
+		return true
+	}
+
+	for _, prefix := range s.prefixes {
+		if strings.HasPrefix(objectType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Claims are the verified claims of the token with which a request was authenticated.
+type Claims struct {
+	// Subject identifies the caller.
+	Subject string
+
+	// Scopes are the scopes granted to the caller.
+	Scopes Scopes
+
+	// SchemaPrefix is the prefix of the schema definitions to which the caller is bound, if any.
+	SchemaPrefix string
+}
+
+type claimsKey struct{}
+
+// ContextWithClaims returns a context carrying the claims of an authenticated request.
+func ContextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims of the token with which a request was authenticated, if
+// it was authenticated with a token carrying claims rather than a preshared key.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
+
+// PrincipalFromContext returns an identifier of the caller of an authenticated request: the
+// subject of its token if it carries claims, or otherwise a hash of its bearer token, so that the
+// token itself is not retained.
+func PrincipalFromContext(ctx context.Context) string {
+	if claims, ok := ClaimsFromContext(ctx); ok && claims.Subject != "" {
+		return "subject:" + claims.Subject
+	}
+
+	token, _ := grpcauth.AuthFromMD(ctx, "bearer")
+	hashed := sha256.Sum256([]byte(token))
+	return "token:" + hex.EncodeToString(hashed[:])
+}
@@ -0,0 +1,373 @@
+// Package backup implements writing a consistent snapshot of the schema and relationships of a
+// datastore to a compressed, versioned file, and restoring such a file into a datastore.
+package backup
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/datastore"
+	dsoptions "github.com/authzed/spicedb/pkg/datastore/options"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// DefaultBatchSize is the default number of relationships read or written at a time.
+const DefaultBatchSize = 1000
+
+// Options are the options for a backup or restore.
+type Options struct {
+	// BatchSize is the number of relationships read or written at a time. Defaults to
+	// DefaultBatchSize.
+	BatchSize uint64
+
+	// Resume continues a previously interrupted backup or restore, instead of starting over.
+	Resume bool
+
+	// Progress, if set, is invoked with the total number of relationships processed after
+	// each batch.
+	Progress func(processed uint64)
+}
+
+func (o Options) batchSize() uint64 {
+	if o.BatchSize == 0 {
+		return DefaultBatchSize
+	}
+	return o.BatchSize
+}
+
+func (o Options) reportProgress(processed uint64) {
+	if o.Progress != nil {
+		o.Progress(processed)
+	}
+}
+
+// Backup writes the schema, its history and all relationships found in the datastore at its head
+// revision to the file at the given path, returning the header of the backup and the number of
+// relationships written.
+//
+// If resuming and the file contains an interrupted backup, the backup continues at the
+// revision at which it was started, which must still be within the GC window of the datastore.
+func Backup(ctx context.Context, ds datastore.Datastore, path string, opts Options) (Header, uint64, error) {
+	var resumed *resumeState
+	if opts.Resume {
+		var err error
+		resumed, err = loadResumeState(path)
+		if err != nil {
+			return Header{}, 0, err
+		}
+	}
+
+	if resumed != nil && resumed.complete {
+		log.Ctx(ctx).Info().Str("path", path).Msg("backup is already complete")
+		return resumed.header, resumed.count, nil
+	}
+
+	var (
+		file   *os.File
+		header Header
+		count  uint64
+		after  *core.RelationTuple
+		err    error
+	)
+	if resumed != nil {
+		header, count, after = resumed.header, resumed.count, resumed.last
+
+		file, err = os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return Header{}, 0, fmt.Errorf("unable to open backup: %w", err)
+		}
+
+		if err := file.Truncate(resumed.offset); err != nil {
+			_ = file.Close()
+			return Header{}, 0, fmt.Errorf("unable to truncate interrupted backup: %w", err)
+		}
+
+		if _, err := file.Seek(resumed.offset, io.SeekStart); err != nil {
+			_ = file.Close()
+			return Header{}, 0, fmt.Errorf("unable to truncate interrupted backup: %w", err)
+		}
+
+		log.Ctx(ctx).Info().Str("revision", header.Revision).Uint64("relationships", count).Msg("resuming backup")
+	} else {
+		file, err = os.Create(path)
+		if err != nil {
+			return Header{}, 0, fmt.Errorf("unable to create backup: %w", err)
+		}
+	}
+	defer file.Close()
+
+	mw := newMemberWriter(file)
+
+	var revision datastore.Revision
+	if resumed != nil {
+		revision, err = ds.RevisionFromString(header.Revision)
+		if err != nil {
+			return Header{}, 0, fmt.Errorf("unable to parse revision of interrupted backup: %w", err)
+		}
+
+		if err := ds.CheckRevision(ctx, revision); err != nil {
+			return Header{}, 0, fmt.Errorf("cannot resume backup at revision %s: %w", header.Revision, err)
+		}
+	} else {
+		revision, err = ds.HeadRevision(ctx)
+		if err != nil {
+			return Header{}, 0, fmt.Errorf("unable to compute head revision: %w", err)
+		}
+
+		header = Header{
+			Format:    FormatName,
+			Version:   FormatVersion,
+			Revision:  revision.String(),
+			CreatedAt: time.Now().UTC(),
+		}
+	}
+
+	reader := ds.SnapshotReader(revision)
+	namespaces, err := reader.ListAllNamespaces(ctx)
+	if err != nil {
+		return Header{}, 0, fmt.Errorf("unable to read namespaces: %w", err)
+	}
+
+	// Make sure the namespaces are always in a stable order, so that a backup can be resumed.
+	slices.SortFunc(namespaces, func(
+		lhs datastore.RevisionedDefinition[*core.NamespaceDefinition],
+		rhs datastore.RevisionedDefinition[*core.NamespaceDefinition],
+	) int {
+		return strings.Compare(lhs.Definition.Name, rhs.Definition.Name)
+	})
+
+	if resumed == nil {
+		if err := writeSchema(ctx, mw, reader, header, namespaces); err != nil {
+			return Header{}, 0, err
+		}
+	}
+
+	limit := opts.batchSize()
+	for _, ns := range namespaces {
+		if after != nil && ns.Definition.Name < after.ResourceAndRelation.Namespace {
+			continue
+		}
+
+		cursor := after
+		if cursor != nil && cursor.ResourceAndRelation.Namespace != ns.Definition.Name {
+			cursor = nil
+		}
+
+		for {
+			written, last, err := writeRelationshipBatch(ctx, mw, reader, ns.Definition.Name, cursor, limit)
+			if err != nil {
+				return Header{}, 0, err
+			}
+
+			if err := mw.endMember(); err != nil {
+				return Header{}, 0, fmt.Errorf("unable to write backup: %w", err)
+			}
+
+			count += written
+			opts.reportProgress(count)
+
+			if written < limit {
+				break
+			}
+			cursor = last
+		}
+	}
+
+	trailer := binary.AppendUvarint(nil, count)
+	if err := mw.write(kindTrailer, trailer); err != nil {
+		return Header{}, 0, fmt.Errorf("unable to write backup: %w", err)
+	}
+
+	if err := mw.endMember(); err != nil {
+		return Header{}, 0, fmt.Errorf("unable to write backup: %w", err)
+	}
+
+	return header, count, file.Close()
+}
+
+func writeSchema(
+	ctx context.Context,
+	mw *memberWriter,
+	reader datastore.Reader,
+	header Header,
+	namespaces []datastore.RevisionedDefinition[*core.NamespaceDefinition],
+) error {
+	encodedHeader, err := encodeHeader(header)
+	if err != nil {
+		return err
+	}
+
+	if err := mw.write(kindHeader, encodedHeader); err != nil {
+		return fmt.Errorf("unable to write backup: %w", err)
+	}
+
+	for _, ns := range namespaces {
+		serialized, err := ns.Definition.MarshalVT()
+		if err != nil {
+			return fmt.Errorf("unable to serialize namespace %s: %w", ns.Definition.Name, err)
+		}
+
+		if err := mw.write(kindNamespace, serialized); err != nil {
+			return fmt.Errorf("unable to write backup: %w", err)
+		}
+	}
+
+	caveats, err := reader.ListAllCaveats(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to read caveats: %w", err)
+	}
+
+	for _, caveat := range caveats {
+		serialized, err := caveat.Definition.MarshalVT()
+		if err != nil {
+			return fmt.Errorf("unable to serialize caveat %s: %w", caveat.Definition.Name, err)
+		}
+
+		if err := mw.write(kindCaveat, serialized); err != nil {
+			return fmt.Errorf("unable to write backup: %w", err)
+		}
+	}
+
+	versions, err := reader.ListSchemaVersions(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to read schema history: %w", err)
+	}
+
+	// The versions are listed most recent first, but are restored oldest first.
+	for i := len(versions) - 1; i >= 0; i-- {
+		encoded, err := json.Marshal(schemaVersionRecord{
+			Version: versions[i].Version,
+			Schema:  versions[i].Schema,
+			Author:  versions[i].Author,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to serialize version %d of the schema: %w", versions[i].Version, err)
+		}
+
+		if err := mw.write(kindSchemaVersion, encoded); err != nil {
+			return fmt.Errorf("unable to write backup: %w", err)
+		}
+	}
+
+	if err := mw.endMember(); err != nil {
+		return fmt.Errorf("unable to write backup: %w", err)
+	}
+
+	return nil
+}
+
+func writeRelationshipBatch(
+	ctx context.Context,
+	mw *memberWriter,
+	reader datastore.Reader,
+	resourceType string,
+	after *core.RelationTuple,
+	limit uint64,
+) (uint64, *core.RelationTuple, error) {
+	it, err := reader.QueryRelationships(
+		ctx,
+		datastore.RelationshipsFilter{ResourceType: resourceType},
+		dsoptions.WithLimit(&limit),
+		dsoptions.WithAfter(after),
+		dsoptions.WithSort(dsoptions.ByResource),
+	)
+	if err != nil {
+		return 0, nil, fmt.Errorf("unable to read relationships: %w", err)
+	}
+	defer it.Close()
+
+	var written uint64
+	var last *core.RelationTuple
+	for rel := it.Next(); rel != nil; rel = it.Next() {
+		serialized, err := rel.MarshalVT()
+		if err != nil {
+			return 0, nil, fmt.Errorf("unable to serialize relationship %s: %w", tuple.MustString(rel), err)
+		}
+
+		if err := mw.write(kindRelationship, serialized); err != nil {
+			return 0, nil, fmt.Errorf("unable to write backup: %w", err)
+		}
+
+		written++
+		last = rel.CloneVT()
+	}
+
+	if it.Err() != nil {
+		return 0, nil, fmt.Errorf("unable to read relationships: %w", it.Err())
+	}
+
+	return written, last, nil
+}
+
+// resumeState is the state of a previously started backup.
+type resumeState struct {
+	header   Header
+	offset   int64
+	count    uint64
+	last     *core.RelationTuple
+	complete bool
+}
+
+// loadResumeState reads the backup found at the given path, if any, returning the state from
+// which it can be resumed. Nil is returned if there is no backup from which to resume.
+func loadResumeState(path string) (*resumeState, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to open backup: %w", err)
+	}
+	defer file.Close()
+
+	var state *resumeState
+	offset, err := scanMembers(file, func(records []record) error {
+		if state == nil {
+			if len(records) == 0 {
+				return errors.New("not a backup file: missing header")
+			}
+
+			header, err := decodeHeader(records[0])
+			if err != nil {
+				return err
+			}
+
+			state = &resumeState{header: header}
+			records = records[1:]
+		}
+
+		for _, rec := range records {
+			switch rec.kind {
+			case kindRelationship:
+				last := &core.RelationTuple{}
+				if err := last.UnmarshalVT(rec.payload); err != nil {
+					return fmt.Errorf("unable to parse relationship: %w", err)
+				}
+				state.count++
+				state.last = last
+			case kindTrailer:
+				state.complete = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if state == nil {
+		return nil, nil
+	}
+
+	state.offset = offset
+	return state, nil
+}
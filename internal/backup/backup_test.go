@@ -0,0 +1,272 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/internal/testfixtures"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/datastore/options"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+func TestBackupRestore(t *testing.T) {
+	for _, batchSize := range []uint64{1, 3, DefaultBatchSize} {
+		batchSize := batchSize
+		t.Run(fmt.Sprintf("batch size %d", batchSize), func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "backup.spicedb")
+
+			source := newPopulatedDatastore(t)
+
+			var progress []uint64
+			header, backedUp, err := Backup(ctx, source, path, Options{
+				BatchSize: batchSize,
+				Progress:  func(processed uint64) { progress = append(progress, processed) },
+			})
+			require.NoError(err)
+			require.Equal(FormatName, header.Format)
+			require.Equal(FormatVersion, header.Version)
+			require.NotEmpty(progress)
+			require.Equal(backedUp, progress[len(progress)-1])
+
+			expectedRels := allRelationships(t, source)
+			require.Len(expectedRels, int(backedUp))
+
+			target := newEmptyDatastore(t)
+			restoredHeader, restored, err := Restore(ctx, target, path, Options{BatchSize: batchSize})
+			require.NoError(err)
+			require.Equal(header.Revision, restoredHeader.Revision)
+			require.Equal(backedUp, restored)
+
+			require.Equal(expectedRels, allRelationships(t, target))
+			require.Equal(allDefinitions(t, source), allDefinitions(t, target))
+			require.Equal(schemaHistory(t, source), schemaHistory(t, target))
+			require.NoFileExists(path + progressFileSuffix)
+		})
+	}
+}
+
+func TestRestoreIntoNonEmptyDatastore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.spicedb")
+
+	source := newPopulatedDatastore(t)
+	_, _, err := Backup(context.Background(), source, path, Options{})
+	require.NoError(t, err)
+
+	_, _, err = Restore(context.Background(), source, path, Options{})
+	require.ErrorContains(t, err, "already contains")
+}
+
+func TestRestoreInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.spicedb")
+	require.NoError(t, os.WriteFile(path, []byte("definitely not a backup"), 0o600))
+
+	_, _, err := Restore(context.Background(), newEmptyDatastore(t), path, Options{})
+	require.ErrorContains(t, err, "unable to read backup")
+}
+
+func TestResumeBackup(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	source := newPopulatedDatastore(t)
+
+	completePath := filepath.Join(dir, "complete.spicedb")
+	_, expected, err := Backup(ctx, source, completePath, Options{BatchSize: 2})
+	require.NoError(err)
+
+	contents, err := os.ReadFile(completePath)
+	require.NoError(err)
+
+	for _, truncateAt := range []int{0, 10, len(contents) / 3, len(contents) / 2, len(contents) - 5} {
+		path := filepath.Join(dir, "partial.spicedb")
+		require.NoError(os.WriteFile(path, contents[:truncateAt], 0o600))
+
+		_, backedUp, err := Backup(ctx, source, path, Options{BatchSize: 2, Resume: true})
+		require.NoError(err)
+		require.Equal(expected, backedUp)
+
+		target := newEmptyDatastore(t)
+		_, restored, err := Restore(ctx, target, path, Options{})
+		require.NoError(err)
+		require.Equal(expected, restored)
+		require.Equal(allRelationships(t, source), allRelationships(t, target))
+	}
+
+	// Resuming a complete backup does nothing.
+	_, backedUp, err := Backup(ctx, source, completePath, Options{Resume: true})
+	require.NoError(err)
+	require.Equal(expected, backedUp)
+}
+
+func TestResumeRestore(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "backup.spicedb")
+
+	source := newPopulatedDatastore(t)
+	_, expected, err := Backup(ctx, source, path, Options{})
+	require.NoError(err)
+
+	target := &failingDatastore{Datastore: newEmptyDatastore(t), remaining: 3}
+	_, _, err = Restore(ctx, target, path, Options{BatchSize: 4})
+	require.ErrorIs(err, errInjected)
+	require.FileExists(path + progressFileSuffix)
+
+	target.remaining = -1
+	_, restored, err := Restore(ctx, target, path, Options{BatchSize: 4, Resume: true})
+	require.NoError(err)
+	require.Equal(expected, restored)
+	require.Equal(allRelationships(t, source), allRelationships(t, target))
+	require.NoFileExists(path + progressFileSuffix)
+}
+
+func TestResumeRestoreAfterUnrecordedBatch(t *testing.T) {
+	for _, committed := range []int{0, 2} {
+		committed := committed
+		t.Run(fmt.Sprintf("%d batches recorded", committed), func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "backup.spicedb")
+
+			source := newPopulatedDatastore(t)
+			_, expected, err := Backup(ctx, source, path, Options{})
+			require.NoError(err)
+
+			// The next batch is written, but the restore is interrupted before its progress is
+			// recorded.
+			target := &failingDatastore{Datastore: newEmptyDatastore(t), remaining: committed, failAfterCommit: true}
+			_, _, err = Restore(ctx, target, path, Options{BatchSize: 4})
+			require.ErrorIs(err, errInjected)
+
+			target.remaining = -1
+			_, restored, err := Restore(ctx, target, path, Options{BatchSize: 4, Resume: true})
+			require.NoError(err)
+			require.Equal(expected, restored)
+			require.Equal(allRelationships(t, source), allRelationships(t, target))
+			require.Equal(schemaHistory(t, source), schemaHistory(t, target))
+		})
+	}
+}
+
+var errInjected = errors.New("injected failure")
+
+// failingDatastore fails all read-write transactions after the remaining number have succeeded,
+// after committing the first of them if failAfterCommit is set.
+type failingDatastore struct {
+	datastore.Datastore
+	remaining       int
+	failAfterCommit bool
+}
+
+func (fd *failingDatastore) ReadWriteTx(ctx context.Context, f datastore.TxUserFunc, opts ...options.RWTOptionsOption) (datastore.Revision, error) {
+	if fd.remaining == 0 {
+		if fd.failAfterCommit {
+			fd.failAfterCommit = false
+			if _, err := fd.Datastore.ReadWriteTx(ctx, f, opts...); err != nil {
+				return datastore.NoRevision, err
+			}
+		}
+		return datastore.NoRevision, errInjected
+	}
+	fd.remaining--
+	return fd.Datastore.ReadWriteTx(ctx, f, opts...)
+}
+
+func newEmptyDatastore(t *testing.T) datastore.Datastore {
+	ds, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ds.Close() })
+	return ds
+}
+
+func newPopulatedDatastore(t *testing.T) datastore.Datastore {
+	ds, _ := testfixtures.StandardDatastoreWithCaveatedData(newEmptyDatastore(t), require.New(t))
+
+	_, err := ds.ReadWriteTx(context.Background(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		for _, schema := range []string{"definition user {}", "definition user {}\n\ndefinition document {}"} {
+			if _, err := rwt.WriteSchemaVersion(ctx, schema, "someauthor"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return ds
+}
+
+// schemaHistory returns the versions of the schema found at the head revision of the datastore.
+func schemaHistory(t *testing.T, ds datastore.Datastore) []string {
+	ctx := context.Background()
+	headRevision, err := ds.HeadRevision(ctx)
+	require.NoError(t, err)
+
+	versions, err := ds.SnapshotReader(headRevision).ListSchemaVersions(ctx)
+	require.NoError(t, err)
+
+	found := make([]string, 0, len(versions))
+	for _, version := range versions {
+		found = append(found, fmt.Sprintf("%d %s %q", version.Version, version.Author, version.Schema))
+	}
+	return found
+}
+
+func allRelationships(t *testing.T, ds datastore.Datastore) []string {
+	ctx := context.Background()
+	headRevision, err := ds.HeadRevision(ctx)
+	require.NoError(t, err)
+
+	reader := ds.SnapshotReader(headRevision)
+	namespaces, err := reader.ListAllNamespaces(ctx)
+	require.NoError(t, err)
+
+	var found []string
+	for _, ns := range namespaces {
+		it, err := reader.QueryRelationships(ctx, datastore.RelationshipsFilter{ResourceType: ns.Definition.Name})
+		require.NoError(t, err)
+
+		for rel := it.Next(); rel != nil; rel = it.Next() {
+			found = append(found, tuple.MustString(rel))
+		}
+		require.NoError(t, it.Err())
+		it.Close()
+	}
+
+	sort.Strings(found)
+	return found
+}
+
+func allDefinitions(t *testing.T, ds datastore.Datastore) []string {
+	ctx := context.Background()
+	headRevision, err := ds.HeadRevision(ctx)
+	require.NoError(t, err)
+
+	reader := ds.SnapshotReader(headRevision)
+	namespaces, err := reader.ListAllNamespaces(ctx)
+	require.NoError(t, err)
+
+	caveats, err := reader.ListAllCaveats(ctx)
+	require.NoError(t, err)
+
+	var found []string
+	for _, ns := range namespaces {
+		found = append(found, ns.Definition.String())
+	}
+	for _, caveat := range caveats {
+		found = append(found, caveat.Definition.String())
+	}
+
+	sort.Strings(found)
+	return found
+}
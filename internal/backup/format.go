@@ -0,0 +1,273 @@
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// FormatName is the name of the backup format, recorded in the header of every backup.
+const FormatName = "spicedb-backup"
+
+// FormatVersion is the current version of the backup format. Backups of version 1, which do not
+// contain the history of the schema, can still be restored.
+const FormatVersion = 2
+
+// maxRecordSize is the maximum size of a single record, to guard against reading corrupt files.
+const maxRecordSize = 64 * 1024 * 1024
+
+// recordKind is the kind of a record found in a backup.
+//
+// A backup is a sequence of gzip members, each containing a sequence of records. A record is
+// a single byte kind, followed by the uvarint encoded length of its payload and the payload
+// itself. The first member always contains the header followed by the schema and its history;
+// each following member contains a batch of relationships, and the last record is always a
+// trailer. Since every batch is a complete gzip member, an interrupted backup can be resumed
+// after the last member which was fully written.
+type recordKind byte
+
+const (
+	// kindHeader is a JSON encoded Header.
+	kindHeader recordKind = iota + 1
+
+	// kindNamespace is a serialized core.NamespaceDefinition.
+	kindNamespace
+
+	// kindCaveat is a serialized core.CaveatDefinition.
+	kindCaveat
+
+	// kindRelationship is a serialized core.RelationTuple.
+	kindRelationship
+
+	// kindTrailer is the uvarint encoded total number of relationships in the backup.
+	kindTrailer
+
+	// kindSchemaVersion is a JSON encoded schemaVersionRecord. The versions of the schema are
+	// found in order, oldest first.
+	kindSchemaVersion
+)
+
+// Header is the header found at the start of every backup.
+type Header struct {
+	// Format is always FormatName.
+	Format string `json:"format"`
+
+	// Version is the version of the format used to write the backup.
+	Version int `json:"version"`
+
+	// Revision is the revision of the datastore at which the backup was taken.
+	Revision string `json:"revision"`
+
+	// CreatedAt is the time at which the backup was started.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (h Header) validate() error {
+	if h.Format != FormatName {
+		return fmt.Errorf("not a backup file: unexpected format %q", h.Format)
+	}
+
+	if h.Version < 1 || h.Version > FormatVersion {
+		return fmt.Errorf("unsupported backup format version %d; expected at most %d", h.Version, FormatVersion)
+	}
+
+	return nil
+}
+
+// schemaVersionRecord is a version of the schema found in a backup.
+type schemaVersionRecord struct {
+	Version uint64 `json:"version"`
+	Schema  string `json:"schema"`
+	Author  string `json:"author,omitempty"`
+}
+
+type record struct {
+	kind    recordKind
+	payload []byte
+}
+
+// memberWriter writes records to a backup file, with each batch of records compressed as a
+// separate gzip member.
+type memberWriter struct {
+	file *os.File
+	gz   *gzip.Writer
+	open bool
+	buf  [binary.MaxVarintLen64 + 1]byte
+}
+
+func newMemberWriter(file *os.File) *memberWriter {
+	return &memberWriter{file: file, gz: gzip.NewWriter(file)}
+}
+
+func (mw *memberWriter) write(kind recordKind, payload []byte) error {
+	if !mw.open {
+		mw.gz.Reset(mw.file)
+		mw.open = true
+	}
+
+	mw.buf[0] = byte(kind)
+	n := binary.PutUvarint(mw.buf[1:], uint64(len(payload)))
+	if _, err := mw.gz.Write(mw.buf[:n+1]); err != nil {
+		return err
+	}
+
+	_, err := mw.gz.Write(payload)
+	return err
+}
+
+// endMember completes the current gzip member and syncs it to disk.
+func (mw *memberWriter) endMember() error {
+	if !mw.open {
+		return nil
+	}
+
+	mw.open = false
+	if err := mw.gz.Close(); err != nil {
+		return err
+	}
+
+	return mw.file.Sync()
+}
+
+// countingReader counts the bytes read from the underlying reader. It implements io.ByteReader
+// so that gzip and flate do not read ahead of the end of a member.
+type countingReader struct {
+	r     *bufio.Reader
+	count int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.count += int64(n)
+	return n, err
+}
+
+func (cr *countingReader) ReadByte() (byte, error) {
+	b, err := cr.r.ReadByte()
+	if err == nil {
+		cr.count++
+	}
+	return b, err
+}
+
+// scanMembers reads the complete gzip members found in the reader, invoking the callback with
+// the records of each, and returns the offset just after the last complete member. A truncated
+// or corrupt member stops the scan without an error.
+func scanMembers(r io.Reader, onMember func(records []record) error) (int64, error) {
+	cr := &countingReader{r: bufio.NewReader(r)}
+
+	gz, err := gzip.NewReader(cr)
+	if err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, gzip.ErrHeader) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	var validOffset int64
+	for {
+		gz.Multistream(false)
+		decompressed, err := io.ReadAll(gz)
+		if err != nil {
+			return validOffset, nil
+		}
+
+		records, err := parseRecords(decompressed)
+		if err != nil {
+			return validOffset, nil
+		}
+
+		if err := onMember(records); err != nil {
+			return validOffset, err
+		}
+		validOffset = cr.count
+
+		if err := gz.Reset(cr); err != nil {
+			return validOffset, nil
+		}
+	}
+}
+
+func parseRecords(data []byte) ([]record, error) {
+	var records []record
+	for len(data) > 0 {
+		kind := recordKind(data[0])
+		length, n := binary.Uvarint(data[1:])
+		if n <= 0 || length > uint64(len(data)-1-n) {
+			return nil, errors.New("truncated record")
+		}
+
+		start := 1 + n
+		records = append(records, record{kind, data[start : start+int(length)]})
+		data = data[start+int(length):]
+	}
+	return records, nil
+}
+
+// recordReader reads the records of a complete backup.
+type recordReader struct {
+	r *bufio.Reader
+}
+
+func newRecordReader(r io.Reader) (*recordReader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read backup: %w", err)
+	}
+
+	return &recordReader{bufio.NewReader(gz)}, nil
+}
+
+// next returns the next record, or io.EOF if there are none.
+func (rr *recordReader) next() (record, error) {
+	kind, err := rr.r.ReadByte()
+	if err != nil {
+		return record{}, err
+	}
+
+	length, err := binary.ReadUvarint(rr.r)
+	if err != nil {
+		return record{}, unexpectedEOF(err)
+	}
+
+	if length > maxRecordSize {
+		return record{}, fmt.Errorf("record of size %d exceeds the maximum record size", length)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(rr.r, payload); err != nil {
+		return record{}, unexpectedEOF(err)
+	}
+
+	return record{recordKind(kind), payload}, nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func encodeHeader(header Header) ([]byte, error) {
+	return json.Marshal(header)
+}
+
+func decodeHeader(rec record) (Header, error) {
+	if rec.kind != kindHeader {
+		return Header{}, errors.New("not a backup file: missing header")
+	}
+
+	var header Header
+	if err := json.Unmarshal(rec.payload, &header); err != nil {
+		return Header{}, fmt.Errorf("not a backup file: %w", err)
+	}
+
+	return header, header.validate()
+}
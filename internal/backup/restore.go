@@ -0,0 +1,296 @@
+package backup
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/datastore"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// progressFileSuffix is the suffix of the file, next to the backup, in which the number of
+// relationships restored so far is recorded, so that an interrupted restore can be resumed.
+const progressFileSuffix = ".restore-progress"
+
+// Restore writes the schema, its history and the relationships found in the backup at the given
+// path into the datastore, which must be empty unless resuming, returning the header of the
+// backup and the number of relationships restored. The versions of the schema keep their
+// numbers, but are recorded at the revision at which they are restored.
+//
+// Relationships are written in batches, each in its own transaction; after each batch the
+// number of relationships restored is recorded next to the backup, so that an interrupted
+// restore can be resumed. As a batch may have been written without its progress being recorded,
+// the first batch written when resuming overwrites any relationships already written.
+func Restore(ctx context.Context, ds datastore.Datastore, path string, opts Options) (Header, uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Header{}, 0, fmt.Errorf("unable to open backup: %w", err)
+	}
+	defer file.Close()
+
+	rr, err := newRecordReader(file)
+	if err != nil {
+		return Header{}, 0, err
+	}
+
+	first, err := rr.next()
+	if err != nil {
+		return Header{}, 0, fmt.Errorf("not a backup file: %w", err)
+	}
+
+	header, err := decodeHeader(first)
+	if err != nil {
+		return Header{}, 0, err
+	}
+
+	progressPath := path + progressFileSuffix
+	var restored uint64
+	var resuming bool
+	if opts.Resume {
+		restored, resuming, err = readProgress(progressPath)
+		if err != nil {
+			return Header{}, 0, err
+		}
+	}
+
+	var (
+		namespaces []*core.NamespaceDefinition
+		caveats    []*core.CaveatDefinition
+		versions   []schemaVersionRecord
+		current    record
+	)
+	for {
+		current, err = rr.next()
+		if err != nil {
+			return Header{}, 0, fmt.Errorf("unable to read backup: %w", unexpectedEOF(err))
+		}
+
+		if current.kind == kindNamespace {
+			ns := &core.NamespaceDefinition{}
+			if err := ns.UnmarshalVT(current.payload); err != nil {
+				return Header{}, 0, fmt.Errorf("unable to parse namespace: %w", err)
+			}
+			namespaces = append(namespaces, ns)
+		} else if current.kind == kindCaveat {
+			caveat := &core.CaveatDefinition{}
+			if err := caveat.UnmarshalVT(current.payload); err != nil {
+				return Header{}, 0, fmt.Errorf("unable to parse caveat: %w", err)
+			}
+			caveats = append(caveats, caveat)
+		} else if current.kind == kindSchemaVersion {
+			var version schemaVersionRecord
+			if err := json.Unmarshal(current.payload, &version); err != nil {
+				return Header{}, 0, fmt.Errorf("unable to parse version of the schema: %w", err)
+			}
+			versions = append(versions, version)
+		} else {
+			break
+		}
+	}
+
+	// The schema is written in the same transaction as the first batch of relationships, so it
+	// was written if the datastore holds definitions when resuming.
+	writeSchema := true
+	if resuming {
+		definitionCount, err := countDefinitions(ctx, ds)
+		if err != nil {
+			return Header{}, 0, err
+		}
+
+		if definitionCount == 0 && restored > 0 {
+			return Header{}, 0, fmt.Errorf("cannot resume restore of %d relationships into a datastore without schema", restored)
+		}
+
+		writeSchema = definitionCount == 0
+		log.Ctx(ctx).Info().Uint64("relationships", restored).Msg("resuming restore")
+	} else {
+		if err := ensureEmpty(ctx, ds); err != nil {
+			return Header{}, 0, err
+		}
+
+		// Progress is recorded before anything is written, so that a restore interrupted before
+		// its first batch was recorded can be resumed.
+		if err := writeProgress(progressPath, 0); err != nil {
+			return Header{}, 0, err
+		}
+	}
+
+	touch := resuming
+	toSkip := restored
+	batch := make([]*core.RelationTuple, 0, opts.batchSize())
+	flush := func() error {
+		if len(batch) == 0 && !writeSchema {
+			return nil
+		}
+
+		_, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+			if writeSchema {
+				if err := rwt.WriteCaveats(ctx, caveats); err != nil {
+					return err
+				}
+
+				if err := rwt.WriteNamespaces(ctx, namespaces...); err != nil {
+					return err
+				}
+
+				for _, version := range versions {
+					written, err := rwt.WriteSchemaVersion(ctx, version.Schema, version.Author)
+					if err != nil {
+						return err
+					}
+
+					if written != version.Version {
+						return fmt.Errorf("version %d of the schema was restored as version %d", version.Version, written)
+					}
+				}
+			}
+
+			if len(batch) == 0 {
+				return nil
+			}
+
+			if touch {
+				mutations := make([]*core.RelationTupleUpdate, 0, len(batch))
+				for _, rel := range batch {
+					mutations = append(mutations, tuple.Touch(rel))
+				}
+				return rwt.WriteRelationships(ctx, mutations)
+			}
+
+			_, err := rwt.BulkLoad(ctx, datastore.NewSliceRelationshipSource(batch))
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("unable to write relationships: %w", err)
+		}
+
+		writeSchema = false
+		touch = false
+		restored += uint64(len(batch))
+		batch = batch[:0]
+
+		if err := writeProgress(progressPath, restored); err != nil {
+			return err
+		}
+
+		opts.reportProgress(restored)
+		return nil
+	}
+
+	for ; current.kind == kindRelationship; current, err = rr.next() {
+		if toSkip > 0 {
+			toSkip--
+			continue
+		}
+
+		rel := &core.RelationTuple{}
+		if err := rel.UnmarshalVT(current.payload); err != nil {
+			return Header{}, 0, fmt.Errorf("unable to parse relationship: %w", err)
+		}
+
+		batch = append(batch, rel)
+		if uint64(len(batch)) == opts.batchSize() {
+			if err := flush(); err != nil {
+				return Header{}, 0, err
+			}
+		}
+	}
+
+	if err != nil {
+		return Header{}, 0, fmt.Errorf("unable to read backup: %w", unexpectedEOF(err))
+	}
+
+	if current.kind != kindTrailer {
+		return Header{}, 0, fmt.Errorf("unexpected record of kind %d in backup", current.kind)
+	}
+
+	expected, n := binary.Uvarint(current.payload)
+	if n <= 0 {
+		return Header{}, 0, errors.New("unable to read backup: invalid trailer")
+	}
+
+	if err := flush(); err != nil {
+		return Header{}, 0, err
+	}
+
+	if restored != expected {
+		return Header{}, 0, fmt.Errorf("backup contains %d relationships, but %d were restored", expected, restored)
+	}
+
+	if _, err := rr.next(); !errors.Is(err, io.EOF) {
+		return Header{}, 0, errors.New("unexpected data after the end of the backup")
+	}
+
+	if err := os.Remove(progressPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Header{}, 0, fmt.Errorf("unable to remove restore progress: %w", err)
+	}
+
+	return header, restored, nil
+}
+
+func ensureEmpty(ctx context.Context, ds datastore.Datastore) error {
+	definitionCount, err := countDefinitions(ctx, ds)
+	if err != nil {
+		return err
+	}
+
+	if definitionCount > 0 {
+		return fmt.Errorf("cannot restore into a datastore which already contains %d definitions", definitionCount)
+	}
+
+	return nil
+}
+
+func countDefinitions(ctx context.Context, ds datastore.Datastore) (int, error) {
+	headRevision, err := ds.HeadRevision(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("unable to compute head revision: %w", err)
+	}
+
+	namespaces, err := ds.SnapshotReader(headRevision).ListAllNamespaces(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("unable to read namespaces: %w", err)
+	}
+
+	return len(namespaces), nil
+}
+
+// readProgress returns the number of relationships restored recorded at the given path, and
+// whether any progress was recorded.
+func readProgress(path string) (uint64, bool, error) {
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, fmt.Errorf("unable to read restore progress: %w", err)
+	}
+
+	restored, err := strconv.ParseUint(strings.TrimSpace(string(contents)), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("unable to parse restore progress in %s: %w", path, err)
+	}
+
+	return restored, true, nil
+}
+
+func writeProgress(path string, restored uint64) error {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(strconv.FormatUint(restored, 10)), 0o600); err != nil {
+		return fmt.Errorf("unable to write restore progress: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("unable to write restore progress: %w", err)
+	}
+
+	return nil
+}
@@ -29,6 +29,13 @@ var (
 		Help:      "The number of stale relationships deleted by the datastore garbage collection.",
 	})
 
+	gcExpiredRelationshipsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "spicedb",
+		Subsystem: "datastore",
+		Name:      "gc_expired_relationships_total",
+		Help:      "The number of expired relationships deleted by the datastore garbage collection.",
+	})
+
 	gcTransactionsCounter = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "spicedb",
 		Subsystem: "datastore",
@@ -58,6 +65,7 @@ func RegisterGCMetrics() error {
 	for _, metric := range []prometheus.Collector{
 		gcDurationHistogram,
 		gcRelationshipsCounter,
+		gcExpiredRelationshipsCounter,
 		gcTransactionsCounter,
 		gcNamespacesCounter,
 		gcFailureCounter,
@@ -81,19 +89,22 @@ type GarbageCollector interface {
 	Now(context.Context) (time.Time, error)
 	TxIDBefore(context.Context, time.Time) (datastore.Revision, error)
 	DeleteBeforeTx(ctx context.Context, txID datastore.Revision) (DeletionCounts, error)
+	DeleteExpiredRels(ctx context.Context, before time.Time) (int64, error)
 }
 
 // DeletionCounts tracks the amount of deletions that occurred when calling
-// DeleteBeforeTx.
+// DeleteBeforeTx and DeleteExpiredRels.
 type DeletionCounts struct {
-	Relationships int64
-	Transactions  int64
-	Namespaces    int64
+	Relationships        int64
+	ExpiredRelationships int64
+	Transactions         int64
+	Namespaces           int64
 }
 
 func (g DeletionCounts) MarshalZerologObject(e *zerolog.Event) {
 	e.
 		Int64("relationships", g.Relationships).
+		Int64("expiredRelationships", g.ExpiredRelationships).
 		Int64("transactions", g.Transactions).
 		Int64("namespaces", g.Namespaces)
 }
@@ -188,6 +199,13 @@ func RunGarbageCollection(gc GarbageCollector, window, timeout time.Duration) er
 		return fmt.Errorf("error deleting in gc: %w", err)
 	}
 
+	// Expired relationships are invisible to reads as soon as they expire, so they are
+	// removed once their expiration time falls outside of the GC window.
+	collected.ExpiredRelationships, err = gc.DeleteExpiredRels(ctx, now.Add(-1*window))
+	if err != nil {
+		return fmt.Errorf("error deleting expired relationships in gc: %w", err)
+	}
+
 	collectionDuration := time.Since(startTime)
 	log.Ctx(ctx).Info().
 		Stringer("highestTxID", watermark).
@@ -198,6 +216,7 @@ func RunGarbageCollection(gc GarbageCollector, window, timeout time.Duration) er
 
 	gcDurationHistogram.Observe(collectionDuration.Seconds())
 	gcRelationshipsCounter.Add(float64(collected.Relationships))
+	gcExpiredRelationshipsCounter.Add(float64(collected.ExpiredRelationships))
 	gcTransactionsCounter.Add(float64(collected.Transactions))
 	gcNamespacesCounter.Add(float64(collected.Namespaces))
 	gc.MarkGCCompleted()
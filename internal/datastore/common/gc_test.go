@@ -27,6 +27,7 @@ type fakeGC struct {
 
 type gcMetrics struct {
 	deleteBeforeTxCount   int
+	deleteExpiredCount    int
 	markedCompleteCount   int
 	resetGCCompletedCount int
 }
@@ -71,6 +72,15 @@ func (gc *fakeGC) DeleteBeforeTx(_ context.Context, rev datastore.Revision) (Del
 	return gc.deleter.DeleteBeforeTx(int64(revInt))
 }
 
+func (gc *fakeGC) DeleteExpiredRels(_ context.Context, _ time.Time) (int64, error) {
+	gc.lock.Lock()
+	defer gc.lock.Unlock()
+
+	gc.metrics.deleteExpiredCount++
+
+	return 0, nil
+}
+
 func (gc *fakeGC) HasGCRun() bool {
 	gc.lock.Lock()
 	defer gc.lock.Unlock()
@@ -200,3 +210,12 @@ func TestGCFailureBackoffReset(t *testing.T) {
 	// the GC enough time to run.
 	require.Greater(t, gc.GetMetrics().markedCompleteCount, 20, "Next interval was not reset with backoff")
 }
+
+func TestGCDeletesExpiredRelationships(t *testing.T) {
+	gc := newFakeGC(revisionErrorDeleter{})
+
+	require.NoError(t, RunGarbageCollection(&gc, 10*time.Second, 1*time.Minute))
+	require.Equal(t, 1, gc.GetMetrics().deleteBeforeTxCount)
+	require.Equal(t, 1, gc.GetMetrics().deleteExpiredCount)
+	require.Equal(t, 1, gc.GetMetrics().markedCompleteCount)
+}
@@ -3,8 +3,10 @@ package common
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/authzed/spicedb/pkg/datastore"
 	core "github.com/authzed/spicedb/pkg/proto/core/v1"
@@ -46,3 +48,22 @@ func ContextualizedCaveatFrom(name string, context map[string]any) (*core.Contex
 	}
 	return caveat, nil
 }
+
+// ExpirationFrom convenience method that converts an optional expiration time loaded from a
+// datastore into its proto form.
+func ExpirationFrom(expiration *time.Time) *timestamppb.Timestamp {
+	if expiration == nil {
+		return nil
+	}
+	return timestamppb.New(*expiration)
+}
+
+// ExpirationTimeOf returns the optional expiration time of the tuple in UTC, or nil if the
+// tuple does not expire.
+func ExpirationTimeOf(tpl *core.RelationTuple) *time.Time {
+	if tpl.OptionalExpirationTime == nil {
+		return nil
+	}
+	expiration := tpl.OptionalExpirationTime.AsTime().UTC()
+	return &expiration
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"math"
 	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
@@ -73,6 +74,7 @@ type SchemaInformation struct {
 	colUsersetObjectID   string
 	colUsersetRelation   string
 	colCaveatName        string
+	colExpiration        string
 	paginationFilterType PaginationFilterType
 }
 
@@ -83,7 +85,8 @@ func NewSchemaInformation(
 	colUsersetNamespace,
 	colUsersetObjectID,
 	colUsersetRelation,
-	colCaveatName string,
+	colCaveatName,
+	colExpiration string,
 	paginationFilterType PaginationFilterType,
 ) SchemaInformation {
 	return SchemaInformation{
@@ -94,6 +97,7 @@ func NewSchemaInformation(
 		colUsersetObjectID,
 		colUsersetRelation,
 		colCaveatName,
+		colExpiration,
 		paginationFilterType,
 	}
 }
@@ -478,6 +482,16 @@ func (sqf SchemaQueryFilterer) FilterWithCaveatName(caveatName string) SchemaQue
 	return sqf
 }
 
+// FilterToUnexpired returns a new SchemaQueryFilterer which excludes any relationships
+// whose expiration time is at or before the given time.
+func (sqf SchemaQueryFilterer) FilterToUnexpired(now time.Time) SchemaQueryFilterer {
+	sqf.queryBuilder = sqf.queryBuilder.Where(sq.Or{
+		sq.Eq{sqf.schema.colExpiration: nil},
+		sq.Gt{sqf.schema.colExpiration: now},
+	})
+	return sqf
+}
+
 // Limit returns a new SchemaQueryFilterer which is limited to the specified number of results.
 func (sqf SchemaQueryFilterer) limit(limit uint64) SchemaQueryFilterer {
 	sqf.queryBuilder = sqf.queryBuilder.Limit(limit)
@@ -498,7 +512,7 @@ func (tqs QueryExecutor) ExecuteQuery(
 ) (datastore.RelationshipIterator, error) {
 	queryOpts := options.NewQueryOptionsWithOptions(opts...)
 
-	query = query.TupleOrder(queryOpts.Sort)
+	query = query.FilterToUnexpired(time.Now().UTC()).TupleOrder(queryOpts.Sort)
 
 	if queryOpts.After != nil {
 		if queryOpts.Sort == options.Unsorted {
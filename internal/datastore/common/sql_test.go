@@ -2,6 +2,7 @@ package common
 
 import (
 	"testing"
+	"time"
 
 	"github.com/authzed/spicedb/pkg/datastore/options"
 
@@ -33,6 +34,15 @@ func TestSchemaQueryFilterer(t *testing.T) {
 				"relation": 1,
 			},
 		},
+		{
+			"unexpired filter",
+			func(filterer SchemaQueryFilterer) SchemaQueryFilterer {
+				return filterer.FilterToUnexpired(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			},
+			"SELECT * WHERE (expiration IS NULL OR expiration > ?)",
+			[]any{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			map[string]int{},
+		},
 		{
 			"resource ID filter",
 			func(filterer SchemaQueryFilterer) SchemaQueryFilterer {
@@ -617,6 +627,7 @@ func TestSchemaQueryFilterer(t *testing.T) {
 				"subject_object_id",
 				"subject_relation",
 				"caveat",
+				"expiration",
 				TupleComparison,
 			)
 			filterer := NewSchemaQueryFilterer(schema, base)
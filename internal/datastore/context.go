@@ -109,6 +109,14 @@ func (r *ctxReader) LookupCaveatsWithNames(ctx context.Context, caveatNames []st
 	return r.delegate.LookupCaveatsWithNames(SeparateContextWithTracing(ctx), caveatNames)
 }
 
+func (r *ctxReader) ListSchemaVersions(ctx context.Context) ([]datastore.SchemaVersion, error) {
+	return r.delegate.ListSchemaVersions(SeparateContextWithTracing(ctx))
+}
+
+func (r *ctxReader) ReadSchemaVersion(ctx context.Context, version uint64) (datastore.SchemaVersion, error) {
+	return r.delegate.ReadSchemaVersion(SeparateContextWithTracing(ctx), version)
+}
+
 func (r *ctxReader) ListAllNamespaces(ctx context.Context) ([]datastore.RevisionedNamespace, error) {
 	return r.delegate.ListAllNamespaces(SeparateContextWithTracing(ctx))
 }
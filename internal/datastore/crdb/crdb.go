@@ -45,11 +45,12 @@ var (
 )
 
 const (
-	Engine            = "cockroachdb"
-	tableNamespace    = "namespace_config"
-	tableTuple        = "relation_tuple"
-	tableTransactions = "transactions"
-	tableCaveat       = "caveat"
+	Engine             = "cockroachdb"
+	tableNamespace     = "namespace_config"
+	tableTuple         = "relation_tuple"
+	tableTransactions  = "transactions"
+	tableCaveat        = "caveat"
+	tableSchemaVersion = "schema_version"
 
 	colNamespace         = "namespace"
 	colConfig            = "serialized_config"
@@ -64,6 +65,10 @@ const (
 	colCaveatDefinition  = "definition"
 	colCaveatContextName = "caveat_name"
 	colCaveatContext     = "caveat_context"
+	colExpiration        = "expiration"
+	colVersion           = "version"
+	colSchema            = "schema_text"
+	colAuthor            = "author"
 
 	errUnableToInstantiate = "unable to instantiate datastore"
 	errRevision            = "unable to find revision: %w"
@@ -464,6 +469,8 @@ func (cds *crdbDatastore) features(ctx context.Context) (*datastore.Features, er
 
 	<-streamCtx.Done()
 
+	// Schema changes are reported by the same changefeeds as relationship changes.
+	features.WatchSchema = features.Watch
 	return &features, nil
 }
 
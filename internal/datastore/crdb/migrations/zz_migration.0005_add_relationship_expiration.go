@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	addRelationshipExpiration = `ALTER TABLE relation_tuple
+		ADD COLUMN expiration TIMESTAMPTZ;`
+
+	// Expired relationships are hidden from reads immediately; the row-level TTL job
+	// removes them from storage in the background.
+	addRelationshipExpirationTTL = `ALTER TABLE relation_tuple
+		SET (ttl_expiration_expression = 'expiration');`
+)
+
+func init() {
+	err := CRDBMigrations.Register("add-relationship-expiration", "add-caveats", addRelationshipExpirationFunc, noAtomicMigration)
+	if err != nil {
+		panic("failed to register migration: " + err.Error())
+	}
+}
+
+func addRelationshipExpirationFunc(ctx context.Context, conn *pgx.Conn) error {
+	if _, err := conn.Exec(ctx, addRelationshipExpiration); err != nil {
+		return err
+	}
+	if _, err := conn.Exec(ctx, addRelationshipExpirationTTL); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const createSchemaVersionTable = `CREATE TABLE schema_version (
+	version INT8 NOT NULL,
+	schema_text STRING NOT NULL,
+	author VARCHAR NOT NULL DEFAULT '',
+	timestamp TIMESTAMP WITHOUT TIME ZONE DEFAULT now() NOT NULL,
+	CONSTRAINT pk_schema_version PRIMARY KEY (version)
+);`
+
+func init() {
+	err := CRDBMigrations.Register("add-schema-versions", "add-relationship-expiration", addSchemaVersionsFunc, noAtomicMigration)
+	if err != nil {
+		panic("failed to register migration: " + err.Error())
+	}
+}
+
+func addSchemaVersionsFunc(ctx context.Context, conn *pgx.Conn) error {
+	if _, err := conn.Exec(ctx, createSchemaVersionTable); err != nil {
+		return err
+	}
+	return nil
+}
@@ -34,6 +34,7 @@ var (
 		colUsersetRelation,
 		colCaveatContextName,
 		colCaveatContext,
+		colExpiration,
 	)
 
 	schema = common.NewSchemaInformation(
@@ -44,6 +45,7 @@ var (
 		colUsersetObjectID,
 		colUsersetRelation,
 		colCaveatContextName,
+		colExpiration,
 		common.ExpandedLogicComparison,
 	)
 )
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
@@ -11,6 +12,7 @@ import (
 	"github.com/jzelinskie/stringz"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/authzed/spicedb/internal/datastore/common"
 	pgxcommon "github.com/authzed/spicedb/internal/datastore/postgres/common"
 	log "github.com/authzed/spicedb/internal/logging"
 	"github.com/authzed/spicedb/pkg/datastore"
@@ -47,7 +49,7 @@ type crdbReadWriteTXN struct {
 
 var (
 	upsertTupleSuffix = fmt.Sprintf(
-		"ON CONFLICT (%s,%s,%s,%s,%s,%s) DO UPDATE SET %s = now(), %s = excluded.%s, %s = excluded.%s WHERE (relation_tuple.%s <> excluded.%s OR relation_tuple.%s <> excluded.%s)",
+		"ON CONFLICT (%s,%s,%s,%s,%s,%s) DO UPDATE SET %s = now(), %s = excluded.%s, %s = excluded.%s, %s = excluded.%s WHERE (relation_tuple.%s <> excluded.%s OR relation_tuple.%s <> excluded.%s OR relation_tuple.%s IS DISTINCT FROM excluded.%s)",
 		colNamespace,
 		colObjectID,
 		colRelation,
@@ -59,10 +61,14 @@ var (
 		colCaveatContextName,
 		colCaveatContext,
 		colCaveatContext,
+		colExpiration,
+		colExpiration,
 		colCaveatContextName,
 		colCaveatContextName,
 		colCaveatContext,
 		colCaveatContext,
+		colExpiration,
+		colExpiration,
 	)
 
 	queryWriteTuple = psql.Insert(tableTuple).Columns(
@@ -74,6 +80,7 @@ var (
 		colUsersetRelation,
 		colCaveatContextName,
 		colCaveatContext,
+		colExpiration,
 	)
 
 	queryTouchTuple = queryWriteTuple.Suffix(upsertTupleSuffix)
@@ -96,6 +103,9 @@ func (rwt *crdbReadWriteTXN) WriteRelationships(ctx context.Context, mutations [
 	bulkTouch := queryTouchTuple
 	var bulkTouchCount int64
 
+	expiredClauses := sq.Or{}
+	now := time.Now().UTC()
+
 	// Process the actual updates
 	for _, mutation := range mutations {
 		rel := mutation.Tuple
@@ -122,6 +132,7 @@ func (rwt *crdbReadWriteTXN) WriteRelationships(ctx context.Context, mutations [
 				rel.Subject.Relation,
 				caveatName,
 				caveatContext,
+				common.ExpirationTimeOf(rel),
 			)
 			bulkTouchCount++
 		case core.RelationTupleUpdate_CREATE:
@@ -135,8 +146,10 @@ func (rwt *crdbReadWriteTXN) WriteRelationships(ctx context.Context, mutations [
 				rel.Subject.Relation,
 				caveatName,
 				caveatContext,
+				common.ExpirationTimeOf(rel),
 			)
 			bulkWriteCount++
+			expiredClauses = append(expiredClauses, sq.And{exactRelationshipClause(rel), sq.LtOrEq{colExpiration: now}})
 		case core.RelationTupleUpdate_DELETE:
 			rwt.relCountChange--
 			sql, args, err := queryDeleteTuples.Where(exactRelationshipClause(rel)).ToSql()
@@ -153,6 +166,21 @@ func (rwt *crdbReadWriteTXN) WriteRelationships(ctx context.Context, mutations [
 		}
 	}
 
+	// Delete the expired relationships not yet garbage collected which are replaced by CREATE
+	// operations, as they would otherwise conflict.
+	if len(expiredClauses) > 0 {
+		sql, args, err := queryDeleteTuples.Where(expiredClauses).ToSql()
+		if err != nil {
+			return fmt.Errorf(errUnableToWriteRelationships, err)
+		}
+
+		deleted, err := rwt.tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return fmt.Errorf(errUnableToWriteRelationships, err)
+		}
+		rwt.relCountChange -= deleted.RowsAffected()
+	}
+
 	bulkUpdateQueries := make([]sq.InsertBuilder, 0, 2)
 	if bulkWriteCount > 0 {
 		bulkUpdateQueries = append(bulkUpdateQueries, bulkWrite)
@@ -304,6 +332,7 @@ var copyCols = []string{
 	colUsersetRelation,
 	colCaveatContextName,
 	colCaveatContext,
+	colExpiration,
 }
 
 func (rwt *crdbReadWriteTXN) BulkLoad(ctx context.Context, iter datastore.BulkWriteRelationshipSource) (uint64, error) {
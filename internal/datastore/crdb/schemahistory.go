@@ -0,0 +1,97 @@
+package crdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/pkg/datastore"
+)
+
+var (
+	writeSchemaVersion  = psql.Insert(tableSchemaVersion).Columns(colVersion, colSchema, colAuthor)
+	readSchemaVersion   = psql.Select(colVersion, colSchema, colAuthor, colTimestamp)
+	latestSchemaVersion = psql.Select("COALESCE(MAX(" + colVersion + "), 0)").From(tableSchemaVersion)
+)
+
+const (
+	errListSchemaVersions = "unable to list schema versions: %w"
+	errReadSchemaVersion  = "unable to read schema version `%d`: %w"
+	errWriteSchemaVersion = "unable to write schema version: %w"
+)
+
+func (cr *crdbReader) ListSchemaVersions(ctx context.Context) ([]datastore.SchemaVersion, error) {
+	sql, args, err := cr.fromBuilder(readSchemaVersion, tableSchemaVersion).OrderBy(colVersion + " DESC").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf(errListSchemaVersions, err)
+	}
+
+	var versions []datastore.SchemaVersion
+	err = cr.query.QueryFunc(ctx, func(ctx context.Context, rows pgx.Rows) error {
+		for rows.Next() {
+			var version datastore.SchemaVersion
+			var timestamp time.Time
+			if err := rows.Scan(&version.Version, &version.Schema, &version.Author, &timestamp); err != nil {
+				return fmt.Errorf(errListSchemaVersions, err)
+			}
+			version.Revision = revisions.NewHLCForTime(timestamp)
+			versions = append(versions, version)
+		}
+		return rows.Err()
+	}, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf(errListSchemaVersions, err)
+	}
+
+	return versions, nil
+}
+
+func (cr *crdbReader) ReadSchemaVersion(ctx context.Context, version uint64) (datastore.SchemaVersion, error) {
+	sql, args, err := cr.fromBuilder(readSchemaVersion, tableSchemaVersion).Where(sq.Eq{colVersion: version}).ToSql()
+	if err != nil {
+		return datastore.SchemaVersion{}, fmt.Errorf(errReadSchemaVersion, version, err)
+	}
+
+	var found datastore.SchemaVersion
+	var timestamp time.Time
+	err = cr.query.QueryRowFunc(ctx, func(ctx context.Context, row pgx.Row) error {
+		return row.Scan(&found.Version, &found.Schema, &found.Author, &timestamp)
+	}, sql, args...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = datastore.NewSchemaVersionNotFoundErr(version)
+		}
+		return datastore.SchemaVersion{}, fmt.Errorf(errReadSchemaVersion, version, err)
+	}
+
+	found.Revision = revisions.NewHLCForTime(timestamp)
+	return found, nil
+}
+
+func (rwt *crdbReadWriteTXN) WriteSchemaVersion(ctx context.Context, schema string, author string) (uint64, error) {
+	sql, args, err := latestSchemaVersion.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf(errWriteSchemaVersion, err)
+	}
+
+	var latest uint64
+	if err := rwt.tx.QueryRow(ctx, sql, args...).Scan(&latest); err != nil {
+		return 0, fmt.Errorf(errWriteSchemaVersion, err)
+	}
+
+	next := latest + 1
+	sql, args, err = writeSchemaVersion.Values(next, schema, author).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf(errWriteSchemaVersion, err)
+	}
+
+	if _, err := rwt.tx.Exec(ctx, sql, args...); err != nil {
+		return 0, fmt.Errorf(errWriteSchemaVersion, err)
+	}
+	return next, nil
+}
@@ -50,6 +50,7 @@ type changeDetails struct {
 
 		RelationshipCaveatContext map[string]any `json:"caveat_context"`
 		RelationshipCaveatName    string         `json:"caveat_name"`
+		RelationshipExpiration    *time.Time     `json:"expiration"`
 	}
 }
 
@@ -238,10 +239,14 @@ func (cds *crdbDatastore) watch(
 		case tableTuple:
 			var caveatName string
 			var caveatContext map[string]any
+			var expiration *time.Time
 			if details.After != nil && details.After.RelationshipCaveatName != "" {
 				caveatName = details.After.RelationshipCaveatName
 				caveatContext = details.After.RelationshipCaveatContext
 			}
+			if details.After != nil {
+				expiration = details.After.RelationshipExpiration
+			}
 			ctxCaveat, err := common.ContextualizedCaveatFrom(caveatName, caveatContext)
 			if err != nil {
 				sendError(err)
@@ -259,7 +264,8 @@ func (cds *crdbDatastore) watch(
 					ObjectId:  pkValues[4],
 					Relation:  pkValues[5],
 				},
-				Caveat: ctxCaveat,
+				Caveat:                 ctxCaveat,
+				OptionalExpirationTime: common.ExpirationFrom(expiration),
 			}
 
 			rev, err := revisions.HLCRevisionFromString(details.Updated)
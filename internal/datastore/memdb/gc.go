@@ -0,0 +1,82 @@
+package memdb
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+)
+
+// collectGarbage drops the revision snapshots and changelog entries which have fallen outside
+// of the GC window, along with any expired relationships.
+func (mdb *memdbDatastore) collectGarbage() error {
+	mdb.Lock()
+	defer mdb.Unlock()
+
+	if mdb.db == nil {
+		return nil
+	}
+
+	now := time.Now()
+	oldest := revisions.NewForTimestamp(now.UnixNano() + mdb.negativeGCWindow)
+
+	// Reads are always served from the first snapshot at or after the requested revision, and
+	// revisions outside of the GC window are rejected, so every snapshot before the window can
+	// be dropped. The head snapshot is always kept.
+	firstKept := sort.Search(len(mdb.revisions)-1, func(i int) bool {
+		return !mdb.revisions[i].revision.LessThan(oldest)
+	})
+	if firstKept > 0 {
+		mdb.revisions = append([]snapshot(nil), mdb.revisions[firstKept:]...)
+	}
+
+	// The changelog and relationships can only be modified when no write transaction is in
+	// progress; otherwise they are collected on the next pass.
+	if mdb.activeWriteTxn != nil {
+		return nil
+	}
+
+	txn := mdb.db.Txn(true)
+	defer txn.Abort()
+
+	var staleChanges []any
+	changeIt, err := txn.LowerBound(tableChangelog, indexRevision, int64(0))
+	if err != nil {
+		return fmt.Errorf("unable to read changelog: %w", err)
+	}
+
+	for found := changeIt.Next(); found != nil; found = changeIt.Next() {
+		if found.(*changelog).revisionNanos >= oldest.TimestampNanoSec() {
+			break
+		}
+		staleChanges = append(staleChanges, found)
+	}
+
+	var expiredRels []any
+	relIt, err := txn.LowerBound(tableRelationship, indexID)
+	if err != nil {
+		return fmt.Errorf("unable to read relationships: %w", err)
+	}
+
+	for found := relIt.Next(); found != nil; found = relIt.Next() {
+		if found.(*relationship).isExpired(now) {
+			expiredRels = append(expiredRels, found)
+		}
+	}
+
+	for _, change := range staleChanges {
+		if err := txn.Delete(tableChangelog, change); err != nil {
+			return fmt.Errorf("unable to delete changelog entry: %w", err)
+		}
+	}
+
+	for _, rel := range expiredRels {
+		if err := txn.Delete(tableRelationship, rel); err != nil {
+			return fmt.Errorf("unable to delete expired relationship: %w", err)
+		}
+	}
+
+	txn.Commit()
+	return nil
+}
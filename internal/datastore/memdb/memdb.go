@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/authzed/spicedb/internal/datastore/common"
+	log "github.com/authzed/spicedb/internal/logging"
 	"github.com/authzed/spicedb/pkg/spiceerrors"
 
 	"github.com/google/uuid"
@@ -36,11 +37,17 @@ const DisableGC = time.Duration(math.MaxInt64)
 // NewMemdbDatastore creates a new Datastore compliant datastore backed by memdb.
 //
 // If the watchBufferLength value of 0 is set then a default value of 128 will be used.
+//
+// If a snapshot path is provided and the file exists, the datastore is restored from it. If
+// the gcWindow is not DisableGC, revisions older than the window are periodically dropped.
 func NewMemdbDatastore(
 	watchBufferLength uint16,
 	revisionQuantization,
 	gcWindow time.Duration,
+	options ...Option,
 ) (datastore.Datastore, error) {
+	config := generateConfig(options)
+
 	if revisionQuantization > gcWindow {
 		return nil, errors.New("gc window must be larger than quantization interval")
 	}
@@ -59,14 +66,28 @@ func NewMemdbDatastore(
 	}
 
 	uniqueID := uuid.NewString()
-	return &memdbDatastore{
+	initialRevision := nowRevision()
+	if config.snapshotPath != "" {
+		restored, err := restoreSnapshot(config.snapshotPath, db)
+		if err != nil {
+			return nil, err
+		}
+
+		if restored != nil {
+			log.Info().Str("path", config.snapshotPath).Int("relationships", len(restored.Relationships)).Msg("restored memdb datastore from snapshot")
+			uniqueID = restored.UniqueID
+			initialRevision = revisions.NewForTimestamp(restored.Revision)
+		}
+	}
+
+	mdb := &memdbDatastore{
 		CommonDecoder: revisions.CommonDecoder{
 			Kind: revisions.Timestamp,
 		},
 		db: db,
 		revisions: []snapshot{
 			{
-				revision: nowRevision(),
+				revision: initialRevision,
 				db:       db,
 			},
 		},
@@ -76,7 +97,26 @@ func NewMemdbDatastore(
 		watchBufferLength:       watchBufferLength,
 		watchBufferWriteTimeout: 100 * time.Millisecond,
 		uniqueID:                uniqueID,
-	}, nil
+		snapshotPath:            config.snapshotPath,
+		stopBackground:          make(chan struct{}),
+		backgroundDone:          make(chan struct{}),
+	}
+
+	var snapshotInterval, gcInterval time.Duration
+	if config.snapshotPath != "" {
+		snapshotInterval = config.snapshotInterval
+	}
+	if gcWindow != DisableGC {
+		gcInterval = config.gcInterval
+	}
+
+	if snapshotInterval > 0 || gcInterval > 0 {
+		go mdb.runBackgroundTasks(snapshotInterval, gcInterval)
+	} else {
+		close(mdb.backgroundDone)
+	}
+
+	return mdb, nil
 }
 
 type memdbDatastore struct {
@@ -92,6 +132,11 @@ type memdbDatastore struct {
 	watchBufferLength       uint16
 	watchBufferWriteTimeout time.Duration
 	uniqueID                string
+	snapshotPath            string
+
+	stopBackground chan struct{}
+	backgroundDone chan struct{}
+	closeOnce      sync.Once
 }
 
 type snapshot struct {
@@ -297,15 +342,62 @@ func (mdb *memdbDatastore) ReadyState(_ context.Context) (datastore.ReadyState,
 }
 
 func (mdb *memdbDatastore) Features(_ context.Context) (*datastore.Features, error) {
-	return &datastore.Features{Watch: datastore.Feature{Enabled: true}}, nil
+	return &datastore.Features{
+		Watch:       datastore.Feature{Enabled: true},
+		WatchSchema: datastore.Feature{Enabled: true},
+	}, nil
+}
+
+// runBackgroundTasks periodically snapshots the datastore to disk and collects garbage, until
+// the datastore is closed. An interval of zero disables the corresponding task.
+func (mdb *memdbDatastore) runBackgroundTasks(snapshotInterval, gcInterval time.Duration) {
+	defer close(mdb.backgroundDone)
+
+	var snapshotTick, gcTick <-chan time.Time
+	if snapshotInterval > 0 {
+		snapshotTicker := time.NewTicker(snapshotInterval)
+		defer snapshotTicker.Stop()
+		snapshotTick = snapshotTicker.C
+	}
+	if gcInterval > 0 {
+		gcTicker := time.NewTicker(gcInterval)
+		defer gcTicker.Stop()
+		gcTick = gcTicker.C
+	}
+
+	for {
+		select {
+		case <-mdb.stopBackground:
+			return
+		case <-snapshotTick:
+			if err := mdb.writeSnapshot(); err != nil {
+				log.Warn().Err(err).Str("path", mdb.snapshotPath).Msg("error writing memdb snapshot")
+			}
+		case <-gcTick:
+			if err := mdb.collectGarbage(); err != nil {
+				log.Warn().Err(err).Msg("error collecting memdb garbage")
+			}
+		}
+	}
 }
 
 func (mdb *memdbDatastore) Close() error {
+	mdb.closeOnce.Do(func() {
+		close(mdb.stopBackground)
+		<-mdb.backgroundDone
+	})
+
 	mdb.Lock()
 	defer mdb.Unlock()
 
 	// TODO Make this nil once we have removed all access to closed datastores
 	if db := mdb.db; db != nil {
+		if mdb.snapshotPath != "" {
+			if err := persistSnapshot(mdb.snapshotPath, db.Snapshot(), mdb.headRevisionNoLock(), mdb.uniqueID); err != nil {
+				return err
+			}
+		}
+
 		mdb.revisions = []snapshot{
 			{
 				revision: nowRevision(),
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/authzed/spicedb/internal/datastore/common"
 	"github.com/authzed/spicedb/pkg/datastore"
 	"github.com/authzed/spicedb/pkg/datastore/options"
 	test "github.com/authzed/spicedb/pkg/datastore/test"
@@ -21,8 +23,8 @@ import (
 
 type memDBTest struct{}
 
-func (mdbt memDBTest) New(revisionQuantization, _, gcWindow time.Duration, watchBufferLength uint16) (datastore.Datastore, error) {
-	return NewMemdbDatastore(watchBufferLength, revisionQuantization, gcWindow)
+func (mdbt memDBTest) New(revisionQuantization, gcInterval, gcWindow time.Duration, watchBufferLength uint16) (datastore.Datastore, error) {
+	return NewMemdbDatastore(watchBufferLength, revisionQuantization, gcWindow, GCInterval(gcInterval))
 }
 
 func TestMemdbDatastore(t *testing.T) {
@@ -113,3 +115,136 @@ func TestConcurrentWriteRelsError(t *testing.T) {
 	require.Error(werr)
 	require.ErrorContains(werr, "serialization max retries exceeded")
 }
+
+func TestSnapshotRestore(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	snapshotPath := filepath.Join(t.TempDir(), "memdb.json")
+
+	ds, err := NewMemdbDatastore(0, 0, DisableGC, SnapshotPath(snapshotPath), SnapshotInterval(0))
+	require.NoError(err)
+
+	written, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		if err := rwt.WriteNamespaces(ctx, ns.Namespace("user"), ns.Namespace("document")); err != nil {
+			return err
+		}
+
+		if err := rwt.WriteCaveats(ctx, []*corev1.CaveatDefinition{{Name: "somecaveat"}}); err != nil {
+			return err
+		}
+
+		return rwt.WriteRelationships(ctx, []*corev1.RelationTupleUpdate{
+			tuple.Create(tuple.MustParse("document:first#viewer@user:tom")),
+			tuple.Create(tuple.MustParse("document:first#editor@user:sarah[somecaveat:{\"somecondition\":42}]")),
+			tuple.Create(tuple.MustParse("document:second#viewer@user:fred[expiration:2300-01-01T00:00:00Z]")),
+			tuple.Create(tuple.MustParse("document:expired#viewer@user:fred[expiration:2000-01-01T00:00:00Z]")),
+		})
+	})
+	require.NoError(err)
+
+	originalStats, err := ds.Statistics(ctx)
+	require.NoError(err)
+	require.NoError(ds.Close())
+
+	restored, err := NewMemdbDatastore(0, 0, DisableGC, SnapshotPath(snapshotPath), SnapshotInterval(0))
+	require.NoError(err)
+	t.Cleanup(func() { _ = restored.Close() })
+
+	head, err := restored.HeadRevision(ctx)
+	require.NoError(err)
+	require.True(head.Equal(written))
+
+	restoredStats, err := restored.Statistics(ctx)
+	require.NoError(err)
+	require.Equal(originalStats.UniqueID, restoredStats.UniqueID)
+
+	reader := restored.SnapshotReader(head)
+	namespaces, err := reader.ListAllNamespaces(ctx)
+	require.NoError(err)
+	require.Len(namespaces, 2)
+
+	_, _, err = reader.ReadCaveatByName(ctx, "somecaveat")
+	require.NoError(err)
+
+	it, err := reader.QueryRelationships(ctx, datastore.RelationshipsFilter{ResourceType: "document"})
+	require.NoError(err)
+	defer it.Close()
+
+	var found []string
+	for rel := it.Next(); rel != nil; rel = it.Next() {
+		found = append(found, tuple.MustString(rel))
+	}
+	require.NoError(it.Err())
+	require.ElementsMatch([]string{
+		"document:first#viewer@user:tom",
+		"document:first#editor@user:sarah[somecaveat:{\"somecondition\":42}]",
+		"document:second#viewer@user:fred[expiration:2300-01-01T00:00:00Z]",
+	}, found)
+
+	// Writes after the restore must be at a revision later than the restored head.
+	updated, err := restored.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteRelationships(ctx, []*corev1.RelationTupleUpdate{
+			tuple.Touch(tuple.MustParse("document:third#viewer@user:tom")),
+		})
+	})
+	require.NoError(err)
+	require.True(updated.GreaterThan(written))
+}
+
+func TestSnapshotRestoreMissingFile(t *testing.T) {
+	require := require.New(t)
+	snapshotPath := filepath.Join(t.TempDir(), "memdb.json")
+
+	ds, err := NewMemdbDatastore(0, 0, DisableGC, SnapshotPath(snapshotPath))
+	require.NoError(err)
+	require.NoFileExists(snapshotPath)
+
+	require.NoError(ds.Close())
+	require.FileExists(snapshotPath)
+
+	// Closing again must not overwrite the snapshot with an empty state.
+	require.NoError(ds.Close())
+	require.FileExists(snapshotPath)
+}
+
+func TestGarbageCollection(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	ds, err := NewMemdbDatastore(0, 0, 10*time.Millisecond, GCInterval(5*time.Millisecond))
+	require.NoError(err)
+	t.Cleanup(func() { _ = ds.Close() })
+
+	for i := 0; i < 10; i++ {
+		_, err := common.WriteTuples(ctx, ds, corev1.RelationTupleUpdate_TOUCH, tuple.MustParse(fmt.Sprintf("document:doc-%d#viewer@user:tom", i)))
+		require.NoError(err)
+	}
+
+	_, err = common.WriteTuples(ctx, ds, corev1.RelationTupleUpdate_TOUCH, tuple.MustParse("document:expiring#viewer@user:tom[expiration:2000-01-01T00:00:00Z]"))
+	require.NoError(err)
+
+	mdb := ds.(*memdbDatastore)
+	require.Eventually(func() bool {
+		mdb.RLock()
+		defer mdb.RUnlock()
+
+		txn := mdb.db.Txn(false)
+		defer txn.Abort()
+
+		changes, err := txn.LowerBound(tableChangelog, indexRevision, int64(0))
+		require.NoError(err)
+
+		return len(mdb.revisions) == 1 && changes.Next() == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	count, err := mdb.countRelationships(ctx)
+	require.NoError(err)
+	require.Equal(uint64(10), count)
+
+	head, err := ds.HeadRevision(ctx)
+	require.NoError(err)
+
+	namespaces, err := ds.SnapshotReader(head).ListAllNamespaces(ctx)
+	require.NoError(err)
+	require.Empty(namespaces)
+}
@@ -0,0 +1,60 @@
+package memdb
+
+import "time"
+
+const defaultSnapshotInterval = 1 * time.Minute
+
+type memdbOptions struct {
+	snapshotPath     string
+	snapshotInterval time.Duration
+	gcInterval       time.Duration
+}
+
+// Option provides the facility to configure optional behavior of the
+// memdb datastore.
+type Option func(*memdbOptions)
+
+func generateConfig(options []Option) memdbOptions {
+	computed := memdbOptions{
+		snapshotInterval: defaultSnapshotInterval,
+	}
+
+	for _, option := range options {
+		option(&computed)
+	}
+
+	return computed
+}
+
+// SnapshotPath is the path of the file to which the full state of the
+// datastore is written on shutdown and periodically, and from which it is
+// restored at startup if the file exists.
+//
+// Snapshots are disabled by default.
+func SnapshotPath(path string) Option {
+	return func(mo *memdbOptions) {
+		mo.snapshotPath = path
+	}
+}
+
+// SnapshotInterval is the interval at which the state of the datastore is
+// written to the snapshot path, if one is configured. A value of zero
+// disables periodic snapshots, writing only on shutdown.
+//
+// This value defaults to 1 minute.
+func SnapshotInterval(interval time.Duration) Option {
+	return func(mo *memdbOptions) {
+		mo.snapshotInterval = interval
+	}
+}
+
+// GCInterval is the interval at which revisions and changes older than the
+// GC window are dropped. Garbage collection never runs when the GC window is
+// DisableGC.
+//
+// Garbage collection is disabled by default.
+func GCInterval(interval time.Duration) Option {
+	return func(mo *memdbOptions) {
+		mo.gcInterval = interval
+	}
+}
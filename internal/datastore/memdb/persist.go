@@ -0,0 +1,277 @@
+package memdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/spiceerrors"
+)
+
+const snapshotFormatVersion = 1
+
+// persistedState is the on-disk representation of the full state of the
+// datastore at a single revision.
+//
+// NOTE: the changelog is not persisted, so a Watch can only be started at or
+// after the revision at which the snapshot was taken.
+type persistedState struct {
+	Version       int                     `json:"version"`
+	UniqueID      string                  `json:"unique_id"`
+	Revision      int64                   `json:"revision"`
+	Namespaces    []persistedDefinition   `json:"namespaces"`
+	Caveats       []persistedDefinition   `json:"caveats"`
+	Relationships []persistedRelationship `json:"relationships"`
+
+	SchemaVersions []persistedSchemaVersion `json:"schema_versions,omitempty"`
+}
+
+type persistedDefinition struct {
+	Name       string `json:"name"`
+	Definition []byte `json:"definition"`
+	Revision   int64  `json:"revision"`
+}
+
+type persistedSchemaVersion struct {
+	Version  uint64 `json:"version"`
+	Schema   string `json:"schema"`
+	Author   string `json:"author,omitempty"`
+	Revision int64  `json:"revision"`
+}
+
+type persistedRelationship struct {
+	Namespace        string         `json:"namespace"`
+	ResourceID       string         `json:"resource_id"`
+	Relation         string         `json:"relation"`
+	SubjectNamespace string         `json:"subject_namespace"`
+	SubjectObjectID  string         `json:"subject_object_id"`
+	SubjectRelation  string         `json:"subject_relation"`
+	CaveatName       string         `json:"caveat_name,omitempty"`
+	CaveatContext    map[string]any `json:"caveat_context,omitempty"`
+	Expiration       *time.Time     `json:"expiration,omitempty"`
+}
+
+// writeSnapshot writes the current state of the datastore to the configured
+// snapshot path, if any.
+func (mdb *memdbDatastore) writeSnapshot() error {
+	if mdb.snapshotPath == "" {
+		return nil
+	}
+
+	mdb.RLock()
+	if mdb.db == nil {
+		mdb.RUnlock()
+		return nil
+	}
+	db, revision := mdb.db.Snapshot(), mdb.headRevisionNoLock()
+	mdb.RUnlock()
+
+	return persistSnapshot(mdb.snapshotPath, db, revision, mdb.uniqueID)
+}
+
+// persistSnapshot serializes the contents of the given database, as of the given revision,
+// to the file at the given path. The file is replaced atomically, so that a failure while
+// writing never leaves a truncated snapshot behind.
+func persistSnapshot(path string, db *memdb.MemDB, revision revisions.TimestampRevision, uniqueID string) error {
+	state, err := loadPersistedState(db, revision, uniqueID)
+	if err != nil {
+		return err
+	}
+
+	serialized, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("unable to serialize memdb snapshot: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("unable to create memdb snapshot file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(serialized); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("unable to write memdb snapshot: %w", err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("unable to write memdb snapshot: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("unable to write memdb snapshot: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("unable to replace memdb snapshot: %w", err)
+	}
+
+	return nil
+}
+
+func loadPersistedState(db *memdb.MemDB, revision revisions.TimestampRevision, uniqueID string) (*persistedState, error) {
+	txn := db.Txn(false)
+	defer txn.Abort()
+
+	state := &persistedState{
+		Version:  snapshotFormatVersion,
+		UniqueID: uniqueID,
+		Revision: revision.TimestampNanoSec(),
+	}
+
+	nsIt, err := txn.LowerBound(tableNamespace, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read namespaces: %w", err)
+	}
+
+	for found := nsIt.Next(); found != nil; found = nsIt.Next() {
+		ns := found.(*namespace)
+		updated, err := timestampNanos(ns.updated)
+		if err != nil {
+			return nil, err
+		}
+
+		state.Namespaces = append(state.Namespaces, persistedDefinition{ns.name, ns.configBytes, updated})
+	}
+
+	caveatIt, err := txn.LowerBound(tableCaveats, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read caveats: %w", err)
+	}
+
+	for found := caveatIt.Next(); found != nil; found = caveatIt.Next() {
+		c := found.(*caveat)
+		updated, err := timestampNanos(c.revision)
+		if err != nil {
+			return nil, err
+		}
+
+		state.Caveats = append(state.Caveats, persistedDefinition{c.name, c.definition, updated})
+	}
+
+	versionIt, err := txn.LowerBound(tableSchemaVersions, indexID, uint64(0))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read schema versions: %w", err)
+	}
+
+	for found := versionIt.Next(); found != nil; found = versionIt.Next() {
+		sv := found.(*schemaVersion)
+		written, err := timestampNanos(sv.revision)
+		if err != nil {
+			return nil, err
+		}
+
+		state.SchemaVersions = append(state.SchemaVersions, persistedSchemaVersion{sv.version, sv.schema, sv.author, written})
+	}
+
+	relIt, err := txn.LowerBound(tableRelationship, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read relationships: %w", err)
+	}
+
+	now := time.Now()
+	for found := relIt.Next(); found != nil; found = relIt.Next() {
+		rel := found.(*relationship)
+		if rel.isExpired(now) {
+			continue
+		}
+
+		persisted := persistedRelationship{
+			Namespace:        rel.namespace,
+			ResourceID:       rel.resourceID,
+			Relation:         rel.relation,
+			SubjectNamespace: rel.subjectNamespace,
+			SubjectObjectID:  rel.subjectObjectID,
+			SubjectRelation:  rel.subjectRelation,
+			Expiration:       rel.expiration,
+		}
+		if rel.caveat != nil {
+			persisted.CaveatName = rel.caveat.caveatName
+			persisted.CaveatContext = rel.caveat.context
+		}
+
+		state.Relationships = append(state.Relationships, persisted)
+	}
+
+	return state, nil
+}
+
+// restoreSnapshot loads the state found in the snapshot file at the given path into the given
+// database, returning the persisted state. If the file does not exist, nil is returned.
+func restoreSnapshot(path string, db *memdb.MemDB) (*persistedState, error) {
+	serialized, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read memdb snapshot: %w", err)
+	}
+
+	state := &persistedState{}
+	if err := json.Unmarshal(serialized, state); err != nil {
+		return nil, fmt.Errorf("unable to parse memdb snapshot %s: %w", path, err)
+	}
+
+	if state.Version != snapshotFormatVersion {
+		return nil, fmt.Errorf("unsupported memdb snapshot version %d in %s", state.Version, path)
+	}
+
+	txn := db.Txn(true)
+	defer txn.Abort()
+
+	for _, ns := range state.Namespaces {
+		if err := txn.Insert(tableNamespace, &namespace{ns.Name, ns.Definition, revisions.NewForTimestamp(ns.Revision)}); err != nil {
+			return nil, fmt.Errorf("unable to restore namespace %s: %w", ns.Name, err)
+		}
+	}
+
+	for _, c := range state.Caveats {
+		if err := txn.Insert(tableCaveats, &caveat{c.Name, c.Definition, revisions.NewForTimestamp(c.Revision)}); err != nil {
+			return nil, fmt.Errorf("unable to restore caveat %s: %w", c.Name, err)
+		}
+	}
+
+	for _, sv := range state.SchemaVersions {
+		if err := txn.Insert(tableSchemaVersions, &schemaVersion{sv.Version, sv.Schema, sv.Author, revisions.NewForTimestamp(sv.Revision)}); err != nil {
+			return nil, fmt.Errorf("unable to restore schema version %d: %w", sv.Version, err)
+		}
+	}
+
+	for _, rel := range state.Relationships {
+		restored := &relationship{
+			namespace:        rel.Namespace,
+			resourceID:       rel.ResourceID,
+			relation:         rel.Relation,
+			subjectNamespace: rel.SubjectNamespace,
+			subjectObjectID:  rel.SubjectObjectID,
+			subjectRelation:  rel.SubjectRelation,
+			expiration:       rel.Expiration,
+		}
+		if rel.CaveatName != "" {
+			restored.caveat = &contextualizedCaveat{rel.CaveatName, rel.CaveatContext}
+		}
+
+		if err := txn.Insert(tableRelationship, restored); err != nil {
+			return nil, fmt.Errorf("unable to restore relationship %s: %w", restored, err)
+		}
+	}
+
+	txn.Commit()
+	return state, nil
+}
+
+func timestampNanos(rev datastore.Revision) (int64, error) {
+	timestamped, ok := rev.(revisions.TimestampRevision)
+	if !ok {
+		return 0, spiceerrors.MustBugf("unexpected revision type %T in memdb", rev)
+	}
+
+	return timestamped.TimestampNanoSec(), nil
+}
@@ -6,6 +6,7 @@ import (
 	"runtime"
 	"slices"
 	"sort"
+	"time"
 
 	"github.com/authzed/spicedb/pkg/spiceerrors"
 
@@ -284,10 +285,13 @@ func filterFuncForFilters(
 	optionalCaveatFilter string,
 	cursorFilter func(*relationship) bool,
 ) memdb.FilterFunc {
+	now := time.Now()
 	return func(tupleRaw interface{}) bool {
 		tuple := tupleRaw.(*relationship)
 
 		switch {
+		case tuple.isExpired(now):
+			return true
 		case optionalResourceType != "" && optionalResourceType != tuple.namespace:
 			return true
 		case len(optionalResourceIds) > 0 && !slices.Contains(optionalResourceIds, tuple.resourceID):
@@ -3,6 +3,7 @@ package memdb
 import (
 	"context"
 	"fmt"
+	"time"
 
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 	"github.com/hashicorp/go-memdb"
@@ -44,6 +45,7 @@ func (rwt *memdbReadWriteTx) write(tx *memdb.Txn, mutations ...*core.RelationTup
 			mutation.Tuple.Subject.ObjectId,
 			mutation.Tuple.Subject.Relation,
 			rwt.toCaveatReference(mutation),
+			rwt.toExpiration(mutation),
 		}
 
 		found, err := tx.First(
@@ -67,7 +69,8 @@ func (rwt *memdbReadWriteTx) write(tx *memdb.Txn, mutations ...*core.RelationTup
 
 		switch mutation.Operation {
 		case core.RelationTupleUpdate_CREATE:
-			if existing != nil {
+			// Expired relationships not yet garbage collected are absent, and so are replaced.
+			if existing != nil && !existing.isExpired(time.Now()) {
 				rt, err := existing.RelationTuple()
 				if err != nil {
 					return err
@@ -117,6 +120,15 @@ func (rwt *memdbReadWriteTx) toCaveatReference(mutation *core.RelationTupleUpdat
 	return cr
 }
 
+func (rwt *memdbReadWriteTx) toExpiration(mutation *core.RelationTupleUpdate) *time.Time {
+	if mutation.Tuple.OptionalExpirationTime == nil {
+		return nil
+	}
+
+	expiration := mutation.Tuple.OptionalExpirationTime.AsTime()
+	return &expiration
+}
+
 func (rwt *memdbReadWriteTx) DeleteRelationships(_ context.Context, filter *v1.RelationshipFilter) error {
 	rwt.mustLock()
 	defer rwt.Unlock()
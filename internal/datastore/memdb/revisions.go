@@ -3,6 +3,7 @@ package memdb
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/authzed/spicedb/internal/datastore/revisions"
@@ -83,6 +84,34 @@ func (mdb *memdbDatastore) CheckRevision(_ context.Context, dr datastore.Revisio
 	return mdb.checkRevisionLocalCallerMustLock(dr)
 }
 
+func (mdb *memdbDatastore) RevisionAsOf(_ context.Context, asOf time.Time) (datastore.Revision, error) {
+	mdb.RLock()
+	defer mdb.RUnlock()
+	if mdb.db == nil {
+		return nil, fmt.Errorf("datastore has been closed")
+	}
+
+	asOfRevision := revisions.NewForTime(asOf)
+	if asOfRevision.GreaterThan(nowRevision()) {
+		return datastore.NoRevision, datastore.NewInvalidRevisionErr(asOfRevision, datastore.CouldNotDetermineRevision)
+	}
+
+	// Snapshots are read at the first revision at or after the one requested, so the revision of
+	// the latest snapshot at or before the time is returned.
+	revIndex := sort.Search(len(mdb.revisions), func(i int) bool {
+		return mdb.revisions[i].revision.GreaterThan(asOfRevision)
+	})
+	if revIndex == 0 {
+		return datastore.NoRevision, datastore.NewInvalidRevisionErr(asOfRevision, datastore.RevisionStale)
+	}
+
+	revision := mdb.revisions[revIndex-1].revision
+	if err := mdb.checkRevisionLocalCallerMustLock(revision); err != nil {
+		return datastore.NoRevision, err
+	}
+	return revision, nil
+}
+
 func (mdb *memdbDatastore) checkRevisionLocalCallerMustLock(dr datastore.Revision) error {
 	now := nowRevision()
 
@@ -1,11 +1,14 @@
 package memdb
 
 import (
+	"time"
+
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 	"github.com/hashicorp/go-memdb"
 	"github.com/jzelinskie/stringz"
 	"github.com/rs/zerolog"
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/authzed/spicedb/pkg/datastore"
 	core "github.com/authzed/spicedb/pkg/proto/core/v1"
@@ -42,6 +45,7 @@ type relationship struct {
 	subjectObjectID  string
 	subjectRelation  string
 	caveat           *contextualizedCaveat
+	expiration       *time.Time
 }
 
 type contextualizedCaveat struct {
@@ -72,6 +76,10 @@ func (r relationship) String() string {
 	return r.namespace + ":" + r.resourceID + "#" + r.relation + "@" + r.subjectNamespace + ":" + r.subjectObjectID + "#" + r.subjectRelation + caveat
 }
 
+func (r relationship) isExpired(now time.Time) bool {
+	return r.expiration != nil && !r.expiration.After(now)
+}
+
 func (r relationship) MarshalZerologObject(e *zerolog.Event) {
 	e.Str("rel", r.String())
 }
@@ -98,6 +106,12 @@ func (r relationship) RelationTuple() (*core.RelationTuple, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	var expiration *timestamppb.Timestamp
+	if r.expiration != nil {
+		expiration = timestamppb.New(*r.expiration)
+	}
+
 	return &core.RelationTuple{
 		ResourceAndRelation: &core.ObjectAndRelation{
 			Namespace: r.namespace,
@@ -109,7 +123,8 @@ func (r relationship) RelationTuple() (*core.RelationTuple, error) {
 			ObjectId:  r.subjectObjectID,
 			Relation:  r.subjectRelation,
 		},
-		Caveat: cr,
+		Caveat:                 cr,
+		OptionalExpirationTime: expiration,
 	}, nil
 }
 
@@ -189,5 +204,15 @@ var schema = &memdb.DBSchema{
 				},
 			},
 		},
+		tableSchemaVersions: {
+			Name: tableSchemaVersions,
+			Indexes: map[string]*memdb.IndexSchema{
+				indexID: {
+					Name:    indexID,
+					Unique:  true,
+					Indexer: &memdb.UintFieldIndex{Field: "version"},
+				},
+			},
+		},
 	},
 }
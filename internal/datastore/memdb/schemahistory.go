@@ -0,0 +1,95 @@
+package memdb
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/authzed/spicedb/pkg/datastore"
+)
+
+const tableSchemaVersions = "schemaversions"
+
+type schemaVersion struct {
+	version  uint64
+	schema   string
+	author   string
+	revision datastore.Revision
+}
+
+func (sv *schemaVersion) Unwrap() datastore.SchemaVersion {
+	return datastore.SchemaVersion{
+		Version:  sv.version,
+		Schema:   sv.schema,
+		Author:   sv.author,
+		Revision: sv.revision,
+	}
+}
+
+func (r *memdbReader) ListSchemaVersions(_ context.Context) ([]datastore.SchemaVersion, error) {
+	r.mustLock()
+	defer r.Unlock()
+
+	tx, err := r.txSource()
+	if err != nil {
+		return nil, err
+	}
+
+	it, err := tx.ReverseLowerBound(tableSchemaVersions, indexID, ^uint64(0))
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []datastore.SchemaVersion
+	for found := it.Next(); found != nil; found = it.Next() {
+		versions = append(versions, found.(*schemaVersion).Unwrap())
+	}
+	return versions, nil
+}
+
+func (r *memdbReader) ReadSchemaVersion(_ context.Context, version uint64) (datastore.SchemaVersion, error) {
+	r.mustLock()
+	defer r.Unlock()
+
+	tx, err := r.txSource()
+	if err != nil {
+		return datastore.SchemaVersion{}, err
+	}
+
+	found, err := tx.First(tableSchemaVersions, indexID, version)
+	if err != nil {
+		return datastore.SchemaVersion{}, err
+	}
+	if found == nil {
+		return datastore.SchemaVersion{}, datastore.NewSchemaVersionNotFoundErr(version)
+	}
+	return found.(*schemaVersion).Unwrap(), nil
+}
+
+func (rwt *memdbReadWriteTx) WriteSchemaVersion(_ context.Context, schema string, author string) (uint64, error) {
+	rwt.mustLock()
+	defer rwt.Unlock()
+
+	tx, err := rwt.txSource()
+	if err != nil {
+		return 0, err
+	}
+	return rwt.writeSchemaVersion(tx, schema, author)
+}
+
+func (rwt *memdbReadWriteTx) writeSchemaVersion(tx *memdb.Txn, schema string, author string) (uint64, error) {
+	latest, err := tx.Last(tableSchemaVersions, indexID)
+	if err != nil {
+		return 0, err
+	}
+
+	next := uint64(1)
+	if latest != nil {
+		next = latest.(*schemaVersion).version + 1
+	}
+
+	if err := tx.Insert(tableSchemaVersions, &schemaVersion{next, schema, author, rwt.newRevision}); err != nil {
+		return 0, err
+	}
+	return next, nil
+}
@@ -48,6 +48,10 @@ const (
 	colCaveatDefinition = "definition"
 	colCaveatName       = "caveat_name"
 	colCaveatContext    = "caveat_context"
+	colExpiration       = "expiration"
+	colVersion          = "version"
+	colSchema           = "schema_text"
+	colAuthor           = "author"
 
 	errUnableToInstantiate = "unable to instantiate datastore: %w"
 	liveDeletedTxnID       = uint64(math.MaxInt64)
@@ -196,6 +200,14 @@ func newMySQLDatastore(ctx context.Context, uri string, options ...Option) (*Dat
 		-1*config.gcWindow.Seconds(),
 	)
 
+	revisionAsOfQuery := fmt.Sprintf(
+		queryRevisionAsOf,
+		colID,
+		driver.RelationTupleTransaction(),
+		colTimestamp,
+		-1*config.gcWindow.Seconds(),
+	)
+
 	store := &Datastore{
 		db:                      db,
 		driver:                  driver,
@@ -210,6 +222,7 @@ func newMySQLDatastore(ctx context.Context, uri string, options ...Option) (*Dat
 		watchBufferWriteTimeout: config.watchBufferWriteTimeout,
 		optimizedRevisionQuery:  revisionQuery,
 		validTransactionQuery:   validTransactionQuery,
+		revisionAsOfQuery:       revisionAsOfQuery,
 		createTxn:               createTxn,
 		createBaseTxn:           createBaseTxn,
 		QueryBuilder:            queryBuilder,
@@ -394,6 +407,7 @@ func newMySQLExecutor(tx querier) common.ExecuteQueryFunc {
 
 			var caveatName string
 			var caveatContext caveatContextWrapper
+			var expiration *time.Time
 			err := rows.Scan(
 				&nextTuple.ResourceAndRelation.Namespace,
 				&nextTuple.ResourceAndRelation.ObjectId,
@@ -403,11 +417,14 @@ func newMySQLExecutor(tx querier) common.ExecuteQueryFunc {
 				&nextTuple.Subject.Relation,
 				&caveatName,
 				&caveatContext,
+				&expiration,
 			)
 			if err != nil {
 				return nil, fmt.Errorf(errUnableToQueryTuples, err)
 			}
 
+			nextTuple.OptionalExpirationTime = common.ExpirationFrom(expiration)
+
 			nextTuple.Caveat, err = common.ContextualizedCaveatFrom(caveatName, caveatContext)
 			if err != nil {
 				return nil, fmt.Errorf(errUnableToQueryTuples, err)
@@ -441,6 +458,7 @@ type Datastore struct {
 
 	optimizedRevisionQuery string
 	validTransactionQuery  string
+	revisionAsOfQuery      string
 
 	gcGroup  *errgroup.Group
 	gcCtx    context.Context
@@ -514,7 +532,10 @@ func (mds *Datastore) ReadyState(ctx context.Context) (datastore.ReadyState, err
 }
 
 func (mds *Datastore) Features(_ context.Context) (*datastore.Features, error) {
-	return &datastore.Features{Watch: datastore.Feature{Enabled: true}}, nil
+	return &datastore.Features{
+		Watch:       datastore.Feature{Enabled: true},
+		WatchSchema: datastore.Feature{Enabled: false, Reason: "schema watch unsupported in MySQL"},
+	}, nil
 }
 
 // isSeeded determines if the backing database has been seeded
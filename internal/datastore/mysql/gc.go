@@ -95,6 +95,13 @@ func (mds *Datastore) DeleteBeforeTx(
 	return
 }
 
+// DeleteExpiredRels deletes any relationship rows which expired before the given time.
+// Expired relationships are already invisible to reads, so they are removed outright
+// rather than marked as deleted.
+func (mds *Datastore) DeleteExpiredRels(ctx context.Context, before time.Time) (int64, error) {
+	return mds.batchDelete(ctx, mds.driver.RelationTuple(), sq.Lt{colExpiration: before})
+}
+
 // TODO (@vroldanbet) dupe from postgres datastore - need to refactor
 // - query was reworked to make it compatible with Vitess
 // - API differences with PSQL driver
@@ -1,12 +1,13 @@
 package migrations
 
 const (
-	tableNamespaceDefault   = "namespace_config"
-	tableTransactionDefault = "relation_tuple_transaction"
-	tableTupleDefault       = "relation_tuple"
-	tableMigrationVersion   = "mysql_migration_version"
-	tableMetadataDefault    = "mysql_metadata"
-	tableCaveatDefault      = "caveat"
+	tableNamespaceDefault     = "namespace_config"
+	tableTransactionDefault   = "relation_tuple_transaction"
+	tableTupleDefault         = "relation_tuple"
+	tableMigrationVersion     = "mysql_migration_version"
+	tableMetadataDefault      = "mysql_metadata"
+	tableCaveatDefault        = "caveat"
+	tableSchemaVersionDefault = "schema_version"
 )
 
 type tables struct {
@@ -16,6 +17,7 @@ type tables struct {
 	tableNamespace        string
 	tableMetadata         string
 	tableCaveat           string
+	tableSchemaVersion    string
 }
 
 func newTables(prefix string) *tables {
@@ -26,6 +28,7 @@ func newTables(prefix string) *tables {
 		tableNamespace:        prefix + tableNamespaceDefault,
 		tableMetadata:         prefix + tableMetadataDefault,
 		tableCaveat:           prefix + tableCaveatDefault,
+		tableSchemaVersion:    prefix + tableSchemaVersionDefault,
 	}
 }
 
@@ -55,3 +58,8 @@ func (tn *tables) Metadata() string {
 func (tn *tables) Caveat() string {
 	return tn.tableCaveat
 }
+
+// SchemaVersion returns the prefixed schema version table name.
+func (tn *tables) SchemaVersion() string {
+	return tn.tableSchemaVersion
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import "fmt"
+
+func addExpirationToRelationTuplesTable(t *tables) string {
+	return fmt.Sprintf(`ALTER TABLE %s
+			ADD COLUMN expiration DATETIME(6) NULL DEFAULT NULL;`,
+		t.RelationTuple(),
+	)
+}
+
+func addExpiredRelationTuplesIndex(t *tables) string {
+	return fmt.Sprintf(`CREATE INDEX ix_relation_tuple_expired
+    ON %s (expiration);`, t.RelationTuple(),
+	)
+}
+
+func init() {
+	mustRegisterMigration("add_relationship_expiration", "watch_api_relation_tuple_index", noNonatomicMigration,
+		newStatementBatch(
+			addExpirationToRelationTuplesTable,
+			addExpiredRelationTuplesIndex,
+		).execute,
+	)
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import "fmt"
+
+// Versions of the schema are never deleted: deleted_transaction only allows reading them with the
+// same snapshot filtering as the other tables.
+func createSchemaVersionTable(t *tables) string {
+	return fmt.Sprintf(`CREATE TABLE %s (
+		version BIGINT NOT NULL,
+		schema_text LONGTEXT NOT NULL,
+		author VARCHAR(700) NOT NULL DEFAULT '',
+		created_transaction BIGINT NOT NULL,
+		deleted_transaction BIGINT NOT NULL DEFAULT '9223372036854775807',
+		CONSTRAINT pk_schema_version PRIMARY KEY (version)) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;`,
+		t.SchemaVersion(),
+	)
+}
+
+func init() {
+	mustRegisterMigration("add_schema_versions", "add_relationship_expiration", noNonatomicMigration,
+		newStatementBatch(
+			createSchemaVersionTable,
+		).execute,
+	)
+}
@@ -29,6 +29,10 @@ type QueryBuilder struct {
 	ReadCaveatQuery   sq.SelectBuilder
 	ListCaveatsQuery  sq.SelectBuilder
 	DeleteCaveatQuery sq.UpdateBuilder
+
+	WriteSchemaVersionQuery  sq.InsertBuilder
+	ReadSchemaVersionQuery   sq.SelectBuilder
+	LatestSchemaVersionQuery sq.SelectBuilder
 }
 
 // NewQueryBuilder returns a new QueryBuilder instance. The migration
@@ -61,6 +65,11 @@ func NewQueryBuilder(driver *migrations.MySQLDriver) *QueryBuilder {
 	builder.WriteCaveatQuery = writeCaveat(driver.Caveat())
 	builder.DeleteCaveatQuery = deleteCaveat(driver.Caveat())
 
+	// schema version builders
+	builder.WriteSchemaVersionQuery = writeSchemaVersion(driver.SchemaVersion())
+	builder.ReadSchemaVersionQuery = readSchemaVersion(driver.SchemaVersion())
+	builder.LatestSchemaVersionQuery = latestSchemaVersion(driver.SchemaVersion())
+
 	return &builder
 }
 
@@ -80,6 +89,23 @@ func writeCaveat(tableCaveat string) sq.InsertBuilder {
 	)
 }
 
+func writeSchemaVersion(tableSchemaVersion string) sq.InsertBuilder {
+	return sb.Insert(tableSchemaVersion).Columns(
+		colVersion,
+		colSchema,
+		colAuthor,
+		colCreatedTxn,
+	)
+}
+
+func readSchemaVersion(tableSchemaVersion string) sq.SelectBuilder {
+	return sb.Select(colVersion, colSchema, colAuthor, colCreatedTxn).From(tableSchemaVersion)
+}
+
+func latestSchemaVersion(tableSchemaVersion string) sq.SelectBuilder {
+	return sb.Select("COALESCE(MAX(" + colVersion + "), 0)").From(tableSchemaVersion)
+}
+
 func readCaveat(tableCaveat string) sq.SelectBuilder {
 	return sb.Select(colCaveatDefinition, colCreatedTxn).From(tableCaveat)
 }
@@ -123,6 +149,7 @@ func queryTuplesWithIds(tableTuple string) sq.SelectBuilder {
 		colUsersetRelation,
 		colCaveatName,
 		colCaveatContext,
+		colExpiration,
 	).From(tableTuple)
 }
 
@@ -136,6 +163,7 @@ func queryTuples(tableTuple string) sq.SelectBuilder {
 		colUsersetRelation,
 		colCaveatName,
 		colCaveatContext,
+		colExpiration,
 	).From(tableTuple)
 }
 
@@ -163,6 +191,7 @@ func writeTuple(tableTuple string) sq.InsertBuilder {
 		colUsersetRelation,
 		colCaveatName,
 		colCaveatContext,
+		colExpiration,
 		colCreatedTxn,
 	)
 }
@@ -177,6 +206,7 @@ func queryChanged(tableTuple string) sq.SelectBuilder {
 		colUsersetRelation,
 		colCaveatName,
 		colCaveatContext,
+		colExpiration,
 		colCreatedTxn,
 		colDeletedTxn,
 	).From(tableTuple)
@@ -44,6 +44,7 @@ var schema = common.NewSchemaInformation(
 	colUsersetObjectID,
 	colUsersetRelation,
 	colCaveatName,
+	colExpiration,
 	common.ExpandedLogicComparison,
 )
 
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
@@ -80,7 +81,9 @@ func (rwt *mysqlReadWriteTXN) WriteRelationships(ctx context.Context, mutations
 	clauses := sq.Or{}
 	createAndTouchMutationsByTuple := make(map[string]*core.RelationTupleUpdate, len(mutations))
 
-	// Collect all TOUCH and DELETE operations. CREATE is handled below.
+	// Collect all TOUCH and DELETE operations. CREATE is handled below, except for the expired
+	// relationships not yet garbage collected which it replaces.
+	now := time.Now().UTC()
 	for _, mut := range mutations {
 		tpl := mut.Tuple
 		tplString := tuple.StringWithoutCaveat(tpl)
@@ -88,6 +91,7 @@ func (rwt *mysqlReadWriteTXN) WriteRelationships(ctx context.Context, mutations
 		switch mut.Operation {
 		case core.RelationTupleUpdate_CREATE:
 			createAndTouchMutationsByTuple[tplString] = mut
+			clauses = append(clauses, sq.And{exactRelationshipClause(tpl), sq.LtOrEq{colExpiration: now}})
 
 		case core.RelationTupleUpdate_TOUCH:
 			createAndTouchMutationsByTuple[tplString] = mut
@@ -123,6 +127,7 @@ func (rwt *mysqlReadWriteTXN) WriteRelationships(ctx context.Context, mutations
 
 		var caveatName string
 		var caveatContext caveatContextWrapper
+		var expiration *time.Time
 
 		tupleIdsToDelete := make([]int64, 0, len(clauses))
 		for rows.Next() {
@@ -137,18 +142,20 @@ func (rwt *mysqlReadWriteTXN) WriteRelationships(ctx context.Context, mutations
 				&foundTpl.Subject.Relation,
 				&caveatName,
 				&caveatContext,
+				&expiration,
 			); err != nil {
 				return fmt.Errorf(errUnableToWriteRelationships, err)
 			}
 
 			// if the relationship to be deleted is for a TOUCH operation and the caveat
-			// name or context has not changed, then remove it from delete and create.
+			// name, context or expiration has not changed, then remove it from delete and create.
 			tplString := tuple.StringWithoutCaveat(foundTpl)
-			if mut, ok := createAndTouchMutationsByTuple[tplString]; ok {
+			if mut, ok := createAndTouchMutationsByTuple[tplString]; ok && mut.Operation == core.RelationTupleUpdate_TOUCH {
 				foundTpl.Caveat, err = common.ContextualizedCaveatFrom(caveatName, caveatContext)
 				if err != nil {
 					return fmt.Errorf(errUnableToQueryTuples, err)
 				}
+				foundTpl.OptionalExpirationTime = common.ExpirationFrom(expiration)
 
 				// Ensure the tuples are the same.
 				if tuple.Equal(mut.Tuple, foundTpl) {
@@ -197,6 +204,7 @@ func (rwt *mysqlReadWriteTXN) WriteRelationships(ctx context.Context, mutations
 			tpl.Subject.Relation,
 			caveatName,
 			&caveatContext,
+			common.ExpirationTimeOf(tpl),
 			rwt.newTxnID,
 		)
 		bulkWriteHasValues = true
@@ -352,7 +360,7 @@ func (rwt *mysqlReadWriteTXN) DeleteNamespaces(ctx context.Context, nsNames ...s
 func (rwt *mysqlReadWriteTXN) BulkLoad(ctx context.Context, iter datastore.BulkWriteRelationshipSource) (uint64, error) {
 	var sqlStmt bytes.Buffer
 
-	sql, _, err := rwt.WriteTupleQuery.Values(1, 2, 3, 4, 5, 6, 7, 8, 9).ToSql()
+	sql, _, err := rwt.WriteTupleQuery.Values(1, 2, 3, 4, 5, 6, 7, 8, 9, 10).ToSql()
 	if err != nil {
 		return 0, err
 	}
@@ -371,7 +379,7 @@ func (rwt *mysqlReadWriteTXN) BulkLoad(ctx context.Context, iter datastore.BulkW
 
 		for ; tpl != nil && err == nil && batchLen < bulkInsertRowsLimit; tpl, err = iter.Next(ctx) {
 			if batchLen != 0 {
-				sqlStmt.WriteString(",(?,?,?,?,?,?,?,?,?)")
+				sqlStmt.WriteString(",(?,?,?,?,?,?,?,?,?,?)")
 			}
 
 			var caveatName string
@@ -389,6 +397,7 @@ func (rwt *mysqlReadWriteTXN) BulkLoad(ctx context.Context, iter datastore.BulkW
 				tpl.Subject.Relation,
 				caveatName,
 				&caveatContext,
+				common.ExpirationTimeOf(tpl),
 				rwt.newTxnID,
 			)
 			batchLen++
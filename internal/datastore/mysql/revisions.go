@@ -61,6 +61,23 @@ const (
 			SELECT MAX(%[1]s)
 			FROM   %[2]s
 		) as unknown;`
+
+	// queryRevisionAsOf will return a single row with three values: the latest transaction ID at
+	// or before the given timestamp, if any, whether the timestamp is outside of the garbage
+	// collection window and whether it is in the future.
+	//
+	//   %[1] Name of id column
+	//   %[2] Relationship tuple transaction table
+	//   %[3] Name of timestamp column
+	//   %[4] Inverse of GC window (in seconds)
+	queryRevisionAsOf = `
+		SELECT (
+			SELECT MAX(%[1]s)
+			FROM   %[2]s
+			WHERE  %[3]s <= ?
+		) as revision,
+		? < TIMESTAMPADD(SECOND, %.6[4]f, UTC_TIMESTAMP(6)) as stale,
+		? > UTC_TIMESTAMP(6) as unknown;`
 )
 
 func (mds *Datastore) optimizedRevisionFunc(ctx context.Context) (datastore.Revision, time.Duration, error) {
@@ -113,6 +130,30 @@ func (mds *Datastore) CheckRevision(ctx context.Context, revision datastore.Revi
 	return nil
 }
 
+func (mds *Datastore) RevisionAsOf(ctx context.Context, asOf time.Time) (datastore.Revision, error) {
+	ctx, span := tracer.Start(ctx, "RevisionAsOf")
+	defer span.End()
+
+	var revision sql.NullInt64
+	var stale, unknown sql.NullBool
+
+	asOf = asOf.UTC()
+	if err := mds.db.QueryRowContext(ctx, mds.revisionAsOfQuery, asOf, asOf, asOf).
+		Scan(&revision, &stale, &unknown); err != nil {
+		return datastore.NoRevision, fmt.Errorf(errRevision, err)
+	}
+
+	switch {
+	case stale.Bool || !revision.Valid:
+		// If there is no transaction, the transactions up to that time have been garbage collected.
+		return datastore.NoRevision, datastore.NewInvalidRevisionErr(datastore.NoRevision, datastore.RevisionStale)
+	case unknown.Bool:
+		return datastore.NoRevision, datastore.NewInvalidRevisionErr(datastore.NoRevision, datastore.CouldNotDetermineRevision)
+	default:
+		return revisions.NewForTransactionID(uint64(revision.Int64)), nil
+	}
+}
+
 func (mds *Datastore) loadRevision(ctx context.Context) (uint64, error) {
 	// TODO (@vroldanbet) dupe from postgres datastore - need to refactor
 	// slightly changed to support no revisions at all, needed for runtime seeding of first transaction
@@ -0,0 +1,103 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/pkg/datastore"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+const (
+	errListSchemaVersions = "unable to list schema versions: %w"
+	errReadSchemaVersion  = "unable to read schema version: %w"
+	errWriteSchemaVersion = "unable to write schema version: %w"
+)
+
+func (mr *mysqlReader) ListSchemaVersions(ctx context.Context) ([]datastore.SchemaVersion, error) {
+	sqlStatement, args, err := mr.filterer(mr.ReadSchemaVersionQuery).OrderBy(colVersion + " DESC").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf(errListSchemaVersions, err)
+	}
+
+	tx, txCleanup, err := mr.txSource(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(errListSchemaVersions, err)
+	}
+	defer common.LogOnError(ctx, txCleanup)
+
+	rows, err := tx.QueryContext(ctx, sqlStatement, args...)
+	if err != nil {
+		return nil, fmt.Errorf(errListSchemaVersions, err)
+	}
+	defer common.LogOnError(ctx, rows.Close)
+
+	var versions []datastore.SchemaVersion
+	for rows.Next() {
+		var version datastore.SchemaVersion
+		var txID uint64
+		if err := rows.Scan(&version.Version, &version.Schema, &version.Author, &txID); err != nil {
+			return nil, fmt.Errorf(errListSchemaVersions, err)
+		}
+		version.Revision = revisions.NewForTransactionID(txID)
+		versions = append(versions, version)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf(errListSchemaVersions, rows.Err())
+	}
+
+	return versions, nil
+}
+
+func (mr *mysqlReader) ReadSchemaVersion(ctx context.Context, version uint64) (datastore.SchemaVersion, error) {
+	sqlStatement, args, err := mr.filterer(mr.ReadSchemaVersionQuery).Where(sq.Eq{colVersion: version}).ToSql()
+	if err != nil {
+		return datastore.SchemaVersion{}, fmt.Errorf(errReadSchemaVersion, err)
+	}
+
+	tx, txCleanup, err := mr.txSource(ctx)
+	if err != nil {
+		return datastore.SchemaVersion{}, fmt.Errorf(errReadSchemaVersion, err)
+	}
+	defer common.LogOnError(ctx, txCleanup)
+
+	var found datastore.SchemaVersion
+	var txID uint64
+	err = tx.QueryRowContext(ctx, sqlStatement, args...).Scan(&found.Version, &found.Schema, &found.Author, &txID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return datastore.SchemaVersion{}, datastore.NewSchemaVersionNotFoundErr(version)
+		}
+		return datastore.SchemaVersion{}, fmt.Errorf(errReadSchemaVersion, err)
+	}
+	found.Revision = revisions.NewForTransactionID(txID)
+	return found, nil
+}
+
+func (rwt *mysqlReadWriteTXN) WriteSchemaVersion(ctx context.Context, schema string, author string) (uint64, error) {
+	latestSQL, latestArgs, err := rwt.LatestSchemaVersionQuery.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf(errWriteSchemaVersion, err)
+	}
+
+	var latest uint64
+	if err := rwt.tx.QueryRowContext(ctx, latestSQL, latestArgs...).Scan(&latest); err != nil {
+		return 0, fmt.Errorf(errWriteSchemaVersion, err)
+	}
+
+	next := latest + 1
+	writeSQL, writeArgs, err := rwt.WriteSchemaVersionQuery.Values(next, schema, author, rwt.newTxnID).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf(errWriteSchemaVersion, err)
+	}
+
+	if _, err := rwt.tx.ExecContext(ctx, writeSQL, writeArgs...); err != nil {
+		return 0, fmt.Errorf(errWriteSchemaVersion, err)
+	}
+	return next, nil
+}
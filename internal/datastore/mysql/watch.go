@@ -161,6 +161,7 @@ func (mds *Datastore) loadChanges(
 		var deletedTxn uint64
 		var caveatName string
 		var caveatContext caveatContextWrapper
+		var expiration *time.Time
 		err = rows.Scan(
 			&nextTuple.ResourceAndRelation.Namespace,
 			&nextTuple.ResourceAndRelation.ObjectId,
@@ -170,6 +171,7 @@ func (mds *Datastore) loadChanges(
 			&nextTuple.Subject.Relation,
 			&caveatName,
 			&caveatContext,
+			&expiration,
 			&createdTxn,
 			&deletedTxn,
 		)
@@ -180,6 +182,7 @@ func (mds *Datastore) loadChanges(
 		if err != nil {
 			return
 		}
+		nextTuple.OptionalExpirationTime = common.ExpirationFrom(expiration)
 
 		if createdTxn > afterRevision && createdTxn <= newRevision {
 			if err = stagedChanges.AddRelationshipChange(ctx, revisions.NewForTransactionID(createdTxn), nextTuple, core.RelationTupleUpdate_TOUCH); err != nil {
@@ -123,6 +123,22 @@ func (pgd *pgDatastore) DeleteBeforeTx(ctx context.Context, txID datastore.Revis
 	return removed, err
 }
 
+func (pgd *pgDatastore) DeleteExpiredRels(ctx context.Context, before time.Time) (int64, error) {
+	// Delete any relationship rows which expired before the given time. Expired relationships
+	// are already invisible to reads, so they are removed outright rather than marked as deleted.
+	removed, err := pgd.batchDelete(
+		ctx,
+		tableTuple,
+		relationTuplePKCols,
+		sq.Lt{colExpiration: before},
+	)
+	if err != nil {
+		return removed, fmt.Errorf("failed to GC expired relationships: %w", err)
+	}
+
+	return removed, nil
+}
+
 func (pgd *pgDatastore) batchDelete(
 	ctx context.Context,
 	tableName string,
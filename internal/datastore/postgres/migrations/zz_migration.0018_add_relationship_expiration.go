@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var addExpirationStatements = []string{
+	`ALTER TABLE relation_tuple
+		ADD COLUMN expiration TIMESTAMP WITHOUT TIME ZONE;`,
+	`CREATE INDEX ix_relation_tuple_expired ON relation_tuple (expiration)
+		WHERE expiration IS NOT NULL;`,
+}
+
+func init() {
+	if err := DatabaseMigrations.Register("add-relationship-expiration", "add-rel-by-alive-resource-relation-subject",
+		noNonatomicMigration,
+		func(ctx context.Context, tx pgx.Tx) error {
+			for _, stmt := range addExpirationStatements {
+				if _, err := tx.Exec(ctx, stmt); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}); err != nil {
+		panic("failed to register migration: " + err.Error())
+	}
+}
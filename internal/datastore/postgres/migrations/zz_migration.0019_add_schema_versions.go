@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Versions of the schema are never deleted: deleted_xid only allows reading them with the same
+// snapshot filtering as the other tables.
+const createSchemaVersion = `CREATE TABLE schema_version (
+	version BIGINT NOT NULL,
+	schema_text TEXT NOT NULL,
+	author VARCHAR NOT NULL DEFAULT '',
+	created_xid xid8 NOT NULL DEFAULT (pg_current_xact_id()),
+	deleted_xid xid8 NOT NULL DEFAULT ('9223372036854775807'),
+	CONSTRAINT pk_schema_version PRIMARY KEY (version));`
+
+func init() {
+	if err := DatabaseMigrations.Register("add-schema-versions", "add-relationship-expiration",
+		noNonatomicMigration,
+		func(ctx context.Context, tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, createSchemaVersion); err != nil {
+				return err
+			}
+
+			return nil
+		}); err != nil {
+		panic("failed to register migration: " + err.Error())
+	}
+}
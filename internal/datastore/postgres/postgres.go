@@ -37,11 +37,12 @@ func init() {
 }
 
 const (
-	Engine           = "postgres"
-	tableNamespace   = "namespace_config"
-	tableTransaction = "relation_tuple_transaction"
-	tableTuple       = "relation_tuple"
-	tableCaveat      = "caveat"
+	Engine             = "postgres"
+	tableNamespace     = "namespace_config"
+	tableTransaction   = "relation_tuple_transaction"
+	tableTuple         = "relation_tuple"
+	tableCaveat        = "caveat"
+	tableSchemaVersion = "schema_version"
 
 	colXID               = "xid"
 	colTimestamp         = "timestamp"
@@ -59,6 +60,10 @@ const (
 	colCaveatDefinition  = "definition"
 	colCaveatContextName = "caveat_name"
 	colCaveatContext     = "caveat_context"
+	colExpiration        = "expiration"
+	colVersion           = "version"
+	colSchema            = "schema_text"
+	colAuthor            = "author"
 
 	errUnableToInstantiate = "unable to instantiate datastore"
 
@@ -237,6 +242,15 @@ func newPostgresDatastore(
 		colSnapshot,
 	)
 
+	revisionAsOfQuery := fmt.Sprintf(
+		queryRevisionAsOf,
+		colXID,
+		tableTransaction,
+		colTimestamp,
+		config.gcWindow.Seconds(),
+		colSnapshot,
+	)
+
 	maxRevisionStaleness := time.Duration(float64(config.revisionQuantization.Nanoseconds())*
 		config.maxRevisionStalenessPercent) * time.Nanosecond
 
@@ -251,6 +265,7 @@ func newPostgresDatastore(
 		watchBufferWriteTimeout: config.watchBufferWriteTimeout,
 		optimizedRevisionQuery:  revisionQuery,
 		validTransactionQuery:   validTransactionQuery,
+		revisionAsOfQuery:       revisionAsOfQuery,
 		gcWindow:                config.gcWindow,
 		gcInterval:              config.gcInterval,
 		gcTimeout:               config.gcMaxOperationTime,
@@ -292,6 +307,7 @@ type pgDatastore struct {
 	watchBufferWriteTimeout time.Duration
 	optimizedRevisionQuery  string
 	validTransactionQuery   string
+	revisionAsOfQuery       string
 	gcWindow                time.Duration
 	gcInterval              time.Duration
 	gcTimeout               time.Duration
@@ -568,7 +584,10 @@ func (pgd *pgDatastore) ReadyState(ctx context.Context) (datastore.ReadyState, e
 }
 
 func (pgd *pgDatastore) Features(_ context.Context) (*datastore.Features, error) {
-	return &datastore.Features{Watch: datastore.Feature{Enabled: pgd.watchEnabled}}, nil
+	return &datastore.Features{
+		Watch:       datastore.Feature{Enabled: pgd.watchEnabled},
+		WatchSchema: datastore.Feature{Enabled: pgd.watchEnabled},
+	}, nil
 }
 
 func buildLivingObjectFilterForRevision(revision postgresRevision) queryFilterer {
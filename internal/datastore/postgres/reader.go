@@ -33,6 +33,7 @@ var (
 		colUsersetRelation,
 		colCaveatContextName,
 		colCaveatContext,
+		colExpiration,
 	).From(tableTuple)
 
 	schema = common.NewSchemaInformation(
@@ -43,6 +44,7 @@ var (
 		colUsersetObjectID,
 		colUsersetRelation,
 		colCaveatContextName,
+		colExpiration,
 		common.TupleComparison,
 	)
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/authzed/spicedb/pkg/spiceerrors"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/jzelinskie/stringz"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/authzed/spicedb/internal/datastore/common"
 	pgxcommon "github.com/authzed/spicedb/internal/datastore/postgres/common"
 	"github.com/authzed/spicedb/pkg/datastore"
 	core "github.com/authzed/spicedb/pkg/proto/core/v1"
@@ -46,6 +48,7 @@ var (
 		colUsersetRelation,
 		colCaveatContextName,
 		colCaveatContext,
+		colExpiration,
 	)
 
 	deleteTuple = psql.Update(tableTuple).Where(sq.Eq{colDeletedXid: liveDeletedTxnID})
@@ -74,6 +77,7 @@ func appendForInsertion(builder sq.InsertBuilder, tpl *core.RelationTuple) sq.In
 		tpl.Subject.Relation,
 		caveatName,
 		caveatContext, // PGX driver serializes map[string]any to JSONB type columns
+		common.ExpirationTimeOf(tpl),
 	}
 
 	return builder.Values(valuesToWrite...)
@@ -86,6 +90,8 @@ func (rwt *pgReadWriteTXN) WriteRelationships(ctx context.Context, mutations []*
 	createInserts := writeTuple
 	touchInserts := writeTuple
 	deleteClauses := sq.Or{}
+	expiredClauses := sq.Or{}
+	now := time.Now().UTC()
 
 	// Parse the updates, building inserts for CREATE/TOUCH and deletes for DELETE.
 	for _, mut := range mutations {
@@ -94,6 +100,7 @@ func (rwt *pgReadWriteTXN) WriteRelationships(ctx context.Context, mutations []*
 		switch mut.Operation {
 		case core.RelationTupleUpdate_CREATE:
 			createInserts = appendForInsertion(createInserts, tpl)
+			expiredClauses = append(expiredClauses, sq.And{exactRelationshipClause(tpl), sq.LtOrEq{colExpiration: now}})
 			hasCreateInserts = true
 
 		case core.RelationTupleUpdate_TOUCH:
@@ -108,9 +115,19 @@ func (rwt *pgReadWriteTXN) WriteRelationships(ctx context.Context, mutations []*
 		}
 	}
 
-	// Run CREATE insertions, if any.
+	// Run CREATE insertions, if any, first deleting the expired relationships not yet garbage
+	// collected which they replace, as they would otherwise conflict.
 	if hasCreateInserts {
-		sql, args, err := createInserts.ToSql()
+		sql, args, err := deleteTuple.Where(expiredClauses).Set(colDeletedXid, rwt.newXID).ToSql()
+		if err != nil {
+			return fmt.Errorf(errUnableToWriteRelationships, err)
+		}
+
+		if _, err := rwt.tx.Exec(ctx, sql, args...); err != nil {
+			return fmt.Errorf(errUnableToWriteRelationships, err)
+		}
+
+		sql, args, err = createInserts.ToSql()
 		if err != nil {
 			return fmt.Errorf(errUnableToWriteRelationships, err)
 		}
@@ -175,9 +192,9 @@ func (rwt *pgReadWriteTXN) WriteRelationships(ctx context.Context, mutations []*
 		}
 		rows.Close()
 
-		// For each remaining TOUCH mutation, add a DELETE operation for the row iff the caveat and/or
-		// context has changed. For ones in which the caveat name and/or context did not change, there is
-		// no need to replace the row, as it is already present.
+		// For each remaining TOUCH mutation, add a DELETE operation for the row iff the caveat, context
+		// and/or expiration has changed. For ones in which none of these changed, there is no need to
+		// replace the row, as it is already present.
 		for _, mut := range touchMutationsByNonCaveat {
 			deleteClauses = append(deleteClauses, exactRelationshipDifferentCaveatClause(mut.Tuple))
 		}
@@ -405,6 +422,7 @@ var copyCols = []string{
 	colUsersetRelation,
 	colCaveatContextName,
 	colCaveatContext,
+	colExpiration,
 }
 
 func (rwt *pgReadWriteTXN) BulkLoad(ctx context.Context, iter datastore.BulkWriteRelationshipSource) (uint64, error) {
@@ -446,6 +464,7 @@ func exactRelationshipDifferentCaveatClause(r *core.RelationTuple) sq.And {
 			sq.NotEq{
 				colCaveatContext: caveatContext,
 			},
+			sq.Expr(colExpiration+" IS DISTINCT FROM ?", common.ExpirationTimeOf(r)),
 		},
 	}
 }
@@ -66,6 +66,25 @@ const (
 	)
 	SELECT minvalid.%[1]s, minvalid.%[5]s, pg_current_snapshot() FROM minvalid;`
 
+	// queryRevisionAsOf will return the latest transaction at or before the given
+	// timestamp, with its snapshot, whether the timestamp is outside of the GC window and
+	// whether it is in the future. If there is no such transaction, no row is returned.
+	//
+	// The input values for the format string are:
+	//   %[1] Name of xid column
+	//   %[2] Relationship tuple transaction table
+	//   %[3] Name of timestamp column
+	//   %[4] GC window (in seconds)
+	//   %[5] Name of the snapshot column
+	queryRevisionAsOf = `
+	SELECT %[1]s, %[5]s,
+		$1 < (NOW() AT TIME ZONE 'utc') - INTERVAL '%[4]f seconds',
+		$1 > NOW() AT TIME ZONE 'utc'
+	FROM %[2]s
+	WHERE %[3]s <= $1
+	ORDER BY %[3]s DESC, %[1]s DESC
+	LIMIT 1;`
+
 	queryCurrentSnapshot = `SELECT pg_current_snapshot();`
 
 	queryCurrentTransactionID = `SELECT pg_current_xact_id()::text::integer;`
@@ -124,6 +143,33 @@ func (pgd *pgDatastore) CheckRevision(ctx context.Context, revisionRaw datastore
 	return nil
 }
 
+func (pgd *pgDatastore) RevisionAsOf(ctx context.Context, asOf time.Time) (datastore.Revision, error) {
+	ctx, span := tracer.Start(ctx, "RevisionAsOf")
+	defer span.End()
+
+	var xid xid8
+	var snapshot pgSnapshot
+	var stale, unknown bool
+	if err := pgd.readPool.QueryRow(ctx, pgd.revisionAsOfQuery, asOf.UTC()).
+		Scan(&xid, &snapshot, &stale, &unknown); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// The transactions up to that time have been garbage collected.
+			return datastore.NoRevision, datastore.NewInvalidRevisionErr(datastore.NoRevision, datastore.RevisionStale)
+		}
+		return datastore.NoRevision, fmt.Errorf(errRevision, err)
+	}
+
+	revision := postgresRevision{snapshot.markComplete(xid.Uint64)}
+	switch {
+	case stale:
+		return datastore.NoRevision, datastore.NewInvalidRevisionErr(revision, datastore.RevisionStale)
+	case unknown:
+		return datastore.NoRevision, datastore.NewInvalidRevisionErr(revision, datastore.CouldNotDetermineRevision)
+	default:
+		return revision, nil
+	}
+}
+
 // RevisionFromString reverses the encoding process performed by MarshalBinary and String.
 func (pgd *pgDatastore) RevisionFromString(revisionStr string) (datastore.Revision, error) {
 	return ParseRevisionString(revisionStr)
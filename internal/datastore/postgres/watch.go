@@ -44,6 +44,7 @@ var (
 		colUsersetRelation,
 		colCaveatContextName,
 		colCaveatContext,
+		colExpiration,
 		colCreatedXid,
 		colDeletedXid,
 	).From(tableTuple)
@@ -301,6 +302,7 @@ func (pgd *pgDatastore) loadRelationshipChanges(ctx context.Context, xmin uint64
 		var createdXID, deletedXID xid8
 		var caveatName *string
 		var caveatContext map[string]any
+		var expiration *time.Time
 		if err := changes.Scan(
 			&nextTuple.ResourceAndRelation.Namespace,
 			&nextTuple.ResourceAndRelation.ObjectId,
@@ -310,6 +312,7 @@ func (pgd *pgDatastore) loadRelationshipChanges(ctx context.Context, xmin uint64
 			&nextTuple.Subject.Relation,
 			&caveatName,
 			&caveatContext,
+			&expiration,
 			&createdXID,
 			&deletedXID,
 		); err != nil {
@@ -327,6 +330,8 @@ func (pgd *pgDatastore) loadRelationshipChanges(ctx context.Context, xmin uint64
 			}
 		}
 
+		nextTuple.OptionalExpirationTime = common.ExpirationFrom(expiration)
+
 		if _, found := filter[createdXID.Uint64]; found {
 			if err := tracked.AddRelationshipChange(ctx, txidToRevision[createdXID.Uint64], nextTuple, core.RelationTupleUpdate_TOUCH); err != nil {
 				return err
@@ -167,6 +167,22 @@ func (r *observableReader) ListAllCaveats(ctx context.Context) ([]datastore.Revi
 	return r.delegate.ListAllCaveats(ctx)
 }
 
+func (r *observableReader) ListSchemaVersions(ctx context.Context) ([]datastore.SchemaVersion, error) {
+	ctx, closer := observe(ctx, "ListSchemaVersions")
+	defer closer()
+
+	return r.delegate.ListSchemaVersions(ctx)
+}
+
+func (r *observableReader) ReadSchemaVersion(ctx context.Context, version uint64) (datastore.SchemaVersion, error) {
+	ctx, closer := observe(ctx, "ReadSchemaVersion", trace.WithAttributes(
+		attribute.Int64("version", int64(version)),
+	))
+	defer closer()
+
+	return r.delegate.ReadSchemaVersion(ctx, version)
+}
+
 func (r *observableReader) ListAllNamespaces(ctx context.Context) ([]datastore.RevisionedNamespace, error) {
 	ctx, closer := observe(ctx, "ListAllNamespaces")
 	defer closer()
@@ -267,6 +283,15 @@ func (rwt *observableRWT) DeleteCaveats(ctx context.Context, names []string) err
 	return rwt.delegate.DeleteCaveats(ctx, names)
 }
 
+func (rwt *observableRWT) WriteSchemaVersion(ctx context.Context, schema string, author string) (uint64, error) {
+	ctx, closer := observe(ctx, "WriteSchemaVersion", trace.WithAttributes(
+		attribute.String("author", author),
+	))
+	defer closer()
+
+	return rwt.delegate.WriteSchemaVersion(ctx, schema, author)
+}
+
 func (rwt *observableRWT) WriteRelationships(ctx context.Context, mutations []*core.RelationTupleUpdate) error {
 	ctx, closer := observe(ctx, "WriteRelationships", trace.WithAttributes(
 		attribute.Int("mutations", len(mutations)),
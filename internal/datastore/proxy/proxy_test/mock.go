@@ -2,6 +2,7 @@ package proxy_test
 
 import (
 	"context"
+	"time"
 
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 	"github.com/stretchr/testify/mock"
@@ -50,6 +51,11 @@ func (dm *MockDatastore) CheckRevision(_ context.Context, revision datastore.Rev
 	return args.Error(0)
 }
 
+func (dm *MockDatastore) RevisionAsOf(_ context.Context, asOf time.Time) (datastore.Revision, error) {
+	args := dm.Called(asOf)
+	return args.Get(0).(datastore.Revision), args.Error(1)
+}
+
 func (dm *MockDatastore) RevisionFromString(s string) (datastore.Revision, error) {
 	args := dm.Called(s)
 	return args.Get(0).(datastore.Revision), args.Error(1)
@@ -169,6 +175,16 @@ func (dm *MockReader) ListAllCaveats(_ context.Context) ([]datastore.RevisionedC
 	return args.Get(0).([]datastore.RevisionedCaveat), args.Error(1)
 }
 
+func (dm *MockReader) ListSchemaVersions(_ context.Context) ([]datastore.SchemaVersion, error) {
+	args := dm.Called()
+	return args.Get(0).([]datastore.SchemaVersion), args.Error(1)
+}
+
+func (dm *MockReader) ReadSchemaVersion(_ context.Context, version uint64) (datastore.SchemaVersion, error) {
+	args := dm.Called(version)
+	return args.Get(0).(datastore.SchemaVersion), args.Error(1)
+}
+
 type MockReadWriteTransaction struct {
 	mock.Mock
 }
@@ -297,6 +313,21 @@ func (dm *MockReadWriteTransaction) DeleteCaveats(_ context.Context, _ []string)
 	panic("not used")
 }
 
+func (dm *MockReadWriteTransaction) ListSchemaVersions(_ context.Context) ([]datastore.SchemaVersion, error) {
+	args := dm.Called()
+	return args.Get(0).([]datastore.SchemaVersion), args.Error(1)
+}
+
+func (dm *MockReadWriteTransaction) ReadSchemaVersion(_ context.Context, version uint64) (datastore.SchemaVersion, error) {
+	args := dm.Called(version)
+	return args.Get(0).(datastore.SchemaVersion), args.Error(1)
+}
+
+func (dm *MockReadWriteTransaction) WriteSchemaVersion(_ context.Context, schema string, author string) (uint64, error) {
+	args := dm.Called(schema, author)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
 var (
 	_ datastore.Datastore            = &MockDatastore{}
 	_ datastore.Reader               = &MockReader{}
@@ -26,3 +26,7 @@ func (rd roDatastore) ReadWriteTx(
 ) (datastore.Revision, error) {
 	return datastore.NoRevision, errReadOnly
 }
+
+func (rd roDatastore) Unwrap() datastore.Datastore {
+	return rd.Datastore
+}
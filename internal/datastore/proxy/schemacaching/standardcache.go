@@ -30,6 +30,10 @@ func (p *definitionCachingProxy) Close() error {
 	return p.Datastore.Close()
 }
 
+func (p *definitionCachingProxy) Unwrap() datastore.Datastore {
+	return p.Datastore
+}
+
 func (p *definitionCachingProxy) SnapshotReader(rev datastore.Revision) datastore.Reader {
 	delegateReader := p.Datastore.SnapshotReader(rev)
 	return &definitionCachingReader{delegateReader, rev, p}
@@ -121,6 +121,10 @@ func createWatchingCacheProxy(delegate datastore.Datastore, c cache.Cache, gcWin
 	return proxy
 }
 
+func (p *watchingCachingProxy) Unwrap() datastore.Datastore {
+	return p.Datastore
+}
+
 func (p *watchingCachingProxy) SnapshotReader(rev datastore.Revision) datastore.Reader {
 	delegateReader := p.Datastore.SnapshotReader(rev)
 	return &watchingCachingReader{delegateReader, rev, p}
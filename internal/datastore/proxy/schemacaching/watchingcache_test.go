@@ -575,6 +575,14 @@ func (*fakeSnapshotReader) ListAllCaveats(context.Context) ([]datastore.Revision
 	return []datastore.RevisionedDefinition[*corev1.CaveatDefinition]{}, nil
 }
 
+func (*fakeSnapshotReader) ListSchemaVersions(context.Context) ([]datastore.SchemaVersion, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (*fakeSnapshotReader) ReadSchemaVersion(context.Context, uint64) (datastore.SchemaVersion, error) {
+	return datastore.SchemaVersion{}, fmt.Errorf("not implemented")
+}
+
 func (fsr *fakeSnapshotReader) ListAllNamespaces(context.Context) ([]datastore.RevisionedDefinition[*corev1.NamespaceDefinition], error) {
 	if fsr.fds.existingNamespaces != nil {
 		return fsr.fds.existingNamespaces, nil
@@ -114,3 +114,22 @@ func (rcr *RemoteClockRevisions) CheckRevision(ctx context.Context, dsRevision d
 
 	return nil
 }
+
+// RevisionAsOf returns the revision at the given time, which must be within the GC window.
+func (rcr *RemoteClockRevisions) RevisionAsOf(ctx context.Context, asOf time.Time) (datastore.Revision, error) {
+	now, err := rcr.nowFunc(ctx)
+	if err != nil {
+		return datastore.NoRevision, err
+	}
+
+	nowTS, ok := now.(WithTimestampRevision)
+	if !ok {
+		return datastore.NoRevision, spiceerrors.MustBugf("expected with-timestamp revision, got %T", now)
+	}
+
+	revision := nowTS.ConstructForTimestamp(asOf.UnixNano())
+	if err := rcr.CheckRevision(ctx, revision); err != nil {
+		return datastore.NoRevision, err
+	}
+	return revision, nil
+}
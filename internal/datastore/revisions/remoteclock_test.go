@@ -143,3 +143,43 @@ func TestRemoteClockCheckRevisions(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoteClockRevisionAsOf(t *testing.T) {
+	testCases := []struct {
+		name           string
+		asOfSeconds    int64
+		expectedReason *datastore.InvalidRevisionReason
+	}{
+		{"now", 12345, nil},
+		{"recent past", 12000, nil},
+		{"collected", 8744, reasonPtr(datastore.RevisionStale)},
+		{"future", 12346, reasonPtr(datastore.CouldNotDetermineRevision)},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			rcr := NewRemoteClockRevisions(1*time.Hour, 0, 0, 0)
+			rcr.SetNowFunc(func(ctx context.Context) (datastore.Revision, error) {
+				return NewForTime(time.Unix(12345, 0)), nil
+			})
+
+			revision, err := rcr.RevisionAsOf(context.Background(), time.Unix(tc.asOfSeconds, 0))
+			if tc.expectedReason != nil {
+				var invalidRevisionErr datastore.ErrInvalidRevision
+				require.ErrorAs(err, &invalidRevisionErr)
+				require.Equal(*tc.expectedReason, invalidRevisionErr.Reason())
+				return
+			}
+
+			require.NoError(err)
+			require.True(NewForTimestamp(tc.asOfSeconds * 1_000_000_000).Equal(revision))
+		})
+	}
+}
+
+func reasonPtr(reason datastore.InvalidRevisionReason) *datastore.InvalidRevisionReason {
+	return &reason
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"context"
+
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+)
+
+const (
+	addRelationshipExpiration = `ALTER TABLE relation_tuple
+		ADD COLUMN expiration TIMESTAMP`
+
+	// Expired relationships are hidden from reads immediately; the row deletion
+	// policy removes them from storage in the background.
+	addRelationshipExpirationPolicy = `ALTER TABLE relation_tuple
+		ADD ROW DELETION POLICY (OLDER_THAN(expiration, INTERVAL 0 DAY))`
+)
+
+func init() {
+	if err := SpannerMigrations.Register("add-relationship-expiration", "delete-older-changestreams", func(ctx context.Context, w Wrapper) error {
+		updateOp, err := w.adminClient.UpdateDatabaseDdl(ctx, &databasepb.UpdateDatabaseDdlRequest{
+			Database: w.client.DatabaseName(),
+			Statements: []string{
+				addRelationshipExpiration,
+				addRelationshipExpirationPolicy,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return updateOp.Wait(ctx)
+	}, nil); err != nil {
+		panic("failed to register migration: " + err.Error())
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/authzed/spicedb/internal/datastore/common"
 	"github.com/authzed/spicedb/internal/datastore/revisions"
@@ -90,6 +91,7 @@ func queryExecutor(txSource txFactory) common.ExecuteQueryFunc {
 			}
 			var caveatName spanner.NullString
 			var caveatCtx spanner.NullJSON
+			var expiration spanner.NullTime
 			err := row.Columns(
 				&nextTuple.ResourceAndRelation.Namespace,
 				&nextTuple.ResourceAndRelation.ObjectId,
@@ -99,11 +101,16 @@ func queryExecutor(txSource txFactory) common.ExecuteQueryFunc {
 				&nextTuple.Subject.Relation,
 				&caveatName,
 				&caveatCtx,
+				&expiration,
 			)
 			if err != nil {
 				return err
 			}
 
+			if expiration.Valid {
+				nextTuple.OptionalExpirationTime = timestamppb.New(expiration.Time)
+			}
+
 			nextTuple.Caveat, err = ContextualizedCaveatFrom(caveatName, caveatCtx)
 			if err != nil {
 				return err
@@ -232,6 +239,7 @@ var queryTuples = sql.Select(
 	colUsersetRelation,
 	colCaveatName,
 	colCaveatContext,
+	colExpiration,
 ).From(tableRelationship)
 
 var schema = common.NewSchemaInformation(
@@ -242,6 +250,7 @@ var schema = common.NewSchemaInformation(
 	colUsersetObjectID,
 	colUsersetRelation,
 	colCaveatName,
+	colExpiration,
 	common.ExpandedLogicComparison,
 )
 
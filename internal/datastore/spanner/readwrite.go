@@ -3,11 +3,13 @@ package spanner
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"cloud.google.com/go/spanner"
 	sq "github.com/Masterminds/squirrel"
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 	"github.com/jzelinskie/stringz"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/proto"
 
 	log "github.com/authzed/spicedb/internal/logging"
@@ -28,6 +30,18 @@ func (rwt spannerReadWriteTXN) WriteRelationships(ctx context.Context, mutations
 		if err != nil {
 			return fmt.Errorf(errUnableToWriteRelationships, err)
 		}
+
+		// Expired relationships not yet garbage collected are absent, and so are replaced.
+		if mutation.Operation == core.RelationTupleUpdate_CREATE {
+			expired, err := rwt.isExpired(ctx, mutation.Tuple)
+			if err != nil {
+				return fmt.Errorf(errUnableToWriteRelationships, err)
+			}
+			if expired {
+				txnMut = spanner.Replace(tableRelationship, allRelationshipCols, upsertVals(mutation.Tuple))
+				countChange = 0
+			}
+		}
 		rowCountChange += countChange
 
 		if err := rwt.spannerRWT.BufferWrite([]*spanner.Mutation{txnMut}); err != nil {
@@ -44,6 +58,23 @@ func (rwt spannerReadWriteTXN) WriteRelationships(ctx context.Context, mutations
 	return nil
 }
 
+// isExpired returns whether the stored relationship with the key of the given one has expired.
+func (rwt spannerReadWriteTXN) isExpired(ctx context.Context, tpl *core.RelationTuple) (bool, error) {
+	row, err := rwt.spannerRWT.ReadRow(ctx, tableRelationship, keyFromRelationship(tpl), []string{colExpiration})
+	if err != nil {
+		if spanner.ErrCode(err) == codes.NotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var expiration spanner.NullTime
+	if err := row.Columns(&expiration); err != nil {
+		return false, err
+	}
+	return expiration.Valid && !expiration.Time.After(time.Now()), nil
+}
+
 func spannerMutation(
 	ctx context.Context,
 	operation core.RelationTupleUpdate_Operation,
@@ -133,6 +164,7 @@ func upsertVals(r *core.RelationTuple) []any {
 	key := keyFromRelationship(r)
 	key = append(key, spanner.CommitTimestamp)
 	key = append(key, caveatVals(r)...)
+	key = append(key, expirationVal(r))
 	return key
 }
 
@@ -160,6 +192,13 @@ func caveatVals(r *core.RelationTuple) []any {
 	return vals
 }
 
+func expirationVal(r *core.RelationTuple) spanner.NullTime {
+	if r.OptionalExpirationTime == nil {
+		return spanner.NullTime{}
+	}
+	return spanner.NullTime{Time: r.OptionalExpirationTime.AsTime(), Valid: true}
+}
+
 func (rwt spannerReadWriteTXN) WriteNamespaces(_ context.Context, newConfigs ...*core.NamespaceDefinition) error {
 	mutations := make([]*spanner.Mutation, 0, len(newConfigs))
 	for _, newConfig := range newConfigs {
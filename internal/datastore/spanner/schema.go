@@ -16,12 +16,19 @@ const (
 	colTimestamp        = "timestamp"
 	colCaveatName       = "caveat_name"
 	colCaveatContext    = "caveat_context"
+	colExpiration       = "expiration"
 
 	tableCaveat         = "caveat"
 	colName             = "name"
 	colCaveatDefinition = "definition"
 	colCaveatTS         = "timestamp"
 
+	tableSchemaVersion = "schema_version"
+	colVersion         = "version"
+	colSchema          = "schema_text"
+	colAuthor          = "author"
+	colSchemaVersionTS = "timestamp"
+
 	tableMetadata = "metadata"
 	colUniqueID   = "unique_id"
 
@@ -40,4 +47,5 @@ var allRelationshipCols = []string{
 	colTimestamp,
 	colCaveatName,
 	colCaveatContext,
+	colExpiration,
 }
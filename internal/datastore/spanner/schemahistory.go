@@ -0,0 +1,91 @@
+package spanner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/pkg/datastore"
+)
+
+var schemaVersionCols = []string{colVersion, colSchema, colAuthor, colSchemaVersionTS}
+
+var queryLatestSchemaVersion = fmt.Sprintf("SELECT COALESCE(MAX(%s), 0) FROM %s", colVersion, tableSchemaVersion)
+
+func (sr spannerReader) ListSchemaVersions(ctx context.Context) ([]datastore.SchemaVersion, error) {
+	iter := sr.txSource().Query(ctx, spanner.Statement{
+		SQL: fmt.Sprintf("SELECT %s, %s, %s, %s FROM %s ORDER BY %s DESC",
+			colVersion, colSchema, colAuthor, colSchemaVersionTS, tableSchemaVersion, colVersion),
+	})
+	defer iter.Stop()
+
+	var versions []datastore.SchemaVersion
+	if err := iter.Do(func(row *spanner.Row) error {
+		version, err := schemaVersionFromRow(row)
+		if err != nil {
+			return err
+		}
+		versions = append(versions, version)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf(errUnableToListSchemaVersions, err)
+	}
+
+	return versions, nil
+}
+
+func (sr spannerReader) ReadSchemaVersion(ctx context.Context, version uint64) (datastore.SchemaVersion, error) {
+	row, err := sr.txSource().ReadRow(ctx, tableSchemaVersion, spanner.Key{int64(version)}, schemaVersionCols)
+	if err != nil {
+		if spanner.ErrCode(err) == codes.NotFound {
+			return datastore.SchemaVersion{}, datastore.NewSchemaVersionNotFoundErr(version)
+		}
+		return datastore.SchemaVersion{}, fmt.Errorf(errUnableToReadSchemaVersion, err)
+	}
+
+	found, err := schemaVersionFromRow(row)
+	if err != nil {
+		return datastore.SchemaVersion{}, fmt.Errorf(errUnableToReadSchemaVersion, err)
+	}
+	return found, nil
+}
+
+func (rwt spannerReadWriteTXN) WriteSchemaVersion(ctx context.Context, schema string, author string) (uint64, error) {
+	var latest int64
+	if err := rwt.spannerRWT.Query(ctx, spanner.Statement{SQL: queryLatestSchemaVersion}).Do(func(r *spanner.Row) error {
+		return r.Columns(&latest)
+	}); err != nil {
+		return 0, fmt.Errorf(errUnableToWriteSchemaVersion, err)
+	}
+
+	next := latest + 1
+	if err := rwt.spannerRWT.BufferWrite([]*spanner.Mutation{spanner.Insert(
+		tableSchemaVersion,
+		schemaVersionCols,
+		[]any{next, schema, author, spanner.CommitTimestamp},
+	)}); err != nil {
+		return 0, fmt.Errorf(errUnableToWriteSchemaVersion, err)
+	}
+
+	return uint64(next), nil
+}
+
+func schemaVersionFromRow(row *spanner.Row) (datastore.SchemaVersion, error) {
+	var version int64
+	var schema, author string
+	var updated time.Time
+	if err := row.Columns(&version, &schema, &author, &updated); err != nil {
+		return datastore.SchemaVersion{}, err
+	}
+
+	return datastore.SchemaVersion{
+		Version:  uint64(version),
+		Schema:   schema,
+		Author:   author,
+		Revision: revisions.NewForTime(updated),
+	}, nil
+}
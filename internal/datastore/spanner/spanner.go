@@ -56,6 +56,10 @@ const (
 	errUnableToListCaveats  = "unable to list caveats: %w"
 	errUnableToDeleteCaveat = "unable to delete caveat: %w"
 
+	errUnableToListSchemaVersions = "unable to list schema versions: %w"
+	errUnableToReadSchemaVersion  = "unable to read schema version: %w"
+	errUnableToWriteSchemaVersion = "unable to write schema version: %w"
+
 	// See https://cloud.google.com/spanner/docs/change-streams#data-retention
 	// See https://github.com/authzed/spicedb/issues/1457
 	defaultChangeStreamRetention = 24 * time.Hour
@@ -275,7 +279,10 @@ func (sd spannerDatastore) ReadyState(ctx context.Context) (datastore.ReadyState
 }
 
 func (sd spannerDatastore) Features(_ context.Context) (*datastore.Features, error) {
-	return &datastore.Features{Watch: datastore.Feature{Enabled: true}}, nil
+	return &datastore.Features{
+		Watch:       datastore.Feature{Enabled: true},
+		WatchSchema: datastore.Feature{Enabled: true},
+	}, nil
 }
 
 func (sd spannerDatastore) Close() error {
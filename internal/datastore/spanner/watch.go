@@ -14,6 +14,7 @@ import (
 	"github.com/cloudspannerecosystem/spanner-change-streams-tail/changestreams"
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/api/option"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/authzed/spicedb/internal/datastore/common"
 	"github.com/authzed/spicedb/internal/datastore/revisions"
@@ -187,6 +188,11 @@ func (sd spannerDatastore) watch(
 								return err
 							}
 
+							relationTuple.OptionalExpirationTime, err = expirationFromValues(oldValues)
+							if err != nil {
+								return err
+							}
+
 							err := tracked.AddRelationshipChange(ctx, changeRevision, relationTuple, core.RelationTupleUpdate_DELETE)
 							if err != nil {
 								return err
@@ -241,15 +247,17 @@ func (sd spannerDatastore) watch(
 							}
 
 							// NOTE: Spanner's change stream will return a record for a TOUCH operation that does not
-							// change anything. Therefore, we check  to see if the caveat name or context has changed
-							// between the old and new values, and only raise the event in that case. This works for
-							// caveat context because Spanner will return either `nil` or a string value of the JSON.
+							// change anything. Therefore, we check  to see if the caveat name, context or expiration has
+							// changed between the old and new values, and only raise the event in that case. This works for
+							// caveat context and expiration because Spanner will return either `nil` or a string value.
 							newValues, ok := mod.NewValues.Value.(map[string]any)
 							if !ok {
 								return spiceerrors.MustBugf("error converting new values map")
 							}
 
-							if oldValues[colCaveatName] == newValues[colCaveatName] && oldValues[colCaveatContext] == newValues[colCaveatContext] {
+							if oldValues[colCaveatName] == newValues[colCaveatName] &&
+								oldValues[colCaveatContext] == newValues[colCaveatContext] &&
+								oldValues[colExpiration] == newValues[colExpiration] {
 								continue
 							}
 
@@ -258,6 +266,11 @@ func (sd spannerDatastore) watch(
 								return err
 							}
 
+							relationTuple.OptionalExpirationTime, err = expirationFromValues(newValues)
+							if err != nil {
+								return err
+							}
+
 							err := tracked.AddRelationshipChange(ctx, changeRevision, relationTuple, core.RelationTupleUpdate_TOUCH)
 							if err != nil {
 								return err
@@ -382,3 +395,17 @@ func contextualizedCaveatFromValues(values map[string]any) (*core.Contextualized
 	}
 	return nil, nil
 }
+
+func expirationFromValues(values map[string]any) (*timestamppb.Timestamp, error) {
+	// NOTE: spanner returns the TIMESTAMP field as an RFC3339 string here.
+	expirationString, ok := values[colExpiration].(string)
+	if !ok || expirationString == "" {
+		return nil, nil
+	}
+
+	expiration, err := time.Parse(time.RFC3339Nano, expirationString)
+	if err != nil {
+		return nil, err
+	}
+	return timestamppb.New(expiration), nil
+}
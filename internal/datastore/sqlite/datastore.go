@@ -0,0 +1,495 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/dlmiddlecote/sqlstats"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+
+	datastoreinternal "github.com/authzed/spicedb/internal/datastore"
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/internal/datastore/sqlite/migrations"
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/datastore/options"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+)
+
+const (
+	Engine = "sqlite"
+
+	colID               = "id"
+	colTimestamp        = "timestamp"
+	colNamespace        = "namespace"
+	colConfig           = "serialized_config"
+	colCreatedTxn       = "created_transaction"
+	colDeletedTxn       = "deleted_transaction"
+	colObjectID         = "object_id"
+	colRelation         = "relation"
+	colUsersetNamespace = "userset_namespace"
+	colUsersetObjectID  = "userset_object_id"
+	colUsersetRelation  = "userset_relation"
+	colName             = "name"
+	colCaveatDefinition = "definition"
+	colCaveatName       = "caveat_name"
+	colCaveatContext    = "caveat_context"
+	colExpiration       = "expiration"
+	colVersion          = "version"
+	colSchema           = "schema_text"
+	colAuthor           = "author"
+
+	errUnableToInstantiate = "unable to instantiate datastore: %w"
+	liveDeletedTxnID       = uint64(math.MaxInt64)
+	batchDeleteSize        = 1000
+	seedingTimeout         = 10 * time.Second
+)
+
+var (
+	tracer = otel.Tracer("spicedb/internal/datastore/sqlite")
+
+	sb = sq.StatementBuilder.PlaceholderFormat(sq.Question)
+)
+
+func init() {
+	datastore.Engines = append(datastore.Engines, Engine)
+}
+
+type sqlFilter interface {
+	ToSql() (string, []interface{}, error)
+}
+
+// NewSQLiteDatastore creates a new sqlite.Datastore value backed by the SQLite database file
+// specified through the URI parameter. Supports customization via the various options available
+// in this package.
+//
+// URI: a path to the database file, or a `file:` URI with optional connection parameters,
+// e.g. `file:/var/lib/spicedb/spicedb.db?_busy_timeout=10000`.
+// See https://github.com/mattn/go-sqlite3#connection-string
+func NewSQLiteDatastore(ctx context.Context, uri string, options ...Option) (datastore.Datastore, error) {
+	ds, err := newSQLiteDatastore(ctx, uri, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return datastoreinternal.NewSeparatingContextDatastoreProxy(ds), nil
+}
+
+func newSQLiteDatastore(ctx context.Context, uri string, options ...Option) (*Datastore, error) {
+	if err := migrations.CheckDriverAvailable(); err != nil {
+		return nil, fmt.Errorf(errUnableToInstantiate, err)
+	}
+
+	config, err := generateConfig(options)
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToInstantiate, err)
+	}
+
+	connectionURI, err := migrations.ConnectionURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToInstantiate, err)
+	}
+
+	db, err := sql.Open(migrations.DriverName, connectionURI)
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToInstantiate, err)
+	}
+
+	if config.enablePrometheusStats {
+		collector := sqlstats.NewStatsCollector("spicedb", db)
+		if err := prometheus.Register(collector); err != nil {
+			return nil, fmt.Errorf(errUnableToInstantiate, err)
+		}
+
+		if err := common.RegisterGCMetrics(); err != nil {
+			return nil, fmt.Errorf(errUnableToInstantiate, err)
+		}
+	}
+
+	db.SetMaxOpenConns(config.maxOpenConns)
+	db.SetMaxIdleConns(config.maxOpenConns)
+
+	// Closing the last connection to a WAL database checkpoints and removes the WAL file,
+	// so connections are kept around rather than reaped on idle.
+	db.SetConnMaxIdleTime(0)
+	db.SetConnMaxLifetime(0)
+
+	gcCtx, cancelGc := context.WithCancel(context.Background())
+
+	maxRevisionStaleness := time.Duration(float64(config.revisionQuantization.Nanoseconds())*
+		config.maxRevisionStalenessPercent) * time.Nanosecond
+
+	store := &Datastore{
+		db:                      db,
+		driver:                  migrations.NewSQLiteDriverFromDB(db),
+		revisionQuantization:    config.revisionQuantization,
+		gcWindow:                config.gcWindow,
+		gcInterval:              config.gcInterval,
+		gcTimeout:               config.gcMaxOperationTime,
+		gcCtx:                   gcCtx,
+		cancelGc:                cancelGc,
+		watchBufferLength:       config.watchBufferLength,
+		watchBufferWriteTimeout: config.watchBufferWriteTimeout,
+		QueryBuilder:            NewQueryBuilder(),
+		maxRetries:              config.maxRetries,
+		CachedOptimizedRevisions: revisions.NewCachedOptimizedRevisions(
+			maxRevisionStaleness,
+		),
+		CommonDecoder: revisions.CommonDecoder{
+			Kind: revisions.TransactionID,
+		},
+	}
+
+	store.SetOptimizedRevisionFunc(store.optimizedRevisionFunc)
+
+	ctx, cancel := context.WithTimeout(ctx, seedingTimeout)
+	defer cancel()
+	err = store.seedDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start a goroutine for garbage collection.
+	if store.gcInterval > 0*time.Minute && config.gcEnabled {
+		store.gcGroup, store.gcCtx = errgroup.WithContext(store.gcCtx)
+		store.gcGroup.Go(func() error {
+			return common.StartGarbageCollector(
+				store.gcCtx,
+				store,
+				store.gcInterval,
+				store.gcWindow,
+				store.gcTimeout,
+			)
+		})
+	} else {
+		log.Warn().Msg("datastore background garbage collection disabled")
+	}
+
+	return store, nil
+}
+
+// SnapshotReader returns a reader for the given revision.
+//
+// Reads are issued directly against the connection pool rather than within a transaction:
+// every query filters on the created and deleted transactions, so the rows visible at the
+// revision never change, and starting a transaction would needlessly acquire the write lock.
+func (sds *Datastore) SnapshotReader(rev datastore.Revision) datastore.Reader {
+	return &sqliteReader{
+		sds.QueryBuilder,
+		sds.db,
+		common.QueryExecutor{Executor: newSQLiteExecutor(sds.db)},
+		buildLivingObjectFilterForRevision(rev),
+	}
+}
+
+// ReadWriteTx starts a read/write transaction, which will be committed if no error is
+// returned and rolled back if an error is returned.
+func (sds *Datastore) ReadWriteTx(
+	ctx context.Context,
+	fn datastore.TxUserFunc,
+	opts ...options.RWTOptionsOption,
+) (datastore.Revision, error) {
+	config := options.NewRWTOptionsWithOptions(opts...)
+
+	var err error
+	for i := uint8(0); i <= sds.maxRetries; i++ {
+		var newTxnID uint64
+		if err = migrations.BeginTxFunc(ctx, sds.db, func(tx *sql.Tx) error {
+			rwt := &sqliteReadWriteTXN{
+				sqliteReader: &sqliteReader{
+					sds.QueryBuilder,
+					tx,
+					common.QueryExecutor{Executor: newSQLiteExecutor(tx)},
+					currentlyLivingObjects,
+				},
+				tx:                tx,
+				createTransaction: sds.createNewTransaction,
+			}
+
+			if err := fn(ctx, rwt); err != nil {
+				return err
+			}
+
+			// Every read-write transaction results in a new revision, even if nothing was written.
+			if err := rwt.ensureTransaction(ctx); err != nil {
+				return err
+			}
+
+			newTxnID = rwt.newTxnID
+			return nil
+		}); err != nil {
+			if !config.DisableRetries && isErrorRetryable(err) {
+				continue
+			}
+
+			return datastore.NoRevision, wrapError(err)
+		}
+
+		return revisions.NewForTransactionID(newTxnID), nil
+	}
+	if !config.DisableRetries {
+		err = fmt.Errorf("max retries exceeded: %w", err)
+	}
+
+	return datastore.NoRevision, wrapError(err)
+}
+
+// wrapError maps any SQLite internal error into a SpiceDB typed error or an error
+// that implements GRPCStatus().
+func wrapError(err error) error {
+	if cerr := convertToWriteConstraintError(err); cerr != nil {
+		return cerr
+	}
+
+	return err
+}
+
+type querier interface {
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func newSQLiteExecutor(q querier) common.ExecuteQueryFunc {
+	return func(ctx context.Context, sqlQuery string, args []interface{}) ([]*core.RelationTuple, error) {
+		span := trace.SpanFromContext(ctx)
+
+		rows, err := q.QueryContext(ctx, sqlQuery, args...)
+		if err != nil {
+			return nil, fmt.Errorf(errUnableToQueryTuples, err)
+		}
+		defer common.LogOnError(ctx, rows.Close)
+
+		span.AddEvent("Query issued to database")
+
+		var tuples []*core.RelationTuple
+		for rows.Next() {
+			nextTuple := &core.RelationTuple{
+				ResourceAndRelation: &core.ObjectAndRelation{},
+				Subject:             &core.ObjectAndRelation{},
+			}
+
+			var caveatName string
+			var caveatContext caveatContextWrapper
+			var expiration *time.Time
+			err := rows.Scan(
+				&nextTuple.ResourceAndRelation.Namespace,
+				&nextTuple.ResourceAndRelation.ObjectId,
+				&nextTuple.ResourceAndRelation.Relation,
+				&nextTuple.Subject.Namespace,
+				&nextTuple.Subject.ObjectId,
+				&nextTuple.Subject.Relation,
+				&caveatName,
+				&caveatContext,
+				&expiration,
+			)
+			if err != nil {
+				return nil, fmt.Errorf(errUnableToQueryTuples, err)
+			}
+
+			nextTuple.OptionalExpirationTime = common.ExpirationFrom(expiration)
+
+			nextTuple.Caveat, err = common.ContextualizedCaveatFrom(caveatName, caveatContext)
+			if err != nil {
+				return nil, fmt.Errorf(errUnableToQueryTuples, err)
+			}
+
+			tuples = append(tuples, nextTuple)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf(errUnableToQueryTuples, err)
+		}
+		span.AddEvent("Tuples loaded", trace.WithAttributes(attribute.Int("tupleCount", len(tuples))))
+		return tuples, nil
+	}
+}
+
+// Datastore is a SQLite-based implementation of the datastore.Datastore interface
+type Datastore struct {
+	db     *sql.DB
+	driver *migrations.SQLiteDriver
+
+	revisionQuantization    time.Duration
+	gcWindow                time.Duration
+	gcInterval              time.Duration
+	gcTimeout               time.Duration
+	watchBufferLength       uint16
+	watchBufferWriteTimeout time.Duration
+	maxRetries              uint8
+
+	gcGroup  *errgroup.Group
+	gcCtx    context.Context
+	cancelGc context.CancelFunc
+	gcHasRun atomic.Bool
+
+	*QueryBuilder
+	*revisions.CachedOptimizedRevisions
+	revisions.CommonDecoder
+}
+
+// Close closes the data store.
+func (sds *Datastore) Close() error {
+	sds.cancelGc()
+	if sds.gcGroup != nil {
+		if err := sds.gcGroup.Wait(); err != nil && !errors.Is(err, context.Canceled) {
+			log.Error().Err(err).Msg("error waiting for garbage collector to shutdown")
+		}
+	}
+	return sds.db.Close()
+}
+
+// ReadyState returns whether the datastore is ready to accept data. The database file
+// must have been migrated to a compatible revision, and seeded with the initial transaction.
+func (sds *Datastore) ReadyState(ctx context.Context) (datastore.ReadyState, error) {
+	if err := sds.db.PingContext(ctx); err != nil {
+		return datastore.ReadyState{}, err
+	}
+
+	currentMigrationRevision, err := sds.driver.Version(ctx)
+	if err != nil {
+		return datastore.ReadyState{}, err
+	}
+	if currentMigrationRevision == "" {
+		return datastore.ReadyState{
+			Message: "datastore has not been migrated; please run \"spicedb migrate head\"",
+			IsReady: false,
+		}, nil
+	}
+
+	compatible, err := migrations.Manager.IsHeadCompatible(currentMigrationRevision)
+	if err != nil {
+		return datastore.ReadyState{}, err
+	}
+	if !compatible {
+		return datastore.ReadyState{
+			Message: "datastore is not at a currently compatible revision",
+			IsReady: false,
+		}, nil
+	}
+
+	isSeeded, err := sds.isSeeded(ctx)
+	if err != nil {
+		return datastore.ReadyState{}, err
+	}
+	if !isSeeded {
+		return datastore.ReadyState{
+			Message: "datastore is not properly seeded",
+			IsReady: false,
+		}, nil
+	}
+
+	return datastore.ReadyState{
+		Message: "",
+		IsReady: true,
+	}, nil
+}
+
+func (sds *Datastore) Features(_ context.Context) (*datastore.Features, error) {
+	return &datastore.Features{
+		Watch:       datastore.Feature{Enabled: true},
+		WatchSchema: datastore.Feature{Enabled: true},
+	}, nil
+}
+
+// isSeeded determines if the backing database has been seeded
+func (sds *Datastore) isSeeded(ctx context.Context) (bool, error) {
+	headRevision, err := sds.HeadRevision(ctx)
+	if err != nil {
+		return false, err
+	}
+	if headRevision == datastore.NoRevision {
+		return false, nil
+	}
+
+	_, err = sds.getUniqueID(ctx)
+	if err != nil {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// seedDatabase initializes the first transaction revision and the unique ID of the
+// datastore if necessary. If the database has not yet been migrated, seeding is skipped
+// and the datastore will report as not ready.
+func (sds *Datastore) seedDatabase(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "seedDatabase")
+	defer span.End()
+
+	version, err := sds.driver.Version(ctx)
+	if err != nil {
+		return fmt.Errorf("seedDatabase: %w", err)
+	}
+	if version == "" {
+		log.Ctx(ctx).Warn().Msg("SQLite database has not been migrated; skipping seeding")
+		return nil
+	}
+
+	isSeeded, err := sds.isSeeded(ctx)
+	if err != nil {
+		return err
+	}
+	if isSeeded {
+		return nil
+	}
+
+	return migrations.BeginTxFunc(ctx, sds.db, func(tx *sql.Tx) error {
+		// INSERT OR IGNORE on known ID values makes this idempotent.
+		baseTxnSQL, baseTxnArgs, err := sb.
+			Insert(migrations.TableTransaction).
+			Options("OR IGNORE").
+			Columns(colID, colTimestamp).
+			Values(1, 0).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("seedDatabase: failed to prepare SQL: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, baseTxnSQL, baseTxnArgs...); err != nil {
+			return fmt.Errorf("seedDatabase: %w", err)
+		}
+
+		uuidSQL, uuidArgs, err := sb.
+			Insert(migrations.TableMetadata).
+			Options("OR IGNORE").
+			Columns(metadataIDColumn, metadataUniqueIDColumn).
+			Values(0, uuid.NewString()).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("seedDatabase: failed to prepare SQL: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, uuidSQL, uuidArgs...); err != nil {
+			return fmt.Errorf("seedDatabase: failed to insert unique ID: %w", err)
+		}
+
+		log.Ctx(ctx).Info().Msg("seeded base datastore")
+		return nil
+	})
+}
+
+func buildLivingObjectFilterForRevision(revision datastore.Revision) queryFilterer {
+	return func(original sq.SelectBuilder) sq.SelectBuilder {
+		txID := revision.(revisions.TransactionIDRevision).TransactionID()
+		return original.Where(sq.LtOrEq{colCreatedTxn: txID}).
+			Where(sq.Or{
+				sq.Eq{colDeletedTxn: liveDeletedTxnID},
+				sq.Gt{colDeletedTxn: txID},
+			})
+	}
+}
+
+func currentlyLivingObjects(original sq.SelectBuilder) sq.SelectBuilder {
+	return original.Where(sq.Eq{colDeletedTxn: liveDeletedTxnID})
+}
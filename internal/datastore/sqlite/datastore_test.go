@@ -0,0 +1,484 @@
+//go:build cgo
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sqlite "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/sqlite/migrations"
+	"github.com/authzed/spicedb/internal/testfixtures"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/datastore/test"
+	"github.com/authzed/spicedb/pkg/migrate"
+	"github.com/authzed/spicedb/pkg/namespace"
+	corev1 "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+const (
+	chunkRelationshipCount = 2000
+)
+
+// Implement TestableDatastore interface
+func (sds *Datastore) ExampleRetryableError() error {
+	return sqlite.Error{
+		Code: sqlite.ErrBusy,
+	}
+}
+
+// migratedDatabase returns the path to a new database file, migrated to head.
+func migratedDatabase(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "spicedb.db")
+
+	driver, err := migrations.NewSQLiteDriverFromURI(path)
+	require.NoError(t, err)
+	defer driver.Close(context.Background())
+
+	require.NoError(t, migrations.Manager.Run(context.Background(), driver, migrate.Head, migrate.LiveRun))
+	return path
+}
+
+func newTestDatastore(t *testing.T, options ...Option) *Datastore {
+	ds, err := newSQLiteDatastore(context.Background(), migratedDatabase(t), options...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, ds.Close())
+	})
+	return ds
+}
+
+func TestSQLiteDatastore(t *testing.T) {
+	test.AllWithExceptions(t, test.DatastoreTesterFunc(func(revisionQuantization, gcInterval, gcWindow time.Duration, watchBufferLength uint16) (datastore.Datastore, error) {
+		return newSQLiteDatastore(context.Background(), migratedDatabase(t),
+			RevisionQuantization(revisionQuantization),
+			GCWindow(gcWindow),
+			GCInterval(gcInterval),
+			WatchBufferLength(watchBufferLength),
+		)
+	}), test.WithCategories(test.WatchCheckpointsCategory))
+}
+
+var defaultOptions = []Option{
+	RevisionQuantization(0 * time.Millisecond),
+	GCWindow(1 * time.Millisecond),
+	GCInterval(0 * time.Second),
+}
+
+func TestConnectionURI(t *testing.T) {
+	tcs := []struct {
+		name          string
+		uri           string
+		expectedURI   string
+		expectedError string
+	}{
+		{"empty", "", "", "a path to the SQLite database file is required"},
+		{
+			"path",
+			"/var/lib/spicedb.db",
+			"file:/var/lib/spicedb.db?_busy_timeout=5000&_journal_mode=WAL&_synchronous=NORMAL",
+			"",
+		},
+		{
+			"file URI with overridden parameter",
+			"file:spicedb.db?_busy_timeout=100",
+			"file:spicedb.db?_busy_timeout=100&_journal_mode=WAL&_synchronous=NORMAL",
+			"",
+		},
+		{"in-memory", ":memory:", "", "in-memory SQLite databases are not supported"},
+		{"in-memory mode", "file:spicedb.db?mode=memory", "", "in-memory SQLite databases are not supported"},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			uri, err := migrations.ConnectionURI(tc.uri)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedURI, uri)
+		})
+	}
+}
+
+func TestSQLiteMigrations(t *testing.T) {
+	req := require.New(t)
+
+	driver, err := migrations.NewSQLiteDriverFromURI(filepath.Join(t.TempDir(), "spicedb.db"))
+	req.NoError(err)
+	defer driver.Close(context.Background())
+
+	version, err := driver.Version(context.Background())
+	req.NoError(err)
+	req.Equal("", version)
+
+	err = migrations.Manager.Run(context.Background(), driver, migrate.Head, migrate.LiveRun)
+	req.NoError(err)
+
+	version, err = driver.Version(context.Background())
+	req.NoError(err)
+
+	headVersion, err := migrations.Manager.HeadRevision()
+	req.NoError(err)
+	req.Equal(headVersion, version)
+}
+
+func TestUnmigratedDatastore(t *testing.T) {
+	req := require.New(t)
+
+	ds, err := newSQLiteDatastore(context.Background(), filepath.Join(t.TempDir(), "spicedb.db"))
+	req.NoError(err)
+	defer ds.Close()
+
+	r, err := ds.ReadyState(context.Background())
+	req.NoError(err)
+	req.False(r.IsReady)
+}
+
+func TestDatabaseSeeding(t *testing.T) {
+	req := require.New(t)
+	ds := newTestDatastore(t)
+
+	// ensure datastore is seeded right after initialization
+	ctx := context.Background()
+	isSeeded, err := ds.isSeeded(ctx)
+	req.NoError(err)
+	req.True(isSeeded, "expected datastore to be seeded after initialization")
+
+	r, err := ds.ReadyState(ctx)
+	req.NoError(err)
+	req.True(r.IsReady)
+}
+
+func TestGarbageCollection(t *testing.T) {
+	req := require.New(t)
+	ds := newTestDatastore(t, defaultOptions...)
+
+	ctx := context.Background()
+	r, err := ds.ReadyState(ctx)
+	req.NoError(err)
+	req.True(r.IsReady)
+
+	// Write basic namespaces.
+	writtenAt, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteNamespaces(
+			ctx,
+			namespace.Namespace(
+				"resource",
+				namespace.MustRelation("reader", nil),
+			),
+			namespace.Namespace("user"),
+		)
+	})
+	req.NoError(err)
+
+	// Run GC at the transaction and ensure no relationships are removed.
+	removed, err := ds.DeleteBeforeTx(ctx, writtenAt)
+	req.NoError(err)
+	req.Zero(removed.Relationships)
+	req.Zero(removed.Namespaces)
+
+	// Replace the namespace with a new one.
+	writtenAt, err = ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteNamespaces(
+			ctx,
+			namespace.Namespace(
+				"resource",
+				namespace.MustRelation("reader", nil),
+				namespace.MustRelation("unused", nil),
+			),
+			namespace.Namespace("user"),
+		)
+	})
+	req.NoError(err)
+
+	// Run GC to remove the old namespace
+	removed, err = ds.DeleteBeforeTx(ctx, writtenAt)
+	req.NoError(err)
+	req.Zero(removed.Relationships)
+	req.Equal(int64(1), removed.Transactions)
+	req.Equal(int64(2), removed.Namespaces)
+
+	// Write a relationship.
+	tpl := tuple.Parse("resource:someresource#reader@user:someuser#...")
+	relWrittenAt, err := common.WriteTuples(ctx, ds, corev1.RelationTupleUpdate_CREATE, tpl)
+	req.NoError(err)
+
+	// Run GC at the transaction and ensure no relationships are removed, but 1 transaction (the previous write namespace) is.
+	removed, err = ds.DeleteBeforeTx(ctx, relWrittenAt)
+	req.NoError(err)
+	req.Zero(removed.Relationships)
+	req.Equal(int64(1), removed.Transactions)
+	req.Zero(removed.Namespaces)
+
+	// Ensure the relationship is still present.
+	tRequire := testfixtures.TupleChecker{Require: req, DS: ds}
+	tRequire.TupleExists(ctx, tpl, relWrittenAt)
+
+	// Overwrite the relationship.
+	ctpl := tuple.MustWithCaveat(tpl, "somecaveat")
+	relOverwrittenAt, err := common.WriteTuples(ctx, ds, corev1.RelationTupleUpdate_TOUCH, ctpl)
+	req.NoError(err)
+
+	// Run GC at the transaction and ensure the (older copy of the) relationship is removed, as well as 1 transaction (the write).
+	removed, err = ds.DeleteBeforeTx(ctx, relOverwrittenAt)
+	req.NoError(err)
+	req.Equal(int64(1), removed.Relationships)
+	req.Equal(int64(1), removed.Transactions)
+	req.Zero(removed.Namespaces)
+
+	// Ensure the relationship is still present.
+	tRequire.TupleExists(ctx, ctpl, relOverwrittenAt)
+
+	// Delete the relationship.
+	relDeletedAt, err := common.WriteTuples(ctx, ds, corev1.RelationTupleUpdate_DELETE, ctpl)
+	req.NoError(err)
+
+	// Ensure the relationship is gone.
+	tRequire.NoTupleExists(ctx, ctpl, relDeletedAt)
+
+	// Run GC at the transaction and ensure the relationship is removed, as well as 1 transaction (the overwrite).
+	removed, err = ds.DeleteBeforeTx(ctx, relDeletedAt)
+	req.NoError(err)
+	req.Equal(int64(1), removed.Relationships)
+	req.Equal(int64(1), removed.Transactions)
+	req.Zero(removed.Namespaces)
+
+	// Run GC again and ensure there are no changes.
+	removed, err = ds.DeleteBeforeTx(ctx, relDeletedAt)
+	req.NoError(err)
+	req.Zero(removed.Relationships)
+	req.Zero(removed.Transactions)
+	req.Zero(removed.Namespaces)
+}
+
+func TestGarbageCollectionByTime(t *testing.T) {
+	req := require.New(t)
+	ds := newTestDatastore(t, defaultOptions...)
+
+	ctx := context.Background()
+
+	// Write basic namespaces.
+	_, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteNamespaces(
+			ctx,
+			namespace.Namespace(
+				"resource",
+				namespace.MustRelation("reader", nil),
+			),
+			namespace.Namespace("user"),
+		)
+	})
+	req.NoError(err)
+
+	// Sleep 1ms to ensure GC will delete the previous transaction.
+	time.Sleep(1 * time.Millisecond)
+
+	// Write a relationship.
+	tpl := tuple.Parse("resource:someresource#reader@user:someuser#...")
+
+	relLastWriteAt, err := common.WriteTuples(ctx, ds, corev1.RelationTupleUpdate_CREATE, tpl)
+	req.NoError(err)
+
+	// Run GC and ensure only transactions were removed.
+	afterWrite, err := ds.Now(ctx)
+	req.NoError(err)
+
+	afterWriteTx, err := ds.TxIDBefore(ctx, afterWrite)
+	req.NoError(err)
+
+	removed, err := ds.DeleteBeforeTx(ctx, afterWriteTx)
+	req.NoError(err)
+	req.Zero(removed.Relationships)
+	req.NotZero(removed.Transactions)
+	req.Zero(removed.Namespaces)
+
+	// Ensure the relationship is still present.
+	tRequire := testfixtures.TupleChecker{Require: req, DS: ds}
+	tRequire.TupleExists(ctx, tpl, relLastWriteAt)
+
+	// Sleep 1ms to ensure GC will delete the previous write.
+	time.Sleep(1 * time.Millisecond)
+
+	// Delete the relationship.
+	relDeletedAt, err := common.WriteTuples(ctx, ds, corev1.RelationTupleUpdate_DELETE, tpl)
+	req.NoError(err)
+
+	// Run GC and ensure the relationship is removed.
+	afterDelete, err := ds.Now(ctx)
+	req.NoError(err)
+
+	afterDeleteTx, err := ds.TxIDBefore(ctx, afterDelete)
+	req.NoError(err)
+
+	removed, err = ds.DeleteBeforeTx(ctx, afterDeleteTx)
+	req.NoError(err)
+	req.Equal(int64(1), removed.Relationships)
+	req.Equal(int64(1), removed.Transactions)
+	req.Zero(removed.Namespaces)
+
+	// Ensure the relationship is still not present.
+	tRequire.NoTupleExists(ctx, tpl, relDeletedAt)
+}
+
+func TestChunkedGarbageCollection(t *testing.T) {
+	req := require.New(t)
+	ds := newTestDatastore(t, defaultOptions...)
+
+	ctx := context.Background()
+
+	// Write basic namespaces.
+	_, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteNamespaces(
+			ctx,
+			namespace.Namespace(
+				"resource",
+				namespace.MustRelation("reader", nil),
+			),
+			namespace.Namespace("user"),
+		)
+	})
+	req.NoError(err)
+
+	// Prepare relationships to write.
+	var tuples []*corev1.RelationTuple
+	for i := 0; i < chunkRelationshipCount; i++ {
+		tpl := tuple.Parse(fmt.Sprintf("resource:resource-%d#reader@user:someuser#...", i))
+		tuples = append(tuples, tpl)
+	}
+
+	// Write a large number of relationships.
+	_, err = common.WriteTuples(ctx, ds, corev1.RelationTupleUpdate_CREATE, tuples...)
+	req.NoError(err)
+
+	// Sleep to ensure the relationships will GC.
+	time.Sleep(1 * time.Millisecond)
+
+	// Delete all the relationships.
+	deletedAt, err := common.WriteTuples(ctx, ds, corev1.RelationTupleUpdate_DELETE, tuples...)
+	req.NoError(err)
+
+	// Ensure the relationships were deleted.
+	tRequire := testfixtures.TupleChecker{Require: req, DS: ds}
+	for _, tpl := range tuples {
+		tRequire.NoTupleExists(ctx, tpl, deletedAt)
+	}
+
+	// Sleep to ensure GC.
+	time.Sleep(1 * time.Millisecond)
+
+	// Run GC and ensure all the stale relationships are removed.
+	afterDelete, err := ds.Now(ctx)
+	req.NoError(err)
+
+	afterDeleteTx, err := ds.TxIDBefore(ctx, afterDelete)
+	req.NoError(err)
+
+	removed, err := ds.DeleteBeforeTx(ctx, afterDeleteTx)
+	req.NoError(err)
+	req.Equal(int64(chunkRelationshipCount), removed.Relationships)
+	req.Zero(removed.Namespaces)
+}
+
+func TestQuantizedRevisions(t *testing.T) {
+	testCases := []struct {
+		testName         string
+		quantization     time.Duration
+		relativeTimes    []time.Duration
+		expectedRevision uint64
+	}{
+		{"DefaultRevision", 1 * time.Second, []time.Duration{}, 1},
+		{"OnlyPastRevisions", 1 * time.Second, []time.Duration{-2 * time.Second}, 2},
+		{"OnlyFutureRevisions", 1 * time.Second, []time.Duration{2 * time.Second}, 2},
+		{"QuantizedLower", 1 * time.Second, []time.Duration{-2 * time.Second, -1 * time.Nanosecond, 0}, 3},
+		{"QuantizationDisabled", 1 * time.Nanosecond, []time.Duration{-2 * time.Second, -1 * time.Nanosecond, 0}, 4},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			ds := newTestDatastore(t, RevisionQuantization(tc.quantization), GCWindow(24*time.Hour))
+
+			now, err := ds.Now(ctx)
+			require.NoError(err)
+
+			if len(tc.relativeTimes) > 0 {
+				bulkWrite := sb.Insert(migrations.TableTransaction).Columns(colTimestamp)
+				for _, offset := range tc.relativeTimes {
+					bulkWrite = bulkWrite.Values(now.Add(offset).UnixNano())
+				}
+
+				sql, args, err := bulkWrite.ToSql()
+				require.NoError(err)
+
+				_, err = ds.db.ExecContext(ctx, sql, args...)
+				require.NoError(err)
+			}
+
+			revision, validFor, err := ds.optimizedRevisionFunc(ctx)
+			require.NoError(err)
+			require.Greater(validFor, time.Duration(0))
+			require.LessOrEqual(validFor, tc.quantization)
+			require.Equal(fmt.Sprintf("%d", tc.expectedRevision), revision.String())
+		})
+	}
+}
+
+func TestWatchSchemaUpdate(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ds := newTestDatastore(t, defaultOptions...)
+
+	_, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		if err := rwt.WriteNamespaces(ctx, namespace.Namespace("document")); err != nil {
+			return err
+		}
+		return rwt.WriteCaveats(ctx, []*corev1.CaveatDefinition{{Name: "somecaveat"}})
+	})
+	require.NoError(err)
+
+	startRevision, err := ds.HeadRevision(ctx)
+	require.NoError(err)
+
+	// Rewriting a definition deletes its previous version in the same transaction, which must
+	// be reported as a change rather than a deletion.
+	_, err = ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		if err := rwt.WriteNamespaces(ctx, namespace.Namespace("document", namespace.MustRelation("viewer", nil))); err != nil {
+			return err
+		}
+		return rwt.WriteCaveats(ctx, []*corev1.CaveatDefinition{{Name: "somecaveat"}})
+	})
+	require.NoError(err)
+
+	changes, errs := ds.Watch(ctx, startRevision, datastore.WatchJustSchema())
+	select {
+	case change := <-changes:
+		require.Empty(change.DeletedNamespaces)
+		require.Empty(change.DeletedCaveats)
+		require.Len(change.ChangedDefinitions, 2)
+		for _, def := range change.ChangedDefinitions {
+			if ns, ok := def.(*corev1.NamespaceDefinition); ok {
+				require.Len(ns.Relation, 1)
+			}
+		}
+	case err := <-errs:
+		require.NoError(err)
+	case <-time.After(5 * time.Second):
+		require.Fail("timed out waiting for schema changes")
+	}
+}
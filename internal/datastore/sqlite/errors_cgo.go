@@ -0,0 +1,37 @@
+//go:build cgo
+
+package sqlite
+
+import (
+	"errors"
+	"strings"
+
+	sqlite "github.com/mattn/go-sqlite3"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/sqlite/migrations"
+	log "github.com/authzed/spicedb/internal/logging"
+)
+
+func isErrorRetryable(err error) bool {
+	var sqliteErr sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		log.Debug().Err(err).Msg("couldn't determine a SQLite error code")
+		return false
+	}
+
+	return sqliteErr.Code == sqlite.ErrBusy || sqliteErr.Code == sqlite.ErrLocked
+}
+
+// convertToWriteConstraintError converts a violation of the uniqueness of living relationships
+// into the corresponding SpiceDB error. Unlike other engines, SQLite does not report the
+// conflicting values, so the relationship is not included.
+func convertToWriteConstraintError(err error) error {
+	var sqliteErr sqlite.Error
+	if errors.As(err, &sqliteErr) &&
+		(sqliteErr.ExtendedCode == sqlite.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite.ErrConstraintPrimaryKey) &&
+		strings.Contains(sqliteErr.Error(), migrations.TableTuple+"."+colNamespace) {
+		return common.NewCreateRelationshipExistsError(nil)
+	}
+	return nil
+}
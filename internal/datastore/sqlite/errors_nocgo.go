@@ -0,0 +1,13 @@
+//go:build !cgo
+
+package sqlite
+
+// The datastore cannot be instantiated without cgo, so no SQLite error is ever returned.
+
+func isErrorRetryable(_ error) bool {
+	return false
+}
+
+func convertToWriteConstraintError(_ error) error {
+	return nil
+}
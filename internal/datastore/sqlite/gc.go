@@ -0,0 +1,126 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/internal/datastore/sqlite/migrations"
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/datastore"
+)
+
+var _ common.GarbageCollector = (*Datastore)(nil)
+
+func (sds *Datastore) HasGCRun() bool {
+	return sds.gcHasRun.Load()
+}
+
+func (sds *Datastore) MarkGCCompleted() {
+	sds.gcHasRun.Store(true)
+}
+
+func (sds *Datastore) ResetGCCompleted() {
+	sds.gcHasRun.Store(false)
+}
+
+// Now returns the current time from the local clock, which is the same clock used to
+// timestamp transactions.
+func (sds *Datastore) Now(_ context.Context) (time.Time, error) {
+	return time.Now().UTC(), nil
+}
+
+func (sds *Datastore) TxIDBefore(ctx context.Context, before time.Time) (datastore.Revision, error) {
+	// Find the highest transaction ID before the GC window.
+	query, args, err := sds.GetLastRevision.Where(sq.Lt{colTimestamp: before.UnixNano()}).ToSql()
+	if err != nil {
+		return datastore.NoRevision, err
+	}
+
+	var value sql.NullInt64
+	err = sds.db.QueryRowContext(ctx, query, args...).Scan(&value)
+	if err != nil {
+		return datastore.NoRevision, err
+	}
+
+	if !value.Valid {
+		log.Ctx(ctx).Debug().Time("before", before).Msg("no stale transactions found in the datastore")
+		return datastore.NoRevision, nil
+	}
+
+	return revisions.NewForTransactionID(uint64(value.Int64)), nil
+}
+
+func (sds *Datastore) DeleteBeforeTx(
+	ctx context.Context,
+	txID datastore.Revision,
+) (removed common.DeletionCounts, err error) {
+	txIDValue := txID.(revisions.TransactionIDRevision).TransactionID()
+
+	// Delete any relationship rows with deleted_transaction <= the transaction ID.
+	removed.Relationships, err = sds.batchDelete(ctx, migrations.TableTuple, sq.LtOrEq{colDeletedTxn: txIDValue})
+	if err != nil {
+		return
+	}
+
+	// Delete all transaction rows with ID < the transaction ID.
+	//
+	// We don't delete the transaction itself to ensure there is always at least
+	// one transaction present.
+	removed.Transactions, err = sds.batchDelete(ctx, migrations.TableTransaction, sq.Lt{colID: txIDValue})
+	if err != nil {
+		return
+	}
+
+	// Delete any namespace rows with deleted_transaction <= the transaction ID.
+	removed.Namespaces, err = sds.batchDelete(ctx, migrations.TableNamespace, sq.LtOrEq{colDeletedTxn: txIDValue})
+	if err != nil {
+		return
+	}
+
+	// Delete any caveat rows with deleted_transaction <= the transaction ID.
+	_, err = sds.batchDelete(ctx, migrations.TableCaveat, sq.LtOrEq{colDeletedTxn: txIDValue})
+	return
+}
+
+// DeleteExpiredRels deletes any relationship rows which expired before the given time.
+// Expired relationships are already invisible to reads, so they are removed outright
+// rather than marked as deleted.
+func (sds *Datastore) DeleteExpiredRels(ctx context.Context, before time.Time) (int64, error) {
+	return sds.batchDelete(ctx, migrations.TableTuple, sq.Lt{colExpiration: before.UTC()})
+}
+
+// batchDelete deletes the rows matching the filter in batches, to avoid holding the write
+// lock for extended periods of time. SQLite is not built with support for DELETE ... LIMIT,
+// so each batch is selected by rowid.
+func (sds *Datastore) batchDelete(ctx context.Context, tableName string, filter sqlFilter) (int64, error) {
+	selectSQL, args, err := sb.Select("rowid").From(tableName).Where(filter).Limit(batchDeleteSize).ToSql()
+	if err != nil {
+		return -1, err
+	}
+
+	query := "DELETE FROM " + tableName + " WHERE rowid IN (" + selectSQL + ")"
+
+	var deletedCount int64
+	for {
+		cr, err := sds.db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return deletedCount, err
+		}
+
+		rowsDeleted, err := cr.RowsAffected()
+		if err != nil {
+			return deletedCount, err
+		}
+		deletedCount += rowsDeleted
+		if rowsDeleted < batchDeleteSize {
+			break
+		}
+	}
+
+	return deletedCount, nil
+}
@@ -0,0 +1,173 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/authzed/spicedb/pkg/migrate"
+)
+
+const (
+	errUnableToInstantiate = "unable to instantiate SQLiteDriver: %w"
+
+	// DriverName is the name under which the SQLite driver is registered with database/sql.
+	DriverName = "sqlite3"
+
+	// defaultBusyTimeoutMillis is the time a connection waits for a lock held by another
+	// connection before failing with SQLITE_BUSY.
+	defaultBusyTimeoutMillis = "5000"
+)
+
+// connectionDefaults are the connection parameters applied to every connection, unless
+// overridden in the URI.
+//
+// Write-ahead logging allows reads to proceed concurrently with a write. Transactions are
+// started as DEFERRED, so the write lock is only acquired upon the first write; a transaction
+// waiting on the lock fails with SQLITE_BUSY once the busy timeout elapses, and is retried.
+var connectionDefaults = map[string]string{
+	"_journal_mode": "WAL",
+	"_busy_timeout": defaultBusyTimeoutMillis,
+	"_synchronous":  "NORMAL",
+}
+
+// ConnectionURI returns the URI for connecting to the SQLite database at the given path or `file:`
+// URI, with the connection parameters required by the datastore.
+func ConnectionURI(uri string) (string, error) {
+	if uri == "" {
+		return "", errors.New("a path to the SQLite database file is required")
+	}
+
+	path, rawQuery, _ := strings.Cut(uri, "?")
+	if !strings.HasPrefix(path, "file:") {
+		path = "file:" + path
+	}
+
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", fmt.Errorf("invalid SQLite connection parameters: %w", err)
+	}
+
+	if params.Get("mode") == "memory" || strings.HasPrefix(path, "file::memory:") {
+		return "", errors.New("in-memory SQLite databases are not supported; use the memory datastore engine instead")
+	}
+
+	for key, value := range connectionDefaults {
+		if !params.Has(key) {
+			params.Set(key, value)
+		}
+	}
+
+	return path + "?" + params.Encode(), nil
+}
+
+// SQLiteDriver is an implementation of migrate.Driver for SQLite
+type SQLiteDriver struct {
+	db *sql.DB
+}
+
+// NewSQLiteDriverFromURI creates a new migration driver for the SQLite database at the given
+// path or `file:` URI.
+func NewSQLiteDriverFromURI(uri string) (*SQLiteDriver, error) {
+	if err := CheckDriverAvailable(); err != nil {
+		return nil, fmt.Errorf(errUnableToInstantiate, err)
+	}
+
+	connectionURI, err := ConnectionURI(uri)
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToInstantiate, err)
+	}
+
+	db, err := sql.Open(DriverName, connectionURI)
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToInstantiate, err)
+	}
+
+	return NewSQLiteDriverFromDB(db), nil
+}
+
+// NewSQLiteDriverFromDB creates a new migration driver with a connection pool specified upfront.
+func NewSQLiteDriverFromDB(db *sql.DB) *SQLiteDriver {
+	return &SQLiteDriver{db}
+}
+
+// Version returns the version of the schema to which the connected database
+// has been migrated.
+func (driver *SQLiteDriver) Version(ctx context.Context) (string, error) {
+	var loaded string
+	err := driver.db.QueryRowContext(ctx, fmt.Sprintf("SELECT version_num FROM %s", tableMigrationVersion)).Scan(&loaded)
+	if err != nil {
+		if isMissingTableError(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("unable to load migration revision: %w", err)
+	}
+
+	return loaded, nil
+}
+
+// Conn returns the underlying connection pool.
+func (driver *SQLiteDriver) Conn() *sql.DB {
+	return driver.db
+}
+
+// RunTx runs the given migration function within a transaction.
+func (driver *SQLiteDriver) RunTx(ctx context.Context, f migrate.TxMigrationFunc[*sql.Tx]) error {
+	return BeginTxFunc(ctx, driver.db, func(tx *sql.Tx) error {
+		return f(ctx, tx)
+	})
+}
+
+// BeginTxFunc is a polyfill for database/sql which implements a closure style transaction lifecycle.
+// The underlying transaction is aborted if the supplied function returns an error.
+// The underlying transaction is committed if the supplied function returns nil.
+func BeginTxFunc(ctx context.Context, db *sql.DB, f func(*sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := f(tx); err != nil {
+		rerr := tx.Rollback()
+		if rerr != nil {
+			return errors.Join(err, rerr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// WriteVersion overwrites the version stored in the migration version table.
+func (driver *SQLiteDriver) WriteVersion(ctx context.Context, tx *sql.Tx, version, replaced string) error {
+	result, err := tx.ExecContext(
+		ctx,
+		fmt.Sprintf("UPDATE %s SET version_num = ? WHERE version_num = ?", tableMigrationVersion),
+		version,
+		replaced,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to update version row: %w", err)
+	}
+
+	updatedCount, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to update version row: %w", err)
+	}
+
+	if updatedCount != 1 {
+		return fmt.Errorf("writing version update affected %d rows, should be 1", updatedCount)
+	}
+
+	return nil
+}
+
+// Close closes the underlying connection pool.
+func (driver *SQLiteDriver) Close(_ context.Context) error {
+	return driver.db.Close()
+}
+
+var _ migrate.Driver[*sql.DB, *sql.Tx] = &SQLiteDriver{}
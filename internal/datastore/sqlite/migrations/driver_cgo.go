@@ -0,0 +1,21 @@
+//go:build cgo
+
+package migrations
+
+import (
+	"errors"
+	"strings"
+
+	sqlite "github.com/mattn/go-sqlite3"
+)
+
+// CheckDriverAvailable returns an error if the SQLite driver cannot be used by this build.
+func CheckDriverAvailable() error {
+	return nil
+}
+
+func isMissingTableError(err error) bool {
+	var sqliteErr sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite.ErrError &&
+		strings.HasPrefix(sqliteErr.Error(), "no such table")
+}
@@ -0,0 +1,18 @@
+//go:build !cgo
+
+package migrations
+
+import "errors"
+
+// ErrCgoRequired is returned when opening a SQLite database from a build without cgo, which the
+// SQLite driver requires.
+var ErrCgoRequired = errors.New("the sqlite datastore engine is unavailable: SpiceDB was built without cgo (CGO_ENABLED=0)")
+
+// CheckDriverAvailable returns an error if the SQLite driver cannot be used by this build.
+func CheckDriverAvailable() error {
+	return ErrCgoRequired
+}
+
+func isMissingTableError(_ error) bool {
+	return false
+}
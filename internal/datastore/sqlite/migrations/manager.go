@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/authzed/spicedb/pkg/migrate"
+)
+
+var (
+	noNonatomicMigration migrate.MigrationFunc[*sql.DB]
+
+	// Manager is the singleton migration manager instance for SQLite
+	Manager = migrate.NewManager[*SQLiteDriver, *sql.DB, *sql.Tx]()
+)
+
+func mustRegisterMigration(version, replaces string, up migrate.MigrationFunc[*sql.DB], upTx migrate.TxMigrationFunc[*sql.Tx]) {
+	if err := Manager.Register(version, replaces, up, upTx); err != nil {
+		panic("failed to register migration  " + err.Error())
+	}
+}
+
+// newStatementBatch returns a transactional migration which executes each of the given
+// statements in order.
+func newStatementBatch(statements ...string) migrate.TxMigrationFunc[*sql.Tx] {
+	return func(ctx context.Context, tx *sql.Tx) error {
+		for _, stmt := range statements {
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return fmt.Errorf("statementBatch.execute: failed to exec statement: %w", err)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,25 @@
+package migrations
+
+const (
+	// TableNamespace is the table holding the namespace definitions.
+	TableNamespace = "namespace_config"
+
+	// TableTransaction is the table holding the transactions, from which revisions are derived.
+	TableTransaction = "relation_tuple_transaction"
+
+	// TableTuple is the table holding the relationships.
+	TableTuple = "relation_tuple"
+
+	// TableCaveat is the table holding the caveat definitions.
+	TableCaveat = "caveat"
+
+	// TableSchemaVersion is the table holding the history of the schema.
+	TableSchemaVersion = "schema_version"
+
+	// TableMetadata is the table holding the unique ID of the datastore.
+	TableMetadata = "metadata"
+
+	// tableMigrationVersion is the table holding the current migration version. Note that SQLite
+	// reserves all table names starting with `sqlite_`.
+	tableMigrationVersion = "migration_version"
+)
@@ -0,0 +1,87 @@
+package migrations
+
+import "fmt"
+
+var createMigrationVersion = fmt.Sprintf(`CREATE TABLE %s (
+	version_num TEXT NOT NULL);`,
+	tableMigrationVersion,
+)
+
+var insertEmptyVersion = fmt.Sprintf(`INSERT INTO %s (version_num) VALUES ('');`, tableMigrationVersion)
+
+// timestamp holds the time of the transaction, in nanoseconds since the Unix epoch.
+var createRelationTupleTransaction = fmt.Sprintf(`CREATE TABLE %s (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	timestamp INTEGER NOT NULL);`,
+	TableTransaction,
+)
+
+var createTransactionTimestampIndex = fmt.Sprintf(`CREATE INDEX ix_relation_tuple_transaction_by_timestamp ON %s (timestamp);`,
+	TableTransaction,
+)
+
+var createNamespaceConfig = fmt.Sprintf(`CREATE TABLE %s (
+	namespace TEXT NOT NULL,
+	serialized_config BLOB NOT NULL,
+	created_transaction INTEGER NOT NULL,
+	deleted_transaction INTEGER NOT NULL DEFAULT 9223372036854775807,
+	CONSTRAINT pk_namespace_config PRIMARY KEY (namespace, created_transaction),
+	CONSTRAINT uq_namespace_living UNIQUE (namespace, deleted_transaction));`,
+	TableNamespace,
+)
+
+var createRelationTuple = fmt.Sprintf(`CREATE TABLE %s (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	namespace TEXT NOT NULL,
+	object_id TEXT NOT NULL,
+	relation TEXT NOT NULL,
+	userset_namespace TEXT NOT NULL,
+	userset_object_id TEXT NOT NULL,
+	userset_relation TEXT NOT NULL,
+	caveat_name TEXT NOT NULL DEFAULT '',
+	caveat_context BLOB,
+	expiration DATETIME,
+	created_transaction INTEGER NOT NULL,
+	deleted_transaction INTEGER NOT NULL DEFAULT 9223372036854775807,
+	CONSTRAINT uq_relation_tuple_living UNIQUE (namespace, object_id, relation, userset_namespace, userset_object_id, userset_relation, deleted_transaction));`,
+	TableTuple,
+)
+
+var createRelationTupleIndexes = []string{
+	fmt.Sprintf(`CREATE INDEX ix_relation_tuple_by_subject ON %s (userset_object_id, userset_namespace, userset_relation, namespace, relation);`, TableTuple),
+	fmt.Sprintf(`CREATE INDEX ix_relation_tuple_by_subject_relation ON %s (userset_namespace, userset_relation, namespace, relation);`, TableTuple),
+	fmt.Sprintf(`CREATE INDEX ix_relation_tuple_by_created_transaction ON %s (created_transaction);`, TableTuple),
+	fmt.Sprintf(`CREATE INDEX ix_relation_tuple_by_deleted_transaction ON %s (deleted_transaction);`, TableTuple),
+	fmt.Sprintf(`CREATE INDEX ix_relation_tuple_by_expiration ON %s (expiration) WHERE expiration IS NOT NULL;`, TableTuple),
+}
+
+var createCaveat = fmt.Sprintf(`CREATE TABLE %s (
+	name TEXT NOT NULL,
+	definition BLOB NOT NULL,
+	created_transaction INTEGER NOT NULL,
+	deleted_transaction INTEGER NOT NULL DEFAULT 9223372036854775807,
+	CONSTRAINT pk_caveat PRIMARY KEY (name, deleted_transaction),
+	CONSTRAINT uq_caveat UNIQUE (name, created_transaction, deleted_transaction));`,
+	TableCaveat,
+)
+
+var createMetadata = fmt.Sprintf(`CREATE TABLE %s (
+	id INTEGER NOT NULL PRIMARY KEY,
+	unique_id TEXT NOT NULL);`,
+	TableMetadata,
+)
+
+func init() {
+	statements := []string{
+		createMigrationVersion,
+		insertEmptyVersion,
+		createRelationTupleTransaction,
+		createTransactionTimestampIndex,
+		createNamespaceConfig,
+		createRelationTuple,
+	}
+	statements = append(statements, createRelationTupleIndexes...)
+	statements = append(statements, createCaveat, createMetadata)
+
+	mustRegisterMigration("initial", "", noNonatomicMigration, newStatementBatch(statements...))
+}
@@ -0,0 +1,18 @@
+package migrations
+
+import "fmt"
+
+// Versions of the schema are never deleted: deleted_transaction only allows reading them with the
+// same snapshot filtering as the other tables.
+var createSchemaVersion = fmt.Sprintf(`CREATE TABLE %s (
+	version INTEGER NOT NULL PRIMARY KEY,
+	schema_text TEXT NOT NULL,
+	author TEXT NOT NULL DEFAULT '',
+	created_transaction INTEGER NOT NULL,
+	deleted_transaction INTEGER NOT NULL DEFAULT 9223372036854775807);`,
+	TableSchemaVersion,
+)
+
+func init() {
+	mustRegisterMigration("add-schema-versions", "initial", noNonatomicMigration, newStatementBatch(createSchemaVersion))
+}
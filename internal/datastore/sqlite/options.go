@@ -0,0 +1,177 @@
+package sqlite
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	errQuantizationTooLarge = "revision quantization interval (%s) must be less than GC window (%s)"
+
+	defaultGarbageCollectionWindow           = 24 * time.Hour
+	defaultGarbageCollectionInterval         = time.Minute * 3
+	defaultGarbageCollectionMaxOperationTime = time.Minute
+	defaultMaxOpenConns                      = 10
+	defaultWatchBufferLength                 = 128
+	defaultWatchBufferWriteTimeout           = 1 * time.Second
+	defaultQuantization                      = 5 * time.Second
+	defaultMaxRevisionStalenessPercent       = 0.1
+	defaultEnablePrometheusStats             = false
+	defaultMaxRetries                        = 8
+	defaultGCEnabled                         = true
+)
+
+type sqliteOptions struct {
+	revisionQuantization        time.Duration
+	gcWindow                    time.Duration
+	gcInterval                  time.Duration
+	gcMaxOperationTime          time.Duration
+	maxRevisionStalenessPercent float64
+	watchBufferLength           uint16
+	watchBufferWriteTimeout     time.Duration
+	enablePrometheusStats       bool
+	maxOpenConns                int
+	maxRetries                  uint8
+	gcEnabled                   bool
+}
+
+// Option provides the facility to configure how clients within the
+// SQLite datastore interact with the SQLite database.
+type Option func(*sqliteOptions)
+
+func generateConfig(options []Option) (sqliteOptions, error) {
+	computed := sqliteOptions{
+		gcWindow:                    defaultGarbageCollectionWindow,
+		gcInterval:                  defaultGarbageCollectionInterval,
+		gcMaxOperationTime:          defaultGarbageCollectionMaxOperationTime,
+		watchBufferLength:           defaultWatchBufferLength,
+		watchBufferWriteTimeout:     defaultWatchBufferWriteTimeout,
+		maxOpenConns:                defaultMaxOpenConns,
+		revisionQuantization:        defaultQuantization,
+		maxRevisionStalenessPercent: defaultMaxRevisionStalenessPercent,
+		enablePrometheusStats:       defaultEnablePrometheusStats,
+		maxRetries:                  defaultMaxRetries,
+		gcEnabled:                   defaultGCEnabled,
+	}
+
+	for _, option := range options {
+		option(&computed)
+	}
+
+	// Run any checks on the config that need to be done
+	if computed.revisionQuantization >= computed.gcWindow {
+		return computed, fmt.Errorf(
+			errQuantizationTooLarge,
+			computed.revisionQuantization,
+			computed.gcWindow,
+		)
+	}
+
+	return computed, nil
+}
+
+// WatchBufferLength is the number of entries that can be stored in the watch
+// buffer while awaiting read by the client.
+//
+// This value defaults to 128.
+func WatchBufferLength(watchBufferLength uint16) Option {
+	return func(so *sqliteOptions) {
+		so.watchBufferLength = watchBufferLength
+	}
+}
+
+// WatchBufferWriteTimeout is the maximum timeout for writing to the watch buffer,
+// after which the caller to the watch will be disconnected.
+func WatchBufferWriteTimeout(watchBufferWriteTimeout time.Duration) Option {
+	return func(so *sqliteOptions) { so.watchBufferWriteTimeout = watchBufferWriteTimeout }
+}
+
+// RevisionQuantization is the time bucket size to which advertised
+// revisions will be rounded.
+//
+// This value defaults to 5 seconds.
+func RevisionQuantization(quantization time.Duration) Option {
+	return func(so *sqliteOptions) {
+		so.revisionQuantization = quantization
+	}
+}
+
+// MaxRevisionStalenessPercent is the amount of time, expressed as a percentage of
+// the revision quantization window, that a previously computed rounded revision
+// can still be advertised after the next rounded revision would otherwise be ready.
+//
+// This value defaults to 0.1 (10%).
+func MaxRevisionStalenessPercent(stalenessPercent float64) Option {
+	return func(so *sqliteOptions) {
+		so.maxRevisionStalenessPercent = stalenessPercent
+	}
+}
+
+// GCWindow is the maximum age of a passed revision that will be considered
+// valid.
+//
+// This value defaults to 24 hours.
+func GCWindow(window time.Duration) Option {
+	return func(so *sqliteOptions) {
+		so.gcWindow = window
+	}
+}
+
+// GCInterval is the interval at which garbage collection will occur.
+//
+// This value defaults to 3 minutes.
+func GCInterval(interval time.Duration) Option {
+	return func(so *sqliteOptions) {
+		so.gcInterval = interval
+	}
+}
+
+// GCEnabled indicates whether garbage collection is enabled.
+//
+// GC is enabled by default.
+func GCEnabled(isGCEnabled bool) Option {
+	return func(so *sqliteOptions) {
+		so.gcEnabled = isGCEnabled
+	}
+}
+
+// GCMaxOperationTime is the maximum operation time of a garbage collection
+// pass before it times out.
+//
+// This value defaults to 1 minute.
+func GCMaxOperationTime(time time.Duration) Option {
+	return func(so *sqliteOptions) {
+		so.gcMaxOperationTime = time
+	}
+}
+
+// MaxRetries is the maximum number of times a transaction which failed to acquire
+// the write lock will be client-side retried.
+//
+// This value defaults to 8.
+func MaxRetries(maxRetries uint8) Option {
+	return func(so *sqliteOptions) {
+		so.maxRetries = maxRetries
+	}
+}
+
+// MaxOpenConns is the maximum number of open connections to the database file.
+// Only a single connection can write at a time; the others serve reads.
+// See https://pkg.go.dev/database/sql#DB.SetMaxOpenConns
+//
+// This value defaults to 10.
+func MaxOpenConns(conns int) Option {
+	return func(so *sqliteOptions) {
+		so.maxOpenConns = conns
+	}
+}
+
+// WithEnablePrometheusStats marks whether Prometheus metrics provided by Go's database/sql package
+// are enabled.
+//
+// Prometheus metrics are disabled by default.
+func WithEnablePrometheusStats(enablePrometheusStats bool) Option {
+	return func(so *sqliteOptions) {
+		so.enablePrometheusStats = enablePrometheusStats
+	}
+}
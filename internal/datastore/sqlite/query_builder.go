@@ -0,0 +1,224 @@
+package sqlite
+
+import (
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/authzed/spicedb/internal/datastore/sqlite/migrations"
+)
+
+// QueryBuilder captures all parameterizable queries used
+// by the SQLite datastore implementation
+type QueryBuilder struct {
+	GetLastRevision  sq.SelectBuilder
+	GetRevisionRange sq.SelectBuilder
+
+	WriteNamespaceQuery        sq.InsertBuilder
+	ReadNamespaceQuery         sq.SelectBuilder
+	DeleteNamespaceQuery       sq.UpdateBuilder
+	DeleteNamespaceTuplesQuery sq.UpdateBuilder
+	QueryChangedNamespaceQuery sq.SelectBuilder
+
+	QueryTuplesWithIdsQuery sq.SelectBuilder
+	QueryTuplesQuery        sq.SelectBuilder
+	DeleteTupleQuery        sq.UpdateBuilder
+	QueryTupleExistsQuery   sq.SelectBuilder
+	WriteTupleQuery         sq.InsertBuilder
+	QueryChangedQuery       sq.SelectBuilder
+	CountTupleQuery         sq.SelectBuilder
+
+	WriteCaveatQuery        sq.InsertBuilder
+	ReadCaveatQuery         sq.SelectBuilder
+	ListCaveatsQuery        sq.SelectBuilder
+	DeleteCaveatQuery       sq.UpdateBuilder
+	QueryChangedCaveatQuery sq.SelectBuilder
+
+	WriteSchemaVersionQuery  sq.InsertBuilder
+	ReadSchemaVersionQuery   sq.SelectBuilder
+	LatestSchemaVersionQuery sq.SelectBuilder
+}
+
+// NewQueryBuilder returns a new QueryBuilder instance.
+func NewQueryBuilder() *QueryBuilder {
+	builder := QueryBuilder{}
+
+	// transaction builders
+	builder.GetLastRevision = getLastRevision(migrations.TableTransaction)
+	builder.GetRevisionRange = getRevisionRange(migrations.TableTransaction)
+
+	// namespace builders
+	builder.WriteNamespaceQuery = writeNamespace(migrations.TableNamespace)
+	builder.ReadNamespaceQuery = readNamespace(migrations.TableNamespace)
+	builder.DeleteNamespaceQuery = deleteNamespace(migrations.TableNamespace)
+	builder.QueryChangedNamespaceQuery = queryChangedNamespaces(migrations.TableNamespace)
+
+	// tuple builders
+	builder.QueryTuplesWithIdsQuery = queryTuplesWithIds(migrations.TableTuple)
+	builder.DeleteNamespaceTuplesQuery = deleteNamespaceTuples(migrations.TableTuple)
+	builder.QueryTuplesQuery = queryTuples(migrations.TableTuple)
+	builder.DeleteTupleQuery = deleteTuple(migrations.TableTuple)
+	builder.QueryTupleExistsQuery = queryTupleExists(migrations.TableTuple)
+	builder.WriteTupleQuery = writeTuple(migrations.TableTuple)
+	builder.QueryChangedQuery = queryChanged(migrations.TableTuple)
+	builder.CountTupleQuery = countTuples(migrations.TableTuple)
+
+	// caveat builders
+	builder.ReadCaveatQuery = readCaveat(migrations.TableCaveat)
+	builder.ListCaveatsQuery = listCaveats(migrations.TableCaveat)
+	builder.WriteCaveatQuery = writeCaveat(migrations.TableCaveat)
+	builder.DeleteCaveatQuery = deleteCaveat(migrations.TableCaveat)
+	builder.QueryChangedCaveatQuery = queryChangedCaveats(migrations.TableCaveat)
+
+	// schema version builders
+	builder.WriteSchemaVersionQuery = writeSchemaVersion(migrations.TableSchemaVersion)
+	builder.ReadSchemaVersionQuery = readSchemaVersion(migrations.TableSchemaVersion)
+	builder.LatestSchemaVersionQuery = latestSchemaVersion(migrations.TableSchemaVersion)
+
+	return &builder
+}
+
+func listCaveats(tableCaveat string) sq.SelectBuilder {
+	return sb.Select(colCaveatDefinition, colCreatedTxn).From(tableCaveat).OrderBy(colName)
+}
+
+func deleteCaveat(tableCaveat string) sq.UpdateBuilder {
+	return sb.Update(tableCaveat).Where(sq.Eq{colDeletedTxn: liveDeletedTxnID})
+}
+
+func queryChangedCaveats(tableCaveat string) sq.SelectBuilder {
+	return sb.Select(colCaveatDefinition, colCreatedTxn, colDeletedTxn).From(tableCaveat)
+}
+
+func writeCaveat(tableCaveat string) sq.InsertBuilder {
+	return sb.Insert(tableCaveat).Columns(
+		colName,
+		colCaveatDefinition,
+		colCreatedTxn,
+	)
+}
+
+func readCaveat(tableCaveat string) sq.SelectBuilder {
+	return sb.Select(colCaveatDefinition, colCreatedTxn).From(tableCaveat)
+}
+
+func writeSchemaVersion(tableSchemaVersion string) sq.InsertBuilder {
+	return sb.Insert(tableSchemaVersion).Columns(
+		colVersion,
+		colSchema,
+		colAuthor,
+		colCreatedTxn,
+	)
+}
+
+func readSchemaVersion(tableSchemaVersion string) sq.SelectBuilder {
+	return sb.Select(colVersion, colSchema, colAuthor, colCreatedTxn).From(tableSchemaVersion)
+}
+
+func latestSchemaVersion(tableSchemaVersion string) sq.SelectBuilder {
+	return sb.Select("COALESCE(MAX(" + colVersion + "), 0)").From(tableSchemaVersion)
+}
+
+func getLastRevision(tableTransaction string) sq.SelectBuilder {
+	return sb.Select("MAX(id)").From(tableTransaction).Limit(1)
+}
+
+func getRevisionRange(tableTransaction string) sq.SelectBuilder {
+	return sb.Select("MIN(id)", "MAX(id)").From(tableTransaction)
+}
+
+func writeNamespace(tableNamespace string) sq.InsertBuilder {
+	return sb.Insert(tableNamespace).Columns(
+		colNamespace,
+		colConfig,
+		colCreatedTxn,
+	)
+}
+
+func readNamespace(tableNamespace string) sq.SelectBuilder {
+	return sb.Select(colConfig, colCreatedTxn).From(tableNamespace)
+}
+
+func deleteNamespace(tableNamespace string) sq.UpdateBuilder {
+	return sb.Update(tableNamespace).Where(sq.Eq{colDeletedTxn: liveDeletedTxnID})
+}
+
+func queryChangedNamespaces(tableNamespace string) sq.SelectBuilder {
+	return sb.Select(colConfig, colCreatedTxn, colDeletedTxn).From(tableNamespace)
+}
+
+func deleteNamespaceTuples(tableTuple string) sq.UpdateBuilder {
+	return sb.Update(tableTuple).Where(sq.Eq{colDeletedTxn: liveDeletedTxnID})
+}
+
+func queryTuplesWithIds(tableTuple string) sq.SelectBuilder {
+	return sb.Select(
+		colID,
+		colNamespace,
+		colObjectID,
+		colRelation,
+		colUsersetNamespace,
+		colUsersetObjectID,
+		colUsersetRelation,
+		colCaveatName,
+		colCaveatContext,
+		colExpiration,
+	).From(tableTuple)
+}
+
+func queryTuples(tableTuple string) sq.SelectBuilder {
+	return sb.Select(
+		colNamespace,
+		colObjectID,
+		colRelation,
+		colUsersetNamespace,
+		colUsersetObjectID,
+		colUsersetRelation,
+		colCaveatName,
+		colCaveatContext,
+		colExpiration,
+	).From(tableTuple)
+}
+
+func countTuples(tableTuple string) sq.SelectBuilder {
+	return sb.Select(
+		"count(*)",
+	).From(tableTuple)
+}
+
+func deleteTuple(tableTuple string) sq.UpdateBuilder {
+	return sb.Update(tableTuple).Where(sq.Eq{colDeletedTxn: liveDeletedTxnID})
+}
+
+func queryTupleExists(tableTuple string) sq.SelectBuilder {
+	return sb.Select(colID).From(tableTuple)
+}
+
+func writeTuple(tableTuple string) sq.InsertBuilder {
+	return sb.Insert(tableTuple).Columns(
+		colNamespace,
+		colObjectID,
+		colRelation,
+		colUsersetNamespace,
+		colUsersetObjectID,
+		colUsersetRelation,
+		colCaveatName,
+		colCaveatContext,
+		colExpiration,
+		colCreatedTxn,
+	)
+}
+
+func queryChanged(tableTuple string) sq.SelectBuilder {
+	return sb.Select(
+		colNamespace,
+		colObjectID,
+		colRelation,
+		colUsersetNamespace,
+		colUsersetObjectID,
+		colUsersetRelation,
+		colCaveatName,
+		colCaveatContext,
+		colExpiration,
+		colCreatedTxn,
+		colDeletedTxn,
+	).From(tableTuple)
+}
@@ -0,0 +1,188 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/datastore/options"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+)
+
+type sqliteReader struct {
+	*QueryBuilder
+
+	querier  querier
+	executor common.QueryExecutor
+	filterer queryFilterer
+}
+
+type queryFilterer func(original sq.SelectBuilder) sq.SelectBuilder
+
+const (
+	errUnableToReadConfig     = "unable to read namespace config: %w"
+	errUnableToListNamespaces = "unable to list namespaces: %w"
+	errUnableToQueryTuples    = "unable to query tuples: %w"
+)
+
+var schema = common.NewSchemaInformation(
+	colNamespace,
+	colObjectID,
+	colRelation,
+	colUsersetNamespace,
+	colUsersetObjectID,
+	colUsersetRelation,
+	colCaveatName,
+	colExpiration,
+	common.ExpandedLogicComparison,
+)
+
+func (sr *sqliteReader) QueryRelationships(
+	ctx context.Context,
+	filter datastore.RelationshipsFilter,
+	opts ...options.QueryOptionsOption,
+) (iter datastore.RelationshipIterator, err error) {
+	qBuilder, err := common.NewSchemaQueryFilterer(schema, sr.filterer(sr.QueryTuplesQuery)).FilterWithRelationshipsFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return sr.executor.ExecuteQuery(ctx, qBuilder, opts...)
+}
+
+func (sr *sqliteReader) ReverseQueryRelationships(
+	ctx context.Context,
+	subjectsFilter datastore.SubjectsFilter,
+	opts ...options.ReverseQueryOptionsOption,
+) (iter datastore.RelationshipIterator, err error) {
+	qBuilder, err := common.NewSchemaQueryFilterer(schema, sr.filterer(sr.QueryTuplesQuery)).
+		FilterWithSubjectsSelectors(subjectsFilter.AsSelector())
+	if err != nil {
+		return nil, err
+	}
+
+	queryOpts := options.NewReverseQueryOptionsWithOptions(opts...)
+
+	if queryOpts.ResRelation != nil {
+		qBuilder = qBuilder.
+			FilterToResourceType(queryOpts.ResRelation.Namespace).
+			FilterToRelation(queryOpts.ResRelation.Relation)
+	}
+
+	return sr.executor.ExecuteQuery(
+		ctx,
+		qBuilder,
+		options.WithLimit(queryOpts.LimitForReverse),
+		options.WithAfter(queryOpts.AfterForReverse),
+		options.WithSort(queryOpts.SortForReverse),
+	)
+}
+
+func (sr *sqliteReader) ReadNamespaceByName(ctx context.Context, nsName string) (*core.NamespaceDefinition, datastore.Revision, error) {
+	loaded, version, err := loadNamespace(ctx, nsName, sr.querier, sr.filterer(sr.ReadNamespaceQuery))
+	switch {
+	case errors.As(err, &datastore.ErrNamespaceNotFound{}):
+		return nil, datastore.NoRevision, err
+	case err == nil:
+		return loaded, version, nil
+	default:
+		return nil, datastore.NoRevision, fmt.Errorf(errUnableToReadConfig, err)
+	}
+}
+
+func loadNamespace(ctx context.Context, namespace string, q querier, baseQuery sq.SelectBuilder) (*core.NamespaceDefinition, datastore.Revision, error) {
+	ctx, span := tracer.Start(ctx, "loadNamespace")
+	defer span.End()
+
+	query, args, err := baseQuery.Where(sq.Eq{colNamespace: namespace}).ToSql()
+	if err != nil {
+		return nil, datastore.NoRevision, err
+	}
+
+	var config []byte
+	var txID uint64
+	err = q.QueryRowContext(ctx, query, args...).Scan(&config, &txID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = datastore.NewNamespaceNotFoundErr(namespace)
+		}
+		return nil, datastore.NoRevision, err
+	}
+
+	loaded := &core.NamespaceDefinition{}
+	if err := loaded.UnmarshalVT(config); err != nil {
+		return nil, datastore.NoRevision, err
+	}
+
+	return loaded, revisions.NewForTransactionID(txID), nil
+}
+
+func (sr *sqliteReader) ListAllNamespaces(ctx context.Context) ([]datastore.RevisionedNamespace, error) {
+	nsDefs, err := loadAllNamespaces(ctx, sr.querier, sr.filterer(sr.ReadNamespaceQuery))
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToListNamespaces, err)
+	}
+
+	return nsDefs, err
+}
+
+func (sr *sqliteReader) LookupNamespacesWithNames(ctx context.Context, nsNames []string) ([]datastore.RevisionedNamespace, error) {
+	if len(nsNames) == 0 {
+		return nil, nil
+	}
+
+	query := sr.filterer(sr.ReadNamespaceQuery.Where(sq.Eq{colNamespace: nsNames}))
+
+	nsDefs, err := loadAllNamespaces(ctx, sr.querier, query)
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToListNamespaces, err)
+	}
+
+	return nsDefs, err
+}
+
+func loadAllNamespaces(ctx context.Context, q querier, queryBuilder sq.SelectBuilder) ([]datastore.RevisionedNamespace, error) {
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var nsDefs []datastore.RevisionedNamespace
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer common.LogOnError(ctx, rows.Close)
+
+	for rows.Next() {
+		var config []byte
+		var txID uint64
+		if err := rows.Scan(&config, &txID); err != nil {
+			return nil, err
+		}
+
+		loaded := &core.NamespaceDefinition{}
+		if err := loaded.UnmarshalVT(config); err != nil {
+			return nil, fmt.Errorf(errUnableToReadConfig, err)
+		}
+
+		nsDefs = append(nsDefs, datastore.RevisionedNamespace{
+			Definition:          loaded,
+			LastWrittenRevision: revisions.NewForTransactionID(txID),
+		})
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return nsDefs, nil
+}
+
+var _ datastore.Reader = &sqliteReader{}
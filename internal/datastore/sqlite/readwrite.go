@@ -0,0 +1,499 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/jzelinskie/stringz"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/datastore"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/spiceerrors"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+const (
+	errUnableToWriteRelationships     = "unable to write relationships: %w"
+	errUnableToBulkWriteRelationships = "unable to bulk write relationships: %w"
+	errUnableToDeleteRelationships    = "unable to delete relationships: %w"
+	errUnableToWriteConfig            = "unable to write namespace config: %w"
+	errUnableToDeleteConfig           = "unable to delete namespace config: %w"
+
+	bulkInsertRowsLimit = 1_000
+
+	// maxClausesPerQuery is the maximum number of relationships matched in a single query, which
+	// is bounded by the maximum depth of an expression tree in SQLite.
+	maxClausesPerQuery = 250
+)
+
+type sqliteReadWriteTXN struct {
+	*sqliteReader
+
+	tx       *sql.Tx
+	newTxnID uint64
+
+	createTransaction func(context.Context, *sql.Tx) (uint64, error)
+}
+
+// ensureTransaction creates the transaction row for the read-write transaction, if it has not
+// yet been created.
+//
+// The transaction row is only created upon the first write, rather than when the transaction
+// begins: SQLite allows a single writer at a time, and deferring the write lock to the first
+// write allows a read-write transaction that only reads to proceed concurrently with another.
+func (rwt *sqliteReadWriteTXN) ensureTransaction(ctx context.Context) error {
+	if rwt.newTxnID != 0 {
+		return nil
+	}
+
+	newTxnID, err := rwt.createTransaction(ctx, rwt.tx)
+	if err != nil {
+		return fmt.Errorf("unable to create new txn ID: %w", err)
+	}
+
+	rwt.newTxnID = newTxnID
+	return nil
+}
+
+// caveatContextWrapper is used to marshall maps into JSON, stored as a BLOB
+type caveatContextWrapper map[string]any
+
+func (cc *caveatContextWrapper) Scan(val any) error {
+	switch v := val.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, &cc)
+	case string:
+		return json.Unmarshal([]byte(v), &cc)
+	default:
+		return fmt.Errorf("unsupported type: %T", v)
+	}
+}
+
+func (cc *caveatContextWrapper) Value() (driver.Value, error) {
+	return json.Marshal(&cc)
+}
+
+// WriteRelationships takes a list of existing relationships that must exist, and a list of
+// tuple mutations and applies it to the datastore for the specified namespace.
+func (rwt *sqliteReadWriteTXN) WriteRelationships(ctx context.Context, mutations []*core.RelationTupleUpdate) error {
+	if err := rwt.ensureTransaction(ctx); err != nil {
+		return fmt.Errorf(errUnableToWriteRelationships, err)
+	}
+
+	// NOTE: SQLite does not report the conflicting values when a unique constraint is violated,
+	// so the relationships to be created are looked up alongside those to be touched or deleted,
+	// in order to report which relationship already exists.
+	clauses := make([]sq.Sqlizer, 0, len(mutations))
+	mutationsByTuple := make(map[string]*core.RelationTupleUpdate, len(mutations))
+	for _, mut := range mutations {
+		switch mut.Operation {
+		case core.RelationTupleUpdate_CREATE, core.RelationTupleUpdate_TOUCH, core.RelationTupleUpdate_DELETE:
+			mutationsByTuple[tuple.StringWithoutCaveat(mut.Tuple)] = mut
+			clauses = append(clauses, exactRelationshipClause(mut.Tuple))
+
+		default:
+			return spiceerrors.MustBugf("unknown mutation operation")
+		}
+	}
+
+	// SQLite limits the depth of expressions, so the existing relationships are loaded in chunks.
+	unchangedTuples := make(map[string]struct{})
+	for start := 0; start < len(clauses); start += maxClausesPerQuery {
+		end := min(start+maxClausesPerQuery, len(clauses))
+		if err := rwt.deleteExistingRelationships(ctx, sq.Or(clauses[start:end]), mutationsByTuple, unchangedTuples); err != nil {
+			return err
+		}
+	}
+
+	bulkWrite := rwt.WriteTupleQuery
+	bulkWriteLen := 0
+	for _, mut := range mutations {
+		tpl := mut.Tuple
+		if mut.Operation == core.RelationTupleUpdate_DELETE {
+			continue
+		}
+
+		if _, ok := unchangedTuples[tuple.StringWithoutCaveat(tpl)]; ok {
+			continue
+		}
+
+		var caveatName string
+		var caveatContext caveatContextWrapper
+		if tpl.Caveat != nil {
+			caveatName = tpl.Caveat.CaveatName
+			caveatContext = tpl.Caveat.Context.AsMap()
+		}
+		bulkWrite = bulkWrite.Values(
+			tpl.ResourceAndRelation.Namespace,
+			tpl.ResourceAndRelation.ObjectId,
+			tpl.ResourceAndRelation.Relation,
+			tpl.Subject.Namespace,
+			tpl.Subject.ObjectId,
+			tpl.Subject.Relation,
+			caveatName,
+			&caveatContext,
+			common.ExpirationTimeOf(tpl),
+			rwt.newTxnID,
+		)
+		bulkWriteLen++
+
+		if bulkWriteLen == bulkInsertRowsLimit {
+			if err := rwt.execWrite(ctx, bulkWrite); err != nil {
+				return err
+			}
+			bulkWrite = rwt.WriteTupleQuery
+			bulkWriteLen = 0
+		}
+	}
+
+	if bulkWriteLen > 0 {
+		return rwt.execWrite(ctx, bulkWrite)
+	}
+
+	return nil
+}
+
+// deleteExistingRelationships marks as deleted the living relationships matching the given clause,
+// unless they are touched without any change, in which case they are added to unchangedTuples.
+// If a relationship to be created already exists, an error is returned.
+func (rwt *sqliteReadWriteTXN) deleteExistingRelationships(
+	ctx context.Context,
+	clause sq.Sqlizer,
+	mutationsByTuple map[string]*core.RelationTupleUpdate,
+	unchangedTuples map[string]struct{},
+) error {
+	query, args, err := rwt.QueryTuplesWithIdsQuery.
+		Where(clause).
+		Where(sq.GtOrEq{colDeletedTxn: rwt.newTxnID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteRelationships, err)
+	}
+
+	rows, err := rwt.tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteRelationships, err)
+	}
+	defer common.LogOnError(ctx, rows.Close)
+
+	now := time.Now()
+	var tupleIdsToDelete []int64
+	for rows.Next() {
+		foundTpl := &core.RelationTuple{
+			ResourceAndRelation: &core.ObjectAndRelation{},
+			Subject:             &core.ObjectAndRelation{},
+		}
+
+		var tupleID int64
+		var caveatName string
+		var caveatContext caveatContextWrapper
+		var expiration *time.Time
+		if err := rows.Scan(
+			&tupleID,
+			&foundTpl.ResourceAndRelation.Namespace,
+			&foundTpl.ResourceAndRelation.ObjectId,
+			&foundTpl.ResourceAndRelation.Relation,
+			&foundTpl.Subject.Namespace,
+			&foundTpl.Subject.ObjectId,
+			&foundTpl.Subject.Relation,
+			&caveatName,
+			&caveatContext,
+			&expiration,
+		); err != nil {
+			return fmt.Errorf(errUnableToWriteRelationships, err)
+		}
+
+		foundTpl.Caveat, err = common.ContextualizedCaveatFrom(caveatName, caveatContext)
+		if err != nil {
+			return fmt.Errorf(errUnableToQueryTuples, err)
+		}
+		foundTpl.OptionalExpirationTime = common.ExpirationFrom(expiration)
+
+		// Expired relationships are invisible, and are therefore replaced by any mutation.
+		isExpired := expiration != nil && !expiration.After(now)
+
+		tplString := tuple.StringWithoutCaveat(foundTpl)
+		if mut, ok := mutationsByTuple[tplString]; ok && !isExpired {
+			switch mut.Operation {
+			case core.RelationTupleUpdate_CREATE:
+				return common.NewCreateRelationshipExistsError(foundTpl)
+
+			case core.RelationTupleUpdate_TOUCH:
+				// if the caveat name, context or expiration has not changed, then the
+				// relationship is neither deleted nor recreated.
+				if tuple.Equal(mut.Tuple, foundTpl) {
+					unchangedTuples[tplString] = struct{}{}
+					continue
+				}
+			}
+		}
+
+		tupleIdsToDelete = append(tupleIdsToDelete, tupleID)
+	}
+
+	if rows.Err() != nil {
+		return fmt.Errorf(errUnableToWriteRelationships, rows.Err())
+	}
+
+	if len(tupleIdsToDelete) == 0 {
+		return nil
+	}
+
+	deleteQuery, deleteArgs, err := rwt.
+		DeleteTupleQuery.
+		Where(sq.Eq{colID: tupleIdsToDelete}).
+		Set(colDeletedTxn, rwt.newTxnID).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteRelationships, err)
+	}
+	if _, err := rwt.tx.ExecContext(ctx, deleteQuery, deleteArgs...); err != nil {
+		return fmt.Errorf(errUnableToWriteRelationships, err)
+	}
+
+	return nil
+}
+
+func (rwt *sqliteReadWriteTXN) execWrite(ctx context.Context, bulkWrite sq.InsertBuilder) error {
+	query, args, err := bulkWrite.ToSql()
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteRelationships, err)
+	}
+
+	if _, err := rwt.tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf(errUnableToWriteRelationships, err)
+	}
+
+	return nil
+}
+
+func (rwt *sqliteReadWriteTXN) DeleteRelationships(ctx context.Context, filter *v1.RelationshipFilter) error {
+	if err := rwt.ensureTransaction(ctx); err != nil {
+		return fmt.Errorf(errUnableToDeleteRelationships, err)
+	}
+	// Add clauses for the ResourceFilter
+	query := rwt.DeleteTupleQuery.Where(sq.Eq{colNamespace: filter.ResourceType})
+	if filter.OptionalResourceId != "" {
+		query = query.Where(sq.Eq{colObjectID: filter.OptionalResourceId})
+	}
+	if filter.OptionalRelation != "" {
+		query = query.Where(sq.Eq{colRelation: filter.OptionalRelation})
+	}
+
+	// Add clauses for the SubjectFilter
+	if subjectFilter := filter.OptionalSubjectFilter; subjectFilter != nil {
+		query = query.Where(sq.Eq{colUsersetNamespace: subjectFilter.SubjectType})
+		if subjectFilter.OptionalSubjectId != "" {
+			query = query.Where(sq.Eq{colUsersetObjectID: subjectFilter.OptionalSubjectId})
+		}
+		if relationFilter := subjectFilter.OptionalRelation; relationFilter != nil {
+			query = query.Where(sq.Eq{colUsersetRelation: stringz.DefaultEmpty(relationFilter.Relation, datastore.Ellipsis)})
+		}
+	}
+
+	query = query.Set(colDeletedTxn, rwt.newTxnID)
+
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf(errUnableToDeleteRelationships, err)
+	}
+
+	if _, err := rwt.tx.ExecContext(ctx, querySQL, args...); err != nil {
+		return fmt.Errorf(errUnableToDeleteRelationships, err)
+	}
+
+	return nil
+}
+
+func (rwt *sqliteReadWriteTXN) WriteNamespaces(ctx context.Context, newNamespaces ...*core.NamespaceDefinition) error {
+	if err := rwt.ensureTransaction(ctx); err != nil {
+		return fmt.Errorf(errUnableToWriteConfig, err)
+	}
+
+	namespaceNames := make([]string, 0, len(newNamespaces))
+	writeQuery := rwt.WriteNamespaceQuery
+
+	for _, newNamespace := range newNamespaces {
+		serialized, err := proto.Marshal(newNamespace)
+		if err != nil {
+			return fmt.Errorf(errUnableToWriteConfig, err)
+		}
+
+		namespaceNames = append(namespaceNames, newNamespace.Name)
+		writeQuery = writeQuery.Values(newNamespace.Name, serialized, rwt.newTxnID)
+	}
+
+	delSQL, delArgs, err := rwt.DeleteNamespaceQuery.
+		Set(colDeletedTxn, rwt.newTxnID).
+		Where(sq.Eq{colDeletedTxn: liveDeletedTxnID, colNamespace: namespaceNames}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteConfig, err)
+	}
+
+	_, err = rwt.tx.ExecContext(ctx, delSQL, delArgs...)
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteConfig, err)
+	}
+
+	query, args, err := writeQuery.ToSql()
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteConfig, err)
+	}
+
+	_, err = rwt.tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteConfig, err)
+	}
+
+	return nil
+}
+
+func (rwt *sqliteReadWriteTXN) DeleteNamespaces(ctx context.Context, nsNames ...string) error {
+	if err := rwt.ensureTransaction(ctx); err != nil {
+		return fmt.Errorf(errUnableToDeleteConfig, err)
+	}
+
+	// For each namespace, check they exist and collect predicates for the
+	// "WHERE" clause to delete the namespaces and associated tuples.
+	nsClauses := make([]sq.Sqlizer, 0, len(nsNames))
+	tplClauses := make([]sq.Sqlizer, 0, len(nsNames))
+	for _, nsName := range nsNames {
+		baseQuery := rwt.ReadNamespaceQuery.Where(sq.Eq{colDeletedTxn: liveDeletedTxnID})
+		_, createdAt, err := loadNamespace(ctx, nsName, rwt.tx, baseQuery)
+		switch {
+		case errors.As(err, &datastore.ErrNamespaceNotFound{}):
+			return err
+		case err == nil:
+			break
+		default:
+			return fmt.Errorf(errUnableToDeleteConfig, err)
+		}
+
+		nsClauses = append(nsClauses, sq.Eq{colNamespace: nsName, colCreatedTxn: createdAt})
+		tplClauses = append(tplClauses, sq.Eq{colNamespace: nsName})
+	}
+
+	delSQL, delArgs, err := rwt.DeleteNamespaceQuery.
+		Set(colDeletedTxn, rwt.newTxnID).
+		Where(sq.Or(nsClauses)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf(errUnableToDeleteConfig, err)
+	}
+
+	_, err = rwt.tx.ExecContext(ctx, delSQL, delArgs...)
+	if err != nil {
+		return fmt.Errorf(errUnableToDeleteConfig, err)
+	}
+
+	deleteTupleSQL, deleteTupleArgs, err := rwt.DeleteNamespaceTuplesQuery.
+		Set(colDeletedTxn, rwt.newTxnID).
+		Where(sq.Or(tplClauses)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf(errUnableToDeleteConfig, err)
+	}
+
+	_, err = rwt.tx.ExecContext(ctx, deleteTupleSQL, deleteTupleArgs...)
+	if err != nil {
+		return fmt.Errorf(errUnableToDeleteConfig, err)
+	}
+
+	return nil
+}
+
+func (rwt *sqliteReadWriteTXN) BulkLoad(ctx context.Context, iter datastore.BulkWriteRelationshipSource) (uint64, error) {
+	if err := rwt.ensureTransaction(ctx); err != nil {
+		return 0, fmt.Errorf(errUnableToBulkWriteRelationships, err)
+	}
+
+	var sqlStmt bytes.Buffer
+
+	sql, _, err := rwt.WriteTupleQuery.Values(1, 2, 3, 4, 5, 6, 7, 8, 9, 10).ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var numWritten uint64
+	var tpl *core.RelationTuple
+
+	// Bootstrap the loop
+	tpl, err = iter.Next(ctx)
+
+	for tpl != nil && err == nil {
+		sqlStmt.Reset()
+		sqlStmt.WriteString(sql)
+		var args []interface{}
+		var batchLen uint64
+
+		for ; tpl != nil && err == nil && batchLen < bulkInsertRowsLimit; tpl, err = iter.Next(ctx) {
+			if batchLen != 0 {
+				sqlStmt.WriteString(",(?,?,?,?,?,?,?,?,?,?)")
+			}
+
+			var caveatName string
+			var caveatContext caveatContextWrapper
+			if tpl.Caveat != nil {
+				caveatName = tpl.Caveat.CaveatName
+				caveatContext = tpl.Caveat.Context.AsMap()
+			}
+			args = append(args,
+				tpl.ResourceAndRelation.Namespace,
+				tpl.ResourceAndRelation.ObjectId,
+				tpl.ResourceAndRelation.Relation,
+				tpl.Subject.Namespace,
+				tpl.Subject.ObjectId,
+				tpl.Subject.Relation,
+				caveatName,
+				&caveatContext,
+				common.ExpirationTimeOf(tpl),
+				rwt.newTxnID,
+			)
+			batchLen++
+		}
+		if err != nil {
+			return 0, fmt.Errorf(errUnableToBulkWriteRelationships, err)
+		}
+
+		if batchLen > 0 {
+			log.Ctx(ctx).Debug().Uint64("count", batchLen).Uint64("written", numWritten).Msg("writing batch")
+			if _, err := rwt.tx.ExecContext(ctx, sqlStmt.String(), args...); err != nil {
+				return 0, fmt.Errorf(errUnableToBulkWriteRelationships, fmt.Errorf("error writing batch: %w", err))
+			}
+		}
+
+		numWritten += batchLen
+	}
+	if err != nil {
+		return 0, fmt.Errorf(errUnableToBulkWriteRelationships, err)
+	}
+
+	return numWritten, nil
+}
+
+func exactRelationshipClause(r *core.RelationTuple) sq.Eq {
+	return sq.Eq{
+		colNamespace:        r.ResourceAndRelation.Namespace,
+		colObjectID:         r.ResourceAndRelation.ObjectId,
+		colRelation:         r.ResourceAndRelation.Relation,
+		colUsersetNamespace: r.Subject.Namespace,
+		colUsersetObjectID:  r.Subject.ObjectId,
+		colUsersetRelation:  r.Subject.Relation,
+	}
+}
+
+var _ datastore.ReadWriteTransaction = &sqliteReadWriteTXN{}
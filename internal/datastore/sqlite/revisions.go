@@ -0,0 +1,213 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/internal/datastore/sqlite/migrations"
+	"github.com/authzed/spicedb/pkg/datastore"
+)
+
+var ParseRevisionString = revisions.RevisionParser(revisions.TransactionID)
+
+const (
+	errRevision      = "unable to find revision: %w"
+	errCheckRevision = "unable to check revision: %w"
+)
+
+// Transaction timestamps are stored as nanoseconds since the Unix epoch, as SQLite has no native
+// timestamp type; the current time is therefore taken from the local clock rather than the
+// database, which is always local to the process.
+var (
+	// querySelectRevision finds the first transaction at or after the given timestamp. If there
+	// are no such transactions, it picks the latest transaction.
+	querySelectRevision = fmt.Sprintf(`SELECT COALESCE((
+			SELECT MIN(%[1]s)
+			FROM   %[2]s
+			WHERE  %[3]s >= ?
+		), (
+			SELECT MAX(%[1]s)
+			FROM   %[2]s
+		))`, colID, migrations.TableTransaction, colTimestamp)
+
+	// queryValidTransaction will return a single row with two values, one boolean
+	// for whether the specified transaction ID is newer than the garbage collection
+	// window, and one boolean for whether the transaction ID represents a transaction
+	// that will occur in the future.
+	// It treats the current head transaction as always valid even if it falls
+	// outside the GC window.
+	queryValidTransaction = fmt.Sprintf(`SELECT ? >= COALESCE((
+			SELECT MIN(%[1]s)
+			FROM   %[2]s
+			WHERE  %[3]s >= ?
+		), (
+			SELECT MAX(%[1]s)
+			FROM   %[2]s
+		)) as fresh, ? > (
+			SELECT MAX(%[1]s)
+			FROM   %[2]s
+		) as unknown`, colID, migrations.TableTransaction, colTimestamp)
+
+	// queryRevisionAsOf finds the latest transaction at or before the given timestamp, if any.
+	queryRevisionAsOf = fmt.Sprintf(`SELECT MAX(%[1]s)
+			FROM   %[2]s
+			WHERE  %[3]s <= ?`, colID, migrations.TableTransaction, colTimestamp)
+)
+
+// optimizedRevisionFunc rounds the current time down to the nearest quantization period, and then
+// finds the first transaction after that. It also returns the amount of time until the next
+// optimized revision would be selected, for use with caching.
+func (sds *Datastore) optimizedRevisionFunc(ctx context.Context) (datastore.Revision, time.Duration, error) {
+	quantizationPeriodNanos := sds.revisionQuantization.Nanoseconds()
+	if quantizationPeriodNanos < 1 {
+		quantizationPeriodNanos = 1
+	}
+
+	nowNanos := time.Now().UnixNano()
+	sinceQuantizedNanos := nowNanos % quantizationPeriodNanos
+
+	var rev uint64
+	if err := sds.db.QueryRowContext(ctx, querySelectRevision, nowNanos-sinceQuantizedNanos).
+		Scan(&rev); err != nil {
+		return datastore.NoRevision, 0, fmt.Errorf(errRevision, err)
+	}
+
+	validFor := time.Duration(quantizationPeriodNanos-sinceQuantizedNanos) * time.Nanosecond
+	return revisions.NewForTransactionID(rev), validFor, nil
+}
+
+func (sds *Datastore) HeadRevision(ctx context.Context) (datastore.Revision, error) {
+	revision, err := sds.loadRevision(ctx)
+	if err != nil {
+		return datastore.NoRevision, err
+	}
+	if revision == 0 {
+		return datastore.NoRevision, nil
+	}
+
+	return revisions.NewForTransactionID(revision), nil
+}
+
+func (sds *Datastore) CheckRevision(ctx context.Context, revision datastore.Revision) error {
+	if revision == datastore.NoRevision {
+		return datastore.NewInvalidRevisionErr(revision, datastore.CouldNotDetermineRevision)
+	}
+
+	rev, ok := revision.(revisions.TransactionIDRevision)
+	if !ok {
+		return fmt.Errorf("expected transaction revision, got %T", revision)
+	}
+
+	revisionTx := rev.TransactionID()
+	freshEnough, unknown, err := sds.checkValidTransaction(ctx, revisionTx)
+	if err != nil {
+		return fmt.Errorf(errCheckRevision, err)
+	}
+
+	if !freshEnough {
+		return datastore.NewInvalidRevisionErr(revision, datastore.RevisionStale)
+	}
+	if unknown {
+		return datastore.NewInvalidRevisionErr(revision, datastore.CouldNotDetermineRevision)
+	}
+
+	return nil
+}
+
+func (sds *Datastore) RevisionAsOf(ctx context.Context, asOf time.Time) (datastore.Revision, error) {
+	ctx, span := tracer.Start(ctx, "RevisionAsOf")
+	defer span.End()
+
+	now := time.Now()
+	if asOf.After(now) {
+		return datastore.NoRevision, datastore.NewInvalidRevisionErr(datastore.NoRevision, datastore.CouldNotDetermineRevision)
+	}
+	if asOf.Before(now.Add(-sds.gcWindow)) {
+		return datastore.NoRevision, datastore.NewInvalidRevisionErr(datastore.NoRevision, datastore.RevisionStale)
+	}
+
+	var revision sql.NullInt64
+	if err := sds.db.QueryRowContext(ctx, queryRevisionAsOf, asOf.UnixNano()).Scan(&revision); err != nil {
+		return datastore.NoRevision, fmt.Errorf(errRevision, err)
+	}
+	if !revision.Valid {
+		// The transactions up to that time have been garbage collected.
+		return datastore.NoRevision, datastore.NewInvalidRevisionErr(datastore.NoRevision, datastore.RevisionStale)
+	}
+
+	return revisions.NewForTransactionID(uint64(revision.Int64)), nil
+}
+
+// loadRevision returns the latest transaction ID, or zero if the database has not been seeded.
+func (sds *Datastore) loadRevision(ctx context.Context) (uint64, error) {
+	ctx, span := tracer.Start(ctx, "loadRevision")
+	defer span.End()
+
+	query, args, err := sds.GetLastRevision.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf(errRevision, err)
+	}
+
+	var revision *uint64
+	err = sds.db.QueryRowContext(ctx, query, args...).Scan(&revision)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf(errRevision, err)
+	}
+
+	if revision == nil {
+		return 0, nil
+	}
+
+	return *revision, nil
+}
+
+func (sds *Datastore) checkValidTransaction(ctx context.Context, revisionTx uint64) (bool, bool, error) {
+	ctx, span := tracer.Start(ctx, "checkValidTransaction")
+	defer span.End()
+
+	var freshEnough, unknown sql.NullBool
+
+	gcWindowStart := time.Now().Add(-sds.gcWindow).UnixNano()
+	err := sds.db.QueryRowContext(ctx, queryValidTransaction, revisionTx, gcWindowStart, revisionTx).
+		Scan(&freshEnough, &unknown)
+	if err != nil {
+		return false, false, fmt.Errorf(errCheckRevision, err)
+	}
+
+	span.AddEvent("DB returned validTransaction checks")
+
+	return freshEnough.Bool, unknown.Bool, nil
+}
+
+func (sds *Datastore) createNewTransaction(ctx context.Context, tx *sql.Tx) (newTxnID uint64, err error) {
+	ctx, span := tracer.Start(ctx, "createNewTransaction")
+	defer span.End()
+
+	createQuery, createArgs, err := sb.
+		Insert(migrations.TableTransaction).
+		Columns(colTimestamp).
+		Values(time.Now().UnixNano()).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("createNewTransaction: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, createQuery, createArgs...)
+	if err != nil {
+		return 0, fmt.Errorf("createNewTransaction: %w", err)
+	}
+
+	lastInsertID, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("createNewTransaction: failed to get last inserted id: %w", err)
+	}
+
+	return uint64(lastInsertID), nil
+}
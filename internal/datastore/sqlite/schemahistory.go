@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/pkg/datastore"
+)
+
+const (
+	errListSchemaVersions = "unable to list schema versions: %w"
+	errReadSchemaVersion  = "unable to read schema version: %w"
+	errWriteSchemaVersion = "unable to write schema version: %w"
+)
+
+func (sr *sqliteReader) ListSchemaVersions(ctx context.Context) ([]datastore.SchemaVersion, error) {
+	sqlStatement, args, err := sr.filterer(sr.ReadSchemaVersionQuery).OrderBy(colVersion + " DESC").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf(errListSchemaVersions, err)
+	}
+
+	rows, err := sr.querier.QueryContext(ctx, sqlStatement, args...)
+	if err != nil {
+		return nil, fmt.Errorf(errListSchemaVersions, err)
+	}
+	defer common.LogOnError(ctx, rows.Close)
+
+	var versions []datastore.SchemaVersion
+	for rows.Next() {
+		var version datastore.SchemaVersion
+		var txID uint64
+		if err := rows.Scan(&version.Version, &version.Schema, &version.Author, &txID); err != nil {
+			return nil, fmt.Errorf(errListSchemaVersions, err)
+		}
+		version.Revision = revisions.NewForTransactionID(txID)
+		versions = append(versions, version)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf(errListSchemaVersions, rows.Err())
+	}
+
+	return versions, nil
+}
+
+func (sr *sqliteReader) ReadSchemaVersion(ctx context.Context, version uint64) (datastore.SchemaVersion, error) {
+	sqlStatement, args, err := sr.filterer(sr.ReadSchemaVersionQuery).Where(sq.Eq{colVersion: version}).ToSql()
+	if err != nil {
+		return datastore.SchemaVersion{}, fmt.Errorf(errReadSchemaVersion, err)
+	}
+
+	var found datastore.SchemaVersion
+	var txID uint64
+	err = sr.querier.QueryRowContext(ctx, sqlStatement, args...).Scan(&found.Version, &found.Schema, &found.Author, &txID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return datastore.SchemaVersion{}, datastore.NewSchemaVersionNotFoundErr(version)
+		}
+		return datastore.SchemaVersion{}, fmt.Errorf(errReadSchemaVersion, err)
+	}
+	found.Revision = revisions.NewForTransactionID(txID)
+	return found, nil
+}
+
+func (rwt *sqliteReadWriteTXN) WriteSchemaVersion(ctx context.Context, schema string, author string) (uint64, error) {
+	if err := rwt.ensureTransaction(ctx); err != nil {
+		return 0, fmt.Errorf(errWriteSchemaVersion, err)
+	}
+
+	latestSQL, latestArgs, err := rwt.LatestSchemaVersionQuery.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf(errWriteSchemaVersion, err)
+	}
+
+	var latest uint64
+	if err := rwt.tx.QueryRowContext(ctx, latestSQL, latestArgs...).Scan(&latest); err != nil {
+		return 0, fmt.Errorf(errWriteSchemaVersion, err)
+	}
+
+	next := latest + 1
+	writeSQL, writeArgs, err := rwt.WriteSchemaVersionQuery.Values(next, schema, author, rwt.newTxnID).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf(errWriteSchemaVersion, err)
+	}
+
+	if _, err := rwt.tx.ExecContext(ctx, writeSQL, writeArgs...); err != nil {
+		return 0, fmt.Errorf(errWriteSchemaVersion, err)
+	}
+	return next, nil
+}
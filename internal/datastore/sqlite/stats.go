@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/authzed/spicedb/internal/datastore/sqlite/migrations"
+	"github.com/authzed/spicedb/pkg/datastore"
+)
+
+const (
+	metadataIDColumn       = "id"
+	metadataUniqueIDColumn = "unique_id"
+)
+
+func (sds *Datastore) Statistics(ctx context.Context) (datastore.Stats, error) {
+	uniqueID, err := sds.getUniqueID(ctx)
+	if err != nil {
+		return datastore.Stats{}, err
+	}
+
+	// SQLite keeps no estimate of the number of rows in a table, so they are counted.
+	query, args, err := currentlyLivingObjects(sds.CountTupleQuery).ToSql()
+	if err != nil {
+		return datastore.Stats{}, err
+	}
+
+	var count uint64
+	if err := sds.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return datastore.Stats{}, fmt.Errorf("unable to count relationships: %w", err)
+	}
+
+	nsDefs, err := loadAllNamespaces(ctx, sds.db, currentlyLivingObjects(sds.ReadNamespaceQuery))
+	if err != nil {
+		return datastore.Stats{}, fmt.Errorf("unable to load namespaces: %w", err)
+	}
+
+	return datastore.Stats{
+		UniqueID:                   uniqueID,
+		ObjectTypeStatistics:       datastore.ComputeObjectTypeStats(nsDefs),
+		EstimatedRelationshipCount: count,
+	}, nil
+}
+
+func (sds *Datastore) getUniqueID(ctx context.Context) (string, error) {
+	sql, args, err := sb.Select(metadataUniqueIDColumn).From(migrations.TableMetadata).ToSql()
+	if err != nil {
+		return "", fmt.Errorf("unable to generate query sql: %w", err)
+	}
+
+	var uniqueID string
+	if err := sds.db.QueryRowContext(ctx, sql, args...).Scan(&uniqueID); err != nil {
+		return "", fmt.Errorf("unable to query unique ID: %w", err)
+	}
+
+	return uniqueID, nil
+}
@@ -0,0 +1,330 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/pkg/datastore"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+)
+
+const (
+	watchSleep = 100 * time.Millisecond
+)
+
+// Watch notifies the caller about all changes to tuples and, if requested, to the schema.
+//
+// All events following afterRevision will be sent to the caller.
+func (sds *Datastore) Watch(ctx context.Context, afterRevisionRaw datastore.Revision, options datastore.WatchOptions) (<-chan *datastore.RevisionChanges, <-chan error) {
+	watchBufferLength := options.WatchBufferLength
+	if watchBufferLength <= 0 {
+		watchBufferLength = sds.watchBufferLength
+	}
+
+	updates := make(chan *datastore.RevisionChanges, watchBufferLength)
+	errs := make(chan error, 1)
+
+	afterRevision, ok := afterRevisionRaw.(revisions.TransactionIDRevision)
+	if !ok {
+		errs <- datastore.NewInvalidRevisionErr(afterRevisionRaw, datastore.CouldNotDetermineRevision)
+		return updates, errs
+	}
+
+	watchBufferWriteTimeout := options.WatchBufferWriteTimeout
+	if watchBufferWriteTimeout <= 0 {
+		watchBufferWriteTimeout = sds.watchBufferWriteTimeout
+	}
+
+	sendChange := func(change *datastore.RevisionChanges) bool {
+		select {
+		case updates <- change:
+			return true
+
+		default:
+			// If we cannot immediately write, setup the timer and try again.
+		}
+
+		timer := time.NewTimer(watchBufferWriteTimeout)
+		defer timer.Stop()
+
+		select {
+		case updates <- change:
+			return true
+
+		case <-timer.C:
+			errs <- datastore.NewWatchDisconnectedErr()
+			return false
+		}
+	}
+
+	go func() {
+		defer close(updates)
+		defer close(errs)
+
+		currentTxn := afterRevision.TransactionID()
+		for {
+			var stagedUpdates []datastore.RevisionChanges
+			var err error
+			stagedUpdates, currentTxn, err = sds.loadChanges(ctx, currentTxn, options)
+			if err != nil {
+				if errors.Is(ctx.Err(), context.Canceled) {
+					errs <- datastore.NewWatchCanceledErr()
+				} else {
+					errs <- err
+				}
+				return
+			}
+
+			// Write the staged updates to the channel
+			for _, changeToWrite := range stagedUpdates {
+				changeToWrite := changeToWrite
+				if !sendChange(&changeToWrite) {
+					return
+				}
+			}
+
+			// If there were no changes, sleep a bit
+			if len(stagedUpdates) == 0 {
+				sleep := time.NewTimer(watchSleep)
+
+				select {
+				case <-sleep.C:
+					break
+				case <-ctx.Done():
+					errs <- datastore.NewWatchCanceledErr()
+					return
+				}
+			}
+		}
+	}()
+
+	return updates, errs
+}
+
+func (sds *Datastore) loadChanges(
+	ctx context.Context,
+	afterRevision uint64,
+	options datastore.WatchOptions,
+) (changes []datastore.RevisionChanges, newRevision uint64, err error) {
+	newRevision, err = sds.loadRevision(ctx)
+	if err != nil {
+		return
+	}
+
+	if newRevision == afterRevision {
+		return
+	}
+
+	stagedChanges := common.NewChanges(revisions.TransactionIDKeyFunc, options.Content)
+
+	if options.Content&datastore.WatchRelationships == datastore.WatchRelationships {
+		if err = sds.loadRelationshipChanges(ctx, afterRevision, newRevision, stagedChanges); err != nil {
+			return
+		}
+	}
+
+	if options.Content&datastore.WatchSchema == datastore.WatchSchema {
+		if err = sds.loadNamespaceChanges(ctx, afterRevision, newRevision, stagedChanges); err != nil {
+			return
+		}
+
+		if err = sds.loadCaveatChanges(ctx, afterRevision, newRevision, stagedChanges); err != nil {
+			return
+		}
+	}
+
+	changes = stagedChanges.AsRevisionChanges(revisions.TransactionIDKeyLessThanFunc)
+	return
+}
+
+// queryChanged runs the query for the rows created or deleted by the transactions after
+// afterRevision, up to and including newRevision.
+func (sds *Datastore) queryChanged(ctx context.Context, query sq.SelectBuilder, afterRevision, newRevision uint64) (*sql.Rows, error) {
+	sqlQuery, args, err := query.Where(sq.Or{
+		sq.And{
+			sq.Gt{colCreatedTxn: afterRevision},
+			sq.LtOrEq{colCreatedTxn: newRevision},
+		},
+		sq.And{
+			sq.Gt{colDeletedTxn: afterRevision},
+			sq.LtOrEq{colDeletedTxn: newRevision},
+		},
+	}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := sds.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil, datastore.NewWatchCanceledErr()
+		}
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (sds *Datastore) loadRelationshipChanges(
+	ctx context.Context,
+	afterRevision uint64,
+	newRevision uint64,
+	stagedChanges *common.Changes[revisions.TransactionIDRevision, uint64],
+) error {
+	rows, err := sds.queryChanged(ctx, sds.QueryChangedQuery, afterRevision, newRevision)
+	if err != nil {
+		return err
+	}
+	defer common.LogOnError(ctx, rows.Close)
+
+	for rows.Next() {
+		nextTuple := &core.RelationTuple{
+			ResourceAndRelation: &core.ObjectAndRelation{},
+			Subject:             &core.ObjectAndRelation{},
+		}
+
+		var createdTxn uint64
+		var deletedTxn uint64
+		var caveatName string
+		var caveatContext caveatContextWrapper
+		var expiration *time.Time
+		err = rows.Scan(
+			&nextTuple.ResourceAndRelation.Namespace,
+			&nextTuple.ResourceAndRelation.ObjectId,
+			&nextTuple.ResourceAndRelation.Relation,
+			&nextTuple.Subject.Namespace,
+			&nextTuple.Subject.ObjectId,
+			&nextTuple.Subject.Relation,
+			&caveatName,
+			&caveatContext,
+			&expiration,
+			&createdTxn,
+			&deletedTxn,
+		)
+		if err != nil {
+			return err
+		}
+		nextTuple.Caveat, err = common.ContextualizedCaveatFrom(caveatName, caveatContext)
+		if err != nil {
+			return err
+		}
+		nextTuple.OptionalExpirationTime = common.ExpirationFrom(expiration)
+
+		if createdTxn > afterRevision && createdTxn <= newRevision {
+			if err := stagedChanges.AddRelationshipChange(ctx, revisions.NewForTransactionID(createdTxn), nextTuple, core.RelationTupleUpdate_TOUCH); err != nil {
+				return err
+			}
+		}
+
+		if deletedTxn > afterRevision && deletedTxn <= newRevision {
+			if err := stagedChanges.AddRelationshipChange(ctx, revisions.NewForTransactionID(deletedTxn), nextTuple, core.RelationTupleUpdate_DELETE); err != nil {
+				return err
+			}
+		}
+	}
+	return rows.Err()
+}
+
+// changedDefinition is a schema definition written by the transaction with the ID.
+type changedDefinition struct {
+	txnID      uint64
+	definition datastore.SchemaDefinition
+}
+
+func (sds *Datastore) loadNamespaceChanges(
+	ctx context.Context,
+	afterRevision uint64,
+	newRevision uint64,
+	stagedChanges *common.Changes[revisions.TransactionIDRevision, uint64],
+) error {
+	rows, err := sds.queryChanged(ctx, sds.QueryChangedNamespaceQuery, afterRevision, newRevision)
+	if err != nil {
+		return err
+	}
+	defer common.LogOnError(ctx, rows.Close)
+
+	var changed []changedDefinition
+	for rows.Next() {
+		var config []byte
+		var createdTxn uint64
+		var deletedTxn uint64
+		if err := rows.Scan(&config, &createdTxn, &deletedTxn); err != nil {
+			return err
+		}
+
+		loaded := &core.NamespaceDefinition{}
+		if err := loaded.UnmarshalVT(config); err != nil {
+			return fmt.Errorf(errUnableToReadConfig, err)
+		}
+
+		if createdTxn > afterRevision && createdTxn <= newRevision {
+			changed = append(changed, changedDefinition{createdTxn, loaded})
+		}
+
+		if deletedTxn > afterRevision && deletedTxn <= newRevision {
+			stagedChanges.AddDeletedNamespace(ctx, revisions.NewForTransactionID(deletedTxn), loaded.Name)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	addChangedDefinitions(ctx, stagedChanges, changed)
+	return nil
+}
+
+func (sds *Datastore) loadCaveatChanges(
+	ctx context.Context,
+	afterRevision uint64,
+	newRevision uint64,
+	stagedChanges *common.Changes[revisions.TransactionIDRevision, uint64],
+) error {
+	rows, err := sds.queryChanged(ctx, sds.QueryChangedCaveatQuery, afterRevision, newRevision)
+	if err != nil {
+		return err
+	}
+	defer common.LogOnError(ctx, rows.Close)
+
+	var changed []changedDefinition
+	for rows.Next() {
+		var definition []byte
+		var createdTxn uint64
+		var deletedTxn uint64
+		if err := rows.Scan(&definition, &createdTxn, &deletedTxn); err != nil {
+			return err
+		}
+
+		loaded := &core.CaveatDefinition{}
+		if err := loaded.UnmarshalVT(definition); err != nil {
+			return fmt.Errorf(errReadCaveat, err)
+		}
+
+		if createdTxn > afterRevision && createdTxn <= newRevision {
+			changed = append(changed, changedDefinition{createdTxn, loaded})
+		}
+
+		if deletedTxn > afterRevision && deletedTxn <= newRevision {
+			stagedChanges.AddDeletedCaveat(ctx, revisions.NewForTransactionID(deletedTxn), loaded.Name)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	addChangedDefinitions(ctx, stagedChanges, changed)
+	return nil
+}
+
+// addChangedDefinitions adds the changed definitions once all the deletions have been added, as
+// updating a definition deletes its previous version in the same transaction.
+func addChangedDefinitions(ctx context.Context, stagedChanges *common.Changes[revisions.TransactionIDRevision, uint64], changed []changedDefinition) {
+	for _, change := range changed {
+		stagedChanges.AddChangedDefinition(ctx, revisions.NewForTransactionID(change.txnID), change.definition)
+	}
+}
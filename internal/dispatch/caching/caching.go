@@ -6,6 +6,7 @@ import (
 	"maps"
 	"sync"
 	"testing"
+	"time"
 	"unsafe"
 
 	"github.com/dustin/go-humanize"
@@ -17,6 +18,7 @@ import (
 	"github.com/authzed/spicedb/internal/dispatch"
 	"github.com/authzed/spicedb/internal/dispatch/keys"
 	"github.com/authzed/spicedb/pkg/cache"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
 	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
 )
 
@@ -32,6 +34,11 @@ type Dispatcher struct {
 	c          cache.Cache
 	keyHandler keys.Handler
 
+	// invalidator, if set, allows results to be reused across revisions, in which case their
+	// cache keys are computed by the revision-independent key handler.
+	invalidator                   *WatchInvalidator
+	revisionIndependentKeyHandler keys.Handler
+
 	checkTotalCounter                  prometheus.Counter
 	checkFromCacheCounter              prometheus.Counter
 	reachableResourcesTotalCounter     prometheus.Counter
@@ -161,18 +168,123 @@ func (cd *Dispatcher) SetDelegate(delegate dispatch.Dispatcher) {
 	cd.d = delegate
 }
 
+// SetInvalidator sets the invalidator used to reuse cached results across revisions. Results
+// are tagged with the relations on which they depend and reused at newer revisions unless the
+// invalidator observes a change to one of those relations. Returns an error if the key handler
+// of the dispatcher cannot compute revision-independent cache keys.
+func (cd *Dispatcher) SetInvalidator(invalidator *WatchInvalidator) error {
+	keyHandler, ok := keys.WithoutRevision(cd.keyHandler)
+	if !ok {
+		return fmt.Errorf("key handler %T does not support revision-independent cache keys", cd.keyHandler)
+	}
+
+	cd.invalidator = invalidator
+	cd.revisionIndependentKeyHandler = keyHandler
+	return nil
+}
+
+// requestCacheKey is the key under which the result of a request is cached.
+type requestCacheKey struct {
+	key keys.DispatchCacheKey
+
+	// scope, if set, indicates that the key does not include the revision of the request, and
+	// holds the revision at which the request is made and the relations on which it depends.
+	scope *invalidationScope
+}
+
+// trackedEntry is a cached result which may be reused at revisions other than the one at which
+// it was computed, or which depends on relationships that expire.
+type trackedEntry struct {
+	// scope, if set, holds the revision at which the result was computed and the relations on
+	// which it depends.
+	scope *invalidationScope
+
+	// expiresAt, if set, is the earliest expiration of the relationships read to compute the
+	// result, from which time on it is no longer reused.
+	expiresAt *time.Time
+
+	value any
+}
+
+// cacheKey computes the key under which the result of a request is cached, which is independent
+// of the revision of the request if results for the request can be reused across revisions.
+func (cd *Dispatcher) cacheKey(
+	ctx context.Context,
+	metadata *v1.ResolverMeta,
+	relation *core.RelationReference,
+	computeKey func(keyHandler keys.Handler) (keys.DispatchCacheKey, error),
+) (requestCacheKey, error) {
+	if cd.invalidator != nil {
+		scope, ok, err := cd.invalidator.scope(ctx, metadata.AtRevision, relation)
+		if err != nil {
+			return requestCacheKey{}, err
+		}
+
+		if ok {
+			key, err := computeKey(cd.revisionIndependentKeyHandler)
+			return requestCacheKey{key: key, scope: scope}, err
+		}
+	}
+
+	key, err := computeKey(cd.keyHandler)
+	return requestCacheKey{key: key}, err
+}
+
+// get returns the cached result for the key, if any and still valid at the revision of the
+// request and at the current time. The expiration of the result, if any, is recorded in the
+// expiration tracker of the context, as reusing it bounds the validity of the result it is used
+// to compute.
+func (cd *Dispatcher) get(ctx context.Context, key requestCacheKey) (any, bool) {
+	cached, found := cd.c.Get(key.key)
+	if !found {
+		return nil, false
+	}
+
+	entry, ok := cached.(trackedEntry)
+	if !ok {
+		return cached, key.scope == nil
+	}
+
+	if isExpired(entry.expiresAt) {
+		return nil, false
+	}
+
+	if key.scope != nil && (entry.scope == nil || !cd.invalidator.isValid(entry.scope, key.scope.revision)) {
+		return nil, false
+	}
+
+	dispatch.ObserveExpiration(ctx, entry.expiresAt)
+	return entry.value, true
+}
+
+// set caches the result for the key, computed while recording expirations with the given
+// tracker.
+func (cd *Dispatcher) set(key requestCacheKey, tracker *dispatch.ExpirationTracker, value any, cost int64) {
+	expiresAt := tracker.Earliest()
+	if isExpired(expiresAt) {
+		return
+	}
+
+	if key.scope != nil || expiresAt != nil {
+		value = trackedEntry{scope: key.scope, expiresAt: expiresAt, value: value}
+	}
+	cd.c.Set(key.key, value, cost)
+}
+
 // DispatchCheck implements dispatch.Check interface
 func (cd *Dispatcher) DispatchCheck(ctx context.Context, req *v1.DispatchCheckRequest) (*v1.DispatchCheckResponse, error) {
 	cd.checkTotalCounter.Inc()
 
-	requestKey, err := cd.keyHandler.CheckCacheKey(ctx, req)
+	requestKey, err := cd.cacheKey(ctx, req.Metadata, req.ResourceRelation, func(keyHandler keys.Handler) (keys.DispatchCacheKey, error) {
+		return keyHandler.CheckCacheKey(ctx, req)
+	})
 	if err != nil {
 		return &v1.DispatchCheckResponse{Metadata: &v1.ResponseMeta{}}, err
 	}
 
 	// Disable caching when debugging is enabled.
 	span := trace.SpanFromContext(ctx)
-	if cachedResultRaw, found := cd.c.Get(requestKey); found {
+	if cachedResultRaw, found := cd.get(ctx, requestKey); found {
 		var response v1.DispatchCheckResponse
 		if err := response.UnmarshalVT(cachedResultRaw.([]byte)); err != nil {
 			return &v1.DispatchCheckResponse{Metadata: &v1.ResponseMeta{}}, err
@@ -196,7 +308,8 @@ func (cd *Dispatcher) DispatchCheck(ctx context.Context, req *v1.DispatchCheckRe
 		}
 	}
 	span.SetAttributes(attribute.Bool("cached", false))
-	computed, err := cd.d.DispatchCheck(ctx, req)
+	trackedCtx, tracker := dispatch.ContextWithExpirationTracker(ctx)
+	computed, err := cd.d.DispatchCheck(trackedCtx, req)
 
 	// We only want to cache the result if there was no error
 	if err == nil {
@@ -210,7 +323,7 @@ func (cd *Dispatcher) DispatchCheck(ctx context.Context, req *v1.DispatchCheckRe
 			return &v1.DispatchCheckResponse{Metadata: &v1.ResponseMeta{}}, err
 		}
 
-		cd.c.Set(requestKey, adjustedBytes, sliceSize(adjustedBytes))
+		cd.set(requestKey, tracker, adjustedBytes, sliceSize(adjustedBytes))
 	}
 
 	// Return both the computed and err in ALL cases: computed contains resolved
@@ -228,12 +341,14 @@ func (cd *Dispatcher) DispatchExpand(ctx context.Context, req *v1.DispatchExpand
 func (cd *Dispatcher) DispatchReachableResources(req *v1.DispatchReachableResourcesRequest, stream dispatch.ReachableResourcesStream) error {
 	cd.reachableResourcesTotalCounter.Inc()
 
-	requestKey, err := cd.keyHandler.ReachableResourcesCacheKey(stream.Context(), req)
+	requestKey, err := cd.cacheKey(stream.Context(), req.Metadata, req.ResourceRelation, func(keyHandler keys.Handler) (keys.DispatchCacheKey, error) {
+		return keyHandler.ReachableResourcesCacheKey(stream.Context(), req)
+	})
 	if err != nil {
 		return err
 	}
 
-	if cachedResultRaw, found := cd.c.Get(requestKey); found {
+	if cachedResultRaw, found := cd.get(stream.Context(), requestKey); found {
 		cd.reachableResourcesFromCacheCounter.Inc()
 		for _, slice := range cachedResultRaw.([][]byte) {
 			var response v1.DispatchReachableResourcesResponse
@@ -252,9 +367,10 @@ func (cd *Dispatcher) DispatchReachableResources(req *v1.DispatchReachableResour
 		mu             sync.Mutex
 		toCacheResults [][]byte
 	)
+	trackedCtx, tracker := dispatch.ContextWithExpirationTracker(stream.Context())
 	wrapped := &dispatch.WrappedDispatchStream[*v1.DispatchReachableResourcesResponse]{
 		Stream: stream,
-		Ctx:    stream.Context(),
+		Ctx:    trackedCtx,
 		Processor: func(result *v1.DispatchReachableResourcesResponse) (*v1.DispatchReachableResourcesResponse, bool, error) {
 			adjustedResult := result.CloneVT()
 			adjustedResult.Metadata.CachedDispatchCount = adjustedResult.Metadata.DispatchCount
@@ -283,10 +399,15 @@ func (cd *Dispatcher) DispatchReachableResources(req *v1.DispatchReachableResour
 		size += sliceSize(slice)
 	}
 
-	cd.c.Set(requestKey, toCacheResults, size)
+	cd.set(requestKey, tracker, toCacheResults, size)
 	return nil
 }
 
+// isExpired returns whether a result expiring at the given time, if any, has expired.
+func isExpired(expiration *time.Time) bool {
+	return expiration != nil && !time.Now().Before(*expiration)
+}
+
 func sliceSize(xs []byte) int64 {
 	// Slice Header + Slice Contents
 	return int64(int(unsafe.Sizeof(xs)) + len(xs))
@@ -296,12 +417,14 @@ func sliceSize(xs []byte) int64 {
 func (cd *Dispatcher) DispatchLookupResources(req *v1.DispatchLookupResourcesRequest, stream dispatch.LookupResourcesStream) error {
 	cd.lookupResourcesTotalCounter.Inc()
 
-	requestKey, err := cd.keyHandler.LookupResourcesCacheKey(stream.Context(), req)
+	requestKey, err := cd.cacheKey(stream.Context(), req.Metadata, req.ObjectRelation, func(keyHandler keys.Handler) (keys.DispatchCacheKey, error) {
+		return keyHandler.LookupResourcesCacheKey(stream.Context(), req)
+	})
 	if err != nil {
 		return err
 	}
 
-	if cachedResultRaw, found := cd.c.Get(requestKey); found {
+	if cachedResultRaw, found := cd.get(stream.Context(), requestKey); found {
 		cd.lookupResourcesFromCacheCounter.Inc()
 		for _, slice := range cachedResultRaw.([][]byte) {
 			var response v1.DispatchLookupResourcesResponse
@@ -322,9 +445,10 @@ func (cd *Dispatcher) DispatchLookupResources(req *v1.DispatchLookupResourcesReq
 		mu             sync.Mutex
 		toCacheResults [][]byte
 	)
+	trackedCtx, tracker := dispatch.ContextWithExpirationTracker(stream.Context())
 	wrapped := &dispatch.WrappedDispatchStream[*v1.DispatchLookupResourcesResponse]{
 		Stream: stream,
-		Ctx:    stream.Context(),
+		Ctx:    trackedCtx,
 		Processor: func(result *v1.DispatchLookupResourcesResponse) (*v1.DispatchLookupResourcesResponse, bool, error) {
 			adjustedResult := result.CloneVT()
 			adjustedResult.Metadata.CachedDispatchCount = adjustedResult.Metadata.DispatchCount
@@ -353,7 +477,7 @@ func (cd *Dispatcher) DispatchLookupResources(req *v1.DispatchLookupResourcesReq
 		size += sliceSize(slice)
 	}
 
-	cd.c.Set(requestKey, toCacheResults, size)
+	cd.set(requestKey, tracker, toCacheResults, size)
 	return nil
 }
 
@@ -361,12 +485,14 @@ func (cd *Dispatcher) DispatchLookupResources(req *v1.DispatchLookupResourcesReq
 func (cd *Dispatcher) DispatchLookupSubjects(req *v1.DispatchLookupSubjectsRequest, stream dispatch.LookupSubjectsStream) error {
 	cd.lookupSubjectsTotalCounter.Inc()
 
-	requestKey, err := cd.keyHandler.LookupSubjectsCacheKey(stream.Context(), req)
+	requestKey, err := cd.cacheKey(stream.Context(), req.Metadata, req.ResourceRelation, func(keyHandler keys.Handler) (keys.DispatchCacheKey, error) {
+		return keyHandler.LookupSubjectsCacheKey(stream.Context(), req)
+	})
 	if err != nil {
 		return err
 	}
 
-	if cachedResultRaw, found := cd.c.Get(requestKey); found {
+	if cachedResultRaw, found := cd.get(stream.Context(), requestKey); found {
 		cd.lookupSubjectsFromCacheCounter.Inc()
 		for _, slice := range cachedResultRaw.([][]byte) {
 			var response v1.DispatchLookupSubjectsResponse
@@ -387,9 +513,10 @@ func (cd *Dispatcher) DispatchLookupSubjects(req *v1.DispatchLookupSubjectsReque
 		mu             sync.Mutex
 		toCacheResults [][]byte
 	)
+	trackedCtx, tracker := dispatch.ContextWithExpirationTracker(stream.Context())
 	wrapped := &dispatch.WrappedDispatchStream[*v1.DispatchLookupSubjectsResponse]{
 		Stream: stream,
-		Ctx:    stream.Context(),
+		Ctx:    trackedCtx,
 		Processor: func(result *v1.DispatchLookupSubjectsResponse) (*v1.DispatchLookupSubjectsResponse, bool, error) {
 			adjustedResult := result.CloneVT()
 			adjustedResult.Metadata.CachedDispatchCount = adjustedResult.Metadata.DispatchCount
@@ -418,7 +545,7 @@ func (cd *Dispatcher) DispatchLookupSubjects(req *v1.DispatchLookupSubjectsReque
 		size += sliceSize(slice)
 	}
 
-	cd.c.Set(requestKey, toCacheResults, size)
+	cd.set(requestKey, tracker, toCacheResults, size)
 	return nil
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/authzed/spicedb/internal/dispatch"
+	"github.com/authzed/spicedb/pkg/cache"
 	core "github.com/authzed/spicedb/pkg/proto/core/v1"
 	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
 	"github.com/authzed/spicedb/pkg/tuple"
@@ -180,3 +181,52 @@ func (ddm delegateDispatchMock) ReadyState() dispatch.ReadyState {
 }
 
 var _ dispatch.Dispatcher = &delegateDispatchMock{}
+
+func TestSharedRemoteCache(t *testing.T) {
+	require := require.New(t)
+	store := cache.NewInProcessRemoteStore()
+
+	request := &v1.DispatchCheckRequest{
+		ResourceRelation: RR("document", "view"),
+		ResourceIds:      []string{"somedoc"},
+		Subject:          tuple.ParseSubjectONR("user:tom"),
+		Metadata: &v1.ResolverMeta{
+			AtRevision:     "1234",
+			DepthRemaining: 50,
+		},
+	}
+
+	newDispatcher := func(delegate delegateDispatchMock) *Dispatcher {
+		tiered, err := cache.NewTieredCache(DispatchTestCache(t), cache.RemoteConfig{Store: store})
+		require.NoError(err)
+		dispatcher, err := NewCachingDispatcher(tiered, false, "", nil)
+		require.NoError(err)
+		dispatcher.SetDelegate(delegate)
+		t.Cleanup(func() { _ = dispatcher.Close() })
+		return dispatcher
+	}
+
+	firstDelegate := delegateDispatchMock{&mock.Mock{}}
+	firstDelegate.On("DispatchCheck", request).Return(&v1.DispatchCheckResponse{
+		ResultsByResourceId: map[string]*v1.ResourceCheckResult{
+			"somedoc": {Membership: v1.ResourceCheckResult_MEMBER},
+		},
+		Metadata: &v1.ResponseMeta{DispatchCount: 1, DepthRequired: 1},
+	}, nil).Times(1)
+
+	first := newDispatcher(firstDelegate)
+	_, err := first.DispatchCheck(context.Background(), request)
+	require.NoError(err)
+	first.c.Wait()
+
+	// A dispatcher sharing the remote store finds the result without computing it.
+	secondDelegate := delegateDispatchMock{&mock.Mock{}}
+	second := newDispatcher(secondDelegate)
+	resp, err := second.DispatchCheck(context.Background(), request)
+	require.NoError(err)
+	require.Equal(v1.ResourceCheckResult_MEMBER, resp.ResultsByResourceId["somedoc"].Membership)
+	require.Equal(uint32(1), resp.Metadata.CachedDispatchCount)
+
+	firstDelegate.AssertExpectations(t)
+	secondDelegate.AssertExpectations(t)
+}
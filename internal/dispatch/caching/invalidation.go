@@ -0,0 +1,324 @@
+package caching
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/genutil/mapz"
+	"github.com/authzed/spicedb/pkg/graph"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// watchRestartDelay is the delay before the watch is restarted after an error.
+const watchRestartDelay = 5 * time.Second
+
+// WatchInvalidator tracks the relations changed at each revision via the Watch API, allowing the
+// caching Dispatcher to reuse a result computed at one revision for a request made at another,
+// so long as no relation on which the result depends has been changed between the two revisions.
+//
+// Results are only reused between revisions at or after the revision at which the watch was
+// started and at or before the latest checkpoint received from the watch. Any change to the schema
+// prevents reusing results computed before it.
+type WatchInvalidator struct {
+	ds                 datastore.Datastore
+	checkpointInterval time.Duration
+
+	lock sync.RWMutex
+
+	// watching indicates whether the watch is running. If false, no results are reused.
+	watching bool
+
+	// started is the revision after which all changes are observed by the watch.
+	started datastore.Revision
+
+	// observed is the latest revision at which all changes have been observed by the watch.
+	observed datastore.Revision
+
+	// schemaChanged is the latest revision at which the schema was changed, if any.
+	schemaChanged datastore.Revision
+
+	// relationChanged holds the latest revision at which relationships were changed for each
+	// relation, keyed by its relation reference string.
+	relationChanged map[string]datastore.Revision
+
+	// dependencies memoizes the relations on which each relation depends in the latest schema,
+	// keyed by its relation reference string.
+	dependencies map[string][]string
+
+	// generation is incremented whenever the memoized dependencies are cleared.
+	generation uint64
+}
+
+// NewWatchInvalidator creates a new WatchInvalidator for the given datastore. No results are
+// reused until Start is called.
+func NewWatchInvalidator(ds datastore.Datastore, checkpointInterval time.Duration) *WatchInvalidator {
+	return &WatchInvalidator{
+		ds:                 ds,
+		checkpointInterval: checkpointInterval,
+		relationChanged:    map[string]datastore.Revision{},
+		dependencies:       map[string][]string{},
+	}
+}
+
+// Start watches the datastore for changes until the context is canceled.
+func (wi *WatchInvalidator) Start(ctx context.Context) error {
+	for {
+		err := wi.watch(ctx)
+		wi.stopWatching()
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		log.Ctx(ctx).Warn().Err(err).Msg("dispatch cache invalidation watch failed; restarting")
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(watchRestartDelay):
+		}
+	}
+}
+
+func (wi *WatchInvalidator) watch(ctx context.Context) error {
+	headRevision, err := wi.ds.HeadRevision(ctx)
+	if err != nil {
+		return err
+	}
+
+	changes, errs := wi.ds.Watch(ctx, headRevision, datastore.WatchOptions{
+		Content:            datastore.WatchRelationships | datastore.WatchSchema | datastore.WatchCheckpoints,
+		CheckpointInterval: wi.checkpointInterval,
+	})
+
+	// All changes after the head revision will be received from the watch, so results computed
+	// from this point on can be reused until a relevant change is observed.
+	wi.startWatching(headRevision)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case change, ok := <-changes:
+			if !ok {
+				return errors.New("watch closed")
+			}
+			wi.applyChange(change)
+
+		case err := <-errs:
+			return err
+		}
+	}
+}
+
+func (wi *WatchInvalidator) startWatching(revision datastore.Revision) {
+	wi.lock.Lock()
+	defer wi.lock.Unlock()
+
+	wi.watching = true
+	wi.started = revision
+	wi.observed = revision
+	wi.schemaChanged = nil
+	wi.relationChanged = map[string]datastore.Revision{}
+	wi.dependencies = map[string][]string{}
+	wi.generation++
+}
+
+func (wi *WatchInvalidator) stopWatching() {
+	wi.lock.Lock()
+	defer wi.lock.Unlock()
+	wi.watching = false
+}
+
+// applyChange records the relations changed by the given change, or advances the observed
+// revision for a checkpoint.
+func (wi *WatchInvalidator) applyChange(change *datastore.RevisionChanges) {
+	wi.lock.Lock()
+	defer wi.lock.Unlock()
+
+	if change.IsCheckpoint {
+		if change.Revision.GreaterThan(wi.observed) {
+			wi.observed = change.Revision
+		}
+		return
+	}
+
+	if len(change.ChangedDefinitions) > 0 || len(change.DeletedNamespaces) > 0 || len(change.DeletedCaveats) > 0 {
+		wi.schemaChanged = change.Revision
+		wi.dependencies = map[string][]string{}
+		wi.generation++
+	}
+
+	for _, update := range change.RelationshipChanges {
+		wi.relationChanged[tuple.JoinRelRef(
+			update.Tuple.ResourceAndRelation.Namespace,
+			update.Tuple.ResourceAndRelation.Relation,
+		)] = change.Revision
+	}
+}
+
+// invalidationScope is the revision at which a result is computed and the relations on which the
+// result depends.
+type invalidationScope struct {
+	revision     datastore.Revision
+	dependencies []string
+}
+
+// scope returns the scope of a request made at the given revision for the given relation, or
+// false if results for the request cannot be reused across revisions.
+func (wi *WatchInvalidator) scope(ctx context.Context, atRevision string, relation *core.RelationReference) (*invalidationScope, bool, error) {
+	wi.lock.RLock()
+	watching, started := wi.watching, wi.started
+	wi.lock.RUnlock()
+
+	if !watching {
+		return nil, false, nil
+	}
+
+	revision, err := wi.ds.RevisionFromString(atRevision)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if revision.LessThan(started) {
+		return nil, false, nil
+	}
+
+	dependencies, err := wi.dependenciesOf(ctx, revision, relation)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &invalidationScope{revision, dependencies}, true, nil
+}
+
+// isValid returns whether a result computed within the given scope is valid at the given
+// revision, which is the case if all changes between the two revisions have been observed and
+// none of them changed the schema or a relation on which the result depends.
+func (wi *WatchInvalidator) isValid(computed *invalidationScope, revision datastore.Revision) bool {
+	earlier, later := computed.revision, revision
+	if later.LessThan(earlier) {
+		earlier, later = later, earlier
+	}
+
+	wi.lock.RLock()
+	defer wi.lock.RUnlock()
+
+	if !wi.watching || earlier.LessThan(wi.started) || later.GreaterThan(wi.observed) {
+		return false
+	}
+
+	if wi.schemaChanged != nil && wi.schemaChanged.GreaterThan(earlier) {
+		return false
+	}
+
+	for _, dependency := range computed.dependencies {
+		if changed, ok := wi.relationChanged[dependency]; ok && changed.GreaterThan(earlier) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dependenciesOf returns the relations on which the given relation depends at the given revision,
+// memoizing them if the revision is known to have the latest schema.
+func (wi *WatchInvalidator) dependenciesOf(ctx context.Context, revision datastore.Revision, relation *core.RelationReference) ([]string, error) {
+	key := tuple.StringRR(relation)
+
+	wi.lock.RLock()
+	isLatestSchema := !revision.GreaterThan(wi.observed) &&
+		(wi.schemaChanged == nil || !revision.LessThan(wi.schemaChanged))
+	dependencies, ok := wi.dependencies[key]
+	generation := wi.generation
+	wi.lock.RUnlock()
+
+	if isLatestSchema && ok {
+		return dependencies, nil
+	}
+
+	dependencies, err := relationDependencies(ctx, wi.ds.SnapshotReader(revision), relation)
+	if err != nil {
+		return nil, err
+	}
+
+	if isLatestSchema {
+		wi.lock.Lock()
+		if wi.generation == generation {
+			wi.dependencies[key] = dependencies
+		}
+		wi.lock.Unlock()
+	}
+
+	return dependencies, nil
+}
+
+// relationDependencies returns the relation and all the relations and permissions it
+// transitively references in the schema, as relation reference strings.
+func relationDependencies(ctx context.Context, reader datastore.Reader, relation *core.RelationReference) ([]string, error) {
+	namespaces := make(map[string]*core.NamespaceDefinition)
+	visited := mapz.NewSet[string]()
+	toVisit := []*core.RelationReference{relation}
+
+	for len(toVisit) > 0 {
+		current := toVisit[0]
+		toVisit = toVisit[1:]
+		if current.Relation == tuple.Ellipsis || !visited.Add(tuple.StringRR(current)) {
+			continue
+		}
+
+		nsDef, ok := namespaces[current.Namespace]
+		if !ok {
+			found, _, err := reader.ReadNamespaceByName(ctx, current.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			nsDef = found
+			namespaces[current.Namespace] = nsDef
+		}
+
+		rel := findRelation(nsDef, current.Relation)
+		if rel == nil {
+			continue
+		}
+
+		for _, allowed := range rel.GetTypeInformation().GetAllowedDirectRelations() {
+			if allowed.GetRelation() != "" {
+				toVisit = append(toVisit, &core.RelationReference{Namespace: allowed.Namespace, Relation: allowed.GetRelation()})
+			}
+		}
+
+		_, _ = graph.WalkRewrite(rel.UsersetRewrite, func(childOneof *core.SetOperation_Child) interface{} {
+			switch child := childOneof.ChildType.(type) {
+			case *core.SetOperation_Child_ComputedUserset:
+				toVisit = append(toVisit, &core.RelationReference{Namespace: nsDef.Name, Relation: child.ComputedUserset.Relation})
+
+			case *core.SetOperation_Child_TupleToUserset:
+				tuplesetRelation := child.TupleToUserset.GetTupleset().GetRelation()
+				toVisit = append(toVisit, &core.RelationReference{Namespace: nsDef.Name, Relation: tuplesetRelation})
+
+				computedRelation := child.TupleToUserset.GetComputedUserset().GetRelation()
+				for _, allowed := range findRelation(nsDef, tuplesetRelation).GetTypeInformation().GetAllowedDirectRelations() {
+					toVisit = append(toVisit, &core.RelationReference{Namespace: allowed.Namespace, Relation: computedRelation})
+				}
+			}
+			return nil
+		})
+	}
+
+	return visited.AsSlice(), nil
+}
+
+func findRelation(nsDef *core.NamespaceDefinition, relationName string) *core.Relation {
+	for _, rel := range nsDef.Relation {
+		if rel.Name == relationName {
+			return rel
+		}
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package caching
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/internal/dispatch/graph"
+	"github.com/authzed/spicedb/internal/dispatch/keys"
+	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
+	"github.com/authzed/spicedb/internal/testfixtures"
+	"github.com/authzed/spicedb/pkg/datastore"
+	ns "github.com/authzed/spicedb/pkg/namespace"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+const invalidationSchema = `
+	definition user {}
+
+	definition team {
+		relation member: user
+	}
+
+	definition document {
+		relation viewer: user | team#member
+		relation banned: user
+		permission view = viewer - banned
+	}
+
+	definition folder {
+		relation doc: document
+		permission view = doc->view
+	}
+`
+
+func TestRelationDependencies(t *testing.T) {
+	tcs := []struct {
+		relation     *core.RelationReference
+		expectedDeps []string
+	}{
+		{RR("user", "..."), nil},
+		{RR("team", "member"), []string{"team#member"}},
+		{RR("document", "banned"), []string{"document#banned"}},
+		{RR("document", "view"), []string{"document#banned", "document#view", "document#viewer", "team#member"}},
+		{RR("folder", "view"), []string{"document#banned", "document#view", "document#viewer", "folder#doc", "folder#view", "team#member"}},
+	}
+
+	ds := invalidationDatastore(t)
+	headRevision, err := ds.HeadRevision(context.Background())
+	require.NoError(t, err)
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tuple.StringRR(tc.relation), func(t *testing.T) {
+			deps, err := relationDependencies(context.Background(), ds.SnapshotReader(headRevision), tc.relation)
+			require.NoError(t, err)
+
+			sort.Strings(deps)
+			require.Equal(t, tc.expectedDeps, deps)
+		})
+	}
+}
+
+func TestWatchInvalidation(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ds := invalidationDatastore(t)
+	invalidator := NewWatchInvalidator(ds, 0)
+	go func() {
+		_ = invalidator.Start(ctx)
+	}()
+
+	delegate := delegateDispatchMock{&mock.Mock{}}
+	delegate.On("DispatchCheck", mock.Anything).Return(&v1.DispatchCheckResponse{
+		ResultsByResourceId: map[string]*v1.ResourceCheckResult{
+			"somedoc": {Membership: v1.ResourceCheckResult_MEMBER},
+		},
+		Metadata: &v1.ResponseMeta{DispatchCount: 1, DepthRequired: 1},
+	}, nil)
+
+	dispatcher, err := NewCachingDispatcher(DispatchTestCache(t), false, "", &keys.DirectKeyHandler{})
+	require.NoError(err)
+	dispatcher.SetDelegate(delegate)
+	require.NoError(dispatcher.SetInvalidator(invalidator))
+	defer dispatcher.Close()
+
+	require.Eventually(func() bool {
+		invalidator.lock.RLock()
+		defer invalidator.lock.RUnlock()
+		return invalidator.watching
+	}, 5*time.Second, 10*time.Millisecond)
+
+	check := func(revision datastore.Revision, expectedDispatches int) {
+		t.Helper()
+		waitForObserved(t, invalidator, revision)
+
+		_, err := dispatcher.DispatchCheck(ctx, &v1.DispatchCheckRequest{
+			ResourceRelation: RR("document", "view"),
+			ResourceIds:      []string{"somedoc"},
+			Subject:          tuple.ParseSubjectONR("user:tom"),
+			Metadata: &v1.ResolverMeta{
+				AtRevision:     revision.String(),
+				DepthRemaining: 50,
+			},
+		})
+		require.NoError(err)
+		dispatcher.c.Wait()
+		delegate.AssertNumberOfCalls(t, "DispatchCheck", expectedDispatches)
+	}
+
+	writeRelationship := func(rel string) datastore.Revision {
+		revision, err := common.WriteTuples(ctx, ds, core.RelationTupleUpdate_TOUCH, tuple.MustParse(rel))
+		require.NoError(err)
+		return revision
+	}
+
+	initialRevision, err := ds.HeadRevision(ctx)
+	require.NoError(err)
+
+	// The first check is computed and then cached.
+	check(initialRevision, 1)
+	check(initialRevision, 1)
+
+	// Changes to relations on which the check does not depend do not invalidate it.
+	unrelatedRevision := writeRelationship("folder:somefolder#doc@document:somedoc")
+	check(unrelatedRevision, 1)
+
+	// Changes to relations on which the check depends, even indirectly, invalidate it.
+	relatedRevision := writeRelationship("team:someteam#member@user:tom")
+	check(relatedRevision, 2)
+	check(relatedRevision, 2)
+
+	// Results are not reused at revisions before an intervening change.
+	check(unrelatedRevision, 3)
+
+	// Any schema change invalidates all results.
+	schemaRevision, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteNamespaces(ctx, ns.Namespace("team",
+			ns.MustRelation("member", nil, ns.AllowedRelation("user", "...")),
+			ns.MustRelation("admin", nil, ns.AllowedRelation("user", "...")),
+		))
+	})
+	require.NoError(err)
+	check(schemaRevision, 4)
+	check(schemaRevision, 4)
+}
+
+func TestExpiredResultsNotReused(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ds := invalidationDatastore(t)
+	invalidator := NewWatchInvalidator(ds, 0)
+	go func() {
+		_ = invalidator.Start(ctx)
+	}()
+
+	dispatcher, err := NewCachingDispatcher(DispatchTestCache(t), false, "", &keys.DirectKeyHandler{})
+	require.NoError(err)
+	dispatcher.SetDelegate(graph.NewDispatcher(dispatcher, graph.SharedConcurrencyLimits(10), nil))
+	require.NoError(dispatcher.SetInvalidator(invalidator))
+	defer dispatcher.Close()
+
+	ctx = datastoremw.ContextWithDatastore(ctx, ds)
+
+	check := func(revision datastore.Revision) *v1.DispatchCheckResponse {
+		t.Helper()
+		waitForObserved(t, invalidator, revision)
+
+		resp, err := dispatcher.DispatchCheck(ctx, &v1.DispatchCheckRequest{
+			ResourceRelation: RR("document", "view"),
+			ResourceIds:      []string{"somedoc"},
+			ResultsSetting:   v1.DispatchCheckRequest_ALLOW_SINGLE_RESULT,
+			Subject:          tuple.ParseSubjectONR("user:tom"),
+			Metadata: &v1.ResolverMeta{
+				AtRevision:     revision.String(),
+				DepthRemaining: 50,
+			},
+		})
+		require.NoError(err)
+		dispatcher.c.Wait()
+		return resp
+	}
+
+	expiration := time.Now().Add(time.Second)
+	grantRevision, err := common.WriteTuples(ctx, ds, core.RelationTupleUpdate_TOUCH,
+		tuple.WithExpiration(tuple.MustParse("document:somedoc#viewer@user:tom"), expiration))
+	require.NoError(err)
+
+	resp := check(grantRevision)
+	require.Equal(v1.ResourceCheckResult_MEMBER, resp.ResultsByResourceId["somedoc"].Membership)
+
+	// Before the grant expires, the result is reused at a newer revision.
+	unrelatedRevision, err := common.WriteTuples(ctx, ds, core.RelationTupleUpdate_TOUCH, tuple.MustParse("folder:somefolder#doc@document:somedoc"))
+	require.NoError(err)
+
+	resp = check(unrelatedRevision)
+	require.Equal(v1.ResourceCheckResult_MEMBER, resp.ResultsByResourceId["somedoc"].Membership)
+	require.Zero(resp.Metadata.DispatchCount)
+
+	// Once the grant expires, without any change observed by the invalidator, the result is
+	// no longer reused.
+	time.Sleep(time.Until(expiration))
+
+	resp = check(unrelatedRevision)
+	require.Empty(resp.ResultsByResourceId)
+	require.NotZero(resp.Metadata.DispatchCount)
+}
+
+func TestWatchInvalidationNotWatching(t *testing.T) {
+	require := require.New(t)
+
+	ds := invalidationDatastore(t)
+	headRevision, err := ds.HeadRevision(context.Background())
+	require.NoError(err)
+
+	invalidator := NewWatchInvalidator(ds, 0)
+	scope, ok, err := invalidator.scope(context.Background(), headRevision.String(), RR("document", "view"))
+	require.NoError(err)
+	require.False(ok)
+	require.Nil(scope)
+
+	dispatcher, err := NewCachingDispatcher(DispatchTestCache(t), false, "", &keys.DirectKeyHandler{})
+	require.NoError(err)
+	require.NoError(dispatcher.SetInvalidator(invalidator))
+
+	key, err := dispatcher.cacheKey(context.Background(), &v1.ResolverMeta{AtRevision: headRevision.String()}, RR("document", "view"),
+		func(keyHandler keys.Handler) (keys.DispatchCacheKey, error) {
+			return keyHandler.LookupSubjectsCacheKey(context.Background(), &v1.DispatchLookupSubjectsRequest{
+				Metadata:         &v1.ResolverMeta{AtRevision: headRevision.String()},
+				ResourceRelation: RR("document", "view"),
+				SubjectRelation:  RR("user", "..."),
+				ResourceIds:      []string{"somedoc"},
+			})
+		},
+	)
+	require.NoError(err)
+	require.Nil(key.scope)
+}
+
+func invalidationDatastore(t *testing.T) datastore.Datastore {
+	rawDS, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = rawDS.Close() })
+
+	ds, _ := testfixtures.DatastoreFromSchemaAndTestRelationships(rawDS, invalidationSchema, nil, require.New(t))
+	return ds
+}
+
+func waitForObserved(t *testing.T, invalidator *WatchInvalidator, revision datastore.Revision) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		invalidator.lock.RLock()
+		defer invalidator.lock.RUnlock()
+		return invalidator.watching && !revision.GreaterThan(invalidator.observed)
+	}, 5*time.Second, 10*time.Millisecond)
+}
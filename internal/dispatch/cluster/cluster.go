@@ -18,6 +18,8 @@ type optionState struct {
 	prometheusSubsystem   string
 	cache                 cache.Cache
 	concurrencyLimits     graph.ConcurrencyLimits
+	materialized          graph.MaterializedPermissions
+	cacheInvalidator      *caching.WatchInvalidator
 	remoteDispatchTimeout time.Duration
 }
 
@@ -49,6 +51,22 @@ func ConcurrencyLimits(limits graph.ConcurrencyLimits) Option {
 	}
 }
 
+// MaterializedPermissions sets the precomputed resources to consult for materialized
+// permissions. If unset, materialized permissions are always computed.
+func MaterializedPermissions(materialized graph.MaterializedPermissions) Option {
+	return func(state *optionState) {
+		state.materialized = materialized
+	}
+}
+
+// CacheInvalidator sets the invalidator used to reuse cached dispatch results across revisions.
+// If unset, cached results are only used at the revision at which they were computed.
+func CacheInvalidator(invalidator *caching.WatchInvalidator) Option {
+	return func(state *optionState) {
+		state.cacheInvalidator = invalidator
+	}
+}
+
 // RemoteDispatchTimeout sets the maximum timeout for a remote dispatch.
 // Defaults to 60s (as defined in the remote dispatcher).
 func RemoteDispatchTimeout(remoteDispatchTimeout time.Duration) Option {
@@ -66,7 +84,7 @@ func NewClusterDispatcher(dispatch dispatch.Dispatcher, options ...Option) (disp
 		fn(&opts)
 	}
 
-	clusterDispatch := graph.NewDispatcher(dispatch, opts.concurrencyLimits)
+	clusterDispatch := graph.NewDispatcher(dispatch, opts.concurrencyLimits, opts.materialized)
 
 	if opts.prometheusSubsystem == "" {
 		opts.prometheusSubsystem = "dispatch"
@@ -76,6 +94,12 @@ func NewClusterDispatcher(dispatch dispatch.Dispatcher, options ...Option) (disp
 	if err != nil {
 		return nil, err
 	}
+
+	if opts.cacheInvalidator != nil {
+		if err := cachingClusterDispatch.SetInvalidator(opts.cacheInvalidator); err != nil {
+			return nil, err
+		}
+	}
 	cachingClusterDispatch.SetDelegate(clusterDispatch)
 	return cachingClusterDispatch, nil
 }
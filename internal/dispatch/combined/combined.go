@@ -12,6 +12,7 @@ import (
 
 	"github.com/authzed/spicedb/internal/dispatch"
 	"github.com/authzed/spicedb/internal/dispatch/caching"
+	"github.com/authzed/spicedb/internal/dispatch/discovery"
 	"github.com/authzed/spicedb/internal/dispatch/graph"
 	"github.com/authzed/spicedb/internal/dispatch/keys"
 	"github.com/authzed/spicedb/internal/dispatch/remote"
@@ -33,6 +34,9 @@ type optionState struct {
 	grpcDialOpts           []grpc.DialOption
 	cache                  cache.Cache
 	concurrencyLimits      graph.ConcurrencyLimits
+	materialized           graph.MaterializedPermissions
+	cacheInvalidator       *caching.WatchInvalidator
+	peerResolver           *discovery.Resolver
 	remoteDispatchTimeout  time.Duration
 	secondaryUpstreamAddrs map[string]string
 	secondaryUpstreamExprs map[string]string
@@ -114,6 +118,31 @@ func ConcurrencyLimits(limits graph.ConcurrencyLimits) Option {
 	}
 }
 
+// MaterializedPermissions sets the precomputed resources to consult for materialized
+// permissions. If unset, materialized permissions are always computed.
+func MaterializedPermissions(materialized graph.MaterializedPermissions) Option {
+	return func(state *optionState) {
+		state.materialized = materialized
+	}
+}
+
+// CacheInvalidator sets the invalidator used to reuse cached dispatch results across revisions.
+// If unset, cached results are only used at the revision at which they were computed.
+func CacheInvalidator(invalidator *caching.WatchInvalidator) Option {
+	return func(state *optionState) {
+		state.cacheInvalidator = invalidator
+	}
+}
+
+// PeerResolver sets the resolver of the peers to which requests are dispatched. If set, the
+// upstream address is ignored and requests are dispatched to the peers it discovers, which
+// requires the resolver to be started.
+func PeerResolver(resolver *discovery.Resolver) Option {
+	return func(state *optionState) {
+		state.peerResolver = resolver
+	}
+}
+
 // RemoteDispatchTimeout sets the maximum timeout for a remote dispatch.
 // Defaults to 60s (as defined in the remote dispatcher).
 func RemoteDispatchTimeout(remoteDispatchTimeout time.Duration) Option {
@@ -140,9 +169,22 @@ func NewDispatcher(options ...Option) (dispatch.Dispatcher, error) {
 		return nil, err
 	}
 
-	redispatch := graph.NewDispatcher(cachingRedispatch, opts.concurrencyLimits)
+	if opts.cacheInvalidator != nil {
+		if err := cachingRedispatch.SetInvalidator(opts.cacheInvalidator); err != nil {
+			return nil, err
+		}
+	}
+
+	redispatch := graph.NewDispatcher(cachingRedispatch, opts.concurrencyLimits, opts.materialized)
 	redispatch = singleflight.New(redispatch, &keys.CanonicalKeyHandler{})
 
+	var membership remote.Membership
+	if opts.peerResolver != nil {
+		opts.upstreamAddr = opts.peerResolver.Target()
+		opts.grpcDialOpts = append(opts.grpcDialOpts, grpc.WithResolvers(opts.peerResolver))
+		membership = opts.peerResolver
+	}
+
 	// If an upstream is specified, create a cluster dispatcher.
 	if opts.upstreamAddr != "" {
 		if opts.upstreamCAPath != "" {
@@ -188,6 +230,7 @@ func NewDispatcher(options ...Option) (dispatch.Dispatcher, error) {
 		redispatch = remote.NewClusterDispatcher(v1.NewDispatchServiceClient(conn), conn, remote.ClusterDispatcherConfig{
 			KeyHandler:             &keys.CanonicalKeyHandler{},
 			DispatchOverallTimeout: opts.remoteDispatchTimeout,
+			Membership:             membership,
 		}, secondaryClients, secondaryExprs)
 		redispatch = singleflight.New(redispatch, &keys.CanonicalKeyHandler{})
 	}
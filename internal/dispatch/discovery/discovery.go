@@ -0,0 +1,126 @@
+// Package discovery implements discovery of the peers to which requests are dispatched in a
+// dispatch cluster, for deployments where the peers cannot be resolved via Kubernetes.
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// KindStatic discovers peers from a fixed list of addresses.
+	KindStatic = "static"
+
+	// KindDNSSRV discovers peers by periodically resolving a DNS SRV record.
+	KindDNSSRV = "dns-srv"
+
+	// KindGossip discovers peers by gossiping membership with the other peers.
+	KindGossip = "gossip"
+)
+
+// Discoverer discovers the peers of a dispatch cluster.
+type Discoverer interface {
+	// Run discovers peers until the context is canceled, calling update with the dispatch
+	// addresses of all the known peers whenever they change.
+	Run(ctx context.Context, update func(addrs []string)) error
+}
+
+// Config configures the discovery of peers.
+type Config struct {
+	// Kind is the kind of discovery: `static`, `dns-srv` or `gossip`. If empty, peers are not
+	// discovered.
+	Kind string `debugmap:"visible"`
+
+	// StaticAddrs are the dispatch addresses of the peers for static discovery.
+	StaticAddrs []string `debugmap:"visible"`
+
+	// SRVName is the DNS name whose SRV records list the peers for DNS SRV discovery.
+	SRVName string `debugmap:"visible"`
+
+	// RefreshInterval is the interval at which the SRV records are resolved.
+	RefreshInterval time.Duration `debugmap:"visible"`
+
+	// GossipBindAddr is the UDP address on which membership is gossiped.
+	GossipBindAddr string `debugmap:"visible"`
+
+	// GossipAdvertiseAddr is the dispatch address of this peer advertised to the other peers.
+	GossipAdvertiseAddr string `debugmap:"visible"`
+
+	// GossipSeeds are the gossip addresses of the peers first contacted to join the cluster.
+	GossipSeeds []string `debugmap:"visible"`
+
+	// GossipKeys are the keys with which gossip messages are authenticated: messages are signed
+	// with the first key and accepted if signed with any of them.
+	GossipKeys []string `debugmap:"hidden"`
+}
+
+func (c *Config) MarshalZerologObject(e *zerolog.Event) {
+	e.Str("kind", c.Kind)
+	switch c.Kind {
+	case KindStatic:
+		e.Strs("addrs", c.StaticAddrs)
+	case KindDNSSRV:
+		e.Str("srvName", c.SRVName).Dur("refreshInterval", c.RefreshInterval)
+	case KindGossip:
+		e.Str("bindAddr", c.GossipBindAddr).Str("advertiseAddr", c.GossipAdvertiseAddr).Strs("seeds", c.GossipSeeds)
+	}
+}
+
+// Complete validates the config and returns the Discoverer it describes, or nil if peers are
+// not discovered.
+func (c *Config) Complete() (Discoverer, error) {
+	switch c.Kind {
+	case "":
+		return nil, nil
+
+	case KindStatic:
+		if len(c.StaticAddrs) == 0 {
+			return nil, errors.New("static peer discovery requires at least one address")
+		}
+		return NewStaticDiscoverer(c.StaticAddrs), nil
+
+	case KindDNSSRV:
+		if c.SRVName == "" {
+			return nil, errors.New("DNS SRV peer discovery requires an SRV name")
+		}
+		return NewSRVDiscoverer(c.SRVName, c.RefreshInterval), nil
+
+	case KindGossip:
+		if c.GossipBindAddr == "" || c.GossipAdvertiseAddr == "" {
+			return nil, errors.New("gossip peer discovery requires a bind address and an advertise address")
+		}
+		if len(c.GossipKeys) == 0 || slices.Contains(c.GossipKeys, "") {
+			return nil, errors.New("gossip peer discovery requires non-empty keys")
+		}
+		return NewGossipDiscoverer(c.GossipBindAddr, c.GossipAdvertiseAddr, c.GossipSeeds, c.GossipKeys), nil
+
+	default:
+		return nil, fmt.Errorf("unknown peer discovery kind `%s`", c.Kind)
+	}
+}
+
+// NewStaticDiscoverer returns a Discoverer which always reports the given addresses.
+func NewStaticDiscoverer(addrs []string) Discoverer {
+	return staticDiscoverer(sortedAddrs(addrs))
+}
+
+type staticDiscoverer []string
+
+func (sd staticDiscoverer) Run(ctx context.Context, update func(addrs []string)) error {
+	update(slices.Clone(sd))
+	<-ctx.Done()
+	return nil
+}
+
+// sortedAddrs returns the addresses sorted and without duplicates.
+func sortedAddrs(addrs []string) []string {
+	sorted := slices.Clone(addrs)
+	sort.Strings(sorted)
+	return slices.Compact(sorted)
+}
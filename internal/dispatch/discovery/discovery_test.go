@@ -0,0 +1,366 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/serviceconfig"
+)
+
+func TestConfigComplete(t *testing.T) {
+	tcs := []struct {
+		name          string
+		config        Config
+		expectedErr   string
+		expectedNil   bool
+		expectedKindT any
+	}{
+		{"disabled", Config{}, "", true, nil},
+		{"static", Config{Kind: KindStatic, StaticAddrs: []string{"a:50053"}}, "", false, staticDiscoverer{}},
+		{"static without addrs", Config{Kind: KindStatic}, "requires at least one address", false, nil},
+		{"dns srv", Config{Kind: KindDNSSRV, SRVName: "_dispatch._tcp.example.com"}, "", false, &srvDiscoverer{}},
+		{"dns srv without name", Config{Kind: KindDNSSRV}, "requires an SRV name", false, nil},
+		{"gossip", Config{Kind: KindGossip, GossipBindAddr: ":7946", GossipAdvertiseAddr: "a:50053", GossipKeys: []string{"key"}}, "", false, &gossipDiscoverer{}},
+		{"gossip without advertise addr", Config{Kind: KindGossip, GossipBindAddr: ":7946", GossipKeys: []string{"key"}}, "requires a bind address and an advertise address", false, nil},
+		{"gossip without keys", Config{Kind: KindGossip, GossipBindAddr: ":7946", GossipAdvertiseAddr: "a:50053"}, "requires non-empty keys", false, nil},
+		{"gossip with empty key", Config{Kind: KindGossip, GossipBindAddr: ":7946", GossipAdvertiseAddr: "a:50053", GossipKeys: []string{""}}, "requires non-empty keys", false, nil},
+		{"unknown", Config{Kind: "consul"}, "unknown peer discovery kind `consul`", false, nil},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			discoverer, err := tc.config.Complete()
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			if tc.expectedNil {
+				require.Nil(t, discoverer)
+				return
+			}
+			require.IsType(t, tc.expectedKindT, discoverer)
+		})
+	}
+}
+
+func TestStaticDiscoverer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := make(chan []string, 1)
+
+	done := make(chan error)
+	go func() {
+		done <- NewStaticDiscoverer([]string{"b:50053", "a:50053", "b:50053"}).Run(ctx, func(addrs []string) {
+			updates <- addrs
+		})
+	}()
+
+	require.Equal(t, []string{"a:50053", "b:50053"}, <-updates)
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestSRVDiscoverer(t *testing.T) {
+	var lock sync.Mutex
+	records := []*net.SRV{{Target: "b.example.com.", Port: 50053}, {Target: "a.example.com.", Port: 50053}}
+	var lookupErr error
+
+	discoverer := NewSRVDiscoverer("_dispatch._tcp.example.com", 10*time.Millisecond).(*srvDiscoverer)
+	discoverer.lookupSRV = func(_ context.Context, _, _, name string) (string, []*net.SRV, error) {
+		require.Equal(t, "_dispatch._tcp.example.com", name)
+
+		lock.Lock()
+		defer lock.Unlock()
+		return "", records, lookupErr
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates := make(chan []string, 10)
+	go func() {
+		_ = discoverer.Run(ctx, func(addrs []string) {
+			updates <- addrs
+		})
+	}()
+
+	require.Equal(t, []string{"a.example.com:50053", "b.example.com:50053"}, <-updates)
+
+	// Failed lookups keep the peers last resolved.
+	lock.Lock()
+	lookupErr = errors.New("no such host")
+	lock.Unlock()
+	time.Sleep(50 * time.Millisecond)
+	require.Empty(t, updates)
+
+	lock.Lock()
+	lookupErr = nil
+	records = records[:1]
+	lock.Unlock()
+	require.Equal(t, []string{"b.example.com:50053"}, <-updates)
+}
+
+func TestGossipDiscoverer(t *testing.T) {
+	gossipAddrs := freeUDPAddrs(t, 3)
+
+	type node struct {
+		cancel  context.CancelFunc
+		done    chan struct{}
+		lock    sync.Mutex
+		members []string
+	}
+
+	startNode := func(index int) *node {
+		discoverer := NewGossipDiscoverer(gossipAddrs[index], dispatchAddr(index), gossipAddrs[:1], []string{"key"}).(*gossipDiscoverer)
+		discoverer.interval = 10 * time.Millisecond
+		discoverer.failureTimeout = 200 * time.Millisecond
+
+		ctx, cancel := context.WithCancel(context.Background())
+		n := &node{cancel: cancel, done: make(chan struct{})}
+		go func() {
+			defer close(n.done)
+			require.NoError(t, discoverer.Run(ctx, func(addrs []string) {
+				n.lock.Lock()
+				defer n.lock.Unlock()
+				n.members = addrs
+			}))
+		}()
+		return n
+	}
+
+	membersOf := func(n *node) []string {
+		n.lock.Lock()
+		defer n.lock.Unlock()
+		return n.members
+	}
+
+	nodes := []*node{startNode(0), startNode(1), startNode(2)}
+	defer func() {
+		for _, n := range nodes[:2] {
+			n.cancel()
+			<-n.done
+		}
+	}()
+
+	// All nodes discover each other via the seed.
+	all := []string{dispatchAddr(0), dispatchAddr(1), dispatchAddr(2)}
+	for _, n := range nodes {
+		n := n
+		require.Eventually(t, func() bool {
+			return slices.Equal(all, membersOf(n))
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	// A stopped node is eventually considered failed by the others.
+	nodes[2].cancel()
+	<-nodes[2].done
+
+	for _, n := range nodes[:2] {
+		n := n
+		require.Eventually(t, func() bool {
+			return slices.Equal(all[:2], membersOf(n))
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+}
+
+func TestGossipStateMerge(t *testing.T) {
+	now := time.Now()
+	state := &gossipState{
+		self:       gossipMember{Addr: "self:50053", Incarnation: 1},
+		members:    map[string]*memberState{},
+		tombstones: map[string]*memberState{},
+	}
+
+	state.merge(gossipMessage{Members: []gossipMember{
+		{Addr: "a:50053", Incarnation: 1, Heartbeat: 5},
+		{Addr: "b:50053", GossipAddr: "b:7946", Incarnation: 1, Heartbeat: 3},
+		{Addr: "self:50053", Incarnation: 1, Heartbeat: 100},
+	}}, "a:7946", now)
+
+	require.Len(t, state.members, 2)
+	require.Equal(t, "a:7946", state.members["a:50053"].GossipAddr)
+	require.Equal(t, "b:7946", state.members["b:50053"].GossipAddr)
+
+	// Older gossip does not replace newer state, while a restarted member supersedes its
+	// previous run.
+	later := now.Add(time.Second)
+	state.merge(gossipMessage{Members: []gossipMember{
+		{Addr: "a:50053", Incarnation: 1, Heartbeat: 4},
+		{Addr: "b:50053", Incarnation: 2, Heartbeat: 1},
+	}}, "c:7946", later)
+
+	require.Equal(t, uint64(5), state.members["a:50053"].Heartbeat)
+	require.Equal(t, now, state.members["a:50053"].updatedAt)
+	require.Equal(t, int64(2), state.members["b:50053"].Incarnation)
+	require.Equal(t, "b:7946", state.members["b:50053"].GossipAddr)
+	require.Equal(t, later, state.members["b:50053"].updatedAt)
+
+	// Members whose heartbeat does not advance fail, and are then forgotten.
+	require.Equal(t, []string{"b:50053", "self:50053"}, state.tick(now.Add(10500*time.Millisecond), 10*time.Second, time.Minute))
+	require.True(t, state.members["a:50053"].failed)
+
+	message, targets := state.message(3)
+	require.Len(t, message.Members, 2)
+	require.Equal(t, []string{"b:7946"}, targets)
+
+	require.Equal(t, []string{"self:50053"}, state.tick(now.Add(25*time.Second), 10*time.Second, time.Minute))
+	require.Empty(t, state.members)
+	require.Len(t, state.tombstones, 2)
+
+	// Stale gossip about forgotten members does not revive them, while newer gossip does.
+	state.merge(gossipMessage{Members: []gossipMember{
+		{Addr: "a:50053", Incarnation: 1, Heartbeat: 5},
+		{Addr: "b:50053", Incarnation: 2, Heartbeat: 2},
+	}}, "c:7946", now.Add(26*time.Second))
+	require.Len(t, state.members, 1)
+	require.Equal(t, uint64(2), state.members["b:50053"].Heartbeat)
+	require.Len(t, state.tombstones, 1)
+
+	// Tombstones are forgotten after the tombstone timeout.
+	state.tick(now.Add(2*time.Minute), 10*time.Second, time.Minute)
+	require.Empty(t, state.tombstones)
+}
+
+func TestGossipMessageSignature(t *testing.T) {
+	message := gossipMessage{Members: []gossipMember{{Addr: "a:50053", Incarnation: 1, Heartbeat: 5}}}
+	encoded, err := encodeGossipMessage(message, "current")
+	require.NoError(t, err)
+
+	decoded, err := decodeGossipMessage(encoded, []string{"other", "current"})
+	require.NoError(t, err)
+	require.Equal(t, message, decoded)
+
+	_, err = decodeGossipMessage(encoded, []string{"other"})
+	require.ErrorContains(t, err, "signature is invalid")
+
+	tampered := slices.Clone(encoded)
+	tampered[len(tampered)-2] ^= 1
+	_, err = decodeGossipMessage(tampered, []string{"current"})
+	require.ErrorContains(t, err, "signature is invalid")
+
+	_, err = decodeGossipMessage(encoded[:10], []string{"current"})
+	require.ErrorContains(t, err, "truncated")
+}
+
+func TestResolver(t *testing.T) {
+	discoverer := &channelDiscoverer{updates: make(chan []string)}
+	r := NewResolver(discoverer)
+	require.Equal(t, "spicedb-discovery", r.Scheme())
+	require.Equal(t, "spicedb-discovery:///dispatch", r.Target())
+	require.Empty(t, r.Members())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- r.Start(ctx)
+	}()
+
+	cc := &fakeClientConn{states: make(chan resolver.State, 1)}
+	built, err := r.Build(resolver.Target{}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+
+	discoverer.updates <- []string{"a:50053", "b:50053"}
+	state := <-cc.states
+	require.Equal(t, []resolver.Address{{Addr: "a:50053"}, {Addr: "b:50053"}}, state.Addresses)
+	require.Equal(t, []string{"a:50053", "b:50053"}, r.Members())
+
+	// A resolver built after peers are discovered is updated with them right away.
+	late := &fakeClientConn{states: make(chan resolver.State, 1)}
+	lateBuilt, err := r.Build(resolver.Target{}, late, resolver.BuildOptions{})
+	require.NoError(t, err)
+	state = <-late.states
+	require.Equal(t, []resolver.Address{{Addr: "a:50053"}, {Addr: "b:50053"}}, state.Addresses)
+
+	// Closing a resolver only unsubscribes it: discovery goes on for the other resolvers.
+	built.Close()
+	discoverer.updates <- []string{"a:50053"}
+	state = <-late.states
+	require.Equal(t, []resolver.Address{{Addr: "a:50053"}}, state.Addresses)
+	require.Empty(t, cc.states)
+
+	lateBuilt.Close()
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestResolverDiscoveryFailure(t *testing.T) {
+	r := NewResolver(failingDiscoverer{errors.New("bind failed")})
+
+	cc := &fakeClientConn{states: make(chan resolver.State, 1)}
+	built, err := r.Build(resolver.Target{}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer built.Close()
+
+	require.ErrorContains(t, r.Start(context.Background()), "bind failed")
+	require.ErrorContains(t, cc.reportedErr, "bind failed")
+}
+
+type failingDiscoverer struct {
+	err error
+}
+
+func (fd failingDiscoverer) Run(context.Context, func(addrs []string)) error {
+	return fd.err
+}
+
+type channelDiscoverer struct {
+	updates chan []string
+}
+
+func (cd *channelDiscoverer) Run(ctx context.Context, update func(addrs []string)) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case addrs := <-cd.updates:
+			update(addrs)
+		}
+	}
+}
+
+type fakeClientConn struct {
+	resolver.ClientConn
+	states      chan resolver.State
+	reportedErr error
+}
+
+func (fcc *fakeClientConn) UpdateState(state resolver.State) error {
+	fcc.states <- state
+	return nil
+}
+
+func (fcc *fakeClientConn) ReportError(err error) {
+	fcc.reportedErr = err
+}
+
+func (fcc *fakeClientConn) ParseServiceConfig(string) *serviceconfig.ParseResult {
+	return nil
+}
+
+func freeUDPAddrs(t *testing.T, count int) []string {
+	addrs := make([]string, 0, count)
+	conns := make([]net.PacketConn, 0, count)
+	for i := 0; i < count; i++ {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		conns = append(conns, conn)
+		addrs = append(addrs, conn.LocalAddr().String())
+	}
+	for _, conn := range conns {
+		require.NoError(t, conn.Close())
+	}
+	return addrs
+}
+
+func dispatchAddr(index int) string {
+	return []string{"node-0:50053", "node-1:50053", "node-2:50053"}[index]
+}
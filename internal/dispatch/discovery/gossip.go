@@ -0,0 +1,343 @@
+package discovery
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	log "github.com/authzed/spicedb/internal/logging"
+)
+
+const (
+	defaultGossipInterval = 1 * time.Second
+	defaultGossipFanout   = 3
+
+	// defaultFailureTimeout is the duration after which a peer whose heartbeat has not advanced
+	// is considered to have failed.
+	defaultFailureTimeout = 10 * time.Second
+
+	// defaultTombstoneTimeout is the duration for which a forgotten member is remembered, so that
+	// stale gossip about it does not revive it.
+	defaultTombstoneTimeout = 1 * time.Hour
+
+	// maxGossipMessageSize is the maximum size of a gossip message, which must fit in a single
+	// UDP datagram.
+	maxGossipMessageSize = 64 * 1024
+)
+
+// NewGossipDiscoverer returns a Discoverer which gossips membership over UDP with the other
+// peers: every peer periodically increments its own heartbeat and sends the members it knows to
+// a few other peers, which merge them into their own. A peer is considered to have failed once
+// its heartbeat has not advanced for the failure timeout.
+//
+// The discoverer listens for gossip on the bind address and advertises the given dispatch
+// address for this peer, contacting the seeds to join the cluster. Messages are signed with the
+// first of the keys, and messages not signed with any of them are dropped.
+func NewGossipDiscoverer(bindAddr string, advertiseAddr string, seeds []string, keys []string) Discoverer {
+	return &gossipDiscoverer{
+		bindAddr:         bindAddr,
+		advertiseAddr:    advertiseAddr,
+		seeds:            seeds,
+		keys:             keys,
+		interval:         defaultGossipInterval,
+		fanout:           defaultGossipFanout,
+		failureTimeout:   defaultFailureTimeout,
+		tombstoneTimeout: defaultTombstoneTimeout,
+	}
+}
+
+type gossipDiscoverer struct {
+	bindAddr         string
+	advertiseAddr    string
+	seeds            []string
+	keys             []string
+	interval         time.Duration
+	fanout           int
+	failureTimeout   time.Duration
+	tombstoneTimeout time.Duration
+}
+
+// gossipMember is the state of a member, as gossiped between peers.
+type gossipMember struct {
+	// Addr is the dispatch address of the member.
+	Addr string `json:"addr"`
+
+	// GossipAddr is the gossip address of the member. It is empty for the sender of a message, in
+	// which case the address from which the message was sent is used.
+	GossipAddr string `json:"gossip_addr,omitempty"`
+
+	// Incarnation identifies the run of the member, so that a restarted member supersedes its
+	// previous run even though its heartbeat starts over.
+	Incarnation int64 `json:"incarnation"`
+
+	// Heartbeat is incremented by the member on every gossip round.
+	Heartbeat uint64 `json:"heartbeat"`
+}
+
+func (gm gossipMember) newerThan(other gossipMember) bool {
+	if gm.Incarnation != other.Incarnation {
+		return gm.Incarnation > other.Incarnation
+	}
+	return gm.Heartbeat > other.Heartbeat
+}
+
+type gossipMessage struct {
+	Members []gossipMember `json:"members"`
+}
+
+// memberState is the local state of a member, tracking when its heartbeat last advanced.
+type memberState struct {
+	gossipMember
+	updatedAt time.Time
+	failed    bool
+}
+
+// gossipState is the membership known to a peer.
+type gossipState struct {
+	lock    sync.Mutex
+	self    gossipMember
+	members map[string]*memberState
+
+	// tombstones are the members forgotten after failing, which are only known again once
+	// gossiped with a newer state than the one last known.
+	tombstones map[string]*memberState
+}
+
+func (gd *gossipDiscoverer) Run(ctx context.Context, update func(addrs []string)) error {
+	conn, err := net.ListenPacket("udp", gd.bindAddr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	state := &gossipState{
+		self: gossipMember{
+			Addr:        gd.advertiseAddr,
+			Incarnation: time.Now().UnixNano(),
+		},
+		members:    map[string]*memberState{},
+		tombstones: map[string]*memberState{},
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		gd.receive(ctx, conn, state)
+	}()
+	defer wg.Wait()
+
+	ticker := time.NewTicker(gd.interval)
+	defer ticker.Stop()
+
+	var current []string
+	for {
+		addrs := state.tick(time.Now(), gd.failureTimeout, gd.tombstoneTimeout)
+		if current == nil || !slices.Equal(addrs, current) {
+			current = addrs
+			update(slices.Clone(addrs))
+		}
+
+		gd.gossip(ctx, conn, state)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// gossip sends the alive members to a few randomly chosen members, or to the seeds if no other
+// members are known.
+func (gd *gossipDiscoverer) gossip(ctx context.Context, conn net.PacketConn, state *gossipState) {
+	message, targets := state.message(gd.fanout)
+	if len(targets) == 0 {
+		targets = gd.seeds
+	}
+
+	encoded, err := encodeGossipMessage(message, gd.keys[0])
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to encode gossip message")
+		return
+	}
+
+	for _, target := range targets {
+		addr, err := net.ResolveUDPAddr("udp", target)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Str("target", target).Msg("failed to resolve gossip target")
+			continue
+		}
+
+		if _, err := conn.WriteTo(encoded, addr); err != nil && ctx.Err() == nil {
+			log.Ctx(ctx).Debug().Err(err).Str("target", target).Msg("failed to send gossip message")
+		}
+	}
+}
+
+func (gd *gossipDiscoverer) receive(ctx context.Context, conn net.PacketConn, state *gossipState) {
+	buf := make([]byte, maxGossipMessageSize)
+	for {
+		n, from, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() == nil && !errors.Is(err, net.ErrClosed) {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to receive gossip message")
+			}
+			return
+		}
+
+		message, err := decodeGossipMessage(buf[:n], gd.keys)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Str("from", from.String()).Msg("received invalid gossip message")
+			continue
+		}
+
+		state.merge(message, from.String(), time.Now())
+	}
+}
+
+// encodeGossipMessage encodes the message, prefixed with its HMAC-SHA256 signature by the key.
+func encodeGossipMessage(message gossipMessage, key string) ([]byte, error) {
+	encoded, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(encoded)
+	return append(mac.Sum(nil), encoded...), nil
+}
+
+// decodeGossipMessage decodes a message encoded by encodeGossipMessage, returning an error if it
+// is not signed by any of the keys.
+func decodeGossipMessage(encoded []byte, keys []string) (gossipMessage, error) {
+	if len(encoded) < sha256.Size {
+		return gossipMessage{}, errors.New("gossip message is truncated")
+	}
+	signature, payload := encoded[:sha256.Size], encoded[sha256.Size:]
+
+	verified := false
+	for _, key := range keys {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write(payload)
+		if hmac.Equal(signature, mac.Sum(nil)) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return gossipMessage{}, errors.New("gossip message signature is invalid")
+	}
+
+	var message gossipMessage
+	err := json.Unmarshal(payload, &message)
+	return message, err
+}
+
+// tick advances the heartbeat of this peer and marks members whose heartbeat has not advanced
+// within the failure timeout as failed, forgetting them after twice the timeout. Forgotten
+// members are remembered as tombstones until the tombstone timeout. Returns the dispatch
+// addresses of this peer and the alive members.
+func (gs *gossipState) tick(now time.Time, failureTimeout time.Duration, tombstoneTimeout time.Duration) []string {
+	gs.lock.Lock()
+	defer gs.lock.Unlock()
+
+	gs.self.Heartbeat++
+
+	addrs := []string{gs.self.Addr}
+	for addr, member := range gs.members {
+		switch sinceUpdate := now.Sub(member.updatedAt); {
+		case sinceUpdate > 2*failureTimeout:
+			// Stale gossip about forgotten members, from peers which have not yet noticed the
+			// failure, must not revive them.
+			delete(gs.members, addr)
+			gs.tombstones[addr] = member
+		case sinceUpdate > failureTimeout:
+			member.failed = true
+		default:
+			addrs = append(addrs, addr)
+		}
+	}
+
+	for addr, tombstone := range gs.tombstones {
+		if now.Sub(tombstone.updatedAt) > tombstoneTimeout {
+			delete(gs.tombstones, addr)
+		}
+	}
+	return sortedAddrs(addrs)
+}
+
+// message returns the gossip message describing this peer and the alive members, along with the
+// gossip addresses of up to fanout randomly chosen alive members to send it to.
+func (gs *gossipState) message(fanout int) (gossipMessage, []string) {
+	gs.lock.Lock()
+	defer gs.lock.Unlock()
+
+	message := gossipMessage{Members: []gossipMember{gs.self}}
+	targets := make([]string, 0, len(gs.members))
+	for _, member := range gs.members {
+		if member.failed {
+			continue
+		}
+		message.Members = append(message.Members, member.gossipMember)
+		if member.GossipAddr != "" {
+			targets = append(targets, member.GossipAddr)
+		}
+	}
+
+	rand.Shuffle(len(targets), func(i, j int) {
+		targets[i], targets[j] = targets[j], targets[i]
+	})
+	if len(targets) > fanout {
+		targets = targets[:fanout]
+	}
+	return message, targets
+}
+
+// merge merges the members gossiped by a peer into the known members.
+func (gs *gossipState) merge(message gossipMessage, from string, now time.Time) {
+	gs.lock.Lock()
+	defer gs.lock.Unlock()
+
+	for i, gossiped := range message.Members {
+		if gossiped.Addr == "" || gossiped.Addr == gs.self.Addr {
+			continue
+		}
+
+		// The first member is the sender itself, reachable at the address it sent from.
+		if i == 0 && gossiped.GossipAddr == "" {
+			gossiped.GossipAddr = from
+		}
+
+		existing, ok := gs.members[gossiped.Addr]
+		switch {
+		case !ok:
+			if tombstone, ok := gs.tombstones[gossiped.Addr]; ok {
+				if !gossiped.newerThan(tombstone.gossipMember) {
+					continue
+				}
+				delete(gs.tombstones, gossiped.Addr)
+			}
+			gs.members[gossiped.Addr] = &memberState{gossipMember: gossiped, updatedAt: now}
+
+		case gossiped.newerThan(existing.gossipMember):
+			if gossiped.GossipAddr == "" {
+				gossiped.GossipAddr = existing.GossipAddr
+			}
+			existing.gossipMember = gossiped
+			existing.updatedAt = now
+			existing.failed = false
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"google.golang.org/grpc/resolver"
+
+	log "github.com/authzed/spicedb/internal/logging"
+)
+
+const resolverScheme = "spicedb-discovery"
+
+// Resolver is a gRPC resolver builder which resolves the dispatch cluster to the peers found by
+// a Discoverer, so that they are fed into the hashring balancer as they change.
+//
+// Peers are only discovered while Start is running, which is expected to last for the lifetime
+// of the server; the resolvers built only subscribe to the peers discovered.
+type Resolver struct {
+	discoverer Discoverer
+
+	lock        sync.RWMutex
+	members     []string
+	discovered  bool
+	subscribers map[*discoveryResolver]struct{}
+}
+
+// NewResolver returns a Resolver for the peers found by the given Discoverer.
+func NewResolver(discoverer Discoverer) *Resolver {
+	return &Resolver{
+		discoverer:  discoverer,
+		subscribers: map[*discoveryResolver]struct{}{},
+	}
+}
+
+// Target returns the target to dial to connect to the discovered peers, when registered with
+// grpc.WithResolvers.
+func (r *Resolver) Target() string {
+	return resolverScheme + ":///dispatch"
+}
+
+// Members returns the dispatch addresses of the peers last discovered.
+func (r *Resolver) Members() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return slices.Clone(r.members)
+}
+
+// Start discovers peers until the context is canceled, updating the subscribed resolvers
+// whenever they change. Returns an error if the discovery fails.
+func (r *Resolver) Start(ctx context.Context) error {
+	err := r.discoverer.Run(ctx, r.update)
+	if err != nil && ctx.Err() == nil {
+		r.lock.RLock()
+		defer r.lock.RUnlock()
+		for subscriber := range r.subscribers {
+			subscriber.cc.ReportError(err)
+		}
+		return fmt.Errorf("dispatch peer discovery failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Resolver) update(addrs []string) {
+	log.Debug().Strs("peers", addrs).Msg("discovered dispatch peers")
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.members = addrs
+	r.discovered = true
+	for subscriber := range r.subscribers {
+		subscriber.updateState(addrs)
+	}
+}
+
+func (r *Resolver) Scheme() string {
+	return resolverScheme
+}
+
+func (r *Resolver) Build(_ resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	subscriber := &discoveryResolver{parent: r, cc: cc}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.subscribers[subscriber] = struct{}{}
+	if r.discovered {
+		subscriber.updateState(r.members)
+	}
+
+	return subscriber, nil
+}
+
+// discoveryResolver is a resolver subscribed to the peers discovered by a Resolver.
+type discoveryResolver struct {
+	parent *Resolver
+	cc     resolver.ClientConn
+}
+
+func (dr *discoveryResolver) updateState(addrs []string) {
+	resolved := make([]resolver.Address, 0, len(addrs))
+	for _, addr := range addrs {
+		resolved = append(resolved, resolver.Address{Addr: addr})
+	}
+
+	if err := dr.cc.UpdateState(resolver.State{Addresses: resolved}); err != nil {
+		log.Debug().Err(err).Msg("dispatch connection rejected the discovered peers")
+	}
+}
+
+// ResolveNow is a no-op, as the discoverer reports peers whenever they change.
+func (dr *discoveryResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+func (dr *discoveryResolver) Close() {
+	dr.parent.lock.Lock()
+	defer dr.parent.lock.Unlock()
+	delete(dr.parent.subscribers, dr)
+}
+
+var _ resolver.Builder = &Resolver{}
@@ -0,0 +1,75 @@
+package discovery
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/authzed/spicedb/internal/logging"
+)
+
+const defaultSRVRefreshInterval = 30 * time.Second
+
+// lookupSRVFunc resolves the SRV records of the given name, as net.Resolver.LookupSRV.
+type lookupSRVFunc func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+
+// NewSRVDiscoverer returns a Discoverer which resolves the SRV records of the given name at the
+// given interval, reporting the target and port of each record as the address of a peer.
+func NewSRVDiscoverer(name string, refreshInterval time.Duration) Discoverer {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultSRVRefreshInterval
+	}
+
+	return &srvDiscoverer{
+		name:            name,
+		refreshInterval: refreshInterval,
+		lookupSRV:       net.DefaultResolver.LookupSRV,
+	}
+}
+
+type srvDiscoverer struct {
+	name            string
+	refreshInterval time.Duration
+	lookupSRV       lookupSRVFunc
+}
+
+func (sd *srvDiscoverer) Run(ctx context.Context, update func(addrs []string)) error {
+	ticker := time.NewTicker(sd.refreshInterval)
+	defer ticker.Stop()
+
+	var current []string
+	for {
+		addrs, err := sd.resolve(ctx)
+		if err != nil {
+			// Keep the peers last resolved, as a failed lookup does not mean they are gone.
+			log.Ctx(ctx).Warn().Err(err).Str("name", sd.name).Msg("failed to resolve dispatch peers from SRV records")
+		} else if current == nil || !slices.Equal(addrs, current) {
+			current = addrs
+			update(slices.Clone(addrs))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (sd *srvDiscoverer) resolve(ctx context.Context) ([]string, error) {
+	// The name is resolved as given, e.g. `_dispatch._tcp.spicedb.example.com`.
+	_, records, err := sd.lookupSRV(ctx, "", "", sd.name)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]string, 0, len(records))
+	for _, record := range records {
+		target := strings.TrimSuffix(record.Target, ".")
+		addrs = append(addrs, net.JoinHostPort(target, strconv.Itoa(int(record.Port))))
+	}
+	return sortedAddrs(addrs), nil
+}
@@ -0,0 +1,165 @@
+package dispatch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/datastore/options"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+)
+
+// expirationTrailerKey is the key of the trailer in which the dispatch API returns the earliest
+// expiration of the relationships read to compute a response, which is empty if none of them
+// expires.
+const expirationTrailerKey = "io.spicedb.dispatch.earliestexpiration"
+
+// ExpirationTracker records the earliest expiration of the relationships read to compute a
+// dispatch result. As expired relationships are no longer read, without any change being made to
+// the datastore, the result may no longer be valid from that time on.
+type ExpirationTracker struct {
+	parent *ExpirationTracker
+
+	lock     sync.Mutex
+	earliest *time.Time
+}
+
+type expirationTrackerKey struct{}
+
+// ContextWithExpirationTracker returns a context carrying a new tracker, which also reports the
+// expirations it observes to the tracker carried by the given context, if any. The expirations of
+// the relationships read from the datastore of the context are recorded by the tracker.
+func ContextWithExpirationTracker(ctx context.Context) (context.Context, *ExpirationTracker) {
+	if ds := datastoremw.FromContext(ctx); ds != nil {
+		if _, ok := ds.(*expirationObservingDatastore); !ok {
+			ctx = datastoremw.ContextWithDatastore(ctx, &expirationObservingDatastore{ds})
+		}
+	}
+
+	parent, _ := ctx.Value(expirationTrackerKey{}).(*ExpirationTracker)
+	tracker := &ExpirationTracker{parent: parent}
+	return context.WithValue(ctx, expirationTrackerKey{}, tracker), tracker
+}
+
+// ObserveExpiration records the expiration of a relationship read, or of a result reused, to
+// compute a result, in the tracker carried by the context, if any.
+func ObserveExpiration(ctx context.Context, expiration *time.Time) {
+	if tracker, ok := ctx.Value(expirationTrackerKey{}).(*ExpirationTracker); ok {
+		tracker.Observe(expiration)
+	}
+}
+
+// Observe records the given expiration, if any.
+func (et *ExpirationTracker) Observe(expiration *time.Time) {
+	if expiration == nil {
+		return
+	}
+
+	for current := et; current != nil; current = current.parent {
+		current.lock.Lock()
+		if current.earliest == nil || expiration.Before(*current.earliest) {
+			current.earliest = expiration
+		}
+		current.lock.Unlock()
+	}
+}
+
+// Earliest returns the earliest expiration observed, if any.
+func (et *ExpirationTracker) Earliest() *time.Time {
+	et.lock.Lock()
+	defer et.lock.Unlock()
+	return et.earliest
+}
+
+// ExpirationTrailer returns the trailer with which the dispatch API returns the earliest
+// expiration observed by the tracker.
+func ExpirationTrailer(tracker *ExpirationTracker) metadata.MD {
+	var value string
+	if earliest := tracker.Earliest(); earliest != nil {
+		value = earliest.UTC().Format(time.RFC3339Nano)
+	}
+	return metadata.Pairs(expirationTrailerKey, value)
+}
+
+// ObserveExpirationTrailer records the earliest expiration returned in the trailer of a response
+// of the dispatch API in the tracker carried by the context, if any. Peers which do not return the
+// trailer cannot report the expirations of the relationships they read, so their responses are
+// considered to expire immediately.
+func ObserveExpirationTrailer(ctx context.Context, trailer metadata.MD) {
+	values := trailer.Get(expirationTrailerKey)
+	if len(values) == 0 {
+		now := time.Now()
+		ObserveExpiration(ctx, &now)
+		return
+	}
+
+	if values[0] == "" {
+		return
+	}
+
+	earliest, err := time.Parse(time.RFC3339Nano, values[0])
+	if err != nil {
+		earliest = time.Now()
+	}
+	ObserveExpiration(ctx, &earliest)
+}
+
+// expirationObservingDatastore reports the expirations of the relationships read through it to
+// the tracker carried by the context of the query, if any.
+type expirationObservingDatastore struct {
+	datastore.Datastore
+}
+
+func (eod *expirationObservingDatastore) SnapshotReader(revision datastore.Revision) datastore.Reader {
+	return &expirationObservingReader{eod.Datastore.SnapshotReader(revision)}
+}
+
+func (eod *expirationObservingDatastore) Unwrap() datastore.Datastore {
+	return eod.Datastore
+}
+
+type expirationObservingReader struct {
+	datastore.Reader
+}
+
+func (eor *expirationObservingReader) QueryRelationships(
+	ctx context.Context,
+	filter datastore.RelationshipsFilter,
+	opts ...options.QueryOptionsOption,
+) (datastore.RelationshipIterator, error) {
+	it, err := eor.Reader.QueryRelationships(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &expirationObservingIterator{ctx, it}, nil
+}
+
+func (eor *expirationObservingReader) ReverseQueryRelationships(
+	ctx context.Context,
+	subjectsFilter datastore.SubjectsFilter,
+	opts ...options.ReverseQueryOptionsOption,
+) (datastore.RelationshipIterator, error) {
+	it, err := eor.Reader.ReverseQueryRelationships(ctx, subjectsFilter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &expirationObservingIterator{ctx, it}, nil
+}
+
+type expirationObservingIterator struct {
+	ctx context.Context
+	datastore.RelationshipIterator
+}
+
+func (eoi *expirationObservingIterator) Next() *core.RelationTuple {
+	tpl := eoi.RelationshipIterator.Next()
+	if tpl != nil && tpl.OptionalExpirationTime != nil {
+		expiration := tpl.OptionalExpirationTime.AsTime()
+		ObserveExpiration(eoi.ctx, &expiration)
+	}
+	return tpl
+}
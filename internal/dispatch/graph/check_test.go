@@ -410,6 +410,274 @@ func TestCheckDebugging(t *testing.T) {
 	}
 }
 
+func TestCheckAllArrow(t *testing.T) {
+	schema := `definition user {}
+
+		caveat somecaveat(somecondition int) {
+			somecondition == 42
+		}
+
+		definition team {
+			relation member: user | user with somecaveat
+		}
+
+		definition document {
+			relation team: team | team with somecaveat
+			permission view = team.all(member)
+		}`
+
+	relationships := []*core.RelationTuple{
+		tuple.MustParse("team:first#member@user:tom"),
+		tuple.MustParse("team:first#member@user:fred"),
+		tuple.MustWithCaveat(tuple.MustParse("team:first#member@user:sarah"), "somecaveat"),
+		tuple.MustParse("team:second#member@user:tom"),
+		tuple.MustParse("team:second#member@user:sarah"),
+		tuple.MustParse("team:third#member@user:fred"),
+		tuple.MustParse("document:singleteam#team@team:first"),
+		tuple.MustParse("document:multiteam#team@team:first"),
+		tuple.MustParse("document:multiteam#team@team:second"),
+		tuple.MustParse("document:disjointteams#team@team:second"),
+		tuple.MustParse("document:disjointteams#team@team:third"),
+		tuple.MustParse("document:caveatedteam#team@team:second"),
+		tuple.MustWithCaveat(tuple.MustParse("document:caveatedteam#team@team:third"), "somecaveat"),
+	}
+
+	testCases := []struct {
+		subject          *core.ObjectAndRelation
+		expectedMember   []string
+		expectedCaveated []string
+	}{
+		{
+			ONR("user", "tom", graph.Ellipsis),
+			[]string{"singleteam", "multiteam"},
+			nil,
+		},
+		{
+			ONR("user", "fred", graph.Ellipsis),
+			[]string{"singleteam"},
+			nil,
+		},
+		{
+			ONR("user", "sarah", graph.Ellipsis),
+			nil,
+			[]string{"singleteam", "multiteam"},
+		},
+		{
+			ONR("user", "unknown", graph.Ellipsis),
+			nil,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tuple.StringONR(tc.subject), func(t *testing.T) {
+			require := require.New(t)
+
+			ctx, dispatch, revision := newLocalDispatcherWithSchemaAndRels(t, schema, relationships)
+
+			checkResult, err := dispatch.DispatchCheck(ctx, &v1.DispatchCheckRequest{
+				ResourceRelation: RR("document", "view"),
+				ResourceIds:      []string{"singleteam", "multiteam", "disjointteams", "caveatedteam", "noteams"},
+				ResultsSetting:   v1.DispatchCheckRequest_REQUIRE_ALL_RESULTS,
+				Subject:          tc.subject,
+				Metadata: &v1.ResolverMeta{
+					AtRevision:     revision.String(),
+					DepthRemaining: 50,
+				},
+			})
+			require.NoError(err)
+
+			foundMembers := []string{}
+			foundCaveated := []string{}
+			for resourceID, result := range checkResult.ResultsByResourceId {
+				switch result.Membership {
+				case v1.ResourceCheckResult_MEMBER:
+					foundMembers = append(foundMembers, resourceID)
+				case v1.ResourceCheckResult_CAVEATED_MEMBER:
+					foundCaveated = append(foundCaveated, resourceID)
+				}
+			}
+
+			require.ElementsMatch(tc.expectedMember, foundMembers)
+			require.ElementsMatch(tc.expectedCaveated, foundCaveated)
+		})
+	}
+}
+
+// staticMaterializedPermissions answers for a single materialized permission and subject with a
+// fixed set of resources.
+type staticMaterializedPermissions struct {
+	resourceRelation *core.RelationReference
+	subject          *core.ObjectAndRelation
+	resourceIDs      []string
+}
+
+func (smp staticMaterializedPermissions) CheckMembers(_ context.Context, _ datastore.Revision, resourceRelation *core.RelationReference, resourceIDs []string, subject *core.ObjectAndRelation) ([]string, bool) {
+	if !resourceRelation.EqualVT(smp.resourceRelation) || !subject.EqualVT(smp.subject) {
+		return nil, false
+	}
+
+	allowed := mapz.NewSet(smp.resourceIDs...)
+	found := []string{}
+	for _, resourceID := range resourceIDs {
+		if allowed.Has(resourceID) {
+			found = append(found, resourceID)
+		}
+	}
+	return found, true
+}
+
+func (smp staticMaterializedPermissions) LookupResources(_ context.Context, _ datastore.Revision, resourceRelation *core.RelationReference, subject *core.ObjectAndRelation) ([]string, bool) {
+	if !resourceRelation.EqualVT(smp.resourceRelation) || !subject.EqualVT(smp.subject) {
+		return nil, false
+	}
+	return smp.resourceIDs, true
+}
+
+func TestMaterializedPermissions(t *testing.T) {
+	schema := `definition user {}
+
+		definition document {
+			relation viewer: user
+			relation banned: user
+			materialized permission view = viewer - banned
+			permission edit = viewer - banned
+		}`
+
+	relationships := []*core.RelationTuple{
+		tuple.MustParse("document:first#viewer@user:tom"),
+		tuple.MustParse("document:second#viewer@user:fred"),
+	}
+
+	// The materialized resources deliberately differ from the relationships, to ensure they
+	// are used when available.
+	materialized := staticMaterializedPermissions{
+		resourceRelation: RR("document", "view"),
+		subject:          ONR("user", "tom", graph.Ellipsis),
+		resourceIDs:      []string{"second"},
+	}
+
+	testCases := []struct {
+		name              string
+		permission        string
+		subject           *core.ObjectAndRelation
+		expectedResources []string
+	}{
+		{"materialized permission", "view", ONR("user", "tom", graph.Ellipsis), []string{"second"}},
+		{"materialized permission fallback", "view", ONR("user", "fred", graph.Ellipsis), []string{"second"}},
+		{"non-materialized permission", "edit", ONR("user", "tom", graph.Ellipsis), []string{"first"}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx, localDispatcher, revision := newLocalDispatcherWithSchemaAndRels(t, schema, relationships)
+			dispatcher := NewDispatcher(localDispatcher, SharedConcurrencyLimits(10), materialized)
+
+			checkResult, err := dispatcher.DispatchCheck(ctx, &v1.DispatchCheckRequest{
+				ResourceRelation: RR("document", tc.permission),
+				ResourceIds:      []string{"first", "second"},
+				ResultsSetting:   v1.DispatchCheckRequest_REQUIRE_ALL_RESULTS,
+				Subject:          tc.subject,
+				Metadata: &v1.ResolverMeta{
+					AtRevision:     revision.String(),
+					DepthRemaining: 50,
+				},
+			})
+			require.NoError(err)
+
+			foundMembers := []string{}
+			for resourceID, result := range checkResult.ResultsByResourceId {
+				if result.Membership == v1.ResourceCheckResult_MEMBER {
+					foundMembers = append(foundMembers, resourceID)
+				}
+			}
+			require.ElementsMatch(tc.expectedResources, foundMembers)
+
+			stream := dispatch.NewCollectingDispatchStream[*v1.DispatchLookupResourcesResponse](ctx)
+			err = dispatcher.DispatchLookupResources(&v1.DispatchLookupResourcesRequest{
+				ObjectRelation: RR("document", tc.permission),
+				Subject:        tc.subject,
+				Metadata: &v1.ResolverMeta{
+					AtRevision:     revision.String(),
+					DepthRemaining: 50,
+				},
+			}, stream)
+			require.NoError(err)
+
+			foundResources := []string{}
+			for _, result := range stream.Results() {
+				foundResources = append(foundResources, result.ResolvedResource.ResourceId)
+			}
+			require.ElementsMatch(tc.expectedResources, foundResources)
+		})
+	}
+}
+
+func TestMaterializedLookupResourcesCursor(t *testing.T) {
+	require := require.New(t)
+
+	schema := `definition user {}
+
+		definition document {
+			relation viewer: user
+			materialized permission view = viewer
+		}`
+
+	resourceIDs := []string{"a", "b", "c", "d", "e"}
+	relationships := make([]*core.RelationTuple, 0, len(resourceIDs))
+	for _, resourceID := range resourceIDs {
+		relationships = append(relationships, tuple.MustParse("document:"+resourceID+"#viewer@user:tom"))
+	}
+
+	ctx, localDispatcher, revision := newLocalDispatcherWithSchemaAndRels(t, schema, relationships)
+	materializedDispatcher := NewDispatcher(localDispatcher, SharedConcurrencyLimits(10), staticMaterializedPermissions{
+		resourceRelation: RR("document", "view"),
+		subject:          ONR("user", "tom", graph.Ellipsis),
+		resourceIDs:      resourceIDs,
+	})
+	computingDispatcher := NewDispatcher(localDispatcher, SharedConcurrencyLimits(10), nil)
+
+	lookupPage := func(dispatcher dispatch.Dispatcher, cursor *v1.Cursor) ([]string, *v1.Cursor) {
+		stream := dispatch.NewCollectingDispatchStream[*v1.DispatchLookupResourcesResponse](ctx)
+		err := dispatcher.DispatchLookupResources(&v1.DispatchLookupResourcesRequest{
+			ObjectRelation: RR("document", "view"),
+			Subject:        ONR("user", "tom", graph.Ellipsis),
+			Metadata: &v1.ResolverMeta{
+				AtRevision:     revision.String(),
+				DepthRemaining: 50,
+			},
+			OptionalLimit:  2,
+			OptionalCursor: cursor,
+		}, stream)
+		require.NoError(err)
+
+		found := []string{}
+		for _, result := range stream.Results() {
+			found = append(found, result.ResolvedResource.ResourceId)
+			cursor = result.AfterResponseCursor
+		}
+		return found, cursor
+	}
+
+	found, cursor := lookupPage(materializedDispatcher, nil)
+	require.Equal([]string{"a", "b"}, found)
+
+	found, cursor = lookupPage(materializedDispatcher, cursor)
+	require.Equal([]string{"c", "d"}, found)
+
+	// The lookup resumes in the same order when the permission can no longer be answered from
+	// the materialized resources.
+	found, cursor = lookupPage(computingDispatcher, cursor)
+	require.Equal([]string{"e"}, found)
+
+	found, _ = lookupPage(materializedDispatcher, cursor)
+	require.Empty(found)
+}
+
 func newLocalDispatcherWithConcurrencyLimit(t testing.TB, concurrencyLimit uint16) (context.Context, dispatch.Dispatcher, datastore.Revision) {
 	rawDS, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
 	require.NoError(t, err)
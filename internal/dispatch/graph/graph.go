@@ -89,24 +89,28 @@ func NewLocalOnlyDispatcherWithLimits(concurrencyLimits ConcurrencyLimits) dispa
 
 	concurrencyLimits = limitsOrDefaults(concurrencyLimits, defaultConcurrencyLimit)
 
-	d.checker = graph.NewConcurrentChecker(d, concurrencyLimits.Check)
+	d.checker = graph.NewConcurrentChecker(d, concurrencyLimits.Check, nil)
 	d.expander = graph.NewConcurrentExpander(d)
 	d.reachableResourcesHandler = graph.NewCursoredReachableResources(d, concurrencyLimits.ReachableResources)
-	d.lookupResourcesHandler = graph.NewCursoredLookupResources(d, d, concurrencyLimits.LookupResources)
+	d.lookupResourcesHandler = graph.NewCursoredLookupResources(d, d, concurrencyLimits.LookupResources, nil)
 	d.lookupSubjectsHandler = graph.NewConcurrentLookupSubjects(d, concurrencyLimits.LookupSubjects)
 
 	return d
 }
 
+// MaterializedPermissions provides the precomputed resources for materialized permissions.
+type MaterializedPermissions = graph.MaterializedPermissions
+
 // NewDispatcher creates a dispatcher that consults with the graph and redispatches subproblems to
-// the provided redispatcher.
-func NewDispatcher(redispatcher dispatch.Dispatcher, concurrencyLimits ConcurrencyLimits) dispatch.Dispatcher {
+// the provided redispatcher. If materialized is non-nil, it is consulted for materialized
+// permissions before they are computed.
+func NewDispatcher(redispatcher dispatch.Dispatcher, concurrencyLimits ConcurrencyLimits, materialized MaterializedPermissions) dispatch.Dispatcher {
 	concurrencyLimits = limitsOrDefaults(concurrencyLimits, defaultConcurrencyLimit)
 
-	checker := graph.NewConcurrentChecker(redispatcher, concurrencyLimits.Check)
+	checker := graph.NewConcurrentChecker(redispatcher, concurrencyLimits.Check, materialized)
 	expander := graph.NewConcurrentExpander(redispatcher)
 	reachableResourcesHandler := graph.NewCursoredReachableResources(redispatcher, concurrencyLimits.ReachableResources)
-	lookupResourcesHandler := graph.NewCursoredLookupResources(redispatcher, redispatcher, concurrencyLimits.LookupResources)
+	lookupResourcesHandler := graph.NewCursoredLookupResources(redispatcher, redispatcher, concurrencyLimits.LookupResources, materialized)
 	lookupSubjectsHandler := graph.NewConcurrentLookupSubjects(redispatcher, concurrencyLimits.LookupSubjects)
 
 	return &localDispatcher{
@@ -640,6 +640,44 @@ func TestCaveatedLookupSubjects(t *testing.T) {
 				},
 			},
 		},
+		{
+			"all arrow",
+			`definition user {}
+
+			 caveat somecaveat(somecondition int) {
+				somecondition == 42
+			 }
+
+			 definition team {
+				relation member: user | user with somecaveat
+			 }
+
+		 	 definition document {
+				relation team: team
+				permission view = team.all(member)
+  		 }`,
+			[]*corev1.RelationTuple{
+				tuple.MustParse("document:first#team@team:someteam"),
+				tuple.MustParse("document:first#team@team:anotherteam"),
+				tuple.MustParse("team:someteam#member@user:tom"),
+				tuple.MustParse("team:someteam#member@user:fred"),
+				tuple.MustWithCaveat(tuple.MustParse("team:someteam#member@user:sarah"), "somecaveat"),
+				tuple.MustParse("team:anotherteam#member@user:tom"),
+				tuple.MustParse("team:anotherteam#member@user:sarah"),
+				tuple.MustParse("team:anotherteam#member@user:amy"),
+			},
+			ONR("document", "first", "view"),
+			RR("user", "..."),
+			[]*v1.FoundSubject{
+				{
+					SubjectId: "tom",
+				},
+				{
+					SubjectId:        "sarah",
+					CaveatExpression: caveatexpr("somecaveat"),
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -8,6 +8,7 @@ import (
 // dispatched or cached.
 type DispatchCacheKey struct {
 	stableSum          uint64
+	stableCheckSum     uint64
 	processSpecificSum uint64
 }
 
@@ -18,6 +19,13 @@ func (dck DispatchCacheKey) StableSumAsBytes() []byte {
 	return binary.AppendUvarint(make([]byte, 0, 8), dck.stableSum)
 }
 
+// StableCheckSumAsBytes returns a second stable sum of the dispatch cache key as bytes, computed
+// with a hashing algorithm distinct from the one of the stable sum. Keys whose stable sums
+// overlap can be told apart by comparing their stable check sums, including across processes.
+func (dck DispatchCacheKey) StableCheckSumAsBytes() []byte {
+	return binary.AppendUvarint(make([]byte, 0, 8), dck.stableCheckSum)
+}
+
 // AsUInt64s returns the cache key in the form of two uint64's. This method returns uint64s created
 // from two distinct hashing algorithms, which should make the risk of key overlap incredibly
 // unlikely.
@@ -25,4 +33,4 @@ func (dck DispatchCacheKey) AsUInt64s() (uint64, uint64) {
 	return dck.processSpecificSum, dck.stableSum
 }
 
-var emptyDispatchCacheKey = DispatchCacheKey{0, 0}
+var emptyDispatchCacheKey = DispatchCacheKey{0, 0, 0}
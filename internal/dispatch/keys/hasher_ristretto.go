@@ -5,6 +5,8 @@ package keys
 
 import (
 	"fmt"
+	"hash"
+	"hash/fnv"
 	"unsafe"
 
 	"github.com/cespare/xxhash/v2"
@@ -25,14 +27,16 @@ func dispatchCacheKeyHash(prefix cachePrefix, atRevision string, computeOption d
 
 type dispatchCacheKeyHasher struct {
 	stableHasher       *xxhash.Digest
+	stableCheckHasher  hash.Hash64
 	computeOption      dispatchCacheKeyHashComputeOption
 	processSpecificSum uint64
 }
 
 func newDispatchCacheKeyHasher(prefix cachePrefix, computeOption dispatchCacheKeyHashComputeOption) *dispatchCacheKeyHasher {
 	h := &dispatchCacheKeyHasher{
-		stableHasher:  xxhash.New(),
-		computeOption: computeOption,
+		stableHasher:      xxhash.New(),
+		stableCheckHasher: fnv.New64a(),
+		computeOption:     computeOption,
 	}
 
 	prefixString := string(prefix)
@@ -54,6 +58,9 @@ func (h *dispatchCacheKeyHasher) mustWriteString(value string) {
 		panic(fmt.Errorf("got an error from writing to the stable hasher: %w", err))
 	}
 
+	// NOTE: fnv never returns an error for Write.
+	_, _ = h.stableCheckHasher.Write([]byte(value))
+
 	if h.computeOption == computeBothHashes {
 		h.processSpecificSum = runMemHash(h.processSpecificSum, []byte(value))
 	}
@@ -78,6 +85,7 @@ func runMemHash(seed uint64, data []byte) uint64 {
 func (h *dispatchCacheKeyHasher) BuildKey() DispatchCacheKey {
 	return DispatchCacheKey{
 		stableSum:          h.stableHasher.Sum64(),
+		stableCheckSum:     h.stableCheckHasher.Sum64(),
 		processSpecificSum: h.processSpecificSum,
 	}
 }
@@ -41,22 +41,59 @@ type Handler interface {
 	ReachableResourcesDispatchKey(ctx context.Context, req *v1.DispatchReachableResourcesRequest) ([]byte, error)
 }
 
-type baseKeyHandler struct{}
+// WithoutRevision returns a key handler which computes the same cache keys as the given handler,
+// except that the revision at which each request is made is not included. Such keys may only be
+// used by caches which ensure that an entry is still valid at the revision of the request. Dispatch
+// keys are unaffected. Returns false if the handler does not support revision-independent keys.
+func WithoutRevision(handler Handler) (Handler, bool) {
+	switch handler.(type) {
+	case *DirectKeyHandler:
+		return &DirectKeyHandler{baseKeyHandler{revisionIndependent: true}}, true
+	case *CanonicalKeyHandler:
+		return &CanonicalKeyHandler{baseKeyHandler{revisionIndependent: true}}, true
+	default:
+		return nil, false
+	}
+}
+
+type baseKeyHandler struct {
+	// revisionIndependent indicates that the revision of requests is not included in their
+	// cache keys.
+	revisionIndependent bool
+}
+
+// revisionedRequest is a dispatch request made at a revision.
+type revisionedRequest[T any] interface {
+	CloneVT() T
+	GetMetadata() *v1.ResolverMeta
+}
+
+// cacheKeyRequest returns the request from which the cache key is computed, which has its
+// revision removed if the handler computes revision-independent keys.
+func cacheKeyRequest[T revisionedRequest[T]](b baseKeyHandler, req T) T {
+	if !b.revisionIndependent {
+		return req
+	}
+
+	cloned := req.CloneVT()
+	cloned.GetMetadata().AtRevision = ""
+	return cloned
+}
 
 func (b baseKeyHandler) LookupResourcesCacheKey(_ context.Context, req *v1.DispatchLookupResourcesRequest) (DispatchCacheKey, error) {
-	return lookupResourcesRequestToKey(req, computeBothHashes), nil
+	return lookupResourcesRequestToKey(cacheKeyRequest(b, req), computeBothHashes), nil
 }
 
 func (b baseKeyHandler) LookupSubjectsCacheKey(_ context.Context, req *v1.DispatchLookupSubjectsRequest) (DispatchCacheKey, error) {
-	return lookupSubjectsRequestToKey(req, computeBothHashes), nil
+	return lookupSubjectsRequestToKey(cacheKeyRequest(b, req), computeBothHashes), nil
 }
 
 func (b baseKeyHandler) ExpandCacheKey(_ context.Context, req *v1.DispatchExpandRequest) (DispatchCacheKey, error) {
-	return expandRequestToKey(req, computeBothHashes), nil
+	return expandRequestToKey(cacheKeyRequest(b, req), computeBothHashes), nil
 }
 
 func (b baseKeyHandler) ReachableResourcesCacheKey(_ context.Context, req *v1.DispatchReachableResourcesRequest) (DispatchCacheKey, error) {
-	return reachableResourcesRequestToKey(req, computeBothHashes), nil
+	return reachableResourcesRequestToKey(cacheKeyRequest(b, req), computeBothHashes), nil
 }
 
 func (b baseKeyHandler) CheckDispatchKey(_ context.Context, req *v1.DispatchCheckRequest) ([]byte, error) {
@@ -85,7 +122,7 @@ type DirectKeyHandler struct {
 }
 
 func (d *DirectKeyHandler) CheckCacheKey(_ context.Context, req *v1.DispatchCheckRequest) (DispatchCacheKey, error) {
-	return checkRequestToKey(req, computeBothHashes), nil
+	return checkRequestToKey(cacheKeyRequest(d.baseKeyHandler, req), computeBothHashes), nil
 }
 
 // CanonicalKeyHandler is a key handler which makes use of the canonical key for relations for
@@ -121,9 +158,9 @@ func (c *CanonicalKeyHandler) CheckCacheKey(ctx context.Context, req *v1.Dispatc
 		// TODO(jschorr): Remove this conditional once we have a verified migration ordering system that ensures a backfill migration has
 		// run after the namespace annotation code has been fully deployed by users.
 		if relation.CanonicalCacheKey != "" {
-			return checkRequestToKeyWithCanonical(req, relation.CanonicalCacheKey)
+			return checkRequestToKeyWithCanonical(cacheKeyRequest(c.baseKeyHandler, req), relation.CanonicalCacheKey)
 		}
 	}
 
-	return checkRequestToKey(req, computeBothHashes), nil
+	return checkRequestToKey(cacheKeyRequest(c.baseKeyHandler, req), computeBothHashes), nil
 }
@@ -0,0 +1,55 @@
+package keys
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+type unsupportedKeyHandler struct {
+	DirectKeyHandler
+}
+
+func TestWithoutRevision(t *testing.T) {
+	require := require.New(t)
+
+	_, ok := WithoutRevision(&unsupportedKeyHandler{})
+	require.False(ok)
+
+	handler, ok := WithoutRevision(&DirectKeyHandler{})
+	require.True(ok)
+
+	checkRequest := func(revision string) *v1.DispatchCheckRequest {
+		return &v1.DispatchCheckRequest{
+			ResourceRelation: &core.RelationReference{Namespace: "document", Relation: "view"},
+			ResourceIds:      []string{"foo"},
+			Subject:          tuple.ParseSubjectONR("user:tom"),
+			Metadata:         &v1.ResolverMeta{AtRevision: revision},
+		}
+	}
+
+	first := checkRequest("1234")
+	firstKey, err := handler.CheckCacheKey(context.Background(), first)
+	require.NoError(err)
+	require.Equal("1234", first.Metadata.AtRevision, "the request must not be modified")
+
+	secondKey, err := handler.CheckCacheKey(context.Background(), checkRequest("5678"))
+	require.NoError(err)
+	require.Equal(firstKey, secondKey)
+
+	revisionedKey, err := (&DirectKeyHandler{}).CheckCacheKey(context.Background(), first)
+	require.NoError(err)
+	require.NotEqual(firstKey, revisionedKey)
+
+	// Dispatch keys still include the revision.
+	firstDispatchKey, err := handler.CheckDispatchKey(context.Background(), first)
+	require.NoError(err)
+	secondDispatchKey, err := handler.CheckDispatchKey(context.Background(), checkRequest("5678"))
+	require.NoError(err)
+	require.NotEqual(firstDispatchKey, secondDispatchKey)
+}
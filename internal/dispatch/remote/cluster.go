@@ -13,6 +13,7 @@ import (
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/authzed/spicedb/internal/dispatch"
@@ -48,6 +49,15 @@ type ClusterDispatcherConfig struct {
 	// DispatchOverallTimeout is the maximum duration of a dispatched request
 	// before it should timeout.
 	DispatchOverallTimeout time.Duration
+
+	// Membership reports the peers of the cluster, if they are discovered rather than resolved by
+	// the connection itself.
+	Membership Membership
+}
+
+// Membership reports the dispatch addresses of the peers in a cluster.
+type Membership interface {
+	Members() []string
 }
 
 // SecondaryDispatch defines a struct holding a client and its name for secondary
@@ -77,6 +87,7 @@ func NewClusterDispatcher(client ClusterClient, conn *grpc.ClientConn, config Cl
 		dispatchOverallTimeout: dispatchOverallTimeout,
 		secondaryDispatch:      secondaryDispatch,
 		secondaryDispatchExprs: secondaryDispatchExprs,
+		membership:             config.Membership,
 	}
 }
 
@@ -87,6 +98,7 @@ type clusterDispatcher struct {
 	dispatchOverallTimeout time.Duration
 	secondaryDispatch      map[string]SecondaryDispatch
 	secondaryDispatchExprs map[string]*DispatchExpr
+	membership             Membership
 }
 
 func (cr *clusterDispatcher) DispatchCheck(ctx context.Context, req *v1.DispatchCheckRequest) (*v1.DispatchCheckResponse, error) {
@@ -101,8 +113,8 @@ func (cr *clusterDispatcher) DispatchCheck(ctx context.Context, req *v1.Dispatch
 
 	ctx = context.WithValue(ctx, consistent.CtxKey, requestKey)
 
-	resp, err := dispatchRequest(ctx, cr, "check", req, func(ctx context.Context, client ClusterClient) (*v1.DispatchCheckResponse, error) {
-		resp, err := client.DispatchCheck(ctx, req)
+	resp, err := dispatchRequest(ctx, cr, "check", req, func(ctx context.Context, client ClusterClient, opts ...grpc.CallOption) (*v1.DispatchCheckResponse, error) {
+		resp, err := client.DispatchCheck(ctx, req, opts...)
 		if err != nil {
 			return resp, err
 		}
@@ -130,27 +142,46 @@ type responseMessage interface {
 }
 
 type respTuple[S responseMessage] struct {
-	resp S
-	err  error
+	resp    S
+	trailer metadata.MD
+	err     error
 }
 
 type secondaryRespTuple[S responseMessage] struct {
 	handlerName string
 	resp        S
+	trailer     metadata.MD
 }
 
-func dispatchRequest[Q requestMessage, S responseMessage](ctx context.Context, cr *clusterDispatcher, reqKey string, req Q, handler func(context.Context, ClusterClient) (S, error)) (S, error) {
+// dispatchRequest dispatches the request to the cluster, and to any secondary dispatcher matching
+// it, returning the first successful response. The expiration returned in the trailer of that
+// response is recorded in the expiration tracker of the context, if any.
+func dispatchRequest[Q requestMessage, S responseMessage](ctx context.Context, cr *clusterDispatcher, reqKey string, req Q, handler func(context.Context, ClusterClient, ...grpc.CallOption) (S, error)) (S, error) {
 	withTimeout, cancelFn := context.WithTimeout(ctx, cr.dispatchOverallTimeout)
 	defer cancelFn()
 
+	invoke := func(client ClusterClient) (S, metadata.MD, error) {
+		var trailer metadata.MD
+		resp, err := handler(withTimeout, client, grpc.Trailer(&trailer))
+		return resp, trailer, err
+	}
+
+	invokePrimary := func() (S, error) {
+		resp, trailer, err := invoke(cr.clusterClient)
+		if err == nil {
+			dispatch.ObserveExpirationTrailer(ctx, trailer)
+		}
+		return resp, err
+	}
+
 	if len(cr.secondaryDispatchExprs) == 0 || len(cr.secondaryDispatch) == 0 {
-		return handler(withTimeout, cr.clusterClient)
+		return invokePrimary()
 	}
 
 	// If no secondary dispatches are defined, just invoke directly.
 	expr, ok := cr.secondaryDispatchExprs[reqKey]
 	if !ok {
-		return handler(withTimeout, cr.clusterClient)
+		return invokePrimary()
 	}
 
 	// Otherwise invoke in parallel with any secondary matches.
@@ -159,8 +190,8 @@ func dispatchRequest[Q requestMessage, S responseMessage](ctx context.Context, c
 
 	// Run the main dispatch.
 	go func() {
-		resp, err := handler(withTimeout, cr.clusterClient)
-		primaryResultChan <- respTuple[S]{resp, err}
+		resp, trailer, err := invoke(cr.clusterClient)
+		primaryResultChan <- respTuple[S]{resp, trailer, err}
 	}()
 
 	result, err := RunDispatchExpr(expr, req)
@@ -179,7 +210,7 @@ func dispatchRequest[Q requestMessage, S responseMessage](ctx context.Context, c
 
 		log.Trace().Str("secondary-dispatcher", secondary.Name).Object("request", req).Msg("running secondary dispatcher")
 		go func() {
-			resp, err := handler(withTimeout, secondary.Client)
+			resp, trailer, err := invoke(secondary.Client)
 			if err != nil {
 				// For secondary dispatches, ignore any errors, as only the primary will be handled in
 				// that scenario.
@@ -187,7 +218,7 @@ func dispatchRequest[Q requestMessage, S responseMessage](ctx context.Context, c
 				return
 			}
 
-			secondaryResultChan <- secondaryRespTuple[S]{resp: resp, handlerName: secondary.Name}
+			secondaryResultChan <- secondaryRespTuple[S]{resp: resp, trailer: trailer, handlerName: secondary.Name}
 		}()
 	}
 
@@ -199,6 +230,7 @@ func dispatchRequest[Q requestMessage, S responseMessage](ctx context.Context, c
 	case r := <-primaryResultChan:
 		if r.err == nil {
 			dispatchCounter.WithLabelValues(reqKey, "(primary)").Add(1)
+			dispatch.ObserveExpirationTrailer(ctx, r.trailer)
 			return r.resp, nil
 		}
 
@@ -208,6 +240,7 @@ func dispatchRequest[Q requestMessage, S responseMessage](ctx context.Context, c
 
 	case r := <-secondaryResultChan:
 		dispatchCounter.WithLabelValues(reqKey, r.handlerName).Add(1)
+		dispatch.ObserveExpirationTrailer(ctx, r.trailer)
 		return r.resp, nil
 	}
 
@@ -285,6 +318,7 @@ func (cr *clusterDispatcher) DispatchReachableResources(
 		default:
 			result, err := client.Recv()
 			if errors.Is(err, io.EOF) {
+				dispatch.ObserveExpirationTrailer(ctx, client.Trailer())
 				return nil
 			} else if err != nil {
 				return err
@@ -335,6 +369,7 @@ func (cr *clusterDispatcher) DispatchLookupResources(
 		default:
 			result, err := client.Recv()
 			if errors.Is(err, io.EOF) {
+				dispatch.ObserveExpirationTrailer(ctx, client.Trailer())
 				return nil
 			} else if err != nil {
 				return err
@@ -385,6 +420,7 @@ func (cr *clusterDispatcher) DispatchLookupSubjects(
 		default:
 			result, err := client.Recv()
 			if errors.Is(err, io.EOF) {
+				dispatch.ObserveExpirationTrailer(ctx, client.Trailer())
 				return nil
 			} else if err != nil {
 				return err
@@ -411,9 +447,24 @@ func (cr *clusterDispatcher) Close() error {
 func (cr *clusterDispatcher) ReadyState() dispatch.ReadyState {
 	state := cr.conn.GetState()
 	log.Trace().Interface("connection-state", state).Msg("checked if cluster dispatcher is ready")
+	if cr.membership == nil {
+		return dispatch.ReadyState{
+			IsReady: state == connectivity.Ready || state == connectivity.Idle,
+			Message: fmt.Sprintf("found expected state when trying to connect to cluster: %v", state),
+		}
+	}
+
+	members := cr.membership.Members()
+	if len(members) == 0 {
+		return dispatch.ReadyState{
+			IsReady: false,
+			Message: "no dispatch peers have been discovered",
+		}
+	}
+
 	return dispatch.ReadyState{
 		IsReady: state == connectivity.Ready || state == connectivity.Idle,
-		Message: fmt.Sprintf("found expected state when trying to connect to cluster: %v", state),
+		Message: fmt.Sprintf("found expected state when trying to connect to cluster: %v; discovered peers: %s", state, strings.Join(members, ", ")),
 	}
 }
 
@@ -272,3 +272,27 @@ func connectionForDispatching(t *testing.T, svc v1.DispatchServiceServer) *grpc.
 
 	return conn
 }
+
+type fixedMembership []string
+
+func (fm fixedMembership) Members() []string {
+	return fm
+}
+
+func TestReadyStateWithMembership(t *testing.T) {
+	conn := connectionForDispatching(t, &fakeDispatchSvc{})
+
+	dispatcher := NewClusterDispatcher(v1.NewDispatchServiceClient(conn), conn, ClusterDispatcherConfig{
+		Membership: fixedMembership{},
+	}, nil, nil)
+	state := dispatcher.ReadyState()
+	require.False(t, state.IsReady)
+	require.Equal(t, "no dispatch peers have been discovered", state.Message)
+
+	dispatcher = NewClusterDispatcher(v1.NewDispatchServiceClient(conn), conn, ClusterDispatcherConfig{
+		Membership: fixedMembership{"a:50053", "b:50053"},
+	}, nil, nil)
+	state = dispatcher.ReadyState()
+	require.True(t, state.IsReady)
+	require.Contains(t, state.Message, "discovered peers: a:50053, b:50053")
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -42,8 +43,12 @@ type Dispatcher struct {
 	delegate   dispatch.Dispatcher
 	keyHandler keys.Handler
 
-	checkGroup  singleflight.Group[string, *v1.DispatchCheckResponse]
+	checkGroup  singleflight.Group[string, checkResult]
 	expandGroup singleflight.Group[string, *v1.DispatchExpandResponse]
+
+	reachableResourcesGroup streamGroup[*v1.DispatchReachableResourcesResponse]
+	lookupResourcesGroup    streamGroup[*v1.DispatchLookupResourcesResponse]
+	lookupSubjectsGroup     streamGroup[*v1.DispatchLookupSubjectsResponse]
 }
 
 func (d *Dispatcher) DispatchCheck(ctx context.Context, req *v1.DispatchCheckRequest) (*v1.DispatchCheckResponse, error) {
@@ -83,9 +88,11 @@ func (d *Dispatcher) DispatchCheck(ctx context.Context, req *v1.DispatchCheckReq
 	}
 
 	primary := false
-	v, isShared, err := d.checkGroup.Do(ctx, keyString, func(innerCtx context.Context) (*v1.DispatchCheckResponse, error) {
+	v, isShared, err := d.checkGroup.Do(ctx, keyString, func(innerCtx context.Context) (checkResult, error) {
 		primary = true
-		return d.delegate.DispatchCheck(innerCtx, req)
+		innerCtx, tracker := dispatch.ContextWithExpirationTracker(innerCtx)
+		resp, err := d.delegate.DispatchCheck(innerCtx, req)
+		return checkResult{resp, tracker.Earliest()}, err
 	})
 
 	singleFlightCount.WithLabelValues("DispatchCheck", strconv.FormatBool(isShared)).Inc()
@@ -93,10 +100,21 @@ func (d *Dispatcher) DispatchCheck(ctx context.Context, req *v1.DispatchCheckReq
 		return &v1.DispatchCheckResponse{Metadata: &v1.ResponseMeta{DispatchCount: 1}}, err
 	}
 
+	// The callers sharing the execution did not read the relationships themselves, so the
+	// expiration of the result is recorded for each of them.
+	dispatch.ObserveExpiration(ctx, v.expiresAt)
+
 	span := trace.SpanFromContext(ctx)
 	singleflighted := isShared && !primary
 	span.SetAttributes(attribute.Bool("singleflight", singleflighted))
-	return v, err
+	return v.resp, err
+}
+
+// checkResult is the result of a check shared by the callers of a single execution, along with
+// the earliest expiration of the relationships read to compute it, if any.
+type checkResult struct {
+	resp      *v1.DispatchCheckResponse
+	expiresAt *time.Time
 }
 
 func (d *Dispatcher) DispatchExpand(ctx context.Context, req *v1.DispatchExpandRequest) (*v1.DispatchExpandResponse, error) {
@@ -143,15 +161,84 @@ func (d *Dispatcher) DispatchExpand(ctx context.Context, req *v1.DispatchExpandR
 }
 
 func (d *Dispatcher) DispatchReachableResources(req *v1.DispatchReachableResourcesRequest, stream dispatch.ReachableResourcesStream) error {
-	return d.delegate.DispatchReachableResources(req, stream)
+	key, err := d.keyHandler.ReachableResourcesDispatchKey(stream.Context(), req)
+	if err != nil {
+		return status.Error(codes.Internal, "unexpected DispatchReachableResources error")
+	}
+
+	return singleflightStream(&d.reachableResourcesGroup, "DispatchReachableResources", req.Metadata, key, stream,
+		func(stream dispatch.ReachableResourcesStream) error {
+			return d.delegate.DispatchReachableResources(req, stream)
+		})
 }
 
 func (d *Dispatcher) DispatchLookupResources(req *v1.DispatchLookupResourcesRequest, stream dispatch.LookupResourcesStream) error {
-	return d.delegate.DispatchLookupResources(req, stream)
+	key, err := d.keyHandler.LookupResourcesDispatchKey(stream.Context(), req)
+	if err != nil {
+		return status.Error(codes.Internal, "unexpected DispatchLookupResources error")
+	}
+
+	return singleflightStream(&d.lookupResourcesGroup, "DispatchLookupResources", req.Metadata, key, stream,
+		func(stream dispatch.LookupResourcesStream) error {
+			return d.delegate.DispatchLookupResources(req, stream)
+		})
 }
 
 func (d *Dispatcher) DispatchLookupSubjects(req *v1.DispatchLookupSubjectsRequest, stream dispatch.LookupSubjectsStream) error {
-	return d.delegate.DispatchLookupSubjects(req, stream)
+	key, err := d.keyHandler.LookupSubjectsDispatchKey(stream.Context(), req)
+	if err != nil {
+		return status.Error(codes.Internal, "unexpected DispatchLookupSubjects error")
+	}
+
+	return singleflightStream(&d.lookupSubjectsGroup, "DispatchLookupSubjects", req.Metadata, key, stream,
+		func(stream dispatch.LookupSubjectsStream) error {
+			return d.delegate.DispatchLookupSubjects(req, stream)
+		})
+}
+
+// singleflightStream executes a streaming dispatch via the group, sharing the execution with all
+// concurrent dispatches with the same key. As the dispatch key includes the cursor and limit, only
+// dispatches for the same page of results share an execution.
+func singleflightStream[T any](
+	group *streamGroup[T],
+	method string,
+	metadata *v1.ResolverMeta,
+	key []byte,
+	stream dispatch.Stream[T],
+	execute func(stream dispatch.Stream[T]) error,
+) error {
+	keyString := hex.EncodeToString(key)
+
+	// this is in place so that upgrading to a SpiceDB version with traversal bloom does not cause dispatch failures
+	// Since there is no bloom filter, there is no guarantee recursion won't happen, so it's safer not to singleflight
+	if len(metadata.TraversalBloom) == 0 {
+		tb, err := v1.NewTraversalBloomFilter(50)
+		if err != nil {
+			return status.Error(codes.Internal, fmt.Errorf("unable to create traversal bloom filter: %w", err).Error())
+		}
+
+		singleFlightCount.WithLabelValues(method, "missing").Inc()
+		metadata.TraversalBloom = tb
+		return execute(stream)
+	}
+
+	// A streaming dispatch waits for its sub-dispatches, so a recursive dispatch with the same key
+	// must not share the execution it is part of.
+	possiblyLoop, err := metadata.RecordTraversal(keyString)
+	if err != nil {
+		return err
+	} else if possiblyLoop {
+		log.Debug().Str("method", method).Str("key", keyString).Msg("potential streaming dispatch loop detected")
+		singleFlightCount.WithLabelValues(method, "loop").Inc()
+		return execute(stream)
+	}
+
+	isShared, err := group.Do(stream.Context(), keyString, stream, execute)
+	singleFlightCount.WithLabelValues(method, strconv.FormatBool(isShared)).Inc()
+
+	span := trace.SpanFromContext(stream.Context())
+	span.SetAttributes(attribute.Bool("singleflight", isShared))
+	return err
 }
 
 func (d *Dispatcher) Close() error                    { return d.delegate.Close() }
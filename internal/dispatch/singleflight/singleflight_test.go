@@ -304,6 +304,233 @@ func TestSingleFlightDispatcherExpandBypassesIfMissingBloomFiler(t *testing.T) {
 	assertCounterWithLabel(t, reg, 1, "spicedb_dispatch_single_flight_total", "missing")
 }
 
+func TestSingleFlightDispatcherLookupResources(t *testing.T) {
+	var called atomic.Uint64
+	f := func() {
+		time.Sleep(100 * time.Millisecond)
+		called.Add(1)
+	}
+	disp := New(mockDispatcher{f: f}, &keys.DirectKeyHandler{})
+
+	req := &v1.DispatchLookupResourcesRequest{
+		ObjectRelation: tuple.RelationReference("document", "view"),
+		Subject:        tuple.ObjectAndRelation("user", "tom", "..."),
+		Metadata: &v1.ResolverMeta{
+			AtRevision:     "1234",
+			TraversalBloom: v1.MustNewTraversalBloomFilter(defaultBloomFilterSize),
+		},
+		OptionalLimit: 10,
+	}
+
+	anotherReq := req.CloneVT()
+	anotherReq.OptionalLimit = 5
+
+	var streams []*dispatch.CollectingDispatchStream[*v1.DispatchLookupResourcesResponse]
+	wg := sync.WaitGroup{}
+	for _, r := range []*v1.DispatchLookupResourcesRequest{req, req, req, anotherReq} {
+		r := r.CloneVT()
+		stream := dispatch.NewCollectingDispatchStream[*v1.DispatchLookupResourcesResponse](context.Background())
+		streams = append(streams, stream)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, disp.DispatchLookupResources(r, stream))
+		}()
+	}
+
+	wg.Wait()
+
+	require.Equal(t, uint64(2), called.Load(), "should have dispatched %d calls but did %d", uint64(2), called.Load())
+	for _, stream := range streams {
+		require.Len(t, stream.Results(), len(mockResourceIDs))
+		for index, result := range stream.Results() {
+			require.Equal(t, mockResourceIDs[index], result.ResolvedResource.ResourceId)
+		}
+	}
+}
+
+func TestSingleFlightDispatcherLookupResourcesCallerLeaves(t *testing.T) {
+	var called atomic.Uint64
+	f := func() {
+		called.Add(1)
+	}
+	disp := New(mockDispatcher{f: f}, &keys.DirectKeyHandler{})
+
+	req := &v1.DispatchLookupResourcesRequest{
+		ObjectRelation: tuple.RelationReference("document", "view"),
+		Subject:        tuple.ObjectAndRelation("user", "tom", "..."),
+		Metadata: &v1.ResolverMeta{
+			AtRevision:     "1234",
+			TraversalBloom: v1.MustNewTraversalBloomFilter(defaultBloomFilterSize),
+		},
+	}
+
+	// The first caller gives up before the dispatch completes, which must not affect the second,
+	// which joins before the first result has been published.
+	firstCtx, cancel := context.WithTimeout(context.Background(), 15*time.Millisecond)
+	defer cancel()
+
+	first := dispatch.NewCollectingDispatchStream[*v1.DispatchLookupResourcesResponse](firstCtx)
+	second := dispatch.NewCollectingDispatchStream[*v1.DispatchLookupResourcesResponse](context.Background())
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		require.ErrorIs(t, disp.DispatchLookupResources(req.CloneVT(), first), context.DeadlineExceeded)
+	}()
+	go func() {
+		defer wg.Done()
+		time.Sleep(3 * time.Millisecond)
+		require.NoError(t, disp.DispatchLookupResources(req.CloneVT(), second))
+	}()
+
+	wg.Wait()
+
+	require.Equal(t, uint64(1), called.Load())
+	require.Len(t, second.Results(), len(mockResourceIDs))
+	require.Less(t, len(first.Results()), len(mockResourceIDs))
+}
+
+func TestSingleFlightDispatcherLookupResourcesLateJoin(t *testing.T) {
+	var called atomic.Uint64
+	f := func() {
+		called.Add(1)
+	}
+	disp := New(mockDispatcher{f: f}, &keys.DirectKeyHandler{})
+
+	req := &v1.DispatchLookupResourcesRequest{
+		ObjectRelation: tuple.RelationReference("document", "view"),
+		Subject:        tuple.ObjectAndRelation("user", "tom", "..."),
+		Metadata: &v1.ResolverMeta{
+			AtRevision:     "1234",
+			TraversalBloom: v1.MustNewTraversalBloomFilter(defaultBloomFilterSize),
+		},
+	}
+
+	// Results are not retained, so a caller arriving after the first result has been published
+	// starts its own execution.
+	first := dispatch.NewCollectingDispatchStream[*v1.DispatchLookupResourcesResponse](context.Background())
+	second := dispatch.NewCollectingDispatchStream[*v1.DispatchLookupResourcesResponse](context.Background())
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		require.NoError(t, disp.DispatchLookupResources(req.CloneVT(), first))
+	}()
+	go func() {
+		defer wg.Done()
+		time.Sleep(15 * time.Millisecond)
+		require.NoError(t, disp.DispatchLookupResources(req.CloneVT(), second))
+	}()
+
+	wg.Wait()
+
+	require.Equal(t, uint64(2), called.Load())
+	require.Len(t, first.Results(), len(mockResourceIDs))
+	require.Len(t, second.Results(), len(mockResourceIDs))
+}
+
+func TestSingleFlightDispatcherLookupResourcesStreamsResults(t *testing.T) {
+	disp := New(mockDispatcher{f: func() {}}, &keys.DirectKeyHandler{})
+
+	req := &v1.DispatchLookupResourcesRequest{
+		ObjectRelation: tuple.RelationReference("document", "view"),
+		Subject:        tuple.ObjectAndRelation("user", "tom", "..."),
+		Metadata: &v1.ResolverMeta{
+			AtRevision:     "1234",
+			TraversalBloom: v1.MustNewTraversalBloomFilter(defaultBloomFilterSize),
+		},
+	}
+
+	// Each result is received before the next one is produced.
+	var received atomic.Uint64
+	stream := dispatch.NewHandlingDispatchStream(context.Background(), func(result *v1.DispatchLookupResourcesResponse) error {
+		require.Equal(t, mockResourceIDs[received.Load()], result.ResolvedResource.ResourceId)
+		received.Add(1)
+		return nil
+	})
+
+	done := make(chan error)
+	go func() {
+		done <- disp.DispatchLookupResources(req.CloneVT(), stream)
+	}()
+
+	require.Eventually(t, func() bool { return received.Load() == 1 }, time.Second, time.Millisecond)
+	require.Less(t, received.Load(), uint64(len(mockResourceIDs)))
+	require.NoError(t, <-done)
+	require.Equal(t, uint64(len(mockResourceIDs)), received.Load())
+}
+
+func TestSingleFlightDispatcherReachableResourcesDetectsLoopThroughDelegate(t *testing.T) {
+	singleFlightCount = prometheus.NewCounterVec(singleFlightCountConfig, []string{"method", "shared"})
+	reg := registerMetricInGatherer(singleFlightCount)
+
+	var called atomic.Uint64
+	f := func() {
+		time.Sleep(100 * time.Millisecond)
+		called.Add(1)
+	}
+	keyHandler := &keys.DirectKeyHandler{}
+	// we simulate an actual dispatch-chain loop by nesting 2 singleflight dispatchers
+	disp := New(New(mockDispatcher{f: f}, keyHandler), keyHandler)
+
+	req := &v1.DispatchReachableResourcesRequest{
+		ResourceRelation: tuple.RelationReference("document", "view"),
+		SubjectRelation:  tuple.RelationReference("user", "..."),
+		SubjectIds:       []string{"tom"},
+		Metadata: &v1.ResolverMeta{
+			AtRevision:     "1234",
+			TraversalBloom: v1.MustNewTraversalBloomFilter(defaultBloomFilterSize),
+		},
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stream := dispatch.NewCollectingDispatchStream[*v1.DispatchReachableResourcesResponse](context.Background())
+			require.NoError(t, disp.DispatchReachableResources(req.CloneVT(), stream))
+			require.Len(t, stream.Results(), len(mockResourceIDs))
+		}()
+	}
+
+	wg.Wait()
+
+	require.Equal(t, uint64(1), called.Load(), "should have dispatched %d calls but did %d", uint64(1), called.Load())
+	assertCounterWithLabel(t, reg, 3, "spicedb_dispatch_single_flight_total", "loop")
+}
+
+func TestSingleFlightDispatcherLookupSubjectsBypassesIfMissingBloomFiler(t *testing.T) {
+	singleFlightCount = prometheus.NewCounterVec(singleFlightCountConfig, []string{"method", "shared"})
+	reg := registerMetricInGatherer(singleFlightCount)
+
+	var called atomic.Uint64
+	f := func() {
+		called.Add(1)
+	}
+	disp := New(mockDispatcher{f: f}, &keys.DirectKeyHandler{})
+
+	req := &v1.DispatchLookupSubjectsRequest{
+		ResourceRelation: tuple.RelationReference("document", "view"),
+		ResourceIds:      []string{"foo"},
+		SubjectRelation:  tuple.RelationReference("user", "..."),
+		Metadata: &v1.ResolverMeta{
+			AtRevision: "1234",
+		},
+	}
+
+	stream := dispatch.NewCollectingDispatchStream[*v1.DispatchLookupSubjectsResponse](context.Background())
+	require.NoError(t, disp.DispatchLookupSubjects(req.CloneVT(), stream))
+	require.Len(t, stream.Results(), len(mockResourceIDs))
+
+	require.Equal(t, uint64(1), called.Load(), "should have dispatched %d calls but did %d", uint64(1), called.Load())
+	assertCounterWithLabel(t, reg, 1, "spicedb_dispatch_single_flight_total", "missing")
+}
+
 func registerMetricInGatherer(collector prometheus.Collector) prometheus.Gatherer {
 	reg := prometheus.NewRegistry()
 	reg.MustRegister(collector)
@@ -365,15 +592,40 @@ func (m mockDispatcher) DispatchExpand(_ context.Context, _ *v1.DispatchExpandRe
 	return &v1.DispatchExpandResponse{}, nil
 }
 
-func (m mockDispatcher) DispatchReachableResources(_ *v1.DispatchReachableResourcesRequest, _ dispatch.ReachableResourcesStream) error {
-	return nil
+func (m mockDispatcher) DispatchReachableResources(_ *v1.DispatchReachableResourcesRequest, stream dispatch.ReachableResourcesStream) error {
+	m.f()
+	return publishMockResults(stream, func(resourceID string) *v1.DispatchReachableResourcesResponse {
+		return &v1.DispatchReachableResourcesResponse{Resource: &v1.ReachableResource{ResourceId: resourceID}}
+	})
 }
 
-func (m mockDispatcher) DispatchLookupResources(_ *v1.DispatchLookupResourcesRequest, _ dispatch.LookupResourcesStream) error {
-	return nil
+func (m mockDispatcher) DispatchLookupResources(_ *v1.DispatchLookupResourcesRequest, stream dispatch.LookupResourcesStream) error {
+	m.f()
+	return publishMockResults(stream, func(resourceID string) *v1.DispatchLookupResourcesResponse {
+		return &v1.DispatchLookupResourcesResponse{ResolvedResource: &v1.ResolvedResource{ResourceId: resourceID}}
+	})
 }
 
-func (m mockDispatcher) DispatchLookupSubjects(_ *v1.DispatchLookupSubjectsRequest, _ dispatch.LookupSubjectsStream) error {
+func (m mockDispatcher) DispatchLookupSubjects(_ *v1.DispatchLookupSubjectsRequest, stream dispatch.LookupSubjectsStream) error {
+	m.f()
+	return publishMockResults(stream, func(resourceID string) *v1.DispatchLookupSubjectsResponse {
+		return &v1.DispatchLookupSubjectsResponse{FoundSubjectsByResourceId: map[string]*v1.FoundSubjects{resourceID: {}}}
+	})
+}
+
+var mockResourceIDs = []string{"first", "second", "third"}
+
+func publishMockResults[T any](stream dispatch.Stream[T], newResult func(resourceID string) T) error {
+	for _, resourceID := range mockResourceIDs {
+		time.Sleep(10 * time.Millisecond)
+		if err := stream.Context().Err(); err != nil {
+			return err
+		}
+
+		if err := stream.Publish(newResult(resourceID)); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
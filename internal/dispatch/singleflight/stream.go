@@ -0,0 +1,191 @@
+package singleflight
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/authzed/spicedb/internal/dispatch"
+)
+
+// streamGroup deduplicates concurrent streaming dispatches with the same key: the dispatch is
+// executed once, and every result it publishes is sent to all the callers sharing it as it is
+// produced. As results are not retained, callers can only join an execution which has not yet
+// published any result; callers arriving later start a new execution.
+type streamGroup[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*streamCall[T]
+}
+
+// streamCall is a single execution of a streaming dispatch, shared by one or more callers.
+type streamCall[T any] struct {
+	mu          sync.Mutex
+	subscribers []*streamSubscriber[T]
+
+	// started marks the publication of the first result, after which no caller can join.
+	started sync.Once
+
+	// callers is the number of callers still receiving results, guarded by the group's mutex.
+	callers int
+	cancel  context.CancelFunc
+}
+
+// streamSubscriber is a caller receiving the results of a call.
+type streamSubscriber[T any] struct {
+	mu       sync.Mutex
+	stream   dispatch.Stream[T]
+	finished bool
+	err      error
+
+	// expiresAt is the earliest expiration of the relationships read by the execution, if any,
+	// set once the execution completed.
+	expiresAt *time.Time
+
+	// done is closed once the subscriber no longer receives results.
+	done chan struct{}
+}
+
+// Do executes the streaming dispatch for the key, unless an execution for the key which has not
+// yet published any result is in progress, and sends all the results of the execution to the
+// stream as they are produced. Returns whether an execution already in progress was shared.
+//
+// The execution is only canceled once all the callers sharing it have returned.
+func (g *streamGroup[T]) Do(ctx context.Context, key string, stream dispatch.Stream[T], execute func(stream dispatch.Stream[T]) error) (bool, error) {
+	subscriber := &streamSubscriber[T]{stream: stream, done: make(chan struct{})}
+
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*streamCall[T])
+	}
+
+	call, isShared := g.calls[key]
+	if !isShared {
+		execCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &streamCall[T]{cancel: cancel}
+		g.calls[key] = call
+		go g.execute(execCtx, key, call, execute)
+	}
+	call.callers++
+
+	// Joining under the group's mutex ensures the call cannot publish its first result before the
+	// caller has joined, as it is forgotten by the group beforehand.
+	call.mu.Lock()
+	call.subscribers = append(call.subscribers, subscriber)
+	call.mu.Unlock()
+	g.mu.Unlock()
+
+	defer g.leave(key, call)
+	return isShared, subscriber.wait(ctx)
+}
+
+func (g *streamGroup[T]) execute(ctx context.Context, key string, call *streamCall[T], execute func(stream dispatch.Stream[T]) error) {
+	ctx, tracker := dispatch.ContextWithExpirationTracker(ctx)
+	err := execute(dispatch.NewHandlingDispatchStream(ctx, func(result T) error {
+		// Callers arriving from the first result on start a new execution, as they would miss it.
+		call.started.Do(func() {
+			g.forget(key, call)
+		})
+		return call.publish(result)
+	}))
+
+	// Callers arriving from now on start a new execution.
+	g.forget(key, call)
+	call.complete(err, tracker.Earliest())
+	call.cancel()
+}
+
+// leave marks that a caller is no longer receiving results from the call, canceling the
+// execution if it was the last one.
+func (g *streamGroup[T]) leave(key string, call *streamCall[T]) {
+	g.mu.Lock()
+	call.callers--
+	isLast := call.callers == 0
+	if isLast && g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+
+	if isLast {
+		call.cancel()
+	}
+}
+
+func (g *streamGroup[T]) forget(key string, call *streamCall[T]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
+
+// publish sends the result to all the callers still receiving results, waiting for each of them
+// to accept it, so that the execution is not faster than its callers.
+func (c *streamCall[T]) publish(result T) error {
+	c.mu.Lock()
+	subscribers := c.subscribers
+	c.mu.Unlock()
+
+	for _, subscriber := range subscribers {
+		subscriber.publish(result)
+	}
+	return nil
+}
+
+func (c *streamCall[T]) complete(err error, expiresAt *time.Time) {
+	c.mu.Lock()
+	subscribers := c.subscribers
+	c.mu.Unlock()
+
+	for _, subscriber := range subscribers {
+		subscriber.finish(err, expiresAt)
+	}
+}
+
+// publish sends the result to the stream of the subscriber, unless it no longer receives results.
+// A subscriber whose stream fails no longer receives results, and returns the error.
+func (s *streamSubscriber[T]) publish(result T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.finished {
+		return
+	}
+
+	if err := s.stream.Publish(result); err != nil {
+		s.finishLocked(err, nil)
+	}
+}
+
+func (s *streamSubscriber[T]) finish(err error, expiresAt *time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.finished {
+		s.finishLocked(err, expiresAt)
+	}
+}
+
+// finishLocked stops sending results to the subscriber. Must be called with the mutex held.
+func (s *streamSubscriber[T]) finishLocked(err error, expiresAt *time.Time) {
+	s.finished = true
+	s.err = err
+	s.expiresAt = expiresAt
+	close(s.done)
+}
+
+// wait waits until the call completes or the context is canceled, returning the error of the
+// call, if any. Once the call completes, the expiration of its results is recorded in the
+// expiration tracker of the context, if any.
+func (s *streamSubscriber[T]) wait(ctx context.Context) error {
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		// Finishing waits for any result being sent to the stream, which is then no longer used.
+		s.finish(ctx.Err(), nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		dispatch.ObserveExpiration(ctx, s.expiresAt)
+	}
+	return s.err
+}
@@ -47,9 +47,10 @@ func init() {
 	prometheus.MustRegister(dispatchChunkCountHistogram)
 }
 
-// NewConcurrentChecker creates an instance of ConcurrentChecker.
-func NewConcurrentChecker(d dispatch.Check, concurrencyLimit uint16) *ConcurrentChecker {
-	return &ConcurrentChecker{d, concurrencyLimit}
+// NewConcurrentChecker creates an instance of ConcurrentChecker. If materialized is non-nil, it
+// is consulted for checks of materialized permissions before computing them.
+func NewConcurrentChecker(d dispatch.Check, concurrencyLimit uint16, materialized MaterializedPermissions) *ConcurrentChecker {
+	return &ConcurrentChecker{d, concurrencyLimit, materialized}
 }
 
 // ConcurrentChecker exposes a method to perform Check requests, and delegates subproblems to the
@@ -57,6 +58,7 @@ func NewConcurrentChecker(d dispatch.Check, concurrencyLimit uint16) *Concurrent
 type ConcurrentChecker struct {
 	d                dispatch.Check
 	concurrencyLimit uint16
+	materialized     MaterializedPermissions
 }
 
 // ValidatedCheckRequest represents a request after it has been validated and parsed for internal
@@ -195,6 +197,14 @@ func (cc *ConcurrentChecker) checkInternal(ctx context.Context, req ValidatedChe
 		crc.maxDispatchCount = 1
 	}
 
+	// If the permission is materialized, attempt to answer from its precomputed resources. Checks
+	// with debugging enabled are always computed, to ensure a full trace is produced.
+	if cc.materialized != nil && req.Debug == v1.DispatchCheckRequest_NO_DEBUG && nspkg.IsMaterialized(relation) {
+		if result, ok := cc.checkMaterialized(ctx, crc); ok {
+			return combineResultWithFoundResources(result, membershipSet)
+		}
+	}
+
 	if relation.UsersetRewrite == nil {
 		return combineResultWithFoundResources(cc.checkDirect(ctx, crc, relation), membershipSet)
 	}
@@ -590,6 +600,7 @@ func (cc *ConcurrentChecker) checkTupleToUserset(ctx context.Context, crc curren
 
 	subjectsToDispatch := tuple.NewONRByTypeSet()
 	relationshipsBySubjectONR := mapz.NewMultiMap[string, *core.RelationTuple]()
+	relationshipsByResourceID := mapz.NewMultiMap[string, *core.RelationTuple]()
 	for tpl := it.Next(); tpl != nil; tpl = it.Next() {
 		if it.Err() != nil {
 			return checkResultError(NewCheckFailureErr(it.Err()), emptyMetadata)
@@ -597,6 +608,7 @@ func (cc *ConcurrentChecker) checkTupleToUserset(ctx context.Context, crc curren
 
 		subjectsToDispatch.Add(tpl.Subject)
 		relationshipsBySubjectONR.Add(tuple.StringONR(tpl.Subject), tpl)
+		relationshipsByResourceID.Add(tpl.ResourceAndRelation.ObjectId, tpl)
 	}
 	it.Close()
 
@@ -614,6 +626,10 @@ func (cc *ConcurrentChecker) checkTupleToUserset(ctx context.Context, crc curren
 		dispatchChunkCountHistogram.Observe(chunkCount)
 	})
 
+	if ttu.Function == core.TupleToUserset_FUNCTION_ALL {
+		return cc.checkIntersectionTupleToUserset(ctx, crc, ttu, toDispatch, relationshipsByResourceID)
+	}
+
 	return union(
 		ctx,
 		crc,
@@ -630,6 +646,88 @@ func (cc *ConcurrentChecker) checkTupleToUserset(ctx context.Context, crc curren
 	)
 }
 
+// checkIntersectionTupleToUserset checks an arrow over `all`, under which a resource is only a
+// member if the computed userset is found for *every* subject of the resource's tupleset
+// relationships. Resources without any tupleset relationships are never members.
+func (cc *ConcurrentChecker) checkIntersectionTupleToUserset(
+	ctx context.Context,
+	crc currentRequestContext,
+	ttu *core.TupleToUserset,
+	toDispatch []directDispatch,
+	relationshipsByResourceID *mapz.MultiMap[string, *core.RelationTuple],
+) CheckResult {
+	// All subjects must be resolved, so the results for every subject are required.
+	subjectsResult := union(
+		ctx,
+		currentRequestContext{
+			parentReq:           crc.parentReq,
+			filteredResourceIDs: crc.filteredResourceIDs,
+			resultsSetting:      v1.DispatchCheckRequest_REQUIRE_ALL_RESULTS,
+			maxDispatchCount:    crc.maxDispatchCount,
+		},
+		toDispatch,
+		func(ctx context.Context, crc currentRequestContext, dd directDispatch) CheckResult {
+			childResult := cc.checkComputedUserset(ctx, crc, ttu.ComputedUserset, dd.resourceType, dd.resourceIds)
+			if childResult.Err != nil {
+				return childResult
+			}
+
+			return mapFoundSubjects(childResult, dd.resourceType)
+		},
+		cc.concurrencyLimit,
+	)
+	if subjectsResult.Err != nil {
+		return subjectsResult
+	}
+
+	// For each resource, intersect the results found for each of its subjects.
+	membershipSet := NewMembershipSet()
+	for _, resourceID := range relationshipsByResourceID.Keys() {
+		tuples, _ := relationshipsByResourceID.Get(resourceID)
+
+		foundSubjects := NewMembershipSet()
+		isMember := true
+		for _, relationTuple := range tuples {
+			subjectKey := tuple.StringONR(relationTuple.Subject)
+			found, ok := subjectsResult.Resp.ResultsByResourceId[subjectKey]
+			if !ok {
+				isMember = false
+				break
+			}
+
+			foundSubjects.AddMemberViaRelationship(subjectKey, found.Expression, relationTuple)
+		}
+
+		if !isMember {
+			continue
+		}
+
+		var resourceExpression *core.CaveatExpression
+		for _, subjectExpression := range foundSubjects.membersByID {
+			resourceExpression = caveatAnd(resourceExpression, subjectExpression)
+		}
+		membershipSet.addMember(resourceID, resourceExpression)
+	}
+
+	return checkResultsForMembership(membershipSet, subjectsResult.Resp.Metadata)
+}
+
+// mapFoundSubjects re-keys the resources found in the result by their full subject string, to
+// allow for resources of differing types to be distinguished.
+func mapFoundSubjects(result CheckResult, resourceType *core.RelationReference) CheckResult {
+	membershipSet := NewMembershipSet()
+	for foundResourceID, result := range result.Resp.ResultsByResourceId {
+		subjectKey := tuple.StringONR(&core.ObjectAndRelation{
+			Namespace: resourceType.Namespace,
+			ObjectId:  foundResourceID,
+			Relation:  resourceType.Relation,
+		})
+		membershipSet.addMember(subjectKey, result.Expression)
+	}
+
+	return checkResultsForMembership(membershipSet, result.Resp.Metadata)
+}
+
 func withDistinctMetadata(result CheckResult) CheckResult {
 	// NOTE: This is necessary to ensure unique debug information on the request and that debug
 	// information from the child metadata is *not* copied over.
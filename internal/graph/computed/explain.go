@@ -0,0 +1,392 @@
+package computed
+
+import (
+	"context"
+	"fmt"
+
+	cexpr "github.com/authzed/spicedb/internal/caveats"
+	"github.com/authzed/spicedb/internal/dispatch"
+	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
+	"github.com/authzed/spicedb/internal/namespace"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/genutil/mapz"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// ComputeExplanation computes a minimal explanation of why the subject has the permission or
+// relation on the given resource: the chain of relationships, along with any caveats evaluated,
+// that was found to make the subject a member.
+//
+// Returns nil if the subject is not a member of the resource.
+//
+// NOTE: the explanation is computed by walking the schema and relationships directly, using
+// dispatched checks only to decide which branches to follow, so it is considerably more expensive
+// than the check itself and should only be requested for debugging and support tooling.
+func ComputeExplanation(
+	ctx context.Context,
+	d dispatch.Check,
+	params CheckParameters,
+	resourceID string,
+) (*v1.CheckExplanation, error) {
+	e := &explainer{
+		d:      d,
+		params: params,
+		reader: datastoremw.MustFromContext(ctx).SnapshotReader(params.AtRevision),
+	}
+
+	resource := &core.ObjectAndRelation{
+		Namespace: params.ResourceType.Namespace,
+		ObjectId:  resourceID,
+		Relation:  params.ResourceType.Relation,
+	}
+
+	isMember, err := e.isMember(ctx, resource)
+	if err != nil || !isMember {
+		return nil, err
+	}
+
+	return e.explain(ctx, resource, mapz.NewSet[string](), params.MaximumDepth)
+}
+
+type explainer struct {
+	d      dispatch.Check
+	params CheckParameters
+	reader datastore.Reader
+}
+
+// isMember returns whether the subject is a member, possibly conditionally on caveats, of the
+// given resource.
+func (e *explainer) isMember(ctx context.Context, resource *core.ObjectAndRelation) (bool, error) {
+	if tuple.OnrEqual(resource, e.params.Subject) {
+		return true, nil
+	}
+
+	result, _, err := ComputeCheck(ctx, e.d, CheckParameters{
+		ResourceType:  tuple.RelationReference(resource.Namespace, resource.Relation),
+		Subject:       e.params.Subject,
+		CaveatContext: e.params.CaveatContext,
+		AtRevision:    e.params.AtRevision,
+		MaximumDepth:  e.params.MaximumDepth,
+		DebugOption:   NoDebugging,
+	}, resource.ObjectId)
+	if err != nil {
+		return false, err
+	}
+
+	return result.Membership != v1.ResourceCheckResult_NOT_MEMBER, nil
+}
+
+// explain returns the explanation for the subject's membership in the resource, or nil if none
+// could be found. visited holds the resources on the current path, to prevent cycles.
+func (e *explainer) explain(ctx context.Context, resource *core.ObjectAndRelation, visited *mapz.Set[string], depthRemaining uint32) (*v1.CheckExplanation, error) {
+	if tuple.OnrEqual(resource, e.params.Subject) {
+		return &v1.CheckExplanation{Resource: resource}, nil
+	}
+
+	if depthRemaining == 0 {
+		return nil, nil
+	}
+
+	resourceKey := tuple.StringONR(resource)
+	if !visited.Add(resourceKey) {
+		return nil, nil
+	}
+	defer visited.Delete(resourceKey)
+
+	_, relation, err := namespace.ReadNamespaceAndRelation(ctx, resource.Namespace, resource.Relation, e.reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if relation.UsersetRewrite == nil {
+		return e.explainDirect(ctx, resource, visited, depthRemaining)
+	}
+
+	steps, err := e.explainRewrite(ctx, resource, relation.UsersetRewrite, visited, depthRemaining)
+	if err != nil || steps == nil {
+		return nil, err
+	}
+
+	return &v1.CheckExplanation{
+		Resource: resource,
+		Steps:    steps,
+	}, nil
+}
+
+// explainDirect explains membership via a relationship written directly on the resource,
+// preferring a relationship to the subject itself over one to a subject set.
+func (e *explainer) explainDirect(ctx context.Context, resource *core.ObjectAndRelation, visited *mapz.Set[string], depthRemaining uint32) (*v1.CheckExplanation, error) {
+	it, err := e.reader.QueryRelationships(ctx, datastore.RelationshipsFilter{
+		ResourceType:             resource.Namespace,
+		OptionalResourceIds:      []string{resource.ObjectId},
+		OptionalResourceRelation: resource.Relation,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var subjectSets []*core.RelationTuple
+	for tpl := it.Next(); tpl != nil; tpl = it.Next() {
+		if it.Err() != nil {
+			return nil, it.Err()
+		}
+
+		if tuple.OnrEqualOrWildcard(tpl.Subject, e.params.Subject) {
+			caveat, ok, err := e.explainCaveat(ctx, tpl)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return &v1.CheckExplanation{
+					Resource:     resource,
+					Relationship: tpl,
+					Caveat:       caveat,
+				}, nil
+			}
+			continue
+		}
+
+		if tpl.Subject.Relation != tuple.Ellipsis {
+			subjectSets = append(subjectSets, tpl)
+		}
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	it.Close()
+
+	for _, tpl := range subjectSets {
+		step, err := e.explainRelationship(ctx, resource, tpl, tpl.Subject, visited, depthRemaining)
+		if err != nil || step != nil {
+			return step, err
+		}
+	}
+
+	return nil, nil
+}
+
+// explainRelationship explains membership of the target, reached by traversing the given
+// relationship of the resource. Returns nil if the relationship's caveat is false or the subject
+// is not a member of the target.
+func (e *explainer) explainRelationship(
+	ctx context.Context,
+	resource *core.ObjectAndRelation,
+	tpl *core.RelationTuple,
+	target *core.ObjectAndRelation,
+	visited *mapz.Set[string],
+	depthRemaining uint32,
+) (*v1.CheckExplanation, error) {
+	caveat, ok, err := e.explainCaveat(ctx, tpl)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	isMember, err := e.isMember(ctx, target)
+	if err != nil || !isMember {
+		return nil, err
+	}
+
+	step, err := e.explain(ctx, target, visited, depthRemaining-1)
+	if err != nil || step == nil {
+		return nil, err
+	}
+
+	return &v1.CheckExplanation{
+		Resource:     resource,
+		Relationship: tpl,
+		Caveat:       caveat,
+		Steps:        []*v1.CheckExplanation{step},
+	}, nil
+}
+
+// explainRewrite returns the steps that satisfy the rewrite for the resource, or nil if the
+// rewrite is not satisfied.
+func (e *explainer) explainRewrite(ctx context.Context, resource *core.ObjectAndRelation, rewrite *core.UsersetRewrite, visited *mapz.Set[string], depthRemaining uint32) ([]*v1.CheckExplanation, error) {
+	switch rw := rewrite.RewriteOperation.(type) {
+	case *core.UsersetRewrite_Union:
+		for _, child := range rw.Union.Child {
+			steps, err := e.explainSetOperationChild(ctx, resource, child, visited, depthRemaining)
+			if err != nil || steps != nil {
+				return steps, err
+			}
+		}
+		return nil, nil
+
+	case *core.UsersetRewrite_Intersection:
+		var allSteps []*v1.CheckExplanation
+		for _, child := range rw.Intersection.Child {
+			steps, err := e.explainSetOperationChild(ctx, resource, child, visited, depthRemaining)
+			if err != nil || steps == nil {
+				return nil, err
+			}
+			allSteps = append(allSteps, steps...)
+		}
+		return allSteps, nil
+
+	case *core.UsersetRewrite_Exclusion:
+		if len(rw.Exclusion.Child) == 0 {
+			return nil, nil
+		}
+
+		steps, err := e.explainSetOperationChild(ctx, resource, rw.Exclusion.Child[0], visited, depthRemaining)
+		if err != nil || steps == nil {
+			return nil, err
+		}
+
+		for _, child := range rw.Exclusion.Child[1:] {
+			excluded, err := e.explainSetOperationChild(ctx, resource, child, visited, depthRemaining)
+			if err != nil || excluded != nil {
+				return nil, err
+			}
+		}
+		return steps, nil
+
+	default:
+		return nil, fmt.Errorf("unknown userset rewrite operation `%T` in explanation", rw)
+	}
+}
+
+func (e *explainer) explainSetOperationChild(ctx context.Context, resource *core.ObjectAndRelation, child *core.SetOperation_Child, visited *mapz.Set[string], depthRemaining uint32) ([]*v1.CheckExplanation, error) {
+	switch c := child.ChildType.(type) {
+	case *core.SetOperation_Child_XThis:
+		step, err := e.explainDirect(ctx, resource, visited, depthRemaining)
+		return asSteps(step), err
+
+	case *core.SetOperation_Child_ComputedUserset:
+		target := &core.ObjectAndRelation{
+			Namespace: resource.Namespace,
+			ObjectId:  resource.ObjectId,
+			Relation:  c.ComputedUserset.Relation,
+		}
+
+		isMember, err := e.isMember(ctx, target)
+		if err != nil || !isMember {
+			return nil, err
+		}
+
+		step, err := e.explain(ctx, target, visited, depthRemaining-1)
+		return asSteps(step), err
+
+	case *core.SetOperation_Child_UsersetRewrite:
+		return e.explainRewrite(ctx, resource, c.UsersetRewrite, visited, depthRemaining)
+
+	case *core.SetOperation_Child_TupleToUserset:
+		return e.explainTupleToUserset(ctx, resource, c.TupleToUserset, visited, depthRemaining)
+
+	case *core.SetOperation_Child_XNil:
+		return nil, nil
+
+	default:
+		return nil, fmt.Errorf("unknown set operation child `%T` in explanation", c)
+	}
+}
+
+// explainTupleToUserset explains an arrow, returning the step for the first satisfied tupleset
+// relationship or, for an arrow over `all`, the steps for every tupleset relationship.
+func (e *explainer) explainTupleToUserset(ctx context.Context, resource *core.ObjectAndRelation, ttu *core.TupleToUserset, visited *mapz.Set[string], depthRemaining uint32) ([]*v1.CheckExplanation, error) {
+	tuplesetResource := &core.ObjectAndRelation{
+		Namespace: resource.Namespace,
+		ObjectId:  resource.ObjectId,
+		Relation:  ttu.Tupleset.Relation,
+	}
+
+	it, err := e.reader.QueryRelationships(ctx, datastore.RelationshipsFilter{
+		ResourceType:             resource.Namespace,
+		OptionalResourceIds:      []string{resource.ObjectId},
+		OptionalResourceRelation: ttu.Tupleset.Relation,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var tuplesetRelationships []*core.RelationTuple
+	for tpl := it.Next(); tpl != nil; tpl = it.Next() {
+		if it.Err() != nil {
+			return nil, it.Err()
+		}
+		tuplesetRelationships = append(tuplesetRelationships, tpl)
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+	it.Close()
+
+	var steps []*v1.CheckExplanation
+	for _, tpl := range tuplesetRelationships {
+		target := &core.ObjectAndRelation{
+			Namespace: tpl.Subject.Namespace,
+			ObjectId:  tpl.Subject.ObjectId,
+			Relation:  ttu.ComputedUserset.Relation,
+		}
+
+		step, err := e.explainRelationship(ctx, tuplesetResource, tpl, target, visited, depthRemaining)
+		if err != nil {
+			return nil, err
+		}
+
+		if ttu.Function == core.TupleToUserset_FUNCTION_ALL {
+			if step == nil {
+				return nil, nil
+			}
+			steps = append(steps, step)
+			continue
+		}
+
+		if step != nil {
+			return []*v1.CheckExplanation{step}, nil
+		}
+	}
+
+	return steps, nil
+}
+
+// explainCaveat evaluates the caveat on the relationship, if any, returning false if the caveat
+// is known to be false.
+func (e *explainer) explainCaveat(ctx context.Context, tpl *core.RelationTuple) (*v1.CaveatExplanation, bool, error) {
+	if tpl.Caveat == nil || tpl.Caveat.CaveatName == "" {
+		return nil, true, nil
+	}
+
+	result, err := cexpr.RunCaveatExpression(ctx, cexpr.CaveatAsExpr(tpl.Caveat), e.params.CaveatContext, e.reader, cexpr.RunCaveatExpressionWithDebugInformation)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !result.IsPartial() && !result.Value() {
+		return nil, false, nil
+	}
+
+	expression, err := result.ExpressionString()
+	if err != nil {
+		return nil, false, err
+	}
+
+	caveat := &v1.CaveatExplanation{
+		CaveatName: tpl.Caveat.CaveatName,
+		Expression: expression,
+		Result:     v1.CaveatExplanation_TRUE,
+	}
+
+	if result.IsPartial() {
+		missing, err := result.MissingVarNames()
+		if err != nil {
+			return nil, false, err
+		}
+
+		caveat.Result = v1.CaveatExplanation_MISSING_SOME_CONTEXT
+		caveat.MissingRequiredContext = missing
+	}
+
+	return caveat, true, nil
+}
+
+func asSteps(step *v1.CheckExplanation) []*v1.CheckExplanation {
+	if step == nil {
+		return nil
+	}
+	return []*v1.CheckExplanation{step}
+}
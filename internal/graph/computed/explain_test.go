@@ -0,0 +1,195 @@
+package computed_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/internal/dispatch/graph"
+	"github.com/authzed/spicedb/internal/graph/computed"
+	log "github.com/authzed/spicedb/internal/logging"
+	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+func TestComputeExplanation(t *testing.T) {
+	ds, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+
+	dispatch := graph.NewLocalOnlyDispatcher(10)
+	ctx := log.Logger.WithContext(datastoremw.ContextWithHandle(context.Background()))
+	require.NoError(t, datastoremw.SetInContext(ctx, ds))
+
+	revision, err := writeCaveatedTuples(ctx, t, ds, `
+	definition user {}
+
+	caveat somecaveat(somecondition int) {
+		somecondition == 42
+	}
+
+	definition group {
+		relation member: user | user with somecaveat | group#member
+	}
+
+	definition folder {
+		relation viewer: user | group#member
+		permission view = viewer
+	}
+
+	definition document {
+		relation parent: folder
+		relation viewer: user | user:* | group#member
+		relation banned: user
+		relation approver: group
+		permission view = (viewer + parent->view) - banned
+		permission approve = approver.all(member)
+	}
+	`, []caveatedUpdate{
+		{core.RelationTupleUpdate_CREATE, "group:eng#member@user:tom", "", nil},
+		{core.RelationTupleUpdate_CREATE, "group:eng#member@user:jill", "somecaveat", nil},
+		{core.RelationTupleUpdate_CREATE, "group:eng#member@user:fred", "somecaveat", map[string]any{"somecondition": "41"}},
+		{core.RelationTupleUpdate_CREATE, "group:all#member@group:eng#member", "", nil},
+		{core.RelationTupleUpdate_CREATE, "group:leads#member@user:tom", "", nil},
+		{core.RelationTupleUpdate_CREATE, "folder:shared#viewer@group:all#member", "", nil},
+		{core.RelationTupleUpdate_CREATE, "document:direct#viewer@user:tom", "", nil},
+		{core.RelationTupleUpdate_CREATE, "document:direct#viewer@group:eng#member", "", nil},
+		{core.RelationTupleUpdate_CREATE, "document:public#viewer@user:*", "", nil},
+		{core.RelationTupleUpdate_CREATE, "document:nested#parent@folder:shared", "", nil},
+		{core.RelationTupleUpdate_CREATE, "document:nested#banned@user:sarah", "", nil},
+		{core.RelationTupleUpdate_CREATE, "document:banned#viewer@user:tom", "", nil},
+		{core.RelationTupleUpdate_CREATE, "document:banned#banned@user:tom", "", nil},
+		{core.RelationTupleUpdate_CREATE, "document:approval#approver@group:eng", "", nil},
+		{core.RelationTupleUpdate_CREATE, "document:approval#approver@group:leads", "", nil},
+	})
+	require.NoError(t, err)
+
+	tcs := []struct {
+		check       string
+		context     map[string]any
+		explanation string
+	}{
+		{
+			"document:direct#view@user:tom",
+			nil,
+			"document:direct#view[document:direct#viewer(document:direct#viewer@user:tom)]",
+		},
+		{
+			"document:public#view@user:sarah",
+			nil,
+			"document:public#view[document:public#viewer(document:public#viewer@user:*)]",
+		},
+		{
+			"document:nested#view@user:tom",
+			nil,
+			"document:nested#view[document:nested#parent(document:nested#parent@folder:shared)[" +
+				"folder:shared#view[folder:shared#viewer(folder:shared#viewer@group:all#member)[" +
+				"group:all#member(group:all#member@group:eng#member)[" +
+				"group:eng#member(group:eng#member@user:tom)]]]]]",
+		},
+		{
+			"document:nested#view@user:jill",
+			nil,
+			"document:nested#view[document:nested#parent(document:nested#parent@folder:shared)[" +
+				"folder:shared#view[folder:shared#viewer(folder:shared#viewer@group:all#member)[" +
+				"group:all#member(group:all#member@group:eng#member)[" +
+				"group:eng#member(group:eng#member@user:jill[somecaveat]){somecaveat:MISSING_SOME_CONTEXT:somecondition}]]]]]",
+		},
+		{
+			"document:direct#view@user:jill",
+			map[string]any{"somecondition": "42"},
+			"document:direct#view[document:direct#viewer(document:direct#viewer@group:eng#member)[" +
+				"group:eng#member(group:eng#member@user:jill[somecaveat]){somecaveat:TRUE}]]",
+		},
+		{
+			"document:direct#view@user:fred",
+			nil,
+			"",
+		},
+		{
+			"document:nested#view@user:sarah",
+			nil,
+			"",
+		},
+		{
+			"document:banned#view@user:tom",
+			nil,
+			"",
+		},
+		{
+			"document:approval#approve@user:tom",
+			nil,
+			"document:approval#approve[" +
+				"document:approval#approver(document:approval#approver@group:eng)[group:eng#member(group:eng#member@user:tom)] " +
+				"document:approval#approver(document:approval#approver@group:leads)[group:leads#member(group:leads#member@user:tom)]]",
+		},
+		{
+			"document:approval#approve@user:jill",
+			map[string]any{"somecondition": "42"},
+			"",
+		},
+		{
+			"group:eng#member@group:eng#member",
+			nil,
+			"group:eng#member",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(fmt.Sprintf("%s::%v", tc.check, tc.context), func(t *testing.T) {
+			rel := tuple.MustParse(tc.check)
+
+			explanation, err := computed.ComputeExplanation(ctx, dispatch,
+				computed.CheckParameters{
+					ResourceType: &core.RelationReference{
+						Namespace: rel.ResourceAndRelation.Namespace,
+						Relation:  rel.ResourceAndRelation.Relation,
+					},
+					Subject:       rel.Subject,
+					CaveatContext: tc.context,
+					AtRevision:    revision,
+					MaximumDepth:  50,
+				},
+				rel.ResourceAndRelation.ObjectId,
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.explanation, formatExplanation(explanation))
+		})
+	}
+}
+
+func formatExplanation(explanation *v1.CheckExplanation) string {
+	if explanation == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(tuple.StringONR(explanation.Resource))
+	if explanation.Relationship != nil {
+		sb.WriteString("(" + tuple.MustString(explanation.Relationship) + ")")
+	}
+
+	if explanation.Caveat != nil {
+		sb.WriteString("{" + explanation.Caveat.CaveatName + ":" + explanation.Caveat.Result.String())
+		if len(explanation.Caveat.MissingRequiredContext) > 0 {
+			sb.WriteString(":" + strings.Join(explanation.Caveat.MissingRequiredContext, ","))
+		}
+		sb.WriteString("}")
+	}
+
+	if len(explanation.Steps) > 0 {
+		steps := make([]string, 0, len(explanation.Steps))
+		for _, step := range explanation.Steps {
+			steps = append(steps, formatExplanation(step))
+		}
+		sb.WriteString("[" + strings.Join(steps, " ") + "]")
+	}
+
+	return sb.String()
+}
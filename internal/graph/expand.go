@@ -279,6 +279,17 @@ func (ce *ConcurrentExpander) expandTupleToUserset(_ context.Context, req Valida
 		}
 		it.Close()
 
+		if ttu.Function == core.TupleToUserset_FUNCTION_ALL {
+			// An arrow over `all` without any tupleset relationships has no members.
+			if len(requestsToDispatch) == 0 {
+				emptyExpansion(req.ResourceAndRelation)(ctx, resultChan)
+				return
+			}
+
+			resultChan <- expandAll(ctx, req.ResourceAndRelation, requestsToDispatch)
+			return
+		}
+
 		resultChan <- expandAny(ctx, req.ResourceAndRelation, requestsToDispatch)
 	}
 }
@@ -11,9 +11,10 @@ import (
 	"github.com/authzed/spicedb/pkg/tuple"
 )
 
-// NewCursoredLookupResources creates and instance of CursoredLookupResources.
-func NewCursoredLookupResources(c dispatch.Check, r dispatch.ReachableResources, concurrencyLimit uint16) *CursoredLookupResources {
-	return &CursoredLookupResources{c, r, concurrencyLimit}
+// NewCursoredLookupResources creates and instance of CursoredLookupResources. If materialized is
+// non-nil, it is consulted for unlimited lookups of materialized permissions before computing them.
+func NewCursoredLookupResources(c dispatch.Check, r dispatch.ReachableResources, concurrencyLimit uint16, materialized MaterializedPermissions) *CursoredLookupResources {
+	return &CursoredLookupResources{c, r, concurrencyLimit, materialized}
 }
 
 // CursoredLookupResources exposes a method to perform LookupResources requests, and delegates subproblems to the
@@ -22,6 +23,7 @@ type CursoredLookupResources struct {
 	c                dispatch.Check
 	r                dispatch.ReachableResources
 	concurrencyLimit uint16
+	materialized     MaterializedPermissions
 }
 
 // ValidatedLookupResourcesRequest represents a request after it has been validated and parsed for internal
@@ -39,6 +41,17 @@ func (cl *CursoredLookupResources) LookupResources(
 		return NewErrInvalidArgument(errors.New("cannot perform lookup resources on wildcard"))
 	}
 
+	// Materialized permissions are used for lookups that are not resuming from a cursor of a
+	// computed lookup. A lookup resuming from a cursor of a materialized lookup must continue
+	// with the same ordering of resources, even if the permission is no longer materialized.
+	after, resuming := materializedCursorPosition(req.OptionalCursor)
+	if resuming || (cl.materialized != nil && req.OptionalCursor == nil) {
+		handled, err := cl.lookupMaterialized(req, after, resuming, parentStream)
+		if handled || err != nil {
+			return err
+		}
+	}
+
 	lookupContext := parentStream.Context()
 	limits := newLimitTracker(req.OptionalLimit)
 	reachableResourcesCursor := req.OptionalCursor
@@ -184,6 +184,7 @@ func (cl *ConcurrentLookupSubjects) lookupViaComputed(
 		Metadata: &v1.ResolverMeta{
 			AtRevision:     parentRequest.Revision.String(),
 			DepthRemaining: parentRequest.Metadata.DepthRemaining - 1,
+			TraversalBloom: parentRequest.Metadata.TraversalBloom,
 		},
 	}, stream)
 }
@@ -207,6 +208,7 @@ func (cl *ConcurrentLookupSubjects) lookupViaTupleToUserset(
 
 	toDispatchByTuplesetType := datasets.NewSubjectByTypeSet()
 	relationshipsBySubjectONR := mapz.NewMultiMap[string, *core.RelationTuple]()
+	relationshipsByResourceID := mapz.NewMultiMap[string, *core.RelationTuple]()
 	for tpl := it.Next(); tpl != nil; tpl = it.Next() {
 		if it.Err() != nil {
 			return it.Err()
@@ -225,6 +227,7 @@ func (cl *ConcurrentLookupSubjects) lookupViaTupleToUserset(
 			ObjectId:  tpl.Subject.ObjectId,
 			Relation:  ttu.ComputedUserset.Relation,
 		}), tpl)
+		relationshipsByResourceID.Add(tpl.ResourceAndRelation.ObjectId, tpl)
 	}
 	it.Close()
 
@@ -247,9 +250,179 @@ func (cl *ConcurrentLookupSubjects) lookupViaTupleToUserset(
 		return err
 	}
 
+	if ttu.Function == core.TupleToUserset_FUNCTION_ALL {
+		return cl.dispatchToIntersection(ctx, parentRequest, toDispatchByComputedRelationType, relationshipsByResourceID, ttu.ComputedUserset.Relation, parentStream)
+	}
+
 	return cl.dispatchTo(ctx, parentRequest, toDispatchByComputedRelationType, relationshipsBySubjectONR, parentStream)
 }
 
+// dispatchToIntersection dispatches to the subjects of an arrow over `all`, returning for
+// each resource only those subjects found for *every* subject of its tupleset relationships.
+func (cl *ConcurrentLookupSubjects) dispatchToIntersection(
+	ctx context.Context,
+	parentRequest ValidatedLookupSubjectsRequest,
+	toDispatchByType *datasets.SubjectByTypeSet,
+	relationshipsByResourceID *mapz.MultiMap[string, *core.RelationTuple],
+	computedRelation string,
+	parentStream dispatch.LookupSubjectsStream,
+) error {
+	if toDispatchByType.IsEmpty() {
+		return nil
+	}
+
+	cancelCtx, checkCancel := context.WithCancel(ctx)
+	defer checkCancel()
+
+	g, subCtx := errgroup.WithContext(cancelCtx)
+	g.SetLimit(int(cl.concurrencyLimit))
+
+	// Collect the subjects found for each tupleset subject, keyed by the full subject string
+	// to distinguish subjects of different types.
+	collector := dispatch.NewCollectingDispatchStream[*v1.DispatchLookupSubjectsResponse](subCtx)
+	toDispatchByType.ForEachType(func(resourceType *core.RelationReference, foundSubjects datasets.SubjectSet) {
+		slice := foundSubjects.AsSlice()
+		resourceIds := make([]string, 0, len(slice))
+		for _, foundSubject := range slice {
+			resourceIds = append(resourceIds, foundSubject.SubjectId)
+		}
+
+		stream := &dispatch.WrappedDispatchStream[*v1.DispatchLookupSubjectsResponse]{
+			Stream: collector,
+			Ctx:    subCtx,
+			Processor: func(result *v1.DispatchLookupSubjectsResponse) (*v1.DispatchLookupSubjectsResponse, bool, error) {
+				mappedFoundSubjects := make(map[string]*v1.FoundSubjects, len(result.FoundSubjectsByResourceId))
+				for childResourceID, foundSubjects := range result.FoundSubjectsByResourceId {
+					mappedFoundSubjects[tuple.StringONR(&core.ObjectAndRelation{
+						Namespace: resourceType.Namespace,
+						ObjectId:  childResourceID,
+						Relation:  resourceType.Relation,
+					})] = foundSubjects
+				}
+
+				return &v1.DispatchLookupSubjectsResponse{
+					FoundSubjectsByResourceId: mappedFoundSubjects,
+					Metadata:                  addCallToResponseMetadata(result.Metadata),
+				}, true, nil
+			},
+		}
+
+		slicez.ForEachChunk(resourceIds, maxDispatchChunkSize, func(resourceIdChunk []string) {
+			g.Go(func() error {
+				return cl.d.DispatchLookupSubjects(&v1.DispatchLookupSubjectsRequest{
+					ResourceRelation: resourceType,
+					ResourceIds:      resourceIdChunk,
+					SubjectRelation:  parentRequest.SubjectRelation,
+					Metadata: &v1.ResolverMeta{
+						AtRevision:     parentRequest.Revision.String(),
+						DepthRemaining: parentRequest.Metadata.DepthRemaining - 1,
+						TraversalBloom: parentRequest.Metadata.TraversalBloom,
+					},
+				}, stream)
+			})
+		})
+	})
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	metadata := emptyMetadata
+	collected := datasets.NewSubjectSetByResourceID()
+	for _, result := range collector.Results() {
+		metadata = combineResponseMetadata(metadata, result.Metadata)
+		if err := collected.UnionWith(result.FoundSubjectsByResourceId); err != nil {
+			return fmt.Errorf("failed to UnionWith under dispatchToIntersection: %w", err)
+		}
+	}
+	foundBySubjectKey := collected.AsMap()
+
+	// For each resource, intersect the subjects found via each of its tupleset relationships. If
+	// any tupleset subject has no subjects found, then neither does the resource.
+	mappedFoundSubjects := make(map[string]*v1.FoundSubjects)
+	for _, resourceID := range relationshipsByResourceID.Keys() {
+		relationships, _ := relationshipsByResourceID.Get(resourceID)
+		resourceSubjects, err := intersectFoundSubjects(relationships, foundBySubjectKey, computedRelation)
+		if err != nil {
+			return err
+		}
+
+		if resourceSubjects != nil && !resourceSubjects.IsEmpty() {
+			mappedFoundSubjects[resourceID] = resourceSubjects.AsFoundSubjects()
+		}
+	}
+
+	if len(mappedFoundSubjects) == 0 {
+		return nil
+	}
+
+	return parentStream.Publish(&v1.DispatchLookupSubjectsResponse{
+		FoundSubjectsByResourceId: mappedFoundSubjects,
+		Metadata:                  metadata,
+	})
+}
+
+// intersectFoundSubjects returns the intersection of the subjects found for each subject of the
+// given relationships, with each relationship's caveat applied, or nil if any subject has none.
+func intersectFoundSubjects(
+	relationships []*core.RelationTuple,
+	foundBySubjectKey map[string]*v1.FoundSubjects,
+	computedRelation string,
+) (*datasets.SubjectSet, error) {
+	// Multiple relationships to the same subject are unioned together first.
+	subjectSetsByKey := make(map[string]datasets.SubjectSet, len(relationships))
+	for _, relationship := range relationships {
+		subjectKey := tuple.StringONR(&core.ObjectAndRelation{
+			Namespace: relationship.Subject.Namespace,
+			ObjectId:  relationship.Subject.ObjectId,
+			Relation:  computedRelation,
+		})
+
+		foundSubjects, ok := foundBySubjectKey[subjectKey]
+		if !ok {
+			return nil, nil
+		}
+
+		subjectSet := datasets.NewSubjectSet()
+		if err := subjectSet.UnionWith(foundSubjects.FoundSubjects); err != nil {
+			return nil, fmt.Errorf("could not combine subject sets: %w", err)
+		}
+
+		if relationship.GetCaveat() != nil {
+			subjectSet = subjectSet.WithParentCaveatExpression(wrapCaveat(relationship.Caveat))
+		}
+
+		existing, ok := subjectSetsByKey[subjectKey]
+		if !ok {
+			subjectSetsByKey[subjectKey] = subjectSet
+			continue
+		}
+
+		if err := existing.UnionWithSet(subjectSet); err != nil {
+			return nil, fmt.Errorf("could not combine subject sets: %w", err)
+		}
+	}
+
+	var intersection *datasets.SubjectSet
+	for _, subjectSet := range subjectSetsByKey {
+		subjectSet := subjectSet
+		if intersection == nil {
+			intersection = &subjectSet
+			continue
+		}
+
+		if err := intersection.IntersectionDifference(subjectSet); err != nil {
+			return nil, err
+		}
+
+		if intersection.IsEmpty() {
+			return nil, nil
+		}
+	}
+
+	return intersection, nil
+}
+
 func (cl *ConcurrentLookupSubjects) lookupViaRewrite(
 	ctx context.Context,
 	req ValidatedLookupSubjectsRequest,
@@ -426,6 +599,7 @@ func (cl *ConcurrentLookupSubjects) dispatchTo(
 					Metadata: &v1.ResolverMeta{
 						AtRevision:     parentRequest.Revision.String(),
 						DepthRemaining: parentRequest.Metadata.DepthRemaining - 1,
+						TraversalBloom: parentRequest.Metadata.TraversalBloom,
 					},
 				}, stream)
 			})
@@ -0,0 +1,180 @@
+package graph
+
+import (
+	"context"
+	"sort"
+
+	"github.com/authzed/spicedb/internal/dispatch"
+	"github.com/authzed/spicedb/pkg/datastore"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+// MaterializedPermissions provides access to the precomputed resources for permissions which
+// have been marked as `materialized` in the schema.
+//
+// Implementations return false whenever they cannot answer authoritatively for the requested
+// revision, subject or permission, in which case the caller falls back to computing the
+// permission by walking the graph.
+type MaterializedPermissions interface {
+	// CheckMembers returns those resource IDs, out of the given resource IDs, on which the subject
+	// has the permission at the given revision.
+	CheckMembers(
+		ctx context.Context,
+		revision datastore.Revision,
+		resourceRelation *core.RelationReference,
+		resourceIDs []string,
+		subject *core.ObjectAndRelation,
+	) ([]string, bool)
+
+	// LookupResources returns all resource IDs on which the subject has the permission at the
+	// given revision, in order of ID.
+	LookupResources(
+		ctx context.Context,
+		revision datastore.Revision,
+		resourceRelation *core.RelationReference,
+		subject *core.ObjectAndRelation,
+	) ([]string, bool)
+}
+
+// checkMaterialized attempts to answer the check using the materialized permissions, returning
+// false if the check must be computed.
+func (cc *ConcurrentChecker) checkMaterialized(ctx context.Context, crc currentRequestContext) (CheckResult, bool) {
+	members, ok := cc.materialized.CheckMembers(
+		ctx,
+		crc.parentReq.Revision,
+		crc.parentReq.ResourceRelation,
+		crc.filteredResourceIDs,
+		crc.parentReq.Subject,
+	)
+	if !ok {
+		return CheckResult{}, false
+	}
+
+	membershipSet := NewMembershipSet()
+	for _, resourceID := range members {
+		membershipSet.AddDirectMember(resourceID, nil)
+	}
+
+	return checkResultsForMembership(membershipSet, emptyMetadata), true
+}
+
+// materializedCursorSection is the first section of the cursors published with materialized
+// resources, followed by the ID of the resource published. As resource IDs cannot contain `$`, it
+// is never the first section of the cursor of a computed lookup.
+const materializedCursorSection = "$materialized"
+
+// materializedCursorPosition returns the ID of the resource after which the lookup resumes, if
+// the cursor was published with materialized resources.
+func materializedCursorPosition(cursor *v1.Cursor) (string, bool) {
+	if cursor == nil || len(cursor.Sections) != 2 || cursor.Sections[0] != materializedCursorSection {
+		return "", false
+	}
+	return cursor.Sections[1], true
+}
+
+// lookupMaterialized attempts to answer the lookup using the materialized permissions, returning
+// false if the lookup must be computed.
+//
+// Materialized resources are published in order of ID, with a cursor holding the ID of the
+// resource, so that the lookup can resume after it. If the permission can no longer be answered
+// when resuming, the resources are computed in full and published in the same order.
+func (cl *CursoredLookupResources) lookupMaterialized(
+	req ValidatedLookupResourcesRequest,
+	after string,
+	resuming bool,
+	parentStream dispatch.LookupResourcesStream,
+) (bool, error) {
+	var resourceIDs []string
+	ok := false
+	if cl.materialized != nil {
+		resourceIDs, ok = cl.materialized.LookupResources(
+			parentStream.Context(),
+			req.Revision,
+			req.ObjectRelation,
+			req.Subject,
+		)
+	}
+
+	if !ok {
+		if !resuming {
+			return false, nil
+		}
+		return true, cl.lookupOrderedAfter(req, after, parentStream)
+	}
+
+	resources := make([]*v1.DispatchLookupResourcesResponse, 0, len(resourceIDs))
+	for _, resourceID := range resourceIDs {
+		resources = append(resources, &v1.DispatchLookupResourcesResponse{
+			ResolvedResource: &v1.ResolvedResource{
+				ResourceId:     resourceID,
+				Permissionship: v1.ResolvedResource_HAS_PERMISSION,
+			},
+			Metadata: emptyMetadata,
+		})
+	}
+
+	return true, publishOrderedAfter(resources, after, resuming, req.OptionalLimit, parentStream)
+}
+
+// lookupOrderedAfter computes all resources of the lookup, publishing those after the given
+// resource ID in order of ID.
+func (cl *CursoredLookupResources) lookupOrderedAfter(
+	req ValidatedLookupResourcesRequest,
+	after string,
+	parentStream dispatch.LookupResourcesStream,
+) error {
+	fullReq := ValidatedLookupResourcesRequest{
+		DispatchLookupResourcesRequest: req.DispatchLookupResourcesRequest.CloneVT(),
+		Revision:                       req.Revision,
+	}
+	fullReq.OptionalCursor = nil
+	fullReq.OptionalLimit = 0
+
+	stream := dispatch.NewCollectingDispatchStream[*v1.DispatchLookupResourcesResponse](parentStream.Context())
+	if err := cl.LookupResources(fullReq, stream); err != nil {
+		return err
+	}
+
+	resources := stream.Results()
+	sort.SliceStable(resources, func(i, j int) bool {
+		return resources[i].ResolvedResource.ResourceId < resources[j].ResolvedResource.ResourceId
+	})
+
+	return publishOrderedAfter(resources, after, true, req.OptionalLimit, parentStream)
+}
+
+// publishOrderedAfter publishes the resources, which must be ordered by ID, following the given
+// resource ID if resuming, up to the limit if any.
+func publishOrderedAfter(
+	resources []*v1.DispatchLookupResourcesResponse,
+	after string,
+	resuming bool,
+	limit uint32,
+	parentStream dispatch.LookupResourcesStream,
+) error {
+	start := 0
+	if resuming {
+		start = sort.Search(len(resources), func(i int) bool {
+			return resources[i].ResolvedResource.ResourceId > after
+		})
+	}
+
+	var published uint32
+	for _, resource := range resources[start:] {
+		if limit > 0 && published >= limit {
+			return nil
+		}
+
+		resource.AfterResponseCursor = &v1.Cursor{
+			DispatchVersion: dispatchVersion,
+			Sections:        []string{materializedCursorSection, resource.ResolvedResource.ResourceId},
+		}
+		if err := parentStream.Publish(resource); err != nil {
+			return err
+		}
+		published++
+	}
+
+	return nil
+}
@@ -549,6 +549,7 @@ func (crr *CursoredReachableResources) redispatchOrReport(
 				Metadata: &v1.ResolverMeta{
 					AtRevision:     parentRequest.Revision.String(),
 					DepthRemaining: parentRequest.Metadata.DepthRemaining - 1,
+					TraversalBloom: parentRequest.Metadata.TraversalBloom,
 				},
 				OptionalCursor: ci.currentCursor,
 				OptionalLimit:  ci.limits.currentLimit,
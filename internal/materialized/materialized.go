@@ -0,0 +1,597 @@
+// Package materialized maintains precomputed resources for the permissions marked as
+// `materialized` in the schema, allowing checks and lookups of those permissions to be answered
+// without walking the permissions graph.
+package materialized
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/authzed/spicedb/internal/dispatch"
+	"github.com/authzed/spicedb/internal/dispatch/graph"
+	log "github.com/authzed/spicedb/internal/logging"
+	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/datastore/options"
+	"github.com/authzed/spicedb/pkg/genutil/mapz"
+	nspkg "github.com/authzed/spicedb/pkg/namespace"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+const (
+	// lookupChunkSize is the number of resource IDs given to each lookup subjects dispatch
+	// when computing a materialized permission.
+	lookupChunkSize = 100
+
+	// watchRestartDelay is the delay before the watch is restarted after an error.
+	watchRestartDelay = 5 * time.Second
+
+	// refreshInterval is the interval at which stale permissions are recomputed, even if no
+	// change has been signaled. This ensures failed computations are eventually retried.
+	refreshInterval = 30 * time.Second
+
+	// maxChangedRelationships is the number of changed relationships from which a permission is
+	// updated, beyond which it is recomputed in full.
+	maxChangedRelationships = 1000
+)
+
+// Manager maintains the resources for each materialized permission, by computing them via lookup
+// subjects and updating them whenever a relationship that might affect them is changed: only the
+// resources which might depend on the changed relationships are recomputed.
+//
+// A permission is only answered for revisions at or after the revision at which it was computed
+// and at or before the latest revision observed by the watch; all other requests fall back to
+// computing the permission.
+type Manager struct {
+	ds           datastore.Datastore
+	dispatcher   dispatch.Dispatcher
+	maximumDepth uint32
+	refresh      chan struct{}
+
+	lock sync.RWMutex
+
+	// observed is the latest revision at which all changes have been observed by the watch.
+	observed datastore.Revision
+
+	// schemaChanged indicates that the schema has changed and the set of materialized
+	// permissions must be reloaded.
+	schemaChanged bool
+
+	// permissions holds the state of each materialized permission, keyed by its relation
+	// reference string.
+	permissions map[string]*permissionState
+}
+
+var _ graph.MaterializedPermissions = &Manager{}
+
+// NewManager creates a new Manager for the given datastore. The Manager does nothing until
+// Start is called.
+func NewManager(ds datastore.Datastore, concurrencyLimit uint16, maximumDepth uint32) *Manager {
+	return &Manager{
+		ds:            ds,
+		dispatcher:    graph.NewLocalOnlyDispatcher(concurrencyLimit),
+		maximumDepth:  maximumDepth,
+		refresh:       make(chan struct{}, 1),
+		schemaChanged: true,
+		permissions:   map[string]*permissionState{},
+	}
+}
+
+// Start watches the datastore and computes the materialized permissions until the context is
+// canceled.
+func (m *Manager) Start(ctx context.Context) error {
+	go m.runRefresh(ctx)
+
+	for {
+		err := m.watch(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		log.Ctx(ctx).Warn().Err(err).Msg("materialized permissions watch failed; restarting")
+		m.invalidateAll()
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(watchRestartDelay):
+		}
+	}
+}
+
+// CheckMembers implements graph.MaterializedPermissions.
+func (m *Manager) CheckMembers(
+	ctx context.Context,
+	revision datastore.Revision,
+	resourceRelation *core.RelationReference,
+	resourceIDs []string,
+	subject *core.ObjectAndRelation,
+) ([]string, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	members, ok := m.membersFor(ctx, revision, resourceRelation, subject)
+	if !ok {
+		return nil, false
+	}
+
+	found := make([]string, 0, len(resourceIDs))
+	for _, resourceID := range resourceIDs {
+		if members.Has(resourceID) {
+			found = append(found, resourceID)
+		}
+	}
+	return found, true
+}
+
+// LookupResources implements graph.MaterializedPermissions.
+func (m *Manager) LookupResources(
+	ctx context.Context,
+	revision datastore.Revision,
+	resourceRelation *core.RelationReference,
+	subject *core.ObjectAndRelation,
+) ([]string, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	members, ok := m.membersFor(ctx, revision, resourceRelation, subject)
+	if !ok {
+		return nil, false
+	}
+
+	found := members.AsSlice()
+	sort.Strings(found)
+	return found, true
+}
+
+// membersFor returns the resources on which the subject has the permission, if they can be
+// answered from the materialized state, recording the expiration of the answer in the expiration
+// tracker of the context, if any. Must be called with the lock held.
+func (m *Manager) membersFor(ctx context.Context, revision datastore.Revision, resourceRelation *core.RelationReference, subject *core.ObjectAndRelation) (*mapz.Set[string], bool) {
+	if m.observed == nil || revision.GreaterThan(m.observed) {
+		return nil, false
+	}
+
+	state, ok := m.permissions[tuple.StringRR(resourceRelation)]
+	if !ok || state.stale || state.computed == nil {
+		return nil, false
+	}
+
+	members, ok := state.computed.membersFor(revision, subject, time.Now())
+	if ok {
+		dispatch.ObserveExpiration(ctx, state.computed.expiresAt)
+	}
+	return members, ok
+}
+
+func (m *Manager) watch(ctx context.Context) error {
+	headRevision, err := m.ds.HeadRevision(ctx)
+	if err != nil {
+		return err
+	}
+
+	changes, errs := m.ds.Watch(ctx, headRevision, datastore.WatchOptions{
+		Content: datastore.WatchRelationships | datastore.WatchSchema | datastore.WatchCheckpoints,
+	})
+
+	// All changes after the head revision will be received from the watch, so any permission
+	// computed after this point is valid until a relevant change is observed.
+	m.lock.Lock()
+	m.observed = headRevision
+	m.lock.Unlock()
+	m.signalRefresh()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case change, ok := <-changes:
+			if !ok {
+				return errors.New("watch closed")
+			}
+			m.applyChange(change)
+
+		case err := <-errs:
+			return err
+		}
+	}
+}
+
+// applyChange records the change on any materialized permissions it might affect, marking them
+// as stale, or advances the observed revision for a checkpoint.
+func (m *Manager) applyChange(change *datastore.RevisionChanges) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if change.IsCheckpoint {
+		if change.Revision.GreaterThan(m.observed) {
+			m.observed = change.Revision
+		}
+		return
+	}
+
+	if len(change.ChangedDefinitions) > 0 || len(change.DeletedNamespaces) > 0 || len(change.DeletedCaveats) > 0 {
+		m.schemaChanged = true
+		for _, state := range m.permissions {
+			state.invalidate()
+		}
+		m.signalRefresh()
+		return
+	}
+
+	changed := false
+	for _, update := range change.RelationshipChanges {
+		for _, state := range m.permissions {
+			if state.isRelevant(update.Tuple) {
+				state.addChange(update.Tuple)
+				changed = true
+			}
+		}
+	}
+
+	if changed {
+		m.signalRefresh()
+	}
+}
+
+func (m *Manager) invalidateAll() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.observed = nil
+	m.schemaChanged = true
+	for _, state := range m.permissions {
+		state.invalidate()
+	}
+}
+
+func (m *Manager) signalRefresh() {
+	select {
+	case m.refresh <- struct{}{}:
+	default:
+	}
+}
+
+func (m *Manager) runRefresh(ctx context.Context) {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.refresh:
+		case <-ticker.C:
+		}
+
+		if err := m.refreshStale(ctx); err != nil && ctx.Err() == nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to compute materialized permissions")
+		}
+	}
+}
+
+// refreshStale reloads the materialized permissions if the schema has changed and recomputes
+// every permission that is stale.
+func (m *Manager) refreshStale(ctx context.Context) error {
+	m.lock.RLock()
+	watching := m.observed != nil
+	schemaChanged := m.schemaChanged
+	m.lock.RUnlock()
+
+	if !watching {
+		return nil
+	}
+
+	if schemaChanged {
+		if err := m.reloadSchema(ctx); err != nil {
+			return err
+		}
+	}
+
+	// NOTE: a permission depending on an expired relationship is recomputed in full, as the
+	// expiration is not observed by the watch.
+	now := time.Now()
+	m.lock.Lock()
+	stale := make([]*permissionState, 0, len(m.permissions))
+	for _, state := range m.permissions {
+		if state.computed != nil && state.computed.expiresAt != nil && !now.Before(*state.computed.expiresAt) {
+			state.invalidate()
+		}
+
+		if state.stale {
+			stale = append(stale, state)
+		}
+	}
+	m.lock.Unlock()
+
+	for _, state := range stale {
+		if err := m.recompute(ctx, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reloadSchema finds all materialized permissions in the schema at the head revision.
+func (m *Manager) reloadSchema(ctx context.Context) error {
+	// NOTE: the flag is cleared before reading, so that any schema change observed while the
+	// schema is being read causes another reload.
+	m.lock.Lock()
+	m.schemaChanged = false
+	m.lock.Unlock()
+
+	headRevision, err := m.ds.HeadRevision(ctx)
+	if err != nil {
+		return m.failReload(err)
+	}
+
+	namespaces, err := m.ds.SnapshotReader(headRevision).ListAllNamespaces(ctx)
+	if err != nil {
+		return m.failReload(err)
+	}
+
+	definitions := make(map[string]*core.NamespaceDefinition, len(namespaces))
+	for _, ns := range namespaces {
+		definitions[ns.Definition.Name] = ns.Definition
+	}
+
+	permissions := map[string]*permissionState{}
+	for _, ns := range namespaces {
+		for _, relation := range ns.Definition.Relation {
+			if !nspkg.IsMaterialized(relation) {
+				continue
+			}
+
+			state := newPermissionState(ns.Definition.Name, relation.Name, definitions)
+			permissions[tuple.StringRR(state.resourceRelation)] = state
+		}
+	}
+
+	m.lock.Lock()
+	m.permissions = permissions
+	m.lock.Unlock()
+
+	log.Ctx(ctx).Info().Int("count", len(permissions)).Msg("loaded materialized permissions")
+	return nil
+}
+
+func (m *Manager) failReload(err error) error {
+	m.lock.Lock()
+	m.schemaChanged = true
+	m.lock.Unlock()
+	return err
+}
+
+// recompute computes the resources of the given permission at the head revision, installing
+// them only if no relevant change was observed while they were being computed. Unless a full
+// recomputation is required, only the resources which might depend on the relationships changed
+// since the permission was last computed are recomputed.
+func (m *Manager) recompute(ctx context.Context, state *permissionState) error {
+	// NOTE: the generation must be read before the head revision, so that any change made after
+	// the head revision is guaranteed to increment the generation.
+	m.lock.RLock()
+	generation := state.generation
+	changed := state.changed
+	isFull := state.computed == nil || state.requiresFullRecompute
+	m.lock.RUnlock()
+
+	headRevision, err := m.ds.HeadRevision(ctx)
+	if err != nil {
+		return err
+	}
+
+	var computed *computedPermission
+	var affected *mapz.Set[string]
+	if isFull {
+		computed, err = m.compute(ctx, state, headRevision)
+	} else {
+		affected, computed, err = m.computeChanged(ctx, state, headRevision, changed)
+	}
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if state.generation != generation {
+		// A relevant change was observed during computation; another refresh will occur.
+		return nil
+	}
+
+	if isFull {
+		state.computed = computed
+	} else {
+		state.computed.replaceResources(affected, computed)
+	}
+	state.stale = false
+	state.changed = nil
+	state.requiresFullRecompute = false
+
+	event := log.Ctx(ctx).Debug().
+		Str("permission", tuple.StringRR(state.resourceRelation)).
+		Str("revision", headRevision.String()).
+		Int("subjects", len(state.computed.membersBySubject))
+	if isFull {
+		event.Msg("computed materialized permission")
+	} else {
+		event.Int("resources", affected.Len()).Msg("updated materialized permission")
+	}
+	return nil
+}
+
+func (m *Manager) compute(ctx context.Context, state *permissionState, revision datastore.Revision) (*computedPermission, error) {
+	computed := newComputedPermission(revision, state.subjectTypes)
+	reader := m.ds.SnapshotReader(revision)
+
+	// Find all resources of the permission's type with a relationship on which the permission
+	// could depend, as well as the earliest expiration of any such relationship, as the
+	// expiration of a relationship changes the permission without any change being observed by
+	// the watch.
+	resourceIDs := mapz.NewSet[string]()
+	for _, relation := range state.relevantRelations {
+		it, err := reader.QueryRelationships(ctx, datastore.RelationshipsFilter{
+			ResourceType:             relation.Namespace,
+			OptionalResourceRelation: relation.Relation,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for rel := it.Next(); rel != nil; rel = it.Next() {
+			if relation.Namespace == state.resourceRelation.Namespace {
+				resourceIDs.Add(rel.ResourceAndRelation.ObjectId)
+			}
+
+			if rel.OptionalExpirationTime != nil {
+				computed.updateExpiration(rel.OptionalExpirationTime.AsTime())
+			}
+		}
+
+		err = it.Err()
+		it.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.computeResources(ctx, state, computed, resourceIDs.AsSlice()); err != nil {
+		return nil, err
+	}
+	return computed, nil
+}
+
+// computeChanged computes the resources which might depend on the given changed relationships,
+// returning them along with the permission computed for them.
+func (m *Manager) computeChanged(ctx context.Context, state *permissionState, revision datastore.Revision, changed []*core.RelationTuple) (*mapz.Set[string], *computedPermission, error) {
+	affected, err := m.affectedResources(ctx, state, revision, changed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	computed := newComputedPermission(revision, state.subjectTypes)
+	for _, rel := range changed {
+		if rel.OptionalExpirationTime != nil {
+			computed.updateExpiration(rel.OptionalExpirationTime.AsTime())
+		}
+	}
+
+	if err := m.computeResources(ctx, state, computed, affected.AsSlice()); err != nil {
+		return nil, nil, err
+	}
+	return affected, computed, nil
+}
+
+// affectedResources returns the resources of the permission which might depend on the given
+// changed relationships, found by following the dependents of the relation of each changed
+// relationship at the given revision.
+//
+// The revision must be at or after that of the changes: a relationship linking a changed object
+// to a resource at an earlier revision but not at the given one was itself changed, so the
+// resources it linked to are found from its own change.
+func (m *Manager) affectedResources(ctx context.Context, state *permissionState, revision datastore.Revision, changed []*core.RelationTuple) (*mapz.Set[string], error) {
+	reader := m.ds.SnapshotReader(revision)
+	affected := mapz.NewSet[string]()
+	visited := mapz.NewSet[string]()
+	var pending []*core.ObjectAndRelation
+
+	visit := func(relation *core.RelationReference, objectID string) {
+		onr := &core.ObjectAndRelation{Namespace: relation.Namespace, ObjectId: objectID, Relation: relation.Relation}
+		if !visited.Add(tuple.StringONR(onr)) {
+			return
+		}
+
+		if relation.Namespace == state.resourceRelation.Namespace && relation.Relation == state.resourceRelation.Relation {
+			affected.Add(objectID)
+		}
+		pending = append(pending, onr)
+	}
+
+	for _, rel := range changed {
+		visit(&core.RelationReference{
+			Namespace: rel.ResourceAndRelation.Namespace,
+			Relation:  rel.ResourceAndRelation.Relation,
+		}, rel.ResourceAndRelation.ObjectId)
+	}
+
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		for _, dependent := range state.dependents[tuple.JoinRelRef(current.Namespace, current.Relation)] {
+			if dependent.linkRelation == nil {
+				visit(dependent.relation, current.ObjectId)
+				continue
+			}
+
+			it, err := reader.ReverseQueryRelationships(ctx, datastore.SubjectsFilter{
+				SubjectType:        current.Namespace,
+				OptionalSubjectIds: []string{current.ObjectId},
+				RelationFilter:     datastore.SubjectRelationFilter{}.WithRelation(dependent.subjectRelation),
+			}, options.WithResRelation(&options.ResourceRelation{
+				Namespace: dependent.linkRelation.Namespace,
+				Relation:  dependent.linkRelation.Relation,
+			}))
+			if err != nil {
+				return nil, err
+			}
+
+			for rel := it.Next(); rel != nil; rel = it.Next() {
+				visit(dependent.relation, rel.ResourceAndRelation.ObjectId)
+			}
+
+			err = it.Err()
+			it.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return affected, nil
+}
+
+// computeResources computes the permission for the given resources at the revision of the
+// computed permission, adding the subjects found to it.
+func (m *Manager) computeResources(ctx context.Context, state *permissionState, computed *computedPermission, ids []string) error {
+	dispatchCtx := datastoremw.ContextWithDatastore(ctx, m.ds)
+	for _, subjectType := range state.subjectTypes {
+		for start := 0; start < len(ids); start += lookupChunkSize {
+			end := start + lookupChunkSize
+			if end > len(ids) {
+				end = len(ids)
+			}
+
+			stream := dispatch.NewCollectingDispatchStream[*v1.DispatchLookupSubjectsResponse](dispatchCtx)
+			err := m.dispatcher.DispatchLookupSubjects(&v1.DispatchLookupSubjectsRequest{
+				Metadata: &v1.ResolverMeta{
+					AtRevision:     computed.revision.String(),
+					DepthRemaining: m.maximumDepth,
+					TraversalBloom: v1.MustNewTraversalBloomFilter(uint(m.maximumDepth)),
+				},
+				ResourceRelation: state.resourceRelation,
+				ResourceIds:      ids[start:end],
+				SubjectRelation:  subjectType,
+			}, stream)
+			if err != nil {
+				return err
+			}
+
+			for _, result := range stream.Results() {
+				for resourceID, foundSubjects := range result.FoundSubjectsByResourceId {
+					for _, found := range foundSubjects.FoundSubjects {
+						computed.addFoundSubject(subjectType, resourceID, found)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
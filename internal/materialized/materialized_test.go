@@ -0,0 +1,356 @@
+package materialized
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/internal/testfixtures"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+const testSchema = `
+	definition user {}
+
+	caveat somecaveat(somecondition int) {
+		somecondition == 42
+	}
+
+	definition group {
+		relation member: user | user with somecaveat
+	}
+
+	definition document {
+		relation viewer: user | group#member
+		relation banned: user
+		relation public: user:*
+		materialized permission view = viewer - banned
+		materialized permission public_view = view + public
+		permission edit = viewer
+	}
+`
+
+func TestManager(t *testing.T) {
+	rawDS, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+
+	ds, _ := testfixtures.DatastoreFromSchemaAndTestRelationships(rawDS, testSchema, []*core.RelationTuple{
+		tuple.MustParse("document:first#viewer@user:tom"),
+		tuple.MustParse("document:first#viewer@group:eng#member"),
+		tuple.MustParse("document:second#viewer@user:sarah"),
+		tuple.MustParse("document:second#banned@user:tom"),
+		tuple.MustParse("document:third#viewer@user:tom"),
+		tuple.MustParse("document:third#public@user:*"),
+		tuple.MustParse("group:eng#member@user:fred"),
+		tuple.MustParse("group:eng#member@user:jill[somecaveat]"),
+	}, require.New(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	manager := NewManager(ds, 10, 50)
+	go func() {
+		_ = manager.Start(ctx)
+	}()
+
+	view := tuple.RelationReference("document", "view")
+	waitForComputed(t, manager, view)
+
+	headRevision, err := ds.HeadRevision(ctx)
+	require.NoError(t, err)
+
+	tcs := []struct {
+		name             string
+		subject          string
+		expectedOk       bool
+		expectedMembers  []string
+		checkResourceIDs []string
+	}{
+		{"direct subject", "user:tom", true, []string{"first", "third"}, []string{"first", "second", "third"}},
+		{"subject via group", "user:fred", true, []string{"first"}, []string{"first", "second"}},
+		{"subject without resources", "user:unknown", true, []string{}, []string{"first"}},
+		{"caveated subject", "user:jill", false, nil, []string{"first"}},
+		{"non-ellipsis subject", "group:eng#member", false, nil, []string{"first"}},
+		{"uncomputed subject type", "document:first", false, nil, []string{"first"}},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			subject := tuple.ParseSubjectONR(tc.subject)
+
+			found, ok := manager.LookupResources(ctx, headRevision, view, subject)
+			require.Equal(tc.expectedOk, ok)
+			if tc.expectedOk {
+				require.ElementsMatch(tc.expectedMembers, found)
+			}
+
+			checked, ok := manager.CheckMembers(ctx, headRevision, view, tc.checkResourceIDs, subject)
+			require.Equal(tc.expectedOk, ok)
+			if tc.expectedOk {
+				require.ElementsMatch(tc.expectedMembers, checked)
+			}
+		})
+	}
+
+	t.Run("wildcard", func(t *testing.T) {
+		publicView := tuple.RelationReference("document", "public_view")
+		waitForComputed(t, manager, publicView)
+
+		_, ok := manager.LookupResources(ctx, headRevision, publicView, tuple.ParseSubjectONR("user:tom"))
+		require.False(t, ok)
+	})
+
+	t.Run("non-materialized permission", func(t *testing.T) {
+		_, ok := manager.LookupResources(ctx, headRevision, tuple.RelationReference("document", "edit"), tuple.ParseSubjectONR("user:tom"))
+		require.False(t, ok)
+	})
+
+	t.Run("recomputed after change", func(t *testing.T) {
+		require := require.New(t)
+
+		updatedRevision, err := common.WriteTuples(ctx, ds, core.RelationTupleUpdate_TOUCH, tuple.MustParse("document:second#viewer@user:fred"))
+		require.NoError(err)
+
+		// The previously computed resources must not be used at or after the change.
+		_, ok := manager.LookupResources(ctx, updatedRevision, view, tuple.ParseSubjectONR("user:fred"))
+		require.False(ok)
+
+		require.Eventually(func() bool {
+			found, ok := manager.LookupResources(ctx, updatedRevision, view, tuple.ParseSubjectONR("user:fred"))
+			return ok && len(found) == 2
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("updated after removal", func(t *testing.T) {
+		require := require.New(t)
+		waitForComputed(t, manager, view)
+
+		updatedRevision, err := common.WriteTuples(ctx, ds, core.RelationTupleUpdate_TOUCH, tuple.MustParse("document:first#banned@user:tom"))
+		require.NoError(err)
+
+		require.Eventually(func() bool {
+			found, ok := manager.LookupResources(ctx, updatedRevision, view, tuple.ParseSubjectONR("user:tom"))
+			return ok && len(found) == 1 && found[0] == "third"
+		}, 5*time.Second, 10*time.Millisecond)
+
+		// Other resources of the permission are kept.
+		found, ok := manager.LookupResources(ctx, updatedRevision, view, tuple.ParseSubjectONR("user:sarah"))
+		require.True(ok)
+		require.Equal([]string{"second"}, found)
+	})
+
+	t.Run("not recomputed after unrelated change", func(t *testing.T) {
+		require := require.New(t)
+
+		publicView := tuple.RelationReference("document", "public_view")
+		waitForComputed(t, manager, view)
+		waitForComputed(t, manager, publicView)
+
+		generation := func(resourceRelation *core.RelationReference) uint64 {
+			manager.lock.RLock()
+			defer manager.lock.RUnlock()
+			return manager.permissions[tuple.StringRR(resourceRelation)].generation
+		}
+		viewGeneration := generation(view)
+		publicViewGeneration := generation(publicView)
+
+		// The public relation is used by the public_view permission, but not by the view permission.
+		_, err := common.WriteTuples(ctx, ds, core.RelationTupleUpdate_TOUCH, tuple.MustParse("document:fourth#public@user:*"))
+		require.NoError(err)
+
+		require.Eventually(func() bool {
+			return generation(publicView) > publicViewGeneration
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Equal(viewGeneration, generation(view))
+	})
+}
+
+func TestAffectedResources(t *testing.T) {
+	rawDS, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+
+	ds, _ := testfixtures.DatastoreFromSchemaAndTestRelationships(rawDS, testSchema+`
+		definition folder {
+			relation parent: folder
+			relation reader: user | group#member
+			permission read = reader + parent->read
+		}
+
+		definition file {
+			relation folder: folder
+			materialized permission read = folder->read
+		}`, []*core.RelationTuple{
+		tuple.MustParse("document:first#viewer@user:tom"),
+		tuple.MustParse("document:first#viewer@group:eng#member"),
+		tuple.MustParse("document:second#viewer@group:eng#member"),
+		tuple.MustParse("document:third#viewer@group:sales#member"),
+		tuple.MustParse("group:eng#member@user:fred"),
+		tuple.MustParse("folder:root#reader@group:eng#member"),
+		tuple.MustParse("folder:child#parent@folder:root"),
+		tuple.MustParse("folder:other#reader@user:tom"),
+		tuple.MustParse("file:a#folder@folder:root"),
+		tuple.MustParse("file:b#folder@folder:child"),
+		tuple.MustParse("file:c#folder@folder:other"),
+	}, require.New(t))
+
+	ctx := context.Background()
+	manager := NewManager(ds, 10, 50)
+	manager.observed, err = ds.HeadRevision(ctx)
+	require.NoError(t, err)
+	require.NoError(t, manager.reloadSchema(ctx))
+
+	tcs := []struct {
+		name              string
+		permission        *core.RelationReference
+		changed           []string
+		expectedResources []string
+	}{
+		{
+			"direct relationship",
+			tuple.RelationReference("document", "view"),
+			[]string{"document:fourth#viewer@user:tom"},
+			[]string{"fourth"},
+		},
+		{
+			"excluded relationship",
+			tuple.RelationReference("document", "view"),
+			[]string{"document:first#banned@user:tom"},
+			[]string{"first"},
+		},
+		{
+			"relationship via userset",
+			tuple.RelationReference("document", "view"),
+			[]string{"group:eng#member@user:sarah"},
+			[]string{"first", "second"},
+		},
+		{
+			"relationship via nested permission",
+			tuple.RelationReference("document", "public_view"),
+			[]string{"group:sales#member@user:sarah", "document:second#public@user:*"},
+			[]string{"second", "third"},
+		},
+		{
+			"relationship via recursive arrow",
+			tuple.RelationReference("file", "read"),
+			[]string{"group:eng#member@user:sarah"},
+			[]string{"a", "b"},
+		},
+		{
+			"tupleset relationship",
+			tuple.RelationReference("file", "read"),
+			[]string{"folder:child#parent@folder:other"},
+			[]string{"b"},
+		},
+		{
+			"relationship of another permission",
+			tuple.RelationReference("file", "read"),
+			[]string{"document:first#viewer@user:sarah"},
+			[]string{},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			changed := make([]*core.RelationTuple, 0, len(tc.changed))
+			for _, rel := range tc.changed {
+				changed = append(changed, tuple.MustParse(rel))
+			}
+
+			headRevision, err := ds.HeadRevision(ctx)
+			require.NoError(t, err)
+
+			affected, err := manager.affectedResources(ctx, manager.permissions[tuple.StringRR(tc.permission)], headRevision, changed)
+			require.NoError(t, err)
+			require.ElementsMatch(t, tc.expectedResources, affected.AsSlice())
+		})
+	}
+}
+
+func TestRelevantRelations(t *testing.T) {
+	definitions := map[string]*core.NamespaceDefinition{}
+	compiled, err := compiler.Compile(compiler.InputSchema{
+		Source: input.Source("schema"),
+		SchemaString: testSchema + `
+			definition folder {
+				relation parent: folder
+				relation reader: user
+				permission read = reader + parent->read
+			}
+
+			definition file {
+				relation folder: folder
+				relation owner: user
+				materialized permission read = folder->read
+			}`,
+	}, compiler.AllowUnprefixedObjectType())
+	require.NoError(t, err)
+	for _, def := range compiled.ObjectDefinitions {
+		definitions[def.Name] = def
+	}
+
+	tcs := []struct {
+		namespaceName        string
+		permissionName       string
+		expectedRelations    []string
+		expectedSubjectTypes []string
+	}{
+		{
+			"document",
+			"view",
+			[]string{"document#view", "document#viewer", "document#banned", "group#member"},
+			[]string{"user#..."},
+		},
+		{
+			"document",
+			"public_view",
+			[]string{"document#public_view", "document#view", "document#viewer", "document#banned", "document#public", "group#member"},
+			[]string{"user#..."},
+		},
+		{
+			"file",
+			"read",
+			[]string{"file#read", "file#folder", "folder#read", "folder#reader", "folder#parent"},
+			[]string{"user#..."},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.namespaceName+"#"+tc.permissionName, func(t *testing.T) {
+			state := newPermissionState(tc.namespaceName, tc.permissionName, definitions)
+
+			relations := make([]string, 0, len(state.relevantRelations))
+			for key := range state.relevantRelations {
+				relations = append(relations, key)
+			}
+			require.ElementsMatch(t, tc.expectedRelations, relations)
+
+			subjectTypes := make([]string, 0, len(state.subjectTypes))
+			for _, subjectType := range state.subjectTypes {
+				subjectTypes = append(subjectTypes, tuple.StringRR(subjectType))
+			}
+			require.ElementsMatch(t, tc.expectedSubjectTypes, subjectTypes)
+		})
+	}
+}
+
+func waitForComputed(t *testing.T, manager *Manager, resourceRelation *core.RelationReference) {
+	require.Eventually(t, func() bool {
+		manager.lock.RLock()
+		defer manager.lock.RUnlock()
+
+		state, ok := manager.permissions[tuple.StringRR(resourceRelation)]
+		return ok && !state.stale && state.computed != nil && manager.observed != nil &&
+			!state.computed.revision.GreaterThan(manager.observed)
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,364 @@
+package materialized
+
+import (
+	"time"
+
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/genutil/mapz"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// permissionState is the state of a single materialized permission.
+type permissionState struct {
+	// resourceRelation is the materialized permission.
+	resourceRelation *core.RelationReference
+
+	// relevantRelations are the relations whose relationships can affect the permission, keyed
+	// by their relation reference string.
+	relevantRelations map[string]*core.RelationReference
+
+	// dependents holds the relations whose members directly depend on those of each relevant
+	// relation, keyed by the relation reference string of the relevant relation.
+	dependents map[string][]dependentRelation
+
+	// subjectTypes are the subject types for which the permission is computed.
+	subjectTypes []*core.RelationReference
+
+	// generation is incremented whenever a change that might affect the permission is observed.
+	generation uint64
+
+	// stale indicates that the permission must be recomputed before it can be used.
+	stale bool
+
+	// changed holds the relevant relationships changed since the permission was last computed,
+	// from which the computed permission is updated, unless it must be recomputed in full.
+	changed []*core.RelationTuple
+
+	// requiresFullRecompute indicates that the permission cannot be updated from the changed
+	// relationships, and must be recomputed in full.
+	requiresFullRecompute bool
+
+	// computed holds the last computed resources for the permission, if any.
+	computed *computedPermission
+}
+
+// dependentRelation is a relation whose members directly depend on those of a relevant relation.
+type dependentRelation struct {
+	// relation is the dependent relation.
+	relation *core.RelationReference
+
+	// linkRelation, if set, is the relation of the relationships linking the objects of the
+	// relevant relation, as subjects with subjectRelation, to the objects of the dependent
+	// relation. Otherwise, the dependent relation depends on the relevant relation of the same
+	// object.
+	linkRelation    *core.RelationReference
+	subjectRelation string
+}
+
+// newPermissionState creates the state for the given materialized permission, finding the
+// relations and subject types on which the permission depends from the given definitions.
+func newPermissionState(namespaceName string, permissionName string, definitions map[string]*core.NamespaceDefinition) *permissionState {
+	state := &permissionState{
+		resourceRelation: &core.RelationReference{
+			Namespace: namespaceName,
+			Relation:  permissionName,
+		},
+		relevantRelations: map[string]*core.RelationReference{},
+		dependents:        map[string][]dependentRelation{},
+		stale:             true,
+	}
+
+	subjectTypes := mapz.NewSet[string]()
+	var visitRelation func(namespaceName string, relationName string)
+	var visitRewrite func(namespaceName string, relationName string, rewrite *core.UsersetRewrite)
+
+	visitRelation = func(namespaceName string, relationName string) {
+		if !state.addRelevantRelation(namespaceName, relationName) {
+			return
+		}
+
+		relation, ok := findRelation(definitions, namespaceName, relationName)
+		if !ok {
+			return
+		}
+
+		if relation.UsersetRewrite != nil {
+			visitRewrite(namespaceName, relationName, relation.UsersetRewrite)
+			return
+		}
+
+		for _, allowed := range relation.GetTypeInformation().GetAllowedDirectRelations() {
+			if allowed.GetRelation() == tuple.Ellipsis || allowed.GetPublicWildcard() != nil {
+				subjectType := &core.RelationReference{Namespace: allowed.Namespace, Relation: tuple.Ellipsis}
+				if subjectTypes.Add(tuple.StringRR(subjectType)) {
+					state.subjectTypes = append(state.subjectTypes, subjectType)
+				}
+				continue
+			}
+
+			state.addDependent(allowed.Namespace, allowed.GetRelation(), dependentRelation{
+				relation:        &core.RelationReference{Namespace: namespaceName, Relation: relationName},
+				linkRelation:    &core.RelationReference{Namespace: namespaceName, Relation: relationName},
+				subjectRelation: allowed.GetRelation(),
+			})
+			visitRelation(allowed.Namespace, allowed.GetRelation())
+		}
+	}
+
+	visitRewrite = func(namespaceName string, relationName string, rewrite *core.UsersetRewrite) {
+		dependent := dependentRelation{
+			relation: &core.RelationReference{Namespace: namespaceName, Relation: relationName},
+		}
+
+		var children []*core.SetOperation_Child
+		switch rw := rewrite.RewriteOperation.(type) {
+		case *core.UsersetRewrite_Union:
+			children = rw.Union.Child
+		case *core.UsersetRewrite_Intersection:
+			children = rw.Intersection.Child
+		case *core.UsersetRewrite_Exclusion:
+			children = rw.Exclusion.Child
+		}
+
+		for _, child := range children {
+			switch c := child.ChildType.(type) {
+			case *core.SetOperation_Child_ComputedUserset:
+				state.addDependent(namespaceName, c.ComputedUserset.Relation, dependent)
+				visitRelation(namespaceName, c.ComputedUserset.Relation)
+
+			case *core.SetOperation_Child_UsersetRewrite:
+				visitRewrite(namespaceName, relationName, c.UsersetRewrite)
+
+			case *core.SetOperation_Child_TupleToUserset:
+				// Only the resources found via the tupleset matter, not its subject types.
+				tuplesetRelation := c.TupleToUserset.Tupleset.Relation
+				state.addRelevantRelation(namespaceName, tuplesetRelation)
+				state.addDependent(namespaceName, tuplesetRelation, dependent)
+
+				tupleset, ok := findRelation(definitions, namespaceName, tuplesetRelation)
+				if !ok {
+					continue
+				}
+
+				for _, allowed := range tupleset.GetTypeInformation().GetAllowedDirectRelations() {
+					state.addDependent(allowed.Namespace, c.TupleToUserset.ComputedUserset.Relation, dependentRelation{
+						relation:        dependent.relation,
+						linkRelation:    &core.RelationReference{Namespace: namespaceName, Relation: tuplesetRelation},
+						subjectRelation: allowed.GetRelation(),
+					})
+					visitRelation(allowed.Namespace, c.TupleToUserset.ComputedUserset.Relation)
+				}
+			}
+		}
+	}
+
+	visitRelation(namespaceName, permissionName)
+	return state
+}
+
+// addRelevantRelation adds the given relation to the relevant relations, returning false if it
+// was already present.
+func (ps *permissionState) addRelevantRelation(namespaceName string, relationName string) bool {
+	relation := &core.RelationReference{Namespace: namespaceName, Relation: relationName}
+	key := tuple.StringRR(relation)
+	if _, ok := ps.relevantRelations[key]; ok {
+		return false
+	}
+
+	ps.relevantRelations[key] = relation
+	return true
+}
+
+// addDependent records that the members of the dependent relation depend on those of the given
+// relation.
+func (ps *permissionState) addDependent(namespaceName string, relationName string, dependent dependentRelation) {
+	key := tuple.JoinRelRef(namespaceName, relationName)
+	ps.dependents[key] = append(ps.dependents[key], dependent)
+}
+
+// isRelevant returns whether a change to the given relationship can affect the permission.
+func (ps *permissionState) isRelevant(rel *core.RelationTuple) bool {
+	_, ok := ps.relevantRelations[tuple.JoinRelRef(rel.ResourceAndRelation.Namespace, rel.ResourceAndRelation.Relation)]
+	return ok
+}
+
+func findRelation(definitions map[string]*core.NamespaceDefinition, namespaceName string, relationName string) (*core.Relation, bool) {
+	definition, ok := definitions[namespaceName]
+	if !ok {
+		return nil, false
+	}
+
+	for _, relation := range definition.Relation {
+		if relation.Name == relationName {
+			return relation, true
+		}
+	}
+	return nil, false
+}
+
+// invalidate marks the permission as requiring a full recomputation.
+func (ps *permissionState) invalidate() {
+	ps.generation++
+	ps.stale = true
+	ps.changed = nil
+	ps.requiresFullRecompute = true
+}
+
+// addChange marks the permission as requiring an update for the given changed relationship,
+// falling back to a full recomputation once too many relationships have changed.
+func (ps *permissionState) addChange(rel *core.RelationTuple) {
+	if ps.computed == nil || ps.requiresFullRecompute || len(ps.changed) >= maxChangedRelationships {
+		ps.invalidate()
+		return
+	}
+
+	ps.generation++
+	ps.stale = true
+	ps.changed = append(ps.changed, rel)
+}
+
+// computedPermission holds the resources of a materialized permission, as computed at a specific
+// revision.
+type computedPermission struct {
+	// revision is the revision at which the permission was computed.
+	revision datastore.Revision
+
+	// expiresAt is the earliest expiration time of any relationship on which the permission
+	// might depend, if any.
+	expiresAt *time.Time
+
+	// subjectTypes are the subject types for which the permission was computed.
+	subjectTypes *mapz.Set[string]
+
+	// membersBySubject holds the resource IDs for each subject with the permission.
+	membersBySubject map[string]*mapz.Set[string]
+
+	// caveatedSubjects holds the resource IDs on which each subject has the permission
+	// conditionally on a caveat. Such subjects cannot be answered.
+	caveatedSubjects map[string]*mapz.Set[string]
+
+	// wildcardSubjectTypes holds the resource IDs on which a wildcard of each subject type has
+	// the permission. Such subject types cannot be answered.
+	wildcardSubjectTypes map[string]*mapz.Set[string]
+}
+
+func newComputedPermission(revision datastore.Revision, subjectTypes []*core.RelationReference) *computedPermission {
+	subjectTypeKeys := mapz.NewSet[string]()
+	for _, subjectType := range subjectTypes {
+		subjectTypeKeys.Add(tuple.StringRR(subjectType))
+	}
+
+	return &computedPermission{
+		revision:             revision,
+		subjectTypes:         subjectTypeKeys,
+		membersBySubject:     map[string]*mapz.Set[string]{},
+		caveatedSubjects:     map[string]*mapz.Set[string]{},
+		wildcardSubjectTypes: map[string]*mapz.Set[string]{},
+	}
+}
+
+func (cp *computedPermission) updateExpiration(expiration time.Time) {
+	if cp.expiresAt == nil || expiration.Before(*cp.expiresAt) {
+		cp.expiresAt = &expiration
+	}
+}
+
+func (cp *computedPermission) addFoundSubject(subjectType *core.RelationReference, resourceID string, found *v1.FoundSubject) {
+	if found.SubjectId == tuple.PublicWildcard {
+		addResource(cp.wildcardSubjectTypes, tuple.StringRR(subjectType), resourceID)
+		return
+	}
+
+	subjectKey := tuple.StringONR(&core.ObjectAndRelation{
+		Namespace: subjectType.Namespace,
+		ObjectId:  found.SubjectId,
+		Relation:  subjectType.Relation,
+	})
+
+	if found.CaveatExpression != nil {
+		addResource(cp.caveatedSubjects, subjectKey, resourceID)
+		return
+	}
+
+	addResource(cp.membersBySubject, subjectKey, resourceID)
+}
+
+// replaceResources replaces everything computed for the given resources by what was computed for
+// them in the updated permission, which becomes the revision of the computed permission.
+func (cp *computedPermission) replaceResources(resourceIDs *mapz.Set[string], updated *computedPermission) {
+	for _, resourcesByKey := range []map[string]*mapz.Set[string]{cp.membersBySubject, cp.caveatedSubjects, cp.wildcardSubjectTypes} {
+		for key, resources := range resourcesByKey {
+			resources.RemoveAll(resourceIDs)
+			if resources.IsEmpty() {
+				delete(resourcesByKey, key)
+			}
+		}
+	}
+
+	mergeResources(cp.membersBySubject, updated.membersBySubject)
+	mergeResources(cp.caveatedSubjects, updated.caveatedSubjects)
+	mergeResources(cp.wildcardSubjectTypes, updated.wildcardSubjectTypes)
+
+	if updated.expiresAt != nil {
+		cp.updateExpiration(*updated.expiresAt)
+	}
+	cp.revision = updated.revision
+}
+
+func addResource(resourcesByKey map[string]*mapz.Set[string], key string, resourceID string) {
+	resources, ok := resourcesByKey[key]
+	if !ok {
+		resources = mapz.NewSet[string]()
+		resourcesByKey[key] = resources
+	}
+	resources.Add(resourceID)
+}
+
+func mergeResources(resourcesByKey map[string]*mapz.Set[string], other map[string]*mapz.Set[string]) {
+	for key, resources := range other {
+		existing, ok := resourcesByKey[key]
+		if !ok {
+			resourcesByKey[key] = resources
+			continue
+		}
+		existing.Merge(resources)
+	}
+}
+
+// membersFor returns the resources on which the subject has the permission, if the computed
+// permission can answer for the subject at the given revision and time.
+func (cp *computedPermission) membersFor(revision datastore.Revision, subject *core.ObjectAndRelation, now time.Time) (*mapz.Set[string], bool) {
+	if cp.revision.GreaterThan(revision) {
+		return nil, false
+	}
+
+	if cp.expiresAt != nil && !now.Before(*cp.expiresAt) {
+		return nil, false
+	}
+
+	if subject.Relation != tuple.Ellipsis {
+		return nil, false
+	}
+
+	subjectTypeKey := tuple.StringRR(&core.RelationReference{Namespace: subject.Namespace, Relation: subject.Relation})
+	if _, ok := cp.wildcardSubjectTypes[subjectTypeKey]; ok {
+		return nil, false
+	}
+
+	subjectKey := tuple.StringONR(subject)
+	if _, ok := cp.caveatedSubjects[subjectKey]; ok {
+		return nil, false
+	}
+
+	members, ok := cp.membersBySubject[subjectKey]
+	if !ok {
+		// The subject has no resources, which is only known if its type was computed.
+		if !cp.subjectTypes.Has(subjectTypeKey) {
+			return nil, false
+		}
+		return mapz.NewSet[string](), true
+	}
+	return members, true
+}
@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	log "github.com/authzed/spicedb/internal/logging"
@@ -38,6 +40,21 @@ var revisionKey ctxKeyType = struct{}{}
 
 var errInvalidZedToken = errors.New("invalid revision requested")
 
+// AsOfMetadataKey is the key of the metadata in which a point in time, in RFC 3339 format, is
+// passed to query "as of" that time: at the latest revision at or before it. Only the history
+// within the garbage collection window of the datastore can be queried.
+const AsOfMetadataKey = "x-spicedb-as-of"
+
+// isAsOfRequest returns whether the given request can be made as of a point in time.
+func isAsOfRequest(req interface{}) bool {
+	switch req.(type) {
+	case *v1.CheckPermissionRequest, *v1.ReadRelationshipsRequest, *v1.LookupResourcesRequest:
+		return true
+	default:
+		return false
+	}
+}
+
 type revisionHandle struct {
 	revision datastore.Revision
 }
@@ -65,6 +82,12 @@ func RevisionFromContext(ctx context.Context) (datastore.Revision, *v1.ZedToken,
 // AddRevisionToContext adds a revision to the given context, based on the consistency block found
 // in the given request (if applicable).
 func AddRevisionToContext(ctx context.Context, req interface{}, ds datastore.Datastore) error {
+	if _, hasAsOf, err := asOfFromContext(ctx); err != nil {
+		return err
+	} else if hasAsOf && !isAsOfRequest(req) {
+		return status.Errorf(codes.InvalidArgument, "requests of type %T cannot be made as of a point in time", req)
+	}
+
 	switch req := req.(type) {
 	case hasConsistency:
 		return addRevisionToContextFromConsistency(ctx, req, ds)
@@ -86,6 +109,11 @@ func addRevisionToContextFromConsistency(ctx context.Context, req hasConsistency
 
 	withOptionalCursor, hasOptionalCursor := req.(hasOptionalCursor)
 
+	asOf, hasAsOf, err := asOfFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
 	switch {
 	case hasOptionalCursor && withOptionalCursor.GetOptionalCursor() != nil:
 		// Always use the revision encoded in the cursor.
@@ -103,6 +131,26 @@ func addRevisionToContextFromConsistency(ctx context.Context, req hasConsistency
 
 		revision = requestedRev
 
+	case hasAsOf:
+		// As of: Use the datastore's revision at the requested point in time.
+		ConsistentyCounter.WithLabelValues("asof", "request").Inc()
+
+		if consistency != nil && !consistency.GetMinimizeLatency() {
+			return status.Errorf(codes.InvalidArgument, "requests made as of a point in time cannot specify a consistency")
+		}
+
+		historical := datastore.UnwrapAs[datastore.HistoricalDatastore](ds)
+		if historical == nil {
+			return status.Errorf(codes.Unimplemented, "the datastore does not support requests made as of a point in time")
+		}
+
+		requestedRev, err := historical.RevisionAsOf(ctx, asOf)
+		if err != nil {
+			return rewriteAsOfError(ctx, asOf, err)
+		}
+
+		revision = requestedRev
+
 	case consistency == nil || consistency.GetMinimizeLatency():
 		// Minimize Latency: Use the datastore's current revision, whatever it may be.
 		source := "request"
@@ -247,6 +295,37 @@ func pickBestRevision(ctx context.Context, requested *v1.ZedToken, ds datastore.
 	return databaseRev, false, nil
 }
 
+// asOfFromContext returns the point in time at which the request of the given context is made, if
+// any.
+func asOfFromContext(ctx context.Context) (time.Time, bool, error) {
+	values := metadata.ValueFromIncomingContext(ctx, AsOfMetadataKey)
+	if len(values) == 0 {
+		return time.Time{}, false, nil
+	}
+
+	asOf, err := time.Parse(time.RFC3339Nano, values[0])
+	if err != nil {
+		return time.Time{}, false, status.Errorf(codes.InvalidArgument, "invalid `%s` metadata: expected an RFC 3339 timestamp, got `%s`", AsOfMetadataKey, values[0])
+	}
+	return asOf, true, nil
+}
+
+func rewriteAsOfError(ctx context.Context, asOf time.Time, err error) error {
+	var invalidRevisionErr datastore.ErrInvalidRevision
+	if !errors.As(err, &invalidRevisionErr) {
+		return rewriteDatastoreError(ctx, err)
+	}
+
+	switch invalidRevisionErr.Reason() {
+	case datastore.RevisionStale:
+		return status.Errorf(codes.OutOfRange, "the history as of %s has been garbage collected; history is only retained for the garbage collection window of the datastore", asOf.Format(time.RFC3339Nano))
+	case datastore.CouldNotDetermineRevision:
+		return status.Errorf(codes.OutOfRange, "cannot query as of %s, which is in the future", asOf.Format(time.RFC3339Nano))
+	default:
+		return rewriteDatastoreError(ctx, err)
+	}
+}
+
 func rewriteDatastoreError(ctx context.Context, err error) error {
 	// Check if the error can be directly used.
 	if _, ok := status.FromError(err); ok {
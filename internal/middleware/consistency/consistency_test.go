@@ -4,13 +4,18 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/grpcutil"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/authzed/spicedb/internal/datastore/proxy/proxy_test"
 	"github.com/authzed/spicedb/internal/datastore/revisions"
 	"github.com/authzed/spicedb/pkg/cursor"
+	"github.com/authzed/spicedb/pkg/datastore"
 	dispatch "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
 	"github.com/authzed/spicedb/pkg/zedtoken"
 )
@@ -191,3 +196,55 @@ func TestAddRevisionToContextWithCursor(t *testing.T) {
 	require.True(optimized.Equal(rev))
 	ds.AssertExpectations(t)
 }
+
+func TestAddRevisionToContextAsOf(t *testing.T) {
+	asOf := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tcs := []struct {
+		name         string
+		asOf         string
+		req          any
+		resolved     datastore.Revision
+		resolveErr   error
+		expectedCode codes.Code
+		expectedRev  datastore.Revision
+	}{
+		{"check", asOf.Format(time.RFC3339Nano), &v1.CheckPermissionRequest{}, exact, nil, codes.OK, exact},
+		{"lookup with minimize latency", asOf.Format(time.RFC3339Nano), &v1.LookupResourcesRequest{
+			Consistency: &v1.Consistency{Requirement: &v1.Consistency_MinimizeLatency{MinimizeLatency: true}},
+		}, exact, nil, codes.OK, exact},
+		{"collected history", asOf.Format(time.RFC3339Nano), &v1.ReadRelationshipsRequest{}, datastore.NoRevision, datastore.NewInvalidRevisionErr(datastore.NoRevision, datastore.RevisionStale), codes.OutOfRange, nil},
+		{"future", asOf.Format(time.RFC3339Nano), &v1.ReadRelationshipsRequest{}, datastore.NoRevision, datastore.NewInvalidRevisionErr(datastore.NoRevision, datastore.CouldNotDetermineRevision), codes.OutOfRange, nil},
+		{"invalid time", "yesterday", &v1.ReadRelationshipsRequest{}, nil, nil, codes.InvalidArgument, nil},
+		{"with consistency", asOf.Format(time.RFC3339Nano), &v1.ReadRelationshipsRequest{
+			Consistency: &v1.Consistency{Requirement: &v1.Consistency_FullyConsistent{FullyConsistent: true}},
+		}, nil, nil, codes.InvalidArgument, nil},
+		{"unsupported request", asOf.Format(time.RFC3339Nano), &v1.WriteRelationshipsRequest{}, nil, nil, codes.InvalidArgument, nil},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ds := &proxy_test.MockDatastore{}
+			if tc.resolved != nil {
+				ds.On("RevisionAsOf", asOf).Return(tc.resolved, tc.resolveErr).Once()
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(AsOfMetadataKey, tc.asOf))
+			updated := ContextWithHandle(ctx)
+			err := AddRevisionToContext(updated, tc.req, ds)
+			ds.AssertExpectations(t)
+			if tc.expectedCode != codes.OK {
+				grpcutil.RequireStatus(t, tc.expectedCode, err)
+				return
+			}
+			require.NoError(err)
+
+			rev, _, err := RevisionFromContext(updated)
+			require.NoError(err)
+			require.True(tc.expectedRev.Equal(rev))
+		})
+	}
+}
@@ -2,7 +2,12 @@ package pertoken
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -25,19 +30,47 @@ const (
 // MiddlewareForTesting is used to create a unique datastore for each token. It is intended for use in the
 // testserver only.
 type MiddlewareForTesting struct {
-	datastoreByToken *sync.Map
-	configFilePaths  []string
+	datastoreByToken  *sync.Map
+	configFilePaths   []string
+	snapshotDirectory string
 }
 
 // NewMiddleware returns a new per-token datastore middleware that initializes each datastore with the data in the
 // config files.
-func NewMiddleware(configFilePaths []string) *MiddlewareForTesting {
+//
+// If a snapshot directory is given, each datastore is snapshotted to a file in the directory named after a hash of
+// its token, and is restored from that file instead of the config files when it exists.
+func NewMiddleware(configFilePaths []string, snapshotDirectory string) *MiddlewareForTesting {
 	return &MiddlewareForTesting{
-		datastoreByToken: &sync.Map{},
-		configFilePaths:  configFilePaths,
+		datastoreByToken:  &sync.Map{},
+		configFilePaths:   configFilePaths,
+		snapshotDirectory: snapshotDirectory,
 	}
 }
 
+// Close closes all of the datastores created for tokens, writing their snapshots if a snapshot
+// directory was given.
+func (m *MiddlewareForTesting) Close() error {
+	var errs []error
+	m.datastoreByToken.Range(func(key, value any) bool {
+		if err := value.(datastore.Datastore).Close(); err != nil {
+			errs = append(errs, err)
+		}
+		m.datastoreByToken.Delete(key)
+		return true
+	})
+	return errors.Join(errs...)
+}
+
+func (m *MiddlewareForTesting) snapshotPath(tokenStr string) string {
+	if m.snapshotDirectory == "" {
+		return ""
+	}
+
+	hashed := sha256.Sum256([]byte(tokenStr))
+	return filepath.Join(m.snapshotDirectory, hex.EncodeToString(hashed[:])+".json")
+}
+
 type squashable interface {
 	SquashRevisionsForTesting()
 }
@@ -50,20 +83,31 @@ func (m *MiddlewareForTesting) getOrCreateDatastore(ctx context.Context) (datast
 	}
 
 	log.Ctx(ctx).Debug().Str("token", tokenStr).Msg("initializing new upstream for token")
-	ds, err := memdb.NewMemdbDatastore(0, revisionQuantization, gcWindow)
+	snapshotPath := m.snapshotPath(tokenStr)
+	_, statErr := os.Stat(snapshotPath)
+	restoring := snapshotPath != "" && statErr == nil
+
+	ds, err := memdb.NewMemdbDatastore(0, revisionQuantization, gcWindow, memdb.SnapshotPath(snapshotPath))
 	if err != nil {
 		return nil, fmt.Errorf("failed to init datastore: %w", err)
 	}
 
-	_, _, err = validationfile.PopulateFromFiles(ctx, ds, m.configFilePaths)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load config files: %w", err)
-	}
+	if !restoring {
+		_, _, err = validationfile.PopulateFromFiles(ctx, ds, m.configFilePaths)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config files: %w", err)
+		}
 
-	// Squash the revisions so that the caller sees all the populated data.
-	ds.(squashable).SquashRevisionsForTesting()
+		// Squash the revisions so that the caller sees all the populated data.
+		ds.(squashable).SquashRevisionsForTesting()
+	}
 
-	m.datastoreByToken.Store(tokenStr, ds)
+	existing, loaded := m.datastoreByToken.LoadOrStore(tokenStr, ds)
+	if loaded {
+		// Another request for the same token created its datastore concurrently.
+		_ = ds.Close()
+		return existing.(datastore.Datastore), nil
+	}
 	return ds, nil
 }
 
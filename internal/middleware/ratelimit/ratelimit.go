@@ -0,0 +1,327 @@
+// Package ratelimit implements a middleware limiting the rate of requests and dispatches of
+// each tenant of a SpiceDB instance, so that a single tenant cannot starve the others.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/authzed/grpcutil"
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/metadata"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/authzed/spicedb/internal/auth"
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/internal/middleware/usagemetrics"
+	"github.com/authzed/spicedb/pkg/spiceerrors"
+)
+
+const (
+	limitRequests   = "requests"
+	limitDispatches = "dispatches"
+
+	// idleTenantTimeout is the duration after which the limits of a tenant that made no requests
+	// are forgotten.
+	idleTenantTimeout = 1 * time.Minute
+)
+
+var rejectedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "spicedb",
+	Subsystem: "ratelimit",
+	Name:      "rejected_requests_total",
+	Help:      "total number of requests rejected for exceeding a tenant limit",
+}, []string{"method", "limit"})
+
+// Config configures the limits applied to each tenant.
+type Config struct {
+	// TenantHeader is the request header identifying the tenant of a request among those of the
+	// caller which authenticated it, e.g. a gateway serving several tenants with a single token.
+	// Tenants are always identified by the subject of the JWT with which the request was
+	// authenticated, or else by its preshared token, so that a caller cannot use the budget of
+	// another by setting the header. If the header is set, each tenant it names is limited in
+	// addition to the caller as a whole, so that varying the header does not raise the budget of
+	// the caller.
+	TenantHeader string `debugmap:"visible"`
+
+	// RequestsPerSecond is the rate of requests allowed per tenant for each API method. If zero,
+	// the rate of requests is only limited for the methods in MethodRequestsPerSecond.
+	RequestsPerSecond float64 `debugmap:"visible"`
+
+	// RequestBurst is the number of requests allowed per tenant above the rate for each API method.
+	RequestBurst int `debugmap:"visible"`
+
+	// MethodRequestsPerSecond overrides the rate of requests allowed per tenant for the API
+	// methods with the given names, e.g. `CheckPermission`. A rate of zero exempts the method.
+	MethodRequestsPerSecond map[string]float64 `debugmap:"visible"`
+
+	// DispatchesPerSecond is the rate of dispatches allowed per tenant across all API methods. If
+	// zero, dispatches are not limited.
+	DispatchesPerSecond float64 `debugmap:"visible"`
+
+	// DispatchBurst is the number of dispatches allowed per tenant above the rate.
+	DispatchBurst int `debugmap:"visible"`
+}
+
+// Enabled returns whether the config limits any requests.
+func (c Config) Enabled() bool {
+	return c.RequestsPerSecond > 0 || c.DispatchesPerSecond > 0 || len(c.MethodRequestsPerSecond) > 0
+}
+
+func (c Config) MarshalZerologObject(e *zerolog.Event) {
+	e.Str("tenantHeader", c.TenantHeader).
+		Float64("requestsPerSecond", c.RequestsPerSecond).
+		Int("requestBurst", c.RequestBurst).
+		Interface("methodRequestsPerSecond", c.MethodRequestsPerSecond).
+		Float64("dispatchesPerSecond", c.DispatchesPerSecond).
+		Int("dispatchBurst", c.DispatchBurst)
+}
+
+// Limiter limits the requests and dispatches of each tenant.
+type Limiter struct {
+	config Config
+	now    func() time.Time
+
+	lock      sync.Mutex
+	tenants   map[string]*tenantLimits
+	lastSweep time.Time
+}
+
+type tenantLimits struct {
+	requests   map[string]*rate.Limiter
+	dispatches *rate.Limiter
+	lastUsed   time.Time
+}
+
+// NewLimiter returns a Limiter applying the limits of the given config.
+func NewLimiter(config Config) (*Limiter, error) {
+	if config.RequestsPerSecond < 0 || config.DispatchesPerSecond < 0 {
+		return nil, errors.New("rate limits must not be negative")
+	}
+	for method, rps := range config.MethodRequestsPerSecond {
+		if rps < 0 {
+			return nil, fmt.Errorf("rate limit for method `%s` must not be negative", method)
+		}
+	}
+
+	if config.RequestBurst <= 0 {
+		config.RequestBurst = int(math.Max(1, math.Ceil(config.RequestsPerSecond)))
+	}
+	if config.DispatchBurst <= 0 {
+		config.DispatchBurst = int(math.Max(1, math.Ceil(config.DispatchesPerSecond)))
+	}
+
+	return &Limiter{
+		config:  config,
+		now:     time.Now,
+		tenants: map[string]*tenantLimits{},
+	}, nil
+}
+
+// UnaryServerInterceptor returns a new unary server interceptor that limits the requests and
+// dispatches of each tenant.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		tenants, methodName, err := l.admit(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx = usagemetrics.ContextWithHandle(ctx)
+		resp, err := handler(ctx, req)
+		l.chargeDispatches(ctx, tenants, methodName)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a new stream server interceptor that limits the requests and
+// dispatches of each tenant.
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		tenants, methodName, err := l.admit(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		wrapped := middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = usagemetrics.ContextWithHandle(wrapped.WrappedContext)
+		err = handler(srv, wrapped)
+		l.chargeDispatches(wrapped.WrappedContext, tenants, methodName)
+		return err
+	}
+}
+
+// admit returns the tenants and method name of a request if all its tenants are within their
+// limits, or a RESOURCE_EXHAUSTED error if not.
+func (l *Limiter) admit(ctx context.Context, fullMethod string) ([]string, string, error) {
+	_, methodName := grpcutil.SplitMethodName(fullMethod)
+	tenants := l.tenantsFromContext(ctx)
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	allLimits := make([]*tenantLimits, 0, len(tenants))
+	for _, tenant := range tenants {
+		allLimits = append(allLimits, l.limitsForTenant(tenant, now))
+	}
+
+	// Dispatches are only known once a request completes, so requests are admitted as long as
+	// no tenant has already exceeded its dispatch budget.
+	for _, limits := range allLimits {
+		if limits.dispatches == nil {
+			continue
+		}
+
+		if tokens := limits.dispatches.TokensAt(now); tokens < 0 {
+			delay := time.Duration(-tokens / l.config.DispatchesPerSecond * float64(time.Second))
+			return nil, "", l.exhaustedError(methodName, limitDispatches, delay)
+		}
+	}
+
+	// The request is charged against the budget of every tenant, or none if any is exhausted.
+	reservations := make([]*rate.Reservation, 0, len(allLimits))
+	var delay time.Duration
+	for _, limits := range allLimits {
+		limiter := l.requestLimiter(limits, methodName)
+		if limiter == nil {
+			continue
+		}
+
+		reservation := limiter.ReserveN(now, 1)
+		reservations = append(reservations, reservation)
+		delay = max(delay, reservation.DelayFrom(now))
+	}
+
+	if delay > 0 {
+		for _, reservation := range reservations {
+			reservation.CancelAt(now)
+		}
+		return nil, "", l.exhaustedError(methodName, limitRequests, delay)
+	}
+
+	return tenants, methodName, nil
+}
+
+// chargeDispatches charges the dispatches performed by a request against the dispatch budget of
+// each of its tenants.
+func (l *Limiter) chargeDispatches(ctx context.Context, tenants []string, methodName string) {
+	responseMeta := usagemetrics.FromContext(ctx)
+	if responseMeta == nil || responseMeta.DispatchCount == 0 {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	count := int(responseMeta.DispatchCount)
+	for _, tenant := range tenants {
+		limits := l.limitsForTenant(tenant, now)
+		if limits.dispatches == nil {
+			continue
+		}
+
+		// The budget may go into debt, in which case further requests are rejected until it is
+		// repaid. The limiter cannot reserve more than its burst at once, so the dispatches are
+		// charged in chunks.
+		for remaining := count; remaining > 0; remaining -= l.config.DispatchBurst {
+			limits.dispatches.ReserveN(now, min(remaining, l.config.DispatchBurst))
+		}
+	}
+
+	log.Ctx(ctx).Trace().Str("method", methodName).Int("dispatches", count).Msg("charged dispatches against tenant budget")
+}
+
+func (l *Limiter) requestLimiter(limits *tenantLimits, methodName string) *rate.Limiter {
+	if limiter, ok := limits.requests[methodName]; ok {
+		return limiter
+	}
+
+	var limiter *rate.Limiter
+	if rps, ok := l.config.MethodRequestsPerSecond[methodName]; ok {
+		if rps > 0 {
+			limiter = rate.NewLimiter(rate.Limit(rps), l.config.RequestBurst)
+		}
+	} else if l.config.RequestsPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(l.config.RequestsPerSecond), l.config.RequestBurst)
+	}
+
+	limits.requests[methodName] = limiter
+	return limiter
+}
+
+// limitsForTenant returns the limits of the given tenant, creating them if necessary. Must be
+// called with the lock held.
+func (l *Limiter) limitsForTenant(tenant string, now time.Time) *tenantLimits {
+	// Forget idle tenants, whose budgets have been replenished, to bound the tenants tracked.
+	if now.Sub(l.lastSweep) > idleTenantTimeout {
+		for key, limits := range l.tenants {
+			if now.Sub(limits.lastUsed) > idleTenantTimeout {
+				delete(l.tenants, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	limits, ok := l.tenants[tenant]
+	if !ok {
+		limits = &tenantLimits{requests: map[string]*rate.Limiter{}}
+		if l.config.DispatchesPerSecond > 0 {
+			limits.dispatches = rate.NewLimiter(rate.Limit(l.config.DispatchesPerSecond), l.config.DispatchBurst)
+		}
+		l.tenants[tenant] = limits
+	}
+
+	limits.lastUsed = now
+	return limits
+}
+
+// tenantsFromContext returns the tenants of a request: its authenticated principal, followed by
+// the principal qualified by the tenant header if set.
+func (l *Limiter) tenantsFromContext(ctx context.Context) []string {
+	principal := auth.PrincipalFromContext(ctx)
+	if l.config.TenantHeader != "" {
+		if tenant := metadata.ExtractIncoming(ctx).Get(l.config.TenantHeader); tenant != "" {
+			return []string{principal, principal + "/header:" + tenant}
+		}
+	}
+
+	return []string{principal}
+}
+
+func (l *Limiter) exhaustedError(methodName string, limit string, delay time.Duration) error {
+	rejectedCounter.WithLabelValues(methodName, limit).Inc()
+
+	return spiceerrors.WithCodeAndDetailsAsError(
+		fmt.Errorf("rate limit exceeded: too many %s for method %s, retry after %s", limit, methodName, delay.Round(time.Millisecond)),
+		codes.ResourceExhausted,
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)},
+		&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{{
+			Subject:     methodName,
+			Description: "tenant exceeded its limit of " + limit + " per second: " + strconv.FormatFloat(l.limitFor(methodName, limit), 'f', -1, 64),
+		}}},
+	)
+}
+
+func (l *Limiter) limitFor(methodName string, limit string) float64 {
+	if limit == limitDispatches {
+		return l.config.DispatchesPerSecond
+	}
+	if rps, ok := l.config.MethodRequestsPerSecond[methodName]; ok {
+		return rps
+	}
+	return l.config.RequestsPerSecond
+}
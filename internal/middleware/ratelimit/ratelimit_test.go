@@ -0,0 +1,226 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/authzed/spicedb/internal/middleware/usagemetrics"
+	dispatch "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+const (
+	checkMethod = "/authzed.api.v1.PermissionsService/CheckPermission"
+	writeMethod = "/authzed.api.v1.PermissionsService/WriteRelationships"
+)
+
+func TestRequestLimits(t *testing.T) {
+	limiter, clock := newTestLimiter(t, Config{
+		RequestsPerSecond:       2,
+		MethodRequestsPerSecond: map[string]float64{"WriteRelationships": 0},
+	})
+
+	first := contextForToken("first")
+	second := contextForToken("second")
+
+	// Each tenant has its own budget per method.
+	require.NoError(t, call(limiter, first, checkMethod, 0))
+	require.NoError(t, call(limiter, first, checkMethod, 0))
+	requireExhausted(t, call(limiter, first, checkMethod, 0), 500*time.Millisecond)
+	require.NoError(t, call(limiter, second, checkMethod, 0))
+
+	// Exempted methods are not limited.
+	for i := 0; i < 10; i++ {
+		require.NoError(t, call(limiter, first, writeMethod, 0))
+	}
+
+	// The budget is replenished over time.
+	clock.Add(500 * time.Millisecond)
+	require.NoError(t, call(limiter, first, checkMethod, 0))
+	requireExhausted(t, call(limiter, first, checkMethod, 0), 500*time.Millisecond)
+}
+
+func TestFractionalMethodLimit(t *testing.T) {
+	limiter, clock := newTestLimiter(t, Config{
+		MethodRequestsPerSecond: map[string]float64{"CheckPermission": 0.5},
+	})
+
+	ctx := contextForToken("first")
+	require.NoError(t, call(limiter, ctx, checkMethod, 0))
+	requireExhausted(t, call(limiter, ctx, checkMethod, 0), 2*time.Second)
+
+	clock.Add(2 * time.Second)
+	require.NoError(t, call(limiter, ctx, checkMethod, 0))
+}
+
+func TestTenantHeader(t *testing.T) {
+	limiter, _ := newTestLimiter(t, Config{
+		TenantHeader:      "x-tenant",
+		RequestsPerSecond: 1,
+	})
+
+	products := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "bearer first", "x-tenant", "products"))
+	orders := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "bearer first", "x-tenant", "orders"))
+	spoofed := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "bearer second", "x-tenant", "products"))
+	unnamed := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "bearer first"))
+
+	// The tenants named by a caller share its budget.
+	require.NoError(t, call(limiter, products, checkMethod, 0))
+	requireExhausted(t, call(limiter, products, checkMethod, 0), time.Second)
+	requireExhausted(t, call(limiter, orders, checkMethod, 0), time.Second)
+	requireExhausted(t, call(limiter, unnamed, checkMethod, 0), time.Second)
+
+	// Another caller naming the same tenant does not share its budget.
+	require.NoError(t, call(limiter, spoofed, checkMethod, 0))
+	requireExhausted(t, call(limiter, spoofed, checkMethod, 0), time.Second)
+}
+
+func TestTenantHeaderRotation(t *testing.T) {
+	limiter, clock := newTestLimiter(t, Config{
+		TenantHeader:        "x-tenant",
+		RequestsPerSecond:   10,
+		RequestBurst:        10,
+		DispatchesPerSecond: 10,
+		DispatchBurst:       10,
+	})
+
+	withTenant := func(tenant string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "bearer first", "x-tenant", tenant))
+	}
+
+	// Naming a different tenant on every request does not raise the budget of the caller.
+	admitted := 0
+	for i := 0; i < 100; i++ {
+		if call(limiter, withTenant(fmt.Sprintf("tenant-%d", i)), checkMethod, 0) == nil {
+			admitted++
+		}
+	}
+	require.Equal(t, 10, admitted)
+
+	// Neither does it for dispatches.
+	clock.Add(10 * time.Second)
+	require.NoError(t, call(limiter, withTenant("first"), checkMethod, 20))
+	requireExhausted(t, call(limiter, withTenant("second"), checkMethod, 0), time.Second)
+}
+
+func TestDispatchBudget(t *testing.T) {
+	limiter, clock := newTestLimiter(t, Config{
+		DispatchesPerSecond: 10,
+		DispatchBurst:       10,
+	})
+
+	ctx := contextForToken("first")
+
+	// Requests are admitted until the budget is in debt.
+	require.NoError(t, call(limiter, ctx, checkMethod, 6))
+	require.NoError(t, call(limiter, ctx, checkMethod, 6))
+	requireExhausted(t, call(limiter, ctx, checkMethod, 0), 200*time.Millisecond)
+
+	clock.Add(200 * time.Millisecond)
+	require.NoError(t, call(limiter, ctx, checkMethod, 0))
+
+	// Other tenants are unaffected.
+	require.NoError(t, call(limiter, contextForToken("second"), checkMethod, 0))
+}
+
+func TestStreamDispatchBudget(t *testing.T) {
+	limiter, _ := newTestLimiter(t, Config{
+		DispatchesPerSecond: 1,
+	})
+
+	ctx := contextForToken("first")
+	interceptor := limiter.StreamServerInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/authzed.api.v1.PermissionsService/LookupResources"}
+
+	handler := func(srv any, stream grpc.ServerStream) error {
+		usagemetrics.SetInContext(stream.Context(), &dispatch.ResponseMeta{DispatchCount: 5})
+		return nil
+	}
+
+	require.NoError(t, interceptor(nil, &fakeServerStream{ctx: ctx}, info, handler))
+	err := interceptor(nil, &fakeServerStream{ctx: ctx}, info, handler)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestNewLimiterValidation(t *testing.T) {
+	_, err := NewLimiter(Config{RequestsPerSecond: -1})
+	require.ErrorContains(t, err, "must not be negative")
+
+	_, err = NewLimiter(Config{MethodRequestsPerSecond: map[string]float64{"CheckPermission": -1}})
+	require.ErrorContains(t, err, "rate limit for method `CheckPermission` must not be negative")
+
+	require.False(t, Config{}.Enabled())
+	require.True(t, Config{DispatchesPerSecond: 1}.Enabled())
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (fc *fakeClock) Now() time.Time {
+	return fc.now
+}
+
+func (fc *fakeClock) Add(d time.Duration) {
+	fc.now = fc.now.Add(d)
+}
+
+func newTestLimiter(t *testing.T, config Config) (*Limiter, *fakeClock) {
+	limiter, err := NewLimiter(config)
+	require.NoError(t, err)
+
+	clock := &fakeClock{now: time.Now()}
+	limiter.now = clock.Now
+	return limiter, clock
+}
+
+func contextForToken(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "bearer "+token))
+}
+
+// call invokes the unary interceptor for the given method, with the service-specific usage
+// metrics middleware reporting the given number of dispatches.
+func call(limiter *Limiter, ctx context.Context, method string, dispatchCount uint32) error {
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	_, err := limiter.UnaryServerInterceptor()(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		return usagemetrics.UnaryServerInterceptor()(ctx, req, info, func(ctx context.Context, _ any) (any, error) {
+			usagemetrics.SetInContext(ctx, &dispatch.ResponseMeta{DispatchCount: dispatchCount})
+			return nil, nil
+		})
+	})
+	return err
+}
+
+func requireExhausted(t *testing.T, err error, expectedDelay time.Duration) {
+	t.Helper()
+
+	s, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.ResourceExhausted, s.Code())
+
+	var foundRetryInfo bool
+	for _, detail := range s.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			require.Equal(t, expectedDelay, retryInfo.RetryDelay.AsDuration())
+			foundRetryInfo = true
+		}
+	}
+	require.True(t, foundRetryInfo)
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (fss *fakeServerStream) Context() context.Context {
+	return fss.ctx
+}
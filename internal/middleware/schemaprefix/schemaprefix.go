@@ -0,0 +1,251 @@
+// Package schemaprefix implements a middleware scoping requests to the v1 API to the schema
+// prefix to which their callers are bound, isolating callers bound to different prefixes.
+package schemaprefix
+
+import (
+	"context"
+	"strings"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/authzed/spicedb/internal/auth"
+)
+
+const v1ServicePrefix = "/authzed.api.v1."
+
+// scopedMethods are the v1 methods which can be called by callers bound to a prefix. The schema
+// methods are scoped by the schema service, which reads the prefix from the context.
+var scopedMethods = map[string]struct{}{
+	v1.PermissionsService_ReadRelationships_FullMethodName:        {},
+	v1.PermissionsService_WriteRelationships_FullMethodName:       {},
+	v1.PermissionsService_DeleteRelationships_FullMethodName:      {},
+	v1.PermissionsService_CheckPermission_FullMethodName:          {},
+	v1.PermissionsService_ExpandPermissionTree_FullMethodName:     {},
+	v1.PermissionsService_LookupResources_FullMethodName:          {},
+	v1.PermissionsService_LookupSubjects_FullMethodName:           {},
+	v1.ExperimentalService_BulkCheckPermission_FullMethodName:     {},
+	v1.ExperimentalService_BulkImportRelationships_FullMethodName: {},
+	v1.WatchService_Watch_FullMethodName:                          {},
+	v1.SchemaService_ReadSchema_FullMethodName:                    {},
+	v1.SchemaService_WriteSchema_FullMethodName:                   {},
+}
+
+type ctxKeyType struct{}
+
+var prefixKey ctxKeyType = struct{}{}
+
+// ContextWithPrefix returns a context carrying the schema prefix to which the caller is bound.
+func ContextWithPrefix(ctx context.Context, prefix string) context.Context {
+	return context.WithValue(ctx, prefixKey, prefix)
+}
+
+// FromContext returns the schema prefix to which the caller is bound, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	prefix, ok := ctx.Value(prefixKey).(string)
+	return prefix, ok && prefix != ""
+}
+
+// UnaryServerInterceptor returns a new unary server interceptor that scopes requests to the
+// schema prefix to which their callers are bound. Object types and caveat names without a prefix
+// are prefixed with the prefix of the caller, and those with another prefix are rejected.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		prefix, ok := boundPrefix(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		if err := checkMethod(info.FullMethod); err != nil {
+			return nil, err
+		}
+		if err := scopeRequest(prefix, req); err != nil {
+			return nil, err
+		}
+		return handler(ContextWithPrefix(ctx, prefix), req)
+	}
+}
+
+// StreamServerInterceptor returns a new stream server interceptor that scopes requests to the
+// schema prefix to which their callers are bound.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		prefix, ok := boundPrefix(stream.Context())
+		if !ok {
+			return handler(srv, stream)
+		}
+
+		if err := checkMethod(info.FullMethod); err != nil {
+			return err
+		}
+
+		wrapped := middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = ContextWithPrefix(stream.Context(), prefix)
+		return handler(srv, &scopedServerStream{WrappedServerStream: wrapped, prefix: prefix})
+	}
+}
+
+// scopedServerStream scopes each message received on a stream.
+type scopedServerStream struct {
+	*middleware.WrappedServerStream
+	prefix string
+}
+
+func (s *scopedServerStream) RecvMsg(m interface{}) error {
+	if err := s.WrappedServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return scopeRequest(s.prefix, m)
+}
+
+func boundPrefix(ctx context.Context) (string, bool) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims.SchemaPrefix == "" {
+		return "", false
+	}
+	return claims.SchemaPrefix, true
+}
+
+func checkMethod(fullMethod string) error {
+	if !strings.HasPrefix(fullMethod, v1ServicePrefix) {
+		return nil
+	}
+
+	if _, ok := scopedMethods[fullMethod]; !ok {
+		return status.Errorf(codes.PermissionDenied, "method %s cannot be called by callers bound to a schema prefix", fullMethod)
+	}
+	return nil
+}
+
+// scopeRequest scopes the object types and caveat names referenced by a request to the prefix.
+func scopeRequest(prefix string, req interface{}) error {
+	s := scoper{prefix: prefix}
+
+	switch req := req.(type) {
+	case *v1.CheckPermissionRequest:
+		s.objectReference(req.GetResource())
+		s.subjectReference(req.GetSubject())
+
+	case *v1.BulkCheckPermissionRequest:
+		for _, item := range req.GetItems() {
+			s.objectReference(item.GetResource())
+			s.subjectReference(item.GetSubject())
+		}
+
+	case *v1.ExpandPermissionTreeRequest:
+		s.objectReference(req.GetResource())
+
+	case *v1.LookupResourcesRequest:
+		s.objectType(&req.ResourceObjectType)
+		s.subjectReference(req.GetSubject())
+
+	case *v1.LookupSubjectsRequest:
+		s.objectReference(req.GetResource())
+		s.objectType(&req.SubjectObjectType)
+
+	case *v1.ReadRelationshipsRequest:
+		s.filter(req.GetRelationshipFilter())
+
+	case *v1.WriteRelationshipsRequest:
+		for _, update := range req.GetUpdates() {
+			s.relationship(update.GetRelationship())
+		}
+		s.preconditions(req.GetOptionalPreconditions())
+
+	case *v1.DeleteRelationshipsRequest:
+		s.filter(req.GetRelationshipFilter())
+		s.preconditions(req.GetOptionalPreconditions())
+
+	case *v1.BulkImportRelationshipsRequest:
+		for _, relationship := range req.GetRelationships() {
+			s.relationship(relationship)
+		}
+
+	case *v1.WatchRequest:
+		if len(req.GetOptionalObjectTypes()) == 0 {
+			return status.Errorf(codes.PermissionDenied, "callers bound to a schema prefix must specify the object types to watch")
+		}
+		for i := range req.OptionalObjectTypes {
+			s.objectType(&req.OptionalObjectTypes[i])
+		}
+	}
+
+	return s.err
+}
+
+// scoper scopes names to a prefix, recording the first name which cannot be scoped.
+type scoper struct {
+	prefix string
+	err    error
+}
+
+func (s *scoper) objectType(objectType *string) {
+	if *objectType == "" {
+		if s.err == nil {
+			s.err = status.Errorf(codes.PermissionDenied, "callers bound to a schema prefix must specify object types")
+		}
+		return
+	}
+	s.name(objectType)
+}
+
+func (s *scoper) name(name *string) {
+	if s.err != nil {
+		return
+	}
+
+	prefix, _, found := strings.Cut(*name, "/")
+	if !found {
+		*name = s.prefix + "/" + *name
+		return
+	}
+
+	if prefix != s.prefix {
+		s.err = status.Errorf(codes.PermissionDenied, "`%s` is outside of the schema prefix `%s` of the caller", *name, s.prefix)
+	}
+}
+
+func (s *scoper) objectReference(ref *v1.ObjectReference) {
+	// Missing references are rejected by the validation of the request.
+	if ref != nil {
+		s.objectType(&ref.ObjectType)
+	}
+}
+
+func (s *scoper) subjectReference(ref *v1.SubjectReference) {
+	if ref != nil {
+		s.objectReference(ref.Object)
+	}
+}
+
+func (s *scoper) relationship(relationship *v1.Relationship) {
+	if relationship == nil {
+		return
+	}
+
+	s.objectReference(relationship.Resource)
+	s.subjectReference(relationship.Subject)
+	if caveat := relationship.OptionalCaveat; caveat != nil && caveat.CaveatName != "" {
+		s.name(&caveat.CaveatName)
+	}
+}
+
+func (s *scoper) filter(filter *v1.RelationshipFilter) {
+	if filter == nil {
+		return
+	}
+
+	s.objectType(&filter.ResourceType)
+	if subjectFilter := filter.OptionalSubjectFilter; subjectFilter != nil {
+		s.objectType(&subjectFilter.SubjectType)
+	}
+}
+
+func (s *scoper) preconditions(preconditions []*v1.Precondition) {
+	for _, precondition := range preconditions {
+		s.filter(precondition.GetFilter())
+	}
+}
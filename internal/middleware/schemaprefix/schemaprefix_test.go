@@ -0,0 +1,271 @@
+package schemaprefix
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/grpcutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/authzed/spicedb/internal/auth"
+)
+
+func TestUnaryScoping(t *testing.T) {
+	tcs := []struct {
+		name         string
+		method       string
+		req          proto.Message
+		expectedReq  proto.Message
+		expectedCode codes.Code
+	}{
+		{
+			"check without prefixes",
+			v1.PermissionsService_CheckPermission_FullMethodName,
+			&v1.CheckPermissionRequest{
+				Resource:   &v1.ObjectReference{ObjectType: "document", ObjectId: "doc"},
+				Permission: "view",
+				Subject:    &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "user", ObjectId: "tom"}},
+			},
+			&v1.CheckPermissionRequest{
+				Resource:   &v1.ObjectReference{ObjectType: "tenant1/document", ObjectId: "doc"},
+				Permission: "view",
+				Subject:    &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "tenant1/user", ObjectId: "tom"}},
+			},
+			codes.OK,
+		},
+		{
+			"check with the prefix of the caller",
+			v1.PermissionsService_CheckPermission_FullMethodName,
+			&v1.CheckPermissionRequest{
+				Resource:   &v1.ObjectReference{ObjectType: "tenant1/document", ObjectId: "doc"},
+				Permission: "view",
+				Subject:    &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "user", ObjectId: "tom"}},
+			},
+			&v1.CheckPermissionRequest{
+				Resource:   &v1.ObjectReference{ObjectType: "tenant1/document", ObjectId: "doc"},
+				Permission: "view",
+				Subject:    &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "tenant1/user", ObjectId: "tom"}},
+			},
+			codes.OK,
+		},
+		{
+			"check with another prefix",
+			v1.PermissionsService_CheckPermission_FullMethodName,
+			&v1.CheckPermissionRequest{
+				Resource:   &v1.ObjectReference{ObjectType: "tenant2/document", ObjectId: "doc"},
+				Permission: "view",
+				Subject:    &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "user", ObjectId: "tom"}},
+			},
+			nil,
+			codes.PermissionDenied,
+		},
+		{
+			"lookup resources",
+			v1.PermissionsService_LookupResources_FullMethodName,
+			&v1.LookupResourcesRequest{
+				ResourceObjectType: "document",
+				Permission:         "view",
+				Subject:            &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "user", ObjectId: "tom"}},
+			},
+			&v1.LookupResourcesRequest{
+				ResourceObjectType: "tenant1/document",
+				Permission:         "view",
+				Subject:            &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "tenant1/user", ObjectId: "tom"}},
+			},
+			codes.OK,
+		},
+		{
+			"write with caveat",
+			v1.PermissionsService_WriteRelationships_FullMethodName,
+			&v1.WriteRelationshipsRequest{
+				Updates: []*v1.RelationshipUpdate{{
+					Operation: v1.RelationshipUpdate_OPERATION_TOUCH,
+					Relationship: &v1.Relationship{
+						Resource:       &v1.ObjectReference{ObjectType: "document", ObjectId: "doc"},
+						Relation:       "viewer",
+						Subject:        &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "user", ObjectId: "tom"}},
+						OptionalCaveat: &v1.ContextualizedCaveat{CaveatName: "somecaveat"},
+					},
+				}},
+				OptionalPreconditions: []*v1.Precondition{{
+					Operation: v1.Precondition_OPERATION_MUST_NOT_MATCH,
+					Filter:    &v1.RelationshipFilter{ResourceType: "folder"},
+				}},
+			},
+			&v1.WriteRelationshipsRequest{
+				Updates: []*v1.RelationshipUpdate{{
+					Operation: v1.RelationshipUpdate_OPERATION_TOUCH,
+					Relationship: &v1.Relationship{
+						Resource:       &v1.ObjectReference{ObjectType: "tenant1/document", ObjectId: "doc"},
+						Relation:       "viewer",
+						Subject:        &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "tenant1/user", ObjectId: "tom"}},
+						OptionalCaveat: &v1.ContextualizedCaveat{CaveatName: "tenant1/somecaveat"},
+					},
+				}},
+				OptionalPreconditions: []*v1.Precondition{{
+					Operation: v1.Precondition_OPERATION_MUST_NOT_MATCH,
+					Filter:    &v1.RelationshipFilter{ResourceType: "tenant1/folder"},
+				}},
+			},
+			codes.OK,
+		},
+		{
+			"write with caveat of another prefix",
+			v1.PermissionsService_WriteRelationships_FullMethodName,
+			&v1.WriteRelationshipsRequest{
+				Updates: []*v1.RelationshipUpdate{{
+					Operation: v1.RelationshipUpdate_OPERATION_TOUCH,
+					Relationship: &v1.Relationship{
+						Resource:       &v1.ObjectReference{ObjectType: "document", ObjectId: "doc"},
+						Relation:       "viewer",
+						Subject:        &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "user", ObjectId: "tom"}},
+						OptionalCaveat: &v1.ContextualizedCaveat{CaveatName: "tenant2/somecaveat"},
+					},
+				}},
+			},
+			nil,
+			codes.PermissionDenied,
+		},
+		{
+			"delete with subject filter",
+			v1.PermissionsService_DeleteRelationships_FullMethodName,
+			&v1.DeleteRelationshipsRequest{
+				RelationshipFilter: &v1.RelationshipFilter{
+					ResourceType:          "document",
+					OptionalSubjectFilter: &v1.SubjectFilter{SubjectType: "tenant1/user"},
+				},
+			},
+			&v1.DeleteRelationshipsRequest{
+				RelationshipFilter: &v1.RelationshipFilter{
+					ResourceType:          "tenant1/document",
+					OptionalSubjectFilter: &v1.SubjectFilter{SubjectType: "tenant1/user"},
+				},
+			},
+			codes.OK,
+		},
+		{
+			"read without resource type",
+			v1.PermissionsService_ReadRelationships_FullMethodName,
+			&v1.ReadRelationshipsRequest{RelationshipFilter: &v1.RelationshipFilter{}},
+			nil,
+			codes.PermissionDenied,
+		},
+		{
+			"watch without object types",
+			v1.WatchService_Watch_FullMethodName,
+			&v1.WatchRequest{},
+			nil,
+			codes.PermissionDenied,
+		},
+		{
+			"bulk export",
+			v1.ExperimentalService_BulkExportRelationships_FullMethodName,
+			&v1.BulkExportRelationshipsRequest{},
+			nil,
+			codes.PermissionDenied,
+		},
+		{
+			"read schema",
+			v1.SchemaService_ReadSchema_FullMethodName,
+			&v1.ReadSchemaRequest{},
+			&v1.ReadSchemaRequest{},
+			codes.OK,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{Subject: "caller", SchemaPrefix: "tenant1"})
+			_, err := UnaryServerInterceptor()(ctx, tc.req, &grpc.UnaryServerInfo{FullMethod: tc.method}, func(ctx context.Context, req any) (any, error) {
+				prefix, ok := FromContext(ctx)
+				require.True(t, ok)
+				require.Equal(t, "tenant1", prefix)
+				return nil, nil
+			})
+			if tc.expectedCode != codes.OK {
+				grpcutil.RequireStatus(t, tc.expectedCode, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tc.expectedReq, tc.req), "got %v", tc.req)
+		})
+	}
+}
+
+func TestUnboundCallers(t *testing.T) {
+	req := &v1.LookupResourcesRequest{ResourceObjectType: "document"}
+	for _, ctx := range []context.Context{
+		context.Background(),
+		auth.ContextWithClaims(context.Background(), &auth.Claims{Subject: "caller"}),
+	} {
+		_, err := UnaryServerInterceptor()(ctx, req, &grpc.UnaryServerInfo{FullMethod: v1.PermissionsService_LookupResources_FullMethodName}, func(ctx context.Context, req any) (any, error) {
+			_, ok := FromContext(ctx)
+			require.False(t, ok)
+			return nil, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "document", req.ResourceObjectType)
+	}
+}
+
+func TestStreamScoping(t *testing.T) {
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{SchemaPrefix: "tenant1"})
+	info := &grpc.StreamServerInfo{FullMethod: v1.ExperimentalService_BulkImportRelationships_FullMethodName}
+
+	stream := &fakeServerStream{ctx: ctx, messages: [][]*v1.Relationship{
+		{{
+			Resource: &v1.ObjectReference{ObjectType: "document", ObjectId: "first"},
+			Relation: "viewer",
+			Subject:  &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "user", ObjectId: "tom"}},
+		}},
+		{{
+			Resource: &v1.ObjectReference{ObjectType: "tenant2/document", ObjectId: "second"},
+			Relation: "viewer",
+			Subject:  &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "user", ObjectId: "tom"}},
+		}},
+	}}
+
+	var received []*v1.BulkImportRelationshipsRequest
+	err := StreamServerInterceptor()(nil, stream, info, func(_ any, stream grpc.ServerStream) error {
+		prefix, ok := FromContext(stream.Context())
+		require.True(t, ok)
+		require.Equal(t, "tenant1", prefix)
+
+		for {
+			req := &v1.BulkImportRelationshipsRequest{}
+			if err := stream.RecvMsg(req); err != nil {
+				return err
+			}
+			received = append(received, req)
+		}
+	})
+	grpcutil.RequireStatus(t, codes.PermissionDenied, err)
+	require.Len(t, received, 1)
+	require.Equal(t, "tenant1/document", received[0].Relationships[0].Resource.ObjectType)
+	require.Equal(t, "tenant1/user", received[0].Relationships[0].Subject.Object.ObjectType)
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	messages [][]*v1.Relationship
+}
+
+func (fss *fakeServerStream) Context() context.Context {
+	return fss.ctx
+}
+
+func (fss *fakeServerStream) RecvMsg(m any) error {
+	if len(fss.messages) == 0 {
+		return context.Canceled
+	}
+	m.(*v1.BulkImportRelationshipsRequest).Relationships = fss.messages[0]
+	fss.messages = fss.messages[1:]
+	return nil
+}
@@ -0,0 +1,221 @@
+// Package tokenscope implements a middleware enforcing the scopes granted by the tokens with
+// which requests to the v1 API are authenticated.
+package tokenscope
+
+import (
+	"context"
+	"strings"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/authzed/spicedb/internal/auth"
+)
+
+const v1ServicePrefix = "/authzed.api.v1."
+
+// requiredScope is the scope required to call a method.
+type requiredScope struct {
+	area   string
+	access string
+
+	// unrestrictedOnly is set for methods which cannot be limited to object types, and so are
+	// only allowed for tokens which are not restricted to prefixes.
+	unrestrictedOnly bool
+}
+
+var (
+	readRelationships  = requiredScope{area: auth.AreaRelationships, access: auth.AccessRead}
+	writeRelationships = requiredScope{area: auth.AreaRelationships, access: auth.AccessWrite}
+)
+
+// methodScopes are the scopes required to call each v1 method. Methods of the v1 API which are
+// not listed cannot be called with tokens carrying scopes.
+var methodScopes = map[string]requiredScope{
+	v1.PermissionsService_ReadRelationships_FullMethodName:    readRelationships,
+	v1.PermissionsService_CheckPermission_FullMethodName:      readRelationships,
+	v1.PermissionsService_ExpandPermissionTree_FullMethodName: readRelationships,
+	v1.PermissionsService_LookupResources_FullMethodName:      readRelationships,
+	v1.PermissionsService_LookupSubjects_FullMethodName:       readRelationships,
+	v1.ExperimentalService_BulkCheckPermission_FullMethodName: readRelationships,
+	v1.WatchService_Watch_FullMethodName:                      readRelationships,
+
+	v1.ExperimentalService_BulkExportRelationships_FullMethodName: {
+		area:             auth.AreaRelationships,
+		access:           auth.AccessRead,
+		unrestrictedOnly: true,
+	},
+
+	v1.PermissionsService_WriteRelationships_FullMethodName:       writeRelationships,
+	v1.PermissionsService_DeleteRelationships_FullMethodName:      writeRelationships,
+	v1.ExperimentalService_BulkImportRelationships_FullMethodName: writeRelationships,
+
+	// The schema is read and written as a whole, and so covers all object types.
+	v1.SchemaService_ReadSchema_FullMethodName:  {area: auth.AreaSchema, access: auth.AccessRead, unrestrictedOnly: true},
+	v1.SchemaService_WriteSchema_FullMethodName: {area: auth.AreaSchema, access: auth.AccessWrite, unrestrictedOnly: true},
+}
+
+// UnaryServerInterceptor returns a new unary server interceptor that enforces the scopes of the
+// token with which a request was authenticated. Requests authenticated otherwise are unaffected.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		claims, ok := auth.ClaimsFromContext(ctx)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		if err := checkMethod(claims, info.FullMethod); err != nil {
+			return nil, err
+		}
+		if err := checkObjectTypes(claims, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a new stream server interceptor that enforces the scopes of
+// the token with which a request was authenticated. Requests authenticated otherwise are
+// unaffected.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		claims, ok := auth.ClaimsFromContext(stream.Context())
+		if !ok {
+			return handler(srv, stream)
+		}
+
+		if err := checkMethod(claims, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, &scopedServerStream{WrappedServerStream: middleware.WrapServerStream(stream), claims: claims})
+	}
+}
+
+// scopedServerStream checks the object types of each message received on a stream.
+type scopedServerStream struct {
+	*middleware.WrappedServerStream
+	claims *auth.Claims
+}
+
+func (s *scopedServerStream) RecvMsg(m interface{}) error {
+	if err := s.WrappedServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return checkObjectTypes(s.claims, m)
+}
+
+func checkMethod(claims *auth.Claims, fullMethod string) error {
+	if !strings.HasPrefix(fullMethod, v1ServicePrefix) {
+		return nil
+	}
+
+	required, ok := methodScopes[fullMethod]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "method %s cannot be called with a scoped token", fullMethod)
+	}
+
+	if !claims.Scopes.Allows(required.area, required.access) {
+		return status.Errorf(codes.PermissionDenied, "token does not grant scope `%s:%s` required by method %s", required.area, required.access, fullMethod)
+	}
+
+	if required.unrestrictedOnly && claims.Scopes.IsPrefixRestricted() {
+		return status.Errorf(codes.PermissionDenied, "method %s cannot be called with a token restricted to object type prefixes", fullMethod)
+	}
+	return nil
+}
+
+// checkObjectTypes checks that the object types referenced by a request are allowed by the
+// scopes of its token.
+func checkObjectTypes(claims *auth.Claims, req interface{}) error {
+	if !claims.Scopes.IsPrefixRestricted() {
+		return nil
+	}
+
+	for _, objectType := range referencedObjectTypes(req) {
+		if !claims.Scopes.AllowsObjectType(objectType) {
+			if objectType == "" {
+				return status.Errorf(codes.PermissionDenied, "requests with a token restricted to object type prefixes must specify object types")
+			}
+			return status.Errorf(codes.PermissionDenied, "token does not grant access to object type `%s`", objectType)
+		}
+	}
+	return nil
+}
+
+// referencedObjectTypes returns the object types referenced by a request. An empty object type
+// is returned for requests which may reference any object type.
+func referencedObjectTypes(req interface{}) []string {
+	switch req := req.(type) {
+	case *v1.CheckPermissionRequest:
+		return []string{req.GetResource().GetObjectType(), req.GetSubject().GetObject().GetObjectType()}
+
+	case *v1.BulkCheckPermissionRequest:
+		objectTypes := make([]string, 0, 2*len(req.GetItems()))
+		for _, item := range req.GetItems() {
+			objectTypes = append(objectTypes, item.GetResource().GetObjectType(), item.GetSubject().GetObject().GetObjectType())
+		}
+		return objectTypes
+
+	case *v1.ExpandPermissionTreeRequest:
+		return []string{req.GetResource().GetObjectType()}
+
+	case *v1.LookupResourcesRequest:
+		return []string{req.GetResourceObjectType(), req.GetSubject().GetObject().GetObjectType()}
+
+	case *v1.LookupSubjectsRequest:
+		return []string{req.GetResource().GetObjectType(), req.GetSubjectObjectType()}
+
+	case *v1.ReadRelationshipsRequest:
+		return filterObjectTypes(req.GetRelationshipFilter())
+
+	case *v1.WriteRelationshipsRequest:
+		objectTypes := make([]string, 0, 2*len(req.GetUpdates()))
+		for _, update := range req.GetUpdates() {
+			objectTypes = append(objectTypes, relationshipObjectTypes(update.GetRelationship())...)
+		}
+		return append(objectTypes, preconditionObjectTypes(req.GetOptionalPreconditions())...)
+
+	case *v1.DeleteRelationshipsRequest:
+		objectTypes := filterObjectTypes(req.GetRelationshipFilter())
+		return append(objectTypes, preconditionObjectTypes(req.GetOptionalPreconditions())...)
+
+	case *v1.BulkImportRelationshipsRequest:
+		objectTypes := make([]string, 0, 2*len(req.GetRelationships()))
+		for _, relationship := range req.GetRelationships() {
+			objectTypes = append(objectTypes, relationshipObjectTypes(relationship)...)
+		}
+		return objectTypes
+
+	case *v1.WatchRequest:
+		if len(req.GetOptionalObjectTypes()) == 0 {
+			return []string{""}
+		}
+		return req.GetOptionalObjectTypes()
+
+	default:
+		return nil
+	}
+}
+
+func relationshipObjectTypes(relationship *v1.Relationship) []string {
+	return []string{relationship.GetResource().GetObjectType(), relationship.GetSubject().GetObject().GetObjectType()}
+}
+
+func filterObjectTypes(filter *v1.RelationshipFilter) []string {
+	objectTypes := []string{filter.GetResourceType()}
+	if subjectFilter := filter.GetOptionalSubjectFilter(); subjectFilter != nil {
+		objectTypes = append(objectTypes, subjectFilter.GetSubjectType())
+	}
+	return objectTypes
+}
+
+func preconditionObjectTypes(preconditions []*v1.Precondition) []string {
+	objectTypes := make([]string, 0, len(preconditions))
+	for _, precondition := range preconditions {
+		objectTypes = append(objectTypes, filterObjectTypes(precondition.GetFilter())...)
+	}
+	return objectTypes
+}
@@ -0,0 +1,219 @@
+package tokenscope
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/grpcutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/authzed/spicedb/internal/auth"
+)
+
+func TestUnaryScopes(t *testing.T) {
+	tenantDoc := &v1.ObjectReference{ObjectType: "tenant1/document", ObjectId: "doc"}
+	otherDoc := &v1.ObjectReference{ObjectType: "tenant2/document", ObjectId: "doc"}
+	tenantUser := &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "tenant1/user", ObjectId: "tom"}}
+
+	tcs := []struct {
+		name         string
+		scopes       []string
+		method       string
+		req          any
+		expectedCode codes.Code
+	}{
+		{
+			"check with read scope",
+			[]string{"relationships:read"},
+			v1.PermissionsService_CheckPermission_FullMethodName,
+			&v1.CheckPermissionRequest{Resource: tenantDoc, Permission: "view", Subject: tenantUser},
+			codes.OK,
+		},
+		{
+			"check without read scope",
+			[]string{"schema:read"},
+			v1.PermissionsService_CheckPermission_FullMethodName,
+			&v1.CheckPermissionRequest{Resource: tenantDoc, Permission: "view", Subject: tenantUser},
+			codes.PermissionDenied,
+		},
+		{
+			"write with read scope",
+			[]string{"relationships:read"},
+			v1.PermissionsService_WriteRelationships_FullMethodName,
+			&v1.WriteRelationshipsRequest{},
+			codes.PermissionDenied,
+		},
+		{
+			"write with wildcard scope",
+			[]string{"*:write"},
+			v1.PermissionsService_WriteRelationships_FullMethodName,
+			&v1.WriteRelationshipsRequest{},
+			codes.OK,
+		},
+		{
+			"check within prefix",
+			[]string{"relationships:read", "prefix:tenant1/"},
+			v1.PermissionsService_CheckPermission_FullMethodName,
+			&v1.CheckPermissionRequest{Resource: tenantDoc, Permission: "view", Subject: tenantUser},
+			codes.OK,
+		},
+		{
+			"check outside prefix",
+			[]string{"relationships:read", "prefix:tenant1/"},
+			v1.PermissionsService_CheckPermission_FullMethodName,
+			&v1.CheckPermissionRequest{Resource: otherDoc, Permission: "view", Subject: tenantUser},
+			codes.PermissionDenied,
+		},
+		{
+			"write outside prefix",
+			[]string{"relationships:write", "prefix:tenant1/"},
+			v1.PermissionsService_WriteRelationships_FullMethodName,
+			&v1.WriteRelationshipsRequest{Updates: []*v1.RelationshipUpdate{{
+				Operation:    v1.RelationshipUpdate_OPERATION_TOUCH,
+				Relationship: &v1.Relationship{Resource: otherDoc, Relation: "viewer", Subject: tenantUser},
+			}}},
+			codes.PermissionDenied,
+		},
+		{
+			"precondition outside prefix",
+			[]string{"relationships:write", "prefix:tenant1/"},
+			v1.PermissionsService_DeleteRelationships_FullMethodName,
+			&v1.DeleteRelationshipsRequest{
+				RelationshipFilter: &v1.RelationshipFilter{ResourceType: "tenant1/document"},
+				OptionalPreconditions: []*v1.Precondition{{
+					Operation: v1.Precondition_OPERATION_MUST_MATCH,
+					Filter:    &v1.RelationshipFilter{ResourceType: "tenant2/document"},
+				}},
+			},
+			codes.PermissionDenied,
+		},
+		{
+			"read within prefix",
+			[]string{"relationships:read", "prefix:tenant1/"},
+			v1.PermissionsService_ReadRelationships_FullMethodName,
+			&v1.ReadRelationshipsRequest{RelationshipFilter: &v1.RelationshipFilter{
+				ResourceType:          "tenant1/document",
+				OptionalSubjectFilter: &v1.SubjectFilter{SubjectType: "tenant1/user"},
+			}},
+			codes.OK,
+		},
+		{
+			"schema with prefix",
+			[]string{"schema:*", "prefix:tenant1/"},
+			v1.SchemaService_ReadSchema_FullMethodName,
+			&v1.ReadSchemaRequest{},
+			codes.PermissionDenied,
+		},
+		{
+			"schema without prefix",
+			[]string{"schema:*"},
+			v1.SchemaService_WriteSchema_FullMethodName,
+			&v1.WriteSchemaRequest{},
+			codes.OK,
+		},
+		{
+			"watch all types with prefix",
+			[]string{"relationships:read", "prefix:tenant1/"},
+			v1.WatchService_Watch_FullMethodName,
+			&v1.WatchRequest{},
+			codes.PermissionDenied,
+		},
+		{
+			"unknown v1 method",
+			[]string{"*"},
+			"/authzed.api.v1.SomeService/SomeMethod",
+			nil,
+			codes.PermissionDenied,
+		},
+		{
+			"non-v1 method",
+			nil,
+			"/grpc.health.v1.Health/Check",
+			nil,
+			codes.OK,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{Subject: "caller", Scopes: auth.ParseScopes(tc.scopes)})
+			_, err := UnaryServerInterceptor()(ctx, tc.req, &grpc.UnaryServerInfo{FullMethod: tc.method}, func(context.Context, any) (any, error) {
+				return nil, nil
+			})
+			if tc.expectedCode == codes.OK {
+				require.NoError(t, err)
+				return
+			}
+			grpcutil.RequireStatus(t, tc.expectedCode, err)
+		})
+	}
+}
+
+func TestUnscopedRequests(t *testing.T) {
+	// Requests authenticated without claims, e.g. with a preshared key, are unrestricted.
+	_, err := UnaryServerInterceptor()(context.Background(), &v1.WriteSchemaRequest{}, &grpc.UnaryServerInfo{FullMethod: v1.SchemaService_WriteSchema_FullMethodName}, func(context.Context, any) (any, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+}
+
+func TestStreamScopes(t *testing.T) {
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{Scopes: auth.ParseScopes([]string{"relationships:write", "prefix:tenant1/"})})
+	info := &grpc.StreamServerInfo{FullMethod: v1.ExperimentalService_BulkImportRelationships_FullMethodName}
+
+	stream := &fakeServerStream{ctx: ctx, messages: []*v1.BulkImportRelationshipsRequest{
+		{Relationships: []*v1.Relationship{{
+			Resource: &v1.ObjectReference{ObjectType: "tenant1/document", ObjectId: "first"},
+			Relation: "viewer",
+			Subject:  &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "tenant1/user", ObjectId: "tom"}},
+		}}},
+		{Relationships: []*v1.Relationship{{
+			Resource: &v1.ObjectReference{ObjectType: "tenant2/document", ObjectId: "second"},
+			Relation: "viewer",
+			Subject:  &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "tenant1/user", ObjectId: "tom"}},
+		}}},
+	}}
+
+	received := 0
+	err := StreamServerInterceptor()(nil, stream, info, func(_ any, stream grpc.ServerStream) error {
+		for {
+			if err := stream.RecvMsg(&v1.BulkImportRelationshipsRequest{}); err != nil {
+				return err
+			}
+			received++
+		}
+	})
+	grpcutil.RequireStatus(t, codes.PermissionDenied, err)
+	require.Equal(t, 1, received)
+
+	// The method scope is checked before the stream is handled.
+	readOnly := auth.ContextWithClaims(context.Background(), &auth.Claims{Scopes: auth.ParseScopes([]string{"relationships:read"})})
+	err = StreamServerInterceptor()(nil, &fakeServerStream{ctx: readOnly}, info, func(any, grpc.ServerStream) error {
+		require.Fail(t, "stream should not be handled")
+		return nil
+	})
+	grpcutil.RequireStatus(t, codes.PermissionDenied, err)
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	messages []*v1.BulkImportRelationshipsRequest
+}
+
+func (fss *fakeServerStream) Context() context.Context {
+	return fss.ctx
+}
+
+func (fss *fakeServerStream) RecvMsg(m any) error {
+	if len(fss.messages) == 0 {
+		return context.Canceled
+	}
+	m.(*v1.BulkImportRelationshipsRequest).Relationships = fss.messages[0].Relationships
+	fss.messages = fss.messages[1:]
+	return nil
+}
@@ -37,7 +37,11 @@ type reporter struct{}
 
 func (r *reporter) ServerReporter(ctx context.Context, callMeta interceptors.CallMeta) (interceptors.Reporter, context.Context) {
 	_, methodName := grpcutil.SplitMethodName(callMeta.FullMethod())
-	ctx = ContextWithHandle(ctx)
+
+	// Reuse a handle created by an outer middleware, so that it can observe the metadata.
+	if ctx.Value(metadataCtxKey) == nil {
+		ctx = ContextWithHandle(ctx)
+	}
 	return &serverReporter{ctx: ctx, methodName: methodName}, ctx
 }
 
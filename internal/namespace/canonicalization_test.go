@@ -502,6 +502,24 @@ func TestCanonicalizationComparison(t *testing.T) {
 			"(owner & nil) & editor",
 			true,
 		},
+		{
+			"arrow and any arrow function",
+			"viewer->owner",
+			"viewer.any(owner)",
+			true,
+		},
+		{
+			"any and all arrow functions",
+			"viewer.any(owner)",
+			"viewer.all(owner)",
+			false,
+		},
+		{
+			"all arrow function union associativity",
+			"viewer.all(owner) + editor",
+			"editor + viewer.all(owner)",
+			true,
+		},
 	}
 
 	for _, tc := range testCases {
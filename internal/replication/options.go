@@ -0,0 +1,91 @@
+package replication
+
+import "time"
+
+const (
+	// DefaultBatchSize is the number of relationships copied per transaction
+	// during the initial copy, unless otherwise specified.
+	DefaultBatchSize = 1000
+
+	defaultLagReportInterval = 10 * time.Second
+	defaultStateSaveInterval = 1 * time.Second
+	defaultReconnectDelay    = 1 * time.Second
+)
+
+type replicatorOptions struct {
+	batchSize         uint64
+	statePath         string
+	lagReportInterval time.Duration
+	stateSaveInterval time.Duration
+	reconnectDelay    time.Duration
+	onLag             func(lag time.Duration)
+}
+
+// Option provides the facility to configure how the replicator copies and
+// tails the source datastore.
+type Option func(*replicatorOptions)
+
+func generateConfig(options []Option) replicatorOptions {
+	computed := replicatorOptions{
+		batchSize:         DefaultBatchSize,
+		lagReportInterval: defaultLagReportInterval,
+		stateSaveInterval: defaultStateSaveInterval,
+		reconnectDelay:    defaultReconnectDelay,
+	}
+
+	for _, option := range options {
+		option(&computed)
+	}
+
+	return computed
+}
+
+// BatchSize is the number of relationships copied per transaction during the
+// initial copy.
+//
+// This value defaults to DefaultBatchSize.
+func BatchSize(batchSize uint64) Option {
+	return func(ro *replicatorOptions) {
+		ro.batchSize = batchSize
+	}
+}
+
+// StatePath is the path of the file in which the last source revision
+// applied to the destination is recorded, along with the progress of the
+// initial copy until it completes. If the file exists when the replicator
+// starts, an interrupted initial copy is resumed, or the initial copy is
+// skipped and replication resumes after the recorded revision.
+//
+// By default, no state is recorded.
+func StatePath(path string) Option {
+	return func(ro *replicatorOptions) {
+		ro.statePath = path
+	}
+}
+
+// LagReportInterval is the interval at which the head revision of the source
+// is sampled, and the replication lag reported.
+//
+// This value defaults to 10 seconds.
+func LagReportInterval(interval time.Duration) Option {
+	return func(ro *replicatorOptions) {
+		ro.lagReportInterval = interval
+	}
+}
+
+// ReconnectDelay is the amount of time to wait before reconnecting a watch
+// on the source which was disconnected.
+//
+// This value defaults to 1 second.
+func ReconnectDelay(delay time.Duration) Option {
+	return func(ro *replicatorOptions) {
+		ro.reconnectDelay = delay
+	}
+}
+
+// OnLag is invoked with the replication lag every time it is reported.
+func OnLag(onLag func(lag time.Duration)) Option {
+	return func(ro *replicatorOptions) {
+		ro.onLag = onLag
+	}
+}
@@ -0,0 +1,604 @@
+// Package replication implements live replication of the schema and relationships of one
+// datastore into another, possibly of a different engine, by performing an initial copy at a
+// revision and then continuously applying the changes reported by Watch on the source.
+//
+// The history of the schema is not replicated, as Watch does not report the versions of the
+// schema written; the destination only records the versions of the schema written to it directly.
+package replication
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/datastore"
+	dsoptions "github.com/authzed/spicedb/pkg/datastore/options"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/spiceerrors"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+var replicationLagGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: "spicedb",
+	Subsystem: "datastore",
+	Name:      "replication_lag_seconds",
+	Help:      "amount of time since the oldest source head revision not yet applied to the destination was observed",
+})
+
+var replicatedChangesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "spicedb",
+	Subsystem: "datastore",
+	Name:      "replicated_changes_total",
+	Help:      "total number of changes applied to the destination datastore",
+}, []string{"kind"})
+
+func init() {
+	prometheus.MustRegister(replicationLagGauge, replicatedChangesCounter)
+}
+
+// Replicator copies the schema and relationships of a source datastore into a destination
+// datastore, and then tails the source to apply its changes to the destination.
+type Replicator struct {
+	source      datastore.Datastore
+	destination datastore.Datastore
+	options     replicatorOptions
+
+	// pendingHeads are the head revisions of the source, sampled over time, which have not yet
+	// been applied to the destination.
+	pendingHeads []headSample
+
+	lastStateSave time.Time
+}
+
+type headSample struct {
+	revision datastore.Revision
+	sampled  time.Time
+}
+
+// NewReplicator creates a new replicator from the source to the destination datastore.
+func NewReplicator(source, destination datastore.Datastore, options ...Option) *Replicator {
+	return &Replicator{
+		source:      source,
+		destination: destination,
+		options:     generateConfig(options),
+	}
+}
+
+// Run replicates the source into the destination until the context is canceled.
+//
+// Unless the state path contains a previously applied revision, the destination must be empty,
+// and the schema and relationships of the source at its head revision are first copied into it.
+// An interrupted initial copy is resumed from the progress recorded in the state path.
+//
+// If the source cannot report schema changes, only relationship changes are replicated.
+func (r *Replicator) Run(ctx context.Context) error {
+	content, err := r.watchContent(ctx)
+	if err != nil {
+		return err
+	}
+
+	revision, progress, err := r.loadState()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case revision == nil:
+		revision, err = r.initialCopy(ctx, nil, nil)
+		if err != nil {
+			return err
+		}
+
+	case progress != nil:
+		log.Ctx(ctx).Info().Str("revision", revision.String()).Str("resourceType", progress.ResourceType).Msg("resuming initial copy")
+		revision, err = r.initialCopy(ctx, revision, progress)
+		if err != nil {
+			return err
+		}
+
+	default:
+		log.Ctx(ctx).Info().Str("revision", revision.String()).Msg("resuming replication")
+	}
+
+	return r.tail(ctx, revision, content)
+}
+
+// watchContent returns the content of the watch on the source, which includes schema changes
+// only if the source can report them.
+func (r *Replicator) watchContent(ctx context.Context) (datastore.WatchContent, error) {
+	features, err := r.source.Features(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("unable to determine source features: %w", err)
+	}
+
+	if !features.Watch.Enabled {
+		return 0, fmt.Errorf("cannot replicate from a source datastore without Watch support: %s", features.Watch.Reason)
+	}
+
+	if !features.WatchSchema.Enabled {
+		log.Ctx(ctx).Warn().Str("reason", features.WatchSchema.Reason).
+			Msg("source datastore cannot report schema changes; only relationship changes are replicated, and schema changes must also be applied to the destination")
+		return datastore.WatchRelationships | datastore.WatchCheckpoints, nil
+	}
+
+	return datastore.WatchRelationships | datastore.WatchSchema | datastore.WatchCheckpoints, nil
+}
+
+// initialCopy copies the schema and all relationships of the source at a revision into the
+// destination, returning the revision at which they were copied. Unless resuming a copy at the
+// given revision from the given progress, the copy is made at the head revision of the source.
+//
+// The relationships of each resource type are copied in order of name, and the progress of the
+// copy is recorded after each batch.
+func (r *Replicator) initialCopy(ctx context.Context, revision datastore.Revision, progress *copyProgress) (datastore.Revision, error) {
+	resuming := progress != nil
+	if !resuming {
+		if err := ensureEmpty(ctx, r.destination); err != nil {
+			return nil, err
+		}
+
+		head, err := r.source.HeadRevision(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compute source head revision: %w", err)
+		}
+		revision = head
+		progress = &copyProgress{}
+
+		// The progress is recorded before anything is written, so that an interrupted copy is
+		// resumed rather than rejected for a destination which is no longer empty.
+		if err := r.saveState(revision, progress); err != nil {
+			return nil, err
+		}
+
+		log.Ctx(ctx).Info().Str("revision", revision.String()).Msg("starting initial copy")
+	}
+
+	var after dsoptions.Cursor
+	if progress.After != "" {
+		after = tuple.Parse(progress.After)
+		if after == nil {
+			return nil, fmt.Errorf("unable to parse initial copy progress in %s", r.options.statePath)
+		}
+	}
+
+	reader := r.source.SnapshotReader(revision)
+	namespaces, err := reader.ListAllNamespaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read namespaces: %w", err)
+	}
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].Definition.Name < namespaces[j].Definition.Name
+	})
+
+	caveats, err := reader.ListAllCaveats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read caveats: %w", err)
+	}
+
+	// Writing the schema is idempotent, so it is written again when resuming.
+	_, err = r.destination.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		caveatDefs := make([]*core.CaveatDefinition, 0, len(caveats))
+		for _, caveat := range caveats {
+			caveatDefs = append(caveatDefs, caveat.Definition)
+		}
+
+		if err := rwt.WriteCaveats(ctx, caveatDefs); err != nil {
+			return err
+		}
+
+		nsDefs := make([]*core.NamespaceDefinition, 0, len(namespaces))
+		for _, ns := range namespaces {
+			nsDefs = append(nsDefs, ns.Definition)
+		}
+
+		return rwt.WriteNamespaces(ctx, nsDefs...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to write schema: %w", err)
+	}
+
+	// The batch following the recorded progress may have been written before the copy was
+	// interrupted, so it is written with touches when resuming.
+	touch := resuming
+
+	var copied uint64
+	for _, ns := range namespaces {
+		resourceType := ns.Definition.Name
+		if resourceType < progress.ResourceType {
+			continue
+		}
+
+		var cursor dsoptions.Cursor
+		if resourceType == progress.ResourceType {
+			cursor = after
+		}
+
+		for {
+			batch, err := readBatch(ctx, reader, resourceType, cursor, r.options.batchSize)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(batch) > 0 {
+				_, err = r.destination.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+					if touch {
+						mutations := make([]*core.RelationTupleUpdate, 0, len(batch))
+						for _, rel := range batch {
+							mutations = append(mutations, tuple.Touch(rel))
+						}
+						return rwt.WriteRelationships(ctx, mutations)
+					}
+
+					_, err := rwt.BulkLoad(ctx, datastore.NewSliceRelationshipSource(batch))
+					return err
+				})
+				if err != nil {
+					return nil, fmt.Errorf("unable to write relationships: %w", err)
+				}
+				touch = false
+
+				last, err := tuple.String(batch[len(batch)-1])
+				if err != nil {
+					return nil, err
+				}
+				if err := r.saveState(revision, &copyProgress{ResourceType: resourceType, After: last}); err != nil {
+					return nil, err
+				}
+
+				copied += uint64(len(batch))
+				log.Ctx(ctx).Debug().Uint64("relationships", copied).Msg("initial copy in progress")
+			}
+
+			if uint64(len(batch)) < r.options.batchSize {
+				break
+			}
+			cursor = batch[len(batch)-1]
+		}
+	}
+
+	if err := r.saveState(revision, nil); err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().Str("revision", revision.String()).Uint64("relationships", copied).Msg("completed initial copy")
+	return revision, nil
+}
+
+func readBatch(ctx context.Context, reader datastore.Reader, resourceType string, after dsoptions.Cursor, limit uint64) ([]*core.RelationTuple, error) {
+	it, err := reader.QueryRelationships(
+		ctx,
+		datastore.RelationshipsFilter{ResourceType: resourceType},
+		dsoptions.WithLimit(&limit),
+		dsoptions.WithAfter(after),
+		dsoptions.WithSort(dsoptions.ByResource),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read relationships: %w", err)
+	}
+	defer it.Close()
+
+	batch := make([]*core.RelationTuple, 0, limit)
+	for rel := it.Next(); rel != nil; rel = it.Next() {
+		batch = append(batch, rel.CloneVT())
+	}
+
+	if it.Err() != nil {
+		return nil, fmt.Errorf("unable to read relationships: %w", it.Err())
+	}
+
+	return batch, nil
+}
+
+// tail applies all changes made to the source after the given revision to the destination,
+// until the context is canceled.
+func (r *Replicator) tail(ctx context.Context, revision datastore.Revision, content datastore.WatchContent) error {
+	lagTicker := time.NewTicker(r.options.lagReportInterval)
+	defer lagTicker.Stop()
+
+	applied := revision
+	defer func() {
+		if err := r.saveState(applied, nil); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("unable to save replication state")
+		}
+	}()
+
+	for {
+		watchCtx, cancelWatch := context.WithCancel(ctx)
+		changes, errs := r.source.Watch(watchCtx, applied, datastore.WatchOptions{
+			Content: content,
+		})
+
+		err := func() error {
+			defer cancelWatch()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+
+				case <-lagTicker.C:
+					r.sampleHead(ctx, applied)
+					r.reportLag(ctx)
+
+				case change, ok := <-changes:
+					if !ok {
+						return datastore.NewWatchTemporaryErr(errors.New("watch closed"))
+					}
+
+					if err := r.apply(ctx, change); err != nil {
+						return err
+					}
+
+					applied = change.Revision
+					r.markApplied(applied)
+
+					if time.Since(r.lastStateSave) >= r.options.stateSaveInterval {
+						if err := r.saveState(applied, nil); err != nil {
+							return err
+						}
+					}
+
+				case err := <-errs:
+					return err
+				}
+			}
+		}()
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err == nil || !isRetryable(err) {
+			return err
+		}
+
+		log.Ctx(ctx).Warn().Err(err).Str("revision", applied.String()).Msg("source watch disconnected; reconnecting")
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(r.options.reconnectDelay):
+		}
+	}
+}
+
+func isRetryable(err error) bool {
+	var disconnected datastore.ErrWatchDisconnected
+	var retryable datastore.ErrWatchRetryable
+	return errors.As(err, &disconnected) || errors.As(err, &retryable)
+}
+
+// apply applies the changes found at a single revision of the source to the destination.
+func (r *Replicator) apply(ctx context.Context, change *datastore.RevisionChanges) error {
+	if change.IsCheckpoint && len(change.RelationshipChanges) == 0 && len(change.ChangedDefinitions) == 0 &&
+		len(change.DeletedNamespaces) == 0 && len(change.DeletedCaveats) == 0 {
+		return nil
+	}
+
+	var namespaces []*core.NamespaceDefinition
+	var caveats []*core.CaveatDefinition
+	for _, changed := range change.ChangedDefinitions {
+		switch t := changed.(type) {
+		case *core.NamespaceDefinition:
+			namespaces = append(namespaces, t)
+		case *core.CaveatDefinition:
+			caveats = append(caveats, t)
+		default:
+			return spiceerrors.MustBugf("unknown changed definition type %T", changed)
+		}
+	}
+
+	// Creates are applied as touches, so that changes can be safely reapplied when resuming.
+	mutations := make([]*core.RelationTupleUpdate, 0, len(change.RelationshipChanges))
+	for _, update := range change.RelationshipChanges {
+		if update.Operation == core.RelationTupleUpdate_CREATE {
+			update = &core.RelationTupleUpdate{Operation: core.RelationTupleUpdate_TOUCH, Tuple: update.Tuple}
+		}
+		mutations = append(mutations, update)
+	}
+
+	_, err := r.destination.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		if len(caveats) > 0 {
+			if err := rwt.WriteCaveats(ctx, caveats); err != nil {
+				return err
+			}
+		}
+
+		if len(namespaces) > 0 {
+			if err := rwt.WriteNamespaces(ctx, namespaces...); err != nil {
+				return err
+			}
+		}
+
+		if len(mutations) > 0 {
+			if err := rwt.WriteRelationships(ctx, mutations); err != nil {
+				return err
+			}
+		}
+
+		// Deletions of definitions which no longer exist are skipped, as they may have already
+		// been applied before resuming.
+		if len(change.DeletedNamespaces) > 0 {
+			existing, err := rwt.LookupNamespacesWithNames(ctx, change.DeletedNamespaces)
+			if err != nil {
+				return err
+			}
+
+			names := make([]string, 0, len(existing))
+			for _, found := range existing {
+				names = append(names, found.Definition.Name)
+			}
+
+			if len(names) > 0 {
+				if err := rwt.DeleteNamespaces(ctx, names...); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(change.DeletedCaveats) > 0 {
+			existing, err := rwt.LookupCaveatsWithNames(ctx, change.DeletedCaveats)
+			if err != nil {
+				return err
+			}
+
+			names := make([]string, 0, len(existing))
+			for _, found := range existing {
+				names = append(names, found.Definition.Name)
+			}
+
+			if len(names) > 0 {
+				if err := rwt.DeleteCaveats(ctx, names); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to apply changes at revision %s: %w", change.Revision, err)
+	}
+
+	replicatedChangesCounter.WithLabelValues("relationship").Add(float64(len(mutations)))
+	replicatedChangesCounter.WithLabelValues("definition").Add(float64(len(change.ChangedDefinitions) + len(change.DeletedNamespaces) + len(change.DeletedCaveats)))
+	return nil
+}
+
+// sampleHead records the current head revision of the source, if it has not yet been applied,
+// so that the lag can be computed once it has been.
+func (r *Replicator) sampleHead(ctx context.Context, applied datastore.Revision) {
+	head, err := r.source.HeadRevision(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("unable to compute source head revision")
+		return
+	}
+
+	if !head.GreaterThan(applied) {
+		return
+	}
+
+	r.pendingHeads = append(r.pendingHeads, headSample{head, time.Now()})
+}
+
+// markApplied drops the sampled head revisions which are at or before the applied revision.
+func (r *Replicator) markApplied(applied datastore.Revision) {
+	caughtUp := 0
+	for caughtUp < len(r.pendingHeads) && !r.pendingHeads[caughtUp].revision.GreaterThan(applied) {
+		caughtUp++
+	}
+	r.pendingHeads = r.pendingHeads[caughtUp:]
+}
+
+// lag returns the amount of time since the oldest sampled head revision of the source which has
+// not yet been applied was sampled, or zero if all sampled revisions have been applied.
+func (r *Replicator) lag() time.Duration {
+	if len(r.pendingHeads) == 0 {
+		return 0
+	}
+	return time.Since(r.pendingHeads[0].sampled)
+}
+
+func (r *Replicator) reportLag(ctx context.Context) {
+	lag := r.lag()
+	replicationLagGauge.Set(lag.Seconds())
+	log.Ctx(ctx).Info().Stringer("lag", lag).Msg("replication lag")
+
+	if r.options.onLag != nil {
+		r.options.onLag(lag)
+	}
+}
+
+// replicationState is the state of the replication recorded in the state path.
+type replicationState struct {
+	// Revision is the last source revision applied to the destination, or the revision at which
+	// the initial copy is made if it has not completed.
+	Revision string `json:"revision"`
+
+	// InitialCopy, if set, is the progress of the initial copy, which has not completed.
+	InitialCopy *copyProgress `json:"initial_copy,omitempty"`
+}
+
+// copyProgress is the progress of the initial copy.
+type copyProgress struct {
+	// ResourceType is the resource type whose relationships were being copied, if any.
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// After is the last relationship of the resource type copied, if any.
+	After string `json:"after,omitempty"`
+}
+
+// loadState returns the source revision found in the state path, if any, along with the
+// progress of the initial copy if it has not completed.
+func (r *Replicator) loadState() (datastore.Revision, *copyProgress, error) {
+	if r.options.statePath == "" {
+		return nil, nil, nil
+	}
+
+	contents, err := os.ReadFile(r.options.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("unable to read replication state: %w", err)
+	}
+
+	var state replicationState
+	if err := json.Unmarshal(contents, &state); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse replication state in %s: %w", r.options.statePath, err)
+	}
+
+	revision, err := r.source.RevisionFromString(state.Revision)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse replication state in %s: %w", r.options.statePath, err)
+	}
+
+	return revision, state.InitialCopy, nil
+}
+
+func (r *Replicator) saveState(revision datastore.Revision, progress *copyProgress) error {
+	if r.options.statePath == "" {
+		return nil
+	}
+
+	contents, err := json.Marshal(replicationState{Revision: revision.String(), InitialCopy: progress})
+	if err != nil {
+		return fmt.Errorf("unable to write replication state: %w", err)
+	}
+
+	tmpPath := r.options.statePath + ".tmp"
+	if err := os.WriteFile(tmpPath, contents, 0o600); err != nil {
+		return fmt.Errorf("unable to write replication state: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, r.options.statePath); err != nil {
+		return fmt.Errorf("unable to write replication state: %w", err)
+	}
+
+	r.lastStateSave = time.Now()
+	return nil
+}
+
+func ensureEmpty(ctx context.Context, ds datastore.Datastore) error {
+	headRevision, err := ds.HeadRevision(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to compute destination head revision: %w", err)
+	}
+
+	namespaces, err := ds.SnapshotReader(headRevision).ListAllNamespaces(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to read destination namespaces: %w", err)
+	}
+
+	if len(namespaces) > 0 {
+		return fmt.Errorf("cannot replicate into a destination datastore which already contains %d definitions", len(namespaces))
+	}
+
+	return nil
+}
@@ -0,0 +1,286 @@
+package replication
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/internal/testfixtures"
+	"github.com/authzed/spicedb/pkg/datastore"
+	ns "github.com/authzed/spicedb/pkg/namespace"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+func TestReplicator(t *testing.T) {
+	require := require.New(t)
+	statePath := filepath.Join(t.TempDir(), "replication.state")
+
+	source, _ := testfixtures.StandardDatastoreWithCaveatedData(newDatastore(t), require)
+	destination := newDatastore(t)
+
+	var lagLock sync.Mutex
+	var lags []time.Duration
+	options := []Option{
+		BatchSize(3),
+		StatePath(statePath),
+		LagReportInterval(10 * time.Millisecond),
+		OnLag(func(lag time.Duration) {
+			lagLock.Lock()
+			defer lagLock.Unlock()
+			lags = append(lags, lag)
+		}),
+	}
+
+	stop := runReplicator(t, NewReplicator(source, destination, options...))
+	requireReplicated(t, source, destination)
+
+	// Relationship changes are replicated.
+	ctx := context.Background()
+	_, err := common.WriteTuples(ctx, source, core.RelationTupleUpdate_CREATE, tuple.MustParse("document:newdoc#viewer@user:tom"))
+	require.NoError(err)
+	_, err = common.WriteTuples(ctx, source, core.RelationTupleUpdate_DELETE, tuple.MustParse("folder:company#owner@user:owner[test]"))
+	require.NoError(err)
+	requireReplicated(t, source, destination)
+
+	// Schema changes are replicated.
+	_, err = source.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteNamespaces(ctx, ns.Namespace("team", ns.MustRelation("member", nil, ns.AllowedRelation("user", "..."))))
+	})
+	require.NoError(err)
+	_, err = common.WriteTuples(ctx, source, core.RelationTupleUpdate_TOUCH, tuple.MustParse("team:eng#member@user:tom"))
+	require.NoError(err)
+	requireReplicated(t, source, destination)
+
+	require.Eventually(func() bool {
+		lagLock.Lock()
+		defer lagLock.Unlock()
+		return len(lags) > 0 && lags[len(lags)-1] == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	stop()
+	require.FileExists(statePath)
+
+	// Changes made while the replicator is stopped are applied when it resumes.
+	_, err = common.WriteTuples(ctx, source, core.RelationTupleUpdate_TOUCH, tuple.MustParse("team:eng#member@user:sarah"))
+	require.NoError(err)
+	_, err = source.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.DeleteNamespaces(ctx, "team")
+	})
+	require.NoError(err)
+
+	stop = runReplicator(t, NewReplicator(source, destination, options...))
+	defer stop()
+	requireReplicated(t, source, destination)
+}
+
+func TestReplicatorRequiresEmptyDestination(t *testing.T) {
+	require := require.New(t)
+
+	source, _ := testfixtures.StandardDatastoreWithData(newDatastore(t), require)
+	destination, _ := testfixtures.StandardDatastoreWithSchema(newDatastore(t), require)
+
+	err := NewReplicator(source, destination).Run(context.Background())
+	require.ErrorContains(err, "already contains")
+}
+
+func TestReplicatorWithoutSchemaWatch(t *testing.T) {
+	require := require.New(t)
+
+	ds, _ := testfixtures.StandardDatastoreWithData(newDatastore(t), require)
+	source := relationshipsOnlyWatchDatastore{ds}
+	destination := newDatastore(t)
+
+	stop := runReplicator(t, NewReplicator(source, destination, BatchSize(3)))
+	defer stop()
+	requireReplicated(t, source, destination)
+
+	_, err := common.WriteTuples(context.Background(), source, core.RelationTupleUpdate_CREATE, tuple.MustParse("document:newdoc#viewer@user:tom"))
+	require.NoError(err)
+	requireReplicated(t, source, destination)
+}
+
+func TestReplicatorResumesInitialCopy(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	statePath := filepath.Join(t.TempDir(), "replication.state")
+
+	source, _ := testfixtures.StandardDatastoreWithCaveatedData(newDatastore(t), require)
+	destination := newDatastore(t)
+
+	headRevision, err := source.HeadRevision(ctx)
+	require.NoError(err)
+	reader := source.SnapshotReader(headRevision)
+
+	namespaces, err := reader.ListAllNamespaces(ctx)
+	require.NoError(err)
+	caveats, err := reader.ListAllCaveats(ctx)
+	require.NoError(err)
+
+	// The copy is interrupted after writing the first two relationships of the document type,
+	// having recorded the progress of the first one only.
+	it, err := reader.QueryRelationships(ctx, datastore.RelationshipsFilter{ResourceType: "document"})
+	require.NoError(err)
+	var written []*core.RelationTuple
+	for rel := it.Next(); rel != nil && len(written) < 2; rel = it.Next() {
+		written = append(written, rel)
+	}
+	it.Close()
+	require.Len(written, 2)
+
+	_, err = destination.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		caveatDefs := make([]*core.CaveatDefinition, 0, len(caveats))
+		for _, caveat := range caveats {
+			caveatDefs = append(caveatDefs, caveat.Definition)
+		}
+		if err := rwt.WriteCaveats(ctx, caveatDefs); err != nil {
+			return err
+		}
+
+		nsDefs := make([]*core.NamespaceDefinition, 0, len(namespaces))
+		for _, nsDef := range namespaces {
+			nsDefs = append(nsDefs, nsDef.Definition)
+		}
+		if err := rwt.WriteNamespaces(ctx, nsDefs...); err != nil {
+			return err
+		}
+
+		_, err := rwt.BulkLoad(ctx, datastore.NewSliceRelationshipSource(written))
+		return err
+	})
+	require.NoError(err)
+
+	r := NewReplicator(source, destination, BatchSize(3), StatePath(statePath))
+	require.NoError(r.saveState(headRevision, &copyProgress{ResourceType: "document", After: tuple.MustString(written[0])}))
+
+	stop := runReplicator(t, r)
+	defer stop()
+	requireReplicated(t, source, destination)
+}
+
+func TestLag(t *testing.T) {
+	r := NewReplicator(nil, nil)
+	require.Equal(t, time.Duration(0), r.lag())
+
+	start := time.Now().Add(-time.Minute)
+	r.pendingHeads = []headSample{
+		{revision(1), start},
+		{revision(5), start.Add(30 * time.Second)},
+	}
+	require.GreaterOrEqual(t, r.lag(), time.Minute)
+
+	r.markApplied(revision(3))
+	require.Len(t, r.pendingHeads, 1)
+	require.Less(t, r.lag(), time.Minute)
+
+	r.markApplied(revision(5))
+	require.Equal(t, time.Duration(0), r.lag())
+}
+
+// relationshipsOnlyWatchDatastore is a datastore whose Watch api cannot report schema changes.
+type relationshipsOnlyWatchDatastore struct {
+	datastore.Datastore
+}
+
+func (ds relationshipsOnlyWatchDatastore) Features(ctx context.Context) (*datastore.Features, error) {
+	features, err := ds.Datastore.Features(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	features.WatchSchema = datastore.Feature{Enabled: false, Reason: "unsupported in test"}
+	return features, nil
+}
+
+func (ds relationshipsOnlyWatchDatastore) Watch(ctx context.Context, afterRevision datastore.Revision, options datastore.WatchOptions) (<-chan *datastore.RevisionChanges, <-chan error) {
+	if options.Content&datastore.WatchSchema != 0 {
+		errs := make(chan error, 1)
+		errs <- errors.New("schema watch unsupported")
+		return nil, errs
+	}
+
+	return ds.Datastore.Watch(ctx, afterRevision, options)
+}
+
+func revision(value int64) datastore.Revision {
+	return revisions.NewForTimestamp(value)
+}
+
+func newDatastore(t *testing.T) datastore.Datastore {
+	ds, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ds.Close() })
+	return ds
+}
+
+func runReplicator(t *testing.T, r *Replicator) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Run(ctx)
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			cancel()
+			require.NoError(t, <-done)
+		})
+	}
+}
+
+func requireReplicated(t *testing.T, source, destination datastore.Datastore) {
+	t.Helper()
+
+	var expected, found []string
+	require.Eventually(t, func() bool {
+		expected = contents(t, source)
+		found = contents(t, destination)
+		return slices.Equal(expected, found)
+	}, 5*time.Second, 10*time.Millisecond, "expected %v, found %v", expected, found)
+}
+
+// contents returns the definitions and relationships found at the head revision of the datastore.
+func contents(t *testing.T, ds datastore.Datastore) []string {
+	ctx := context.Background()
+	headRevision, err := ds.HeadRevision(ctx)
+	require.NoError(t, err)
+
+	reader := ds.SnapshotReader(headRevision)
+	namespaces, err := reader.ListAllNamespaces(ctx)
+	require.NoError(t, err)
+
+	caveats, err := reader.ListAllCaveats(ctx)
+	require.NoError(t, err)
+
+	var found []string
+	for _, caveat := range caveats {
+		found = append(found, "caveat "+caveat.Definition.Name)
+	}
+
+	for _, nsDef := range namespaces {
+		found = append(found, "definition "+nsDef.Definition.Name)
+
+		it, err := reader.QueryRelationships(ctx, datastore.RelationshipsFilter{ResourceType: nsDef.Definition.Name})
+		require.NoError(t, err)
+
+		for rel := it.Next(); rel != nil; rel = it.Next() {
+			found = append(found, tuple.MustString(rel))
+		}
+		require.NoError(t, it.Err())
+		it.Close()
+	}
+
+	sort.Strings(found)
+	return found
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/authzed/spicedb/internal/middleware"
 
 	grpcvalidate "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/validator"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -29,7 +30,9 @@ type dispatchServer struct {
 	localDispatch dispatch.Dispatcher
 }
 
-// NewDispatchServer creates a server which can be called for internal dispatch.
+// NewDispatchServer creates a server which can be called for internal dispatch. The earliest
+// expiration of the relationships read to compute a response is returned in its trailer, so that
+// the dispatcher caching the response does not reuse it past that time.
 func NewDispatchServer(localDispatch dispatch.Dispatcher) dispatchv1.DispatchServiceServer {
 	return &dispatchServer{
 		localDispatch: localDispatch,
@@ -44,7 +47,11 @@ func NewDispatchServer(localDispatch dispatch.Dispatcher) dispatchv1.DispatchSer
 }
 
 func (ds *dispatchServer) DispatchCheck(ctx context.Context, req *dispatchv1.DispatchCheckRequest) (*dispatchv1.DispatchCheckResponse, error) {
+	ctx, tracker := dispatch.ContextWithExpirationTracker(ctx)
 	resp, err := ds.localDispatch.DispatchCheck(ctx, req)
+	if err == nil {
+		err = grpc.SetTrailer(ctx, dispatch.ExpirationTrailer(tracker))
+	}
 	return resp, rewriteGraphError(ctx, err)
 }
 
@@ -57,24 +64,42 @@ func (ds *dispatchServer) DispatchReachableResources(
 	req *dispatchv1.DispatchReachableResourcesRequest,
 	resp dispatchv1.DispatchService_DispatchReachableResourcesServer,
 ) error {
-	return ds.localDispatch.DispatchReachableResources(req,
-		dispatch.WrapGRPCStream[*dispatchv1.DispatchReachableResourcesResponse](resp))
+	ctx, tracker := dispatch.ContextWithExpirationTracker(resp.Context())
+	if err := ds.localDispatch.DispatchReachableResources(req,
+		dispatch.StreamWithContext(ctx, dispatch.WrapGRPCStream[*dispatchv1.DispatchReachableResourcesResponse](resp))); err != nil {
+		return err
+	}
+
+	resp.SetTrailer(dispatch.ExpirationTrailer(tracker))
+	return nil
 }
 
 func (ds *dispatchServer) DispatchLookupResources(
 	req *dispatchv1.DispatchLookupResourcesRequest,
 	resp dispatchv1.DispatchService_DispatchLookupResourcesServer,
 ) error {
-	return ds.localDispatch.DispatchLookupResources(req,
-		dispatch.WrapGRPCStream[*dispatchv1.DispatchLookupResourcesResponse](resp))
+	ctx, tracker := dispatch.ContextWithExpirationTracker(resp.Context())
+	if err := ds.localDispatch.DispatchLookupResources(req,
+		dispatch.StreamWithContext(ctx, dispatch.WrapGRPCStream[*dispatchv1.DispatchLookupResourcesResponse](resp))); err != nil {
+		return err
+	}
+
+	resp.SetTrailer(dispatch.ExpirationTrailer(tracker))
+	return nil
 }
 
 func (ds *dispatchServer) DispatchLookupSubjects(
 	req *dispatchv1.DispatchLookupSubjectsRequest,
 	resp dispatchv1.DispatchService_DispatchLookupSubjectsServer,
 ) error {
-	return ds.localDispatch.DispatchLookupSubjects(req,
-		dispatch.WrapGRPCStream[*dispatchv1.DispatchLookupSubjectsResponse](resp))
+	ctx, tracker := dispatch.ContextWithExpirationTracker(resp.Context())
+	if err := ds.localDispatch.DispatchLookupSubjects(req,
+		dispatch.StreamWithContext(ctx, dispatch.WrapGRPCStream[*dispatchv1.DispatchLookupSubjectsResponse](resp))); err != nil {
+		return err
+	}
+
+	resp.SetTrailer(dispatch.ExpirationTrailer(tracker))
+	return nil
 }
 
 func (ds *dispatchServer) Close() error {
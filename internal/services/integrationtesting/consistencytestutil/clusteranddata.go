@@ -87,7 +87,7 @@ func CreateDispatcherForTesting(t *testing.T, withCaching bool) dispatch.Dispatc
 		cachingDispatcher, err := caching.NewCachingDispatcher(nil, false, "", &keys.CanonicalKeyHandler{})
 		require.NoError(err)
 
-		localDispatcher := graph.NewDispatcher(cachingDispatcher, graph.SharedConcurrencyLimits(10))
+		localDispatcher := graph.NewDispatcher(cachingDispatcher, graph.SharedConcurrencyLimits(10), nil)
 		t.Cleanup(func() {
 			err := localDispatcher.Close()
 			require.NoError(err)
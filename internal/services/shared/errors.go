@@ -165,6 +165,8 @@ func RewriteError(ctx context.Context, err error, config *ConfigForErrors) error
 		return ErrServiceReadOnly
 	case errors.As(err, &datastore.ErrCaveatNameNotFound{}):
 		return spiceerrors.WithCodeAndReason(err, codes.FailedPrecondition, v1.ErrorReason_ERROR_REASON_UNKNOWN_CAVEAT)
+	case errors.As(err, &datastore.ErrSchemaVersionNotFound{}):
+		return status.Errorf(codes.NotFound, "%s", err)
 	case errors.As(err, &datastore.ErrWatchDisabled{}):
 		return status.Errorf(codes.FailedPrecondition, "%s", err)
 
@@ -2,6 +2,7 @@ package shared
 
 import (
 	"context"
+	"strings"
 
 	log "github.com/authzed/spicedb/internal/logging"
 	"github.com/authzed/spicedb/internal/namespace"
@@ -22,6 +23,38 @@ type ValidatedSchemaChanges struct {
 	newCaveatDefNames *mapz.Set[string]
 	newObjectDefNames *mapz.Set[string]
 	additiveOnly      bool
+
+	// definitionPrefix, if not empty, is the prefix of the definitions to which the changes are
+	// scoped.
+	definitionPrefix string
+}
+
+// ScopedToPrefix returns the changes scoped to the definitions under the given prefix: existing
+// definitions outside of the prefix are neither removed nor otherwise considered when the
+// changes are applied or planned. The compiled definitions must all be under the prefix.
+func (vsc *ValidatedSchemaChanges) ScopedToPrefix(prefix string) *ValidatedSchemaChanges {
+	scoped := *vsc
+	scoped.definitionPrefix = prefix
+	return &scoped
+}
+
+// inScope returns the given existing definitions which are within the scope of the changes.
+func inScope[T datastore.SchemaDefinition](validated *ValidatedSchemaChanges, defs []datastore.RevisionedDefinition[T]) []datastore.RevisionedDefinition[T] {
+	if validated.definitionPrefix == "" {
+		return defs
+	}
+	return DefinitionsUnderPrefix(defs, validated.definitionPrefix)
+}
+
+// DefinitionsUnderPrefix returns the definitions whose names are under the given prefix.
+func DefinitionsUnderPrefix[T datastore.SchemaDefinition](defs []datastore.RevisionedDefinition[T], prefix string) []datastore.RevisionedDefinition[T] {
+	filtered := make([]datastore.RevisionedDefinition[T], 0, len(defs))
+	for _, def := range defs {
+		if strings.HasPrefix(def.Definition.GetName(), prefix+"/") {
+			filtered = append(filtered, def)
+		}
+	}
+	return filtered
 }
 
 // ValidateSchemaChanges validates the schema found in the compiled schema and returns a
@@ -101,6 +134,8 @@ func ApplySchemaChanges(ctx context.Context, rwt datastore.ReadWriteTransaction,
 		return nil, err
 	}
 
+	existingCaveats = inScope(validated, existingCaveats)
+	existingObjectDefs = inScope(validated, existingObjectDefs)
 	return ApplySchemaChangesOverExisting(ctx, rwt, validated, datastore.DefinitionsOf(existingCaveats), datastore.DefinitionsOf(existingObjectDefs))
 }
 
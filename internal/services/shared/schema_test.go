@@ -62,3 +62,63 @@ func TestApplySchemaChanges(t *testing.T) {
 	})
 	require.NoError(err)
 }
+
+func TestApplySchemaChangesScopedToPrefix(t *testing.T) {
+	require := require.New(t)
+	rawDS, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+	require.NoError(err)
+
+	// Write the initial schema, covering two prefixes.
+	ds, _ := testfixtures.DatastoreFromSchemaAndTestRelationships(rawDS, `
+		definition tenant1/user {}
+
+		definition tenant1/document {
+			relation viewer: tenant1/user
+		}
+
+		definition tenant2/user {}
+
+		caveat tenant2/somecaveat(value int) {
+			value == 42
+		}
+	`, nil, require)
+
+	// Replace the definitions of the first prefix.
+	compiled, err := compiler.Compile(compiler.InputSchema{
+		Source:       input.Source("schema"),
+		SchemaString: `definition user {}`,
+	}, compiler.ObjectTypePrefix("tenant1"))
+	require.NoError(err)
+
+	validated, err := ValidateSchemaChanges(context.Background(), compiled, false)
+	require.NoError(err)
+
+	revision, err := ds.ReadWriteTx(context.Background(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		applied, err := ApplySchemaChanges(context.Background(), rwt, validated.ScopedToPrefix("tenant1"))
+		require.NoError(err)
+
+		require.Empty(applied.NewObjectDefNames)
+		require.Equal([]string{"tenant1/document"}, applied.RemovedObjectDefNames)
+		require.Empty(applied.RemovedCaveatDefNames)
+		return nil
+	})
+	require.NoError(err)
+
+	// The definitions of the other prefix are left untouched.
+	reader := ds.SnapshotReader(revision)
+	nsDefs, err := reader.ListAllNamespaces(context.Background())
+	require.NoError(err)
+	require.ElementsMatch([]string{"tenant1/user", "tenant2/user"}, namesOf(datastore.DefinitionsOf(nsDefs)))
+
+	caveatDefs, err := reader.ListAllCaveats(context.Background())
+	require.NoError(err)
+	require.Equal([]string{"tenant2/somecaveat"}, namesOf(datastore.DefinitionsOf(caveatDefs)))
+}
+
+func namesOf[T datastore.SchemaDefinition](defs []T) []string {
+	names := make([]string, 0, len(defs))
+	for _, def := range defs {
+		names = append(names, def.GetName())
+	}
+	return names
+}
@@ -0,0 +1,227 @@
+package shared
+
+import (
+	"context"
+	"strings"
+
+	"github.com/authzed/spicedb/pkg/datastore"
+	caveatdiff "github.com/authzed/spicedb/pkg/diff/caveats"
+	nsdiff "github.com/authzed/spicedb/pkg/diff/namespace"
+	"github.com/authzed/spicedb/pkg/genutil/mapz"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/generator"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+	"github.com/authzed/spicedb/pkg/typesystem"
+	"github.com/authzed/spicedb/pkg/zedtoken"
+)
+
+// SchemaVersionSummary describes a version of the schema, without its text.
+type SchemaVersionSummary struct {
+	// Version is the number of the version.
+	Version uint64 `json:"version"`
+
+	// Author is the principal which wrote the version, if known.
+	Author string `json:"author,omitempty"`
+
+	// WrittenAt is the ZedToken of the revision at which the version was written.
+	WrittenAt string `json:"written_at"`
+}
+
+// SummarizeSchemaVersions returns the summaries of the given versions of the schema.
+func SummarizeSchemaVersions(versions []datastore.SchemaVersion) ([]SchemaVersionSummary, error) {
+	summaries := make([]SchemaVersionSummary, 0, len(versions))
+	for _, version := range versions {
+		writtenAt, err := zedtoken.NewFromRevision(version.Revision)
+		if err != nil {
+			return nil, err
+		}
+
+		summaries = append(summaries, SchemaVersionSummary{
+			Version:   version.Version,
+			Author:    version.Author,
+			WrittenAt: writtenAt.Token,
+		})
+	}
+	return summaries, nil
+}
+
+// SchemaVersionDiff describes the changes made to the schema between two of its versions.
+type SchemaVersionDiff struct {
+	// FromVersion is the number of the version from which the changes are made.
+	FromVersion uint64 `json:"from_version"`
+
+	// ToVersion is the number of the version to which the changes are made.
+	ToVersion uint64 `json:"to_version"`
+
+	// Deltas are the changes between the two versions.
+	Deltas []SchemaVersionDelta `json:"deltas"`
+
+	// Truncated indicates that only the first of the changes between the two versions are found
+	// in Deltas.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// SchemaVersionDelta describes a single change between two versions of the schema.
+type SchemaVersionDelta struct {
+	// DefinitionKind is the kind of definition changed: PlannedObjectDefinition or
+	// PlannedCaveatDefinition.
+	DefinitionKind string `json:"definition_kind"`
+
+	// DefinitionName is the name of the definition changed.
+	DefinitionName string `json:"definition_name"`
+
+	// Type is the type of the delta, as found in the namespace or caveat diff.
+	Type string `json:"type"`
+
+	// RelationName is the name of the relation or permission changed, if any.
+	RelationName string `json:"relation_name,omitempty"`
+
+	// AllowedType is the allowed subject type added or removed, if any.
+	AllowedType string `json:"allowed_type,omitempty"`
+
+	// ParameterName is the name of the caveat parameter changed, if any.
+	ParameterName string `json:"parameter_name,omitempty"`
+}
+
+// DiffSchemaVersions returns the changes made to the schema between the two given versions.
+func DiffSchemaVersions(from datastore.SchemaVersion, to datastore.SchemaVersion) (*SchemaVersionDiff, error) {
+	fromCompiled, err := compileSchemaVersion(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toCompiled, err := compileSchemaVersion(to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &SchemaVersionDiff{
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		Deltas:      []SchemaVersionDelta{},
+	}
+
+	// Diff the caveats.
+	fromCaveats := make(map[string]*core.CaveatDefinition, len(fromCompiled.CaveatDefinitions))
+	caveatNames := mapz.NewSet[string]()
+	for _, caveatDef := range fromCompiled.CaveatDefinitions {
+		fromCaveats[caveatDef.Name] = caveatDef
+		caveatNames.Insert(caveatDef.Name)
+	}
+
+	toCaveats := make(map[string]*core.CaveatDefinition, len(toCompiled.CaveatDefinitions))
+	for _, caveatDef := range toCompiled.CaveatDefinitions {
+		toCaveats[caveatDef.Name] = caveatDef
+		caveatNames.Insert(caveatDef.Name)
+	}
+
+	for _, caveatName := range sortedNames(caveatNames) {
+		caveatDiff, err := caveatdiff.DiffCaveats(fromCaveats[caveatName], toCaveats[caveatName])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, delta := range caveatDiff.Deltas() {
+			// The diff compares the serialized expressions, which differ whenever the caveat
+			// is found at a different position in the schema, so compare the expressions.
+			if delta.Type == caveatdiff.CaveatExpressionMayHaveChanged {
+				changed, err := expressionChanged(fromCaveats[caveatName], toCaveats[caveatName])
+				if err != nil {
+					return nil, err
+				}
+				if !changed {
+					continue
+				}
+			}
+
+			diff.Deltas = append(diff.Deltas, SchemaVersionDelta{
+				DefinitionKind: PlannedCaveatDefinition,
+				DefinitionName: caveatName,
+				Type:           string(delta.Type),
+				ParameterName:  delta.ParameterName,
+			})
+		}
+	}
+
+	// Diff the object definitions.
+	fromObjectDefs := make(map[string]*core.NamespaceDefinition, len(fromCompiled.ObjectDefinitions))
+	objectDefNames := mapz.NewSet[string]()
+	for _, nsdef := range fromCompiled.ObjectDefinitions {
+		fromObjectDefs[nsdef.Name] = nsdef
+		objectDefNames.Insert(nsdef.Name)
+	}
+
+	toObjectDefs := make(map[string]*core.NamespaceDefinition, len(toCompiled.ObjectDefinitions))
+	for _, nsdef := range toCompiled.ObjectDefinitions {
+		toObjectDefs[nsdef.Name] = nsdef
+		objectDefNames.Insert(nsdef.Name)
+	}
+
+	for _, nsdefName := range sortedNames(objectDefNames) {
+		nsDiff, err := nsdiff.DiffNamespaces(fromObjectDefs[nsdefName], toObjectDefs[nsdefName])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, delta := range nsDiff.Deltas() {
+			vd := SchemaVersionDelta{
+				DefinitionKind: PlannedObjectDefinition,
+				DefinitionName: nsdefName,
+				Type:           string(delta.Type),
+				RelationName:   delta.RelationName,
+			}
+			if delta.AllowedType != nil {
+				vd.AllowedType = typesystem.SourceForAllowedRelation(delta.AllowedType)
+			}
+			diff.Deltas = append(diff.Deltas, vd)
+		}
+	}
+
+	return diff, nil
+}
+
+func compileSchemaVersion(version datastore.SchemaVersion) (*compiler.CompiledSchema, error) {
+	return compiler.Compile(compiler.InputSchema{
+		Source:       input.Source("schema"),
+		SchemaString: version.Schema,
+	}, compiler.AllowUnprefixedObjectType())
+}
+
+// SchemaTextAfterChanges returns the text of the full schema which results from applying the
+// validated changes over the definitions found in the reader. Definitions outside of the scope
+// of the changes, or not removed because the changes are additive only, are kept.
+func SchemaTextAfterChanges(ctx context.Context, reader datastore.Reader, validated *ValidatedSchemaChanges) (string, error) {
+	existingCaveats, err := reader.ListAllCaveats(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	existingObjectDefs, err := reader.ListAllNamespaces(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	isKept := func(name string) bool {
+		if validated.additiveOnly {
+			return true
+		}
+		return validated.definitionPrefix != "" && !strings.HasPrefix(name, validated.definitionPrefix+"/")
+	}
+
+	definitions := make([]compiler.SchemaDefinition, 0, len(existingCaveats)+len(existingObjectDefs)+len(validated.compiled.OrderedDefinitions))
+	for _, existing := range existingCaveats {
+		if !validated.newCaveatDefNames.Has(existing.Definition.Name) && isKept(existing.Definition.Name) {
+			definitions = append(definitions, existing.Definition)
+		}
+	}
+	for _, existing := range existingObjectDefs {
+		if !validated.newObjectDefNames.Has(existing.Definition.Name) && isKept(existing.Definition.Name) {
+			definitions = append(definitions, existing.Definition)
+		}
+	}
+	definitions = append(definitions, validated.compiled.OrderedDefinitions...)
+
+	schemaText, _, err := generator.GenerateSchema(definitions)
+	return schemaText, err
+}
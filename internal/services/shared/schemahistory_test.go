@@ -0,0 +1,124 @@
+package shared
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/internal/testfixtures"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+)
+
+func TestDiffSchemaVersions(t *testing.T) {
+	from := datastore.SchemaVersion{Version: 1, Schema: `
+		definition user {}
+
+		caveat somecaveat(value int) {
+			value == 42
+		}
+
+		definition document {
+			relation viewer: user with somecaveat
+			relation editor: user
+			permission view = viewer
+		}
+	`}
+
+	to := datastore.SchemaVersion{Version: 2, Schema: `
+		definition user {}
+
+		definition team {}
+
+		caveat somecaveat(value int, other string) {
+			value == 42
+		}
+
+		definition document {
+			relation viewer: user with somecaveat | team
+			permission view = viewer
+		}
+	`}
+
+	diff, err := DiffSchemaVersions(from, to)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), diff.FromVersion)
+	require.Equal(t, uint64(2), diff.ToVersion)
+	require.Equal(t, []SchemaVersionDelta{
+		{DefinitionKind: "caveat", DefinitionName: "somecaveat", Type: "added-parameter", ParameterName: "other"},
+		{DefinitionKind: "definition", DefinitionName: "document", Type: "removed-relation", RelationName: "editor"},
+		{DefinitionKind: "definition", DefinitionName: "document", Type: "relation-allowed-type-added", RelationName: "viewer", AllowedType: "team"},
+		{DefinitionKind: "definition", DefinitionName: "team", Type: "namespace-added"},
+	}, diff.Deltas)
+
+	unchanged, err := DiffSchemaVersions(from, from)
+	require.NoError(t, err)
+	require.Empty(t, unchanged.Deltas)
+}
+
+func TestSchemaTextAfterChanges(t *testing.T) {
+	existingSchema := "definition tenant/user {}\n\ndefinition other/user {}"
+
+	tcs := []struct {
+		name           string
+		proposedSchema string
+		additiveOnly   bool
+		prefix         string
+		expectedSchema string
+	}{
+		{
+			"replaced schema",
+			"definition tenant/user {}\n\ndefinition tenant/document {}",
+			false,
+			"",
+			"definition tenant/user {}\n\ndefinition tenant/document {}",
+		},
+		{
+			"additive only",
+			"definition tenant/document {}",
+			true,
+			"",
+			"definition other/user {}\n\ndefinition tenant/user {}\n\ndefinition tenant/document {}",
+		},
+		{
+			"scoped to a prefix",
+			"definition tenant/document {}",
+			false,
+			"tenant",
+			"definition other/user {}\n\ndefinition tenant/document {}",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			rawDS, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+			require.NoError(err)
+
+			ds, _ := testfixtures.DatastoreFromSchemaAndTestRelationships(rawDS, existingSchema, nil, require)
+
+			compiled, err := compiler.Compile(compiler.InputSchema{
+				Source:       input.Source("schema"),
+				SchemaString: tc.proposedSchema,
+			}, compiler.AllowUnprefixedObjectType())
+			require.NoError(err)
+
+			validated, err := ValidateSchemaChanges(context.Background(), compiled, tc.additiveOnly)
+			require.NoError(err)
+			if tc.prefix != "" {
+				validated = validated.ScopedToPrefix(tc.prefix)
+			}
+
+			headRevision, err := ds.HeadRevision(context.Background())
+			require.NoError(err)
+
+			schemaText, err := SchemaTextAfterChanges(context.Background(), ds.SnapshotReader(headRevision), validated)
+			require.NoError(err)
+			require.Equal(tc.expectedSchema, schemaText)
+		})
+	}
+}
@@ -0,0 +1,522 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/authzed/spicedb/pkg/caveats"
+	"github.com/authzed/spicedb/pkg/caveats/types"
+	"github.com/authzed/spicedb/pkg/datastore"
+	caveatdiff "github.com/authzed/spicedb/pkg/diff/caveats"
+	nsdiff "github.com/authzed/spicedb/pkg/diff/namespace"
+	"github.com/authzed/spicedb/pkg/genutil/mapz"
+	"github.com/authzed/spicedb/pkg/graph"
+	nspkg "github.com/authzed/spicedb/pkg/namespace"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	iv1 "github.com/authzed/spicedb/pkg/proto/impl/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+	"github.com/authzed/spicedb/pkg/typesystem"
+)
+
+const (
+	// PlannedObjectDefinition is the kind of a delta applying to an object definition.
+	PlannedObjectDefinition = "definition"
+
+	// PlannedCaveatDefinition is the kind of a delta applying to a caveat definition.
+	PlannedCaveatDefinition = "caveat"
+)
+
+// SchemaChangePlan describes the changes that applying a set of validated schema changes would
+// make, and their effect on the relationships found in the datastore.
+type SchemaChangePlan struct {
+	// Safe indicates whether all the deltas can be applied without affecting stored relationships.
+	Safe bool `json:"safe"`
+
+	// Deltas are the changes between the existing and the proposed schema.
+	Deltas []PlannedSchemaDelta `json:"deltas"`
+}
+
+// PlannedSchemaDelta describes a single change between the existing and the proposed schema.
+type PlannedSchemaDelta struct {
+	// DefinitionKind is the kind of definition changed: PlannedObjectDefinition or
+	// PlannedCaveatDefinition.
+	DefinitionKind string `json:"definition_kind"`
+
+	// DefinitionName is the name of the definition changed.
+	DefinitionName string `json:"definition_name"`
+
+	// Type is the type of the delta, as found in the namespace or caveat diff.
+	Type string `json:"type"`
+
+	// RelationName is the name of the relation or permission changed, if any.
+	RelationName string `json:"relation_name,omitempty"`
+
+	// AllowedType is the allowed subject type added or removed, if any.
+	AllowedType string `json:"allowed_type,omitempty"`
+
+	// ParameterName is the name of the caveat parameter changed, if any.
+	ParameterName string `json:"parameter_name,omitempty"`
+
+	// Safe indicates whether the delta can be applied without affecting stored relationships.
+	Safe bool `json:"safe"`
+
+	// Reason explains why the delta is not safe, if it is not.
+	Reason string `json:"reason,omitempty"`
+
+	// AffectedRelationships is the number of stored relationships affected by the delta.
+	AffectedRelationships uint64 `json:"affected_relationships"`
+
+	// ChangedPermissions are the permissions, in `definition#permission` form, whose semantics
+	// change as a result of the delta.
+	ChangedPermissions []string `json:"changed_permissions,omitempty"`
+}
+
+// PlanSchemaChanges computes the plan for the schema changes found in the validated changes struct
+// against the schema and relationships found in the reader, without applying them.
+func PlanSchemaChanges(ctx context.Context, reader datastore.Reader, validated *ValidatedSchemaChanges) (*SchemaChangePlan, error) {
+	existingCaveats, err := reader.ListAllCaveats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existingObjectDefs, err := reader.ListAllNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existingCaveats = inScope(validated, existingCaveats)
+	existingObjectDefs = inScope(validated, existingObjectDefs)
+
+	planner := &schemaPlanner{
+		reader:       reader,
+		existing:     newSchemaDependencies(datastore.DefinitionsOf(existingObjectDefs)),
+		updated:      newSchemaDependencies(validated.compiled.ObjectDefinitions),
+		existingDefs: datastore.DefinitionsOf(existingObjectDefs),
+	}
+
+	plan := &SchemaChangePlan{Safe: true, Deltas: []PlannedSchemaDelta{}}
+	addDeltas := func(deltas []PlannedSchemaDelta) {
+		for _, delta := range deltas {
+			plan.Safe = plan.Safe && delta.Safe
+			plan.Deltas = append(plan.Deltas, delta)
+		}
+	}
+
+	// Plan the caveat changes.
+	existingCaveatDefMap := make(map[string]*core.CaveatDefinition, len(existingCaveats))
+	caveatNames := mapz.NewSet[string]()
+	for _, existingCaveat := range existingCaveats {
+		existingCaveatDefMap[existingCaveat.Definition.Name] = existingCaveat.Definition
+		caveatNames.Insert(existingCaveat.Definition.Name)
+	}
+
+	updatedCaveatDefMap := make(map[string]*core.CaveatDefinition, len(validated.compiled.CaveatDefinitions))
+	for _, caveatDef := range validated.compiled.CaveatDefinitions {
+		updatedCaveatDefMap[caveatDef.Name] = caveatDef
+		caveatNames.Insert(caveatDef.Name)
+	}
+
+	for _, caveatName := range sortedNames(caveatNames) {
+		updated, ok := updatedCaveatDefMap[caveatName]
+		if !ok && validated.additiveOnly {
+			continue
+		}
+
+		diff, err := caveatdiff.DiffCaveats(existingCaveatDefMap[caveatName], updated)
+		if err != nil {
+			return nil, err
+		}
+
+		deltas, err := planner.planCaveatDeltas(ctx, caveatName, existingCaveatDefMap[caveatName], updated, diff.Deltas())
+		if err != nil {
+			return nil, err
+		}
+		addDeltas(deltas)
+	}
+
+	// Plan the object definition changes.
+	existingObjectDefMap := make(map[string]*core.NamespaceDefinition, len(existingObjectDefs))
+	objectDefNames := mapz.NewSet[string]()
+	for _, existingDef := range existingObjectDefs {
+		existingObjectDefMap[existingDef.Definition.Name] = existingDef.Definition
+		objectDefNames.Insert(existingDef.Definition.Name)
+	}
+
+	updatedObjectDefMap := make(map[string]*core.NamespaceDefinition, len(validated.compiled.ObjectDefinitions))
+	for _, nsdef := range validated.compiled.ObjectDefinitions {
+		updatedObjectDefMap[nsdef.Name] = nsdef
+		objectDefNames.Insert(nsdef.Name)
+	}
+
+	for _, nsdefName := range sortedNames(objectDefNames) {
+		updated, ok := updatedObjectDefMap[nsdefName]
+		if !ok && validated.additiveOnly {
+			continue
+		}
+
+		diff, err := nsdiff.DiffNamespaces(existingObjectDefMap[nsdefName], updated)
+		if err != nil {
+			return nil, err
+		}
+
+		deltas, err := planner.planNamespaceDeltas(ctx, nsdefName, existingObjectDefMap[nsdefName], diff.Deltas())
+		if err != nil {
+			return nil, err
+		}
+		addDeltas(deltas)
+	}
+
+	return plan, nil
+}
+
+type schemaPlanner struct {
+	reader       datastore.Reader
+	existing     *schemaDependencies
+	updated      *schemaDependencies
+	existingDefs []*core.NamespaceDefinition
+}
+
+func (sp *schemaPlanner) planCaveatDeltas(ctx context.Context, caveatName string, existing, updated *core.CaveatDefinition, deltas []caveatdiff.Delta) ([]PlannedSchemaDelta, error) {
+	planned := make([]PlannedSchemaDelta, 0, len(deltas))
+	for _, delta := range deltas {
+		pd := PlannedSchemaDelta{
+			DefinitionKind: PlannedCaveatDefinition,
+			DefinitionName: caveatName,
+			Type:           string(delta.Type),
+			ParameterName:  delta.ParameterName,
+			Safe:           true,
+		}
+
+		var err error
+		switch delta.Type {
+		case caveatdiff.CaveatRemoved:
+			pd.AffectedRelationships, err = sp.countCaveatedRelationships(ctx, caveatName)
+			if pd.AffectedRelationships > 0 {
+				pd.Safe = false
+				pd.Reason = fmt.Sprintf("relationships exist with caveat `%s`", caveatName)
+			}
+
+		case caveatdiff.RemovedParameter:
+			pd.AffectedRelationships, err = sp.countCaveatedRelationships(ctx, caveatName)
+			pd.Safe = false
+			pd.Reason = fmt.Sprintf("cannot remove parameter `%s` on caveat `%s`", delta.ParameterName, caveatName)
+
+		case caveatdiff.ParameterTypeChanged:
+			pd.AffectedRelationships, err = sp.countCaveatedRelationships(ctx, caveatName)
+			pd.Safe = false
+			pd.Reason = fmt.Sprintf("cannot change the type of parameter `%s` on caveat `%s`", delta.ParameterName, caveatName)
+
+		case caveatdiff.CaveatExpressionMayHaveChanged:
+			// The diff compares the serialized expressions, which differ whenever the caveat
+			// is found at a different position in the schema, so compare the expressions.
+			changed, cerr := expressionChanged(existing, updated)
+			if cerr != nil {
+				return nil, cerr
+			}
+			if !changed {
+				continue
+			}
+
+			pd.AffectedRelationships, err = sp.countCaveatedRelationships(ctx, caveatName)
+			pd.ChangedPermissions = sp.changedPermissions(append(
+				sp.existing.caveatUsers[caveatName],
+				sp.updated.caveatUsers[caveatName]...,
+			)...)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		planned = append(planned, pd)
+	}
+	return planned, nil
+}
+
+func (sp *schemaPlanner) planNamespaceDeltas(ctx context.Context, nsdefName string, existing *core.NamespaceDefinition, deltas []nsdiff.Delta) ([]PlannedSchemaDelta, error) {
+	planned := make([]PlannedSchemaDelta, 0, len(deltas))
+	for _, delta := range deltas {
+		pd := PlannedSchemaDelta{
+			DefinitionKind: PlannedObjectDefinition,
+			DefinitionName: nsdefName,
+			Type:           string(delta.Type),
+			RelationName:   delta.RelationName,
+			Safe:           true,
+		}
+		if delta.AllowedType != nil {
+			pd.AllowedType = typesystem.SourceForAllowedRelation(delta.AllowedType)
+		}
+
+		var err error
+		switch delta.Type {
+		case nsdiff.NamespaceRemoved:
+			pd.AffectedRelationships, err = sp.countRelationships(ctx, nsdefName, "")
+			if pd.AffectedRelationships > 0 {
+				pd.Safe = false
+				pd.Reason = fmt.Sprintf("relationships exist under or reference object definition `%s`", nsdefName)
+			}
+
+			seeds := make([]relationKey, 0, len(existing.Relation))
+			for _, relation := range existing.Relation {
+				seeds = append(seeds, relationKey{nsdefName, relation.Name})
+			}
+			pd.ChangedPermissions = sp.changedPermissions(seeds...)
+
+		case nsdiff.RemovedRelation:
+			pd.AffectedRelationships, err = sp.countRelationships(ctx, nsdefName, delta.RelationName)
+			if pd.AffectedRelationships > 0 {
+				pd.Safe = false
+				pd.Reason = fmt.Sprintf("relationships exist under or reference relation `%s` in object definition `%s`", delta.RelationName, nsdefName)
+			}
+			pd.ChangedPermissions = sp.changedPermissions(relationKey{nsdefName, delta.RelationName})
+
+		case nsdiff.RelationAllowedTypeRemoved:
+			pd.AffectedRelationships, err = sp.countAllowedTypeRelationships(ctx, nsdefName, delta)
+			if pd.AffectedRelationships > 0 {
+				pd.Safe = false
+				pd.Reason = fmt.Sprintf("relationships exist with allowed type `%s` on relation `%s` in object definition `%s`", pd.AllowedType, delta.RelationName, nsdefName)
+			}
+			pd.ChangedPermissions = sp.changedPermissions(relationKey{nsdefName, delta.RelationName})
+
+		case nsdiff.RemovedPermission, nsdiff.ChangedPermissionImpl, nsdiff.LegacyChangedRelationImpl:
+			pd.ChangedPermissions = sp.changedPermissions(relationKey{nsdefName, delta.RelationName})
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		planned = append(planned, pd)
+	}
+	return planned, nil
+}
+
+// changedPermissions returns the permissions, in either the existing or the updated schema, which
+// are or depend upon the given relations.
+func (sp *schemaPlanner) changedPermissions(seeds ...relationKey) []string {
+	changed := mapz.NewSet[string]()
+	for _, deps := range []*schemaDependencies{sp.existing, sp.updated} {
+		for _, key := range deps.dependentsOf(seeds) {
+			if deps.permissions.Has(key) {
+				changed.Insert(key.String())
+			}
+		}
+	}
+	return sortedNames(changed)
+}
+
+// countRelationships counts the relationships under the given relation in the given object
+// definition, or referencing it as their subject. If the relation name is empty, all relations
+// are counted.
+func (sp *schemaPlanner) countRelationships(ctx context.Context, nsdefName string, relationName string) (uint64, error) {
+	count, err := countIterator(sp.reader.QueryRelationships(ctx, datastore.RelationshipsFilter{
+		ResourceType:             nsdefName,
+		OptionalResourceRelation: relationName,
+	}))
+	if err != nil {
+		return 0, err
+	}
+
+	subjectsFilter := datastore.SubjectsFilter{SubjectType: nsdefName}
+	if relationName != "" {
+		subjectsFilter.RelationFilter = datastore.SubjectRelationFilter{NonEllipsisRelation: relationName}
+	}
+
+	it, err := sp.reader.ReverseQueryRelationships(ctx, subjectsFilter)
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	for rel := it.Next(); rel != nil; rel = it.Next() {
+		// Skip relationships already counted above.
+		if rel.ResourceAndRelation.Namespace == nsdefName &&
+			(relationName == "" || rel.ResourceAndRelation.Relation == relationName) {
+			continue
+		}
+		count++
+	}
+	return count, it.Err()
+}
+
+// countAllowedTypeRelationships counts the relationships written with the allowed type removed
+// by the delta.
+func (sp *schemaPlanner) countAllowedTypeRelationships(ctx context.Context, nsdefName string, delta nsdiff.Delta) (uint64, error) {
+	var optionalSubjectIds []string
+	var relationFilter datastore.SubjectRelationFilter
+	optionalCaveatName := ""
+
+	if delta.AllowedType.GetPublicWildcard() != nil {
+		optionalSubjectIds = []string{tuple.PublicWildcard}
+	} else {
+		relationFilter = datastore.SubjectRelationFilter{
+			NonEllipsisRelation: delta.AllowedType.GetRelation(),
+		}
+	}
+
+	if delta.AllowedType.GetRequiredCaveat() != nil {
+		optionalCaveatName = delta.AllowedType.GetRequiredCaveat().CaveatName
+	}
+
+	return countIterator(sp.reader.QueryRelationships(ctx, datastore.RelationshipsFilter{
+		ResourceType:             nsdefName,
+		OptionalResourceRelation: delta.RelationName,
+		OptionalSubjectsSelectors: []datastore.SubjectsSelector{
+			{
+				OptionalSubjectType: delta.AllowedType.Namespace,
+				OptionalSubjectIds:  optionalSubjectIds,
+				RelationFilter:      relationFilter,
+			},
+		},
+		OptionalCaveatName: optionalCaveatName,
+	}))
+}
+
+// countCaveatedRelationships counts the relationships written with the given caveat.
+func (sp *schemaPlanner) countCaveatedRelationships(ctx context.Context, caveatName string) (uint64, error) {
+	var count uint64
+	for _, nsdef := range sp.existingDefs {
+		found, err := countIterator(sp.reader.QueryRelationships(ctx, datastore.RelationshipsFilter{
+			ResourceType:       nsdef.Name,
+			OptionalCaveatName: caveatName,
+		}))
+		if err != nil {
+			return 0, err
+		}
+		count += found
+	}
+	return count, nil
+}
+
+func expressionChanged(existing, updated *core.CaveatDefinition) (bool, error) {
+	existingExpr, err := caveatExpression(existing)
+	if err != nil {
+		return false, err
+	}
+
+	updatedExpr, err := caveatExpression(updated)
+	if err != nil {
+		return false, err
+	}
+
+	return existingExpr != updatedExpr, nil
+}
+
+func caveatExpression(caveatDef *core.CaveatDefinition) (string, error) {
+	parameterTypes, err := types.DecodeParameterTypes(caveatDef.ParameterTypes)
+	if err != nil {
+		return "", err
+	}
+
+	deserialized, err := caveats.DeserializeCaveat(caveatDef.SerializedExpression, parameterTypes)
+	if err != nil {
+		return "", err
+	}
+
+	return deserialized.ExprString()
+}
+
+func countIterator(it datastore.RelationshipIterator, err error) (uint64, error) {
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	var count uint64
+	for rel := it.Next(); rel != nil; rel = it.Next() {
+		count++
+	}
+	return count, it.Err()
+}
+
+// relationKey identifies a relation or permission within an object definition.
+type relationKey struct {
+	namespace string
+	relation  string
+}
+
+func (rk relationKey) String() string {
+	return rk.namespace + "#" + rk.relation
+}
+
+// schemaDependencies holds, for a set of object definitions, the relations and permissions
+// depending upon each relation, permission and caveat.
+type schemaDependencies struct {
+	dependents  map[relationKey][]relationKey
+	caveatUsers map[string][]relationKey
+	permissions *mapz.Set[relationKey]
+}
+
+func newSchemaDependencies(nsdefs []*core.NamespaceDefinition) *schemaDependencies {
+	relations := make(map[relationKey]*core.Relation)
+	for _, nsdef := range nsdefs {
+		for _, relation := range nsdef.Relation {
+			relations[relationKey{nsdef.Name, relation.Name}] = relation
+		}
+	}
+
+	deps := &schemaDependencies{
+		dependents:  make(map[relationKey][]relationKey),
+		caveatUsers: make(map[string][]relationKey),
+		permissions: mapz.NewSet[relationKey](),
+	}
+	addDependency := func(dependency, dependent relationKey) {
+		deps.dependents[dependency] = append(deps.dependents[dependency], dependent)
+	}
+
+	for key, relation := range relations {
+		if nspkg.GetRelationKind(relation) == iv1.RelationMetadata_PERMISSION {
+			deps.permissions.Insert(key)
+		}
+
+		for _, allowed := range relation.GetTypeInformation().GetAllowedDirectRelations() {
+			if allowed.GetRequiredCaveat() != nil {
+				caveatName := allowed.GetRequiredCaveat().CaveatName
+				deps.caveatUsers[caveatName] = append(deps.caveatUsers[caveatName], key)
+			}
+
+			if allowed.GetRelation() != "" && allowed.GetRelation() != tuple.Ellipsis {
+				addDependency(relationKey{allowed.Namespace, allowed.GetRelation()}, key)
+			}
+		}
+
+		_, _ = graph.WalkRewrite(relation.UsersetRewrite, func(childOneof *core.SetOperation_Child) interface{} {
+			switch child := childOneof.ChildType.(type) {
+			case *core.SetOperation_Child_ComputedUserset:
+				addDependency(relationKey{key.namespace, child.ComputedUserset.Relation}, key)
+
+			case *core.SetOperation_Child_TupleToUserset:
+				tuplesetKey := relationKey{key.namespace, child.TupleToUserset.GetTupleset().GetRelation()}
+				addDependency(tuplesetKey, key)
+
+				computedRelation := child.TupleToUserset.GetComputedUserset().GetRelation()
+				for _, allowed := range relations[tuplesetKey].GetTypeInformation().GetAllowedDirectRelations() {
+					addDependency(relationKey{allowed.Namespace, computedRelation}, key)
+				}
+			}
+			return nil
+		})
+	}
+
+	return deps
+}
+
+// dependentsOf returns the given relations and all those which transitively depend upon them.
+func (sd *schemaDependencies) dependentsOf(seeds []relationKey) []relationKey {
+	visited := mapz.NewSet[relationKey]()
+	toVisit := append([]relationKey{}, seeds...)
+	for len(toVisit) > 0 {
+		current := toVisit[0]
+		toVisit = toVisit[1:]
+		if !visited.Add(current) {
+			continue
+		}
+		toVisit = append(toVisit, sd.dependents[current]...)
+	}
+	return visited.AsSlice()
+}
+
+func sortedNames(names *mapz.Set[string]) []string {
+	sorted := names.AsSlice()
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,300 @@
+package shared
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/internal/testfixtures"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+const planExistingSchema = `
+	definition user {}
+
+	caveat somecaveat(value int) {
+		value == 42
+	}
+
+	definition document {
+		relation viewer: user | user with somecaveat
+		relation editor: user
+		permission edit = editor
+		permission view = viewer + edit
+	}
+
+	definition folder {
+		relation doc: document
+		permission view_all = doc->view
+	}
+`
+
+func TestPlanSchemaChanges(t *testing.T) {
+	tcs := []struct {
+		name           string
+		proposedSchema string
+		additiveOnly   bool
+		expectedSafe   bool
+		expectedDeltas []PlannedSchemaDelta
+	}{
+		{
+			"no changes",
+			planExistingSchema,
+			false,
+			true,
+			[]PlannedSchemaDelta{},
+		},
+		{
+			"added definition",
+			planExistingSchema + `definition team {}`,
+			false,
+			true,
+			[]PlannedSchemaDelta{
+				{DefinitionKind: "definition", DefinitionName: "team", Type: "namespace-added", Safe: true},
+			},
+		},
+		{
+			"changed permission",
+			`
+			definition user {}
+
+			caveat somecaveat(value int) {
+				value == 42
+			}
+
+			definition document {
+				relation viewer: user | user with somecaveat
+				relation editor: user
+				permission edit = editor
+				permission view = viewer
+			}
+
+			definition folder {
+				relation doc: document
+				permission view_all = doc->view
+			}
+			`,
+			false,
+			true,
+			[]PlannedSchemaDelta{
+				{
+					DefinitionKind:     "definition",
+					DefinitionName:     "document",
+					Type:               "changed-permission-implementation",
+					RelationName:       "view",
+					Safe:               true,
+					ChangedPermissions: []string{"document#view", "folder#view_all"},
+				},
+			},
+		},
+		{
+			"removed relation with relationships",
+			`
+			definition user {}
+
+			caveat somecaveat(value int) {
+				value == 42
+			}
+
+			definition document {
+				relation viewer: user | user with somecaveat
+				permission view = viewer
+			}
+
+			definition folder {
+				relation doc: document
+				permission view_all = doc->view
+			}
+			`,
+			false,
+			false,
+			[]PlannedSchemaDelta{
+				{
+					DefinitionKind:        "definition",
+					DefinitionName:        "document",
+					Type:                  "removed-relation",
+					RelationName:          "editor",
+					Safe:                  false,
+					Reason:                "relationships exist under or reference relation `editor` in object definition `document`",
+					AffectedRelationships: 2,
+					ChangedPermissions:    []string{"document#edit", "document#view", "folder#view_all"},
+				},
+				{
+					DefinitionKind:     "definition",
+					DefinitionName:     "document",
+					Type:               "removed-permission",
+					RelationName:       "edit",
+					Safe:               true,
+					ChangedPermissions: []string{"document#edit", "document#view", "folder#view_all"},
+				},
+				{
+					DefinitionKind:     "definition",
+					DefinitionName:     "document",
+					Type:               "changed-permission-implementation",
+					RelationName:       "view",
+					Safe:               true,
+					ChangedPermissions: []string{"document#view", "folder#view_all"},
+				},
+			},
+		},
+		{
+			"removed allowed type with relationships",
+			`
+			definition user {}
+
+			caveat somecaveat(value int) {
+				value == 42
+			}
+
+			definition document {
+				relation viewer: user
+				relation editor: user
+				permission edit = editor
+				permission view = viewer + edit
+			}
+
+			definition folder {
+				relation doc: document
+				permission view_all = doc->view
+			}
+			`,
+			false,
+			false,
+			[]PlannedSchemaDelta{
+				{
+					DefinitionKind:        "definition",
+					DefinitionName:        "document",
+					Type:                  "relation-allowed-type-removed",
+					RelationName:          "viewer",
+					AllowedType:           "user with somecaveat",
+					Safe:                  false,
+					Reason:                "relationships exist with allowed type `user with somecaveat` on relation `viewer` in object definition `document`",
+					AffectedRelationships: 1,
+					ChangedPermissions:    []string{"document#view", "folder#view_all"},
+				},
+			},
+		},
+		{
+			"changed caveat expression",
+			`
+			definition user {}
+
+			caveat somecaveat(value int) {
+				value == 43
+			}
+
+			definition document {
+				relation viewer: user | user with somecaveat
+				relation editor: user
+				permission edit = editor
+				permission view = viewer + edit
+			}
+
+			definition folder {
+				relation doc: document
+				permission view_all = doc->view
+			}
+			`,
+			false,
+			true,
+			[]PlannedSchemaDelta{
+				{
+					DefinitionKind:        "caveat",
+					DefinitionName:        "somecaveat",
+					Type:                  "expression-may-have-changed",
+					Safe:                  true,
+					AffectedRelationships: 1,
+					ChangedPermissions:    []string{"document#view", "folder#view_all"},
+				},
+			},
+		},
+		{
+			"removed definition with relationships",
+			`
+			definition user {}
+
+			caveat somecaveat(value int) {
+				value == 42
+			}
+
+			definition document {
+				relation viewer: user | user with somecaveat
+				relation editor: user
+				permission edit = editor
+				permission view = viewer + edit
+			}
+			`,
+			false,
+			false,
+			[]PlannedSchemaDelta{
+				{
+					DefinitionKind:        "definition",
+					DefinitionName:        "folder",
+					Type:                  "namespace-removed",
+					Safe:                  false,
+					Reason:                "relationships exist under or reference object definition `folder`",
+					AffectedRelationships: 1,
+					ChangedPermissions:    []string{"folder#view_all"},
+				},
+			},
+		},
+		{
+			"removed definition in additive-only mode",
+			`
+			definition user {}
+
+			caveat somecaveat(value int) {
+				value == 42
+			}
+
+			definition document {
+				relation viewer: user | user with somecaveat
+				relation editor: user
+				permission edit = editor
+				permission view = viewer + edit
+			}
+			`,
+			true,
+			true,
+			[]PlannedSchemaDelta{},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			rawDS, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+			require.NoError(err)
+
+			ds, _ := testfixtures.DatastoreFromSchemaAndTestRelationships(rawDS, planExistingSchema, []*core.RelationTuple{
+				tuple.MustParse("document:first#viewer@user:tom[somecaveat]"),
+				tuple.MustParse("document:first#editor@user:sarah"),
+				tuple.MustParse("document:second#editor@user:fred"),
+				tuple.MustParse("folder:root#doc@document:first"),
+			}, require)
+
+			compiled, err := compiler.Compile(compiler.InputSchema{
+				Source:       input.Source("schema"),
+				SchemaString: tc.proposedSchema,
+			}, compiler.AllowUnprefixedObjectType())
+			require.NoError(err)
+
+			validated, err := ValidateSchemaChanges(context.Background(), compiled, tc.additiveOnly)
+			require.NoError(err)
+
+			headRevision, err := ds.HeadRevision(context.Background())
+			require.NoError(err)
+
+			plan, err := PlanSchemaChanges(context.Background(), ds.SnapshotReader(headRevision), validated)
+			require.NoError(err)
+			require.Equal(tc.expectedSafe, plan.Safe)
+			require.Equal(tc.expectedDeltas, plan.Deltas)
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/authzed/authzed-go/pkg/requestmeta"
+	"github.com/authzed/authzed-go/pkg/responsemeta"
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 
 	cexpr "github.com/authzed/spicedb/internal/caveats"
@@ -17,6 +19,81 @@ import (
 	"github.com/authzed/spicedb/pkg/tuple"
 )
 
+const (
+	// RequestWhyInformation, if specified on a CheckPermission request, requests that a minimal
+	// explanation of why the subject has the permission be returned in the WhyInformation trailer.
+	RequestWhyInformation requestmeta.BoolRequestMetadataHeaderKey = "io.spicedb.requestwhyinfo"
+
+	// WhyInformation is the trailer containing the JSON-encoded WhyExplanation of why the subject
+	// has the permission, if requested via RequestWhyInformation and the subject has the permission.
+	WhyInformation responsemeta.ResponseMetadataTrailerKey = "io.spicedb.respmeta.whyinfo"
+)
+
+// WhyExplanation is the explanation of why a subject has a permission or relation on a resource,
+// returned in the WhyInformation trailer. Unlike the explanation computed by the check engine, its
+// JSON encoding is stable across releases.
+type WhyExplanation struct {
+	// Resource is the resource and permission or relation explained, e.g. `document:readme#view`.
+	Resource string `json:"resource"`
+
+	// Relationship is the relationship of the resource that was traversed to reach the steps, e.g.
+	// `document:readme#viewer@group:eng#member`, if any.
+	Relationship string `json:"relationship,omitempty"`
+
+	// Caveat is the evaluation of the caveat on the relationship, if any.
+	Caveat *WhyCaveat `json:"caveat,omitempty"`
+
+	// Steps are the explanations that satisfied the resource: one for a union or an arrow, and one
+	// per branch for an intersection or an `all` arrow. A resource without steps nor relationship
+	// is the subject itself.
+	Steps []*WhyExplanation `json:"steps,omitempty"`
+}
+
+// WhyCaveat is the evaluation of a caveat found on a relationship in a WhyExplanation.
+type WhyCaveat struct {
+	Name string `json:"name"`
+
+	// Expression is the caveat expression as evaluated, with the context values applied.
+	Expression string `json:"expression"`
+
+	// Result is the name of the CaveatEvalInfo.Result of the evaluation: `RESULT_TRUE` or
+	// `RESULT_MISSING_SOME_CONTEXT`.
+	Result string `json:"result"`
+
+	// MissingRequiredContext are the context parameters that were required to fully evaluate the
+	// caveat, if the result is `RESULT_MISSING_SOME_CONTEXT`.
+	MissingRequiredContext []string `json:"missingRequiredContext,omitempty"`
+}
+
+// ConvertCheckExplanation converts an explanation computed by the check engine into the
+// WhyExplanation returnable to the API.
+func ConvertCheckExplanation(explanation *dispatch.CheckExplanation) *WhyExplanation {
+	converted := &WhyExplanation{
+		Resource:     tuple.StringONR(explanation.Resource),
+		Relationship: tuple.StringWithoutCaveat(explanation.Relationship),
+	}
+
+	if caveat := explanation.Caveat; caveat != nil {
+		result := v1.CaveatEvalInfo_RESULT_TRUE
+		if caveat.Result == dispatch.CaveatExplanation_MISSING_SOME_CONTEXT {
+			result = v1.CaveatEvalInfo_RESULT_MISSING_SOME_CONTEXT
+		}
+
+		converted.Caveat = &WhyCaveat{
+			Name:                   caveat.CaveatName,
+			Expression:             caveat.Expression,
+			Result:                 result.String(),
+			MissingRequiredContext: caveat.MissingRequiredContext,
+		}
+	}
+
+	for _, step := range explanation.Steps {
+		converted.Steps = append(converted.Steps, ConvertCheckExplanation(step))
+	}
+
+	return converted
+}
+
 // ConvertCheckDispatchDebugInformation converts dispatch debug information found in the response metadata
 // into DebugInformation returnable to the API.
 func ConvertCheckDispatchDebugInformation(
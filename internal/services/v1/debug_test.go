@@ -2,6 +2,7 @@ package v1_test
 
 import (
 	"context"
+	"encoding/json"
 	"sort"
 	"strings"
 	"testing"
@@ -17,11 +18,13 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/authzed/spicedb/internal/datastore/memdb"
+	v1svc "github.com/authzed/spicedb/internal/services/v1"
 	tf "github.com/authzed/spicedb/internal/testfixtures"
 	"github.com/authzed/spicedb/internal/testserver"
 	"github.com/authzed/spicedb/pkg/datastore"
 	"github.com/authzed/spicedb/pkg/genutil/mapz"
 	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	dispatch "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
 	"github.com/authzed/spicedb/pkg/tuple"
 	"github.com/authzed/spicedb/pkg/zedtoken"
 )
@@ -508,3 +511,42 @@ func TestCheckPermissionWithDebug(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertCheckExplanation(t *testing.T) {
+	explanation := &dispatch.CheckExplanation{
+		Resource:     tuple.ParseONR("document:readme#view"),
+		Relationship: tuple.MustParse("document:readme#viewer@group:eng#member"),
+		Steps: []*dispatch.CheckExplanation{
+			{
+				Resource:     tuple.ParseONR("group:eng#member"),
+				Relationship: tuple.MustParse(`group:eng#member@user:tom[on_weekdays:{"day":"monday"}]`),
+				Caveat: &dispatch.CaveatExplanation{
+					CaveatName:             "on_weekdays",
+					Expression:             "day != \"saturday\" && day != \"sunday\" && hour < 18",
+					Result:                 dispatch.CaveatExplanation_MISSING_SOME_CONTEXT,
+					MissingRequiredContext: []string{"hour"},
+				},
+			},
+		},
+	}
+
+	// The JSON encoding of the explanation is part of the API, and must not change.
+	marshaled, err := json.Marshal(v1svc.ConvertCheckExplanation(explanation))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"resource": "document:readme#view",
+		"relationship": "document:readme#viewer@group:eng#member",
+		"steps": [
+			{
+				"resource": "group:eng#member",
+				"relationship": "group:eng#member@user:tom",
+				"caveat": {
+					"name": "on_weekdays",
+					"expression": "day != \"saturday\" && day != \"sunday\" && hour < 18",
+					"result": "RESULT_MISSING_SOME_CONTEXT",
+					"missingRequiredContext": ["hour"]
+				}
+			}
+		]
+	}`, string(marshaled))
+}
@@ -17,6 +17,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
 
+	"github.com/authzed/spicedb/internal/audit"
 	"github.com/authzed/spicedb/internal/dispatch"
 	"github.com/authzed/spicedb/internal/graph"
 	"github.com/authzed/spicedb/internal/graph/computed"
@@ -211,7 +212,7 @@ func (es *experimentalServer) BulkImportRelationships(stream v1.ExperimentalServ
 	ds := datastoremw.MustFromContext(stream.Context())
 
 	var numWritten uint64
-	if _, err := ds.ReadWriteTx(stream.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+	revision, err := ds.ReadWriteTx(stream.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
 		loadedNamespaces := make(map[string]*typesystem.TypeSystem)
 		loadedCaveats := make(map[string]*core.CaveatDefinition)
 
@@ -264,7 +265,8 @@ func (es *experimentalServer) BulkImportRelationships(stream v1.ExperimentalServ
 		numWritten += streamWritten
 
 		return err
-	}, dsoptions.WithDisableRetries(true)); err != nil {
+	}, dsoptions.WithDisableRetries(true))
+	if err != nil {
 		return es.rewriteError(stream.Context(), err)
 	}
 
@@ -273,6 +275,9 @@ func (es *experimentalServer) BulkImportRelationships(stream v1.ExperimentalServ
 		DispatchCount: 1,
 	})
 
+	// The response does not carry the revision of the load, which is reported for auditing.
+	audit.SetRevisionInContext(stream.Context(), revision)
+
 	return stream.SendAndClose(&v1.BulkImportRelationshipsResponse{
 		NumLoaded: numWritten,
 	})
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/authzed/authzed-go/pkg/requestmeta"
@@ -67,31 +68,33 @@ func (ps *permissionServer) CheckPermission(ctx context.Context, req *v1.CheckPe
 	}
 
 	debugOption := computed.NoDebugging
+	isWhyRequested := false
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
 		_, isDebuggingEnabled := md[string(requestmeta.RequestDebugInformation)]
 		if isDebuggingEnabled {
 			debugOption = computed.BasicDebuggingEnabled
 		}
+
+		_, isWhyRequested = md[string(RequestWhyInformation)]
 	}
 
-	cr, metadata, err := computed.ComputeCheck(ctx, ps.dispatch,
-		computed.CheckParameters{
-			ResourceType: &core.RelationReference{
-				Namespace: req.Resource.ObjectType,
-				Relation:  req.Permission,
-			},
-			Subject: &core.ObjectAndRelation{
-				Namespace: req.Subject.Object.ObjectType,
-				ObjectId:  req.Subject.Object.ObjectId,
-				Relation:  normalizeSubjectRelation(req.Subject),
-			},
-			CaveatContext: caveatContext,
-			AtRevision:    atRevision,
-			MaximumDepth:  ps.config.MaximumAPIDepth,
-			DebugOption:   debugOption,
+	checkParams := computed.CheckParameters{
+		ResourceType: &core.RelationReference{
+			Namespace: req.Resource.ObjectType,
+			Relation:  req.Permission,
+		},
+		Subject: &core.ObjectAndRelation{
+			Namespace: req.Subject.Object.ObjectType,
+			ObjectId:  req.Subject.Object.ObjectId,
+			Relation:  normalizeSubjectRelation(req.Subject),
 		},
-		req.Resource.ObjectId,
-	)
+		CaveatContext: caveatContext,
+		AtRevision:    atRevision,
+		MaximumDepth:  ps.config.MaximumAPIDepth,
+		DebugOption:   debugOption,
+	}
+
+	cr, metadata, err := computed.ComputeCheck(ctx, ps.dispatch, checkParams, req.Resource.ObjectId)
 	usagemetrics.SetInContext(ctx, metadata)
 
 	if debugOption != computed.NoDebugging && metadata.DebugInfo != nil {
@@ -119,6 +122,29 @@ func (ps *permissionServer) CheckPermission(ctx context.Context, req *v1.CheckPe
 		return nil, ps.rewriteError(ctx, err)
 	}
 
+	if isWhyRequested && cr.Membership != dispatch.ResourceCheckResult_NOT_MEMBER {
+		// Compute the minimal explanation for the permission and marshal into the footer.
+		checkParams.DebugOption = computed.NoDebugging
+		explanation, eerr := computed.ComputeExplanation(ctx, ps.dispatch, checkParams, req.Resource.ObjectId)
+		if eerr != nil {
+			return nil, ps.rewriteError(ctx, eerr)
+		}
+
+		if explanation != nil {
+			marshaled, merr := json.Marshal(ConvertCheckExplanation(explanation))
+			if merr != nil {
+				return nil, ps.rewriteError(ctx, merr)
+			}
+
+			serr := responsemeta.SetResponseTrailerMetadata(ctx, map[responsemeta.ResponseMetadataTrailerKey]string{
+				WhyInformation: string(marshaled),
+			})
+			if serr != nil {
+				return nil, ps.rewriteError(ctx, serr)
+			}
+		}
+	}
+
 	permissionship, partialCaveat := checkResultToAPITypes(cr)
 
 	return &v1.CheckPermissionResponse{
@@ -3,6 +3,7 @@ package v1_test
 import (
 	"cmp"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -359,6 +360,56 @@ func TestCheckPermissionWithDebugInfo(t *testing.T) {
 	require.Equal(4, len(compiled.OrderedDefinitions))
 }
 
+func TestCheckPermissionWithWhyInfo(t *testing.T) {
+	require := require.New(t)
+	conn, cleanup, _, revision := testserver.NewTestServer(require, testTimedeltas[0], memdb.DisableGC, true, tf.StandardDatastoreWithData)
+	client := v1.NewPermissionsServiceClient(conn)
+	t.Cleanup(cleanup)
+
+	ctx := context.Background()
+	ctx = requestmeta.AddRequestHeaders(ctx, v1svc.RequestWhyInformation)
+
+	for _, tc := range []struct {
+		subject                *v1.SubjectReference
+		expectedPermissionship v1.CheckPermissionResponse_Permissionship
+	}{
+		{sub("user", "auditor", ""), v1.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION},
+		{sub("user", "unknown", ""), v1.CheckPermissionResponse_PERMISSIONSHIP_NO_PERMISSION},
+	} {
+		var trailer metadata.MD
+		checkResp, err := client.CheckPermission(ctx, &v1.CheckPermissionRequest{
+			Consistency: &v1.Consistency{
+				Requirement: &v1.Consistency_AtLeastAsFresh{
+					AtLeastAsFresh: zedtoken.MustNewFromRevision(revision),
+				},
+			},
+			Resource:   obj("document", "masterplan"),
+			Permission: "view",
+			Subject:    tc.subject,
+		}, grpc.Trailer(&trailer))
+
+		require.NoError(err)
+		require.Equal(tc.expectedPermissionship, checkResp.Permissionship)
+
+		encodedWhyInfo, err := responsemeta.GetResponseTrailerMetadataOrNil(trailer, v1svc.WhyInformation)
+		require.NoError(err)
+
+		if tc.expectedPermissionship == v1.CheckPermissionResponse_PERMISSIONSHIP_NO_PERMISSION {
+			require.Nil(encodedWhyInfo)
+			continue
+		}
+
+		require.NotNil(encodedWhyInfo)
+
+		var explanation v1svc.WhyExplanation
+		err = json.Unmarshal([]byte(*encodedWhyInfo), &explanation)
+		require.NoError(err)
+
+		require.Equal("document:masterplan#view", explanation.Resource)
+		require.NotEmpty(explanation.Steps)
+	}
+}
+
 func TestLookupResources(t *testing.T) {
 	testCases := []struct {
 		objectType           string
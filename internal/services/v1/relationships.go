@@ -3,6 +3,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
@@ -13,6 +14,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/authzed/spicedb/internal/audit"
 	"github.com/authzed/spicedb/internal/dispatch"
 	"github.com/authzed/spicedb/internal/middleware"
 	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
@@ -335,6 +337,10 @@ func (ps *permissionServer) WriteRelationships(ctx context.Context, req *v1.Writ
 	}, nil
 }
 
+// auditDeletedRelationships is the detail of the audit event of a deletion of relationships which
+// holds the number of relationships deleted.
+const auditDeletedRelationships = "deleted_relationships"
+
 func (ps *permissionServer) DeleteRelationships(ctx context.Context, req *v1.DeleteRelationshipsRequest) (*v1.DeleteRelationshipsResponse, error) {
 	if len(req.OptionalPreconditions) > int(ps.config.MaxPreconditionsCount) {
 		return nil, ps.rewriteError(
@@ -346,7 +352,9 @@ func (ps *permissionServer) DeleteRelationships(ctx context.Context, req *v1.Del
 	ds := datastoremw.MustFromContext(ctx)
 	deletionProgress := v1.DeleteRelationshipsResponse_DELETION_PROGRESS_COMPLETE
 
+	var deletedCount uint64
 	revision, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		deletedCount = 0
 		if err := ps.checkFilterNamespaces(ctx, req.RelationshipFilter, rwt); err != nil {
 			return err
 		}
@@ -395,17 +403,45 @@ func (ps *permissionServer) DeleteRelationships(ctx context.Context, req *v1.Del
 		}
 
 		if len(deleteMutations) > 0 {
+			deletedCount = uint64(len(deleteMutations))
 			return rwt.WriteRelationships(ctx, deleteMutations)
 		}
 
+		// The datastore does not report the number of relationships deleted by a filter, so they
+		// are only counted, in the same transaction, for the audit log.
+		if audit.IsAuditedInContext(ctx) {
+			count, err := countRelationships(ctx, rwt, req.RelationshipFilter)
+			if err != nil {
+				return ps.rewriteError(ctx, err)
+			}
+			deletedCount = count
+		}
+
 		return rwt.DeleteRelationships(ctx, req.RelationshipFilter)
 	})
 	if err != nil {
 		return nil, ps.rewriteError(ctx, err)
 	}
 
+	audit.SetDetailInContext(ctx, auditDeletedRelationships, strconv.FormatUint(deletedCount, 10))
+
 	return &v1.DeleteRelationshipsResponse{
 		DeletedAt:        zedtoken.MustNewFromRevision(revision),
 		DeletionProgress: deletionProgress,
 	}, nil
 }
+
+// countRelationships returns the number of relationships matching the filter.
+func countRelationships(ctx context.Context, reader datastore.Reader, filter *v1.RelationshipFilter) (uint64, error) {
+	iter, err := reader.QueryRelationships(ctx, datastore.RelationshipsFilterFromPublicFilter(filter))
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+
+	var count uint64
+	for tpl := iter.Next(); tpl != nil; tpl = iter.Next() {
+		count++
+	}
+	return count, iter.Err()
+}
@@ -2,15 +2,25 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/authzed/authzed-go/pkg/requestmeta"
+	"github.com/authzed/authzed-go/pkg/responsemeta"
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 	grpcvalidate "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/validator"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/authzed/spicedb/internal/audit"
+	"github.com/authzed/spicedb/internal/auth"
 	log "github.com/authzed/spicedb/internal/logging"
 	"github.com/authzed/spicedb/internal/middleware"
 	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
+	"github.com/authzed/spicedb/internal/middleware/schemaprefix"
 	"github.com/authzed/spicedb/internal/middleware/usagemetrics"
 	"github.com/authzed/spicedb/internal/services/shared"
 	"github.com/authzed/spicedb/pkg/datastore"
@@ -18,9 +28,80 @@ import (
 	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
 	"github.com/authzed/spicedb/pkg/schemadsl/generator"
 	"github.com/authzed/spicedb/pkg/schemadsl/input"
+	"github.com/authzed/spicedb/pkg/schemadsl/lint"
 	"github.com/authzed/spicedb/pkg/zedtoken"
 )
 
+const (
+	// RequestSchemaPlan, if specified on a WriteSchema request, requests that the schema not be
+	// written, and that the plan of its changes be returned in the SchemaPlan trailer instead, in
+	// which case the response has no WrittenAt.
+	RequestSchemaPlan requestmeta.BoolRequestMetadataHeaderKey = "io.spicedb.requestschemaplan"
+
+	// SchemaPlan is the trailer containing the JSON-encoded plan of the changes the schema would
+	// make, if requested via RequestSchemaPlan.
+	SchemaPlan responsemeta.ResponseMetadataTrailerKey = "io.spicedb.respmeta.schemaplan"
+
+	// RequestSchemaLint, if specified on a WriteSchema request, requests that the schema be linted
+	// and the warnings found be returned in the SchemaLintWarnings trailer. Warnings do not prevent
+	// the schema from being written.
+	RequestSchemaLint requestmeta.BoolRequestMetadataHeaderKey = "io.spicedb.requestschemalint"
+
+	// SchemaLintWarnings is the trailer containing the JSON-encoded lint warnings of the schema, if
+	// requested via RequestSchemaLint.
+	SchemaLintWarnings responsemeta.ResponseMetadataTrailerKey = "io.spicedb.respmeta.schemalintwarnings"
+
+	// RequestSchemaVersions, if specified on a ReadSchema request, requests that the versions of the
+	// schema be returned in the SchemaVersions trailer, most recent first. At most
+	// maxSchemaVersionsPerPage versions are returned; the following ones are requested via
+	// RequestSchemaVersionsBefore.
+	RequestSchemaVersions requestmeta.BoolRequestMetadataHeaderKey = "io.spicedb.requestschemaversions"
+
+	// RequestSchemaVersionsBefore, if specified on a ReadSchema request along with
+	// RequestSchemaVersions, holds the number of a version of the schema, such that only the
+	// versions preceding it are returned.
+	RequestSchemaVersionsBefore requestmeta.RequestMetadataHeaderKey = "io.spicedb.requestschemaversionsbefore"
+
+	// SchemaVersions is the trailer containing the JSON-encoded versions of the schema, if
+	// requested via RequestSchemaVersions.
+	SchemaVersions responsemeta.ResponseMetadataTrailerKey = "io.spicedb.respmeta.schemaversions"
+
+	// RequestSchemaVersion, if specified on a ReadSchema request, holds the number of the version
+	// of the schema to be read instead of the current schema.
+	RequestSchemaVersion requestmeta.RequestMetadataHeaderKey = "io.spicedb.requestschemaversion"
+
+	// RequestSchemaAtRevision, if specified on a ReadSchema request, holds the ZedToken of the
+	// revision at which the schema is to be read instead of the head revision.
+	RequestSchemaAtRevision requestmeta.RequestMetadataHeaderKey = "io.spicedb.requestschemaatrevision"
+
+	// RequestSchemaDiff, if specified on a ReadSchema request, holds the numbers of two versions of
+	// the schema, in `from:to` form, whose changes are returned in the SchemaDiff trailer.
+	RequestSchemaDiff requestmeta.RequestMetadataHeaderKey = "io.spicedb.requestschemadiff"
+
+	// SchemaDiff is the trailer containing the JSON-encoded changes between the two versions of
+	// the schema requested via RequestSchemaDiff. At most maxSchemaDiffDeltas changes are
+	// returned, and the diff is marked as truncated if there are more.
+	SchemaDiff responsemeta.ResponseMetadataTrailerKey = "io.spicedb.respmeta.schemadiff"
+
+	// RequestSchemaRollback, if specified on a WriteSchema request, holds the number of the version
+	// of the schema to be written again, in place of the schema of the request, which must be
+	// empty. The version is subject to the same checks as any other schema written.
+	RequestSchemaRollback requestmeta.RequestMetadataHeaderKey = "io.spicedb.requestschemarollback"
+)
+
+const (
+	// maxSchemaVersionsPerPage is the maximum number of versions of the schema returned in the
+	// SchemaVersions trailer.
+	maxSchemaVersionsPerPage = 100
+
+	// maxSchemaDiffDeltas is the maximum number of changes returned in the SchemaDiff trailer.
+	maxSchemaDiffDeltas = 500
+
+	// auditRolledBackToVersion is the detail of the audit event of a write of the schema which
+	// holds the version of the schema to which it rolled back.
+	auditRolledBackToVersion = "rolled_back_to_version"
+)
+
 // NewSchemaServer creates a SchemaServiceServer instance.
 func NewSchemaServer(additiveOnly bool) v1.SchemaServiceServer {
 	return &schemaServer{
@@ -50,14 +131,63 @@ func (ss *schemaServer) rewriteError(ctx context.Context, err error) error {
 }
 
 func (ss *schemaServer) ReadSchema(ctx context.Context, _ *v1.ReadSchemaRequest) (*v1.ReadSchemaResponse, error) {
-	// Schema is always read from the head revision.
+	// Schema is read from the head revision, unless another revision is requested.
 	ds := datastoremw.MustFromContext(ctx)
 	headRevision, err := ds.HeadRevision(ctx)
 	if err != nil {
 		return nil, ss.rewriteError(ctx, err)
 	}
 
-	reader := ds.SnapshotReader(headRevision)
+	md, _ := metadata.FromIncomingContext(ctx)
+	_, isVersionsRequested := md[string(RequestSchemaVersions)]
+	requestedVersion := md.Get(string(RequestSchemaVersion))
+	requestedDiff := md.Get(string(RequestSchemaDiff))
+
+	if isVersionsRequested || len(requestedVersion) > 0 || len(requestedDiff) > 0 {
+		// The versions hold the full schema, including the definitions of other prefixes.
+		if _, ok := schemaprefix.FromContext(ctx); ok {
+			return nil, status.Errorf(codes.PermissionDenied, "the versions of the schema cannot be read by a caller bound to a schema prefix")
+		}
+
+		historyReader := ds.SnapshotReader(headRevision)
+		if isVersionsRequested {
+			if err := ss.listSchemaVersions(ctx, historyReader, md.Get(string(RequestSchemaVersionsBefore))); err != nil {
+				return nil, ss.rewriteError(ctx, err)
+			}
+		}
+
+		if len(requestedDiff) > 0 {
+			if err := ss.diffSchemaVersions(ctx, historyReader, requestedDiff[0]); err != nil {
+				return nil, ss.rewriteError(ctx, err)
+			}
+		}
+
+		if len(requestedVersion) > 0 {
+			found, err := readSchemaVersion(ctx, historyReader, requestedVersion[0])
+			if err != nil {
+				return nil, ss.rewriteError(ctx, err)
+			}
+
+			return &v1.ReadSchemaResponse{
+				SchemaText: found.Schema,
+				ReadAt:     zedtoken.MustNewFromRevision(headRevision),
+			}, nil
+		}
+	}
+
+	readRevision := headRevision
+	if requestedRevision := md.Get(string(RequestSchemaAtRevision)); len(requestedRevision) > 0 {
+		readRevision, err = zedtoken.DecodeRevision(&v1.ZedToken{Token: requestedRevision[0]}, ds)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid zedtoken in `%s`: %s", RequestSchemaAtRevision, err)
+		}
+
+		if err := ds.CheckRevision(ctx, readRevision); err != nil {
+			return nil, ss.rewriteError(ctx, err)
+		}
+	}
+
+	reader := ds.SnapshotReader(readRevision)
 
 	nsDefs, err := reader.ListAllNamespaces(ctx)
 	if err != nil {
@@ -69,6 +199,12 @@ func (ss *schemaServer) ReadSchema(ctx context.Context, _ *v1.ReadSchemaRequest)
 		return nil, ss.rewriteError(ctx, err)
 	}
 
+	// Callers bound to a schema prefix only see the definitions under their prefix.
+	if prefix, ok := schemaprefix.FromContext(ctx); ok {
+		nsDefs = shared.DefinitionsUnderPrefix(nsDefs, prefix)
+		caveatDefs = shared.DefinitionsUnderPrefix(caveatDefs, prefix)
+	}
+
 	if len(nsDefs) == 0 {
 		return nil, status.Errorf(codes.NotFound, "No schema has been defined; please call WriteSchema to start")
 	}
@@ -93,7 +229,7 @@ func (ss *schemaServer) ReadSchema(ctx context.Context, _ *v1.ReadSchemaRequest)
 
 	return &v1.ReadSchemaResponse{
 		SchemaText: schemaText,
-		ReadAt:     zedtoken.MustNewFromRevision(headRevision),
+		ReadAt:     zedtoken.MustNewFromRevision(readRevision),
 	}, nil
 }
 
@@ -102,28 +238,88 @@ func (ss *schemaServer) WriteSchema(ctx context.Context, in *v1.WriteSchemaReque
 
 	ds := datastoremw.MustFromContext(ctx)
 
+	// Callers bound to a schema prefix write the definitions under their prefix, to which
+	// definitions without a prefix are added.
+	prefixOption := compiler.AllowUnprefixedObjectType()
+	prefix, isPrefixBound := schemaprefix.FromContext(ctx)
+	if isPrefixBound {
+		prefixOption = compiler.ObjectTypePrefix(prefix)
+	}
+
+	schemaText := in.GetSchema()
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if requestedVersion := md.Get(string(RequestSchemaRollback)); len(requestedVersion) > 0 {
+			rollbackVersion, err := ss.rollbackSchemaVersion(ctx, ds, in, requestedVersion[0])
+			if err != nil {
+				return nil, ss.rewriteError(ctx, err)
+			}
+			schemaText = rollbackVersion.Schema
+
+			// The request holds no schema, so the schema applied is audited instead.
+			audit.SetMutationsInContext(ctx, &v1.WriteSchemaRequest{Schema: schemaText})
+			audit.SetDetailInContext(ctx, auditRolledBackToVersion, strconv.FormatUint(rollbackVersion.Version, 10))
+		}
+	}
+
 	// Compile the schema into the namespace definitions.
 	compiled, err := compiler.Compile(compiler.InputSchema{
 		Source:       input.Source("schema"),
-		SchemaString: in.GetSchema(),
-	}, compiler.AllowUnprefixedObjectType())
+		SchemaString: schemaText,
+	}, prefixOption)
 	if err != nil {
 		return nil, ss.rewriteError(ctx, err)
 	}
 	log.Ctx(ctx).Trace().Int("objectDefinitions", len(compiled.ObjectDefinitions)).Int("caveatDefinitions", len(compiled.CaveatDefinitions)).Msg("compiled namespace definitions")
 
+	if isPrefixBound {
+		for _, def := range compiled.OrderedDefinitions {
+			if !strings.HasPrefix(def.GetName(), prefix+"/") {
+				return nil, status.Errorf(codes.PermissionDenied, "definition `%s` is outside of the schema prefix `%s` of the caller", def.GetName(), prefix)
+			}
+		}
+	}
+
 	// Do as much validation as we can before talking to the datastore.
 	validated, err := shared.ValidateSchemaChanges(ctx, compiled, ss.additiveOnly)
 	if err != nil {
 		return nil, ss.rewriteError(ctx, err)
 	}
 
-	// Update the schema.
+	// The definitions of other prefixes are left untouched.
+	if isPrefixBound {
+		validated = validated.ScopedToPrefix(prefix)
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if _, isLintRequested := md[string(RequestSchemaLint)]; isLintRequested {
+			if err := ss.lintSchema(ctx, compiled); err != nil {
+				return nil, ss.rewriteError(ctx, err)
+			}
+		}
+
+		if _, isPlanRequested := md[string(RequestSchemaPlan)]; isPlanRequested {
+			// Planning does not write the schema, and so is not audited.
+			audit.SkipInContext(ctx)
+			return ss.planSchema(ctx, ds, validated)
+		}
+	}
+
+	// Update the schema, recording the resulting schema as its next version.
 	revision, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		versionText, err := shared.SchemaTextAfterChanges(ctx, rwt, validated)
+		if err != nil {
+			return err
+		}
+
 		applied, err := shared.ApplySchemaChanges(ctx, rwt, validated)
 		if err != nil {
 			return err
 		}
+
+		if _, err := rwt.WriteSchemaVersion(ctx, versionText, auth.PrincipalFromContext(ctx)); err != nil {
+			return err
+		}
+
 		usagemetrics.SetInContext(ctx, &dispatchv1.ResponseMeta{
 			DispatchCount: applied.TotalOperationCount,
 		})
@@ -137,3 +333,157 @@ func (ss *schemaServer) WriteSchema(ctx context.Context, in *v1.WriteSchemaReque
 		WrittenAt: zedtoken.MustNewFromRevision(revision),
 	}, nil
 }
+
+// lintSchema returns the lint warnings of the compiled schema in the SchemaLintWarnings trailer.
+func (ss *schemaServer) lintSchema(ctx context.Context, compiled *compiler.CompiledSchema) error {
+	warnings := lint.Lint(compiled, lint.DefaultRules())
+	if warnings == nil {
+		warnings = []lint.Warning{}
+	}
+
+	marshaled, err := json.Marshal(warnings)
+	if err != nil {
+		return err
+	}
+
+	return responsemeta.SetResponseTrailerMetadata(ctx, map[responsemeta.ResponseMetadataTrailerKey]string{
+		SchemaLintWarnings: string(marshaled),
+	})
+}
+
+// planSchema computes the plan of the validated schema changes against the head revision of the
+// datastore, and returns it in the SchemaPlan trailer without writing the schema.
+func (ss *schemaServer) planSchema(ctx context.Context, ds datastore.Datastore, validated *shared.ValidatedSchemaChanges) (*v1.WriteSchemaResponse, error) {
+	headRevision, err := ds.HeadRevision(ctx)
+	if err != nil {
+		return nil, ss.rewriteError(ctx, err)
+	}
+
+	plan, err := shared.PlanSchemaChanges(ctx, ds.SnapshotReader(headRevision), validated)
+	if err != nil {
+		return nil, ss.rewriteError(ctx, err)
+	}
+
+	marshaled, err := json.Marshal(plan)
+	if err != nil {
+		return nil, ss.rewriteError(ctx, err)
+	}
+
+	err = responsemeta.SetResponseTrailerMetadata(ctx, map[responsemeta.ResponseMetadataTrailerKey]string{
+		SchemaPlan: string(marshaled),
+	})
+	if err != nil {
+		return nil, ss.rewriteError(ctx, err)
+	}
+
+	// Nothing was written, so the response carries no revision.
+	return &v1.WriteSchemaResponse{}, nil
+}
+
+// rollbackSchemaVersion returns the version of the schema to which the WriteSchema request rolls
+// back.
+func (ss *schemaServer) rollbackSchemaVersion(ctx context.Context, ds datastore.Datastore, in *v1.WriteSchemaRequest, requestedVersion string) (datastore.SchemaVersion, error) {
+	// The versions hold the full schema, including the definitions of other prefixes.
+	if _, ok := schemaprefix.FromContext(ctx); ok {
+		return datastore.SchemaVersion{}, status.Errorf(codes.PermissionDenied, "the schema cannot be rolled back by a caller bound to a schema prefix")
+	}
+
+	if in.GetSchema() != "" {
+		return datastore.SchemaVersion{}, status.Errorf(codes.InvalidArgument, "the schema must be empty when rolling back to a version of the schema")
+	}
+
+	headRevision, err := ds.HeadRevision(ctx)
+	if err != nil {
+		return datastore.SchemaVersion{}, err
+	}
+
+	return readSchemaVersion(ctx, ds.SnapshotReader(headRevision), requestedVersion)
+}
+
+// listSchemaVersions returns a page of the versions of the schema, preceding the requested
+// version if any, in the SchemaVersions trailer.
+func (ss *schemaServer) listSchemaVersions(ctx context.Context, reader datastore.Reader, requestedBefore []string) error {
+	versions, err := reader.ListSchemaVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(requestedBefore) > 0 {
+		before, err := strconv.ParseUint(strings.TrimSpace(requestedBefore[0]), 10, 64)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid `%s`: invalid version of the schema `%s`", RequestSchemaVersionsBefore, requestedBefore[0])
+		}
+
+		// The versions are listed most recent first.
+		start := sort.Search(len(versions), func(i int) bool {
+			return versions[i].Version < before
+		})
+		versions = versions[start:]
+	}
+
+	if len(versions) > maxSchemaVersionsPerPage {
+		versions = versions[:maxSchemaVersionsPerPage]
+	}
+
+	summaries, err := shared.SummarizeSchemaVersions(versions)
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(summaries)
+	if err != nil {
+		return err
+	}
+
+	return responsemeta.SetResponseTrailerMetadata(ctx, map[responsemeta.ResponseMetadataTrailerKey]string{
+		SchemaVersions: string(marshaled),
+	})
+}
+
+// diffSchemaVersions returns the changes between the two versions of the schema, given in
+// `from:to` form, in the SchemaDiff trailer.
+func (ss *schemaServer) diffSchemaVersions(ctx context.Context, reader datastore.Reader, requestedDiff string) error {
+	fromVersion, toVersion, ok := strings.Cut(requestedDiff, ":")
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "invalid `%s`: expected two versions of the schema in `from:to` form", RequestSchemaDiff)
+	}
+
+	from, err := readSchemaVersion(ctx, reader, fromVersion)
+	if err != nil {
+		return err
+	}
+
+	to, err := readSchemaVersion(ctx, reader, toVersion)
+	if err != nil {
+		return err
+	}
+
+	diff, err := shared.DiffSchemaVersions(from, to)
+	if err != nil {
+		return err
+	}
+
+	if len(diff.Deltas) > maxSchemaDiffDeltas {
+		diff.Deltas = diff.Deltas[:maxSchemaDiffDeltas]
+		diff.Truncated = true
+	}
+
+	marshaled, err := json.Marshal(diff)
+	if err != nil {
+		return err
+	}
+
+	return responsemeta.SetResponseTrailerMetadata(ctx, map[responsemeta.ResponseMetadataTrailerKey]string{
+		SchemaDiff: string(marshaled),
+	})
+}
+
+// readSchemaVersion reads the version of the schema with the given number.
+func readSchemaVersion(ctx context.Context, reader datastore.Reader, requestedVersion string) (datastore.SchemaVersion, error) {
+	version, err := strconv.ParseUint(strings.TrimSpace(requestedVersion), 10, 64)
+	if err != nil {
+		return datastore.SchemaVersion{}, status.Errorf(codes.InvalidArgument, "invalid version of the schema `%s`", requestedVersion)
+	}
+
+	return reader.ReadSchemaVersion(ctx, version)
+}
@@ -2,19 +2,27 @@ package v1_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"google.golang.org/protobuf/types/known/structpb"
 
+	"github.com/authzed/authzed-go/pkg/requestmeta"
+	"github.com/authzed/authzed-go/pkg/responsemeta"
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 	"github.com/authzed/grpcutil"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/internal/services/shared"
+	v1svc "github.com/authzed/spicedb/internal/services/v1"
 	tf "github.com/authzed/spicedb/internal/testfixtures"
 	"github.com/authzed/spicedb/internal/testserver"
 	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/lint"
 	"github.com/authzed/spicedb/pkg/spiceerrors"
 	"github.com/authzed/spicedb/pkg/tuple"
 )
@@ -585,3 +593,202 @@ func TestSchemaInvalid(t *testing.T) {
 	grpcutil.RequireStatus(t, codes.InvalidArgument, err)
 	require.ErrorContains(t, err, "found token TokenTypeStar")
 }
+
+func TestSchemaWritePlan(t *testing.T) {
+	conn, cleanup, _, _ := testserver.NewTestServer(require.New(t), 0, memdb.DisableGC, true, tf.EmptyDatastore)
+	t.Cleanup(cleanup)
+	client := v1.NewSchemaServiceClient(conn)
+	v1client := v1.NewPermissionsServiceClient(conn)
+
+	originalSchema := "definition example/document {\n\trelation somerelation: example/user\n}\n\ndefinition example/user {}"
+	_, err := client.WriteSchema(context.Background(), &v1.WriteSchemaRequest{
+		Schema: originalSchema,
+	})
+	require.NoError(t, err)
+
+	_, err = v1client.WriteRelationships(context.Background(), &v1.WriteRelationshipsRequest{
+		Updates: []*v1.RelationshipUpdate{tuple.UpdateToRelationshipUpdate(tuple.Create(
+			tuple.MustParse("example/document:somedoc#somerelation@example/user:someuser#..."),
+		))},
+	})
+	require.NoError(t, err)
+
+	// Request the plan for removing the `somerelation` relation.
+	ctx := requestmeta.AddRequestHeaders(context.Background(), v1svc.RequestSchemaPlan)
+
+	var trailer metadata.MD
+	planResp, err := client.WriteSchema(ctx, &v1.WriteSchemaRequest{
+		Schema: `definition example/user {}
+
+		definition example/document {}`,
+	}, grpc.Trailer(&trailer))
+	require.NoError(t, err)
+	require.Nil(t, planResp.WrittenAt)
+
+	encodedPlan, err := responsemeta.GetResponseTrailerMetadata(trailer, v1svc.SchemaPlan)
+	require.NoError(t, err)
+
+	var plan shared.SchemaChangePlan
+	require.NoError(t, json.Unmarshal([]byte(encodedPlan), &plan))
+	require.False(t, plan.Safe)
+	require.Len(t, plan.Deltas, 1)
+	require.Equal(t, "removed-relation", plan.Deltas[0].Type)
+	require.Equal(t, "somerelation", plan.Deltas[0].RelationName)
+	require.Equal(t, uint64(1), plan.Deltas[0].AffectedRelationships)
+
+	// Ensure the schema was not written.
+	readback, err := client.ReadSchema(context.Background(), &v1.ReadSchemaRequest{})
+	require.NoError(t, err)
+	require.Equal(t, originalSchema, readback.SchemaText)
+}
+
+func TestSchemaWriteLint(t *testing.T) {
+	conn, cleanup, _, _ := testserver.NewTestServer(require.New(t), 0, memdb.DisableGC, true, tf.EmptyDatastore)
+	t.Cleanup(cleanup)
+	client := v1.NewSchemaServiceClient(conn)
+
+	schema := `definition example/user {}
+
+definition example/document {
+	relation viewer: example/user
+	relation editor: example/user
+	permission view = viewer
+}`
+
+	ctx := requestmeta.AddRequestHeaders(context.Background(), v1svc.RequestSchemaLint)
+
+	var trailer metadata.MD
+	_, err := client.WriteSchema(ctx, &v1.WriteSchemaRequest{Schema: schema}, grpc.Trailer(&trailer))
+	require.NoError(t, err)
+
+	encodedWarnings, err := responsemeta.GetResponseTrailerMetadata(trailer, v1svc.SchemaLintWarnings)
+	require.NoError(t, err)
+
+	var warnings []lint.Warning
+	require.NoError(t, json.Unmarshal([]byte(encodedWarnings), &warnings))
+	require.Len(t, warnings, 1)
+	require.Equal(t, lint.UnusedRelationRule, warnings[0].Rule)
+	require.Equal(t, "example/document", warnings[0].Definition)
+	require.Equal(t, "editor", warnings[0].Relation)
+	require.Equal(t, uint64(5), warnings[0].Line)
+
+	// The schema is written despite the warnings.
+	readback, err := client.ReadSchema(context.Background(), &v1.ReadSchemaRequest{})
+	require.NoError(t, err)
+	require.Contains(t, readback.SchemaText, "relation editor")
+}
+
+func TestSchemaHistory(t *testing.T) {
+	conn, cleanup, _, _ := testserver.NewTestServer(require.New(t), 0, memdb.DisableGC, true, tf.EmptyDatastore)
+	t.Cleanup(cleanup)
+	client := v1.NewSchemaServiceClient(conn)
+
+	firstSchema := "definition example/document {\n\trelation viewer: example/user\n}\n\ndefinition example/user {}"
+	firstResp, err := client.WriteSchema(context.Background(), &v1.WriteSchemaRequest{Schema: firstSchema})
+	require.NoError(t, err)
+
+	secondSchema := "definition example/document {\n\trelation viewer: example/user\n\trelation editor: example/user\n}\n\ndefinition example/user {}"
+	_, err = client.WriteSchema(context.Background(), &v1.WriteSchemaRequest{Schema: secondSchema})
+	require.NoError(t, err)
+
+	// List the versions.
+	var trailer metadata.MD
+	_, err = client.ReadSchema(
+		requestmeta.AddRequestHeaders(context.Background(), v1svc.RequestSchemaVersions),
+		&v1.ReadSchemaRequest{},
+		grpc.Trailer(&trailer),
+	)
+	require.NoError(t, err)
+
+	encodedVersions, err := responsemeta.GetResponseTrailerMetadata(trailer, v1svc.SchemaVersions)
+	require.NoError(t, err)
+
+	var versions []shared.SchemaVersionSummary
+	require.NoError(t, json.Unmarshal([]byte(encodedVersions), &versions))
+	require.Len(t, versions, 2)
+	require.Equal(t, uint64(2), versions[0].Version)
+	require.Equal(t, uint64(1), versions[1].Version)
+	require.Equal(t, firstResp.WrittenAt.Token, versions[1].WrittenAt)
+
+	// List the versions preceding a version.
+	_, err = client.ReadSchema(
+		requestmeta.SetRequestHeaders(
+			requestmeta.AddRequestHeaders(context.Background(), v1svc.RequestSchemaVersions),
+			map[requestmeta.RequestMetadataHeaderKey]string{v1svc.RequestSchemaVersionsBefore: "2"},
+		),
+		&v1.ReadSchemaRequest{},
+		grpc.Trailer(&trailer),
+	)
+	require.NoError(t, err)
+
+	encodedVersions, err = responsemeta.GetResponseTrailerMetadata(trailer, v1svc.SchemaVersions)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(encodedVersions), &versions))
+	require.Len(t, versions, 1)
+	require.Equal(t, uint64(1), versions[0].Version)
+
+	// Read a past version.
+	readback, err := client.ReadSchema(requestmeta.SetRequestHeaders(context.Background(), map[requestmeta.RequestMetadataHeaderKey]string{
+		v1svc.RequestSchemaVersion: "1",
+	}), &v1.ReadSchemaRequest{})
+	require.NoError(t, err)
+	require.Equal(t, firstSchema, readback.SchemaText)
+
+	// Read the schema at a past revision.
+	readback, err = client.ReadSchema(requestmeta.SetRequestHeaders(context.Background(), map[requestmeta.RequestMetadataHeaderKey]string{
+		v1svc.RequestSchemaAtRevision: firstResp.WrittenAt.Token,
+	}), &v1.ReadSchemaRequest{})
+	require.NoError(t, err)
+	require.Equal(t, firstSchema, readback.SchemaText)
+	require.Equal(t, firstResp.WrittenAt.Token, readback.ReadAt.Token)
+
+	// Diff the two versions.
+	_, err = client.ReadSchema(requestmeta.SetRequestHeaders(context.Background(), map[requestmeta.RequestMetadataHeaderKey]string{
+		v1svc.RequestSchemaDiff: "1:2",
+	}), &v1.ReadSchemaRequest{}, grpc.Trailer(&trailer))
+	require.NoError(t, err)
+
+	encodedDiff, err := responsemeta.GetResponseTrailerMetadata(trailer, v1svc.SchemaDiff)
+	require.NoError(t, err)
+
+	var diff shared.SchemaVersionDiff
+	require.NoError(t, json.Unmarshal([]byte(encodedDiff), &diff))
+	require.Equal(t, []shared.SchemaVersionDelta{{
+		DefinitionKind: shared.PlannedObjectDefinition,
+		DefinitionName: "example/document",
+		Type:           "added-relation",
+		RelationName:   "editor",
+	}}, diff.Deltas)
+	require.False(t, diff.Truncated)
+
+	// Roll back to the first version, which is recorded as a new version.
+	rollbackCtx := requestmeta.SetRequestHeaders(context.Background(), map[requestmeta.RequestMetadataHeaderKey]string{
+		v1svc.RequestSchemaRollback: "1",
+	})
+	_, err = client.WriteSchema(rollbackCtx, &v1.WriteSchemaRequest{Schema: secondSchema})
+	grpcutil.RequireStatus(t, codes.InvalidArgument, err)
+
+	_, err = client.WriteSchema(rollbackCtx, &v1.WriteSchemaRequest{})
+	require.NoError(t, err)
+
+	readback, err = client.ReadSchema(context.Background(), &v1.ReadSchemaRequest{})
+	require.NoError(t, err)
+	require.Equal(t, firstSchema, readback.SchemaText)
+
+	readback, err = client.ReadSchema(requestmeta.SetRequestHeaders(context.Background(), map[requestmeta.RequestMetadataHeaderKey]string{
+		v1svc.RequestSchemaVersion: "3",
+	}), &v1.ReadSchemaRequest{})
+	require.NoError(t, err)
+	require.Equal(t, firstSchema, readback.SchemaText)
+
+	// Unknown versions are not found.
+	_, err = client.ReadSchema(requestmeta.SetRequestHeaders(context.Background(), map[requestmeta.RequestMetadataHeaderKey]string{
+		v1svc.RequestSchemaVersion: "42",
+	}), &v1.ReadSchemaRequest{})
+	grpcutil.RequireStatus(t, codes.NotFound, err)
+
+	_, err = client.WriteSchema(requestmeta.SetRequestHeaders(context.Background(), map[requestmeta.RequestMetadataHeaderKey]string{
+		v1svc.RequestSchemaRollback: "42",
+	}), &v1.WriteSchemaRequest{})
+	grpcutil.RequireStatus(t, codes.NotFound, err)
+}
@@ -167,6 +167,14 @@ func (vsr validatingSnapshotReader) ListAllCaveats(ctx context.Context) ([]datas
 	return read, err
 }
 
+func (vsr validatingSnapshotReader) ListSchemaVersions(ctx context.Context) ([]datastore.SchemaVersion, error) {
+	return vsr.delegate.ListSchemaVersions(ctx)
+}
+
+func (vsr validatingSnapshotReader) ReadSchemaVersion(ctx context.Context, version uint64) (datastore.SchemaVersion, error) {
+	return vsr.delegate.ReadSchemaVersion(ctx, version)
+}
+
 type validatingReadWriteTransaction struct {
 	validatingSnapshotReader
 	delegate datastore.ReadWriteTransaction
@@ -221,6 +229,10 @@ func (vrwt validatingReadWriteTransaction) DeleteCaveats(ctx context.Context, na
 	return vrwt.delegate.DeleteCaveats(ctx, names)
 }
 
+func (vrwt validatingReadWriteTransaction) WriteSchemaVersion(ctx context.Context, schema string, author string) (uint64, error) {
+	return vrwt.delegate.WriteSchemaVersion(ctx, schema, author)
+}
+
 func (vrwt validatingReadWriteTransaction) BulkLoad(ctx context.Context, source datastore.BulkWriteRelationshipSource) (uint64, error) {
 	return vrwt.delegate.BulkLoad(ctx, source)
 }
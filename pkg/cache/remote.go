@@ -0,0 +1,449 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+
+	"github.com/authzed/spicedb/internal/dispatch/keys"
+	log "github.com/authzed/spicedb/internal/logging"
+)
+
+const (
+	defaultRemoteKeyPrefix       = "spicedb/dispatch/"
+	defaultRemoteMaxEntrySize    = 1 << 20
+	defaultRemoteTTL             = 10 * time.Minute
+	defaultRemoteTimeout         = 50 * time.Millisecond
+	defaultRemoteWriteBufferSize = 1024
+
+	// remoteEntryVersion is the version of the encoding of entries stored remotely, which must be
+	// changed whenever the encoding is changed.
+	remoteEntryVersion byte = 2
+
+	remoteEntryBytes      byte = 1
+	remoteEntryBytesSlice byte = 2
+)
+
+var (
+	remoteHitsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: promNamespace,
+		Subsystem: promSubsystem,
+		Name:      "remote_hits_total",
+		Help:      "Number of entries found in the remote cache after missing the local cache",
+	})
+
+	remoteMissesCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: promNamespace,
+		Subsystem: promSubsystem,
+		Name:      "remote_misses_total",
+		Help:      "Number of entries not found in either the local or the remote cache",
+	})
+
+	remoteErrorsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: promNamespace,
+		Subsystem: promSubsystem,
+		Name:      "remote_errors_total",
+		Help:      "Number of failed operations against the remote cache",
+	}, []string{"operation"})
+
+	remoteDroppedWritesCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: promNamespace,
+		Subsystem: promSubsystem,
+		Name:      "remote_dropped_writes_total",
+		Help:      "Number of entries not written to the remote cache because too many writes were pending",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(remoteHitsCounter, remoteMissesCounter, remoteErrorsCounter, remoteDroppedWritesCounter)
+}
+
+// RemoteStore is a key/value service holding cache entries shared between processes.
+type RemoteStore interface {
+	// Get returns the value stored for the key, if any.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores the value for the key. If the TTL is greater than zero, the value expires after it.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// RemoteConfig configures a second-level cache backed by a RemoteStore.
+type RemoteConfig struct {
+	// Store is the remote store holding the entries.
+	Store RemoteStore
+
+	// KeyPrefix is prepended to the key of every entry in the remote store.
+	// Defaults to `spicedb/dispatch/`.
+	KeyPrefix string
+
+	// MaxEntrySize is the maximum size, in bytes, of an encoded entry written to the remote store.
+	// Larger entries are only cached locally. Defaults to 1MiB.
+	MaxEntrySize int
+
+	// TTL is the lifetime of entries written to the remote store. Defaults to 10m.
+	TTL time.Duration
+
+	// Timeout is the maximum duration of each operation against the remote store.
+	// Defaults to 50ms.
+	Timeout time.Duration
+
+	// WriteBufferSize is the maximum number of writes to the remote store which can be pending;
+	// further entries are only cached locally until the writes complete. Defaults to 1024.
+	WriteBufferSize int
+}
+
+func (rc *RemoteConfig) MarshalZerologObject(e *zerolog.Event) {
+	e.
+		Str("keyPrefix", rc.KeyPrefix).
+		Int("maxEntrySize", rc.MaxEntrySize).
+		Dur("ttl", rc.TTL).
+		Dur("timeout", rc.Timeout).
+		Int("writeBufferSize", rc.WriteBufferSize)
+}
+
+// NewTieredCache returns a cache which first consults the local cache and then the remote store
+// described by the config, populating the local cache with entries found remotely. Entries set
+// on the cache are written to the local cache and asynchronously to the remote store.
+//
+// Only entries keyed by a dispatch cache key with a value of either a byte slice or a slice of
+// byte slices, such as the serialized dispatch responses cached by the caching dispatcher, are
+// stored remotely; all other entries, including the results the caching dispatcher tracks for
+// reuse across revisions or for expiration, are only cached locally. Entries are stored remotely
+// under the stable sum of their dispatch cache key, along with its stable check sum, and are only
+// returned for keys with the same check sum.
+func NewTieredCache(local Cache, config RemoteConfig) (Cache, error) {
+	if config.Store == nil {
+		return nil, errors.New("remote cache requires a store")
+	}
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = defaultRemoteKeyPrefix
+	}
+	if config.MaxEntrySize <= 0 {
+		config.MaxEntrySize = defaultRemoteMaxEntrySize
+	}
+	if config.TTL <= 0 {
+		config.TTL = defaultRemoteTTL
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultRemoteTimeout
+	}
+	if config.WriteBufferSize <= 0 {
+		config.WriteBufferSize = defaultRemoteWriteBufferSize
+	}
+
+	tc := &tieredCache{
+		local:   local,
+		config:  config,
+		writes:  make(chan remoteWrite, config.WriteBufferSize),
+		closed:  make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go tc.runWrites()
+	return tc, nil
+}
+
+type tieredCache struct {
+	local  Cache
+	config RemoteConfig
+
+	writes  chan remoteWrite
+	pending sync.WaitGroup
+
+	lock     sync.RWMutex
+	isClosed bool
+	closed   chan struct{}
+	stopped  chan struct{}
+}
+
+type remoteWrite struct {
+	key   string
+	value []byte
+}
+
+var _ Cache = (*tieredCache)(nil)
+
+func (tc *tieredCache) Get(key any) (any, bool) {
+	if value, found := tc.local.Get(key); found {
+		return value, true
+	}
+
+	dispatchCacheKey, ok := key.(keys.DispatchCacheKey)
+	if !ok {
+		return nil, false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), tc.config.Timeout)
+	defer cancel()
+
+	encoded, found, err := tc.config.Store.Get(ctx, tc.remoteKey(dispatchCacheKey))
+	if err != nil {
+		remoteErrorsCounter.WithLabelValues("get").Inc()
+		log.Debug().Err(err).Msg("failed to read from remote cache")
+		return nil, false
+	}
+	if !found {
+		remoteMissesCounter.Inc()
+		return nil, false
+	}
+
+	checkSum, value, cost, err := decodeRemoteEntry(encoded)
+	if err != nil {
+		remoteErrorsCounter.WithLabelValues("decode").Inc()
+		log.Debug().Err(err).Msg("failed to decode remote cache entry")
+		return nil, false
+	}
+
+	// The entry was stored for another key with the same stable sum.
+	if !bytes.Equal(checkSum, dispatchCacheKey.StableCheckSumAsBytes()) {
+		remoteMissesCounter.Inc()
+		return nil, false
+	}
+
+	remoteHitsCounter.Inc()
+	tc.local.Set(key, value, cost)
+	return value, true
+}
+
+func (tc *tieredCache) Set(key, entry any, cost int64) bool {
+	added := tc.local.Set(key, entry, cost)
+
+	dispatchCacheKey, ok := key.(keys.DispatchCacheKey)
+	if !ok {
+		return added
+	}
+
+	encoded, ok := encodeRemoteEntry(dispatchCacheKey.StableCheckSumAsBytes(), entry)
+	if !ok || len(encoded) > tc.config.MaxEntrySize {
+		return added
+	}
+
+	tc.lock.RLock()
+	defer tc.lock.RUnlock()
+	if tc.isClosed {
+		return added
+	}
+
+	tc.pending.Add(1)
+	select {
+	case tc.writes <- remoteWrite{tc.remoteKey(dispatchCacheKey), encoded}:
+	default:
+		tc.pending.Done()
+		remoteDroppedWritesCounter.Inc()
+	}
+	return added
+}
+
+func (tc *tieredCache) runWrites() {
+	defer close(tc.stopped)
+	for {
+		select {
+		case write := <-tc.writes:
+			tc.write(write)
+
+		case <-tc.closed:
+			// Drop any writes still pending.
+			for {
+				select {
+				case <-tc.writes:
+					tc.pending.Done()
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (tc *tieredCache) write(write remoteWrite) {
+	defer tc.pending.Done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), tc.config.Timeout)
+	defer cancel()
+
+	if err := tc.config.Store.Set(ctx, write.key, write.value, tc.config.TTL); err != nil {
+		remoteErrorsCounter.WithLabelValues("set").Inc()
+		log.Debug().Err(err).Msg("failed to write to remote cache")
+	}
+}
+
+// remoteKey returns the key in the remote store for the given key.
+func (tc *tieredCache) remoteKey(key keys.DispatchCacheKey) string {
+	return tc.config.KeyPrefix + hex.EncodeToString(key.StableSumAsBytes())
+}
+
+// Wait waits for the local cache to apply updates and for pending remote writes to complete.
+func (tc *tieredCache) Wait() {
+	tc.local.Wait()
+	tc.pending.Wait()
+}
+
+func (tc *tieredCache) Close() {
+	tc.lock.Lock()
+	if tc.isClosed {
+		tc.lock.Unlock()
+		return
+	}
+	tc.isClosed = true
+	close(tc.closed)
+	tc.lock.Unlock()
+
+	<-tc.stopped
+	tc.local.Close()
+}
+
+func (tc *tieredCache) GetMetrics() Metrics { return tc.local.GetMetrics() }
+
+func (tc *tieredCache) MarshalZerologObject(e *zerolog.Event) {
+	e.EmbedObject(tc.local).Object("remote", &tc.config)
+}
+
+// encodeRemoteEntry encodes a cache entry for storing remotely, along with the check sum of its
+// key, returning false if the entry cannot be stored remotely.
+func encodeRemoteEntry(checkSum []byte, entry any) ([]byte, bool) {
+	header := func(kind byte, size int) []byte {
+		encoded := make([]byte, 0, 2+binary.MaxVarintLen64+len(checkSum)+size)
+		encoded = append(encoded, remoteEntryVersion, kind)
+		encoded = binary.AppendUvarint(encoded, uint64(len(checkSum)))
+		return append(encoded, checkSum...)
+	}
+
+	switch value := entry.(type) {
+	case []byte:
+		encoded := header(remoteEntryBytes, len(value))
+		return append(encoded, value...), true
+
+	case [][]byte:
+		size := binary.MaxVarintLen64
+		for _, slice := range value {
+			size += binary.MaxVarintLen64 + len(slice)
+		}
+
+		encoded := header(remoteEntryBytesSlice, size)
+		encoded = binary.AppendUvarint(encoded, uint64(len(value)))
+		for _, slice := range value {
+			encoded = binary.AppendUvarint(encoded, uint64(len(slice)))
+			encoded = append(encoded, slice...)
+		}
+		return encoded, true
+
+	default:
+		return nil, false
+	}
+}
+
+// decodeRemoteEntry decodes an entry stored remotely, returning the check sum of its key and the
+// entry along with its cost.
+func decodeRemoteEntry(encoded []byte) ([]byte, any, int64, error) {
+	if len(encoded) < 2 {
+		return nil, nil, 0, errors.New("remote cache entry is truncated")
+	}
+	if encoded[0] != remoteEntryVersion {
+		return nil, nil, 0, fmt.Errorf("unsupported remote cache entry version %d", encoded[0])
+	}
+
+	data := encoded[2:]
+	checkSumLength, n := binary.Uvarint(data)
+	if n <= 0 || checkSumLength > uint64(len(data)-n) {
+		return nil, nil, 0, errors.New("remote cache entry has an invalid check sum")
+	}
+	checkSum := data[n : n+int(checkSumLength)]
+	data = data[n+int(checkSumLength):]
+
+	switch encoded[1] {
+	case remoteEntryBytes:
+		value := bytes.Clone(data)
+		return checkSum, value, sliceCost(value), nil
+
+	case remoteEntryBytesSlice:
+		count, n := binary.Uvarint(data)
+		if n <= 0 || count > uint64(len(data)) {
+			return nil, nil, 0, errors.New("remote cache entry has an invalid slice count")
+		}
+		data = data[n:]
+
+		value := make([][]byte, 0, count)
+		var cost int64
+		for i := uint64(0); i < count; i++ {
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return nil, nil, 0, errors.New("remote cache entry has an invalid slice length")
+			}
+
+			slice := bytes.Clone(data[n : n+int(length)])
+			value = append(value, slice)
+			cost += sliceCost(slice)
+			data = data[n+int(length):]
+		}
+
+		if len(data) > 0 {
+			return nil, nil, 0, errors.New("remote cache entry has trailing data")
+		}
+		return checkSum, value, cost, nil
+
+	default:
+		return nil, nil, 0, fmt.Errorf("unsupported remote cache entry kind %d", encoded[1])
+	}
+}
+
+func sliceCost(xs []byte) int64 {
+	// Slice Header + Slice Contents
+	return int64(int(unsafe.Sizeof(xs)) + len(xs))
+}
+
+// NewInProcessRemoteStore returns a RemoteStore holding its entries in memory, which can stand
+// in for an external key/value service in tests and single-process deployments.
+func NewInProcessRemoteStore() *InProcessRemoteStore {
+	return &InProcessRemoteStore{entries: map[string]inProcessEntry{}}
+}
+
+// InProcessRemoteStore is a RemoteStore holding its entries in memory.
+type InProcessRemoteStore struct {
+	lock    sync.RWMutex
+	entries map[string]inProcessEntry
+}
+
+type inProcessEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+var _ RemoteStore = (*InProcessRemoteStore)(nil)
+
+func (s *InProcessRemoteStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	entry, ok := s.entries[key]
+	if !ok || (!entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt)) {
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+func (s *InProcessRemoteStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entry := inProcessEntry{value: bytes.Clone(value)}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	s.entries[key] = entry
+	return nil
+}
+
+// Len returns the number of entries in the store, including any which have expired.
+func (s *InProcessRemoteStore) Len() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.entries)
+}
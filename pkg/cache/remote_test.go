@@ -0,0 +1,201 @@
+//go:build !wasm
+// +build !wasm
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/dispatch/keys"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+func TestRemoteEntryEncoding(t *testing.T) {
+	tcs := []struct {
+		name  string
+		entry any
+		cost  int64
+	}{
+		{"empty bytes", []byte{}, 24},
+		{"bytes", []byte("hello world"), 35},
+		{"no slices", [][]byte{}, 0},
+		{"slices", [][]byte{[]byte("hello"), {}, []byte("world")}, 82},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			encoded, ok := encodeRemoteEntry([]byte("checksum"), tc.entry)
+			require.True(t, ok)
+
+			checkSum, decoded, cost, err := decodeRemoteEntry(encoded)
+			require.NoError(t, err)
+			require.Equal(t, []byte("checksum"), checkSum)
+			require.Equal(t, tc.entry, decoded)
+			require.Equal(t, tc.cost, cost)
+
+			// Truncated slices are rejected.
+			if _, isSlices := tc.entry.([][]byte); isSlices && len(encoded) > 3 {
+				_, _, _, err = decodeRemoteEntry(encoded[:len(encoded)-1])
+				require.Error(t, err)
+			}
+		})
+	}
+
+	_, ok := encodeRemoteEntry(nil, "not bytes")
+	require.False(t, ok)
+
+	_, _, _, err := decodeRemoteEntry([]byte{remoteEntryVersion + 1, remoteEntryBytes})
+	require.ErrorContains(t, err, "unsupported remote cache entry version")
+
+	_, _, _, err = decodeRemoteEntry([]byte{remoteEntryVersion, remoteEntryBytes, 8, 'a'})
+	require.ErrorContains(t, err, "invalid check sum")
+}
+
+func TestNewTieredCacheConfig(t *testing.T) {
+	_, err := NewTieredCache(newLocalCache(t), RemoteConfig{})
+	require.ErrorContains(t, err, "requires a store")
+
+	tiered, err := NewTieredCache(newLocalCache(t), RemoteConfig{Store: NewInProcessRemoteStore()})
+	require.NoError(t, err)
+	defer tiered.Close()
+	require.Equal(t, defaultRemoteTTL, tiered.(*tieredCache).config.TTL)
+}
+
+func TestTieredCache(t *testing.T) {
+	require := require.New(t)
+	store := NewInProcessRemoteStore()
+
+	// Two caches sharing a store, as for two nodes of a cluster.
+	first := newTieredCache(t, RemoteConfig{Store: store, MaxEntrySize: 64})
+	defer first.Close()
+	second := newTieredCache(t, RemoteConfig{Store: store, MaxEntrySize: 64})
+	defer second.Close()
+
+	key := dispatchCacheKey(t, "first")
+	_, found := second.Get(key)
+	require.False(found)
+
+	first.Set(key, [][]byte{[]byte("some"), []byte("results")}, 1)
+	first.Wait()
+	require.Equal(1, store.Len())
+
+	// Found remotely, and then locally.
+	value, found := second.Get(key)
+	require.True(found)
+	require.Equal([][]byte{[]byte("some"), []byte("results")}, value)
+
+	second.Wait()
+	value, found = second.(*tieredCache).local.Get(key)
+	require.True(found)
+	require.Equal([][]byte{[]byte("some"), []byte("results")}, value)
+
+	// Entries larger than the maximum size are only cached locally.
+	largeKey := dispatchCacheKey(t, "large")
+	first.Set(largeKey, make([]byte, 100), 1)
+	first.Wait()
+	require.Equal(1, store.Len())
+
+	_, found = first.Get(largeKey)
+	require.True(found)
+	_, found = second.Get(largeKey)
+	require.False(found)
+
+	// Entries not keyed by dispatch cache keys are only cached locally.
+	first.Set("somekey", []byte("value"), 1)
+	first.Wait()
+	require.Equal(1, store.Len())
+
+	// Entries stored for another key with the same stable sum are not returned.
+	collidingKey := dispatchCacheKey(t, "colliding")
+	encoded, ok := encodeRemoteEntry(key.StableCheckSumAsBytes(), []byte("value"))
+	require.True(ok)
+	require.NoError(store.Set(context.Background(), first.(*tieredCache).remoteKey(collidingKey), encoded, 0))
+
+	_, found = second.Get(collidingKey)
+	require.False(found)
+
+	// Entries set after closing are not written remotely.
+	first.Close()
+	first.Set(dispatchCacheKey(t, "closed"), []byte("value"), 1)
+	require.Equal(2, store.Len())
+}
+
+func TestTieredCacheRemoteErrors(t *testing.T) {
+	require := require.New(t)
+
+	tiered := newTieredCache(t, RemoteConfig{Store: failingStore{}})
+	defer tiered.Close()
+
+	key := dispatchCacheKey(t, "first")
+	tiered.Set(key, []byte("value"), 1)
+	tiered.Wait()
+
+	value, found := tiered.Get(key)
+	require.True(found)
+	require.Equal([]byte("value"), value)
+
+	_, found = tiered.Get(dispatchCacheKey(t, "second"))
+	require.False(found)
+}
+
+func TestInProcessRemoteStoreTTL(t *testing.T) {
+	require := require.New(t)
+	store := NewInProcessRemoteStore()
+
+	require.NoError(store.Set(context.Background(), "expiring", []byte("value"), time.Millisecond))
+	require.NoError(store.Set(context.Background(), "permanent", []byte("value"), 0))
+	time.Sleep(5 * time.Millisecond)
+
+	_, found, err := store.Get(context.Background(), "expiring")
+	require.NoError(err)
+	require.False(found)
+
+	value, found, err := store.Get(context.Background(), "permanent")
+	require.NoError(err)
+	require.True(found)
+	require.Equal([]byte("value"), value)
+}
+
+type failingStore struct{}
+
+func (failingStore) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, errors.New("unavailable")
+}
+
+func (failingStore) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("unavailable")
+}
+
+func newLocalCache(t *testing.T) Cache {
+	local, err := NewCache(&Config{
+		NumCounters: 1000,
+		MaxCost:     1 * humanize.MiByte,
+	})
+	require.NoError(t, err)
+	return local
+}
+
+func newTieredCache(t *testing.T, config RemoteConfig) Cache {
+	tiered, err := NewTieredCache(newLocalCache(t), config)
+	require.NoError(t, err)
+	return tiered
+}
+
+func dispatchCacheKey(t *testing.T, resourceID string) keys.DispatchCacheKey {
+	key, err := (&keys.DirectKeyHandler{}).CheckCacheKey(context.Background(), &v1.DispatchCheckRequest{
+		ResourceRelation: &core.RelationReference{Namespace: "document", Relation: "view"},
+		ResourceIds:      []string{resourceID},
+		Subject:          &core.ObjectAndRelation{Namespace: "user", ObjectId: "tom", Relation: "..."},
+		Metadata:         &v1.ResolverMeta{AtRevision: "1234"},
+	})
+	require.NoError(t, err)
+	return key
+}
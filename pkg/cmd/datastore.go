@@ -2,17 +2,29 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/authzed/spicedb/internal/backup"
 	"github.com/authzed/spicedb/internal/datastore/common"
 	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/internal/replication"
+	"github.com/authzed/spicedb/internal/services/shared"
 	"github.com/authzed/spicedb/pkg/cmd/datastore"
 	"github.com/authzed/spicedb/pkg/cmd/server"
 	"github.com/authzed/spicedb/pkg/cmd/termination"
 	dspkg "github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
 )
 
 func RegisterDatastoreRootFlags(_ *cobra.Command) {
@@ -43,9 +55,91 @@ func NewDatastoreCommand(programName string) (*cobra.Command, error) {
 	}
 	datastoreCmd.AddCommand(repairCmd)
 
+	backupCfg := backupConfig{}
+
+	backupCmd := NewBackupDatastoreCommand(programName, &cfg, &backupCfg)
+	if err := datastore.RegisterDatastoreFlagsWithPrefix(backupCmd.Flags(), "", &cfg); err != nil {
+		return nil, err
+	}
+	registerBackupFlags(backupCmd, &backupCfg)
+	datastoreCmd.AddCommand(backupCmd)
+
+	restoreCmd := NewRestoreDatastoreCommand(programName, &cfg, &backupCfg)
+	if err := datastore.RegisterDatastoreFlagsWithPrefix(restoreCmd.Flags(), "", &cfg); err != nil {
+		return nil, err
+	}
+	registerBackupFlags(restoreCmd, &backupCfg)
+	datastoreCmd.AddCommand(restoreCmd)
+
+	planSchemaCfg := planSchemaConfig{}
+
+	planSchemaCmd := NewPlanSchemaDatastoreCommand(programName, &cfg, &planSchemaCfg)
+	if err := datastore.RegisterDatastoreFlagsWithPrefix(planSchemaCmd.Flags(), "", &cfg); err != nil {
+		return nil, err
+	}
+	planSchemaCmd.Flags().BoolVar(&planSchemaCfg.json, "json", false, "output the plan as JSON")
+	datastoreCmd.AddCommand(planSchemaCmd)
+
+	destinationCfg := datastore.Config{}
+	replicateCfg := replicateConfig{}
+
+	replicateCmd := NewReplicateDatastoreCommand(programName, &cfg, &destinationCfg, &replicateCfg)
+	if err := datastore.RegisterDatastoreFlagsWithPrefix(replicateCmd.Flags(), "", &cfg); err != nil {
+		return nil, err
+	}
+	if err := datastore.RegisterDatastoreFlagsWithPrefix(replicateCmd.Flags(), "destination", &destinationCfg); err != nil {
+		return nil, err
+	}
+	registerReplicateFlags(replicateCmd, &replicateCfg)
+	datastoreCmd.AddCommand(replicateCmd)
+
 	return datastoreCmd, nil
 }
 
+type planSchemaConfig struct {
+	json bool
+}
+
+type replicateConfig struct {
+	batchSize         uint64
+	statePath         string
+	lagReportInterval time.Duration
+}
+
+func registerReplicateFlags(cmd *cobra.Command, cfg *replicateConfig) {
+	cmd.Flags().Uint64Var(&cfg.batchSize, "batch-size", replication.DefaultBatchSize, "number of relationships copied at a time during the initial copy")
+	cmd.Flags().StringVar(&cfg.statePath, "state-path", "", "file in which the last replicated revision and the progress of the initial copy are recorded, so that replication can be resumed after a restart")
+	cmd.Flags().DurationVar(&cfg.lagReportInterval, "lag-report-interval", 10*time.Second, "interval at which the replication lag is reported")
+}
+
+type backupConfig struct {
+	batchSize uint64
+	resume    bool
+}
+
+func registerBackupFlags(cmd *cobra.Command, cfg *backupConfig) {
+	cmd.Flags().Uint64Var(&cfg.batchSize, "batch-size", backup.DefaultBatchSize, "number of relationships read or written at a time")
+	cmd.Flags().BoolVar(&cfg.resume, "resume", false, "resume a previously interrupted operation instead of starting over")
+}
+
+// progressLogger returns a progress function which logs the number of relationships processed
+// at most once per second.
+func progressLogger(ctx context.Context, operation string) func(uint64) {
+	start := time.Now()
+	var lastLogged time.Time
+	return func(processed uint64) {
+		if time.Since(lastLogged) < time.Second {
+			return
+		}
+		lastLogged = time.Now()
+
+		log.Ctx(ctx).Info().
+			Uint64("relationships", processed).
+			Float64("relationships_per_second", float64(processed)/time.Since(start).Seconds()).
+			Msg(operation + " in progress")
+	}
+}
+
 func NewGCDatastoreCommand(programName string, cfg *datastore.Config) *cobra.Command {
 	return &cobra.Command{
 		Use:     "gc",
@@ -128,3 +222,235 @@ func NewRepairDatastoreCommand(programName string, cfg *datastore.Config) *cobra
 		}),
 	}
 }
+
+func NewBackupDatastoreCommand(programName string, cfg *datastore.Config, backupCfg *backupConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:     "backup <path>",
+		Short:   "writes a backup of the datastore",
+		Long:    "Writes the schema, its history and all relationships of the datastore at its current revision to a compressed backup file",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: server.DefaultPreRunE(programName),
+		RunE: termination.PublishError(func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			// Disable background GC and hedging.
+			cfg.GCInterval = -1 * time.Hour
+			cfg.RequestHedgingEnabled = false
+
+			ds, err := datastore.NewDatastore(ctx, cfg.ToOption())
+			if err != nil {
+				return fmt.Errorf("failed to create datastore: %w", err)
+			}
+			defer ds.Close()
+
+			log.Ctx(ctx).Info().Str("path", args[0]).Msg("Running backup...")
+			header, count, err := backup.Backup(ctx, ds, args[0], backup.Options{
+				BatchSize: backupCfg.batchSize,
+				Resume:    backupCfg.resume,
+				Progress:  progressLogger(ctx, "backup"),
+			})
+			if err != nil {
+				return err
+			}
+
+			log.Ctx(ctx).Info().
+				Str("revision", header.Revision).
+				Uint64("relationships", count).
+				Msg("Backup completed")
+			return nil
+		}),
+	}
+}
+
+func NewRestoreDatastoreCommand(programName string, cfg *datastore.Config, backupCfg *backupConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:     "restore <path>",
+		Short:   "restores a backup into the datastore",
+		Long:    "Writes the schema, its history and all relationships found in a backup file into an empty datastore",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: server.DefaultPreRunE(programName),
+		RunE: termination.PublishError(func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			// Disable background GC and hedging.
+			cfg.GCInterval = -1 * time.Hour
+			cfg.RequestHedgingEnabled = false
+
+			ds, err := datastore.NewDatastore(ctx, cfg.ToOption())
+			if err != nil {
+				return fmt.Errorf("failed to create datastore: %w", err)
+			}
+			defer ds.Close()
+
+			log.Ctx(ctx).Info().Str("path", args[0]).Msg("Running restore...")
+			header, count, err := backup.Restore(ctx, ds, args[0], backup.Options{
+				BatchSize: backupCfg.batchSize,
+				Resume:    backupCfg.resume,
+				Progress:  progressLogger(ctx, "restore"),
+			})
+			if err != nil {
+				return err
+			}
+
+			log.Ctx(ctx).Info().
+				Str("backup_revision", header.Revision).
+				Time("backup_created_at", header.CreatedAt).
+				Uint64("relationships", count).
+				Msg("Restore completed")
+			return nil
+		}),
+	}
+}
+
+func NewReplicateDatastoreCommand(programName string, cfg, destinationCfg *datastore.Config, replicateCfg *replicateConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "replicate",
+		Short: "continuously replicates the datastore into another datastore",
+		Long: "Copies the schema and all relationships of the datastore into an empty destination datastore, and then " +
+			"continuously applies all subsequent changes to the destination until interrupted, reporting the replication lag. " +
+			"The history of the schema is not replicated: the destination only records versions of the schema written to it directly",
+		PreRunE: server.DefaultPreRunE(programName),
+		RunE: termination.PublishError(func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			// Disable background GC and hedging.
+			for _, c := range []*datastore.Config{cfg, destinationCfg} {
+				c.GCInterval = -1 * time.Hour
+				c.RequestHedgingEnabled = false
+			}
+
+			source, err := datastore.NewDatastore(ctx, cfg.ToOption())
+			if err != nil {
+				return fmt.Errorf("failed to create source datastore: %w", err)
+			}
+			defer source.Close()
+
+			destination, err := datastore.NewDatastore(ctx, destinationCfg.ToOption())
+			if err != nil {
+				return fmt.Errorf("failed to create destination datastore: %w", err)
+			}
+			defer destination.Close()
+
+			log.Ctx(ctx).Info().
+				Str("source_engine", cfg.Engine).
+				Str("destination_engine", destinationCfg.Engine).
+				Msg("Running replication...")
+			err = replication.NewReplicator(source, destination,
+				replication.BatchSize(replicateCfg.batchSize),
+				replication.StatePath(replicateCfg.statePath),
+				replication.LagReportInterval(replicateCfg.lagReportInterval),
+			).Run(ctx)
+			if err != nil {
+				return err
+			}
+
+			log.Ctx(ctx).Info().Msg("Replication stopped")
+			return nil
+		}),
+	}
+}
+
+func NewPlanSchemaDatastoreCommand(programName string, cfg *datastore.Config, planCfg *planSchemaConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "plan-schema <path>",
+		Short: "plans the changes a schema would make to the datastore",
+		Long: "Compares the schema in the given file against the schema of the datastore without writing it, reporting for " +
+			"each change whether it is safe, how many relationships it affects and which permissions change; exits with an error " +
+			"if any change is not safe",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: server.DefaultPreRunE(programName),
+		RunE: termination.PublishError(func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			schema, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read schema: %w", err)
+			}
+
+			compiled, err := compiler.Compile(compiler.InputSchema{
+				Source:       input.Source(args[0]),
+				SchemaString: string(schema),
+			}, compiler.AllowUnprefixedObjectType())
+			if err != nil {
+				return err
+			}
+
+			validated, err := shared.ValidateSchemaChanges(ctx, compiled, false)
+			if err != nil {
+				return err
+			}
+
+			// Disable background GC and hedging.
+			cfg.GCInterval = -1 * time.Hour
+			cfg.RequestHedgingEnabled = false
+
+			ds, err := datastore.NewDatastore(ctx, cfg.ToOption())
+			if err != nil {
+				return fmt.Errorf("failed to create datastore: %w", err)
+			}
+			defer ds.Close()
+
+			headRevision, err := ds.HeadRevision(ctx)
+			if err != nil {
+				return err
+			}
+
+			plan, err := shared.PlanSchemaChanges(ctx, ds.SnapshotReader(headRevision), validated)
+			if err != nil {
+				return err
+			}
+
+			if planCfg.json {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(plan); err != nil {
+					return err
+				}
+			} else {
+				printSchemaPlan(cmd.OutOrStdout(), plan)
+			}
+
+			if !plan.Safe {
+				return errors.New("the schema changes are not safe to apply")
+			}
+			return nil
+		}),
+	}
+}
+
+func printSchemaPlan(out io.Writer, plan *shared.SchemaChangePlan) {
+	if len(plan.Deltas) == 0 {
+		fmt.Fprintln(out, "No changes.")
+		return
+	}
+
+	for _, delta := range plan.Deltas {
+		status := "safe"
+		if !delta.Safe {
+			status = "UNSAFE"
+		}
+
+		subject := delta.DefinitionKind + " " + delta.DefinitionName
+		switch {
+		case delta.RelationName != "":
+			subject += "#" + delta.RelationName
+		case delta.ParameterName != "":
+			subject += "(" + delta.ParameterName + ")"
+		}
+		if delta.AllowedType != "" {
+			subject += " [" + delta.AllowedType + "]"
+		}
+
+		fmt.Fprintf(out, "%-6s %s: %s\n", status, delta.Type, subject)
+		if delta.Reason != "" {
+			fmt.Fprintf(out, "       reason: %s\n", delta.Reason)
+		}
+		if delta.AffectedRelationships > 0 {
+			fmt.Fprintf(out, "       affected relationships: %d\n", delta.AffectedRelationships)
+		}
+		if len(delta.ChangedPermissions) > 0 {
+			fmt.Fprintf(out, "       changed permissions: %s\n", strings.Join(delta.ChangedPermissions, ", "))
+		}
+	}
+}
@@ -18,6 +18,26 @@ func TestDefaults(t *testing.T) {
 	require.Equal(t, expected, *received)
 }
 
+func TestPrefixedFlags(t *testing.T) {
+	f := pflag.FlagSet{}
+	unprefixed := Config{}
+	require.NoError(t, RegisterDatastoreFlagsWithPrefix(&f, "", &unprefixed))
+
+	prefixed := Config{}
+	require.NoError(t, RegisterDatastoreFlagsWithPrefix(&f, "destination", &prefixed))
+
+	require.NoError(t, f.Parse([]string{
+		"--datastore-engine=postgres",
+		"--datastore-conn-pool-read-max-open=5",
+		"--destination-datastore-engine=cockroachdb",
+		"--destination-datastore-connpool-read-max-open=7",
+	}))
+	require.Equal(t, "postgres", unprefixed.Engine)
+	require.Equal(t, 5, unprefixed.ReadConnPool.MaxOpenConns)
+	require.Equal(t, "cockroachdb", prefixed.Engine)
+	require.Equal(t, 7, prefixed.ReadConnPool.MaxOpenConns)
+}
+
 func TestLoadDatastoreFromFileContents(t *testing.T) {
 	ctx := context.Background()
 	ds, err := NewDatastore(ctx,
@@ -63,6 +63,8 @@ func (c *Config) ToOption() ConfigOption {
 		to.SpannerMinSessions = c.SpannerMinSessions
 		to.SpannerMaxSessions = c.SpannerMaxSessions
 		to.TablePrefix = c.TablePrefix
+		to.MemorySnapshotPath = c.MemorySnapshotPath
+		to.MemorySnapshotInterval = c.MemorySnapshotInterval
 		to.WatchBufferLength = c.WatchBufferLength
 		to.WatchBufferWriteTimeout = c.WatchBufferWriteTimeout
 		to.MigrationPhase = c.MigrationPhase
@@ -104,6 +106,8 @@ func (c Config) DebugMap() map[string]any {
 	debugMap["SpannerMinSessions"] = helpers.DebugValue(c.SpannerMinSessions, false)
 	debugMap["SpannerMaxSessions"] = helpers.DebugValue(c.SpannerMaxSessions, false)
 	debugMap["TablePrefix"] = helpers.DebugValue(c.TablePrefix, false)
+	debugMap["MemorySnapshotPath"] = helpers.DebugValue(c.MemorySnapshotPath, false)
+	debugMap["MemorySnapshotInterval"] = helpers.DebugValue(c.MemorySnapshotInterval, false)
 	debugMap["WatchBufferLength"] = helpers.DebugValue(c.WatchBufferLength, false)
 	debugMap["WatchBufferWriteTimeout"] = helpers.DebugValue(c.WatchBufferWriteTimeout, false)
 	debugMap["MigrationPhase"] = helpers.DebugValue(c.MigrationPhase, false)
@@ -364,6 +368,20 @@ func WithTablePrefix(tablePrefix string) ConfigOption {
 	}
 }
 
+// WithMemorySnapshotPath returns an option that can set MemorySnapshotPath on a Config
+func WithMemorySnapshotPath(memorySnapshotPath string) ConfigOption {
+	return func(c *Config) {
+		c.MemorySnapshotPath = memorySnapshotPath
+	}
+}
+
+// WithMemorySnapshotInterval returns an option that can set MemorySnapshotInterval on a Config
+func WithMemorySnapshotInterval(memorySnapshotInterval time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.MemorySnapshotInterval = memorySnapshotInterval
+	}
+}
+
 // WithWatchBufferLength returns an option that can set WatchBufferLength on a Config
 func WithWatchBufferLength(watchBufferLength uint16) ConfigOption {
 	return func(c *Config) {
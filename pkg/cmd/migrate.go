@@ -13,6 +13,7 @@ import (
 	mysqlmigrations "github.com/authzed/spicedb/internal/datastore/mysql/migrations"
 	"github.com/authzed/spicedb/internal/datastore/postgres/migrations"
 	spannermigrations "github.com/authzed/spicedb/internal/datastore/spanner/migrations"
+	sqlitemigrations "github.com/authzed/spicedb/internal/datastore/sqlite/migrations"
 	log "github.com/authzed/spicedb/internal/logging"
 	"github.com/authzed/spicedb/pkg/cmd/server"
 	"github.com/authzed/spicedb/pkg/cmd/termination"
@@ -93,6 +94,14 @@ func migrateRun(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("unable to create migration driver for %s: %w", datastoreEngine, err)
 		}
 		return runMigration(cmd.Context(), migrationDriver, mysqlmigrations.Manager, args[0], timeout, migrationBatachSize)
+	} else if datastoreEngine == "sqlite" {
+		log.Ctx(cmd.Context()).Info().Msg("migrating sqlite datastore")
+
+		migrationDriver, err := sqlitemigrations.NewSQLiteDriverFromURI(dbURL)
+		if err != nil {
+			return fmt.Errorf("unable to create migration driver for %s: %w", datastoreEngine, err)
+		}
+		return runMigration(cmd.Context(), migrationDriver, sqlitemigrations.Manager, args[0], timeout, migrationBatachSize)
 	}
 
 	return fmt.Errorf("cannot migrate datastore engine type: %s", datastoreEngine)
@@ -152,6 +161,8 @@ func HeadRevision(engine string) (string, error) {
 		return mysqlmigrations.Manager.HeadRevision()
 	case "spanner":
 		return spannermigrations.SpannerMigrations.HeadRevision()
+	case "sqlite":
+		return sqlitemigrations.Manager.HeadRevision()
 	default:
 		return "", fmt.Errorf("cannot migrate datastore engine type: %s", engine)
 	}
@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/spf13/cobra"
+
+	"github.com/authzed/spicedb/internal/services/shared"
+	"github.com/authzed/spicedb/pkg/cmd/server"
+	"github.com/authzed/spicedb/pkg/cmd/termination"
+	"github.com/authzed/spicedb/pkg/schemadsl/codegen"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/diagram"
+	"github.com/authzed/spicedb/pkg/schemadsl/docgen"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+	"github.com/authzed/spicedb/pkg/schemadsl/lint"
+)
+
+func RegisterSchemaRootFlags(_ *cobra.Command) {
+}
+
+func NewSchemaCommand(programName string) *cobra.Command {
+	schemaCmd := &cobra.Command{
+		Use:   "schema",
+		Short: "schema operations",
+		Long:  "Operations on schema files",
+	}
+
+	lintCfg := lintSchemaConfig{}
+
+	lintCmd := NewLintSchemaCommand(programName, &lintCfg)
+	registerLintSchemaFlags(lintCmd, &lintCfg)
+	schemaCmd.AddCommand(lintCmd)
+
+	codegenCfg := codegenSchemaConfig{}
+
+	codegenCmd := NewCodegenSchemaCommand(programName, &codegenCfg)
+	registerCodegenSchemaFlags(codegenCmd, &codegenCfg)
+	schemaCmd.AddCommand(codegenCmd)
+
+	diagramCfg := diagramSchemaConfig{}
+
+	diagramCmd := NewDiagramSchemaCommand(programName, &diagramCfg)
+	registerDiagramSchemaFlags(diagramCmd, &diagramCfg)
+	schemaCmd.AddCommand(diagramCmd)
+
+	docsCfg := docsSchemaConfig{}
+
+	docsCmd := NewDocsSchemaCommand(programName, &docsCfg)
+	registerDocsSchemaFlags(docsCmd, &docsCfg)
+	schemaCmd.AddCommand(docsCmd)
+
+	return schemaCmd
+}
+
+// compileSchemaFile reads, compiles and validates the schema in the file at the given path. The
+// imports of the schema are resolved relative to the directory of the file, which they cannot
+// escape.
+func compileSchemaFile(path string) (*compiler.CompiledSchema, error) {
+	schema, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	compiled, err := compiler.Compile(compiler.InputSchema{
+		Source:       input.Source(filepath.Base(path)),
+		SchemaString: string(schema),
+	}, compiler.AllowUnprefixedObjectType(), compiler.SourceFS(os.DirFS(filepath.Dir(path))))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := shared.ValidateSchemaChanges(context.Background(), compiled, false); err != nil {
+		return nil, err
+	}
+	return compiled, nil
+}
+
+type lintSchemaConfig struct {
+	json                  bool
+	disabledRules         []string
+	definitionNamePattern string
+	caveatNamePattern     string
+	relationNamePattern   string
+	permissionNamePattern string
+}
+
+func registerLintSchemaFlags(cmd *cobra.Command, cfg *lintSchemaConfig) {
+	defaultPattern := lint.DefaultNamingPattern.String()
+
+	cmd.Flags().BoolVar(&cfg.json, "json", false, "output the warnings as JSON")
+	cmd.Flags().StringSliceVar(&cfg.disabledRules, "disable-rule", nil, "names of the lint rules to disable")
+	cmd.Flags().StringVar(&cfg.definitionNamePattern, "definition-name-pattern", defaultPattern, "pattern the names of object definitions must match, without their prefix; empty to not check")
+	cmd.Flags().StringVar(&cfg.caveatNamePattern, "caveat-name-pattern", defaultPattern, "pattern the names of caveats must match, without their prefix; empty to not check")
+	cmd.Flags().StringVar(&cfg.relationNamePattern, "relation-name-pattern", defaultPattern, "pattern the names of relations must match; empty to not check")
+	cmd.Flags().StringVar(&cfg.permissionNamePattern, "permission-name-pattern", defaultPattern, "pattern the names of permissions must match; empty to not check")
+}
+
+func NewLintSchemaCommand(programName string, cfg *lintSchemaConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "lint <path>",
+		Short: "lints a schema",
+		Long: "Validates the schema in the given file and reports warnings for likely mistakes, such as unused relations, " +
+			"unreachable permissions and redundant union branches; exits with an error if any warning is found",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: server.DefaultPreRunE(programName),
+		RunE: termination.PublishError(func(cmd *cobra.Command, args []string) error {
+			rules, err := cfg.rules()
+			if err != nil {
+				return err
+			}
+
+			compiled, err := compileSchemaFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			warnings := lint.Lint(compiled, rules)
+			if cfg.json {
+				if warnings == nil {
+					warnings = []lint.Warning{}
+				}
+
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(warnings); err != nil {
+					return err
+				}
+			} else {
+				for _, warning := range warnings {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s:%s\n", args[0], warning)
+				}
+			}
+
+			if len(warnings) > 0 {
+				return fmt.Errorf("found %d lint warning(s) in the schema", len(warnings))
+			}
+			return nil
+		}),
+	}
+}
+
+// rules returns the default lint rules with the configured naming conventions, without the
+// disabled rules.
+func (cfg *lintSchemaConfig) rules() ([]lint.Rule, error) {
+	var conventions lint.NamingConventions
+	for _, pattern := range []struct {
+		flag  string
+		value string
+		into  **regexp.Regexp
+	}{
+		{"definition-name-pattern", cfg.definitionNamePattern, &conventions.Definitions},
+		{"caveat-name-pattern", cfg.caveatNamePattern, &conventions.Caveats},
+		{"relation-name-pattern", cfg.relationNamePattern, &conventions.Relations},
+		{"permission-name-pattern", cfg.permissionNamePattern, &conventions.Permissions},
+	} {
+		if pattern.value == "" {
+			continue
+		}
+
+		compiled, err := regexp.Compile(pattern.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s: %w", pattern.flag, err)
+		}
+		*pattern.into = compiled
+	}
+
+	disabled := make(map[string]struct{}, len(cfg.disabledRules))
+	for _, name := range cfg.disabledRules {
+		disabled[name] = struct{}{}
+	}
+
+	var rules []lint.Rule
+	for _, rule := range lint.DefaultRules() {
+		if rule.Name() == lint.NamingConventionRule {
+			rule = lint.NamingConvention(conventions)
+		}
+
+		if _, ok := disabled[rule.Name()]; ok {
+			delete(disabled, rule.Name())
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	for name := range disabled {
+		return nil, fmt.Errorf("unknown lint rule `%s`", name)
+	}
+	return rules, nil
+}
+
+type codegenSchemaConfig struct {
+	language    string
+	packageName string
+	output      string
+}
+
+func registerCodegenSchemaFlags(cmd *cobra.Command, cfg *codegenSchemaConfig) {
+	cmd.Flags().StringVar(&cfg.language, "language", "go", `language of the generated code ("go" or "typescript")`)
+	cmd.Flags().StringVar(&cfg.packageName, "package", "schema", "name of the package of the generated Go code")
+	cmd.Flags().StringVar(&cfg.output, "output", "", "path of the file to write the generated code to; empty for stdout")
+}
+
+func NewCodegenSchemaCommand(programName string, cfg *codegenSchemaConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "codegen <path>",
+		Short: "generates typed client code from a schema",
+		Long: "Validates the schema in the given file and generates code with typed constants for its definitions, relations, " +
+			"permissions and caveats, the subject types allowed on each relation, and types for the context of each caveat",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: server.DefaultPreRunE(programName),
+		RunE: termination.PublishError(func(cmd *cobra.Command, args []string) error {
+			compiled, err := compileSchemaFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var generated string
+			switch cfg.language {
+			case "go":
+				generated, err = codegen.GenerateGo(context.Background(), compiled, cfg.packageName)
+			case "typescript":
+				generated, err = codegen.GenerateTypeScript(context.Background(), compiled)
+			default:
+				return fmt.Errorf("unsupported --language `%s`", cfg.language)
+			}
+			if err != nil {
+				return err
+			}
+
+			return writeSchemaOutput(cmd, cfg.output, generated)
+		}),
+	}
+}
+
+// writeSchemaOutput writes the generated content to the file at the given path, or to the
+// output of the command if the path is empty.
+func writeSchemaOutput(cmd *cobra.Command, path string, generated string) error {
+	if path == "" {
+		_, err := fmt.Fprint(cmd.OutOrStdout(), generated)
+		return err
+	}
+	return os.WriteFile(path, []byte(generated), 0o600)
+}
+
+type diagramSchemaConfig struct {
+	format string
+	output string
+}
+
+func registerDiagramSchemaFlags(cmd *cobra.Command, cfg *diagramSchemaConfig) {
+	cmd.Flags().StringVar(&cfg.format, "format", string(diagram.DOT), `format of the diagram ("dot" or "mermaid")`)
+	cmd.Flags().StringVar(&cfg.output, "output", "", "path of the file to write the diagram to; empty for stdout")
+}
+
+func NewDiagramSchemaCommand(programName string, cfg *diagramSchemaConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "diagram <path>",
+		Short: "generates a diagram of a schema",
+		Long: "Validates the schema in the given file and generates a Graphviz DOT or Mermaid diagram of its definitions, " +
+			"relations and permissions, the subject types allowed on each relation and the arrows between definitions",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: server.DefaultPreRunE(programName),
+		RunE: termination.PublishError(func(cmd *cobra.Command, args []string) error {
+			compiled, err := compileSchemaFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			generated, err := diagram.Generate(context.Background(), compiled, diagram.Format(cfg.format))
+			if err != nil {
+				return err
+			}
+
+			return writeSchemaOutput(cmd, cfg.output, generated)
+		}),
+	}
+}
+
+type docsSchemaConfig struct {
+	format    string
+	outputDir string
+}
+
+func registerDocsSchemaFlags(cmd *cobra.Command, cfg *docsSchemaConfig) {
+	cmd.Flags().StringVar(&cfg.format, "format", string(docgen.Markdown), `format of the documentation ("markdown" or "html")`)
+	cmd.Flags().StringVar(&cfg.outputDir, "output-dir", "docs", "path of the directory to write the pages of the documentation to")
+}
+
+func NewDocsSchemaCommand(programName string, cfg *docsSchemaConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "docs <path>",
+		Short: "generates reference documentation for a schema",
+		Long: "Validates the schema in the given file and generates Markdown or HTML reference documentation from its doc comments, " +
+			"with a page for each definition and caveat linked to each other",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: server.DefaultPreRunE(programName),
+		RunE: termination.PublishError(func(cmd *cobra.Command, args []string) error {
+			compiled, err := compileSchemaFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			pages, err := docgen.Generate(context.Background(), compiled, docgen.Format(cfg.format))
+			if err != nil {
+				return err
+			}
+
+			for _, page := range pages {
+				pagePath := filepath.Join(cfg.outputDir, filepath.FromSlash(page.Path))
+				if err := os.MkdirAll(filepath.Dir(pagePath), 0o750); err != nil {
+					return fmt.Errorf("failed to create directory for page: %w", err)
+				}
+
+				if err := os.WriteFile(pagePath, []byte(page.Content), 0o600); err != nil {
+					return fmt.Errorf("failed to write page: %w", err)
+				}
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "wrote %d page(s) to %s\n", len(pages), cfg.outputDir)
+			return nil
+		}),
+	}
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintSchemaWithImport(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "common.zed"), []byte(`definition user {}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schema.zed"), []byte(`import "common.zed"
+
+definition document {
+	relation viewer: user
+	permission view = viewer
+}`), 0o600))
+
+	var cfg lintSchemaConfig
+	cmd := NewLintSchemaCommand("spicedb", &cfg)
+	registerLintSchemaFlags(cmd, &cfg)
+	cmd.PreRunE = nil
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--json", filepath.Join(dir, "schema.zed")})
+	require.NoError(t, cmd.Execute())
+	require.JSONEq(t, `[]`, out.String())
+}
+
+func TestLintSchemaImportOutsideDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "schemas"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "common.zed"), []byte(`definition user {}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas", "schema.zed"), []byte(`import "../common.zed"
+
+definition document {
+	relation viewer: user
+	permission view = viewer
+}`), 0o600))
+
+	var cfg lintSchemaConfig
+	cmd := NewLintSchemaCommand("spicedb", &cfg)
+	registerLintSchemaFlags(cmd, &cfg)
+	cmd.PreRunE = nil
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SilenceUsage = true
+	cmd.SetArgs([]string{"--json", filepath.Join(dir, "schemas", "schema.zed")})
+	require.Error(t, cmd.Execute())
+}
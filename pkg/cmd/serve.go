@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/authzed/spicedb/internal/audit"
 	"github.com/authzed/spicedb/internal/telemetry"
 	"github.com/authzed/spicedb/pkg/cmd/datastore"
 	"github.com/authzed/spicedb/pkg/cmd/server"
@@ -54,6 +55,10 @@ func RegisterServeFlags(cmd *cobra.Command, config *server.Config) error {
 	// Flags for the gRPC API server
 	util.RegisterGRPCServerFlags(cmd.Flags(), &config.GRPCServer, "grpc", "gRPC", ":50051", true)
 	cmd.Flags().StringSliceVar(&config.PresharedSecureKey, PresharedKeyFlag, []string{}, "preshared key(s) to require for authenticated requests")
+	cmd.Flags().StringVar(&config.JWTAuth.JWKS, "grpc-jwt-jwks", "", "path or https URL of the JSON Web Key Set used to verify JWT bearer tokens; if set, tokens are accepted in addition to the preshared key(s), with access restricted by their scopes")
+	cmd.Flags().StringVar(&config.JWTAuth.Issuer, "grpc-jwt-issuer", "", "issuer required in the `iss` claim of JWT bearer tokens")
+	cmd.Flags().StringVar(&config.JWTAuth.Audience, "grpc-jwt-audience", "", "audience required in the `aud` claim of JWT bearer tokens")
+	cmd.Flags().StringVar(&config.JWTAuth.SchemaPrefixClaim, "grpc-jwt-schema-prefix-claim", "", "claim of JWT bearer tokens carrying the schema prefix to which callers are bound; if set, callers can only read and write the definitions and relationships under their prefix")
 	cmd.Flags().DurationVar(&config.ShutdownGracePeriod, "grpc-shutdown-grace-period", 0*time.Second, "amount of time after receiving sigint to continue serving")
 	if err := cmd.MarkFlagRequired(PresharedKeyFlag); err != nil {
 		return fmt.Errorf("failed to mark flag as required: %w", err)
@@ -77,6 +82,9 @@ func RegisterServeFlags(cmd *cobra.Command, config *server.Config) error {
 	cmd.Flags().BoolVar(&config.EnableExperimentalWatchableSchemaCache, "enable-experimental-watchable-schema-cache", false, "enables the experimental schema cache which makes use of the Watch API for automatic updates")
 	cmd.Flags().DurationVar(&config.SchemaWatchHeartbeat, "datastore-schema-watch-heartbeat", 1*time.Second, "heartbeat time on the schema watch in the datastore (if supported). 0 means to default to the datastore's minimum.")
 
+	// Flags for materialized permissions
+	cmd.Flags().BoolVar(&config.EnableExperimentalMaterializedPermissions, "enable-experimental-materialized-permissions", false, "enables precomputing the resources of permissions marked as `materialized` in the schema, which are kept up to date via the Watch API")
+
 	// Flags for parsing and validating schemas.
 	cmd.Flags().BoolVar(&config.SchemaPrefixesRequired, "schema-prefixes-required", false, "require prefixes on all object definitions in schemas")
 
@@ -103,6 +111,7 @@ func RegisterServeFlags(cmd *cobra.Command, config *server.Config) error {
 	util.RegisterGRPCServerFlags(cmd.Flags(), &config.DispatchServer, "dispatch-cluster", "dispatch", ":50053", false)
 	server.RegisterCacheFlags(cmd.Flags(), "dispatch-cache", &config.DispatchCacheConfig, dispatchCacheDefaults)
 	server.RegisterCacheFlags(cmd.Flags(), "dispatch-cluster-cache", &config.ClusterDispatchCacheConfig, dispatchClusterCacheDefaults)
+	cmd.Flags().BoolVar(&config.EnableExperimentalDispatchCacheInvalidation, "enable-experimental-dispatch-cache-watch-invalidation", false, "enables reusing cached dispatch results across revisions until the Watch API reports a change to a relation on which they depend")
 
 	// Flags for configuring dispatch requests
 	cmd.Flags().Uint32Var(&config.DispatchMaxDepth, "dispatch-max-depth", 50, "maximum recursion depth for nested calls")
@@ -120,6 +129,14 @@ func RegisterServeFlags(cmd *cobra.Command, config *server.Config) error {
 	cmd.Flags().Uint16Var(&config.DispatchHashringReplicationFactor, "dispatch-hashring-replication-factor", 100, "set the replication factor of the consistent hasher used for the dispatcher")
 	cmd.Flags().Uint8Var(&config.DispatchHashringSpread, "dispatch-hashring-spread", 1, "set the spread of the consistent hasher used for the dispatcher")
 
+	cmd.Flags().StringVar(&config.DispatchPeerDiscovery.Kind, "dispatch-peer-discovery", "", `discovery of the dispatch peers, used instead of the upstream address ("static", "dns-srv" or "gossip")`)
+	cmd.Flags().StringSliceVar(&config.DispatchPeerDiscovery.StaticAddrs, "dispatch-peer-discovery-static-addrs", nil, "dispatch addresses of the peers for static peer discovery")
+	cmd.Flags().StringVar(&config.DispatchPeerDiscovery.SRVName, "dispatch-peer-discovery-srv-name", "", "DNS name whose SRV records list the dispatch peers for dns-srv peer discovery")
+	cmd.Flags().DurationVar(&config.DispatchPeerDiscovery.RefreshInterval, "dispatch-peer-discovery-refresh-interval", 30*time.Second, "interval at which the SRV records are resolved for dns-srv peer discovery")
+	cmd.Flags().StringVar(&config.DispatchPeerDiscovery.GossipBindAddr, "dispatch-peer-discovery-gossip-bind-addr", ":50055", "UDP address on which membership is gossiped for gossip peer discovery")
+	cmd.Flags().StringVar(&config.DispatchPeerDiscovery.GossipAdvertiseAddr, "dispatch-peer-discovery-gossip-advertise-addr", "", "dispatch address of this node advertised to its peers for gossip peer discovery")
+	cmd.Flags().StringSliceVar(&config.DispatchPeerDiscovery.GossipSeeds, "dispatch-peer-discovery-gossip-seeds", nil, "gossip addresses of the peers first contacted to join the cluster for gossip peer discovery")
+
 	cmd.Flags().StringToStringVar(&config.DispatchSecondaryUpstreamAddrs, "experimental-dispatch-secondary-upstream-addrs", nil, "secondary upstream addresses for dispatches, each with a name")
 	cmd.Flags().StringToStringVar(&config.DispatchSecondaryUpstreamExprs, "experimental-dispatch-secondary-upstream-exprs", nil, "map from request type (currently supported: `check`) to its associated CEL expression, which returns the secondary upstream(s) to be used for the request")
 
@@ -138,6 +155,38 @@ func RegisterServeFlags(cmd *cobra.Command, config *server.Config) error {
 		return fmt.Errorf("failed to mark flag as required: %w", err)
 	}
 
+	// Flags for per-tenant rate limiting
+	cmd.Flags().StringVar(&config.RateLimit.TenantHeader, "rate-limit-tenant-header", "", "request header naming the tenant of a request, limited in addition to its caller, identified by the subject of its JWT or by its preshared token")
+	cmd.Flags().Float64Var(&config.RateLimit.RequestsPerSecond, "rate-limit-requests-per-second", 0, "requests per second allowed per tenant for each API method. 0 means unlimited")
+	cmd.Flags().IntVar(&config.RateLimit.RequestBurst, "rate-limit-request-burst", 0, "requests allowed per tenant above the rate for each API method. 0 means the rate per second")
+	util.StringToFloat64Var(cmd.Flags(), &config.RateLimit.MethodRequestsPerSecond, "rate-limit-method-requests-per-second", nil, "map from API method name (e.g. `CheckPermission`) to the requests per second allowed per tenant, overriding --rate-limit-requests-per-second. 0 means unlimited")
+	cmd.Flags().Float64Var(&config.RateLimit.DispatchesPerSecond, "rate-limit-dispatches-per-second", 0, "dispatches per second allowed per tenant across all API methods. 0 means unlimited")
+	cmd.Flags().IntVar(&config.RateLimit.DispatchBurst, "rate-limit-dispatch-burst", 0, "dispatches allowed per tenant above the rate. 0 means the rate per second")
+
+	// Flags for the audit log
+	cmd.Flags().StringVar(&config.Audit.Sink, "audit-log-sink", "", "sink to which writes to relationships and schema are recorded: file, stdout or grpc; if unset, writes are not audited")
+	cmd.Flags().StringVar(&config.Audit.FilePath, "audit-log-file-path", "", "path of the file to which the file audit log sink writes JSON lines, which is rotated once full")
+	cmd.Flags().IntVar(&config.Audit.FileMaxSizeMB, "audit-log-file-max-size-mb", 100, "size in megabytes at which the audit log file is rotated")
+	cmd.Flags().IntVar(&config.Audit.FileMaxBackups, "audit-log-file-max-backups", 0, "number of rotated audit log files kept. 0 means all")
+	cmd.Flags().StringVar(&config.Audit.GRPCEndpoint, "audit-log-grpc-endpoint", "", "address of the collector to which the grpc audit log sink streams")
+	cmd.Flags().StringVar(&config.Audit.GRPCCACertPath, "audit-log-grpc-ca-path", "", "certificate authority used to verify the collector of the grpc audit log sink; if unset, the connection is not secured")
+	cmd.Flags().IntVar(&config.Audit.BufferSize, "audit-log-buffer-size", 1000, "number of audit events buffered while being written to the sink")
+	cmd.Flags().StringVar(&config.Audit.OverflowPolicy, "audit-log-overflow-policy", audit.OverflowBlock, "policy applied to writes while the audit log buffer is full: block them until there is room, reject them, or drop their audit events")
+
+	// Flags for the decision log
+	cmd.Flags().StringVar(&config.DecisionLog.Log.Sink, "decision-log-sink", "", "sink to which decisions of permission checks and lookups are logged: file, stdout or grpc; if unset, decisions are not logged")
+	cmd.Flags().StringVar(&config.DecisionLog.Log.FilePath, "decision-log-file-path", "", "path of the file to which the file decision log sink writes JSON lines, which is rotated once full")
+	cmd.Flags().IntVar(&config.DecisionLog.Log.FileMaxSizeMB, "decision-log-file-max-size-mb", 100, "size in megabytes at which the decision log file is rotated")
+	cmd.Flags().IntVar(&config.DecisionLog.Log.FileMaxBackups, "decision-log-file-max-backups", 0, "number of rotated decision log files kept. 0 means all")
+	cmd.Flags().StringVar(&config.DecisionLog.Log.GRPCEndpoint, "decision-log-grpc-endpoint", "", "address of the collector to which the grpc decision log sink streams")
+	cmd.Flags().StringVar(&config.DecisionLog.Log.GRPCCACertPath, "decision-log-grpc-ca-path", "", "certificate authority used to verify the collector of the grpc decision log sink; if unset, the connection is not secured")
+	cmd.Flags().IntVar(&config.DecisionLog.Log.BufferSize, "decision-log-buffer-size", 1000, "number of decision events buffered while being written to the sink")
+	cmd.Flags().StringVar(&config.DecisionLog.Log.OverflowPolicy, "decision-log-overflow-policy", audit.OverflowDrop, "policy applied to checks and lookups while the decision log buffer is full: block them until there is room, reject them, or drop their decisions")
+	cmd.Flags().Float64Var(&config.DecisionLog.CheckSampleRate, "decision-log-check-sample-rate", 1, "fraction of CheckPermission calls whose decisions are logged, between 0 and 1")
+	cmd.Flags().Float64Var(&config.DecisionLog.BulkCheckSampleRate, "decision-log-bulk-check-sample-rate", 1, "fraction of BulkCheckPermission calls whose decisions are logged, between 0 and 1")
+	cmd.Flags().Float64Var(&config.DecisionLog.LookupResourcesSampleRate, "decision-log-lookup-resources-sample-rate", 1, "fraction of LookupResources calls whose decisions are logged, between 0 and 1")
+	cmd.Flags().StringSliceVar(&config.DecisionLog.RedactedFields, "decision-log-redacted-fields", nil, "fields of decisions whose values are redacted: resource_id, subject_id, caveat_context or caveat_context.<name>")
+
 	// Flags for misc services
 	util.RegisterHTTPServerFlags(cmd.Flags(), &config.MetricsAPI, "metrics", "metrics", ":9090", true)
 
@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/authzed/spicedb/internal/audit"
+	"github.com/authzed/spicedb/internal/middleware/ratelimit"
+	"github.com/authzed/spicedb/internal/middleware/schemaprefix"
+	"github.com/authzed/spicedb/internal/middleware/tokenscope"
 	"github.com/authzed/spicedb/pkg/genutil/mapz"
 	"github.com/authzed/spicedb/pkg/spiceerrors"
 
@@ -218,6 +222,85 @@ func (mc *MiddlewareChain[T]) modify(modifications ...MiddlewareModification[T])
 	return nil
 }
 
+// afterAuthModifications returns the modifications installing the given interceptors under the
+// given name right after authentication.
+func afterAuthModifications(name string, unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) (MiddlewareModification[grpc.UnaryServerInterceptor], MiddlewareModification[grpc.StreamServerInterceptor]) {
+	unaryModification := MiddlewareModification[grpc.UnaryServerInterceptor]{
+		DependencyMiddlewareName: DefaultMiddlewareGRPCAuth,
+		Operation:                OperationAppend,
+		Middlewares: []ReferenceableMiddleware[grpc.UnaryServerInterceptor]{
+			NewUnaryMiddleware().
+				WithName(name).
+				WithInterceptor(unary).
+				EnsureAlreadyExecuted(DefaultMiddlewareGRPCAuth).
+				Done(),
+		},
+	}
+
+	streamModification := MiddlewareModification[grpc.StreamServerInterceptor]{
+		DependencyMiddlewareName: DefaultMiddlewareGRPCAuth,
+		Operation:                OperationAppend,
+		Middlewares: []ReferenceableMiddleware[grpc.StreamServerInterceptor]{
+			NewStreamMiddleware().
+				WithName(name).
+				WithInterceptor(stream).
+				EnsureInterceptorAlreadyExecuted(DefaultMiddlewareGRPCAuth).
+				Done(),
+		},
+	}
+
+	return unaryModification, streamModification
+}
+
+// MiddlewareRateLimit is the name of the middleware limiting the requests of each tenant.
+const MiddlewareRateLimit = "ratelimit"
+
+// RateLimitMiddlewareModifications returns the modifications installing the given limiter right
+// after authentication, so that only authenticated requests count against the limits of a tenant.
+func RateLimitMiddlewareModifications(limiter *ratelimit.Limiter) (MiddlewareModification[grpc.UnaryServerInterceptor], MiddlewareModification[grpc.StreamServerInterceptor]) {
+	return afterAuthModifications(MiddlewareRateLimit, limiter.UnaryServerInterceptor(), limiter.StreamServerInterceptor())
+}
+
+// MiddlewareTokenScope is the name of the middleware enforcing the scopes of tokens.
+const MiddlewareTokenScope = "tokenscope"
+
+// TokenScopeMiddlewareModifications returns the modifications installing the enforcement of the
+// scopes of tokens right after authentication, which makes the scopes available.
+func TokenScopeMiddlewareModifications() (MiddlewareModification[grpc.UnaryServerInterceptor], MiddlewareModification[grpc.StreamServerInterceptor]) {
+	return afterAuthModifications(MiddlewareTokenScope, tokenscope.UnaryServerInterceptor(), tokenscope.StreamServerInterceptor())
+}
+
+// MiddlewareSchemaPrefix is the name of the middleware scoping requests to the schema prefixes
+// of their callers.
+const MiddlewareSchemaPrefix = "schemaprefix"
+
+// SchemaPrefixMiddlewareModifications returns the modifications installing the scoping of requests
+// to the schema prefixes of their callers right after authentication, which binds the callers to
+// their prefixes.
+func SchemaPrefixMiddlewareModifications() (MiddlewareModification[grpc.UnaryServerInterceptor], MiddlewareModification[grpc.StreamServerInterceptor]) {
+	return afterAuthModifications(MiddlewareSchemaPrefix, schemaprefix.UnaryServerInterceptor(), schemaprefix.StreamServerInterceptor())
+}
+
+// MiddlewareAudit is the name of the middleware recording writes to the audit log.
+const MiddlewareAudit = "audit"
+
+// AuditMiddlewareModifications returns the modifications installing the given auditor right
+// after authentication, so that writes are attributed to their authenticated callers.
+func AuditMiddlewareModifications(auditor *audit.Auditor) (MiddlewareModification[grpc.UnaryServerInterceptor], MiddlewareModification[grpc.StreamServerInterceptor]) {
+	return afterAuthModifications(MiddlewareAudit, auditor.UnaryServerInterceptor(), auditor.StreamServerInterceptor())
+}
+
+// MiddlewareDecisionLog is the name of the middleware logging the decisions of permission checks
+// and lookups.
+const MiddlewareDecisionLog = "decisionlog"
+
+// DecisionLogMiddlewareModifications returns the modifications installing the given decision
+// logger right after authentication, so that decisions are attributed to their authenticated
+// callers.
+func DecisionLogMiddlewareModifications(logger *audit.DecisionLogger) (MiddlewareModification[grpc.UnaryServerInterceptor], MiddlewareModification[grpc.StreamServerInterceptor]) {
+	return afterAuthModifications(MiddlewareDecisionLog, logger.UnaryServerInterceptor(), logger.StreamServerInterceptor())
+}
+
 type streamOrderAssertion struct {
 	grpc.ServerStream
 	name            string
@@ -2,9 +2,12 @@ package server
 
 import (
 	"context"
+	"slices"
 	"testing"
 	"time"
 
+	"github.com/authzed/spicedb/internal/audit"
+	"github.com/authzed/spicedb/internal/middleware/ratelimit"
 	"github.com/authzed/spicedb/pkg/cmd/datastore"
 	"github.com/authzed/spicedb/pkg/cmd/util"
 
@@ -296,6 +299,100 @@ func TestAppendMiddleware(t *testing.T) {
 	require.Equal(t, expectedAppend, receivedAppend)
 }
 
+func TestRateLimitMiddlewareModifications(t *testing.T) {
+	limiter, err := ratelimit.NewLimiter(ratelimit.Config{RequestsPerSecond: 1})
+	require.NoError(t, err)
+	unaryMod, streamingMod := RateLimitMiddlewareModifications(limiter)
+
+	unary, err := DefaultUnaryMiddleware(MiddlewareOption{})
+	require.NoError(t, err)
+	require.NoError(t, unary.modify(unaryMod))
+
+	streaming, err := DefaultStreamingMiddleware(MiddlewareOption{})
+	require.NoError(t, err)
+	require.NoError(t, streaming.modify(streamingMod))
+
+	// The rate limit directly follows authentication in both chains.
+	for _, names := range [][]string{middlewareNames(unary.chain), middlewareNames(streaming.chain)} {
+		authIndex := slices.Index(names, DefaultMiddlewareGRPCAuth)
+		require.Equal(t, MiddlewareRateLimit, names[authIndex+1])
+	}
+}
+
+func TestTokenScopeMiddlewareModifications(t *testing.T) {
+	unaryMod, streamingMod := TokenScopeMiddlewareModifications()
+
+	unary, err := DefaultUnaryMiddleware(MiddlewareOption{})
+	require.NoError(t, err)
+	require.NoError(t, unary.modify(unaryMod))
+
+	streaming, err := DefaultStreamingMiddleware(MiddlewareOption{})
+	require.NoError(t, err)
+	require.NoError(t, streaming.modify(streamingMod))
+
+	// Scopes are enforced directly after authentication in both chains.
+	for _, names := range [][]string{middlewareNames(unary.chain), middlewareNames(streaming.chain)} {
+		authIndex := slices.Index(names, DefaultMiddlewareGRPCAuth)
+		require.Equal(t, MiddlewareTokenScope, names[authIndex+1])
+	}
+}
+
+func TestSchemaPrefixMiddlewareModifications(t *testing.T) {
+	unary, err := DefaultUnaryMiddleware(MiddlewareOption{})
+	require.NoError(t, err)
+	streaming, err := DefaultStreamingMiddleware(MiddlewareOption{})
+	require.NoError(t, err)
+
+	for _, modifications := range []func() (MiddlewareModification[grpc.UnaryServerInterceptor], MiddlewareModification[grpc.StreamServerInterceptor]){
+		TokenScopeMiddlewareModifications,
+		SchemaPrefixMiddlewareModifications,
+	} {
+		unaryMod, streamingMod := modifications()
+		require.NoError(t, unary.modify(unaryMod))
+		require.NoError(t, streaming.modify(streamingMod))
+	}
+
+	// Requests are scoped to schema prefixes before the scopes of tokens are enforced.
+	for _, names := range [][]string{middlewareNames(unary.chain), middlewareNames(streaming.chain)} {
+		authIndex := slices.Index(names, DefaultMiddlewareGRPCAuth)
+		require.Equal(t, []string{MiddlewareSchemaPrefix, MiddlewareTokenScope}, names[authIndex+1:authIndex+3])
+	}
+}
+
+func TestAuditMiddlewareModifications(t *testing.T) {
+	auditor, err := audit.NewAuditor(context.Background(), audit.Config{Sink: audit.SinkStdout, BufferSize: 1, OverflowPolicy: audit.OverflowBlock})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, auditor.Close()) })
+
+	limiter, err := ratelimit.NewLimiter(ratelimit.Config{RequestsPerSecond: 1})
+	require.NoError(t, err)
+
+	unary, err := DefaultUnaryMiddleware(MiddlewareOption{})
+	require.NoError(t, err)
+	streaming, err := DefaultStreamingMiddleware(MiddlewareOption{})
+	require.NoError(t, err)
+
+	// Modifications are applied in the order of the server, which prepends them.
+	unaryAudit, streamingAudit := AuditMiddlewareModifications(auditor)
+	unaryRateLimit, streamingRateLimit := RateLimitMiddlewareModifications(limiter)
+	require.NoError(t, unary.modify(unaryAudit, unaryRateLimit))
+	require.NoError(t, streaming.modify(streamingAudit, streamingRateLimit))
+
+	// Writes are audited once rate limited, so that rejected writes do not reserve room.
+	for _, names := range [][]string{middlewareNames(unary.chain), middlewareNames(streaming.chain)} {
+		authIndex := slices.Index(names, DefaultMiddlewareGRPCAuth)
+		require.Equal(t, []string{MiddlewareRateLimit, MiddlewareAudit}, names[authIndex+1:authIndex+3])
+	}
+}
+
+func middlewareNames[T middlewareTypes](chain []ReferenceableMiddleware[T]) []string {
+	names := make([]string, 0, len(chain))
+	for _, mw := range chain {
+		names = append(names, mw.Name)
+	}
+	return names
+}
+
 func TestDeleteMiddleware(t *testing.T) {
 	defaultMiddleware := &MiddlewareChain[grpc.UnaryServerInterceptor]{
 		chain: []ReferenceableMiddleware[grpc.UnaryServerInterceptor]{
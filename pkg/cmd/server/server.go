@@ -26,20 +26,26 @@ import (
 	"google.golang.org/grpc"
 	_ "google.golang.org/grpc/encoding/gzip" // enable gzip compression on all derivative servers
 
+	"github.com/authzed/spicedb/internal/audit"
 	"github.com/authzed/spicedb/internal/auth"
 	"github.com/authzed/spicedb/internal/datastore/proxy"
 	"github.com/authzed/spicedb/internal/datastore/proxy/schemacaching"
 	"github.com/authzed/spicedb/internal/dispatch"
+	"github.com/authzed/spicedb/internal/dispatch/caching"
 	clusterdispatch "github.com/authzed/spicedb/internal/dispatch/cluster"
 	combineddispatch "github.com/authzed/spicedb/internal/dispatch/combined"
+	"github.com/authzed/spicedb/internal/dispatch/discovery"
 	"github.com/authzed/spicedb/internal/dispatch/graph"
 	"github.com/authzed/spicedb/internal/gateway"
 	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/internal/materialized"
+	"github.com/authzed/spicedb/internal/middleware/ratelimit"
 	"github.com/authzed/spicedb/internal/services"
 	dispatchSvc "github.com/authzed/spicedb/internal/services/dispatch"
 	"github.com/authzed/spicedb/internal/services/health"
 	v1svc "github.com/authzed/spicedb/internal/services/v1"
 	"github.com/authzed/spicedb/internal/telemetry"
+	"github.com/authzed/spicedb/pkg/cache"
 	datastorecfg "github.com/authzed/spicedb/pkg/cmd/datastore"
 	"github.com/authzed/spicedb/pkg/cmd/util"
 	"github.com/authzed/spicedb/pkg/datastore"
@@ -56,6 +62,7 @@ type Config struct {
 	GRPCServer             util.GRPCServerConfig `debugmap:"visible"`
 	GRPCAuthFunc           grpc_auth.AuthFunc    `debugmap:"visible"`
 	PresharedSecureKey     []string              `debugmap:"sensitive"`
+	JWTAuth                auth.JWTConfig        `debugmap:"visible"`
 	ShutdownGracePeriod    time.Duration         `debugmap:"visible"`
 	DisableVersionResponse bool                  `debugmap:"visible"`
 
@@ -79,6 +86,9 @@ type Config struct {
 	SchemaWatchHeartbeat                   time.Duration `debugmap:"visible"`
 	NamespaceCacheConfig                   CacheConfig   `debugmap:"visible"`
 
+	// Materialized permissions
+	EnableExperimentalMaterializedPermissions bool `debugmap:"visible"`
+
 	// Schema options
 	SchemaPrefixesRequired bool `debugmap:"visible"`
 
@@ -97,12 +107,18 @@ type Config struct {
 	Dispatcher                        dispatch.Dispatcher     `debugmap:"visible"`
 	DispatchHashringReplicationFactor uint16                  `debugmap:"visible"`
 	DispatchHashringSpread            uint8                   `debugmap:"visible"`
+	DispatchPeerDiscovery             discovery.Config        `debugmap:"visible"`
 
 	DispatchSecondaryUpstreamAddrs map[string]string `debugmap:"visible"`
 	DispatchSecondaryUpstreamExprs map[string]string `debugmap:"visible"`
 
-	DispatchCacheConfig        CacheConfig `debugmap:"visible"`
-	ClusterDispatchCacheConfig CacheConfig `debugmap:"visible"`
+	DispatchCacheConfig                         CacheConfig `debugmap:"visible"`
+	ClusterDispatchCacheConfig                  CacheConfig `debugmap:"visible"`
+	EnableExperimentalDispatchCacheInvalidation bool        `debugmap:"visible"`
+
+	// DispatchRemoteCache, if set, configures a second-level dispatch cache shared between
+	// nodes, consulted after the local dispatch caches.
+	DispatchRemoteCache *cache.RemoteConfig `debugmap:"hidden"`
 
 	// API Behavior
 	DisableV1SchemaAPI       bool          `debugmap:"visible"`
@@ -120,6 +136,15 @@ type Config struct {
 	UnaryMiddlewareModification     []MiddlewareModification[grpc.UnaryServerInterceptor]  `debugmap:"hidden"`
 	StreamingMiddlewareModification []MiddlewareModification[grpc.StreamServerInterceptor] `debugmap:"hidden"`
 
+	// RateLimit configures the limits on the requests and dispatches of each tenant.
+	RateLimit ratelimit.Config `debugmap:"visible"`
+
+	// Audit configures the audit log of writes to relationships and schema.
+	Audit audit.Config `debugmap:"visible"`
+
+	// DecisionLog configures the decision log of permission checks and lookups.
+	DecisionLog audit.DecisionConfig `debugmap:"visible"`
+
 	// Middleware for internal dispatch API
 	DispatchUnaryMiddleware     []grpc.UnaryServerInterceptor  `debugmap:"hidden"`
 	DispatchStreamingMiddleware []grpc.StreamServerInterceptor `debugmap:"hidden"`
@@ -193,6 +218,9 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 		return nil, fmt.Errorf("a preshared key must be provided to authenticate API requests")
 	}
 
+	// The dispatch API is only used between nodes, which authenticate with the preshared
+	// key(s): tokens are only accepted on the v1 API.
+	dispatchAuthFunc := c.GRPCAuthFunc
 	if c.GRPCAuthFunc == nil {
 		log.Ctx(ctx).Trace().Int("preshared-keys-count", len(c.PresharedSecureKey)).Msg("using gRPC auth with preshared key(s)")
 		for index, presharedKey := range c.PresharedSecureKey {
@@ -204,6 +232,31 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 		}
 
 		c.GRPCAuthFunc = auth.MustRequirePresharedKey(c.PresharedSecureKey)
+		dispatchAuthFunc = c.GRPCAuthFunc
+		if c.JWTAuth.JWKS != "" {
+			verifier, err := auth.NewJWTVerifier(ctx, c.JWTAuth)
+			if err != nil {
+				return nil, fmt.Errorf("failed to configure JWT authentication: %w", err)
+			}
+
+			// Preshared keys remain valid on the v1 API, granting unrestricted access.
+			c.GRPCAuthFunc = auth.RequireJWT(verifier, c.PresharedSecureKey)
+			unaryMod, streamingMod := TokenScopeMiddlewareModifications()
+			unaryMods := []MiddlewareModification[grpc.UnaryServerInterceptor]{unaryMod}
+			streamingMods := []MiddlewareModification[grpc.StreamServerInterceptor]{streamingMod}
+
+			// Both modifications directly follow authentication, so the one applied last comes
+			// first: requests are scoped to schema prefixes before their scopes are enforced.
+			if c.JWTAuth.SchemaPrefixClaim != "" {
+				unaryMod, streamingMod := SchemaPrefixMiddlewareModifications()
+				unaryMods = append(unaryMods, unaryMod)
+				streamingMods = append(streamingMods, streamingMod)
+			}
+
+			c.UnaryMiddlewareModification = append(unaryMods, c.UnaryMiddlewareModification...)
+			c.StreamingMiddlewareModification = append(streamingMods, c.StreamingMiddlewareModification...)
+			log.Ctx(ctx).Info().EmbedObject(c.JWTAuth).Msg("configured JWT authentication")
+		}
 	} else {
 		log.Ctx(ctx).Trace().Msg("using preconfigured auth function")
 	}
@@ -242,6 +295,27 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 	specificConcurrencyLimits := c.DispatchConcurrencyLimits
 	concurrencyLimits := specificConcurrencyLimits.WithOverallDefaultLimit(c.GlobalDispatchConcurrencyLimit)
 
+	var materializedManager *materialized.Manager
+	var materializedPermissions graph.MaterializedPermissions
+	if c.EnableExperimentalMaterializedPermissions {
+		materializedManager = materialized.NewManager(ds, concurrencyLimits.LookupSubjects, c.DispatchMaxDepth)
+		materializedPermissions = materializedManager
+		log.Ctx(ctx).Info().Msg("enabled experimental materialized permissions")
+	}
+
+	var cacheInvalidator *caching.WatchInvalidator
+	if c.EnableExperimentalDispatchCacheInvalidation {
+		// Results reused across revisions are only cached locally, so the remote cache would
+		// only hold the results which cannot be reused.
+		if c.DispatchRemoteCache != nil {
+			return nil, fmt.Errorf("the remote dispatch cache cannot be used with watch-driven dispatch cache invalidation")
+		}
+
+		cacheInvalidator = caching.NewWatchInvalidator(ds, c.SchemaWatchHeartbeat)
+		log.Ctx(ctx).Info().Msg("enabled experimental watch-driven dispatch cache invalidation")
+	}
+
+	var peerResolver *discovery.Resolver
 	dispatcher := c.Dispatcher
 	if dispatcher == nil {
 		cc, err := c.DispatchCacheConfig.WithRevisionParameters(
@@ -252,6 +326,12 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to create dispatcher: %w", err)
 		}
+		if c.DispatchRemoteCache != nil {
+			cc, err = cache.NewTieredCache(cc, *c.DispatchRemoteCache)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create dispatcher: %w", err)
+			}
+		}
 		closeables.AddWithoutError(cc.Close)
 		log.Ctx(ctx).Info().EmbedObject(cc).Msg("configured dispatch cache")
 
@@ -260,6 +340,19 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 			dispatchPresharedKey = c.PresharedSecureKey[0]
 		}
 
+		// Gossip is authenticated with the preshared keys unless other keys are configured.
+		if len(c.DispatchPeerDiscovery.GossipKeys) == 0 {
+			c.DispatchPeerDiscovery.GossipKeys = c.PresharedSecureKey
+		}
+		peerDiscovery, err := c.DispatchPeerDiscovery.Complete()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create dispatch peer discovery: %w", err)
+		}
+		if peerDiscovery != nil {
+			peerResolver = discovery.NewResolver(peerDiscovery)
+			log.Ctx(ctx).Info().EmbedObject(&c.DispatchPeerDiscovery).Msg("configured dispatch peer discovery")
+		}
+
 		hashringConfigJSON, err := (&consistent.BalancerConfig{
 			ReplicationFactor: c.DispatchHashringReplicationFactor,
 			Spread:            c.DispatchHashringSpread,
@@ -282,6 +375,9 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 			combineddispatch.PrometheusSubsystem(c.DispatchClientMetricsPrefix),
 			combineddispatch.Cache(cc),
 			combineddispatch.ConcurrencyLimits(concurrencyLimits),
+			combineddispatch.MaterializedPermissions(materializedPermissions),
+			combineddispatch.CacheInvalidator(cacheInvalidator),
+			combineddispatch.PeerResolver(peerResolver),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create dispatcher: %w", err)
@@ -292,11 +388,7 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 	closeables.AddWithError(dispatcher.Close)
 
 	if len(c.DispatchUnaryMiddleware) == 0 && len(c.DispatchStreamingMiddleware) == 0 {
-		if c.GRPCAuthFunc == nil {
-			c.DispatchUnaryMiddleware, c.DispatchStreamingMiddleware = DefaultDispatchMiddleware(log.Logger, auth.MustRequirePresharedKey(c.PresharedSecureKey), ds)
-		} else {
-			c.DispatchUnaryMiddleware, c.DispatchStreamingMiddleware = DefaultDispatchMiddleware(log.Logger, c.GRPCAuthFunc, ds)
-		}
+		c.DispatchUnaryMiddleware, c.DispatchStreamingMiddleware = DefaultDispatchMiddleware(log.Logger, dispatchAuthFunc, ds)
 	}
 
 	var cachingClusterDispatch dispatch.Dispatcher
@@ -309,6 +401,12 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to configure cluster dispatch: %w", err)
 		}
+		if c.DispatchRemoteCache != nil {
+			cdcc, err = cache.NewTieredCache(cdcc, *c.DispatchRemoteCache)
+			if err != nil {
+				return nil, fmt.Errorf("failed to configure cluster dispatch: %w", err)
+			}
+		}
 		log.Ctx(ctx).Info().EmbedObject(cdcc).Msg("configured cluster dispatch cache")
 		closeables.AddWithoutError(cdcc.Close)
 
@@ -319,6 +417,8 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 			clusterdispatch.Cache(cdcc),
 			clusterdispatch.RemoteDispatchTimeout(c.DispatchUpstreamTimeout),
 			clusterdispatch.ConcurrencyLimits(concurrencyLimits),
+			clusterdispatch.MaterializedPermissions(materializedPermissions),
+			clusterdispatch.CacheInvalidator(cacheInvalidator),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to configure cluster dispatch: %w", err)
@@ -383,6 +483,49 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 		)
 	}
 
+	if c.RateLimit.Enabled() {
+		limiter, err := ratelimit.NewLimiter(c.RateLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create rate limiter: %w", err)
+		}
+
+		// The rate limit is installed first, so that other modifications may reference it.
+		unaryMod, streamingMod := RateLimitMiddlewareModifications(limiter)
+		c.UnaryMiddlewareModification = append([]MiddlewareModification[grpc.UnaryServerInterceptor]{unaryMod}, c.UnaryMiddlewareModification...)
+		c.StreamingMiddlewareModification = append([]MiddlewareModification[grpc.StreamServerInterceptor]{streamingMod}, c.StreamingMiddlewareModification...)
+		log.Ctx(ctx).Info().EmbedObject(c.RateLimit).Msg("configured per-tenant rate limits")
+	}
+
+	if c.Audit.Enabled() {
+		auditor, err := audit.NewAuditor(ctx, c.Audit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create audit log: %w", err)
+		}
+		closeables.AddWithError(auditor.Close)
+
+		// The audit log is installed first, so that it follows the other modifications which
+		// directly follow authentication and records writes as they are performed.
+		unaryMod, streamingMod := AuditMiddlewareModifications(auditor)
+		c.UnaryMiddlewareModification = append([]MiddlewareModification[grpc.UnaryServerInterceptor]{unaryMod}, c.UnaryMiddlewareModification...)
+		c.StreamingMiddlewareModification = append([]MiddlewareModification[grpc.StreamServerInterceptor]{streamingMod}, c.StreamingMiddlewareModification...)
+		log.Ctx(ctx).Info().EmbedObject(c.Audit).Msg("configured audit log")
+	}
+
+	if c.DecisionLog.Enabled() {
+		decisionLogger, err := audit.NewDecisionLogger(ctx, c.DecisionLog)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create decision log: %w", err)
+		}
+		closeables.AddWithError(decisionLogger.Close)
+
+		// Like the audit log, the decision log follows the other modifications which directly
+		// follow authentication, and so logs requests as they are performed.
+		unaryMod, streamingMod := DecisionLogMiddlewareModifications(decisionLogger)
+		c.UnaryMiddlewareModification = append([]MiddlewareModification[grpc.UnaryServerInterceptor]{unaryMod}, c.UnaryMiddlewareModification...)
+		c.StreamingMiddlewareModification = append([]MiddlewareModification[grpc.StreamServerInterceptor]{streamingMod}, c.StreamingMiddlewareModification...)
+		log.Ctx(ctx).Info().EmbedObject(c.DecisionLog).Msg("configured decision log")
+	}
+
 	unaryMiddleware, err := c.buildUnaryMiddleware(defaultUnaryMiddlewareChain)
 	if err != nil {
 		return nil, fmt.Errorf("error building unary middlewares: %w", err)
@@ -462,6 +605,9 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 
 	return &completedServerConfig{
 		ds:                  ds,
+		materialized:        materializedManager,
+		cacheInvalidator:    cacheInvalidator,
+		peerResolver:        peerResolver,
 		gRPCServer:          grpcServer,
 		dispatchGRPCServer:  dispatchGrpcServer,
 		gatewayServer:       gatewayServer,
@@ -564,7 +710,10 @@ type RunnableServer interface {
 // but is assumed have already been validated via `Complete()` on Config.
 // It offers limited options for mutation before Run() starts the services.
 type completedServerConfig struct {
-	ds datastore.Datastore
+	ds               datastore.Datastore
+	materialized     *materialized.Manager
+	cacheInvalidator *caching.WatchInvalidator
+	peerResolver     *discovery.Resolver
 
 	gRPCServer         util.RunnableGRPCServer
 	dispatchGRPCServer util.RunnableGRPCServer
@@ -622,6 +771,18 @@ func (c *completedServerConfig) Run(ctx context.Context) error {
 	g.Go(c.metricsServer.ListenAndServe)
 	g.Go(func() error { return c.telemetryReporter(ctx) })
 
+	if c.materialized != nil {
+		g.Go(func() error { return c.materialized.Start(ctx) })
+	}
+
+	if c.cacheInvalidator != nil {
+		g.Go(func() error { return c.cacheInvalidator.Start(ctx) })
+	}
+
+	if c.peerResolver != nil {
+		g.Go(func() error { return c.peerResolver.Start(ctx) })
+	}
+
 	g.Go(stopOnCancelWithErr(c.closeFunc))
 
 	if err := g.Wait(); err != nil {
@@ -2,15 +2,24 @@ package server
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"log"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/authzed/spicedb/internal/auth"
 	"github.com/authzed/spicedb/internal/datastore/memdb"
 	"github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/cache"
 	"github.com/authzed/spicedb/pkg/cmd/datastore"
 	"github.com/authzed/spicedb/pkg/cmd/util"
+	dispatchv1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
 
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 	"github.com/stretchr/testify/require"
@@ -19,6 +28,9 @@ import (
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/goleak"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 func TestServerGracefulTermination(t *testing.T) {
@@ -176,6 +188,106 @@ func TestServerGracefulTerminationOnError(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRemoteDispatchCacheRejectsInvalidation(t *testing.T) {
+	ds, err := memdb.NewMemdbDatastore(0, 1*time.Second, 10*time.Second)
+	require.NoError(t, err)
+
+	c := ConfigWithOptions(&Config{
+		GRPCServer: util.GRPCServerConfig{
+			Network: util.BufferedNetwork,
+		},
+	}, WithPresharedSecureKey("psk"), WithDatastore(ds),
+		WithEnableExperimentalDispatchCacheInvalidation(true),
+		WithDispatchRemoteCache(&cache.RemoteConfig{Store: cache.NewInProcessRemoteStore()}),
+	)
+	_, err = c.Complete(context.Background())
+	require.ErrorContains(t, err, "cannot be used with watch-driven dispatch cache invalidation")
+}
+
+func TestDispatchRejectsJWT(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwks, err := json.Marshal(map[string]any{"keys": []map[string]string{{
+		"kty": "OKP",
+		"kid": "test",
+		"crv": "Ed25519",
+		"x":   base64.RawURLEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+	}}})
+	require.NoError(t, err)
+	jwksPath := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksPath, jwks, 0o600))
+
+	header, err := json.Marshal(map[string]string{"alg": "EdDSA", "kid": "test", "typ": "JWT"})
+	require.NoError(t, err)
+	claims, err := json.Marshal(map[string]any{"sub": "someone", "scope": "*", "exp": time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	token := signed + "." + base64.RawURLEncoding.EncodeToString(ed25519.Sign(key, []byte(signed)))
+
+	// The token is valid for the v1 API.
+	verifier, err := auth.NewJWTVerifier(ctx, auth.JWTConfig{JWKS: jwksPath})
+	require.NoError(t, err)
+	_, err = verifier.Verify(ctx, token)
+	require.NoError(t, err)
+
+	ds, err := memdb.NewMemdbDatastore(0, 1*time.Second, 10*time.Second)
+	require.NoError(t, err)
+
+	c := ConfigWithOptions(
+		&Config{},
+		WithPresharedSecureKey("psk"),
+		WithJWTAuth(auth.JWTConfig{JWKS: jwksPath}),
+		WithDatastore(ds),
+		WithGRPCServer(util.GRPCServerConfig{
+			Network: util.BufferedNetwork,
+			Enabled: true,
+		}),
+		WithDispatchServer(util.GRPCServerConfig{
+			Network: util.BufferedNetwork,
+			Enabled: true,
+		}),
+		WithHTTPGateway(util.HTTPServerConfig{HTTPEnabled: false}),
+		WithMetricsAPI(util.HTTPServerConfig{HTTPEnabled: false}),
+	)
+	rs, err := c.Complete(ctx)
+	require.NoError(t, err)
+
+	go func() {
+		_ = rs.Run(ctx)
+	}()
+
+	// The token is rejected on the dispatch API, which only accepts the preshared key.
+	dispatchConn, err := grpc.DialContext(ctx, util.BufferedNetwork,
+		grpc.WithContextDialer(rs.DispatchNetDialContext),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+	)
+	require.NoError(t, err)
+	defer dispatchConn.Close()
+
+	dispatchClient := dispatchv1.NewDispatchServiceClient(dispatchConn)
+	_, err = dispatchClient.DispatchCheck(ctx, &dispatchv1.DispatchCheckRequest{}, grpc.PerRPCCredentials(insecureBearerToken(token)))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = dispatchClient.DispatchCheck(ctx, &dispatchv1.DispatchCheckRequest{}, grpc.PerRPCCredentials(insecureBearerToken("psk")))
+	require.NotContains(t, []codes.Code{codes.PermissionDenied, codes.Unauthenticated}, status.Code(err))
+}
+
+// insecureBearerToken authenticates calls with a bearer token over insecure connections.
+type insecureBearerToken string
+
+func (t insecureBearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t insecureBearerToken) RequireTransportSecurity() bool {
+	return false
+}
+
 func TestReplaceUnaryMiddleware(t *testing.T) {
 	c := Config{UnaryMiddlewareModification: []MiddlewareModification[grpc.UnaryServerInterceptor]{
 		{
@@ -2,8 +2,13 @@
 package server
 
 import (
+	audit "github.com/authzed/spicedb/internal/audit"
+	auth1 "github.com/authzed/spicedb/internal/auth"
 	dispatch "github.com/authzed/spicedb/internal/dispatch"
+	discovery "github.com/authzed/spicedb/internal/dispatch/discovery"
 	graph "github.com/authzed/spicedb/internal/dispatch/graph"
+	ratelimit "github.com/authzed/spicedb/internal/middleware/ratelimit"
+	cache "github.com/authzed/spicedb/pkg/cache"
 	datastore "github.com/authzed/spicedb/pkg/cmd/datastore"
 	util "github.com/authzed/spicedb/pkg/cmd/util"
 	datastore1 "github.com/authzed/spicedb/pkg/datastore"
@@ -41,6 +46,7 @@ func (c *Config) ToOption() ConfigOption {
 		to.GRPCServer = c.GRPCServer
 		to.GRPCAuthFunc = c.GRPCAuthFunc
 		to.PresharedSecureKey = c.PresharedSecureKey
+		to.JWTAuth = c.JWTAuth
 		to.ShutdownGracePeriod = c.ShutdownGracePeriod
 		to.DisableVersionResponse = c.DisableVersionResponse
 		to.HTTPGateway = c.HTTPGateway
@@ -55,6 +61,7 @@ func (c *Config) ToOption() ConfigOption {
 		to.EnableExperimentalWatchableSchemaCache = c.EnableExperimentalWatchableSchemaCache
 		to.SchemaWatchHeartbeat = c.SchemaWatchHeartbeat
 		to.NamespaceCacheConfig = c.NamespaceCacheConfig
+		to.EnableExperimentalMaterializedPermissions = c.EnableExperimentalMaterializedPermissions
 		to.SchemaPrefixesRequired = c.SchemaPrefixesRequired
 		to.DispatchServer = c.DispatchServer
 		to.DispatchMaxDepth = c.DispatchMaxDepth
@@ -70,10 +77,13 @@ func (c *Config) ToOption() ConfigOption {
 		to.Dispatcher = c.Dispatcher
 		to.DispatchHashringReplicationFactor = c.DispatchHashringReplicationFactor
 		to.DispatchHashringSpread = c.DispatchHashringSpread
+		to.DispatchPeerDiscovery = c.DispatchPeerDiscovery
 		to.DispatchSecondaryUpstreamAddrs = c.DispatchSecondaryUpstreamAddrs
 		to.DispatchSecondaryUpstreamExprs = c.DispatchSecondaryUpstreamExprs
 		to.DispatchCacheConfig = c.DispatchCacheConfig
 		to.ClusterDispatchCacheConfig = c.ClusterDispatchCacheConfig
+		to.EnableExperimentalDispatchCacheInvalidation = c.EnableExperimentalDispatchCacheInvalidation
+		to.DispatchRemoteCache = c.DispatchRemoteCache
 		to.DisableV1SchemaAPI = c.DisableV1SchemaAPI
 		to.V1SchemaAdditiveOnly = c.V1SchemaAdditiveOnly
 		to.MaximumUpdatesPerWrite = c.MaximumUpdatesPerWrite
@@ -84,6 +94,9 @@ func (c *Config) ToOption() ConfigOption {
 		to.MetricsAPI = c.MetricsAPI
 		to.UnaryMiddlewareModification = c.UnaryMiddlewareModification
 		to.StreamingMiddlewareModification = c.StreamingMiddlewareModification
+		to.RateLimit = c.RateLimit
+		to.Audit = c.Audit
+		to.DecisionLog = c.DecisionLog
 		to.DispatchUnaryMiddleware = c.DispatchUnaryMiddleware
 		to.DispatchStreamingMiddleware = c.DispatchStreamingMiddleware
 		to.SilentlyDisableTelemetry = c.SilentlyDisableTelemetry
@@ -101,6 +114,7 @@ func (c Config) DebugMap() map[string]any {
 	debugMap["GRPCServer"] = helpers.DebugValue(c.GRPCServer, false)
 	debugMap["GRPCAuthFunc"] = helpers.DebugValue(c.GRPCAuthFunc, false)
 	debugMap["PresharedSecureKey"] = helpers.SensitiveDebugValue(c.PresharedSecureKey)
+	debugMap["JWTAuth"] = helpers.DebugValue(c.JWTAuth, false)
 	debugMap["ShutdownGracePeriod"] = helpers.DebugValue(c.ShutdownGracePeriod, false)
 	debugMap["DisableVersionResponse"] = helpers.DebugValue(c.DisableVersionResponse, false)
 	debugMap["HTTPGateway"] = helpers.DebugValue(c.HTTPGateway, false)
@@ -115,6 +129,7 @@ func (c Config) DebugMap() map[string]any {
 	debugMap["EnableExperimentalWatchableSchemaCache"] = helpers.DebugValue(c.EnableExperimentalWatchableSchemaCache, false)
 	debugMap["SchemaWatchHeartbeat"] = helpers.DebugValue(c.SchemaWatchHeartbeat, false)
 	debugMap["NamespaceCacheConfig"] = helpers.DebugValue(c.NamespaceCacheConfig, false)
+	debugMap["EnableExperimentalMaterializedPermissions"] = helpers.DebugValue(c.EnableExperimentalMaterializedPermissions, false)
 	debugMap["SchemaPrefixesRequired"] = helpers.DebugValue(c.SchemaPrefixesRequired, false)
 	debugMap["DispatchServer"] = helpers.DebugValue(c.DispatchServer, false)
 	debugMap["DispatchMaxDepth"] = helpers.DebugValue(c.DispatchMaxDepth, false)
@@ -130,10 +145,12 @@ func (c Config) DebugMap() map[string]any {
 	debugMap["Dispatcher"] = helpers.DebugValue(c.Dispatcher, false)
 	debugMap["DispatchHashringReplicationFactor"] = helpers.DebugValue(c.DispatchHashringReplicationFactor, false)
 	debugMap["DispatchHashringSpread"] = helpers.DebugValue(c.DispatchHashringSpread, false)
+	debugMap["DispatchPeerDiscovery"] = helpers.DebugValue(c.DispatchPeerDiscovery, false)
 	debugMap["DispatchSecondaryUpstreamAddrs"] = helpers.DebugValue(c.DispatchSecondaryUpstreamAddrs, false)
 	debugMap["DispatchSecondaryUpstreamExprs"] = helpers.DebugValue(c.DispatchSecondaryUpstreamExprs, false)
 	debugMap["DispatchCacheConfig"] = helpers.DebugValue(c.DispatchCacheConfig, false)
 	debugMap["ClusterDispatchCacheConfig"] = helpers.DebugValue(c.ClusterDispatchCacheConfig, false)
+	debugMap["EnableExperimentalDispatchCacheInvalidation"] = helpers.DebugValue(c.EnableExperimentalDispatchCacheInvalidation, false)
 	debugMap["DisableV1SchemaAPI"] = helpers.DebugValue(c.DisableV1SchemaAPI, false)
 	debugMap["V1SchemaAdditiveOnly"] = helpers.DebugValue(c.V1SchemaAdditiveOnly, false)
 	debugMap["MaximumUpdatesPerWrite"] = helpers.DebugValue(c.MaximumUpdatesPerWrite, false)
@@ -142,6 +159,9 @@ func (c Config) DebugMap() map[string]any {
 	debugMap["StreamingAPITimeout"] = helpers.DebugValue(c.StreamingAPITimeout, false)
 	debugMap["WatchHeartbeat"] = helpers.DebugValue(c.WatchHeartbeat, false)
 	debugMap["MetricsAPI"] = helpers.DebugValue(c.MetricsAPI, false)
+	debugMap["RateLimit"] = helpers.DebugValue(c.RateLimit, false)
+	debugMap["Audit"] = helpers.DebugValue(c.Audit, false)
+	debugMap["DecisionLog"] = helpers.DebugValue(c.DecisionLog, false)
 	debugMap["SilentlyDisableTelemetry"] = helpers.DebugValue(c.SilentlyDisableTelemetry, false)
 	debugMap["TelemetryCAOverridePath"] = helpers.DebugValue(c.TelemetryCAOverridePath, false)
 	debugMap["TelemetryEndpoint"] = helpers.DebugValue(c.TelemetryEndpoint, false)
@@ -195,6 +215,13 @@ func SetPresharedSecureKey(presharedSecureKey []string) ConfigOption {
 	}
 }
 
+// WithJWTAuth returns an option that can set JWTAuth on a Config
+func WithJWTAuth(jWTAuth auth1.JWTConfig) ConfigOption {
+	return func(c *Config) {
+		c.JWTAuth = jWTAuth
+	}
+}
+
 // WithShutdownGracePeriod returns an option that can set ShutdownGracePeriod on a Config
 func WithShutdownGracePeriod(shutdownGracePeriod time.Duration) ConfigOption {
 	return func(c *Config) {
@@ -300,6 +327,13 @@ func WithNamespaceCacheConfig(namespaceCacheConfig CacheConfig) ConfigOption {
 	}
 }
 
+// WithEnableExperimentalMaterializedPermissions returns an option that can set EnableExperimentalMaterializedPermissions on a Config
+func WithEnableExperimentalMaterializedPermissions(enableExperimentalMaterializedPermissions bool) ConfigOption {
+	return func(c *Config) {
+		c.EnableExperimentalMaterializedPermissions = enableExperimentalMaterializedPermissions
+	}
+}
+
 // WithSchemaPrefixesRequired returns an option that can set SchemaPrefixesRequired on a Config
 func WithSchemaPrefixesRequired(schemaPrefixesRequired bool) ConfigOption {
 	return func(c *Config) {
@@ -405,6 +439,13 @@ func WithDispatchHashringSpread(dispatchHashringSpread uint8) ConfigOption {
 	}
 }
 
+// WithDispatchPeerDiscovery returns an option that can set DispatchPeerDiscovery on a Config
+func WithDispatchPeerDiscovery(dispatchPeerDiscovery discovery.Config) ConfigOption {
+	return func(c *Config) {
+		c.DispatchPeerDiscovery = dispatchPeerDiscovery
+	}
+}
+
 // WithDispatchSecondaryUpstreamAddrs returns an option that can append DispatchSecondaryUpstreamAddrss to Config.DispatchSecondaryUpstreamAddrs
 func WithDispatchSecondaryUpstreamAddrs(key string, value string) ConfigOption {
 	return func(c *Config) {
@@ -447,6 +488,20 @@ func WithClusterDispatchCacheConfig(clusterDispatchCacheConfig CacheConfig) Conf
 	}
 }
 
+// WithEnableExperimentalDispatchCacheInvalidation returns an option that can set EnableExperimentalDispatchCacheInvalidation on a Config
+func WithEnableExperimentalDispatchCacheInvalidation(enableExperimentalDispatchCacheInvalidation bool) ConfigOption {
+	return func(c *Config) {
+		c.EnableExperimentalDispatchCacheInvalidation = enableExperimentalDispatchCacheInvalidation
+	}
+}
+
+// WithDispatchRemoteCache returns an option that can set DispatchRemoteCache on a Config
+func WithDispatchRemoteCache(dispatchRemoteCache *cache.RemoteConfig) ConfigOption {
+	return func(c *Config) {
+		c.DispatchRemoteCache = dispatchRemoteCache
+	}
+}
+
 // WithDisableV1SchemaAPI returns an option that can set DisableV1SchemaAPI on a Config
 func WithDisableV1SchemaAPI(disableV1SchemaAPI bool) ConfigOption {
 	return func(c *Config) {
@@ -531,6 +586,27 @@ func SetStreamingMiddlewareModification(streamingMiddlewareModification []Middle
 	}
 }
 
+// WithRateLimit returns an option that can set RateLimit on a Config
+func WithRateLimit(rateLimit ratelimit.Config) ConfigOption {
+	return func(c *Config) {
+		c.RateLimit = rateLimit
+	}
+}
+
+// WithAudit returns an option that can set Audit on a Config
+func WithAudit(audit audit.Config) ConfigOption {
+	return func(c *Config) {
+		c.Audit = audit
+	}
+}
+
+// WithDecisionLog returns an option that can set DecisionLog on a Config
+func WithDecisionLog(decisionLog audit.DecisionConfig) ConfigOption {
+	return func(c *Config) {
+		c.DecisionLog = decisionLog
+	}
+}
+
 // WithDispatchUnaryMiddleware returns an option that can append DispatchUnaryMiddlewares to Config.DispatchUnaryMiddleware
 func WithDispatchUnaryMiddleware(dispatchUnaryMiddleware grpc.UnaryServerInterceptor) ConfigOption {
 	return func(c *Config) {
@@ -33,6 +33,7 @@ type Config struct {
 	HTTPGateway                util.HTTPServerConfig `debugmap:"visible"`
 	ReadOnlyHTTPGateway        util.HTTPServerConfig `debugmap:"visible"`
 	LoadConfigs                []string              `debugmap:"visible"`
+	SnapshotDirectory          string                `debugmap:"visible"`
 	MaximumUpdatesPerWrite     uint16                `debugmap:"visible"`
 	MaximumPreconditionCount   uint16                `debugmap:"visible"`
 	MaxCaveatContextSize       int                   `debugmap:"visible"`
@@ -54,7 +55,7 @@ func (dr datastoreReady) ReadyState(_ context.Context) (datastore.ReadyState, er
 func (c *Config) Complete() (RunnableTestServer, error) {
 	dispatcher := graph.NewLocalOnlyDispatcher(10)
 
-	datastoreMiddleware := pertoken.NewMiddleware(c.LoadConfigs)
+	datastoreMiddleware := pertoken.NewMiddleware(c.LoadConfigs, c.SnapshotDirectory)
 
 	healthManager := health.NewHealthManager(dispatcher, &datastoreReady{})
 
@@ -146,6 +147,7 @@ func (c *Config) Complete() (RunnableTestServer, error) {
 		gatewayServer:         gatewayServer,
 		readOnlyGatewayServer: readOnlyGatewayServer,
 		healthManager:         healthManager,
+		datastoreMiddleware:   datastoreMiddleware,
 	}, nil
 }
 
@@ -157,6 +159,8 @@ type completedTestServer struct {
 	readOnlyGatewayServer util.RunnableHTTPServer
 
 	healthManager health.Manager
+
+	datastoreMiddleware *pertoken.MiddlewareForTesting
 }
 
 func (c *completedTestServer) Run(ctx context.Context) error {
@@ -188,6 +192,10 @@ func (c *completedTestServer) Run(ctx context.Context) error {
 		log.Ctx(ctx).Warn().Err(err).Msg("error shutting down servers")
 	}
 
+	if err := c.datastoreMiddleware.Close(); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("error closing datastores")
+	}
+
 	return nil
 }
 
@@ -36,6 +36,7 @@ func (c *Config) ToOption() ConfigOption {
 		to.HTTPGateway = c.HTTPGateway
 		to.ReadOnlyHTTPGateway = c.ReadOnlyHTTPGateway
 		to.LoadConfigs = c.LoadConfigs
+		to.SnapshotDirectory = c.SnapshotDirectory
 		to.MaximumUpdatesPerWrite = c.MaximumUpdatesPerWrite
 		to.MaximumPreconditionCount = c.MaximumPreconditionCount
 		to.MaxCaveatContextSize = c.MaxCaveatContextSize
@@ -51,6 +52,7 @@ func (c Config) DebugMap() map[string]any {
 	debugMap["HTTPGateway"] = helpers.DebugValue(c.HTTPGateway, false)
 	debugMap["ReadOnlyHTTPGateway"] = helpers.DebugValue(c.ReadOnlyHTTPGateway, false)
 	debugMap["LoadConfigs"] = helpers.DebugValue(c.LoadConfigs, false)
+	debugMap["SnapshotDirectory"] = helpers.DebugValue(c.SnapshotDirectory, false)
 	debugMap["MaximumUpdatesPerWrite"] = helpers.DebugValue(c.MaximumUpdatesPerWrite, false)
 	debugMap["MaximumPreconditionCount"] = helpers.DebugValue(c.MaximumPreconditionCount, false)
 	debugMap["MaxCaveatContextSize"] = helpers.DebugValue(c.MaxCaveatContextSize, false)
@@ -116,6 +118,13 @@ func SetLoadConfigs(loadConfigs []string) ConfigOption {
 	}
 }
 
+// WithSnapshotDirectory returns an option that can set SnapshotDirectory on a Config
+func WithSnapshotDirectory(snapshotDirectory string) ConfigOption {
+	return func(c *Config) {
+		c.SnapshotDirectory = snapshotDirectory
+	}
+}
+
 // WithMaximumUpdatesPerWrite returns an option that can set MaximumUpdatesPerWrite on a Config
 func WithMaximumUpdatesPerWrite(maximumUpdatesPerWrite uint16) ConfigOption {
 	return func(c *Config) {
@@ -0,0 +1,71 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// StringToFloat64Var defines a flag with the given name and usage, setting a map from strings to
+// float64 values given as comma-separated `key=value` pairs, e.g. `a=1,b=0.5`.
+func StringToFloat64Var(flags *pflag.FlagSet, p *map[string]float64, name string, value map[string]float64, usage string) {
+	*p = value
+	flags.Var(&stringToFloat64Value{value: p}, name, usage)
+}
+
+type stringToFloat64Value struct {
+	value   *map[string]float64
+	changed bool
+}
+
+func (s *stringToFloat64Value) Set(val string) error {
+	parsed := make(map[string]float64)
+	for _, pair := range strings.Split(val, ",") {
+		if pair == "" {
+			continue
+		}
+
+		key, rawValue, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("%s must be formatted as key=value", pair)
+		}
+
+		number, err := strconv.ParseFloat(rawValue, 64)
+		if err != nil {
+			return fmt.Errorf("invalid value for key `%s`: %w", key, err)
+		}
+		parsed[key] = number
+	}
+
+	// The flag may be given more than once, in which case the maps are merged.
+	if !s.changed || *s.value == nil {
+		*s.value = parsed
+	} else {
+		for key, number := range parsed {
+			(*s.value)[key] = number
+		}
+	}
+	s.changed = true
+	return nil
+}
+
+func (s *stringToFloat64Value) Type() string {
+	return "stringToFloat64"
+}
+
+func (s *stringToFloat64Value) String() string {
+	keys := make([]string, 0, len(*s.value))
+	for key := range *s.value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+strconv.FormatFloat((*s.value)[key], 'f', -1, 64))
+	}
+	return "[" + strings.Join(pairs, ",") + "]"
+}
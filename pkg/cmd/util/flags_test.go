@@ -0,0 +1,21 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringToFloat64Var(t *testing.T) {
+	var value map[string]float64
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	StringToFloat64Var(flags, &value, "rates", nil, "")
+
+	require.NoError(t, flags.Parse([]string{"--rates", "CheckPermission=0.5,WriteRelationships=0", "--rates", "LookupResources=2"}))
+	require.Equal(t, map[string]float64{"CheckPermission": 0.5, "WriteRelationships": 0, "LookupResources": 2}, value)
+	require.Equal(t, "[CheckPermission=0.5,LookupResources=2,WriteRelationships=0]", flags.Lookup("rates").Value.String())
+
+	require.ErrorContains(t, flags.Parse([]string{"--rates", "CheckPermission"}), "must be formatted as key=value")
+	require.ErrorContains(t, flags.Parse([]string{"--rates", "CheckPermission=fast"}), "invalid value for key `CheckPermission`")
+}
@@ -252,6 +252,7 @@ type RevisionedNamespace = RevisionedDefinition[*core.NamespaceDefinition]
 // Reader is an interface for reading relationships from the datastore.
 type Reader interface {
 	CaveatReader
+	SchemaHistoryReader
 
 	// QueryRelationships reads relationships, starting from the resource side.
 	QueryRelationships(
@@ -281,6 +282,7 @@ type Reader interface {
 type ReadWriteTransaction interface {
 	Reader
 	CaveatStorer
+	SchemaHistoryStorer
 
 	// WriteRelationships takes a list of tuple mutations and applies them to the datastore.
 	WriteRelationships(ctx context.Context, mutations []*core.RelationTupleUpdate) error
@@ -463,6 +465,18 @@ type RepairableDatastore interface {
 	RepairOperations() []RepairOperation
 }
 
+// HistoricalDatastore represents a datastore that can resolve the revision which was current at
+// a point in time, so that it can be read "as of" that time.
+type HistoricalDatastore interface {
+	Datastore
+
+	// RevisionAsOf returns the latest revision at or before the given time. If the history of
+	// the datastore at that time is no longer retained, an ErrInvalidRevision with reason
+	// RevisionStale is returned. If the time is in the future, its reason is
+	// CouldNotDetermineRevision.
+	RevisionAsOf(ctx context.Context, asOf time.Time) (Revision, error)
+}
+
 // UnwrappableDatastore represents a datastore that can be unwrapped into the underlying
 // datastore.
 type UnwrappableDatastore interface {
@@ -505,6 +519,10 @@ type Feature struct {
 type Features struct {
 	// Watch is enabled if the underlying datastore can support the Watch api.
 	Watch Feature
+
+	// WatchSchema is enabled if the Watch api of the underlying datastore can report changes
+	// to the schema.
+	WatchSchema Feature
 }
 
 // ObjectTypeStat represents statistics for a single object type (namespace).
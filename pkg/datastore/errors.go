@@ -3,6 +3,7 @@ package datastore
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/rs/zerolog"
 )
@@ -197,6 +198,38 @@ func (err ErrCaveatNameNotFound) DetailsMetadata() map[string]string {
 	}
 }
 
+// ErrSchemaVersionNotFound is the error returned when a version of the schema is not found
+type ErrSchemaVersionNotFound struct {
+	error
+	version uint64
+}
+
+var _ ErrNotFound = ErrSchemaVersionNotFound{}
+
+func (err ErrSchemaVersionNotFound) IsNotFoundError() bool {
+	return true
+}
+
+// Version returns the number of the version of the schema that couldn't be found
+func (err ErrSchemaVersionNotFound) Version() uint64 {
+	return err.version
+}
+
+// NewSchemaVersionNotFoundErr constructs a new schema version not found error.
+func NewSchemaVersionNotFoundErr(version uint64) error {
+	return ErrSchemaVersionNotFound{
+		error:   fmt.Errorf("schema version `%d` not found", version),
+		version: version,
+	}
+}
+
+// DetailsMetadata returns the metadata for details for this error.
+func (err ErrSchemaVersionNotFound) DetailsMetadata() map[string]string {
+	return map[string]string{
+		"schema_version": strconv.FormatUint(err.version, 10),
+	}
+}
+
 var (
 	ErrClosedIterator        = errors.New("unable to iterate: iterator closed")
 	ErrCursorsWithoutSorting = errors.New("cursors are disabled on unsorted results")
@@ -259,6 +259,14 @@ func (m *mockedReader) LookupCaveatsWithNames(_ context.Context, _ []string) ([]
 	panic("not implemented")
 }
 
+func (m *mockedReader) ListSchemaVersions(_ context.Context) ([]datastore.SchemaVersion, error) {
+	panic("not implemented")
+}
+
+func (m *mockedReader) ReadSchemaVersion(_ context.Context, _ uint64) (datastore.SchemaVersion, error) {
+	panic("not implemented")
+}
+
 func (m *mockedReader) ReadNamespaceByName(_ context.Context, _ string) (ns *core.NamespaceDefinition, lastWritten datastore.Revision, err error) {
 	panic("not implemented")
 }
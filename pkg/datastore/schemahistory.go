@@ -0,0 +1,38 @@
+package datastore
+
+import "context"
+
+// SchemaVersion is a version of the schema, recorded each time the schema is written.
+type SchemaVersion struct {
+	// Version is the number of the version, starting at 1 and incremented with each write.
+	Version uint64
+
+	// Schema is the full text of the schema written.
+	Schema string
+
+	// Author is the principal which wrote the schema, if known.
+	Author string
+
+	// Revision is the revision at which the schema was written.
+	Revision Revision
+}
+
+// SchemaHistoryReader offers read operations for the history of the schema.
+type SchemaHistoryReader interface {
+	// ListSchemaVersions returns the versions of the schema written at or before the revision of
+	// the reader, most recent first.
+	ListSchemaVersions(ctx context.Context) ([]SchemaVersion, error)
+
+	// ReadSchemaVersion returns the version of the schema with the given number.
+	// It returns an instance of ErrSchemaVersionNotFound if not found.
+	ReadSchemaVersion(ctx context.Context, version uint64) (SchemaVersion, error)
+}
+
+// SchemaHistoryStorer offers both read and write operations for the history of the schema.
+type SchemaHistoryStorer interface {
+	SchemaHistoryReader
+
+	// WriteSchemaVersion records the given schema text as the next version of the schema, and
+	// returns the number of the version.
+	WriteSchemaVersion(ctx context.Context, schema string, author string) (uint64, error)
+}
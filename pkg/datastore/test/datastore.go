@@ -101,6 +101,7 @@ func AllWithExceptions(t *testing.T, tester DatastoreTester, except Categories)
 	t.Run("TestCreateDeleteTouchTest", func(t *testing.T) { CreateDeleteTouchTest(t, tester) })
 	t.Run("TestCreateTouchDeleteTouchTest", func(t *testing.T) { CreateTouchDeleteTouchTest(t, tester) })
 	t.Run("TestTouchAlreadyExistingCaveated", func(t *testing.T) { TouchAlreadyExistingCaveatedTest(t, tester) })
+	t.Run("TestRelationshipExpiration", func(t *testing.T) { RelationshipExpirationTest(t, tester) })
 
 	t.Run("TestMultipleReadsInRWT", func(t *testing.T) { MultipleReadsInRWTTest(t, tester) })
 	t.Run("TestConcurrentWriteSerialization", func(t *testing.T) { ConcurrentWriteSerializationTest(t, tester) })
@@ -115,6 +116,7 @@ func AllWithExceptions(t *testing.T, tester DatastoreTester, except Categories)
 	t.Run("TestRevisionSerialization", func(t *testing.T) { RevisionSerializationTest(t, tester) })
 	t.Run("TestSequentialRevisions", func(t *testing.T) { SequentialRevisionsTest(t, tester) })
 	t.Run("TestConcurrentRevisions", func(t *testing.T) { ConcurrentRevisionsTest(t, tester) })
+	t.Run("TestRevisionAsOf", func(t *testing.T) { RevisionAsOfTest(t, tester) })
 
 	if !except.GC() {
 		t.Run("TestRevisionGC", func(t *testing.T) { RevisionGCTest(t, tester) })
@@ -134,6 +136,8 @@ func AllWithExceptions(t *testing.T, tester DatastoreTester, except Categories)
 	t.Run("TestCaveatedRelationshipFilter", func(t *testing.T) { CaveatedRelationshipFilterTest(t, tester) })
 	t.Run("TestCaveatSnapshotReads", func(t *testing.T) { CaveatSnapshotReadsTest(t, tester) })
 
+	t.Run("TestSchemaHistory", func(t *testing.T) { SchemaHistoryTest(t, tester) })
+
 	if !except.Watch() {
 		t.Run("TestWatchBasic", func(t *testing.T) { WatchTest(t, tester) })
 		t.Run("TestWatchCancel", func(t *testing.T) { WatchCancelTest(t, tester) })
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/testfixtures"
 	"github.com/authzed/spicedb/pkg/datastore"
 	ns "github.com/authzed/spicedb/pkg/namespace"
 	core "github.com/authzed/spicedb/pkg/proto/core/v1"
@@ -225,3 +226,58 @@ func ConcurrentRevisionsTest(t *testing.T, tester DatastoreTester) {
 
 	wg.Wait()
 }
+
+// RevisionAsOfTest tests that revisions resolved as of points in time read the data written up to
+// that time, and that times outside of the GC window cannot be resolved.
+func RevisionAsOfTest(t *testing.T, tester DatastoreTester) {
+	require := require.New(t)
+
+	ds, err := tester.New(0, veryLargeGCInterval, 1*time.Hour, 1)
+	require.NoError(err)
+
+	historical := datastore.UnwrapAs[datastore.HistoricalDatastore](ds)
+	require.NotNil(historical, "expected datastore to support revisions as of points in time")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	setupDatastore(ds, require)
+	tRequire := testfixtures.TupleChecker{Require: require, DS: ds}
+
+	// Times are taken between writes, leaving room for the skew of the clock of the datastore.
+	first := makeTestTuple("first", "owner")
+	_, err = common.WriteTuples(ctx, ds, core.RelationTupleUpdate_CREATE, first)
+	require.NoError(err)
+
+	time.Sleep(100 * time.Millisecond)
+	betweenWrites := time.Now()
+	time.Sleep(100 * time.Millisecond)
+
+	second := makeTestTuple("second", "owner")
+	_, err = common.WriteTuples(ctx, ds, core.RelationTupleUpdate_CREATE, second)
+	require.NoError(err)
+
+	time.Sleep(100 * time.Millisecond)
+	afterWrites := time.Now()
+	time.Sleep(100 * time.Millisecond)
+
+	betweenRevision, err := historical.RevisionAsOf(ctx, betweenWrites)
+	require.NoError(err)
+	tRequire.TupleExists(ctx, first, betweenRevision)
+	tRequire.NoTupleExists(ctx, second, betweenRevision)
+
+	afterRevision, err := historical.RevisionAsOf(ctx, afterWrites)
+	require.NoError(err)
+	require.True(afterRevision.GreaterThan(betweenRevision))
+	tRequire.TupleExists(ctx, first, afterRevision)
+	tRequire.TupleExists(ctx, second, afterRevision)
+
+	var invalidRevisionErr datastore.ErrInvalidRevision
+	_, err = historical.RevisionAsOf(ctx, time.Now().Add(-2*time.Hour))
+	require.ErrorAs(err, &invalidRevisionErr)
+	require.Equal(datastore.RevisionStale, invalidRevisionErr.Reason())
+
+	_, err = historical.RevisionAsOf(ctx, time.Now().Add(1*time.Hour))
+	require.ErrorAs(err, &invalidRevisionErr)
+	require.Equal(datastore.CouldNotDetermineRevision, invalidRevisionErr.Reason())
+}
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/pkg/datastore"
+)
+
+// SchemaHistoryTest tests writing, listing and reading versions of the schema.
+func SchemaHistoryTest(t *testing.T, tester DatastoreTester) {
+	req := require.New(t)
+
+	ds, err := tester.New(0, veryLargeGCInterval, veryLargeGCWindow, 1)
+	req.NoError(err)
+
+	ctx := context.Background()
+
+	startRevision, err := ds.HeadRevision(ctx)
+	req.NoError(err)
+
+	versions, err := ds.SnapshotReader(startRevision).ListSchemaVersions(ctx)
+	req.NoError(err)
+	req.Empty(versions)
+
+	_, err = ds.SnapshotReader(startRevision).ReadSchemaVersion(ctx, 1)
+	req.True(errors.As(err, &datastore.ErrSchemaVersionNotFound{}))
+
+	writeVersion := func(schema string, author string) (uint64, datastore.Revision) {
+		var written uint64
+		rev, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+			var err error
+			written, err = rwt.WriteSchemaVersion(ctx, schema, author)
+			return err
+		})
+		req.NoError(err)
+		return written, rev
+	}
+
+	firstVersion, firstRev := writeVersion("definition user {}", "alice")
+	req.Equal(uint64(1), firstVersion)
+
+	secondVersion, secondRev := writeVersion("definition user {}\n\ndefinition document {}", "")
+	req.Equal(uint64(2), secondVersion)
+
+	// The most recent version is listed first.
+	versions, err = ds.SnapshotReader(secondRev).ListSchemaVersions(ctx)
+	req.NoError(err)
+	req.Len(versions, 2)
+	req.Equal(uint64(2), versions[0].Version)
+	req.Equal("definition user {}\n\ndefinition document {}", versions[0].Schema)
+	req.Equal("", versions[0].Author)
+	req.Equal(uint64(1), versions[1].Version)
+	req.Equal("definition user {}", versions[1].Schema)
+	req.Equal("alice", versions[1].Author)
+	req.False(versions[0].Revision.LessThan(versions[1].Revision))
+
+	found, err := ds.SnapshotReader(secondRev).ReadSchemaVersion(ctx, 1)
+	req.NoError(err)
+	req.Equal(versions[1].Schema, found.Schema)
+	req.Equal(versions[1].Author, found.Author)
+
+	// Versions written after the revision of the reader are not visible.
+	versions, err = ds.SnapshotReader(firstRev).ListSchemaVersions(ctx)
+	req.NoError(err)
+	req.Len(versions, 1)
+	req.Equal(uint64(1), versions[0].Version)
+
+	_, err = ds.SnapshotReader(firstRev).ReadSchemaVersion(ctx, 2)
+	req.True(errors.As(err, &datastore.ErrSchemaVersionNotFound{}))
+
+}
@@ -695,6 +695,55 @@ func TouchAlreadyExistingCaveatedTest(t *testing.T, tester DatastoreTester) {
 	ensureTuples(ctx, require, ds, tpl2, tpl3, ctpl1)
 }
 
+// RelationshipExpirationTest tests that expired relationships are not returned from reads.
+func RelationshipExpirationTest(t *testing.T, tester DatastoreTester) {
+	require := require.New(t)
+
+	rawDS, err := tester.New(0, veryLargeGCInterval, veryLargeGCWindow, 1)
+	require.NoError(err)
+
+	ds, _ := testfixtures.StandardDatastoreWithData(rawDS, require)
+	ctx := context.Background()
+
+	expired := tuple.WithExpiration(makeTestTuple("foo", "tom"), time.Now().Add(-1*time.Hour))
+	unexpired := tuple.WithExpiration(makeTestTuple("foo", "sarah"), time.Now().Add(1*time.Hour))
+	noExpiration := makeTestTuple("foo", "fred")
+
+	_, err = common.WriteTuples(ctx, ds, core.RelationTupleUpdate_CREATE, expired, unexpired, noExpiration)
+	require.NoError(err)
+
+	ensureTuples(ctx, require, ds, unexpired, noExpiration)
+	ensureNotTuples(ctx, require, ds, expired)
+
+	headRev, err := ds.HeadRevision(ctx)
+	require.NoError(err)
+
+	iter, err := ds.SnapshotReader(headRev).ReverseQueryRelationships(
+		ctx,
+		onrToSubjectsFilter(expired.Subject),
+	)
+	require.NoError(err)
+	defer iter.Close()
+	require.Nil(iter.Next())
+	require.NoError(iter.Err())
+
+	// Creating a relationship which has not expired should fail.
+	_, err = common.WriteTuples(ctx, ds, core.RelationTupleUpdate_CREATE, unexpired)
+	require.ErrorAs(err, &common.CreateRelationshipExistsError{})
+
+	// Creating the expired relationship again should succeed, as it no longer exists, even if it
+	// has not been garbage collected.
+	recreated := tuple.WithExpiration(makeTestTuple("foo", "tom"), time.Now().Add(1*time.Hour))
+	_, err = common.WriteTuples(ctx, ds, core.RelationTupleUpdate_CREATE, recreated)
+	require.NoError(err)
+	ensureTuples(ctx, require, ds, recreated)
+
+	// Touching the relationship without an expiration should remove its expiration.
+	_, err = common.WriteTuples(ctx, ds, core.RelationTupleUpdate_TOUCH, makeTestTuple("foo", "tom"))
+	require.NoError(err)
+	ensureTuples(ctx, require, ds, makeTestTuple("foo", "tom"))
+}
+
 func MultipleReadsInRWTTest(t *testing.T, tester DatastoreTester) {
 	require := require.New(t)
 
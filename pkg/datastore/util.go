@@ -1,5 +1,11 @@
 package datastore
 
+import (
+	"context"
+
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+)
+
 // DefinitionsOf returns just the schema definitions found in the list of revisioned
 // definitions.
 func DefinitionsOf[T SchemaDefinition](revisionedDefinitions []RevisionedDefinition[T]) []T {
@@ -9,3 +15,22 @@ func DefinitionsOf[T SchemaDefinition](revisionedDefinitions []RevisionedDefinit
 	}
 	return definitions
 }
+
+// NewSliceRelationshipSource returns a BulkWriteRelationshipSource over the given relationships.
+func NewSliceRelationshipSource(rels []*core.RelationTuple) BulkWriteRelationshipSource {
+	return &sliceRelationshipSource{rels: rels}
+}
+
+type sliceRelationshipSource struct {
+	rels []*core.RelationTuple
+}
+
+func (s *sliceRelationshipSource) Next(_ context.Context) (*core.RelationTuple, error) {
+	if len(s.rels) == 0 {
+		return nil, nil
+	}
+
+	next := s.rels[0]
+	s.rels = s.rels[1:]
+	return next, nil
+}
@@ -29,6 +29,7 @@ import (
 	core "github.com/authzed/spicedb/pkg/proto/core/v1"
 	devinterface "github.com/authzed/spicedb/pkg/proto/developer/v1"
 	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/lint"
 	"github.com/authzed/spicedb/pkg/spiceerrors"
 	"github.com/authzed/spicedb/pkg/tuple"
 	"github.com/authzed/spicedb/pkg/typesystem"
@@ -164,6 +165,11 @@ func (dc *DevContext) RunV1InMemoryService() (*grpc.ClientConn, func(), error) {
 	}, err
 }
 
+// Lint returns the warnings found in the schema of the DevContext by the default lint rules.
+func (dc *DevContext) Lint() []lint.Warning {
+	return lint.Lint(dc.CompiledSchema, lint.DefaultRules())
+}
+
 // Dispose disposes of the DevContext and its underlying datastore.
 func (dc *DevContext) Dispose() {
 	if dc.Dispatcher == nil {
@@ -140,3 +140,24 @@ definition document {
 
 	shutdown()
 }
+
+func TestDevContextLint(t *testing.T) {
+	devCtx, devErrs, err := NewDevContext(context.Background(), &devinterface.RequestContext{
+		Schema: `definition user {}
+
+definition document {
+	relation viewer: user
+	relation editor: user
+	permission view = viewer + viewer
+}
+`,
+	})
+	require.NoError(t, err)
+	require.Nil(t, devErrs)
+	defer devCtx.Dispose()
+
+	warnings := devCtx.Lint()
+	require.Len(t, warnings, 2)
+	require.Equal(t, "5:2: relation `editor` is not used by any permission (unused-relation)", warnings[0].String())
+	require.Equal(t, "6:29: `viewer` appears more than once in the union (redundant-union-branch)", warnings[1].String())
+}
@@ -0,0 +1,102 @@
+//go:build wasm
+// +build wasm
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"syscall/js"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/authzed/spicedb/pkg/development"
+	devinterface "github.com/authzed/spicedb/pkg/proto/developer/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/diagram"
+)
+
+// schemaDiagramResponse is the response of runSchemaDiagram.
+type schemaDiagramResponse struct {
+	// Diagram is the generated diagram.
+	Diagram string `json:"diagram,omitempty"`
+
+	// InternalError is the error encountered while generating the diagram, if any.
+	InternalError string `json:"internalError,omitempty"`
+
+	// InputErrors are the errors found in the schema, each encoded as a DeveloperError.
+	InputErrors []json.RawMessage `json:"inputErrors,omitempty"`
+}
+
+// runSchemaDiagram is the function exported into the WASM environment for generating the
+// diagram of a schema.
+//
+// The arguments are:
+//
+//  1. The schema, as a string.
+//  2. The format of the diagram: `dot` or `mermaid`.
+//
+// The function returns:
+//
+//	A single JSON-encoded schemaDiagramResponse containing the diagram, or the errors
+//	encountered.
+func runSchemaDiagram(this js.Value, args []js.Value) any {
+	if len(args) != 2 {
+		return encodeDiagramResponse(schemaDiagramResponse{InternalError: "invalid number of arguments specified"})
+	}
+
+	devContext, inputErrors, err := newSchemaDevContext(args[0].String())
+	if err != nil {
+		return encodeDiagramResponse(schemaDiagramResponse{InternalError: err.Error()})
+	}
+
+	if len(inputErrors) > 0 {
+		return encodeDiagramResponse(schemaDiagramResponse{InputErrors: inputErrors})
+	}
+	defer devContext.Dispose()
+
+	generated, err := diagram.Generate(context.Background(), devContext.CompiledSchema, diagram.Format(args[1].String()))
+	if err != nil {
+		return encodeDiagramResponse(schemaDiagramResponse{InternalError: fmt.Sprintf("could not generate diagram: %s", err)})
+	}
+
+	return encodeDiagramResponse(schemaDiagramResponse{Diagram: generated})
+}
+
+// newSchemaDevContext returns the DevContext for the given schema, or the errors found in the
+// schema, each encoded as a DeveloperError.
+func newSchemaDevContext(schema string) (*development.DevContext, []json.RawMessage, error) {
+	devContext, devErrors, err := development.NewDevContext(context.Background(), &devinterface.RequestContext{
+		Schema: schema,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if devErrors != nil && len(devErrors.InputErrors) > 0 {
+		if devContext != nil {
+			devContext.Dispose()
+		}
+
+		inputErrors := make([]json.RawMessage, 0, len(devErrors.InputErrors))
+		for _, inputErr := range devErrors.InputErrors {
+			encoded, err := protojson.Marshal(inputErr)
+			if err != nil {
+				panic(err)
+			}
+			inputErrors = append(inputErrors, encoded)
+		}
+		return nil, inputErrors, nil
+	}
+
+	return devContext, nil, nil
+}
+
+func encodeDiagramResponse(response schemaDiagramResponse) js.Value {
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		panic(err)
+	}
+
+	return js.ValueOf(string(encoded))
+}
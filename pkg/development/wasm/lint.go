@@ -0,0 +1,64 @@
+//go:build wasm
+// +build wasm
+
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+
+	"github.com/authzed/spicedb/pkg/schemadsl/lint"
+)
+
+// schemaLintResponse is the response of runSchemaLint.
+type schemaLintResponse struct {
+	// Warnings are the warnings found in the schema by the default lint rules.
+	Warnings []lint.Warning `json:"warnings"`
+
+	// InternalError is the error encountered while linting the schema, if any.
+	InternalError string `json:"internalError,omitempty"`
+
+	// InputErrors are the errors found in the schema, each encoded as a DeveloperError.
+	InputErrors []json.RawMessage `json:"inputErrors,omitempty"`
+}
+
+// runSchemaLint is the function exported into the WASM environment for linting a schema.
+//
+// The arguments are:
+//
+//  1. The schema, as a string.
+//
+// The function returns:
+//
+//	A single JSON-encoded schemaLintResponse containing the warnings, or the errors
+//	encountered.
+func runSchemaLint(this js.Value, args []js.Value) any {
+	if len(args) != 1 {
+		return encodeLintResponse(schemaLintResponse{InternalError: "invalid number of arguments specified"})
+	}
+
+	devContext, inputErrors, err := newSchemaDevContext(args[0].String())
+	if err != nil {
+		return encodeLintResponse(schemaLintResponse{InternalError: err.Error()})
+	}
+
+	if len(inputErrors) > 0 {
+		return encodeLintResponse(schemaLintResponse{InputErrors: inputErrors})
+	}
+	defer devContext.Dispose()
+
+	warnings := devContext.Lint()
+	if warnings == nil {
+		warnings = []lint.Warning{}
+	}
+	return encodeLintResponse(schemaLintResponse{Warnings: warnings})
+}
+
+func encodeLintResponse(response schemaLintResponse) js.Value {
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		panic(err)
+	}
+
+	return js.ValueOf(string(encoded))
+}
@@ -11,6 +11,8 @@ import (
 func main() {
 	c := make(chan struct{}, 0)
 	js.Global().Set("runSpiceDBDeveloperRequest", js.FuncOf(runDeveloperRequest))
+	js.Global().Set("runSpiceDBSchemaDiagram", js.FuncOf(runSchemaDiagram))
+	js.Global().Set("runSpiceDBSchemaLint", js.FuncOf(runSchemaLint))
 	fmt.Println("Developer system initialized")
 	<-c
 }
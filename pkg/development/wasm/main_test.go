@@ -8,6 +8,7 @@
 package main
 
 import (
+	"encoding/json"
 	"syscall/js"
 	"testing"
 
@@ -16,6 +17,7 @@ import (
 
 	core "github.com/authzed/spicedb/pkg/proto/core/v1"
 	devinterface "github.com/authzed/spicedb/pkg/proto/developer/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/lint"
 )
 
 func TestMissingArgument(t *testing.T) {
@@ -88,3 +90,90 @@ func run(t *testing.T, request *devinterface.DeveloperRequest) *devinterface.Dev
 	require.NoError(t, err)
 	return response
 }
+
+func TestSchemaDiagram(t *testing.T) {
+	tcs := []struct {
+		name     string
+		args     []js.Value
+		expected schemaDiagramResponse
+	}{
+		{
+			"missing argument",
+			[]js.Value{js.ValueOf("definition user {}")},
+			schemaDiagramResponse{InternalError: "invalid number of arguments specified"},
+		},
+		{
+			"unsupported format",
+			[]js.Value{js.ValueOf("definition user {}"), js.ValueOf("svg")},
+			schemaDiagramResponse{InternalError: "could not generate diagram: unsupported diagram format `svg`"},
+		},
+		{
+			"mermaid diagram",
+			[]js.Value{js.ValueOf(`definition user {}
+
+			definition document {
+				relation viewer: user
+			}`), js.ValueOf("mermaid")},
+			schemaDiagramResponse{Diagram: `flowchart LR
+  subgraph d0_definition["definition user"]
+    d0["user"]
+  end
+  subgraph d1_definition["definition document"]
+    d1["document"]
+    d1r0("viewer")
+  end
+  d1r0 --> d0
+`},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			encodedResponse := runSchemaDiagram(js.Null(), tc.args)
+			response := schemaDiagramResponse{}
+			err := json.Unmarshal([]byte(encodedResponse.(js.Value).String()), &response)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, response)
+		})
+	}
+}
+
+func TestSchemaDiagramInvalidSchema(t *testing.T) {
+	encodedResponse := runSchemaDiagram(js.Null(), []js.Value{js.ValueOf("definitio user {"), js.ValueOf("dot")})
+	response := schemaDiagramResponse{}
+	err := json.Unmarshal([]byte(encodedResponse.(js.Value).String()), &response)
+	require.NoError(t, err)
+	require.Len(t, response.InputErrors, 1)
+
+	devErr := &devinterface.DeveloperError{}
+	err = protojson.Unmarshal(response.InputErrors[0], devErr)
+	require.NoError(t, err)
+	require.Equal(t, "Unexpected token at root level: TokenTypeIdentifier", devErr.Message)
+}
+
+func TestSchemaLint(t *testing.T) {
+	encodedResponse := runSchemaLint(js.Null(), []js.Value{js.ValueOf(`definition user {}
+
+	definition document {
+		relation viewer: user
+		relation editor: user
+		permission view = viewer
+	}`)})
+	response := schemaLintResponse{}
+	err := json.Unmarshal([]byte(encodedResponse.(js.Value).String()), &response)
+	require.NoError(t, err)
+	require.Empty(t, response.InternalError)
+	require.Len(t, response.Warnings, 1)
+	require.Equal(t, lint.UnusedRelationRule, response.Warnings[0].Rule)
+	require.Equal(t, "editor", response.Warnings[0].Relation)
+}
+
+func TestSchemaLintInvalidSchema(t *testing.T) {
+	encodedResponse := runSchemaLint(js.Null(), []js.Value{js.ValueOf("definitio user {")})
+	response := schemaLintResponse{}
+	err := json.Unmarshal([]byte(encodedResponse.(js.Value).String()), &response)
+	require.NoError(t, err)
+	require.Len(t, response.InputErrors, 1)
+	require.Empty(t, response.Warnings)
+}
@@ -45,6 +45,20 @@ func GenerateRequestID() string {
 	return randSeq(32)
 }
 
+// FromContext returns the request ID found in the metadata of the given incoming context, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	requestIDs := md.Get(RequestIDMetadataKey)
+	if len(requestIDs) == 0 {
+		return "", false
+	}
+	return requestIDs[0], true
+}
+
 // GetOrGenerateRequestID returns the request ID found in the given context. If not, a new request ID
 // is generated and added to the returned context.
 func GetOrGenerateRequestID(ctx context.Context) (string, context.Context) {
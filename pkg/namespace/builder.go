@@ -73,6 +73,14 @@ func MustRelationWithComment(name string, comment string, rewrite *core.UsersetR
 	return rel
 }
 
+// MustMaterialized marks the given relation as materialized and returns it.
+func MustMaterialized(relation *core.Relation) *core.Relation {
+	if err := SetMaterialized(relation); err != nil {
+		panic(err)
+	}
+	return relation
+}
+
 // AllowedRelation creates a relation reference to an allowed relation.
 func AllowedRelation(namespaceName string, relationName string) *core.AllowedRelation {
 	return &core.AllowedRelation{
@@ -245,6 +253,20 @@ func MustComputesUsersetWithSourcePosition(relation string, lineNumber uint64) *
 // and then unions all children on the usersets found by following a relation on those loaded
 // tuples.
 func TupleToUserset(tuplesetRelation, usersetRelation string) *core.SetOperation_Child {
+	return FunctionedTupleToUserset(tuplesetRelation, usersetRelation, core.TupleToUserset_FUNCTION_ANY)
+}
+
+// AllTupleToUserset creates a child which first loads all tuples with the specific relation,
+// and then intersects all children on the usersets found by following a relation on those loaded
+// tuples.
+func AllTupleToUserset(tuplesetRelation, usersetRelation string) *core.SetOperation_Child {
+	return FunctionedTupleToUserset(tuplesetRelation, usersetRelation, core.TupleToUserset_FUNCTION_ALL)
+}
+
+// FunctionedTupleToUserset creates a child which first loads all tuples with the specific relation,
+// and then combines the children on the usersets found by following a relation on those loaded
+// tuples, using the given function.
+func FunctionedTupleToUserset(tuplesetRelation, usersetRelation string, function core.TupleToUserset_Function) *core.SetOperation_Child {
 	return &core.SetOperation_Child{
 		ChildType: &core.SetOperation_Child_TupleToUserset{
 			TupleToUserset: &core.TupleToUserset{
@@ -255,6 +277,7 @@ func TupleToUserset(tuplesetRelation, usersetRelation string) *core.SetOperation
 					Relation: usersetRelation,
 					Object:   core.ComputedUserset_TUPLE_USERSET_OBJECT,
 				},
+				Function: function,
 			},
 		},
 	}
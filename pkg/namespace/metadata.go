@@ -1,6 +1,8 @@
 package namespace
 
 import (
+	"strings"
+
 	"google.golang.org/protobuf/types/known/anypb"
 
 	core "github.com/authzed/spicedb/pkg/proto/core/v1"
@@ -54,6 +56,28 @@ func GetComments(metadata *core.Metadata) []string {
 	return comments
 }
 
+// GetCommentLines returns the lines of text of the comments found within the given metadata
+// message, without their comment markers.
+func GetCommentLines(metadata *core.Metadata) []string {
+	lines := []string{}
+	for _, comment := range GetComments(metadata) {
+		trimmed := strings.TrimSpace(comment)
+		switch {
+		case strings.HasPrefix(trimmed, "/*"):
+			trimmed = strings.TrimPrefix(trimmed, "/**")
+			trimmed = strings.TrimPrefix(trimmed, "/*")
+			trimmed = strings.TrimSuffix(trimmed, "*/")
+			for _, line := range strings.Split(strings.TrimSpace(trimmed), "\n") {
+				lines = append(lines, strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*")))
+			}
+
+		default:
+			lines = append(lines, strings.TrimSpace(strings.TrimPrefix(trimmed, "//")))
+		}
+	}
+	return lines
+}
+
 // AddComment adds a comment to the given metadata message.
 func AddComment(metadata *core.Metadata, comment string) (*core.Metadata, error) {
 	if metadata == nil {
@@ -108,3 +132,54 @@ func SetRelationKind(relation *core.Relation, kind iv1.RelationMetadata_Relation
 	metadata.MetadataMessage = append(metadata.MetadataMessage, encoded)
 	return nil
 }
+
+// IsMaterialized returns whether the relation has been marked as materialized.
+func IsMaterialized(relation *core.Relation) bool {
+	metadata := relation.Metadata
+	if metadata == nil {
+		return false
+	}
+
+	for _, msg := range metadata.MetadataMessage {
+		var rm iv1.RelationMetadata
+		if err := msg.UnmarshalTo(&rm); err == nil {
+			return rm.Materialized
+		}
+	}
+
+	return false
+}
+
+// SetMaterialized marks the relation as materialized, updating its existing relation
+// metadata if present.
+func SetMaterialized(relation *core.Relation) error {
+	metadata := relation.Metadata
+	if metadata == nil {
+		metadata = &core.Metadata{}
+		relation.Metadata = metadata
+	}
+
+	for index, msg := range metadata.MetadataMessage {
+		var rm iv1.RelationMetadata
+		if err := msg.UnmarshalTo(&rm); err != nil {
+			continue
+		}
+
+		rm.Materialized = true
+		encoded, err := anypb.New(&rm)
+		if err != nil {
+			return err
+		}
+
+		metadata.MetadataMessage[index] = encoded
+		return nil
+	}
+
+	encoded, err := anypb.New(&iv1.RelationMetadata{Materialized: true})
+	if err != nil {
+		return err
+	}
+
+	metadata.MetadataMessage = append(metadata.MetadataMessage, encoded)
+	return nil
+}
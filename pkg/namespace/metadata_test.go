@@ -61,3 +61,34 @@ func TestMetadata(t *testing.T) {
 
 	require.Equal(iv1.RelationMetadata_PERMISSION, GetRelationKind(ns.Relation[0]))
 }
+
+func TestMaterializedMetadata(t *testing.T) {
+	require := require.New(t)
+
+	permission := MustRelation("somepermission", Union(ComputedUserset("viewer")))
+	require.False(IsMaterialized(permission))
+
+	require.NoError(SetMaterialized(permission))
+	require.True(IsMaterialized(permission))
+	require.Equal(iv1.RelationMetadata_PERMISSION, GetRelationKind(permission))
+	require.Len(permission.Metadata.MetadataMessage, 1)
+
+	relation := &core.Relation{Name: "somerelation"}
+	require.NoError(SetMaterialized(relation))
+	require.True(IsMaterialized(relation))
+	require.Equal(iv1.RelationMetadata_UNKNOWN_KIND, GetRelationKind(relation))
+}
+
+func TestGetCommentLines(t *testing.T) {
+	require := require.New(t)
+
+	var metadata *core.Metadata
+	for _, comment := range []string{"// first line", "//second line", "/**\n * a block\n * comment\n */", "/* inline */"} {
+		var err error
+		metadata, err = AddComment(metadata, comment)
+		require.NoError(err)
+	}
+
+	require.Equal([]string{"first line", "second line", "a block", "comment", "inline"}, GetCommentLines(metadata))
+	require.Equal([]string{}, GetCommentLines(nil))
+}
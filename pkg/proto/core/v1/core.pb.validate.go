@@ -166,6 +166,35 @@ func (m *RelationTuple) validate(all bool) error {
 		}
 	}
 
+	if all {
+		switch v := interface{}(m.GetOptionalExpirationTime()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RelationTupleValidationError{
+					field:  "OptionalExpirationTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RelationTupleValidationError{
+					field:  "OptionalExpirationTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetOptionalExpirationTime()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RelationTupleValidationError{
+				field:  "OptionalExpirationTime",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return RelationTupleMultiError(errors)
 	}
@@ -4256,6 +4285,17 @@ func (m *TupleToUserset) validate(all bool) error {
 		}
 	}
 
+	if _, ok := TupleToUserset_Function_name[int32(m.GetFunction())]; !ok {
+		err := TupleToUsersetValidationError{
+			field:  "Function",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
 	if len(errors) > 0 {
 		return TupleToUsersetMultiError(errors)
 	}
@@ -3483,3 +3483,332 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = CheckDebugTraceValidationError{}
+
+// Validate checks the field values on CheckExplanation with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CheckExplanation) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CheckExplanation with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// CheckExplanationMultiError, or nil if none found.
+func (m *CheckExplanation) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CheckExplanation) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetResource()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CheckExplanationValidationError{
+					field:  "Resource",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CheckExplanationValidationError{
+					field:  "Resource",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetResource()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CheckExplanationValidationError{
+				field:  "Resource",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetRelationship()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CheckExplanationValidationError{
+					field:  "Relationship",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CheckExplanationValidationError{
+					field:  "Relationship",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRelationship()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CheckExplanationValidationError{
+				field:  "Relationship",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetCaveat()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CheckExplanationValidationError{
+					field:  "Caveat",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CheckExplanationValidationError{
+					field:  "Caveat",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCaveat()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CheckExplanationValidationError{
+				field:  "Caveat",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetSteps() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, CheckExplanationValidationError{
+						field:  fmt.Sprintf("Steps[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, CheckExplanationValidationError{
+						field:  fmt.Sprintf("Steps[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return CheckExplanationValidationError{
+					field:  fmt.Sprintf("Steps[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return CheckExplanationMultiError(errors)
+	}
+
+	return nil
+}
+
+// CheckExplanationMultiError is an error wrapping multiple validation errors
+// returned by CheckExplanation.ValidateAll() if the designated constraints
+// aren't met.
+type CheckExplanationMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CheckExplanationMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CheckExplanationMultiError) AllErrors() []error { return m }
+
+// CheckExplanationValidationError is the validation error returned by
+// CheckExplanation.Validate if the designated constraints aren't met.
+type CheckExplanationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CheckExplanationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CheckExplanationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CheckExplanationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CheckExplanationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CheckExplanationValidationError) ErrorName() string { return "CheckExplanationValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CheckExplanationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCheckExplanation.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CheckExplanationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CheckExplanationValidationError{}
+
+// Validate checks the field values on CaveatExplanation with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CaveatExplanation) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CaveatExplanation with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// CaveatExplanationMultiError, or nil if none found.
+func (m *CaveatExplanation) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CaveatExplanation) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for CaveatName
+
+	// no validation rules for Expression
+
+	// no validation rules for Result
+
+	if len(errors) > 0 {
+		return CaveatExplanationMultiError(errors)
+	}
+
+	return nil
+}
+
+// CaveatExplanationMultiError is an error wrapping multiple validation errors
+// returned by CaveatExplanation.ValidateAll() if the designated constraints
+// aren't met.
+type CaveatExplanationMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CaveatExplanationMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CaveatExplanationMultiError) AllErrors() []error { return m }
+
+// CaveatExplanationValidationError is the validation error returned by
+// CaveatExplanation.Validate if the designated constraints aren't met.
+type CaveatExplanationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CaveatExplanationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CaveatExplanationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CaveatExplanationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CaveatExplanationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CaveatExplanationValidationError) ErrorName() string {
+	return "CaveatExplanationValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CaveatExplanationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCaveatExplanation.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CaveatExplanationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CaveatExplanationValidationError{}
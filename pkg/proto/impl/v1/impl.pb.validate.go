@@ -935,6 +935,8 @@ func (m *RelationMetadata) validate(all bool) error {
 
 	// no validation rules for Kind
 
+	// no validation rules for Materialized
+
 	if len(errors) > 0 {
 		return RelationMetadataMultiError(errors)
 	}
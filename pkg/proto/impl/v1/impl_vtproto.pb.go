@@ -281,6 +281,7 @@ func (m *RelationMetadata) CloneVT() *RelationMetadata {
 	}
 	r := new(RelationMetadata)
 	r.Kind = m.Kind
+	r.Materialized = m.Materialized
 	if len(m.unknownFields) > 0 {
 		r.unknownFields = make([]byte, len(m.unknownFields))
 		copy(r.unknownFields, m.unknownFields)
@@ -745,6 +746,9 @@ func (this *RelationMetadata) EqualVT(that *RelationMetadata) bool {
 	if this.Kind != that.Kind {
 		return false
 	}
+	if this.Materialized != that.Materialized {
+		return false
+	}
 	return string(this.unknownFields) == string(that.unknownFields)
 }
 
@@ -1403,6 +1407,16 @@ func (m *RelationMetadata) MarshalToSizedBufferVT(dAtA []byte) (int, error) {
 		i -= len(m.unknownFields)
 		copy(dAtA[i:], m.unknownFields)
 	}
+	if m.Materialized {
+		i--
+		if m.Materialized {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
 	if m.Kind != 0 {
 		i = protohelpers.EncodeVarint(dAtA, i, uint64(m.Kind))
 		i--
@@ -1744,6 +1758,9 @@ func (m *RelationMetadata) SizeVT() (n int) {
 	if m.Kind != 0 {
 		n += 1 + protohelpers.SizeOfVarint(uint64(m.Kind))
 	}
+	if m.Materialized {
+		n += 2
+	}
 	n += len(m.unknownFields)
 	return n
 }
@@ -2902,6 +2919,26 @@ func (m *RelationMetadata) UnmarshalVT(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Materialized", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return protohelpers.ErrIntOverflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Materialized = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := protohelpers.Skip(dAtA[iNdEx:])
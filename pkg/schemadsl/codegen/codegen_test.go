@@ -0,0 +1,272 @@
+package codegen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+)
+
+const testSchema = `
+/** only allows access from the given network */
+caveat ip_allowed(user_ip ipaddress, allowed_ranges list<string>, expires_at timestamp) {
+	user_ip.in_cidr(allowed_ranges[0])
+}
+
+definition user {}
+
+definition team {
+	relation member: user | team#member
+}
+
+/**
+ * document is a document.
+ */
+definition document {
+	// viewer can view the document
+	relation viewer: user | user:* | team#member | user with ip_allowed
+	permission view = viewer
+}`
+
+func compile(t *testing.T, schema string) *compiler.CompiledSchema {
+	compiled, err := compiler.Compile(compiler.InputSchema{
+		Source:       input.Source("schema"),
+		SchemaString: schema,
+	}, compiler.AllowUnprefixedObjectType())
+	require.NoError(t, err)
+	return compiled
+}
+
+func TestGenerateGo(t *testing.T) {
+	generated, err := GenerateGo(context.Background(), compile(t, testSchema), "schema")
+	require.NoError(t, err)
+	require.Equal(t, `// Code generated by spicedb schema codegen. DO NOT EDIT.
+
+package schema
+
+import (
+	"time"
+)
+
+// SubjectType is a type of subject allowed on a relation.
+type SubjectType struct {
+	// Definition is the name of the definition of the subject.
+	Definition string
+
+	// Relation is the name of the relation of the subject, if any.
+	Relation string
+
+	// Wildcard is true if the subject type is the wildcard for all subjects of the definition.
+	Wildcard bool
+
+	// Caveat is the name of the caveat required on relationships with the subject type, if any.
+	Caveat string
+}
+
+// IpAllowedCaveat is the name of caveat `+"`ip_allowed`"+`.
+//
+// only allows access from the given network
+const IpAllowedCaveat = "ip_allowed"
+
+// IpAllowedContext is the context of caveat `+"`ip_allowed`"+`. Its fields are nil when not set.
+type IpAllowedContext struct {
+	AllowedRanges []string
+	ExpiresAt     *time.Time
+	UserIp        *string
+}
+
+// ToMap returns the fields of the context which are set, keyed by the names of the parameters of
+// the caveat, with values of the types expected in a caveat context.
+func (c IpAllowedContext) ToMap() map[string]any {
+	context := map[string]any{}
+	if c.AllowedRanges != nil {
+		context["allowed_ranges"] = contextList(c.AllowedRanges, func(v string) any { return v })
+	}
+	if c.ExpiresAt != nil {
+		context["expires_at"] = (*c.ExpiresAt).Format(time.RFC3339)
+	}
+	if c.UserIp != nil {
+		context["user_ip"] = *c.UserIp
+	}
+	return context
+}
+
+// User is the name of definition `+"`user`"+`.
+const User = "user"
+
+// Team is the name of definition `+"`team`"+`.
+const Team = "team"
+
+// Relations and permissions of definition `+"`team`"+`.
+const (
+	// TeamMember is the name of relation `+"`member`"+` of definition `+"`team`"+`.
+	TeamMember = "member"
+)
+
+// TeamMemberSubjectTypes are the types of subjects allowed on relation `+"`member`"+` of definition `+"`team`"+`.
+var TeamMemberSubjectTypes = []SubjectType{
+	{Definition: User},
+	{Definition: Team, Relation: TeamMember},
+}
+
+// Document is the name of definition `+"`document`"+`.
+//
+// document is a document.
+const Document = "document"
+
+// Relations and permissions of definition `+"`document`"+`.
+const (
+	// DocumentViewer is the name of relation `+"`viewer`"+` of definition `+"`document`"+`.
+	//
+	// viewer can view the document
+	DocumentViewer = "viewer"
+
+	// DocumentView is the name of permission `+"`view`"+` of definition `+"`document`"+`.
+	DocumentView = "view"
+)
+
+// DocumentViewerSubjectTypes are the types of subjects allowed on relation `+"`viewer`"+` of definition `+"`document`"+`.
+var DocumentViewerSubjectTypes = []SubjectType{
+	{Definition: User},
+	{Definition: User, Wildcard: true},
+	{Definition: Team, Relation: TeamMember},
+	{Definition: User, Caveat: IpAllowedCaveat},
+}
+
+func contextList[T any](values []T, convert func(T) any) []any {
+	converted := make([]any, 0, len(values))
+	for _, value := range values {
+		converted = append(converted, convert(value))
+	}
+	return converted
+}
+`, generated)
+}
+
+func TestGenerateTypeScript(t *testing.T) {
+	generated, err := GenerateTypeScript(context.Background(), compile(t, testSchema))
+	require.NoError(t, err)
+	require.Equal(t, `// Code generated by spicedb schema codegen. DO NOT EDIT.
+
+/** A type of subject allowed on a relation. */
+export interface SubjectType {
+  /** The name of the definition of the subject. */
+  readonly definition: string;
+  /** The name of the relation of the subject, if any. */
+  readonly relation?: string;
+  /** True if the subject type is the wildcard for all subjects of the definition. */
+  readonly wildcard?: boolean;
+  /** The name of the caveat required on relationships with the subject type, if any. */
+  readonly caveat?: string;
+}
+
+/**
+ * The name of caveat `+"`ip_allowed`"+`.
+ *
+ * only allows access from the given network
+ */
+export const IpAllowedCaveat = "ip_allowed";
+
+/** The context of caveat `+"`ip_allowed`"+`. */
+export interface IpAllowedContext {
+  allowed_ranges?: string[];
+  expires_at?: string;
+  user_ip?: string;
+}
+
+/** The name of definition `+"`user`"+`. */
+export const User = "user";
+
+/** The name of definition `+"`team`"+`. */
+export const Team = "team";
+/** The name of relation `+"`member`"+` of definition `+"`team`"+`. */
+export const TeamMember = "member";
+
+/**
+ * The name of definition `+"`document`"+`.
+ *
+ * document is a document.
+ */
+export const Document = "document";
+/**
+ * The name of relation `+"`viewer`"+` of definition `+"`document`"+`.
+ *
+ * viewer can view the document
+ */
+export const DocumentViewer = "viewer";
+/** The name of permission `+"`view`"+` of definition `+"`document`"+`. */
+export const DocumentView = "view";
+
+/** The types of subjects allowed on relation `+"`member`"+` of definition `+"`team`"+`. */
+export const TeamMemberSubjectTypes: readonly SubjectType[] = [
+  { definition: User },
+  { definition: Team, relation: TeamMember },
+];
+
+/** The types of subjects allowed on relation `+"`viewer`"+` of definition `+"`document`"+`. */
+export const DocumentViewerSubjectTypes: readonly SubjectType[] = [
+  { definition: User },
+  { definition: User, wildcard: true },
+  { definition: Team, relation: TeamMember },
+  { definition: User, caveat: IpAllowedCaveat },
+];
+`, generated)
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tcs := []struct {
+		name          string
+		schema        string
+		packageName   string
+		expectedError string
+	}{
+		{
+			"invalid package name",
+			`definition user {}`,
+			"some-package",
+			"invalid Go package name `some-package`",
+		},
+		{
+			"colliding definitions",
+			`definition some_user {}
+			definition some/user {}`,
+			"schema",
+			"the identifier `SomeUser` of definition `some/user` is already used by definition `some_user`",
+		},
+		{
+			"colliding relation",
+			`definition user {}
+			definition document {
+				relation user: user
+			}
+			definition document_user {}`,
+			"schema",
+			"the identifier `DocumentUser` of definition `document_user` is already used by relation `user` of definition `document`",
+		},
+		{
+			"reserved identifier",
+			`definition subject_type {}`,
+			"schema",
+			"the identifier `SubjectType` of definition `subject_type` is already used by the generated code",
+		},
+		{
+			"invalid schema",
+			`definition document {
+				relation viewer: user
+			}`,
+			"schema",
+			"could not lookup definition `user` for relation `viewer`: object definition `user` not found",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := GenerateGo(context.Background(), compile(t, tc.schema), tc.packageName)
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package codegen
+
+import (
+	"context"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+)
+
+const (
+	subjectTypeStruct  = "SubjectType"
+	contextToMapMethod = "ToMap"
+)
+
+// GenerateGo generates the source of a Go package with the given name, which contains typed
+// constants for the definitions, relations, permissions and caveats of the schema, the subject
+// types allowed on each relation, and a struct for the context of each caveat.
+func GenerateGo(ctx context.Context, compiled *compiler.CompiledSchema, packageName string) (string, error) {
+	if !token.IsIdentifier(packageName) {
+		return "", fmt.Errorf("invalid Go package name `%s`", packageName)
+	}
+
+	model, err := buildModel(ctx, compiled, subjectTypeStruct)
+	if err != nil {
+		return "", err
+	}
+
+	g := &goGenerator{}
+	g.generateSubjectType()
+	for _, caveat := range model.caveats {
+		if err := g.generateCaveat(caveat); err != nil {
+			return "", err
+		}
+	}
+	for _, definition := range model.definitions {
+		g.generateDefinition(definition)
+	}
+	g.generateHelpers()
+
+	var sb strings.Builder
+	sb.WriteString("// Code generated by spicedb schema codegen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&sb, "package %s\n", packageName)
+	if g.usesBase64 || g.usesTime {
+		sb.WriteString("\nimport (\n")
+		if g.usesBase64 {
+			sb.WriteString("\"encoding/base64\"\n")
+		}
+		if g.usesTime {
+			sb.WriteString("\"time\"\n")
+		}
+		sb.WriteString(")\n")
+	}
+	sb.WriteString(g.body.String())
+
+	formatted, err := format.Source([]byte(sb.String()))
+	if err != nil {
+		return "", fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return string(formatted), nil
+}
+
+type goGenerator struct {
+	body strings.Builder
+
+	usesBase64      bool
+	usesTime        bool
+	usesContextList bool
+	usesContextMap  bool
+}
+
+func (g *goGenerator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.body, format, args...)
+}
+
+// comment writes a comment made of the given summary followed by the doc comment lines found in
+// the schema, if any.
+func (g *goGenerator) comment(summary string, docLines []string) {
+	g.printf("// %s\n", summary)
+	if len(docLines) > 0 {
+		g.printf("//\n")
+		for _, line := range docLines {
+			g.printf("%s\n", strings.TrimRight("// "+line, " "))
+		}
+	}
+}
+
+func (g *goGenerator) generateSubjectType() {
+	g.printf(`
+// %[1]s is a type of subject allowed on a relation.
+type %[1]s struct {
+	// Definition is the name of the definition of the subject.
+	Definition string
+
+	// Relation is the name of the relation of the subject, if any.
+	Relation string
+
+	// Wildcard is true if the subject type is the wildcard for all subjects of the definition.
+	Wildcard bool
+
+	// Caveat is the name of the caveat required on relationships with the subject type, if any.
+	Caveat string
+}
+`, subjectTypeStruct)
+}
+
+func (g *goGenerator) generateCaveat(caveat caveatModel) error {
+	g.printf("\n")
+	g.comment(fmt.Sprintf("%s is the name of caveat `%s`.", caveat.identifier, caveat.name), caveat.comments)
+	g.printf("const %s = %s\n", caveat.identifier, strconv.Quote(caveat.name))
+
+	g.printf("\n// %s is the context of caveat `%s`. Its fields are nil when not set.\n", caveat.contextIdentifier, caveat.name)
+	g.printf("type %s struct {\n", caveat.contextIdentifier)
+	for _, parameter := range caveat.parameters {
+		fieldType, err := g.goType(parameter.typeRef)
+		if err != nil {
+			return fmt.Errorf("parameter `%s` of caveat `%s`: %w", parameter.name, caveat.name, err)
+		}
+		if !isNillable(parameter.typeRef) {
+			fieldType = "*" + fieldType
+		}
+		g.printf("%s %s\n", parameter.identifier, fieldType)
+	}
+	g.printf("}\n")
+
+	g.printf(`
+// %s returns the fields of the context which are set, keyed by the names of the parameters of
+// the caveat, with values of the types expected in a caveat context.
+func (c %s) %[1]s() map[string]any {
+	context := map[string]any{}
+`, contextToMapMethod, caveat.contextIdentifier)
+	for _, parameter := range caveat.parameters {
+		value := "c." + parameter.identifier
+		switch {
+		case isNillable(parameter.typeRef):
+		case parameter.typeRef.TypeName == "duration" || parameter.typeRef.TypeName == "timestamp":
+			value = "(*" + value + ")"
+		default:
+			value = "*" + value
+		}
+
+		converted, err := g.contextValue(parameter.typeRef, value)
+		if err != nil {
+			return err
+		}
+
+		g.printf("if c.%s != nil {\n", parameter.identifier)
+		g.printf("context[%s] = %s\n", strconv.Quote(parameter.name), converted)
+		g.printf("}\n")
+	}
+	g.printf("return context\n}\n")
+	return nil
+}
+
+func (g *goGenerator) generateDefinition(definition definitionModel) {
+	g.printf("\n")
+	g.comment(fmt.Sprintf("%s is the name of definition `%s`.", definition.identifier, definition.name), definition.comments)
+	g.printf("const %s = %s\n", definition.identifier, strconv.Quote(definition.name))
+
+	if len(definition.relations) == 0 {
+		return
+	}
+
+	g.printf("\n// Relations and permissions of definition `%s`.\nconst (\n", definition.name)
+	for index, relation := range definition.relations {
+		if index > 0 {
+			g.printf("\n")
+		}
+
+		kind := "relation"
+		if relation.isPermission {
+			kind = "permission"
+		}
+		g.comment(fmt.Sprintf("%s is the name of %s `%s` of definition `%s`.", relation.identifier, kind, relation.name, definition.name), relation.comments)
+		g.printf("%s = %s\n", relation.identifier, strconv.Quote(relation.name))
+	}
+	g.printf(")\n")
+
+	for _, relation := range definition.relations {
+		if relation.isPermission {
+			continue
+		}
+
+		g.printf("\n// %s are the types of subjects allowed on relation `%s` of definition `%s`.\n", relation.subjectTypesIdentifier, relation.name, definition.name)
+		g.printf("var %s = []%s{\n", relation.subjectTypesIdentifier, subjectTypeStruct)
+		for _, subjectType := range relation.subjectTypes {
+			fields := []string{"Definition: " + subjectType.definition}
+			if subjectType.relation != "" {
+				fields = append(fields, "Relation: "+subjectType.relation)
+			}
+			if subjectType.wildcard {
+				fields = append(fields, "Wildcard: true")
+			}
+			if subjectType.caveat != "" {
+				fields = append(fields, "Caveat: "+subjectType.caveat)
+			}
+			g.printf("{%s},\n", strings.Join(fields, ", "))
+		}
+		g.printf("}\n")
+	}
+}
+
+func (g *goGenerator) generateHelpers() {
+	if g.usesContextList {
+		g.printf(`
+func contextList[T any](values []T, convert func(T) any) []any {
+	converted := make([]any, 0, len(values))
+	for _, value := range values {
+		converted = append(converted, convert(value))
+	}
+	return converted
+}
+`)
+	}
+
+	if g.usesContextMap {
+		g.printf(`
+func contextMap[T any](values map[string]T, convert func(T) any) map[string]any {
+	converted := make(map[string]any, len(values))
+	for key, value := range values {
+		converted[key] = convert(value)
+	}
+	return converted
+}
+`)
+	}
+}
+
+// isNillable returns whether the Go type of a caveat parameter of the given type can be nil, in
+// which case its field is not a pointer.
+func isNillable(typeRef *core.CaveatTypeReference) bool {
+	switch typeRef.TypeName {
+	case "any", "bytes", "list", "map":
+		return true
+	default:
+		return false
+	}
+}
+
+// goType returns the Go type for values of the given caveat parameter type.
+func (g *goGenerator) goType(typeRef *core.CaveatTypeReference) (string, error) {
+	switch typeRef.TypeName {
+	case "any":
+		return "any", nil
+	case "bool":
+		return "bool", nil
+	case "int":
+		return "int64", nil
+	case "uint":
+		return "uint64", nil
+	case "double":
+		return "float64", nil
+	case "string", "ipaddress":
+		return "string", nil
+	case "bytes":
+		return "[]byte", nil
+	case "duration":
+		g.usesTime = true
+		return "time.Duration", nil
+	case "timestamp":
+		g.usesTime = true
+		return "time.Time", nil
+	case "list":
+		childType, err := g.childGoType(typeRef)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + childType, nil
+	case "map":
+		childType, err := g.childGoType(typeRef)
+		if err != nil {
+			return "", err
+		}
+		return "map[string]" + childType, nil
+	default:
+		return "", fmt.Errorf("unsupported caveat parameter type `%s`", typeRef.TypeName)
+	}
+}
+
+func (g *goGenerator) childGoType(typeRef *core.CaveatTypeReference) (string, error) {
+	if len(typeRef.ChildTypes) != 1 {
+		return "", fmt.Errorf("expected a single child type for caveat parameter type `%s`", typeRef.TypeName)
+	}
+	return g.goType(typeRef.ChildTypes[0])
+}
+
+// contextValue returns the expression converting the given Go expression, of the Go type for
+// the caveat parameter type, into a value as expected in a caveat context.
+func (g *goGenerator) contextValue(typeRef *core.CaveatTypeReference, expr string) (string, error) {
+	switch typeRef.TypeName {
+	case "bytes":
+		g.usesBase64 = true
+		return fmt.Sprintf("base64.StdEncoding.EncodeToString(%s)", expr), nil
+	case "duration":
+		return fmt.Sprintf("%s.String()", expr), nil
+	case "timestamp":
+		return fmt.Sprintf("%s.Format(time.RFC3339)", expr), nil
+	case "list", "map":
+		childType, err := g.childGoType(typeRef)
+		if err != nil {
+			return "", err
+		}
+
+		converted, err := g.contextValue(typeRef.ChildTypes[0], "v")
+		if err != nil {
+			return "", err
+		}
+
+		helper := "contextList"
+		g.usesContextList = g.usesContextList || typeRef.TypeName == "list"
+		if typeRef.TypeName == "map" {
+			helper = "contextMap"
+			g.usesContextMap = true
+		}
+		return fmt.Sprintf("%s(%s, func(v %s) any { return %s })", helper, expr, childType, converted), nil
+	default:
+		return expr, nil
+	}
+}
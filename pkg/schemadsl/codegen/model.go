@@ -0,0 +1,211 @@
+package codegen
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	nspkg "github.com/authzed/spicedb/pkg/namespace"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/tuple"
+	"github.com/authzed/spicedb/pkg/typesystem"
+)
+
+// schemaModel is the schema for which code is generated, with the identifiers of its elements.
+type schemaModel struct {
+	definitions []definitionModel
+	caveats     []caveatModel
+}
+
+type definitionModel struct {
+	name       string
+	identifier string
+	comments   []string
+	relations  []relationModel
+}
+
+type relationModel struct {
+	name         string
+	identifier   string
+	comments     []string
+	isPermission bool
+	subjectTypes []subjectTypeModel
+
+	// subjectTypesIdentifier is the identifier of the subject types of the relation; empty for
+	// permissions.
+	subjectTypesIdentifier string
+}
+
+// subjectTypeModel is a type of subject allowed on a relation, referencing the identifiers of
+// the definition, relation and caveat.
+type subjectTypeModel struct {
+	definition string
+	relation   string
+	wildcard   bool
+	caveat     string
+}
+
+type caveatModel struct {
+	name              string
+	identifier        string
+	contextIdentifier string
+	comments          []string
+	parameters        []parameterModel
+}
+
+type parameterModel struct {
+	name       string
+	identifier string
+	typeRef    *core.CaveatTypeReference
+}
+
+// identifiers tracks the identifiers generated, to detect collisions.
+type identifiers map[string]string
+
+func (ids identifiers) add(identifier string, source string) error {
+	if existing, ok := ids[identifier]; ok {
+		return fmt.Errorf("the identifier `%s` of %s is already used by %s", identifier, source, existing)
+	}
+	ids[identifier] = source
+	return nil
+}
+
+// buildModel validates the compiled schema with the type system, and returns its model. The
+// given identifiers are reserved by the generated code.
+func buildModel(ctx context.Context, compiled *compiler.CompiledSchema, reserved ...string) (*schemaModel, error) {
+	ids := identifiers{}
+	for _, reservedIdentifier := range reserved {
+		ids[reservedIdentifier] = "the generated code"
+	}
+	model := &schemaModel{}
+
+	caveatIdentifiers := make(map[string]string, len(compiled.CaveatDefinitions))
+	for _, caveatDef := range compiled.CaveatDefinitions {
+		cm := caveatModel{
+			name:              caveatDef.Name,
+			identifier:        identifier(caveatDef.Name) + "Caveat",
+			contextIdentifier: identifier(caveatDef.Name) + "Context",
+			comments:          nspkg.GetCommentLines(caveatDef.Metadata),
+		}
+		if err := ids.add(cm.identifier, fmt.Sprintf("caveat `%s`", caveatDef.Name)); err != nil {
+			return nil, err
+		}
+		if err := ids.add(cm.contextIdentifier, fmt.Sprintf("the context of caveat `%s`", caveatDef.Name)); err != nil {
+			return nil, err
+		}
+
+		parameterNames := make([]string, 0, len(caveatDef.ParameterTypes))
+		for parameterName := range caveatDef.ParameterTypes {
+			parameterNames = append(parameterNames, parameterName)
+		}
+		sort.Strings(parameterNames)
+
+		fieldIDs := identifiers{contextToMapMethod: "the generated code"}
+		for _, parameterName := range parameterNames {
+			pm := parameterModel{
+				name:       parameterName,
+				identifier: identifier(parameterName),
+				typeRef:    caveatDef.ParameterTypes[parameterName],
+			}
+			if err := fieldIDs.add(pm.identifier, fmt.Sprintf("parameter `%s` of caveat `%s`", parameterName, caveatDef.Name)); err != nil {
+				return nil, err
+			}
+			cm.parameters = append(cm.parameters, pm)
+		}
+
+		caveatIdentifiers[caveatDef.Name] = cm.identifier
+		model.caveats = append(model.caveats, cm)
+	}
+
+	definitionIdentifiers := make(map[string]string, len(compiled.ObjectDefinitions))
+	for _, def := range compiled.ObjectDefinitions {
+		definitionIdentifiers[def.Name] = identifier(def.Name)
+	}
+
+	resolver := typesystem.ResolverForPredefinedDefinitions(typesystem.PredefinedElements{
+		Namespaces: compiled.ObjectDefinitions,
+		Caveats:    compiled.CaveatDefinitions,
+	})
+
+	for _, def := range compiled.ObjectDefinitions {
+		ts, err := typesystem.NewNamespaceTypeSystem(def, resolver)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := ts.Validate(ctx); err != nil {
+			return nil, err
+		}
+
+		dm := definitionModel{
+			name:       def.Name,
+			identifier: definitionIdentifiers[def.Name],
+			comments:   nspkg.GetCommentLines(def.Metadata),
+		}
+		if err := ids.add(dm.identifier, fmt.Sprintf("definition `%s`", def.Name)); err != nil {
+			return nil, err
+		}
+
+		for _, relation := range def.Relation {
+			rm := relationModel{
+				name:         relation.Name,
+				identifier:   dm.identifier + identifier(relation.Name),
+				comments:     nspkg.GetCommentLines(relation.Metadata),
+				isPermission: ts.IsPermission(relation.Name),
+			}
+			if err := ids.add(rm.identifier, fmt.Sprintf("relation `%s` of definition `%s`", relation.Name, def.Name)); err != nil {
+				return nil, err
+			}
+
+			if !rm.isPermission {
+				rm.subjectTypesIdentifier = rm.identifier + "SubjectTypes"
+				if err := ids.add(rm.subjectTypesIdentifier, fmt.Sprintf("the subject types of relation `%s` of definition `%s`", relation.Name, def.Name)); err != nil {
+					return nil, err
+				}
+
+				allowed, err := ts.AllowedDirectRelationsAndWildcards(relation.Name)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, allowedRelation := range allowed {
+					st := subjectTypeModel{
+						definition: definitionIdentifiers[allowedRelation.Namespace],
+						wildcard:   allowedRelation.GetPublicWildcard() != nil,
+					}
+					if subjectRelation := allowedRelation.GetRelation(); subjectRelation != "" && subjectRelation != tuple.Ellipsis {
+						st.relation = definitionIdentifiers[allowedRelation.Namespace] + identifier(subjectRelation)
+					}
+					if caveat := allowedRelation.GetRequiredCaveat(); caveat != nil {
+						st.caveat = caveatIdentifiers[caveat.CaveatName]
+					}
+					rm.subjectTypes = append(rm.subjectTypes, st)
+				}
+			}
+
+			dm.relations = append(dm.relations, rm)
+		}
+
+		model.definitions = append(model.definitions, dm)
+	}
+
+	return model, nil
+}
+
+// identifier returns the exported identifier for the given name of a definition, relation,
+// caveat or parameter: each of the parts of the name, separated by prefixes or underscores, is
+// capitalized.
+func identifier(name string) string {
+	var sb strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(part)
+		sb.WriteRune(unicode.ToUpper(runes[0]))
+		sb.WriteString(string(runes[1:]))
+	}
+	return sb.String()
+}
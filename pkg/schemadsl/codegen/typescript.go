@@ -0,0 +1,166 @@
+package codegen
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+)
+
+// GenerateTypeScript generates the source of a TypeScript module, which exports typed constants
+// for the definitions, relations, permissions and caveats of the schema, the subject types
+// allowed on each relation, and an interface for the context of each caveat.
+func GenerateTypeScript(ctx context.Context, compiled *compiler.CompiledSchema) (string, error) {
+	model, err := buildModel(ctx, compiled, subjectTypeStruct)
+	if err != nil {
+		return "", err
+	}
+
+	g := &tsGenerator{}
+	g.printf("// Code generated by spicedb schema codegen. DO NOT EDIT.\n")
+	g.printf(`
+/** A type of subject allowed on a relation. */
+export interface %s {
+  /** The name of the definition of the subject. */
+  readonly definition: string;
+  /** The name of the relation of the subject, if any. */
+  readonly relation?: string;
+  /** True if the subject type is the wildcard for all subjects of the definition. */
+  readonly wildcard?: boolean;
+  /** The name of the caveat required on relationships with the subject type, if any. */
+  readonly caveat?: string;
+}
+`, subjectTypeStruct)
+
+	for _, caveat := range model.caveats {
+		if err := g.generateCaveat(caveat); err != nil {
+			return "", err
+		}
+	}
+
+	// The subject types reference the constants of other definitions, so they are generated
+	// once all the constants are declared.
+	for _, definition := range model.definitions {
+		g.generateDefinition(definition)
+	}
+	for _, definition := range model.definitions {
+		g.generateSubjectTypes(definition)
+	}
+
+	return g.body.String(), nil
+}
+
+type tsGenerator struct {
+	body strings.Builder
+}
+
+func (g *tsGenerator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.body, format, args...)
+}
+
+// comment writes a JSDoc comment made of the given summary followed by the doc comment lines
+// found in the schema, if any.
+func (g *tsGenerator) comment(summary string, docLines []string) {
+	if len(docLines) == 0 {
+		g.printf("/** %s */\n", summary)
+		return
+	}
+
+	g.printf("/**\n * %s\n *\n", summary)
+	for _, line := range docLines {
+		g.printf("%s\n", strings.TrimRight(" * "+strings.ReplaceAll(line, "*/", "* /"), " "))
+	}
+	g.printf(" */\n")
+}
+
+func (g *tsGenerator) generateCaveat(caveat caveatModel) error {
+	g.printf("\n")
+	g.comment(fmt.Sprintf("The name of caveat `%s`.", caveat.name), caveat.comments)
+	g.printf("export const %s = %s;\n", caveat.identifier, strconv.Quote(caveat.name))
+
+	g.printf("\n/** The context of caveat `%s`. */\n", caveat.name)
+	g.printf("export interface %s {\n", caveat.contextIdentifier)
+	for _, parameter := range caveat.parameters {
+		fieldType, err := tsType(parameter.typeRef)
+		if err != nil {
+			return fmt.Errorf("parameter `%s` of caveat `%s`: %w", parameter.name, caveat.name, err)
+		}
+		g.printf("  %s?: %s;\n", parameter.name, fieldType)
+	}
+	g.printf("}\n")
+	return nil
+}
+
+func (g *tsGenerator) generateDefinition(definition definitionModel) {
+	g.printf("\n")
+	g.comment(fmt.Sprintf("The name of definition `%s`.", definition.name), definition.comments)
+	g.printf("export const %s = %s;\n", definition.identifier, strconv.Quote(definition.name))
+
+	for _, relation := range definition.relations {
+		kind := "relation"
+		if relation.isPermission {
+			kind = "permission"
+		}
+		g.comment(fmt.Sprintf("The name of %s `%s` of definition `%s`.", kind, relation.name, definition.name), relation.comments)
+		g.printf("export const %s = %s;\n", relation.identifier, strconv.Quote(relation.name))
+	}
+}
+
+func (g *tsGenerator) generateSubjectTypes(definition definitionModel) {
+	for _, relation := range definition.relations {
+		if relation.isPermission {
+			continue
+		}
+
+		g.printf("\n/** The types of subjects allowed on relation `%s` of definition `%s`. */\n", relation.name, definition.name)
+		g.printf("export const %s: readonly %s[] = [\n", relation.subjectTypesIdentifier, subjectTypeStruct)
+		for _, subjectType := range relation.subjectTypes {
+			fields := []string{"definition: " + subjectType.definition}
+			if subjectType.relation != "" {
+				fields = append(fields, "relation: "+subjectType.relation)
+			}
+			if subjectType.wildcard {
+				fields = append(fields, "wildcard: true")
+			}
+			if subjectType.caveat != "" {
+				fields = append(fields, "caveat: "+subjectType.caveat)
+			}
+			g.printf("  { %s },\n", strings.Join(fields, ", "))
+		}
+		g.printf("];\n")
+	}
+}
+
+// tsType returns the TypeScript type for values of the given caveat parameter type, as found in
+// a caveat context.
+func tsType(typeRef *core.CaveatTypeReference) (string, error) {
+	switch typeRef.TypeName {
+	case "any":
+		return "unknown", nil
+	case "bool":
+		return "boolean", nil
+	case "int", "uint", "double":
+		return "number", nil
+	case "string", "ipaddress", "bytes", "duration", "timestamp":
+		return "string", nil
+	case "list", "map":
+		if len(typeRef.ChildTypes) != 1 {
+			return "", fmt.Errorf("expected a single child type for caveat parameter type `%s`", typeRef.TypeName)
+		}
+
+		childType, err := tsType(typeRef.ChildTypes[0])
+		if err != nil {
+			return "", err
+		}
+
+		if typeRef.TypeName == "map" {
+			return fmt.Sprintf("Record<string, %s>", childType), nil
+		}
+		return childType + "[]", nil
+	default:
+		return "", fmt.Errorf("unsupported caveat parameter type `%s`", typeRef.TypeName)
+	}
+}
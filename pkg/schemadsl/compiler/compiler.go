@@ -3,13 +3,13 @@ package compiler
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 
 	"google.golang.org/protobuf/proto"
 
 	core "github.com/authzed/spicedb/pkg/proto/core/v1"
 	"github.com/authzed/spicedb/pkg/schemadsl/dslshape"
 	"github.com/authzed/spicedb/pkg/schemadsl/input"
-	"github.com/authzed/spicedb/pkg/schemadsl/parser"
 )
 
 // InputSchema defines the input for a Compile.
@@ -44,10 +44,16 @@ type CompiledSchema struct {
 type config struct {
 	skipValidation   bool
 	objectTypePrefix *string
+	sourceFS         fs.FS
 }
 
 func SkipValidation() Option { return func(cfg *config) { cfg.skipValidation = true } }
 
+// SourceFS sets the file system used to resolve `import` statements in the schema. Import
+// paths are resolved relative to the directory of the importing file, with the Source of the
+// input schema used as the path of the root file.
+func SourceFS(sourceFS fs.FS) Option { return func(cfg *config) { cfg.sourceFS = sourceFS } }
+
 func ObjectTypePrefix(prefix string) ObjectPrefixOption {
 	return func(cfg *config) { cfg.objectTypePrefix = &prefix }
 }
@@ -74,31 +80,39 @@ func Compile(schema InputSchema, prefix ObjectPrefixOption, opts ...Option) (*Co
 	}
 
 	mapper := newPositionMapper(schema)
-	root := parser.Parse(createAstNode, schema.Source, schema.SchemaString).(*dslNode)
-	errs := root.FindAll(dslshape.NodeTypeError)
-	if len(errs) > 0 {
-		err := errorNodeToError(errs[0], mapper)
+	root, err := parseSchema(schema.Source, schema.SchemaString, mapper)
+	if err != nil {
 		return nil, err
 	}
 
+	roots, err := newImporter(cfg.sourceFS, mapper, schema.Source).resolve(root, schema.Source)
+	if err != nil {
+		return nil, withNodeContext(err, mapper)
+	}
+
 	compiled, err := translate(translationContext{
 		objectTypePrefix: cfg.objectTypePrefix,
 		mapper:           mapper,
 		schemaString:     schema.SchemaString,
 		skipValidate:     cfg.skipValidation,
-	}, root)
+	}, roots)
 	if err != nil {
-		var errorWithNode errorWithNode
-		if errors.As(err, &errorWithNode) {
-			err = toContextError(errorWithNode.error.Error(), errorWithNode.errorSourceCode, errorWithNode.node, mapper)
-		}
-
-		return nil, err
+		return nil, withNodeContext(err, mapper)
 	}
 
 	return compiled, nil
 }
 
+// withNodeContext converts an error raised against a specific node into an ErrorWithContext.
+func withNodeContext(err error, mapper input.PositionMapper) error {
+	var errorWithNode errorWithNode
+	if errors.As(err, &errorWithNode) {
+		return toContextError(errorWithNode.error.Error(), errorWithNode.errorSourceCode, errorWithNode.node, mapper)
+	}
+
+	return err
+}
+
 func errorNodeToError(node *dslNode, mapper input.PositionMapper) error {
 	if node.GetType() != dslshape.NodeTypeError {
 		return fmt.Errorf("given none error node")
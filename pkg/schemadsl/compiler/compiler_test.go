@@ -1,8 +1,10 @@
 package compiler
 
 import (
+	"io/fs"
 	"os"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -357,6 +359,72 @@ func TestCompile(t *testing.T) {
 			},
 		},
 
+		{
+			"arrow function permissions",
+			withTenantPrefix,
+			`definition arrowed {
+				permission foos = bars.any(bazs)
+				permission alls = bars.all(bazs)
+			}`,
+			"",
+			[]SchemaDefinition{
+				namespace.Namespace("sometenant/arrowed",
+					namespace.MustRelation("foos",
+						namespace.Union(
+							namespace.TupleToUserset("bars", "bazs"),
+						),
+					),
+					namespace.MustRelation("alls",
+						namespace.Union(
+							namespace.AllTupleToUserset("bars", "bazs"),
+						),
+					),
+				),
+			},
+		},
+
+		{
+			"materialized permission",
+			withTenantPrefix,
+			`definition simple {
+				relation viewer: user
+				materialized permission view = viewer
+			}`,
+			"",
+			[]SchemaDefinition{
+				namespace.Namespace("sometenant/simple",
+					namespace.MustRelation("viewer", nil,
+						namespace.AllowedRelation("sometenant/user", "..."),
+					),
+					namespace.MustMaterialized(namespace.MustRelation("view",
+						namespace.Union(
+							namespace.ComputedUserset("viewer"),
+						),
+					)),
+				),
+			},
+		},
+
+		{
+			"materialized relation",
+			withTenantPrefix,
+			`definition simple {
+				materialized relation viewer: user
+			}`,
+			"parse error in `materialized relation`, line 2, column 5: Expected end of statement or definition, found: TokenTypeIdentifier",
+			[]SchemaDefinition{},
+		},
+
+		{
+			"unknown arrow function",
+			withTenantPrefix,
+			`definition arrowed {
+				permission foos = bars.some(bazs)
+			}`,
+			"parse error in `unknown arrow function`, line 2, column 32: Expected `any` or `all` for arrow function, found: some",
+			[]SchemaDefinition{},
+		},
+
 		{
 			"multiarrow permission",
 			withTenantPrefix,
@@ -1035,3 +1103,150 @@ func TestSuperLargeCaveatCompile(t *testing.T) {
 	require.Equal(t, 29, len(compiled.ObjectDefinitions))
 	require.Equal(t, 1, len(compiled.CaveatDefinitions))
 }
+
+func TestCompileImports(t *testing.T) {
+	sourceFS := fstest.MapFS{
+		"common/user.zed": {Data: []byte(`definition user {}`)},
+		"common/group.zed": {Data: []byte(`import "user.zed"
+
+definition group {
+	relation member: user
+}`)},
+		"docs/document.zed": {Data: []byte(`import "../common/group.zed"
+import "../common/user.zed"
+
+definition document {
+	relation viewer: user | group#member
+}`)},
+		"cycle/a.zed": {Data: []byte(`import "b.zed"
+
+definition first {}`)},
+		"cycle/b.zed": {Data: []byte(`import "a.zed"
+
+definition second {}`)},
+		"broken/broken.zed": {Data: []byte(`definition broken {
+	relation foo: bar +
+}`)},
+		"duplicate/user.zed": {Data: []byte(`definition user {}`)},
+	}
+
+	tests := []struct {
+		name          string
+		source        string
+		input         string
+		sourceFS      fs.FS
+		expectedError string
+		expectedNames []string
+	}{
+		{
+			"no imports",
+			"schema.zed",
+			`definition user {}`,
+			sourceFS,
+			"",
+			[]string{"user"},
+		},
+		{
+			"simple import",
+			"schema.zed",
+			`import "common/user.zed"
+
+			definition document {
+				relation viewer: user
+			}`,
+			sourceFS,
+			"",
+			[]string{"user", "document"},
+		},
+		{
+			"transitive and shared imports",
+			"schema.zed",
+			`import "docs/document.zed"
+			import "common/user.zed"`,
+			sourceFS,
+			"",
+			[]string{"user", "group", "document"},
+		},
+		{
+			"import relative to root source",
+			"docs/schema.zed",
+			`import "document.zed"`,
+			sourceFS,
+			"",
+			[]string{"user", "group", "document"},
+		},
+		{
+			"import cycle",
+			"schema.zed",
+			`import "cycle/a.zed"`,
+			sourceFS,
+			"",
+			[]string{"second", "first"},
+		},
+		{
+			"missing file system",
+			"schema.zed",
+			`import "common/user.zed"`,
+			nil,
+			"parse error in `schema.zed`, line 1, column 1: cannot import `common/user.zed`: no file system was provided for resolving imports",
+			nil,
+		},
+		{
+			"missing file",
+			"schema.zed",
+			`import "common/missing.zed"`,
+			sourceFS,
+			"parse error in `schema.zed`, line 1, column 1: could not read imported file `common/missing.zed`: open common/missing.zed: file does not exist",
+			nil,
+		},
+		{
+			"escaping import",
+			"schema.zed",
+			`import "../outside.zed"`,
+			sourceFS,
+			"parse error in `schema.zed`, line 1, column 1: invalid import path `../outside.zed`: imports must be relative and cannot escape the schema root",
+			nil,
+		},
+		{
+			"parse error in imported file",
+			"schema.zed",
+			`import "broken/broken.zed"`,
+			sourceFS,
+			"parse error in `broken/broken.zed`, line 2, column 20: Expected end of statement or definition, found: TokenTypePlus",
+			nil,
+		},
+		{
+			"duplicate definition across files",
+			"schema.zed",
+			`import "common/user.zed"
+			import "duplicate/user.zed"`,
+			sourceFS,
+			"parse error in `duplicate/user.zed`, line 1, column 1: found name reused between multiple definitions and/or caveats: user",
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			compiled, err := Compile(InputSchema{
+				input.Source(test.source), test.input,
+			}, AllowUnprefixedObjectType(), SourceFS(test.sourceFS))
+			if test.expectedError != "" {
+				require.Error(err)
+				require.Equal(test.expectedError, err.Error())
+				return
+			}
+
+			require.NoError(err)
+
+			names := make([]string, 0, len(compiled.OrderedDefinitions))
+			for _, def := range compiled.OrderedDefinitions {
+				names = append(names, def.GetName())
+			}
+			require.Equal(test.expectedNames, names)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package compiler
+
+import (
+	"io/fs"
+	"path"
+
+	"github.com/authzed/spicedb/pkg/genutil/mapz"
+	"github.com/authzed/spicedb/pkg/schemadsl/dslshape"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+	"github.com/authzed/spicedb/pkg/schemadsl/parser"
+)
+
+// importer resolves the `import` statements found in a schema, parsing each imported file
+// from the configured file system.
+type importer struct {
+	sourceFS fs.FS
+	mapper   *positionMapper
+
+	// imported holds the paths of all files already parsed. Each file is only ever
+	// parsed once, which also breaks any import cycles.
+	imported *mapz.Set[string]
+}
+
+func newImporter(sourceFS fs.FS, mapper *positionMapper, rootSource input.Source) *importer {
+	imported := mapz.NewSet[string]()
+	imported.Add(path.Clean(string(rootSource)))
+
+	return &importer{
+		sourceFS: sourceFS,
+		mapper:   mapper,
+		imported: imported,
+	}
+}
+
+// resolve returns the root nodes of all files transitively imported by the given root node,
+// in dependency order, followed by the root node itself.
+func (imp *importer) resolve(root *dslNode, source input.Source) ([]*dslNode, error) {
+	var roots []*dslNode
+	for _, childNode := range root.GetChildren() {
+		if childNode.GetType() != dslshape.NodeTypeImport {
+			continue
+		}
+
+		importPath, err := childNode.GetString(dslshape.NodeImportPredicatePath)
+		if err != nil {
+			return nil, childNode.Errorf("invalid import path: %w", err)
+		}
+
+		if imp.sourceFS == nil {
+			return nil, childNode.ErrorWithSourcef(importPath, "cannot import `%s`: no file system was provided for resolving imports", importPath)
+		}
+
+		// Imports are resolved relative to the directory of the importing file.
+		resolvedPath := path.Join(path.Dir(string(source)), importPath)
+		if !fs.ValidPath(resolvedPath) {
+			return nil, childNode.ErrorWithSourcef(importPath, "invalid import path `%s`: imports must be relative and cannot escape the schema root", importPath)
+		}
+
+		if !imp.imported.Add(resolvedPath) {
+			continue
+		}
+
+		contents, err := fs.ReadFile(imp.sourceFS, resolvedPath)
+		if err != nil {
+			return nil, childNode.ErrorWithSourcef(importPath, "could not read imported file `%s`: %w", importPath, err)
+		}
+
+		importedSource := input.Source(resolvedPath)
+		imp.mapper.addSource(importedSource, string(contents))
+
+		importedRoot, err := parseSchema(importedSource, string(contents), imp.mapper)
+		if err != nil {
+			return nil, err
+		}
+
+		importedRoots, err := imp.resolve(importedRoot, importedSource)
+		if err != nil {
+			return nil, err
+		}
+
+		roots = append(roots, importedRoots...)
+	}
+
+	return append(roots, root), nil
+}
+
+// parseSchema parses the given schema contents, returning an error if any parse errors were found.
+func parseSchema(source input.Source, schemaString string, mapper input.PositionMapper) (*dslNode, error) {
+	root := parser.Parse(createAstNode, source, schemaString).(*dslNode)
+	errs := root.FindAll(dslshape.NodeTypeError)
+	if len(errs) > 0 {
+		return nil, errorNodeToError(errs[0], mapper)
+	}
+
+	return root, nil
+}
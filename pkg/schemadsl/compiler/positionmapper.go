@@ -1,32 +1,66 @@
 package compiler
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/authzed/spicedb/pkg/schemadsl/input"
 )
 
 type positionMapper struct {
-	schema InputSchema
-	mapper input.SourcePositionMapper
+	schemas map[input.Source]string
+	mappers map[input.Source]input.SourcePositionMapper
 }
 
-func newPositionMapper(schema InputSchema) input.PositionMapper {
-	return &positionMapper{
-		schema: schema,
-		mapper: input.CreateSourcePositionMapper([]byte(schema.SchemaString)),
+func newPositionMapper(schema InputSchema) *positionMapper {
+	pm := &positionMapper{
+		schemas: map[input.Source]string{},
+		mappers: map[input.Source]input.SourcePositionMapper{},
 	}
+	pm.addSource(schema.Source, schema.SchemaString)
+	return pm
 }
 
-func (pm *positionMapper) RunePositionToLineAndCol(runePosition int, _ input.Source) (int, int, error) {
-	return pm.mapper.RunePositionToLineAndCol(runePosition)
+// addSource registers the contents of an additional source, such as an imported file,
+// with the mapper.
+func (pm *positionMapper) addSource(source input.Source, schemaString string) {
+	pm.schemas[source] = schemaString
+	pm.mappers[source] = input.CreateSourcePositionMapper([]byte(schemaString))
 }
 
-func (pm *positionMapper) LineAndColToRunePosition(lineNumber int, colPosition int, _ input.Source) (int, error) {
-	return pm.mapper.LineAndColToRunePosition(lineNumber, colPosition)
+func (pm *positionMapper) mapperFor(source input.Source) (input.SourcePositionMapper, error) {
+	mapper, ok := pm.mappers[source]
+	if !ok {
+		return input.SourcePositionMapper{}, fmt.Errorf("unknown source `%s`", source)
+	}
+
+	return mapper, nil
+}
+
+func (pm *positionMapper) RunePositionToLineAndCol(runePosition int, source input.Source) (int, int, error) {
+	mapper, err := pm.mapperFor(source)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return mapper.RunePositionToLineAndCol(runePosition)
 }
 
-func (pm *positionMapper) TextForLine(lineNumber int, _ input.Source) (string, error) {
-	lines := strings.Split(pm.schema.SchemaString, "\n")
+func (pm *positionMapper) LineAndColToRunePosition(lineNumber int, colPosition int, source input.Source) (int, error) {
+	mapper, err := pm.mapperFor(source)
+	if err != nil {
+		return 0, err
+	}
+
+	return mapper.LineAndColToRunePosition(lineNumber, colPosition)
+}
+
+func (pm *positionMapper) TextForLine(lineNumber int, source input.Source) (string, error) {
+	schemaString, ok := pm.schemas[source]
+	if !ok {
+		return "", fmt.Errorf("unknown source `%s`", source)
+	}
+
+	lines := strings.Split(schemaString, "\n")
 	return lines[lineNumber], nil
 }
@@ -42,17 +42,26 @@ func (tctx translationContext) prefixedPath(definitionName string) (string, erro
 
 const Ellipsis = "..."
 
-func translate(tctx translationContext, root *dslNode) (*CompiledSchema, error) {
-	orderedDefinitions := make([]SchemaDefinition, 0, len(root.GetChildren()))
+func translate(tctx translationContext, roots []*dslNode) (*CompiledSchema, error) {
+	var definitionNodes []*dslNode
+	for _, root := range roots {
+		definitionNodes = append(definitionNodes, root.GetChildren()...)
+	}
+
+	orderedDefinitions := make([]SchemaDefinition, 0, len(definitionNodes))
 	var objectDefinitions []*core.NamespaceDefinition
 	var caveatDefinitions []*core.CaveatDefinition
 
 	names := mapz.NewSet[string]()
 
-	for _, definitionNode := range root.GetChildren() {
+	for _, definitionNode := range definitionNodes {
 		var definition SchemaDefinition
 
 		switch definitionNode.GetType() {
+		case dslshape.NodeTypeImport:
+			// Imports are resolved before translation.
+			continue
+
 		case dslshape.NodeTypeCaveatDefinition:
 			def, err := translateCaveatDefinition(tctx, definitionNode)
 			if err != nil {
@@ -362,6 +371,12 @@ func translatePermission(tctx translationContext, permissionNode *dslNode) (*cor
 		return nil, err
 	}
 
+	if permissionNode.Has(dslshape.NodePermissionPredicateMaterialized) {
+		if err := namespace.SetMaterialized(permission); err != nil {
+			return nil, permissionNode.Errorf("error in permission %s: %w", permissionName, err)
+		}
+	}
+
 	if !tctx.skipValidate {
 		if err := permission.Validate(); err != nil {
 			return nil, permissionNode.Errorf("error in permission %s: %w", permissionName, err)
@@ -492,6 +507,9 @@ func translateExpressionOperationDirect(tctx translationContext, expressionOpNod
 	case dslshape.NodeTypeNilExpression:
 		return namespace.Nil(), nil
 
+	case dslshape.NodeTypeFunctionedArrowExpression:
+		fallthrough
+
 	case dslshape.NodeTypeArrowExpression:
 		leftChild, err := expressionOpNode.Lookup(dslshape.NodeExpressionPredicateLeftExpr)
 		if err != nil {
@@ -517,7 +535,25 @@ func translateExpressionOperationDirect(tctx translationContext, expressionOpNod
 			return nil, err
 		}
 
-		return namespace.TupleToUserset(tuplesetRelation, usersetRelation), nil
+		if expressionOpNode.GetType() == dslshape.NodeTypeArrowExpression {
+			return namespace.TupleToUserset(tuplesetRelation, usersetRelation), nil
+		}
+
+		functionName, err := expressionOpNode.GetString(dslshape.NodeFunctionedArrowExpressionPredicateFunctionName)
+		if err != nil {
+			return nil, err
+		}
+
+		switch functionName {
+		case "any":
+			return namespace.TupleToUserset(tuplesetRelation, usersetRelation), nil
+
+		case "all":
+			return namespace.AllTupleToUserset(tuplesetRelation, usersetRelation), nil
+
+		default:
+			return nil, expressionOpNode.Errorf("unknown arrow function `%s`", functionName)
+		}
 
 	case dslshape.NodeTypeUnionExpression:
 		fallthrough
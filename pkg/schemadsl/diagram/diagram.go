@@ -0,0 +1,269 @@
+package diagram
+
+import (
+	"context"
+	"fmt"
+
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/tuple"
+	"github.com/authzed/spicedb/pkg/typesystem"
+)
+
+// Format is a format in which the diagram of a schema can be generated.
+type Format string
+
+const (
+	// DOT is the Graphviz DOT format.
+	DOT Format = "dot"
+
+	// Mermaid is the format of Mermaid flowcharts.
+	Mermaid Format = "mermaid"
+)
+
+// Generate generates a diagram of the schema in the given format, showing its definitions, their
+// relations and permissions, the subject types allowed on each relation, the relations and
+// permissions referenced by each permission, including through arrows, and the types of subjects
+// which can reach each permission.
+func Generate(ctx context.Context, compiled *compiler.CompiledSchema, format Format) (string, error) {
+	switch format {
+	case DOT, Mermaid:
+	default:
+		return "", fmt.Errorf("unsupported diagram format `%s`", format)
+	}
+
+	g, err := buildGraph(ctx, compiled)
+	if err != nil {
+		return "", err
+	}
+
+	if format == DOT {
+		return generateDOT(g), nil
+	}
+	return generateMermaid(g), nil
+}
+
+// graph is the diagram of a schema, independent of its format.
+type graph struct {
+	definitions []definitionNode
+	edges       []edge
+}
+
+type definitionNode struct {
+	name      string
+	relations []relationNode
+}
+
+type relationNode struct {
+	name         string
+	isPermission bool
+
+	// reachableBy are the names of the definitions of the subjects which can reach the
+	// permission; empty for relations.
+	reachableBy []string
+}
+
+type edgeKind int
+
+const (
+	// subjectTypeEdge goes from a relation to a type of subject allowed on it.
+	subjectTypeEdge edgeKind = iota
+
+	// computedEdge goes from a permission to a relation or permission of the same definition
+	// which it references.
+	computedEdge
+
+	// arrowEdge goes from a permission to a relation or permission reached through an arrow.
+	arrowEdge
+)
+
+// edge is an edge between two nodes, identified by the name of their definition and, for
+// relations and permissions, their own name.
+type edge struct {
+	kind         edgeKind
+	fromDef      string
+	fromRelation string
+	toDef        string
+	toRelation   string
+	label        string
+}
+
+func buildGraph(ctx context.Context, compiled *compiler.CompiledSchema) (*graph, error) {
+	resolver := typesystem.ResolverForPredefinedDefinitions(typesystem.PredefinedElements{
+		Namespaces: compiled.ObjectDefinitions,
+		Caveats:    compiled.CaveatDefinitions,
+	})
+
+	relationsByDef := make(map[string]map[string]struct{}, len(compiled.ObjectDefinitions))
+	for _, def := range compiled.ObjectDefinitions {
+		relations := make(map[string]struct{}, len(def.Relation))
+		for _, relation := range def.Relation {
+			relations[relation.Name] = struct{}{}
+		}
+		relationsByDef[def.Name] = relations
+	}
+
+	g := &graph{}
+	seenEdges := make(map[edge]struct{})
+	addEdge := func(e edge) {
+		if _, ok := seenEdges[e]; !ok {
+			seenEdges[e] = struct{}{}
+			g.edges = append(g.edges, e)
+		}
+	}
+
+	for _, def := range compiled.ObjectDefinitions {
+		ts, err := typesystem.NewNamespaceTypeSystem(def, resolver)
+		if err != nil {
+			return nil, err
+		}
+
+		vts, err := ts.Validate(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		rg := typesystem.ReachabilityGraphFor(vts)
+		dn := definitionNode{name: def.Name}
+		for _, relation := range def.Relation {
+			rn := relationNode{name: relation.Name, isPermission: ts.IsPermission(relation.Name)}
+			if !rn.isPermission {
+				allowed, err := ts.AllowedDirectRelationsAndWildcards(relation.Name)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, allowedRelation := range allowed {
+					e := edge{
+						kind:         subjectTypeEdge,
+						fromDef:      def.Name,
+						fromRelation: relation.Name,
+						toDef:        allowedRelation.Namespace,
+					}
+					if subjectRelation := allowedRelation.GetRelation(); subjectRelation != tuple.Ellipsis {
+						e.toRelation = subjectRelation
+					}
+					if allowedRelation.GetPublicWildcard() != nil {
+						e.label = "*"
+					}
+					if caveat := allowedRelation.GetRequiredCaveat(); caveat != nil {
+						e.label = joinLabel(e.label, "with "+caveat.CaveatName)
+					}
+					addEdge(e)
+				}
+
+				dn.relations = append(dn.relations, rn)
+				continue
+			}
+
+			// Find the definitions of the subjects which can reach the permission.
+			for _, subjectDef := range compiled.ObjectDefinitions {
+				entrypoints, err := rg.AllEntrypointsForSubjectToResource(ctx,
+					&core.RelationReference{Namespace: subjectDef.Name, Relation: tuple.Ellipsis},
+					&core.RelationReference{Namespace: def.Name, Relation: relation.Name},
+				)
+				if err != nil {
+					return nil, err
+				}
+				if len(entrypoints) > 0 {
+					rn.reachableBy = append(rn.reachableBy, subjectDef.Name)
+				}
+			}
+
+			err := walkRewrite(relation.UsersetRewrite, "", func(child *core.SetOperation_Child, label string) error {
+				switch child := child.ChildType.(type) {
+				case *core.SetOperation_Child_ComputedUserset:
+					addEdge(edge{
+						kind:         computedEdge,
+						fromDef:      def.Name,
+						fromRelation: relation.Name,
+						toDef:        def.Name,
+						toRelation:   child.ComputedUserset.Relation,
+						label:        label,
+					})
+
+				case *core.SetOperation_Child_TupleToUserset:
+					tuplesetRelation := child.TupleToUserset.GetTupleset().GetRelation()
+					computedRelation := child.TupleToUserset.GetComputedUserset().GetRelation()
+
+					allowed, err := ts.AllowedDirectRelationsAndWildcards(tuplesetRelation)
+					if err != nil {
+						return err
+					}
+
+					for _, allowedRelation := range allowed {
+						if _, ok := relationsByDef[allowedRelation.Namespace][computedRelation]; !ok {
+							continue
+						}
+
+						addEdge(edge{
+							kind:         arrowEdge,
+							fromDef:      def.Name,
+							fromRelation: relation.Name,
+							toDef:        allowedRelation.Namespace,
+							toRelation:   computedRelation,
+							label:        joinLabel(label, tuplesetRelation+"->"+computedRelation),
+						})
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			dn.relations = append(dn.relations, rn)
+		}
+
+		g.definitions = append(g.definitions, dn)
+	}
+
+	return g, nil
+}
+
+// walkRewrite invokes the handler for each of the children of the rewrite, recursively, with the
+// label of the operator applied to the child: `&` for intersections and `-` for the excluded
+// children of exclusions.
+func walkRewrite(rewrite *core.UsersetRewrite, label string, handler func(child *core.SetOperation_Child, label string) error) error {
+	var children []*core.SetOperation_Child
+	childLabel := func(int) string { return label }
+
+	switch operation := rewrite.GetRewriteOperation().(type) {
+	case *core.UsersetRewrite_Union:
+		children = operation.Union.Child
+
+	case *core.UsersetRewrite_Intersection:
+		children = operation.Intersection.Child
+		childLabel = func(int) string { return "&" }
+
+	case *core.UsersetRewrite_Exclusion:
+		children = operation.Exclusion.Child
+		childLabel = func(index int) string {
+			if index > 0 {
+				return "-"
+			}
+			return label
+		}
+	}
+
+	for index, child := range children {
+		if nested := child.GetUsersetRewrite(); nested != nil {
+			if err := walkRewrite(nested, childLabel(index), handler); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := handler(child, childLabel(index)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func joinLabel(first string, second string) string {
+	if first == "" {
+		return second
+	}
+	return first + " " + second
+}
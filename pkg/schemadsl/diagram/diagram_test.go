@@ -0,0 +1,149 @@
+package diagram
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+)
+
+const testSchema = `
+caveat ip_allowed(user_ip ipaddress) {
+	user_ip.in_cidr('10.0.0.0/8')
+}
+
+definition user {}
+
+definition team {
+	relation member: user | team#member
+}
+
+definition folder {
+	relation viewer: user
+	permission view = viewer
+}
+
+definition document {
+	relation folder: folder
+	relation viewer: user | user:* | team#member | user with ip_allowed
+	relation banned: user
+	permission view = (viewer + folder->view) - banned
+}`
+
+func TestGenerate(t *testing.T) {
+	tcs := []struct {
+		format   Format
+		expected string
+	}{
+		{
+			DOT,
+			`digraph schema {
+  rankdir=LR;
+  node [fontname="Helvetica"];
+  edge [fontname="Helvetica", fontsize=10];
+
+  subgraph "cluster_user" {
+    label="definition user";
+    "user" [label="user", shape=box, style=bold];
+  }
+
+  subgraph "cluster_team" {
+    label="definition team";
+    "team" [label="team", shape=box, style=bold];
+    "team#member" [label="member", shape=box, style=rounded];
+  }
+
+  subgraph "cluster_folder" {
+    label="definition folder";
+    "folder" [label="folder", shape=box, style=bold];
+    "folder#viewer" [label="viewer", shape=box, style=rounded];
+    "folder#view" [label="view\n(user)", shape=ellipse];
+  }
+
+  subgraph "cluster_document" {
+    label="definition document";
+    "document" [label="document", shape=box, style=bold];
+    "document#folder" [label="folder", shape=box, style=rounded];
+    "document#viewer" [label="viewer", shape=box, style=rounded];
+    "document#banned" [label="banned", shape=box, style=rounded];
+    "document#view" [label="view\n(user)", shape=ellipse];
+  }
+
+  "team#member" -> "user";
+  "team#member" -> "team#member";
+  "folder#viewer" -> "user";
+  "folder#view" -> "folder#viewer" [style=dashed];
+  "document#folder" -> "folder";
+  "document#viewer" -> "user";
+  "document#viewer" -> "user" [label="*"];
+  "document#viewer" -> "team#member";
+  "document#viewer" -> "user" [label="with ip_allowed"];
+  "document#banned" -> "user";
+  "document#view" -> "document#viewer" [style=dashed];
+  "document#view" -> "folder#view" [style=dotted, label="folder->view"];
+  "document#view" -> "document#banned" [style=dashed, label="-"];
+}
+`,
+		},
+		{
+			Mermaid,
+			`flowchart LR
+  subgraph d0_definition["definition user"]
+    d0["user"]
+  end
+  subgraph d1_definition["definition team"]
+    d1["team"]
+    d1r0("member")
+  end
+  subgraph d2_definition["definition folder"]
+    d2["folder"]
+    d2r0("viewer")
+    d2r1(["view<br/>(user)"])
+  end
+  subgraph d3_definition["definition document"]
+    d3["document"]
+    d3r0("folder")
+    d3r1("viewer")
+    d3r2("banned")
+    d3r3(["view<br/>(user)"])
+  end
+  d1r0 --> d0
+  d1r0 --> d1r0
+  d2r0 --> d0
+  d2r1 -.-> d2r0
+  d3r0 --> d2
+  d3r1 --> d0
+  d3r1 -->|"*"| d0
+  d3r1 --> d1r0
+  d3r1 -->|"with ip_allowed"| d0
+  d3r2 --> d0
+  d3r3 -.-> d3r1
+  d3r3 ==>|"folder->view"| d2r1
+  d3r3 -.->|"-"| d3r2
+`,
+		},
+	}
+
+	compiled, err := compiler.Compile(compiler.InputSchema{
+		Source:       input.Source("schema"),
+		SchemaString: testSchema,
+	}, compiler.AllowUnprefixedObjectType())
+	require.NoError(t, err)
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(string(tc.format), func(t *testing.T) {
+			generated, err := Generate(context.Background(), compiled, tc.format)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, generated)
+		})
+	}
+}
+
+func TestGenerateUnsupportedFormat(t *testing.T) {
+	_, err := Generate(context.Background(), &compiler.CompiledSchema{}, Format("svg"))
+	require.EqualError(t, err, "unsupported diagram format `svg`")
+}
@@ -0,0 +1,70 @@
+package diagram
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// generateDOT generates the diagram in the Graphviz DOT format. Each definition is a cluster
+// containing a node for the definition itself and a node for each of its relations and
+// permissions.
+func generateDOT(g *graph) string {
+	var sb strings.Builder
+	sb.WriteString("digraph schema {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [fontname=\"Helvetica\"];\n")
+	sb.WriteString("  edge [fontname=\"Helvetica\", fontsize=10];\n")
+
+	for _, def := range g.definitions {
+		fmt.Fprintf(&sb, "\n  subgraph %s {\n", strconv.Quote("cluster_"+def.name))
+		fmt.Fprintf(&sb, "    label=%s;\n", strconv.Quote("definition "+def.name))
+		fmt.Fprintf(&sb, "    %s [label=%s, shape=box, style=bold];\n", dotNodeID(def.name, ""), strconv.Quote(def.name))
+		for _, relation := range def.relations {
+			if relation.isPermission {
+				label := relation.name
+				if len(relation.reachableBy) > 0 {
+					label += "\n(" + strings.Join(relation.reachableBy, ", ") + ")"
+				}
+				fmt.Fprintf(&sb, "    %s [label=%s, shape=ellipse];\n", dotNodeID(def.name, relation.name), strconv.Quote(label))
+			} else {
+				fmt.Fprintf(&sb, "    %s [label=%s, shape=box, style=rounded];\n", dotNodeID(def.name, relation.name), strconv.Quote(relation.name))
+			}
+		}
+		sb.WriteString("  }\n")
+	}
+
+	if len(g.edges) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, e := range g.edges {
+		var attributes []string
+		switch e.kind {
+		case computedEdge:
+			attributes = append(attributes, "style=dashed")
+		case arrowEdge:
+			attributes = append(attributes, "style=dotted")
+		}
+		if e.label != "" {
+			attributes = append(attributes, "label="+strconv.Quote(e.label))
+		}
+
+		fmt.Fprintf(&sb, "  %s -> %s", dotNodeID(e.fromDef, e.fromRelation), dotNodeID(e.toDef, e.toRelation))
+		if len(attributes) > 0 {
+			fmt.Fprintf(&sb, " [%s]", strings.Join(attributes, ", "))
+		}
+		sb.WriteString(";\n")
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func dotNodeID(def string, relation string) string {
+	if relation == "" {
+		return strconv.Quote(def)
+	}
+	return strconv.Quote(tuple.JoinRelRef(def, relation))
+}
@@ -0,0 +1,62 @@
+package diagram
+
+import (
+	"fmt"
+	"strings"
+)
+
+// generateMermaid generates the diagram as a Mermaid flowchart. Each definition is a subgraph
+// containing a node for the definition itself and a node for each of its relations and
+// permissions. As names may contain characters not allowed in Mermaid identifiers, nodes are
+// identified by their position in the schema.
+func generateMermaid(g *graph) string {
+	ids := make(map[string]string)
+	nodeID := func(def string, relation string) string {
+		return ids[def+"#"+relation]
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for defIndex, def := range g.definitions {
+		defID := fmt.Sprintf("d%d", defIndex)
+		ids[def.name+"#"] = defID
+
+		fmt.Fprintf(&sb, "  subgraph %s_definition[%s]\n", defID, mermaidLabel("definition "+def.name))
+		fmt.Fprintf(&sb, "    %s[%s]\n", defID, mermaidLabel(def.name))
+		for relationIndex, relation := range def.relations {
+			relationID := fmt.Sprintf("%sr%d", defID, relationIndex)
+			ids[def.name+"#"+relation.name] = relationID
+
+			if relation.isPermission {
+				label := relation.name
+				if len(relation.reachableBy) > 0 {
+					label += "<br/>(" + strings.Join(relation.reachableBy, ", ") + ")"
+				}
+				fmt.Fprintf(&sb, "    %s([%s])\n", relationID, mermaidLabel(label))
+			} else {
+				fmt.Fprintf(&sb, "    %s(%s)\n", relationID, mermaidLabel(relation.name))
+			}
+		}
+		sb.WriteString("  end\n")
+	}
+
+	for _, e := range g.edges {
+		link := "-->"
+		switch e.kind {
+		case computedEdge:
+			link = "-.->"
+		case arrowEdge:
+			link = "==>"
+		}
+		if e.label != "" {
+			link += "|" + mermaidLabel(e.label) + "|"
+		}
+		fmt.Fprintf(&sb, "  %s %s %s\n", nodeID(e.fromDef, e.fromRelation), link, nodeID(e.toDef, e.toRelation))
+	}
+	return sb.String()
+}
+
+// mermaidLabel returns the given text as a quoted Mermaid label.
+func mermaidLabel(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,475 @@
+package docgen
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/authzed/spicedb/pkg/caveats"
+	caveattypes "github.com/authzed/spicedb/pkg/caveats/types"
+	nspkg "github.com/authzed/spicedb/pkg/namespace"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/tuple"
+	"github.com/authzed/spicedb/pkg/typesystem"
+)
+
+// Format is a format in which the documentation of a schema can be generated.
+type Format string
+
+const (
+	// Markdown is the Markdown format.
+	Markdown Format = "markdown"
+
+	// HTML is the HTML format.
+	HTML Format = "html"
+)
+
+// Page is a page of the generated documentation.
+type Page struct {
+	// Path is the path of the page, relative to the root of the documentation.
+	Path string
+
+	// Content is the content of the page.
+	Content string
+}
+
+// Generate generates the reference documentation of the schema in the given format: an index page
+// listing the definitions and caveats, and a page for each definition and caveat, with the doc
+// comments found in the schema, the subject types allowed on each relation, the expression of
+// each permission, and the parameters of each caveat, linked to each other.
+func Generate(ctx context.Context, compiled *compiler.CompiledSchema, format Format) ([]Page, error) {
+	var newWriter func(title string) writer
+	var extension string
+	switch format {
+	case Markdown:
+		newWriter = newMarkdownWriter
+		extension = ".md"
+	case HTML:
+		newWriter = newHTMLWriter
+		extension = ".html"
+	default:
+		return nil, fmt.Errorf("unsupported documentation format `%s`", format)
+	}
+
+	g := &generator{
+		compiled:  compiled,
+		newWriter: newWriter,
+		extension: extension,
+		usedBy:    make(map[string][]segment),
+	}
+	return g.generate(ctx)
+}
+
+type generator struct {
+	compiled  *compiler.CompiledSchema
+	newWriter func(title string) writer
+	extension string
+
+	// usedBy are the links to the relations which require each caveat, by caveat name.
+	usedBy map[string][]segment
+}
+
+const indexPath = "index"
+
+func (g *generator) generate(ctx context.Context) ([]Page, error) {
+	resolver := typesystem.ResolverForPredefinedDefinitions(typesystem.PredefinedElements{
+		Namespaces: g.compiled.ObjectDefinitions,
+		Caveats:    g.compiled.CaveatDefinitions,
+	})
+
+	pages := make([]Page, 0, 1+len(g.compiled.ObjectDefinitions)+len(g.compiled.CaveatDefinitions))
+	pages = append(pages, g.generateIndex())
+
+	for _, def := range g.compiled.ObjectDefinitions {
+		ts, err := typesystem.NewNamespaceTypeSystem(def, resolver)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := ts.Validate(ctx); err != nil {
+			return nil, err
+		}
+
+		page, err := g.generateDefinition(def, ts)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+	}
+
+	// Caveat pages are generated last, to link to the relations using them.
+	for _, caveatDef := range g.compiled.CaveatDefinitions {
+		page, err := g.generateCaveat(caveatDef)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+	}
+
+	return pages, nil
+}
+
+func (g *generator) generateIndex() Page {
+	w := g.newWriter("Schema")
+	w.heading(1, "", text("Schema"))
+
+	if len(g.compiled.ObjectDefinitions) > 0 {
+		w.heading(2, "", text("Definitions"))
+		items := make([][]segment, 0, len(g.compiled.ObjectDefinitions))
+		for _, def := range g.compiled.ObjectDefinitions {
+			items = append(items, summaryItem(
+				link(def.Name, g.href(indexPath, definitionPath(def.Name), "")),
+				nspkg.GetCommentLines(def.Metadata),
+			))
+		}
+		w.list(items)
+	}
+
+	if len(g.compiled.CaveatDefinitions) > 0 {
+		w.heading(2, "", text("Caveats"))
+		items := make([][]segment, 0, len(g.compiled.CaveatDefinitions))
+		for _, caveatDef := range g.compiled.CaveatDefinitions {
+			items = append(items, summaryItem(
+				link(caveatDef.Name, g.href(indexPath, caveatPath(caveatDef.Name), "")),
+				nspkg.GetCommentLines(caveatDef.Metadata),
+			))
+		}
+		w.list(items)
+	}
+
+	return Page{Path: indexPath + g.extension, Content: w.String()}
+}
+
+// summaryItem returns a list item made of the given link followed by the first paragraph of the
+// comments, if any.
+func summaryItem(linked segment, commentLines []string) []segment {
+	paragraphs := paragraphs(commentLines)
+	if len(paragraphs) == 0 {
+		return []segment{linked}
+	}
+	return []segment{linked, text(": " + strings.ReplaceAll(paragraphs[0], "\n", " "))}
+}
+
+func (g *generator) generateDefinition(def *core.NamespaceDefinition, ts *typesystem.TypeSystem) (Page, error) {
+	pagePath := definitionPath(def.Name)
+
+	w := g.newWriter("definition " + def.Name)
+	w.heading(1, "", text("definition "), code(def.Name))
+	w.paragraph(textLink("Back to the index", g.href(pagePath, indexPath, "")))
+	g.writeComments(w, def.Metadata)
+
+	var relations, permissions []*core.Relation
+	for _, relation := range def.Relation {
+		if ts.IsPermission(relation.Name) {
+			permissions = append(permissions, relation)
+		} else {
+			relations = append(relations, relation)
+		}
+	}
+
+	if len(relations) > 0 {
+		w.heading(2, "", text("Relations"))
+	}
+	for _, relation := range relations {
+		w.heading(3, relation.Name, text("relation "), code(relation.Name))
+		g.writeComments(w, relation.Metadata)
+
+		allowed, err := ts.AllowedDirectRelationsAndWildcards(relation.Name)
+		if err != nil {
+			return Page{}, err
+		}
+
+		w.paragraph(text("Allowed subject types:"))
+		items := make([][]segment, 0, len(allowed))
+		for _, allowedRelation := range allowed {
+			subjectType := allowedRelation.Namespace
+			subjectHref := g.href(pagePath, definitionPath(allowedRelation.Namespace), "")
+			if subjectRelation := allowedRelation.GetRelation(); subjectRelation != "" && subjectRelation != tuple.Ellipsis {
+				subjectType = tuple.JoinRelRef(allowedRelation.Namespace, subjectRelation)
+				subjectHref = g.href(pagePath, definitionPath(allowedRelation.Namespace), subjectRelation)
+			}
+			if allowedRelation.GetPublicWildcard() != nil {
+				subjectType += ":*"
+			}
+
+			item := []segment{link(subjectType, subjectHref)}
+			if caveat := allowedRelation.GetRequiredCaveat(); caveat != nil {
+				item = append(item, text(" with caveat "), link(caveat.CaveatName, g.href(pagePath, caveatPath(caveat.CaveatName), "")))
+				g.usedBy[caveat.CaveatName] = append(g.usedBy[caveat.CaveatName], link(
+					tuple.JoinRelRef(def.Name, relation.Name),
+					g.href(caveatPath(caveat.CaveatName), pagePath, relation.Name),
+				))
+			}
+			items = append(items, item)
+		}
+		w.list(items)
+	}
+
+	if len(permissions) > 0 {
+		w.heading(2, "", text("Permissions"))
+	}
+	for _, permission := range permissions {
+		w.heading(3, permission.Name, text("permission "), code(permission.Name))
+		g.writeComments(w, permission.Metadata)
+
+		w.paragraph(append([]segment{text("Expression: ")}, g.expression(permission.UsersetRewrite)...)...)
+
+		var arrowTargets [][]segment
+		seenTargets := make(map[string]struct{})
+		err := walkArrows(permission.UsersetRewrite, func(ttu *core.TupleToUserset) error {
+			allowed, err := ts.AllowedDirectRelationsAndWildcards(ttu.GetTupleset().GetRelation())
+			if err != nil {
+				return err
+			}
+
+			for _, allowedRelation := range allowed {
+				target, ok := g.relation(allowedRelation.Namespace, ttu.GetComputedUserset().GetRelation())
+				if !ok {
+					continue
+				}
+
+				// The same definition may be allowed more than once, e.g. with different caveats.
+				key := arrowSource(ttu) + "=>" + tuple.JoinRelRef(allowedRelation.Namespace, target.Name)
+				if _, ok := seenTargets[key]; ok {
+					continue
+				}
+				seenTargets[key] = struct{}{}
+
+				arrowTargets = append(arrowTargets, []segment{
+					code(arrowSource(ttu)),
+					text(" reaches "),
+					link(tuple.JoinRelRef(allowedRelation.Namespace, target.Name), g.href(pagePath, definitionPath(allowedRelation.Namespace), target.Name)),
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return Page{}, err
+		}
+
+		if len(arrowTargets) > 0 {
+			w.paragraph(text("Arrows:"))
+			w.list(arrowTargets)
+		}
+	}
+
+	return Page{Path: pagePath + g.extension, Content: w.String()}, nil
+}
+
+func (g *generator) generateCaveat(caveatDef *core.CaveatDefinition) (Page, error) {
+	pagePath := caveatPath(caveatDef.Name)
+
+	w := g.newWriter("caveat " + caveatDef.Name)
+	w.heading(1, "", text("caveat "), code(caveatDef.Name))
+	w.paragraph(textLink("Back to the index", g.href(pagePath, indexPath, "")))
+	g.writeComments(w, caveatDef.Metadata)
+
+	parameterNames := make([]string, 0, len(caveatDef.ParameterTypes))
+	for parameterName := range caveatDef.ParameterTypes {
+		parameterNames = append(parameterNames, parameterName)
+	}
+	sort.Strings(parameterNames)
+
+	if len(parameterNames) > 0 {
+		w.heading(2, "", text("Parameters"))
+		rows := make([][][]segment, 0, len(parameterNames))
+		for _, parameterName := range parameterNames {
+			decoded, err := caveattypes.DecodeParameterType(caveatDef.ParameterTypes[parameterName])
+			if err != nil {
+				return Page{}, fmt.Errorf("invalid parameter type on caveat: %w", err)
+			}
+			rows = append(rows, [][]segment{{code(parameterName)}, {code(decoded.String())}})
+		}
+		w.table([]string{"Name", "Type"}, rows)
+	}
+
+	parameterTypes, err := caveattypes.DecodeParameterTypes(caveatDef.ParameterTypes)
+	if err != nil {
+		return Page{}, fmt.Errorf("invalid caveat parameters: %w", err)
+	}
+
+	deserialized, err := caveats.DeserializeCaveat(caveatDef.SerializedExpression, parameterTypes)
+	if err != nil {
+		return Page{}, fmt.Errorf("invalid caveat expression bytes: %w", err)
+	}
+
+	exprString, err := deserialized.ExprString()
+	if err != nil {
+		return Page{}, fmt.Errorf("invalid caveat expression: %w", err)
+	}
+
+	w.heading(2, "", text("Expression"))
+	w.codeBlock(strings.TrimSpace(exprString))
+
+	if usedBy := g.usedBy[caveatDef.Name]; len(usedBy) > 0 {
+		w.heading(2, "", text("Used by"))
+		items := make([][]segment, 0, len(usedBy))
+		for _, relationLink := range usedBy {
+			items = append(items, []segment{relationLink})
+		}
+		w.list(items)
+	}
+
+	return Page{Path: pagePath + g.extension, Content: w.String()}, nil
+}
+
+func (g *generator) writeComments(w writer, metadata *core.Metadata) {
+	for _, paragraph := range paragraphs(nspkg.GetCommentLines(metadata)) {
+		w.paragraph(text(paragraph))
+	}
+}
+
+// relation returns the relation or permission with the given name in the given definition.
+func (g *generator) relation(definitionName string, relationName string) (*core.Relation, bool) {
+	for _, def := range g.compiled.ObjectDefinitions {
+		if def.Name != definitionName {
+			continue
+		}
+
+		for _, relation := range def.Relation {
+			if relation.Name == relationName {
+				return relation, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// expression returns the segments of the expression of the rewrite, with links to the relations
+// and permissions it references.
+func (g *generator) expression(rewrite *core.UsersetRewrite) []segment {
+	var operator string
+	var children []*core.SetOperation_Child
+	switch operation := rewrite.GetRewriteOperation().(type) {
+	case *core.UsersetRewrite_Union:
+		operator, children = " + ", operation.Union.Child
+	case *core.UsersetRewrite_Intersection:
+		operator, children = " & ", operation.Intersection.Child
+	case *core.UsersetRewrite_Exclusion:
+		operator, children = " - ", operation.Exclusion.Child
+	}
+
+	var segments []segment
+	for index, child := range children {
+		if index > 0 {
+			segments = append(segments, text(operator))
+		}
+
+		switch child := child.ChildType.(type) {
+		case *core.SetOperation_Child_UsersetRewrite:
+			_, isUnion := rewrite.GetRewriteOperation().(*core.UsersetRewrite_Union)
+			_, isChildUnion := child.UsersetRewrite.GetRewriteOperation().(*core.UsersetRewrite_Union)
+			if isUnion && isChildUnion {
+				segments = append(segments, g.expression(child.UsersetRewrite)...)
+				continue
+			}
+
+			segments = append(segments, text("("))
+			segments = append(segments, g.expression(child.UsersetRewrite)...)
+			segments = append(segments, text(")"))
+
+		case *core.SetOperation_Child_XNil:
+			segments = append(segments, code("nil"))
+
+		case *core.SetOperation_Child_ComputedUserset:
+			segments = append(segments, link(child.ComputedUserset.Relation, "#"+child.ComputedUserset.Relation))
+
+		case *core.SetOperation_Child_TupleToUserset:
+			tupleset := child.TupleToUserset.GetTupleset().GetRelation()
+			computed := child.TupleToUserset.GetComputedUserset().GetRelation()
+			segments = append(segments, link(tupleset, "#"+tupleset))
+			if child.TupleToUserset.Function == core.TupleToUserset_FUNCTION_ALL {
+				segments = append(segments, text(".all("), code(computed), text(")"))
+			} else {
+				segments = append(segments, text("->"), code(computed))
+			}
+		}
+	}
+	return segments
+}
+
+// arrowSource returns the source of the arrow in the schema.
+func arrowSource(ttu *core.TupleToUserset) string {
+	if ttu.Function == core.TupleToUserset_FUNCTION_ALL {
+		return ttu.GetTupleset().GetRelation() + ".all(" + ttu.GetComputedUserset().GetRelation() + ")"
+	}
+	return ttu.GetTupleset().GetRelation() + "->" + ttu.GetComputedUserset().GetRelation()
+}
+
+// walkArrows invokes the handler for each arrow found in the rewrite, recursively.
+func walkArrows(rewrite *core.UsersetRewrite, handler func(ttu *core.TupleToUserset) error) error {
+	var children []*core.SetOperation_Child
+	switch operation := rewrite.GetRewriteOperation().(type) {
+	case *core.UsersetRewrite_Union:
+		children = operation.Union.Child
+	case *core.UsersetRewrite_Intersection:
+		children = operation.Intersection.Child
+	case *core.UsersetRewrite_Exclusion:
+		children = operation.Exclusion.Child
+	}
+
+	for _, child := range children {
+		switch child := child.ChildType.(type) {
+		case *core.SetOperation_Child_UsersetRewrite:
+			if err := walkArrows(child.UsersetRewrite, handler); err != nil {
+				return err
+			}
+		case *core.SetOperation_Child_TupleToUserset:
+			if err := handler(child.TupleToUserset); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// paragraphs returns the paragraphs of the given comment lines, separated by blank lines, with
+// their lines joined by newlines.
+func paragraphs(lines []string) []string {
+	var paragraphs []string
+	var current []string
+	for _, line := range append(lines, "") {
+		if line != "" {
+			current = append(current, line)
+			continue
+		}
+
+		if len(current) > 0 {
+			paragraphs = append(paragraphs, strings.Join(current, "\n"))
+			current = nil
+		}
+	}
+	return paragraphs
+}
+
+func definitionPath(name string) string {
+	return path.Join("definitions", name)
+}
+
+func caveatPath(name string) string {
+	return path.Join("caveats", name)
+}
+
+// href returns the link from the page at the given path to the page at the given path, at the
+// given anchor if any. Paths are without extension.
+func (g *generator) href(fromPath string, toPath string, anchor string) string {
+	fromParts := strings.Split(path.Dir(fromPath), "/")
+	toParts := strings.Split(toPath, "/")
+	if fromParts[0] == "." {
+		fromParts = nil
+	}
+
+	common := 0
+	for common < len(fromParts) && common < len(toParts)-1 && fromParts[common] == toParts[common] {
+		common++
+	}
+
+	relative := strings.Repeat("../", len(fromParts)-common) + strings.Join(toParts[common:], "/") + g.extension
+	if anchor != "" {
+		relative += "#" + anchor
+	}
+	return relative
+}
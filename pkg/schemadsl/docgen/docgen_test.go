@@ -0,0 +1,245 @@
+package docgen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+)
+
+const testSchema = `
+/** ip_allowed only allows access from the private network. */
+caveat ip_allowed(user_ip ipaddress, ranges list<string>) {
+	user_ip.in_cidr('10.0.0.0/8')
+}
+
+// user is a person.
+definition user {}
+
+definition tenant/team {
+	relation member: user | tenant/team#member
+}
+
+definition folder {
+	relation viewer: user
+	permission view = viewer
+}
+
+/**
+ * document is a document.
+ *
+ * It lives in a folder.
+ */
+definition document {
+	relation folder: folder | folder with ip_allowed
+	// viewer can view the document.
+	relation viewer: user | user:* | tenant/team#member | user with ip_allowed
+	relation banned: user
+	permission view = (viewer + folder->view) - banned
+	permission nothing = nil & view
+}`
+
+func TestGenerate(t *testing.T) {
+	tcs := []struct {
+		format        Format
+		expectedPaths []string
+		expectedPages map[string]string
+	}{
+		{
+			Markdown,
+			[]string{
+				"index.md",
+				"definitions/user.md",
+				"definitions/tenant/team.md",
+				"definitions/folder.md",
+				"definitions/document.md",
+				"caveats/ip_allowed.md",
+			},
+			map[string]string{
+				"index.md": `# Schema
+
+## Definitions
+
+- [` + "`" + `user` + "`" + `](definitions/user.md): user is a person.
+- [` + "`" + `tenant/team` + "`" + `](definitions/tenant/team.md)
+- [` + "`" + `folder` + "`" + `](definitions/folder.md)
+- [` + "`" + `document` + "`" + `](definitions/document.md): document is a document.
+
+## Caveats
+
+- [` + "`" + `ip_allowed` + "`" + `](caveats/ip_allowed.md): ip_allowed only allows access from the private network.
+`,
+				"definitions/document.md": `# definition ` + "`" + `document` + "`" + `
+
+[Back to the index](../index.md)
+
+document is a document.
+
+It lives in a folder.
+
+## Relations
+
+<a id="folder"></a>
+
+### relation ` + "`" + `folder` + "`" + `
+
+Allowed subject types:
+
+- [` + "`" + `folder` + "`" + `](folder.md)
+- [` + "`" + `folder` + "`" + `](folder.md) with caveat [` + "`" + `ip_allowed` + "`" + `](../caveats/ip_allowed.md)
+
+<a id="viewer"></a>
+
+### relation ` + "`" + `viewer` + "`" + `
+
+viewer can view the document.
+
+Allowed subject types:
+
+- [` + "`" + `user` + "`" + `](user.md)
+- [` + "`" + `user:*` + "`" + `](user.md)
+- [` + "`" + `tenant/team#member` + "`" + `](tenant/team.md#member)
+- [` + "`" + `user` + "`" + `](user.md) with caveat [` + "`" + `ip_allowed` + "`" + `](../caveats/ip_allowed.md)
+
+<a id="banned"></a>
+
+### relation ` + "`" + `banned` + "`" + `
+
+Allowed subject types:
+
+- [` + "`" + `user` + "`" + `](user.md)
+
+## Permissions
+
+<a id="view"></a>
+
+### permission ` + "`" + `view` + "`" + `
+
+Expression: ([` + "`" + `viewer` + "`" + `](#viewer) + [` + "`" + `folder` + "`" + `](#folder)->` + "`" + `view` + "`" + `) - [` + "`" + `banned` + "`" + `](#banned)
+
+Arrows:
+
+- ` + "`" + `folder->view` + "`" + ` reaches [` + "`" + `folder#view` + "`" + `](folder.md#view)
+
+<a id="nothing"></a>
+
+### permission ` + "`" + `nothing` + "`" + `
+
+Expression: ` + "`" + `nil` + "`" + ` & [` + "`" + `view` + "`" + `](#view)
+`,
+				"caveats/ip_allowed.md": `# caveat ` + "`" + `ip_allowed` + "`" + `
+
+[Back to the index](../index.md)
+
+ip_allowed only allows access from the private network.
+
+## Parameters
+
+| Name | Type |
+| --- | --- |
+| ` + "`" + `ranges` + "`" + ` | ` + "`" + `list<string>` + "`" + ` |
+| ` + "`" + `user_ip` + "`" + ` | ` + "`" + `ipaddress` + "`" + ` |
+
+## Expression
+
+` + "`" + `` + "`" + `` + "`" + `
+user_ip.in_cidr("10.0.0.0/8")
+` + "`" + `` + "`" + `` + "`" + `
+
+## Used by
+
+- [` + "`" + `document#folder` + "`" + `](../definitions/document.md#folder)
+- [` + "`" + `document#viewer` + "`" + `](../definitions/document.md#viewer)
+`,
+			},
+		},
+		{
+			HTML,
+			[]string{
+				"index.html",
+				"definitions/user.html",
+				"definitions/tenant/team.html",
+				"definitions/folder.html",
+				"definitions/document.html",
+				"caveats/ip_allowed.html",
+			},
+			map[string]string{
+				"definitions/tenant/team.html": `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>definition tenant/team</title>
+</head>
+<body>
+<h1>definition <code>tenant/team</code></h1>
+<p><a href="../../index.html">Back to the index</a></p>
+<h2>Relations</h2>
+<h3 id="member">relation <code>member</code></h3>
+<p>Allowed subject types:</p>
+<ul>
+<li><a href="../user.html"><code>user</code></a></li>
+<li><a href="team.html#member"><code>tenant/team#member</code></a></li>
+</ul>
+</body>
+</html>
+`,
+			},
+		},
+	}
+
+	compiled, err := compiler.Compile(compiler.InputSchema{
+		Source:       input.Source("schema"),
+		SchemaString: testSchema,
+	}, compiler.AllowUnprefixedObjectType())
+	require.NoError(t, err)
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(string(tc.format), func(t *testing.T) {
+			pages, err := Generate(context.Background(), compiled, tc.format)
+			require.NoError(t, err)
+
+			paths := make([]string, 0, len(pages))
+			for _, page := range pages {
+				paths = append(paths, page.Path)
+				if expected, ok := tc.expectedPages[page.Path]; ok {
+					require.Equal(t, expected, page.Content, page.Path)
+				}
+			}
+			require.Equal(t, tc.expectedPaths, paths)
+		})
+	}
+}
+
+func TestGenerateUnsupportedFormat(t *testing.T) {
+	_, err := Generate(context.Background(), &compiler.CompiledSchema{}, Format("pdf"))
+	require.EqualError(t, err, "unsupported documentation format `pdf`")
+}
+
+func TestHref(t *testing.T) {
+	tcs := []struct {
+		fromPath string
+		toPath   string
+		anchor   string
+		expected string
+	}{
+		{"index", "definitions/user", "", "definitions/user.md"},
+		{"definitions/user", "index", "", "../index.md"},
+		{"definitions/user", "definitions/document", "viewer", "document.md#viewer"},
+		{"definitions/tenant/team", "definitions/user", "", "../user.md"},
+		{"definitions/tenant/team", "definitions/tenant/team", "member", "team.md#member"},
+		{"definitions/document", "definitions/tenant/team", "", "tenant/team.md"},
+		{"definitions/tenant/team", "caveats/tenant/ip_allowed", "", "../../caveats/tenant/ip_allowed.md"},
+	}
+
+	g := &generator{extension: ".md"}
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.fromPath+"=>"+tc.toPath, func(t *testing.T) {
+			require.Equal(t, tc.expected, g.href(tc.fromPath, tc.toPath, tc.anchor))
+		})
+	}
+}
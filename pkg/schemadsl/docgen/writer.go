@@ -0,0 +1,202 @@
+package docgen
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// segment is a piece of inline content of the documentation.
+type segment struct {
+	text string
+	href string
+	code bool
+}
+
+func text(value string) segment {
+	return segment{text: value}
+}
+
+func code(value string) segment {
+	return segment{text: value, code: true}
+}
+
+// link returns a link to the given target, with the name of an element of the schema as text.
+func link(name string, href string) segment {
+	return segment{text: name, href: href, code: true}
+}
+
+func textLink(value string, href string) segment {
+	return segment{text: value, href: href}
+}
+
+// writer writes the content of a page in a format.
+type writer interface {
+	// heading writes a heading of the given level, with the given anchor if not empty.
+	heading(level int, anchor string, content ...segment)
+
+	// paragraph writes a paragraph.
+	paragraph(content ...segment)
+
+	// list writes an unordered list with the given items.
+	list(items [][]segment)
+
+	// table writes a table with the given header and rows of cells.
+	table(header []string, rows [][][]segment)
+
+	// codeBlock writes a block of code.
+	codeBlock(code string)
+
+	// String returns the content of the page.
+	String() string
+}
+
+type markdownWriter struct {
+	sb strings.Builder
+}
+
+func newMarkdownWriter(string) writer {
+	return &markdownWriter{}
+}
+
+func (mw *markdownWriter) block(content string) {
+	if mw.sb.Len() > 0 {
+		mw.sb.WriteString("\n")
+	}
+	mw.sb.WriteString(content)
+	mw.sb.WriteString("\n")
+}
+
+func (mw *markdownWriter) inline(content []segment) string {
+	var sb strings.Builder
+	for _, s := range content {
+		value := s.text
+		if s.code {
+			value = "`" + value + "`"
+		}
+		if s.href != "" {
+			value = "[" + value + "](" + s.href + ")"
+		}
+		sb.WriteString(value)
+	}
+	return sb.String()
+}
+
+func (mw *markdownWriter) heading(level int, anchor string, content ...segment) {
+	heading := strings.Repeat("#", level) + " " + mw.inline(content)
+	if anchor != "" {
+		heading = fmt.Sprintf("<a id=%q></a>\n\n%s", anchor, heading)
+	}
+	mw.block(heading)
+}
+
+func (mw *markdownWriter) paragraph(content ...segment) {
+	mw.block(mw.inline(content))
+}
+
+func (mw *markdownWriter) list(items [][]segment) {
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, "- "+mw.inline(item))
+	}
+	mw.block(strings.Join(lines, "\n"))
+}
+
+func (mw *markdownWriter) table(header []string, rows [][][]segment) {
+	lines := make([]string, 0, len(rows)+2)
+	lines = append(lines, "| "+strings.Join(header, " | ")+" |")
+	lines = append(lines, strings.TrimSuffix(strings.Repeat("| --- ", len(header)), " ")+" |")
+	for _, row := range rows {
+		cells := make([]string, 0, len(row))
+		for _, cell := range row {
+			cells = append(cells, mw.inline(cell))
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+	}
+	mw.block(strings.Join(lines, "\n"))
+}
+
+func (mw *markdownWriter) codeBlock(code string) {
+	mw.block("```\n" + code + "\n```")
+}
+
+func (mw *markdownWriter) String() string {
+	return mw.sb.String()
+}
+
+type htmlWriter struct {
+	title string
+	sb    strings.Builder
+}
+
+func newHTMLWriter(title string) writer {
+	return &htmlWriter{title: title}
+}
+
+func (hw *htmlWriter) inline(content []segment) string {
+	var sb strings.Builder
+	for _, s := range content {
+		value := html.EscapeString(s.text)
+		if s.code {
+			value = "<code>" + value + "</code>"
+		}
+		if s.href != "" {
+			value = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(s.href), value)
+		}
+		sb.WriteString(value)
+	}
+	return sb.String()
+}
+
+func (hw *htmlWriter) heading(level int, anchor string, content ...segment) {
+	if anchor != "" {
+		fmt.Fprintf(&hw.sb, "<h%d id=\"%s\">%s</h%[1]d>\n", level, html.EscapeString(anchor), hw.inline(content))
+		return
+	}
+	fmt.Fprintf(&hw.sb, "<h%d>%s</h%[1]d>\n", level, hw.inline(content))
+}
+
+func (hw *htmlWriter) paragraph(content ...segment) {
+	fmt.Fprintf(&hw.sb, "<p>%s</p>\n", strings.ReplaceAll(hw.inline(content), "\n", "<br>\n"))
+}
+
+func (hw *htmlWriter) list(items [][]segment) {
+	hw.sb.WriteString("<ul>\n")
+	for _, item := range items {
+		fmt.Fprintf(&hw.sb, "<li>%s</li>\n", hw.inline(item))
+	}
+	hw.sb.WriteString("</ul>\n")
+}
+
+func (hw *htmlWriter) table(header []string, rows [][][]segment) {
+	hw.sb.WriteString("<table>\n<tr>")
+	for _, name := range header {
+		fmt.Fprintf(&hw.sb, "<th>%s</th>", html.EscapeString(name))
+	}
+	hw.sb.WriteString("</tr>\n")
+	for _, row := range rows {
+		hw.sb.WriteString("<tr>")
+		for _, cell := range row {
+			fmt.Fprintf(&hw.sb, "<td>%s</td>", hw.inline(cell))
+		}
+		hw.sb.WriteString("</tr>\n")
+	}
+	hw.sb.WriteString("</table>\n")
+}
+
+func (hw *htmlWriter) codeBlock(code string) {
+	fmt.Fprintf(&hw.sb, "<pre><code>%s</code></pre>\n", html.EscapeString(code))
+}
+
+func (hw *htmlWriter) String() string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+</head>
+<body>
+%s</body>
+</html>
+`, html.EscapeString(hw.title), hw.sb.String())
+}
@@ -11,6 +11,7 @@ const (
 	NodeTypeError   NodeType = iota // error occurred; value is text of error
 	NodeTypeFile                    // The file root node
 	NodeTypeComment                 // A single or multiline comment
+	NodeTypeImport                  // An import of another schema file
 
 	NodeTypeDefinition       // A definition.
 	NodeTypeCaveatDefinition // A caveat definition.
@@ -29,7 +30,8 @@ const (
 	NodeTypeIntersectExpression
 	NodeTypeExclusionExpression
 
-	NodeTypeArrowExpression // A TTU in arrow form.
+	NodeTypeArrowExpression           // A TTU in arrow form.
+	NodeTypeFunctionedArrowExpression // A TTU in function form, e.g. `parent.all(view)`.
 
 	NodeTypeIdentifier    // An identifier under an expression.
 	NodeTypeNilExpression // A nil keyword
@@ -71,6 +73,13 @@ const (
 	// The value of the comment, including its delimeter(s)
 	NodeCommentPredicateValue = "comment-value"
 
+	//
+	// NodeTypeImport
+	//
+
+	// The path of the file being imported
+	NodeImportPredicatePath = "import-path"
+
 	//
 	// NodeTypeDefinition
 	//
@@ -169,6 +178,9 @@ const (
 	// The expression to compute the permission.
 	NodePermissionPredicateComputeExpression = "compute-expression"
 
+	// Whether the permission was marked as materialized.
+	NodePermissionPredicateMaterialized = "materialized"
+
 	//
 	// NodeTypeIdentifer
 	//
@@ -177,7 +189,14 @@ const (
 	NodeIdentiferPredicateValue = "identifier-value"
 
 	//
-	// NodeTypeUnionExpression + NodeTypeIntersectExpression + NodeTypeExclusionExpression + NodeTypeArrowExpression
+	// NodeTypeFunctionedArrowExpression
+	//
+
+	// The name of the function applied to the arrow: `any` or `all`.
+	NodeFunctionedArrowExpressionPredicateFunctionName = "function-name"
+
+	//
+	// NodeTypeUnionExpression + NodeTypeIntersectExpression + NodeTypeExclusionExpression + NodeTypeArrowExpression + NodeTypeFunctionedArrowExpression
 	//
 	NodeExpressionPredicateLeftExpr  = "left-expr"
 	NodeExpressionPredicateRightExpr = "right-expr"
@@ -11,27 +11,29 @@ func _() {
 	_ = x[NodeTypeError-0]
 	_ = x[NodeTypeFile-1]
 	_ = x[NodeTypeComment-2]
-	_ = x[NodeTypeDefinition-3]
-	_ = x[NodeTypeCaveatDefinition-4]
-	_ = x[NodeTypeCaveatParameter-5]
-	_ = x[NodeTypeCaveatExpession-6]
-	_ = x[NodeTypeRelation-7]
-	_ = x[NodeTypePermission-8]
-	_ = x[NodeTypeTypeReference-9]
-	_ = x[NodeTypeSpecificTypeReference-10]
-	_ = x[NodeTypeCaveatReference-11]
-	_ = x[NodeTypeUnionExpression-12]
-	_ = x[NodeTypeIntersectExpression-13]
-	_ = x[NodeTypeExclusionExpression-14]
-	_ = x[NodeTypeArrowExpression-15]
-	_ = x[NodeTypeIdentifier-16]
-	_ = x[NodeTypeNilExpression-17]
-	_ = x[NodeTypeCaveatTypeReference-18]
+	_ = x[NodeTypeImport-3]
+	_ = x[NodeTypeDefinition-4]
+	_ = x[NodeTypeCaveatDefinition-5]
+	_ = x[NodeTypeCaveatParameter-6]
+	_ = x[NodeTypeCaveatExpession-7]
+	_ = x[NodeTypeRelation-8]
+	_ = x[NodeTypePermission-9]
+	_ = x[NodeTypeTypeReference-10]
+	_ = x[NodeTypeSpecificTypeReference-11]
+	_ = x[NodeTypeCaveatReference-12]
+	_ = x[NodeTypeUnionExpression-13]
+	_ = x[NodeTypeIntersectExpression-14]
+	_ = x[NodeTypeExclusionExpression-15]
+	_ = x[NodeTypeArrowExpression-16]
+	_ = x[NodeTypeFunctionedArrowExpression-17]
+	_ = x[NodeTypeIdentifier-18]
+	_ = x[NodeTypeNilExpression-19]
+	_ = x[NodeTypeCaveatTypeReference-20]
 }
 
-const _NodeType_name = "NodeTypeErrorNodeTypeFileNodeTypeCommentNodeTypeDefinitionNodeTypeCaveatDefinitionNodeTypeCaveatParameterNodeTypeCaveatExpessionNodeTypeRelationNodeTypePermissionNodeTypeTypeReferenceNodeTypeSpecificTypeReferenceNodeTypeCaveatReferenceNodeTypeUnionExpressionNodeTypeIntersectExpressionNodeTypeExclusionExpressionNodeTypeArrowExpressionNodeTypeIdentifierNodeTypeNilExpressionNodeTypeCaveatTypeReference"
+const _NodeType_name = "NodeTypeErrorNodeTypeFileNodeTypeCommentNodeTypeImportNodeTypeDefinitionNodeTypeCaveatDefinitionNodeTypeCaveatParameterNodeTypeCaveatExpessionNodeTypeRelationNodeTypePermissionNodeTypeTypeReferenceNodeTypeSpecificTypeReferenceNodeTypeCaveatReferenceNodeTypeUnionExpressionNodeTypeIntersectExpressionNodeTypeExclusionExpressionNodeTypeArrowExpressionNodeTypeFunctionedArrowExpressionNodeTypeIdentifierNodeTypeNilExpressionNodeTypeCaveatTypeReference"
 
-var _NodeType_index = [...]uint16{0, 13, 25, 40, 58, 82, 105, 128, 144, 162, 183, 212, 235, 258, 285, 312, 335, 353, 374, 401}
+var _NodeType_index = [...]uint16{0, 13, 25, 40, 54, 72, 96, 119, 142, 158, 176, 197, 226, 249, 272, 299, 326, 349, 382, 400, 421, 448}
 
 func (i NodeType) String() string {
 	if i < 0 || i >= NodeType(len(_NodeType_index)-1) {
@@ -180,6 +180,9 @@ func (sg *sourceGenerator) emitRelation(relation *core.Relation) error {
 
 	sg.emitComments(relation.Metadata)
 	if isPermission {
+		if namespace.IsMaterialized(relation) {
+			sg.append("materialized ")
+		}
 		sg.append("permission ")
 	} else {
 		sg.append("relation ")
@@ -289,6 +292,13 @@ func (sg *sourceGenerator) emitSetOpChild(setOpChild *core.SetOperation_Child) {
 
 	case *core.SetOperation_Child_TupleToUserset:
 		sg.append(child.TupleToUserset.Tupleset.Relation)
+		if child.TupleToUserset.Function == core.TupleToUserset_FUNCTION_ALL {
+			sg.append(".all(")
+			sg.append(child.TupleToUserset.ComputedUserset.Relation)
+			sg.append(")")
+			return
+		}
+
 		sg.append("->")
 		sg.append(child.TupleToUserset.ComputedUserset.Relation)
 	}
@@ -151,6 +151,33 @@ func TestGenerateNamespace(t *testing.T) {
 			),
 			`definition foos/test {
 	permission someperm = (rela - relb - rely->relz - nil) + relc
+}`,
+			true,
+		},
+		{
+			"materialized permission",
+			namespace.Namespace("foos/test",
+				namespace.MustRelation("rela", nil, namespace.AllowedRelation("foos/bars", "...")),
+				namespace.MustMaterialized(namespace.MustRelation("someperm", namespace.Union(
+					namespace.ComputedUserset("rela"),
+				))),
+			),
+			`definition foos/test {
+	relation rela: foos/bars
+	materialized permission someperm = rela
+}`,
+			true,
+		},
+		{
+			"permission with all arrow",
+			namespace.Namespace("foos/test",
+				namespace.MustRelation("someperm", namespace.Union(
+					namespace.AllTupleToUserset("rely", "relz"),
+					namespace.TupleToUserset("rely", "rela"),
+				)),
+			),
+			`definition foos/test {
+	permission someperm = rely.all(relz) + rely->rela
 }`,
 			true,
 		},
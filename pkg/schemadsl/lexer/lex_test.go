@@ -236,6 +236,15 @@ var lexerTests = []lexerTest{
 			tEOF,
 		},
 	},
+	{
+		"import statement", `import "common/user.zed"`,
+		[]Lexeme{
+			{TokenTypeIdentifier, 0, "import", ""},
+			tWhitespace,
+			{TokenTypeString, 0, `"common/user.zed"`, ""},
+			tEOF,
+		},
+	},
 	{
 		"unterminated cel string literal", "\"hi\nthere\"",
 		[]Lexeme{
@@ -33,3 +33,21 @@ func (l *PeekableLexer) NextToken() Lexeme {
 
 	return l.lex.nextToken()
 }
+
+// PeekToken returns the next token found in the lexer whose kind is not ignored, without
+// consuming it.
+func (l *PeekableLexer) PeekToken(ignored map[TokenType]bool) Lexeme {
+	for element := l.readTokens.Front(); element != nil; element = element.Next() {
+		if token := element.Value.(Lexeme); !ignored[token.Kind] {
+			return token
+		}
+	}
+
+	for {
+		token := l.lex.nextToken()
+		l.readTokens.PushBack(token)
+		if !ignored[token.Kind] || token.Kind == TokenTypeEOF {
+			return token
+		}
+	}
+}
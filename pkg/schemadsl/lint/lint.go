@@ -0,0 +1,156 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	nspkg "github.com/authzed/spicedb/pkg/namespace"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	iv1 "github.com/authzed/spicedb/pkg/proto/impl/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// Warning is an issue found in a schema by a lint rule. Unlike the errors of the type system,
+// warnings do not prevent a schema from being written.
+type Warning struct {
+	// Rule is the name of the rule which produced the warning.
+	Rule string `json:"rule"`
+
+	// Message describes the issue.
+	Message string `json:"message"`
+
+	// Definition is the name of the object or caveat definition in which the issue was found.
+	Definition string `json:"definition"`
+
+	// Relation is the name of the relation or permission in which the issue was found, if any.
+	Relation string `json:"relation,omitempty"`
+
+	// Line and Column are the 1-indexed position of the issue in the schema, if known.
+	Line   uint64 `json:"line,omitempty"`
+	Column uint64 `json:"column,omitempty"`
+}
+
+// String returns the warning prefixed with its position, if known.
+func (w Warning) String() string {
+	if w.Line == 0 {
+		return fmt.Sprintf("%s (%s)", w.Message, w.Rule)
+	}
+	return fmt.Sprintf("%d:%d: %s (%s)", w.Line, w.Column, w.Message, w.Rule)
+}
+
+// Rule is a check run over a schema, producing warnings.
+type Rule interface {
+	// Name is the name of the rule, which tags its warnings and by which it can be disabled.
+	Name() string
+
+	// Check returns the warnings found in the schema. The name of the rule is filled in by Lint.
+	Check(schema *Schema) []Warning
+}
+
+// DefaultRules returns the rules run by default, with the default naming conventions.
+func DefaultRules() []Rule {
+	return []Rule{
+		UnusedRelations(),
+		UnreachablePermissions(),
+		WildcardArrows(),
+		RedundantUnionBranches(),
+		NamingConvention(DefaultNamingConventions()),
+	}
+}
+
+// Lint runs the given rules over a compiled schema, returning the warnings found ordered by their
+// position. The schema is expected to have been validated by the type system.
+func Lint(compiled *compiler.CompiledSchema, rules []Rule) []Warning {
+	schema := NewSchema(compiled.ObjectDefinitions, compiled.CaveatDefinitions)
+
+	var warnings []Warning
+	for _, rule := range rules {
+		for _, warning := range rule.Check(schema) {
+			warning.Rule = rule.Name()
+			warnings = append(warnings, warning)
+		}
+	}
+
+	sort.SliceStable(warnings, func(i, j int) bool {
+		if warnings[i].Line != warnings[j].Line {
+			return warnings[i].Line < warnings[j].Line
+		}
+		return warnings[i].Column < warnings[j].Column
+	})
+	return warnings
+}
+
+// Schema is the schema being linted, indexed for lookups by rules.
+type Schema struct {
+	definitions []*core.NamespaceDefinition
+	caveats     []*core.CaveatDefinition
+	relations   map[string]*core.Relation
+}
+
+// NewSchema returns the schema made up of the given definitions.
+func NewSchema(definitions []*core.NamespaceDefinition, caveats []*core.CaveatDefinition) *Schema {
+	relations := make(map[string]*core.Relation)
+	for _, def := range definitions {
+		for _, relation := range def.Relation {
+			relations[tuple.JoinRelRef(def.Name, relation.Name)] = relation
+		}
+	}
+
+	return &Schema{
+		definitions: definitions,
+		caveats:     caveats,
+		relations:   relations,
+	}
+}
+
+// Definitions returns the object definitions of the schema.
+func (s *Schema) Definitions() []*core.NamespaceDefinition {
+	return s.definitions
+}
+
+// Caveats returns the caveat definitions of the schema.
+func (s *Schema) Caveats() []*core.CaveatDefinition {
+	return s.caveats
+}
+
+// Relation returns the relation or permission with the given name under the given definition.
+func (s *Schema) Relation(definitionName string, relationName string) (*core.Relation, bool) {
+	relation, ok := s.relations[tuple.JoinRelRef(definitionName, relationName)]
+	return relation, ok
+}
+
+// SubjectTypes returns the names of the definitions allowed as subjects of the given relation.
+func (s *Schema) SubjectTypes(relation *core.Relation) []string {
+	var subjectTypes []string
+	encountered := make(map[string]struct{})
+	for _, allowed := range relation.GetTypeInformation().GetAllowedDirectRelations() {
+		if _, ok := encountered[allowed.Namespace]; ok {
+			continue
+		}
+		encountered[allowed.Namespace] = struct{}{}
+		subjectTypes = append(subjectTypes, allowed.Namespace)
+	}
+	return subjectTypes
+}
+
+// IsPermission returns whether the given relation is a permission.
+func IsPermission(relation *core.Relation) bool {
+	return nspkg.GetRelationKind(relation) == iv1.RelationMetadata_PERMISSION
+}
+
+// NewWarning returns a warning found in the given definition and relation, at the position of the
+// given element of the schema.
+func NewWarning(message string, definitionName string, relationName string, at nspkg.WithSourcePosition) Warning {
+	warning := Warning{
+		Message:    message,
+		Definition: definitionName,
+		Relation:   relationName,
+	}
+
+	if position := at.GetSourcePosition(); position != nil {
+		warning.Line = position.ZeroIndexedLineNumber + 1
+		warning.Column = position.ZeroIndexedColumnPosition + 1
+	}
+	return warning
+}
@@ -0,0 +1,184 @@
+package lint
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+)
+
+func TestLint(t *testing.T) {
+	tcs := []struct {
+		name             string
+		schema           string
+		expectedWarnings []string
+	}{
+		{
+			"clean schema",
+			`definition user {}
+
+			definition group {
+				relation member: user | group#member
+			}
+
+			definition document {
+				relation parent: document
+				relation viewer: user | group#member
+				relation banned: user
+				permission view = (viewer + parent->view) - banned
+			}`,
+			nil,
+		},
+		{
+			"unused relation",
+			`definition user {}
+
+			definition document {
+				relation viewer: user
+				relation editor: user
+				permission view = viewer
+			}`,
+			[]string{"5:5: relation `editor` is not used by any permission (unused-relation)"},
+		},
+		{
+			"relation used by arrow from another definition",
+			`definition user {}
+
+			definition organization {
+				relation admin: user
+			}
+
+			definition document {
+				relation org: organization
+				permission manage = org->admin
+			}`,
+			nil,
+		},
+		{
+			"unreachable permission",
+			`definition user {}
+
+			definition folder {}
+
+			definition document {
+				relation viewer: user
+				relation folder: folder
+				permission view = viewer
+				permission nothing = viewer & nil
+				permission inherited = folder->view
+				permission chained = inherited + nothing
+			}`,
+			[]string{
+				"9:5: permission `nothing` cannot be reached from any subject type (unreachable-permission)",
+				"10:5: permission `inherited` cannot be reached from any subject type (unreachable-permission)",
+				"11:5: permission `chained` cannot be reached from any subject type (unreachable-permission)",
+			},
+		},
+		{
+			"recursive permissions are reachable",
+			`definition user {}
+
+			definition folder {
+				relation parent: folder
+				relation viewer: user
+				permission view = parent->view + viewer
+			}`,
+			nil,
+		},
+		{
+			"arrow over wildcard relation",
+			`definition user {}
+
+			definition folder {
+				relation viewer: user:*
+			}
+
+			definition document {
+				relation folder: folder
+				permission view = folder->viewer
+			}`,
+			[]string{"9:23: arrow `folder->viewer` reaches relation `folder#viewer`, which only allows wildcard subjects (wildcard-arrow)"},
+		},
+		{
+			"duplicate union branch",
+			`definition user {}
+
+			definition document {
+				relation viewer: user
+				permission view = viewer + viewer
+			}`,
+			[]string{"5:32: `viewer` appears more than once in the union (redundant-union-branch)"},
+		},
+		{
+			"union branch included by another branch",
+			`definition user {}
+
+			definition document {
+				relation viewer: user
+				relation editor: user
+				permission edit = editor
+				permission view = viewer + edit + editor
+			}`,
+			[]string{"7:39: `editor` is redundant in the union, as it is already included by `edit` (redundant-union-branch)"},
+		},
+		{
+			"naming convention",
+			`definition user {}
+
+			definition some__document {
+				relation the__viewer: user
+				permission view = the__viewer
+			}`,
+			[]string{
+				"3:4: definition `some__document` does not follow the naming convention `^[a-z][a-z0-9]*(_[a-z0-9]+)*$` (naming-convention)",
+				"4:5: relation `the__viewer` does not follow the naming convention `^[a-z][a-z0-9]*(_[a-z0-9]+)*$` (naming-convention)",
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			compiled, err := compiler.Compile(compiler.InputSchema{
+				Source:       input.Source("schema"),
+				SchemaString: tc.schema,
+			}, compiler.AllowUnprefixedObjectType())
+			require.NoError(t, err)
+
+			var found []string
+			for _, warning := range Lint(compiled, DefaultRules()) {
+				found = append(found, warning.String())
+			}
+			require.Equal(t, tc.expectedWarnings, found)
+		})
+	}
+}
+
+func TestCustomRules(t *testing.T) {
+	compiled, err := compiler.Compile(compiler.InputSchema{
+		Source: input.Source("schema"),
+		SchemaString: `definition tenant/user {}
+
+		definition tenant/document {
+			relation viewer: tenant/user
+			permission view = viewer
+		}`,
+	}, compiler.AllowUnprefixedObjectType())
+	require.NoError(t, err)
+
+	warnings := Lint(compiled, []Rule{NamingConvention(NamingConventions{
+		Permissions: regexp.MustCompile(`^can_`),
+	})})
+	require.Len(t, warnings, 1)
+	require.Equal(t, Warning{
+		Rule:       NamingConventionRule,
+		Message:    "permission `view` does not follow the naming convention `^can_`",
+		Definition: "tenant/document",
+		Relation:   "view",
+		Line:       5,
+		Column:     4,
+	}, warnings[0])
+}
@@ -0,0 +1,459 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/authzed/spicedb/pkg/graph"
+	nspkg "github.com/authzed/spicedb/pkg/namespace"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+const (
+	// UnusedRelationRule is the name of the rule reporting relations not used by any permission.
+	UnusedRelationRule = "unused-relation"
+
+	// UnreachablePermissionRule is the name of the rule reporting permissions which no subject
+	// can ever have.
+	UnreachablePermissionRule = "unreachable-permission"
+
+	// WildcardArrowRule is the name of the rule reporting arrows over relations only allowing
+	// wildcard subjects.
+	WildcardArrowRule = "wildcard-arrow"
+
+	// RedundantUnionBranchRule is the name of the rule reporting branches of unions already
+	// included by other branches.
+	RedundantUnionBranchRule = "redundant-union-branch"
+
+	// NamingConventionRule is the name of the rule reporting names not following the naming
+	// conventions.
+	NamingConventionRule = "naming-convention"
+)
+
+type unusedRelations struct{}
+
+// UnusedRelations returns the rule reporting relations which are not referenced by any
+// permission, arrow or allowed subject relation.
+func UnusedRelations() Rule {
+	return unusedRelations{}
+}
+
+func (unusedRelations) Name() string {
+	return UnusedRelationRule
+}
+
+func (unusedRelations) Check(schema *Schema) []Warning {
+	referenced := make(map[string]struct{})
+	for _, def := range schema.Definitions() {
+		for _, relation := range def.Relation {
+			for _, allowed := range relation.GetTypeInformation().GetAllowedDirectRelations() {
+				if allowed.GetRelation() != "" && allowed.GetRelation() != tuple.Ellipsis {
+					referenced[tuple.JoinRelRef(allowed.Namespace, allowed.GetRelation())] = struct{}{}
+				}
+			}
+
+			_, _ = graph.WalkRewrite(relation.UsersetRewrite, func(child *core.SetOperation_Child) interface{} {
+				switch child := child.ChildType.(type) {
+				case *core.SetOperation_Child_ComputedUserset:
+					referenced[tuple.JoinRelRef(def.Name, child.ComputedUserset.Relation)] = struct{}{}
+
+				case *core.SetOperation_Child_TupleToUserset:
+					tuplesetName := child.TupleToUserset.GetTupleset().GetRelation()
+					referenced[tuple.JoinRelRef(def.Name, tuplesetName)] = struct{}{}
+
+					tupleset, ok := schema.Relation(def.Name, tuplesetName)
+					if !ok {
+						return nil
+					}
+					for _, subjectType := range schema.SubjectTypes(tupleset) {
+						referenced[tuple.JoinRelRef(subjectType, child.TupleToUserset.GetComputedUserset().GetRelation())] = struct{}{}
+					}
+				}
+				return nil
+			})
+		}
+	}
+
+	var warnings []Warning
+	for _, def := range schema.Definitions() {
+		for _, relation := range def.Relation {
+			if IsPermission(relation) {
+				continue
+			}
+			if _, ok := referenced[tuple.JoinRelRef(def.Name, relation.Name)]; !ok {
+				warnings = append(warnings, NewWarning(
+					fmt.Sprintf("relation `%s` is not used by any permission", relation.Name),
+					def.Name, relation.Name, relation,
+				))
+			}
+		}
+	}
+	return warnings
+}
+
+type unreachablePermissions struct{}
+
+// UnreachablePermissions returns the rule reporting permissions which cannot be reached from any
+// subject type, such as those intersecting with `nil` or arrowing to relations which none of the
+// subject types of the arrow define.
+func UnreachablePermissions() Rule {
+	return unreachablePermissions{}
+}
+
+func (unreachablePermissions) Name() string {
+	return UnreachablePermissionRule
+}
+
+func (unreachablePermissions) Check(schema *Schema) []Warning {
+	// Reachability is computed as a fixed point, as permissions may reference each other
+	// recursively through arrows.
+	reachable := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, def := range schema.Definitions() {
+			for _, relation := range def.Relation {
+				key := tuple.JoinRelRef(def.Name, relation.Name)
+				if !reachable[key] && relationReachable(schema, def.Name, relation, reachable) {
+					reachable[key] = true
+					changed = true
+				}
+			}
+		}
+	}
+
+	var warnings []Warning
+	for _, def := range schema.Definitions() {
+		for _, relation := range def.Relation {
+			if IsPermission(relation) && !reachable[tuple.JoinRelRef(def.Name, relation.Name)] {
+				warnings = append(warnings, NewWarning(
+					fmt.Sprintf("permission `%s` cannot be reached from any subject type", relation.Name),
+					def.Name, relation.Name, relation,
+				))
+			}
+		}
+	}
+	return warnings
+}
+
+func relationReachable(schema *Schema, definitionName string, relation *core.Relation, reachable map[string]bool) bool {
+	if relation.UsersetRewrite == nil {
+		return len(relation.GetTypeInformation().GetAllowedDirectRelations()) > 0
+	}
+	return rewriteReachable(schema, definitionName, relation, relation.UsersetRewrite, reachable)
+}
+
+func rewriteReachable(schema *Schema, definitionName string, relation *core.Relation, rewrite *core.UsersetRewrite, reachable map[string]bool) bool {
+	childReachable := func(child *core.SetOperation_Child) bool {
+		switch child := child.ChildType.(type) {
+		case *core.SetOperation_Child_XThis:
+			return len(relation.GetTypeInformation().GetAllowedDirectRelations()) > 0
+
+		case *core.SetOperation_Child_ComputedUserset:
+			return reachable[tuple.JoinRelRef(definitionName, child.ComputedUserset.Relation)]
+
+		case *core.SetOperation_Child_TupleToUserset:
+			tupleset, ok := schema.Relation(definitionName, child.TupleToUserset.GetTupleset().GetRelation())
+			if !ok {
+				return false
+			}
+			for _, subjectType := range schema.SubjectTypes(tupleset) {
+				if reachable[tuple.JoinRelRef(subjectType, child.TupleToUserset.GetComputedUserset().GetRelation())] {
+					return true
+				}
+			}
+			return false
+
+		case *core.SetOperation_Child_UsersetRewrite:
+			return rewriteReachable(schema, definitionName, relation, child.UsersetRewrite, reachable)
+
+		default:
+			return false
+		}
+	}
+
+	switch operation := rewrite.RewriteOperation.(type) {
+	case *core.UsersetRewrite_Union:
+		for _, child := range operation.Union.Child {
+			if childReachable(child) {
+				return true
+			}
+		}
+		return false
+
+	case *core.UsersetRewrite_Intersection:
+		for _, child := range operation.Intersection.Child {
+			if !childReachable(child) {
+				return false
+			}
+		}
+		return len(operation.Intersection.Child) > 0
+
+	case *core.UsersetRewrite_Exclusion:
+		return len(operation.Exclusion.Child) > 0 && childReachable(operation.Exclusion.Child[0])
+
+	default:
+		return false
+	}
+}
+
+type wildcardArrows struct{}
+
+// WildcardArrows returns the rule reporting arrows walking to relations which only allow wildcard
+// subjects, which grant the permission to every subject of the wildcard types.
+func WildcardArrows() Rule {
+	return wildcardArrows{}
+}
+
+func (wildcardArrows) Name() string {
+	return WildcardArrowRule
+}
+
+func (wildcardArrows) Check(schema *Schema) []Warning {
+	var warnings []Warning
+	for _, def := range schema.Definitions() {
+		for _, relation := range def.Relation {
+			_, _ = graph.WalkRewrite(relation.UsersetRewrite, func(child *core.SetOperation_Child) interface{} {
+				ttu := child.GetTupleToUserset()
+				if ttu == nil {
+					return nil
+				}
+
+				tupleset, ok := schema.Relation(def.Name, ttu.GetTupleset().GetRelation())
+				if !ok {
+					return nil
+				}
+
+				for _, subjectType := range schema.SubjectTypes(tupleset) {
+					target, ok := schema.Relation(subjectType, ttu.GetComputedUserset().GetRelation())
+					if !ok || !onlyAllowsWildcards(target) {
+						continue
+					}
+
+					warnings = append(warnings, NewWarning(
+						fmt.Sprintf("arrow `%s->%s` reaches relation `%s`, which only allows wildcard subjects",
+							ttu.GetTupleset().GetRelation(), ttu.GetComputedUserset().GetRelation(), tuple.JoinRelRef(subjectType, target.Name)),
+						def.Name, relation.Name, child,
+					))
+				}
+				return nil
+			})
+		}
+	}
+	return warnings
+}
+
+func onlyAllowsWildcards(relation *core.Relation) bool {
+	if relation.UsersetRewrite != nil {
+		return false
+	}
+
+	allowed := relation.GetTypeInformation().GetAllowedDirectRelations()
+	for _, allowedRelation := range allowed {
+		if allowedRelation.GetPublicWildcard() == nil {
+			return false
+		}
+	}
+	return len(allowed) > 0
+}
+
+type redundantUnionBranches struct{}
+
+// RedundantUnionBranches returns the rule reporting branches of unions which appear more than once,
+// or which are already included by another branch referencing a permission.
+func RedundantUnionBranches() Rule {
+	return redundantUnionBranches{}
+}
+
+func (redundantUnionBranches) Name() string {
+	return RedundantUnionBranchRule
+}
+
+func (redundantUnionBranches) Check(schema *Schema) []Warning {
+	var warnings []Warning
+	for _, def := range schema.Definitions() {
+		for _, relation := range def.Relation {
+			walkUnions(relation.UsersetRewrite, func(union *core.SetOperation) {
+				encountered := make(map[string]struct{})
+				for _, child := range union.Child {
+					key := branchKey(child)
+					if key == "" {
+						continue
+					}
+
+					if _, ok := encountered[key]; ok {
+						warnings = append(warnings, NewWarning(
+							fmt.Sprintf("`%s` appears more than once in the union", key),
+							def.Name, relation.Name, child,
+						))
+						continue
+					}
+					encountered[key] = struct{}{}
+
+					for _, other := range union.Child {
+						computed := other.GetComputedUserset()
+						if other == child || computed == nil || branchKey(other) == key {
+							continue
+						}
+
+						if _, ok := unionBranches(schema, def.Name, computed.Relation, map[string]struct{}{})[key]; ok {
+							warnings = append(warnings, NewWarning(
+								fmt.Sprintf("`%s` is redundant in the union, as it is already included by `%s`", key, computed.Relation),
+								def.Name, relation.Name, child,
+							))
+							break
+						}
+					}
+				}
+			})
+		}
+	}
+	return warnings
+}
+
+// walkUnions invokes the handler for each union found in the rewrite, including nested rewrites.
+func walkUnions(rewrite *core.UsersetRewrite, handler func(union *core.SetOperation)) {
+	if rewrite == nil {
+		return
+	}
+
+	var operation *core.SetOperation
+	switch rw := rewrite.RewriteOperation.(type) {
+	case *core.UsersetRewrite_Union:
+		operation = rw.Union
+		handler(operation)
+	case *core.UsersetRewrite_Intersection:
+		operation = rw.Intersection
+	case *core.UsersetRewrite_Exclusion:
+		operation = rw.Exclusion
+	default:
+		return
+	}
+
+	for _, child := range operation.Child {
+		walkUnions(child.GetUsersetRewrite(), handler)
+	}
+}
+
+// unionBranches returns the keys of the branches included by the top-level union of the given
+// relation, following branches referencing other relations of the same definition.
+func unionBranches(schema *Schema, definitionName string, relationName string, encountered map[string]struct{}) map[string]struct{} {
+	branches := make(map[string]struct{})
+	if _, ok := encountered[relationName]; ok {
+		return branches
+	}
+	encountered[relationName] = struct{}{}
+
+	relation, ok := schema.Relation(definitionName, relationName)
+	if !ok {
+		return branches
+	}
+
+	for _, child := range relation.GetUsersetRewrite().GetUnion().GetChild() {
+		key := branchKey(child)
+		if key == "" || key == "nil" {
+			continue
+		}
+		branches[key] = struct{}{}
+
+		if computed := child.GetComputedUserset(); computed != nil {
+			for included := range unionBranches(schema, definitionName, computed.Relation, encountered) {
+				branches[included] = struct{}{}
+			}
+		}
+	}
+	return branches
+}
+
+// branchKey returns the key identifying a branch of a union, in its schema syntax, or empty if
+// the branch is a nested expression.
+func branchKey(child *core.SetOperation_Child) string {
+	switch child := child.ChildType.(type) {
+	case *core.SetOperation_Child_XThis:
+		return "_this"
+	case *core.SetOperation_Child_XNil:
+		return "nil"
+	case *core.SetOperation_Child_ComputedUserset:
+		return child.ComputedUserset.Relation
+	case *core.SetOperation_Child_TupleToUserset:
+		tupleset := child.TupleToUserset.GetTupleset().GetRelation()
+		computed := child.TupleToUserset.GetComputedUserset().GetRelation()
+		if child.TupleToUserset.Function == core.TupleToUserset_FUNCTION_ALL {
+			return fmt.Sprintf("%s.all(%s)", tupleset, computed)
+		}
+		return tupleset + "->" + computed
+	default:
+		return ""
+	}
+}
+
+// DefaultNamingPattern is the default naming convention: lowercase words separated by single
+// underscores.
+var DefaultNamingPattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// NamingConventions are the patterns the names of a schema must match. Names of definitions are
+// matched without their prefix. A nil pattern is not checked.
+type NamingConventions struct {
+	Definitions *regexp.Regexp
+	Caveats     *regexp.Regexp
+	Relations   *regexp.Regexp
+	Permissions *regexp.Regexp
+}
+
+// DefaultNamingConventions returns the conventions matching all names against
+// DefaultNamingPattern.
+func DefaultNamingConventions() NamingConventions {
+	return NamingConventions{
+		Definitions: DefaultNamingPattern,
+		Caveats:     DefaultNamingPattern,
+		Relations:   DefaultNamingPattern,
+		Permissions: DefaultNamingPattern,
+	}
+}
+
+type namingConvention struct {
+	conventions NamingConventions
+}
+
+// NamingConvention returns the rule reporting names which do not match the given conventions.
+func NamingConvention(conventions NamingConventions) Rule {
+	return namingConvention{conventions}
+}
+
+func (namingConvention) Name() string {
+	return NamingConventionRule
+}
+
+func (nc namingConvention) Check(schema *Schema) []Warning {
+	var warnings []Warning
+	check := func(pattern *regexp.Regexp, kind string, name string, definitionName string, relationName string, at nspkg.WithSourcePosition) {
+		if pattern == nil || pattern.MatchString(name) {
+			return
+		}
+		warnings = append(warnings, NewWarning(
+			fmt.Sprintf("%s `%s` does not follow the naming convention `%s`", kind, name, pattern),
+			definitionName, relationName, at,
+		))
+	}
+
+	for _, caveat := range schema.Caveats() {
+		check(nc.conventions.Caveats, "caveat", unprefixed(caveat.Name), caveat.Name, "", caveat)
+	}
+
+	for _, def := range schema.Definitions() {
+		check(nc.conventions.Definitions, "definition", unprefixed(def.Name), def.Name, "", def)
+		for _, relation := range def.Relation {
+			if IsPermission(relation) {
+				check(nc.conventions.Permissions, "permission", relation.Name, def.Name, relation.Name, relation)
+			} else {
+				check(nc.conventions.Relations, "relation", relation.Name, def.Name, relation.Name, relation)
+			}
+		}
+	}
+	return warnings
+}
+
+func unprefixed(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
@@ -51,11 +51,15 @@ Loop:
 			break Loop
 		}
 
-		// The top level of the DSL is a set of definitions and caveats:
+		// The top level of the DSL is a set of imports, definitions and caveats:
+		// import "path/to/file.zed"
 		// definition foobar { ... }
 		// caveat somecaveat (...) { ... }
 
 		switch {
+		case p.isContextualKeyword("import"):
+			rootNode.Connect(dslshape.NodePredicateChild, p.consumeImport())
+
 		case p.isKeyword("definition"):
 			rootNode.Connect(dslshape.NodePredicateChild, p.consumeDefinition())
 
@@ -71,6 +75,39 @@ Loop:
 	return rootNode
 }
 
+// consumeImport attempts to consume a single import statement.
+// ```import "path/to/file.zed"```
+func (p *sourceParser) consumeImport() AstNode {
+	importNode := p.startNode(dslshape.NodeTypeImport)
+	defer p.mustFinishNode()
+
+	// import ...
+	p.tryConsumeContextualKeyword("import")
+	pathToken, ok := p.consume(lexer.TokenTypeString)
+	if !ok {
+		return importNode
+	}
+
+	importPath, ok := unquoteImportPath(pathToken.Value)
+	if !ok {
+		p.emitErrorf("Expected a single-line quoted path for import, found: %s", pathToken.Value)
+		return importNode
+	}
+
+	importNode.MustDecorate(dslshape.NodeImportPredicatePath, importPath)
+	return importNode
+}
+
+// unquoteImportPath strips the quotes from the given single-line string literal,
+// returning false if the literal is not a valid, non-empty import path.
+func unquoteImportPath(value string) (string, bool) {
+	if strings.HasPrefix(value, `"""`) || strings.HasPrefix(value, `'''`) || len(value) < 3 {
+		return "", false
+	}
+
+	return value[1 : len(value)-1], true
+}
+
 // consumeCaveat attempts to consume a single caveat definition.
 // ```caveat somecaveat(param1 type, param2 type) { ... }```
 func (p *sourceParser) consumeCaveat() AstNode {
@@ -261,11 +298,12 @@ func (p *sourceParser) consumeDefinition() AstNode {
 
 		// relation ...
 		// permission ...
+		// materialized permission ...
 		switch {
 		case p.isKeyword("relation"):
 			defNode.Connect(dslshape.NodePredicateChild, p.consumeRelation())
 
-		case p.isKeyword("permission"):
+		case p.isKeyword("permission") || p.isMaterializedPermission():
 			defNode.Connect(dslshape.NodePredicateChild, p.consumePermission())
 		}
 
@@ -413,12 +451,24 @@ func (p *sourceParser) consumeTypePath() (string, bool) {
 	return strings.Join(segments, "/"), true
 }
 
+// isMaterializedPermission returns true if the current token starts a materialized permission,
+// as `materialized` is only a keyword when directly followed by `permission`.
+func (p *sourceParser) isMaterializedPermission() bool {
+	return p.isContextualKeyword("materialized") && p.isNextKeyword("permission")
+}
+
 // consumePermission consumes a permission.
 // ```permission foo = bar + baz```
 func (p *sourceParser) consumePermission() AstNode {
 	permNode := p.startNode(dslshape.NodeTypePermission)
 	defer p.mustFinishNode()
 
+	// materialized?
+	if p.isMaterializedPermission() {
+		p.consumeToken()
+		permNode.MustDecorate(dslshape.NodePermissionPredicateMaterialized, "true")
+	}
+
 	// permission ...
 	p.consumeKeyword("permission")
 	permissionName, ok := p.consumeIdentifier()
@@ -476,8 +526,13 @@ func (p *sourceParser) tryConsumeComputeExpression(subTryExprFn tryParserFn, bin
 
 // tryConsumeArrowExpression attempts to consume an arrow expression.
 // ```foo->bar->baz->meh```
+// ```foo.all(bar)```
 func (p *sourceParser) tryConsumeArrowExpression() (AstNode, bool) {
 	rightNodeBuilder := func(leftNode AstNode, operatorToken lexer.Lexeme) (AstNode, bool) {
+		if operatorToken.Kind == lexer.TokenTypePeriod {
+			return p.tryConsumeArrowFunction(leftNode)
+		}
+
 		rightNode, ok := p.tryConsumeBaseExpression()
 		if !ok {
 			return nil, false
@@ -489,7 +544,49 @@ func (p *sourceParser) tryConsumeArrowExpression() (AstNode, bool) {
 		exprNode.Connect(dslshape.NodeExpressionPredicateRightExpr, rightNode)
 		return exprNode, true
 	}
-	return p.performLeftRecursiveParsing(p.tryConsumeIdentifierLiteral, rightNodeBuilder, nil, lexer.TokenTypeRightArrow)
+	return p.performLeftRecursiveParsing(p.tryConsumeIdentifierLiteral, rightNodeBuilder, nil, lexer.TokenTypeRightArrow, lexer.TokenTypePeriod)
+}
+
+// arrowFunctions are the functions which can be applied to an arrow.
+var arrowFunctions = map[string]struct{}{
+	"any": {},
+	"all": {},
+}
+
+// tryConsumeArrowFunction attempts to consume the function portion of an arrow in function
+// form, with the period already consumed.
+// ```all(bar)```
+func (p *sourceParser) tryConsumeArrowFunction(leftNode AstNode) (AstNode, bool) {
+	functionName, ok := p.consumeIdentifier()
+	if !ok {
+		return nil, false
+	}
+
+	if _, ok := arrowFunctions[functionName]; !ok {
+		p.emitErrorf("Expected `any` or `all` for arrow function, found: %s", functionName)
+		return nil, false
+	}
+
+	// (
+	if _, ok := p.consume(lexer.TokenTypeLeftParen); !ok {
+		return nil, false
+	}
+
+	rightNode, ok := p.tryConsumeIdentifierLiteral()
+	if !ok {
+		return nil, false
+	}
+
+	// )
+	if _, ok := p.consume(lexer.TokenTypeRightParen); !ok {
+		return nil, false
+	}
+
+	exprNode := p.createNode(dslshape.NodeTypeFunctionedArrowExpression)
+	exprNode.MustDecorate(dslshape.NodeFunctionedArrowExpressionPredicateFunctionName, functionName)
+	exprNode.Connect(dslshape.NodeExpressionPredicateLeftExpr, leftNode)
+	exprNode.Connect(dslshape.NodeExpressionPredicateRightExpr, rightNode)
+	return exprNode, true
 }
 
 // tryConsumeBaseExpression attempts to consume base compute expressions (identifiers, parenthesis).
@@ -168,6 +168,20 @@ func (p *sourceParser) isKeyword(keyword string) bool {
 	return p.isToken(lexer.TokenTypeKeyword) && p.currentToken.Value == keyword
 }
 
+// isContextualKeyword returns true if the current token is an identifier matching the contextual
+// keyword given. Contextual keywords are only recognized where the grammar expects them, and
+// remain valid identifiers everywhere else.
+func (p *sourceParser) isContextualKeyword(keyword string) bool {
+	return p.isToken(lexer.TokenTypeIdentifier) && p.currentToken.Value == keyword
+}
+
+// isNextKeyword returns true if the token following the current one is a keyword matching that
+// given.
+func (p *sourceParser) isNextKeyword(keyword string) bool {
+	next := p.lex.PeekToken(ignoredTokenTypes)
+	return next.Kind == lexer.TokenTypeKeyword && next.Value == keyword
+}
+
 // emitErrorf creates a new error node and attachs it as a child of the current
 // node.
 func (p *sourceParser) emitErrorf(format string, args ...interface{}) {
@@ -197,6 +211,16 @@ func (p *sourceParser) tryConsumeKeyword(keyword string) bool {
 	return true
 }
 
+// tryConsumeContextualKeyword attempts to consume an expected contextual keyword.
+func (p *sourceParser) tryConsumeContextualKeyword(keyword string) bool {
+	if !p.isContextualKeyword(keyword) {
+		return false
+	}
+
+	p.consumeToken()
+	return true
+}
+
 // cosumeIdentifier consumes an expected identifier token or adds an error node.
 func (p *sourceParser) consumeIdentifier() (string, bool) {
 	token, ok := p.tryConsume(lexer.TokenTypeIdentifier)
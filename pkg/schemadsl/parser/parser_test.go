@@ -117,6 +117,13 @@ func TestParser(t *testing.T) {
 		{"associativity test", "associativity"},
 		{"super large test", "superlarge"},
 		{"invalid permission name test", "invalid_perm_name"},
+		{"import test", "import"},
+		{"broken import test", "brokenimport"},
+		{"arrow functions test", "arrowfunctions"},
+		{"broken arrow function test", "brokenarrowfunction"},
+		{"materialized permission test", "materialized"},
+		{"broken materialized test", "brokenmaterialized"},
+		{"contextual keywords test", "contextualkeywords"},
 	}
 
 	for _, test := range parserTests {
@@ -7,10 +7,12 @@ import (
 	"reflect"
 	"regexp"
 	"slices"
+	"time"
 
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 	"github.com/jzelinskie/stringz"
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	core "github.com/authzed/spicedb/pkg/proto/core/v1"
 )
@@ -22,6 +24,9 @@ const (
 	// PublicWildcard is the wildcard value for subject object IDs that indicates public access
 	// for the subject type.
 	PublicWildcard = "*"
+
+	// ExpirationFormat is the format used for the expiration time in the string form of a tuple.
+	ExpirationFormat = time.RFC3339Nano
 )
 
 const (
@@ -48,6 +53,8 @@ var subjectExpr = fmt.Sprintf(
 
 var caveatExpr = fmt.Sprintf(`\[(?P<caveatName>(%s))(:(?P<caveatContext>(\{(.+)\})))?\]`, caveatNameExpr)
 
+var expirationExpr = `\[expiration:(?P<expirationTime>([0-9TZ:.+\-]+))\]`
+
 var (
 	onrRegex        = regexp.MustCompile(fmt.Sprintf("^%s$", onrExpr))
 	subjectRegex    = regexp.MustCompile(fmt.Sprintf("^%s$", subjectExpr))
@@ -57,10 +64,11 @@ var (
 
 var parserRegex = regexp.MustCompile(
 	fmt.Sprintf(
-		`^%s@%s(%s)?$`,
+		`^%s@%s(%s)?(%s)?$`,
 		onrExpr,
 		subjectExpr,
 		caveatExpr,
+		expirationExpr,
 	),
 )
 
@@ -108,7 +116,7 @@ func String(tpl *core.RelationTuple) (string, error) {
 		return "", err
 	}
 
-	return fmt.Sprintf("%s@%s%s", StringONR(tpl.ResourceAndRelation), StringONR(tpl.Subject), caveatString), nil
+	return fmt.Sprintf("%s@%s%s%s", StringONR(tpl.ResourceAndRelation), StringONR(tpl.Subject), caveatString, StringExpiration(tpl.OptionalExpirationTime)), nil
 }
 
 // StringWithoutCaveat converts a tuple to a string, without its caveat included.
@@ -146,6 +154,15 @@ func StringCaveat(caveat *core.ContextualizedCaveat) (string, error) {
 	return fmt.Sprintf("[%s%s]", caveat.CaveatName, contextString), nil
 }
 
+// StringExpiration converts an expiration time to a string. If the expiration is nil, returns empty string.
+func StringExpiration(expiration *timestamppb.Timestamp) string {
+	if expiration == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("[expiration:%s]", expiration.AsTime().UTC().Format(ExpirationFormat))
+}
+
 // StringCaveatContext converts the context of a caveat to a string. If the context is nil or empty, returns an empty string.
 func StringCaveatContext(context *structpb.Struct) (string, error) {
 	if context == nil || len(context.Fields) == 0 {
@@ -217,6 +234,17 @@ func Parse(tpl string) *core.RelationTuple {
 		}
 	}
 
+	var optionalExpiration *timestamppb.Timestamp
+	expirationString := groups[slices.Index(parserRegex.SubexpNames(), "expirationTime")]
+	if expirationString != "" {
+		expiration, err := time.Parse(ExpirationFormat, expirationString)
+		if err != nil {
+			return nil
+		}
+
+		optionalExpiration = timestamppb.New(expiration)
+	}
+
 	resourceID := groups[slices.Index(parserRegex.SubexpNames(), "resourceID")]
 	if err := ValidateResourceID(resourceID); err != nil {
 		return nil
@@ -238,7 +266,8 @@ func Parse(tpl string) *core.RelationTuple {
 			ObjectId:  subjectID,
 			Relation:  subjectRelation,
 		},
-		Caveat: optionalCaveat,
+		Caveat:                 optionalCaveat,
+		OptionalExpirationTime: optionalExpiration,
 	}
 }
 
@@ -273,7 +302,25 @@ func Delete(tpl *core.RelationTuple) *core.RelationTupleUpdate {
 
 func Equal(lhs, rhs *core.RelationTuple) bool {
 	// TODO(jschorr): Use a faster method then string comparison for caveats.
-	return OnrEqual(lhs.ResourceAndRelation, rhs.ResourceAndRelation) && OnrEqual(lhs.Subject, rhs.Subject) && MustStringCaveat(lhs.Caveat) == MustStringCaveat(rhs.Caveat)
+	return OnrEqual(lhs.ResourceAndRelation, rhs.ResourceAndRelation) && OnrEqual(lhs.Subject, rhs.Subject) && MustStringCaveat(lhs.Caveat) == MustStringCaveat(rhs.Caveat) && ExpirationEqual(lhs.OptionalExpirationTime, rhs.OptionalExpirationTime)
+}
+
+// ExpirationEqual returns true if the two optional expiration times are equal.
+func ExpirationEqual(lhs, rhs *timestamppb.Timestamp) bool {
+	if lhs == nil || rhs == nil {
+		return lhs == nil && rhs == nil
+	}
+
+	return lhs.AsTime().Equal(rhs.AsTime())
+}
+
+// IsExpired returns true if the tuple has an expiration time at or before the given time.
+func IsExpired(tpl *core.RelationTuple, now time.Time) bool {
+	if tpl.OptionalExpirationTime == nil {
+		return false
+	}
+
+	return !tpl.OptionalExpirationTime.AsTime().After(now)
 }
 
 // MustToRelationship converts a RelationTuple into a Relationship. Will panic if
@@ -286,7 +333,8 @@ func MustToRelationship(tpl *core.RelationTuple) *v1.Relationship {
 	return ToRelationship(tpl)
 }
 
-// ToRelationship converts a RelationTuple into a Relationship.
+// ToRelationship converts a RelationTuple into a Relationship. The expiration time of the
+// tuple, if any, is dropped, as the v1 API has no field for it.
 func ToRelationship(tpl *core.RelationTuple) *v1.Relationship {
 	var caveat *v1.ContextualizedCaveat
 	if tpl.Caveat != nil {
@@ -450,7 +498,8 @@ func MustFromRelationships[R objectReference, S subjectReference[R], C caveat](r
 	return tuples
 }
 
-// FromRelationship converts a Relationship into a RelationTuple.
+// FromRelationship converts a Relationship into a RelationTuple, which never expires as the v1
+// API has no field for an expiration time.
 func FromRelationship[T objectReference, S subjectReference[T], C caveat](r relationship[T, S, C]) *core.RelationTuple {
 	rel := &core.RelationTuple{
 		ResourceAndRelation: &core.ObjectAndRelation{},
@@ -559,3 +608,10 @@ func WithCaveat(tpl *core.RelationTuple, caveatName string, contexts ...map[stri
 	}
 	return tpl, nil
 }
+
+// WithExpiration returns a copy of the tuple with the given expiration time set.
+func WithExpiration(tpl *core.RelationTuple, expiration time.Time) *core.RelationTuple {
+	tpl = tpl.CloneVT()
+	tpl.OptionalExpirationTime = timestamppb.New(expiration)
+	return tpl
+}
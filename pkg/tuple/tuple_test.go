@@ -3,6 +3,7 @@ package tuple
 import (
 	"strings"
 	"testing"
+	"time"
 
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 	"github.com/stretchr/testify/require"
@@ -734,3 +735,84 @@ func TestEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestExpiration(t *testing.T) {
+	base := makeTuple(
+		ObjectAndRelation("document", "foo", "viewer"),
+		ObjectAndRelation("user", "tom", "..."),
+	)
+	expiration := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		input          string
+		expectedOutput string
+		tupleFormat    *core.RelationTuple
+	}{
+		{
+			input:          "document:foo#viewer@user:tom[expiration:2024-01-02T03:04:05Z]",
+			expectedOutput: "document:foo#viewer@user:tom[expiration:2024-01-02T03:04:05Z]",
+			tupleFormat:    WithExpiration(base, expiration),
+		},
+		{
+			input:          "document:foo#viewer@user:tom[expiration:2024-01-02T04:04:05+01:00]",
+			expectedOutput: "document:foo#viewer@user:tom[expiration:2024-01-02T03:04:05Z]",
+			tupleFormat:    WithExpiration(base, expiration),
+		},
+		{
+			input:          `document:foo#viewer@user:tom[somecaveat:{"hi":"there"}][expiration:2024-01-02T03:04:05Z]`,
+			expectedOutput: `document:foo#viewer@user:tom[somecaveat:{"hi":"there"}][expiration:2024-01-02T03:04:05Z]`,
+			tupleFormat:    WithExpiration(MustWithCaveat(base, "somecaveat", map[string]any{"hi": "there"}), expiration),
+		},
+		{
+			input:          "document:foo#viewer@user:tom[expiration]",
+			expectedOutput: "document:foo#viewer@user:tom[expiration]",
+			tupleFormat:    MustWithCaveat(base, "expiration"),
+		},
+		{
+			input:          "document:foo#viewer@user:tom[expiration:notatime]",
+			expectedOutput: "",
+			tupleFormat:    nil,
+		},
+		{
+			input:          "document:foo#viewer@user:tom[expiration:2024-01-02T03:04:05Z][somecaveat]",
+			expectedOutput: "",
+			tupleFormat:    nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.input, func(t *testing.T) {
+			parsed := Parse(tc.input)
+			testutil.RequireProtoEqual(t, tc.tupleFormat, parsed, "found difference in parsed tuple")
+			if parsed == nil {
+				return
+			}
+
+			require.Equal(t, tc.expectedOutput, MustString(parsed))
+			require.True(t, Equal(tc.tupleFormat, parsed))
+		})
+	}
+
+	t.Run("equality", func(t *testing.T) {
+		require := require.New(t)
+		require.False(Equal(base, WithExpiration(base, expiration)))
+		require.False(Equal(WithExpiration(base, expiration), WithExpiration(base, expiration.Add(time.Second))))
+		require.True(Equal(WithExpiration(base, expiration), WithExpiration(base, expiration.In(time.Local))))
+	})
+
+	t.Run("is expired", func(t *testing.T) {
+		require := require.New(t)
+		require.False(IsExpired(base, expiration))
+		require.True(IsExpired(WithExpiration(base, expiration), expiration))
+		require.True(IsExpired(WithExpiration(base, expiration), expiration.Add(time.Second)))
+		require.False(IsExpired(WithExpiration(base, expiration), expiration.Add(-time.Second)))
+	})
+
+	t.Run("v1 conversion", func(t *testing.T) {
+		// The v1 API has no field for the expiration time.
+		require := require.New(t)
+		expiring := WithExpiration(base, expiration)
+		require.True(Equal(base, FromRelationship[*v1.ObjectReference, *v1.SubjectReference, *v1.ContextualizedCaveat](ToRelationship(expiring))))
+	})
+}
@@ -472,6 +472,28 @@ func TestReachabilityGraph(t *testing.T) {
 				rrt("organization", "viewer", true),
 			},
 		},
+		{
+			"permission with all arrow",
+			`definition user {}
+
+			definition organization {
+				relation admin: user
+			}
+
+			definition document {
+				relation org: organization
+				relation viewer: user
+				permission view = viewer + org.all(admin)
+			}`,
+			rr("document", "view"),
+			rr("organization", "admin"),
+			[]rrtStruct{
+				rrt("document", "view", false),
+			},
+			[]rrtStruct{
+				rrt("document", "view", false),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -103,6 +103,13 @@ func computeRewriteOpReachability(ctx context.Context, children []*core.SetOpera
 				return err
 			}
 
+			// An arrow over `all` only produces a result if the subject is found for every
+			// resource on the tupleset, so reaching a single one is conditional.
+			ttuResultState := operationResultState
+			if child.TupleToUserset.Function == core.TupleToUserset_FUNCTION_ALL {
+				ttuResultState = core.ReachabilityEntrypoint_REACHABLE_CONDITIONAL_RESULT
+			}
+
 			computedUsersetRelation := child.TupleToUserset.ComputedUserset.Relation
 			for _, allowedRelationType := range directRelationTypes {
 				// For each namespace allowed to be found on the right hand side of the
@@ -144,7 +151,7 @@ func computeRewriteOpReachability(ctx context.Context, children []*core.SetOpera
 					err := addSubjectEntrypoint(graph, allowedRelationType.Namespace, computedUsersetRelation, &core.ReachabilityEntrypoint{
 						Kind:             core.ReachabilityEntrypoint_TUPLESET_TO_USERSET_ENTRYPOINT,
 						TargetRelation:   rr,
-						ResultStatus:     operationResultState,
+						ResultStatus:     ttuResultState,
 						TuplesetRelation: tuplesetRelation,
 					})
 					if err != nil {
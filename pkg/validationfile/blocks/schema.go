@@ -3,6 +3,7 @@ package blocks
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 
 	yamlv3 "gopkg.in/yaml.v3"
 
@@ -11,6 +12,9 @@ import (
 	"github.com/authzed/spicedb/pkg/spiceerrors"
 )
 
+// inlineSchemaSource is the source name given to schemas found inline in a validation file.
+const inlineSchemaSource = input.Source("schema")
+
 // ParsedSchema is the parsed schema in a validationfile.
 type ParsedSchema struct {
 	// Schema is the schema found.
@@ -21,6 +25,10 @@ type ParsedSchema struct {
 
 	// CompiledSchema is the compiled schema.
 	CompiledSchema *compiler.CompiledSchema
+
+	// SourceFS is the file system against which `import` statements in the schema are
+	// resolved, relative to its root. If nil, the schema cannot contain imports.
+	SourceFS fs.FS `yaml:"-"`
 }
 
 // UnmarshalYAML is a custom unmarshaller.
@@ -30,30 +38,70 @@ func (ps *ParsedSchema) UnmarshalYAML(node *yamlv3.Node) error {
 		return convertYamlError(err)
 	}
 
+	compiled, err := compileSchema(inlineSchemaSource, ps.Schema, ps.SourceFS)
+	if err != nil {
+		return err
+	}
+
+	ps.CompiledSchema = compiled
+	ps.SourcePosition = spiceerrors.SourcePosition{LineNumber: node.Line, ColumnPosition: node.Column}
+	return nil
+}
+
+// ParseSchemaFile reads and compiles the schema file found at the given path in the file
+// system. Any `import` statements in the schema file are resolved relative to its directory.
+func ParseSchemaFile(sourceFS fs.FS, schemaPath string) (*ParsedSchema, error) {
+	if sourceFS == nil {
+		return nil, fmt.Errorf("cannot read schema file `%s`: no file system was provided", schemaPath)
+	}
+
+	contents, err := fs.ReadFile(sourceFS, schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("error when reading schema file: %w", err)
+	}
+
+	compiled, err := compileSchema(input.Source(schemaPath), string(contents), sourceFS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ParsedSchema{
+		Schema:         string(contents),
+		CompiledSchema: compiled,
+		SourceFS:       sourceFS,
+	}, nil
+}
+
+func compileSchema(source input.Source, schema string, sourceFS fs.FS) (*compiler.CompiledSchema, error) {
 	compiled, err := compiler.Compile(compiler.InputSchema{
-		Source:       input.Source("schema"),
-		SchemaString: ps.Schema,
-	}, compiler.AllowUnprefixedObjectType())
+		Source:       source,
+		SchemaString: schema,
+	}, compiler.AllowUnprefixedObjectType(), compiler.SourceFS(sourceFS))
 	if err != nil {
 		var errWithContext compiler.ErrorWithContext
 		if errors.As(err, &errWithContext) {
 			line, col, lerr := errWithContext.SourceRange.Start().LineAndColumn()
 			if lerr != nil {
-				return lerr
+				return nil, lerr
+			}
+
+			// Errors found outside of the inline schema have positions relative to their own
+			// file, so the file is included in the message.
+			message := fmt.Sprintf("error when parsing schema: %s", errWithContext.BaseMessage)
+			if errWithContext.Source != inlineSchemaSource {
+				message = fmt.Sprintf("error when parsing schema file `%s`: %s", errWithContext.Source, errWithContext.BaseMessage)
 			}
 
-			return spiceerrors.NewErrorWithSource(
-				fmt.Errorf("error when parsing schema: %s", errWithContext.BaseMessage),
+			return nil, spiceerrors.NewErrorWithSource(
+				errors.New(message),
 				errWithContext.ErrorSourceCode,
 				uint64(line+1), // source line is 0-indexed
 				uint64(col+1),  // source col is 0-indexed
 			)
 		}
 
-		return fmt.Errorf("error when parsing schema: %w", err)
+		return nil, fmt.Errorf("error when parsing schema: %w", err)
 	}
 
-	ps.CompiledSchema = compiled
-	ps.SourcePosition = spiceerrors.SourcePosition{LineNumber: node.Line, ColumnPosition: node.Column}
-	return nil
+	return compiled, nil
 }
@@ -1,6 +1,9 @@
 package validationfile
 
 import (
+	"fmt"
+	"io/fs"
+
 	yamlv3 "gopkg.in/yaml.v3"
 
 	"github.com/authzed/spicedb/pkg/validationfile/blocks"
@@ -9,11 +12,32 @@ import (
 // DecodeValidationFile decodes the validation file as found in the contents bytes
 // and returns it.
 func DecodeValidationFile(contents []byte) (*ValidationFile, error) {
-	p := ValidationFile{}
+	return DecodeValidationFileWithFS(contents, nil)
+}
+
+// DecodeValidationFileWithFS decodes the validation file as found in the contents bytes
+// and returns it. Schema imports and the `schemaFile` reference, if any, are resolved
+// against the given file system, which is typically rooted at the validation file's directory.
+func DecodeValidationFileWithFS(contents []byte, sourceFS fs.FS) (*ValidationFile, error) {
+	p := ValidationFile{Schema: blocks.ParsedSchema{SourceFS: sourceFS}}
 	err := yamlv3.Unmarshal(contents, &p)
 	if err != nil {
 		return nil, err
 	}
+
+	if p.SchemaFile != "" {
+		if p.Schema.Schema != "" {
+			return nil, fmt.Errorf("only one of `schema` and `schemaFile` can be specified")
+		}
+
+		parsed, err := blocks.ParseSchemaFile(sourceFS, p.SchemaFile)
+		if err != nil {
+			return nil, err
+		}
+
+		p.Schema = *parsed
+	}
+
 	return &p, nil
 }
 
@@ -22,6 +46,11 @@ type ValidationFile struct {
 	// Schema is the schema.
 	Schema blocks.ParsedSchema `yaml:"schema"`
 
+	// SchemaFile is the path to a schema file to use in place of an inline `schema`,
+	// relative to the validation file. Imports within it are resolved relative to
+	// the schema file itself.
+	SchemaFile string `yaml:"schemaFile"`
+
 	// Relationships are the relationships specified in the validation file.
 	Relationships blocks.ParsedRelationships `yaml:"relationships"`
 
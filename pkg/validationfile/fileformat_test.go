@@ -2,6 +2,7 @@ package validationfile
 
 import (
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/require"
 
@@ -205,3 +206,36 @@ assertions:
 	require.Equal(t, err.Error(), "unexpected value `asdk`")
 	require.Equal(t, uint64(9), errWithSource.LineNumber)
 }
+
+func TestDecodeSchemaImportErrorSource(t *testing.T) {
+	sourceFS := fstest.MapFS{
+		"user.zed": {Data: []byte("definition user {}\n\ndefinition user {}")},
+	}
+
+	_, err := DecodeValidationFileWithFS([]byte(`schema: >-
+  import "user.zed"
+`), sourceFS)
+
+	errWithSource, ok := spiceerrors.AsErrorWithSource(err)
+	require.True(t, ok)
+
+	require.Equal(t, err.Error(), "error when parsing schema file `user.zed`: found name reused between multiple definitions and/or caveats: user")
+	require.Equal(t, uint64(3), errWithSource.LineNumber)
+}
+
+func TestDecodeSchemaAndSchemaFile(t *testing.T) {
+	sourceFS := fstest.MapFS{
+		"schema.zed": {Data: []byte("definition user {}")},
+	}
+
+	decoded, err := DecodeValidationFileWithFS([]byte(`schemaFile: schema.zed`), sourceFS)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(decoded.Schema.CompiledSchema.OrderedDefinitions))
+
+	_, err = DecodeValidationFileWithFS([]byte(`schema: >-
+  definition user {}
+schemaFile: schema.zed
+`), sourceFS)
+	require.Error(t, err)
+	require.Equal(t, err.Error(), "only one of `schema` and `schemaFile` can be specified")
+}
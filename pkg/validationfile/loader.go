@@ -3,7 +3,9 @@ package validationfile
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
 
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 
@@ -40,9 +42,11 @@ type PopulatedValidationFile struct {
 }
 
 // PopulateFromFiles populates the given datastore with the namespaces and tuples found in
-// the validation file(s) specified.
+// the validation file(s) specified. Schema imports are resolved relative to the directory
+// of each validation file.
 func PopulateFromFiles(ctx context.Context, ds datastore.Datastore, filePaths []string) (*PopulatedValidationFile, datastore.Revision, error) {
 	contents := map[string][]byte{}
+	sourceFSs := map[string]fs.FS{}
 
 	for _, filePath := range filePaths {
 		fileContents, err := os.ReadFile(filePath)
@@ -51,14 +55,20 @@ func PopulateFromFiles(ctx context.Context, ds datastore.Datastore, filePaths []
 		}
 
 		contents[filePath] = fileContents
+		sourceFSs[filePath] = os.DirFS(filepath.Dir(filePath))
 	}
 
-	return PopulateFromFilesContents(ctx, ds, contents)
+	return populateFromFilesContents(ctx, ds, contents, sourceFSs)
 }
 
 // PopulateFromFilesContents populates the given datastore with the namespaces and tuples found in
-// the validation file(s) contents specified.
+// the validation file(s) contents specified. As the contents have no location on disk, the
+// schemas found within cannot contain imports.
 func PopulateFromFilesContents(ctx context.Context, ds datastore.Datastore, filesContents map[string][]byte) (*PopulatedValidationFile, datastore.Revision, error) {
+	return populateFromFilesContents(ctx, ds, filesContents, nil)
+}
+
+func populateFromFilesContents(ctx context.Context, ds datastore.Datastore, filesContents map[string][]byte, sourceFSs map[string]fs.FS) (*PopulatedValidationFile, datastore.Revision, error) {
 	var schema string
 	var objectDefs []*core.NamespaceDefinition
 	var caveatDefs []*core.CaveatDefinition
@@ -72,7 +82,7 @@ func PopulateFromFilesContents(ctx context.Context, ds datastore.Datastore, file
 	// Parse each file into definitions and relationship updates.
 	for filePath, fileContents := range filesContents {
 		// Decode the validation file.
-		parsed, err := DecodeValidationFile(fileContents)
+		parsed, err := DecodeValidationFileWithFS(fileContents, sourceFSs[filePath])
 		if err != nil {
 			return nil, datastore.NoRevision, fmt.Errorf("error when parsing config file %s: %w", filePath, err)
 		}
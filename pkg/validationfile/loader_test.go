@@ -106,6 +106,30 @@ func TestPopulateFromFiles(t *testing.T) {
 			want:          nil,
 			expectedError: "error parsing relationship",
 		},
+		{
+			name:      "schema file",
+			filePaths: []string{"testdata/schema_file.yaml"},
+			want: []string{
+				"example/project:pied_piper#reader@example/user:tarben",
+				"example/project:pied_piper#writer@example/user:freyja",
+			},
+			expectedError: "",
+		},
+		{
+			name:      "schema imports",
+			filePaths: []string{"testdata/schema_imports.yaml"},
+			want: []string{
+				"example/team:engineering#member@example/user:tarben",
+				"example/project:pied_piper#reader@example/user:tarben",
+			},
+			expectedError: "",
+		},
+		{
+			name:          "missing import",
+			filePaths:     []string{"testdata/missing_import.yaml"},
+			want:          nil,
+			expectedError: "could not read imported file `schemas/teams/missing.zed`",
+		},
 		{
 			name:          "repeated relationship",
 			filePaths:     []string{"testdata/repeated_relationship.yaml"},